@@ -2,6 +2,7 @@ package cmd
 
 import (
 	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
 )
 
 // aiCmd represents the ai parent command for AI-powered features (Feature 003)
@@ -33,4 +34,7 @@ AI workflows focused on policy context and autonomous collection.`,
 
 func init() {
 	rootCmd.AddCommand(aiCmd)
+
+	aiCmd.PersistentFlags().Bool("allow-unknown-model", false, "Use a model that is not in the provider's known model list (e.g. a new release)")
+	viper.BindPFlag("ai.allow_unknown_model", aiCmd.PersistentFlags().Lookup("allow-unknown-model"))
 }
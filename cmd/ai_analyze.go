@@ -1,17 +1,26 @@
 package cmd
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
 	"log/slog"
 	"os"
 	"path/filepath"
+	"sort"
+	"strconv"
 	"strings"
+	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/pickjonathan/sdek-cli/internal/ai"
 	"github.com/pickjonathan/sdek-cli/internal/ai/factory"
 	"github.com/pickjonathan/sdek-cli/internal/analyze"
+	"github.com/pickjonathan/sdek-cli/internal/config"
+	"github.com/pickjonathan/sdek-cli/internal/i18n"
+	"github.com/pickjonathan/sdek-cli/internal/report"
 	"github.com/pickjonathan/sdek-cli/pkg/types"
 	"github.com/pickjonathan/sdek-cli/ui/components"
 	"github.com/spf13/cobra"
@@ -55,6 +64,12 @@ context-grounded analysis for specific policy sections.`,
       --evidence-path ./evidence/*.json \
       --no-cache
 
+  # Check whether a fresh analysis still matches the cached finding
+  sdek ai analyze --framework SOC2 --section CC6.1 \
+      --excerpts-file ./policies/soc2_excerpts.json \
+      --evidence-path ./evidence/*.json \
+      --compare-cache
+
   # Multiple evidence paths from different sources
   sdek ai analyze --framework PCI-DSS --section 8.2.4 \
       --excerpts-file ./policies/pci_excerpts.json \
@@ -68,7 +83,43 @@ context-grounded analysis for specific policy sections.`,
       --evidence-path ./evidence/*.json \
       --output ./findings/iso_a942_finding.json
 
+  # Reproducible analysis for audit evidence (temperature 0, seeded sampling)
+  sdek ai analyze --framework SOC2 --section CC6.1 \
+      --excerpts-file ./policies/soc2_excerpts.json \
+      --evidence-path ./evidence/*.json \
+      --deterministic --no-cache
+
+  # Show where time is spent (load, redact, prompt-build, provider, parse)
+  sdek ai analyze --framework SOC2 --section CC6.1 \
+      --excerpts-file ./policies/soc2_excerpts.json \
+      --evidence-path ./evidence/*.json \
+      --timing --yes
+
+  # Give up if the whole analysis takes longer than two minutes
+  sdek ai analyze --framework SOC2 --section CC6.1 \
+      --excerpts-file ./policies/soc2_excerpts.json \
+      --evidence-path ./evidence/*.json \
+      --timeout 2m --yes
+
+  # Pipe evidence (JSON array or NDJSON) in on stdin
+  collect-evidence | sdek ai analyze --framework SOC2 --section CC6.1 \
+      --excerpts-file ./policies/soc2_excerpts.json \
+      --evidence-path - --yes
+
+  # Headless: print the context preview and estimated cost as JSON, then stop
+  sdek ai analyze --framework SOC2 --section CC6.1 \
+      --excerpts-file ./policies/soc2_excerpts.json \
+      --evidence-path ./evidence/*.json \
+      --preview-format json --preview-only
+
+  # Try a stricter review threshold for this run only
+  sdek ai analyze --framework SOC2 --section CC6.1 \
+      --excerpts-file ./policies/soc2_excerpts.json \
+      --evidence-path ./evidence/*.json \
+      --confidence-threshold 0.85 --no-cache
+
 Note: Confidence thresholds are configured in config.yaml under ai.context_injection.confidence_threshold
+      or per framework under frameworks.rubrics; --confidence-threshold overrides them for one run
       PII/secrets are automatically redacted before sending to AI providers`,
 	PreRunE: func(cmd *cobra.Command, args []string) error {
 		// Validate required flags
@@ -89,14 +140,44 @@ Note: Confidence thresholds are configured in config.yaml under ai.context_injec
 		if len(evidencePaths) == 0 {
 			return fmt.Errorf("--evidence-path is required (at least one path)")
 		}
+		if timeout, _ := cmd.Flags().GetDuration("timeout"); timeout < 0 {
+			return fmt.Errorf("--timeout must not be negative")
+		}
+		compare, _ := cmd.Flags().GetBool("compare-cache")
+		if updateCache, _ := cmd.Flags().GetBool("update-cache"); updateCache && !compare {
+			return fmt.Errorf("--update-cache requires --compare-cache")
+		}
+		if noCache, _ := cmd.Flags().GetBool("no-cache"); noCache && compare {
+			return fmt.Errorf("--compare-cache cannot be used with --no-cache")
+		}
+		previewFormat, _ := cmd.Flags().GetString("preview-format")
+		if previewFormat != previewFormatTUI && previewFormat != previewFormatJSON {
+			return fmt.Errorf("invalid preview format '%s', must be one of: tui, json", previewFormat)
+		}
+		if previewOnly, _ := cmd.Flags().GetBool("preview-only"); previewOnly && previewFormat != previewFormatJSON {
+			return fmt.Errorf("--preview-only requires --preview-format json")
+		}
+		if err := applyConfidenceThreshold(cmd, &types.ContextPreamble{}); err != nil {
+			return err
+		}
 
-		// Check excerpts file exists
-		if _, err := os.Stat(excerptsFile); os.IsNotExist(err) {
+		// Check excerpts file exists (URLs are fetched when loading)
+		if _, err := os.Stat(excerptsFile); os.IsNotExist(err) && !isExcerptsURL(excerptsFile) {
 			return fmt.Errorf("excerpts file not found: %s", excerptsFile)
 		}
 
 		// Validate evidence paths exist
+		stdinPaths := 0
 		for _, path := range evidencePaths {
+			// "-" reads evidence from stdin rather than a file
+			if path == stdinEvidencePath {
+				stdinPaths++
+				if stdinPaths > 1 {
+					return fmt.Errorf("--evidence-path - can only be given once")
+				}
+				continue
+			}
+
 			// Support glob patterns
 			matches, err := filepath.Glob(path)
 			if err != nil {
@@ -122,6 +203,29 @@ Note: Confidence thresholds are configured in config.yaml under ai.context_injec
 			"excerpts_file", excerptsFile,
 			"evidence_paths", len(evidencePaths))
 
+		// --timeout caps the whole command: loading, redaction and the provider call
+		timeout, _ := cmd.Flags().GetDuration("timeout")
+		ctx, cancel := withAnalysisTimeout(cmd.Context(), timeout)
+		defer cancel()
+
+		// Phase timings are only collected when --timing is set
+		var timings *ai.PhaseTimings
+		if showTiming, _ := cmd.Flags().GetBool("timing"); showTiming {
+			timings = ai.NewPhaseTimings()
+		}
+		loadStart := time.Now()
+
+		// Step 1: Load configuration
+		cfg, err := loadConfig()
+		if err != nil {
+			return fmt.Errorf("failed to load config: %w", err)
+		}
+
+		// Reproducible analysis: temperature 0 and a fixed seed
+		if deterministic, _ := cmd.Flags().GetBool("deterministic"); deterministic {
+			cfg.AI.Deterministic = true
+		}
+
 		// Step 2: Load policy excerpts
 		slog.Info("Loading policy excerpts", "file", excerptsFile)
 		excerpts, err := loadExcerpts(excerptsFile)
@@ -132,68 +236,96 @@ Note: Confidence thresholds are configured in config.yaml under ai.context_injec
 		// Find the excerpt for this framework/section
 		excerpt, found := findExcerpt(excerpts, framework, section)
 		if !found {
-			return fmt.Errorf("excerpt not found for %s %s in %s", framework, section, excerptsFile)
+			return fmt.Errorf("excerpt not found for %s %s in %s%s", framework, section, excerptsFile, excerptSuggestion(excerpts, framework, section))
 		}
 
 		// Step 3: Build ContextPreamble
 		slog.Info("Building context preamble", "framework", framework, "section", section)
-		preamble, err := types.NewContextPreamble(
+		preamble, err := types.NewContextPreambleWithRubrics(
 			framework,
-			excerpt.Version,
+			excerptVersion(excerpt, cfg.Frameworks),
 			section,
 			excerpt.Text,
 			excerpt.RelatedSections,
+			cfg.Frameworks.RubricsFor(framework),
 		)
 		if err != nil {
 			return fmt.Errorf("failed to create context preamble: %w", err)
 		}
+		if err := applyConfidenceThreshold(cmd, preamble); err != nil {
+			return err
+		}
 
 		// Step 4: Load evidence from paths
 		slog.Info("Loading evidence files", "paths", len(evidencePaths))
-		evidence, err := loadEvidenceFromPaths(evidencePaths)
+		evidenceLoadTime := time.Now()
+		evidence, err := loadEvidenceFromPaths(evidencePaths, cmd.InOrStdin(), cfg.AI)
 		if err != nil {
 			return fmt.Errorf("failed to load evidence: %w", err)
 		}
 
+		// Fill in missing event types and timestamps before anything is cached
+		dropUntimestamped, _ := cmd.Flags().GetBool("drop-untimestamped")
+		normalized, _ := ai.NormalizeEvidence(*evidence, ai.NormalizeOptions{
+			LoadTime:          evidenceLoadTime,
+			DropUntimestamped: dropUntimestamped,
+		})
+		evidence = &normalized
+
 		slog.Info("Evidence loaded", "event_count", len(evidence.Events))
+		warnLikelySecrets(cmd.ErrOrStderr(), *evidence)
 
 		if len(evidence.Events) == 0 {
 			return fmt.Errorf("no evidence events found in specified paths")
 		}
-
-		// Step 5: Show interactive context preview (Feature 003) unless --yes flag is set
-		skipPreview, _ := cmd.Flags().GetBool("yes")
-		if !skipPreview {
-			if err := showContextPreview(preamble, len(evidence.Events)); err != nil {
-				return fmt.Errorf("preview cancelled or failed: %w", err)
-			}
-		} else {
-			slog.Info("Skipping interactive preview (--yes flag set)")
+		timings.Track(ai.PhaseLoad, loadStart)
+		if err := ctx.Err(); err != nil {
+			return analysisTimeoutError(ctx, timeout, err)
 		}
 
-		// Step 6: Load configuration
-		cfg, err := loadConfig()
-		if err != nil {
-			return fmt.Errorf("failed to load config: %w", err)
+		// Step 5: Show the context preview (Feature 003): as JSON for headless
+		// runs, otherwise interactively unless disabled. Unless the run stops
+		// after it, the JSON preview goes to stderr so stdout holds only the analysis.
+		if previewFormat, _ := cmd.Flags().GetString("preview-format"); previewFormat == previewFormatJSON {
+			previewOnly, _ := cmd.Flags().GetBool("preview-only")
+			previewOut := cmd.ErrOrStderr()
+			if previewOnly {
+				previewOut = cmd.OutOrStdout()
+			}
+			if err := writeJSON(previewOut, newJSONContextPreview(cfg, preamble, *evidence)); err != nil {
+				return fmt.Errorf("failed to write preview: %w", err)
+			}
+			if previewOnly {
+				return nil
+			}
+		} else if err := confirmContextPreview(cmd, cfg, preamble, len(evidence.Events), evidencePaths); err != nil {
+			return err
 		}
 
-		// Step 7: Check if AI is enabled
+		// Step 6: Check if AI is enabled
 		if !cfg.AI.Enabled {
 			return fmt.Errorf("AI analysis is disabled in config. Set ai.enabled=true to use this command")
 		}
 
-		// Step 8: Initialize AI engine
+		// Step 7: Initialize AI engine
 		slog.Info("Initializing AI engine", "provider", cfg.AI.Provider)
 		engine, err := initializeAIEngine(cfg)
 		if err != nil {
 			return fmt.Errorf("failed to initialize AI engine: %w", err)
 		}
 
-		// Step 9: Perform AI analysis
+		// Step 8: Perform AI analysis
 		fmt.Println("\n🤖 Analyzing evidence with AI context injection...")
-		finding, err := engine.Analyze(cmd.Context(), *preamble, *evidence)
+		analyzeCtx := ai.WithPhaseTimings(ctx, timings)
+		var finding *types.Finding
+		if compare, _ := cmd.Flags().GetBool("compare-cache"); compare {
+			updateCache, _ := cmd.Flags().GetBool("update-cache")
+			finding, err = compareWithCache(analyzeCtx, engine, *preamble, *evidence, updateCache, cmd.OutOrStdout())
+		} else {
+			finding, err = engine.Analyze(analyzeCtx, *preamble, *evidence)
+		}
 		if err != nil {
-			return fmt.Errorf("AI analysis failed: %w", err)
+			return analysisTimeoutError(ctx, timeout, fmt.Errorf("AI analysis failed: %w", err))
 		}
 
 		slog.Info("AI analysis complete",
@@ -201,7 +333,7 @@ Note: Confidence thresholds are configured in config.yaml under ai.context_injec
 			"controls", len(finding.MappedControls),
 			"citations", len(finding.Citations))
 
-		// Step 10: Flag low confidence findings
+		// Step 9: Flag low confidence findings
 		confidenceThreshold := preamble.Rubrics.ConfidenceThreshold
 		analyze.FlagLowConfidence(finding, confidenceThreshold)
 
@@ -211,23 +343,159 @@ Note: Confidence thresholds are configured in config.yaml under ai.context_injec
 				"threshold", confidenceThreshold)
 		}
 
-		// Step 11: Export finding to output file
+		// Attach the cited events so reviewers don't have to cross-reference evidence files
+		if embed, _ := cmd.Flags().GetBool("embed-evidence"); embed {
+			if err := embedEvidence(cfg, finding, *evidence); err != nil {
+				return fmt.Errorf("failed to embed evidence: %w", err)
+			}
+		}
+		if detailed, _ := cmd.Flags().GetBool("detailed-citations"); detailed {
+			ai.DetailCitations(finding, *evidence)
+		}
+
+		// Step 10: Export finding to output file
 		outputFile, _ := cmd.Flags().GetString("output")
-		if err := exportFinding(finding, outputFile); err != nil {
+		if err := exportFinding(finding, outputFile, cfg.Export.Mode()); err != nil {
 			return fmt.Errorf("failed to export finding: %w", err)
 		}
 
-		// Step 12: Display summary
-		displayFindingSummary(finding, outputFile)
+		// Step 11: Display summary
+		displayFindingSummary(cmd.OutOrStdout(), finding, outputFile)
+		if timings != nil {
+			timings.Print(os.Stdout)
+		}
 
 		return nil
 	},
 }
 
+// applyConfidenceThreshold overrides the rubric confidence threshold of
+// preamble with --confidence-threshold, when the flag is set
+func applyConfidenceThreshold(cmd *cobra.Command, preamble *types.ContextPreamble) error {
+	if !cmd.Flags().Changed("confidence-threshold") {
+		return nil
+	}
+	threshold, _ := cmd.Flags().GetFloat64("confidence-threshold")
+	if threshold < 0 || threshold > 1 {
+		return fmt.Errorf("invalid confidence-threshold %.2f, must be between 0 and 1", threshold)
+	}
+	preamble.Rubrics.ConfidenceThreshold = threshold
+	return nil
+}
+
+// Context preview formats (--preview-format)
+const (
+	previewFormatTUI  = "tui"
+	previewFormatJSON = "json"
+)
+
+// jsonContextPreview is the context preview printed by --preview-format json:
+// the preamble's fields, the evidence count and the estimated prompt cost
+type jsonContextPreview struct {
+	types.ContextPreamble
+	EvidenceCount int                     `json:"evidence_count"`
+	Estimate      ai.AnalysisCostEstimate `json:"estimate"`
+}
+
+// newJSONContextPreview builds the JSON context preview, estimating the cost
+// with the configured provider's model
+func newJSONContextPreview(cfg *types.Config, preamble *types.ContextPreamble, evidence types.EvidenceBundle) jsonContextPreview {
+	return jsonContextPreview{
+		ContextPreamble: *preamble,
+		EvidenceCount:   len(evidence.Events),
+		Estimate:        ai.EstimateAnalysisCost(*preamble, evidence, cfg.AI.ModelFor(cfg.AI.Provider)),
+	}
+}
+
+// contextPreview runs the interactive preview; tests replace it
+var contextPreview = showContextPreview
+
+// stdoutIsTerminal reports whether stdout is a terminal; tests replace it
+var stdoutIsTerminal = func() bool {
+	return isTerminal(os.Stdout)
+}
+
+// isTerminal reports whether w is a terminal. Writers other than files
+// (buffers, pipes wrapped by tests) are not.
+func isTerminal(w io.Writer) bool {
+	f, ok := w.(*os.File)
+	if !ok {
+		return false
+	}
+	info, err := f.Stat()
+	return err == nil && info.Mode()&os.ModeCharDevice != 0
+}
+
+// plainOutput reports whether output to w should be plain ASCII, without
+// colors or emoji: with --no-color, when NO_COLOR is set (https://no-color.org),
+// or when w is not a terminal, so logs and CI output stay readable
+func plainOutput(w io.Writer) bool {
+	return noColor || os.Getenv("NO_COLOR") != "" || !isTerminal(w)
+}
+
+// outputGlyphs are the decorations of human-readable command output
+type outputGlyphs struct {
+	Done    string // Prefixes a completed operation
+	Warning string // Prefixes a warning
+	File    string // Prefixes a written file
+	Rule    string // Horizontal separator
+	Bar     string // One unit of a histogram bar
+}
+
+var (
+	fancyGlyphs = outputGlyphs{Done: "✅ ", Warning: "⚠️  ", File: "📄 ", Rule: strings.Repeat("━", 46), Bar: "█"}
+	plainGlyphs = outputGlyphs{Done: "", Warning: "WARNING: ", File: "", Rule: strings.Repeat("-", 46), Bar: "#"}
+)
+
+// glyphsFor returns the decorations to use for output to w
+func glyphsFor(w io.Writer) outputGlyphs {
+	if plainOutput(w) {
+		return plainGlyphs
+	}
+	return fancyGlyphs
+}
+
+// previewSkipReason returns why the context preview is skipped: --yes,
+// ui.interactive set to false, or stdout not being a terminal. It returns ""
+// when the preview should be shown.
+func previewSkipReason(cmd *cobra.Command, cfg *types.Config) string {
+	if yes, _ := cmd.Flags().GetBool("yes"); yes {
+		return "--yes flag set"
+	}
+	if !cfg.UI.Interactive {
+		return "ui.interactive is false"
+	}
+	if !stdoutIsTerminal() {
+		return "stdout is not a terminal"
+	}
+	return ""
+}
+
+// confirmContextPreview shows the context preview and fails if the user
+// doesn't confirm it, unless the preview is skipped (see previewSkipReason)
+func confirmContextPreview(cmd *cobra.Command, cfg *types.Config, preamble *types.ContextPreamble, evidenceCount int, evidencePaths []string) error {
+	if reason := previewSkipReason(cmd, cfg); reason != "" {
+		slog.Info("Skipping interactive preview", "reason", reason)
+		return nil
+	}
+
+	// Evidence piped on stdin leaves the preview to read keys from the terminal
+	var previewOpts []tea.ProgramOption
+	for _, path := range evidencePaths {
+		if path == stdinEvidencePath {
+			previewOpts = append(previewOpts, tea.WithInputTTY())
+		}
+	}
+	if err := contextPreview(preamble, evidenceCount, previewOpts...); err != nil {
+		return fmt.Errorf("preview cancelled or failed: %w", err)
+	}
+	return nil
+}
+
 // showContextPreview displays an interactive preview of the analysis context
-func showContextPreview(preamble *types.ContextPreamble, evidenceCount int) error {
+func showContextPreview(preamble *types.ContextPreamble, evidenceCount int, opts ...tea.ProgramOption) error {
 	model := components.NewContextPreview(*preamble, evidenceCount)
-	p := tea.NewProgram(model)
+	p := tea.NewProgram(model, opts...)
 
 	finalModel, err := p.Run()
 	if err != nil {
@@ -251,6 +519,25 @@ func showContextPreview(preamble *types.ContextPreamble, evidenceCount int) erro
 	return nil
 }
 
+// withAnalysisTimeout bounds ctx by timeout; a zero timeout leaves ctx without a deadline
+func withAnalysisTimeout(ctx context.Context, timeout time.Duration) (context.Context, context.CancelFunc) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	if timeout <= 0 {
+		return context.WithCancel(ctx)
+	}
+	return context.WithTimeout(ctx, timeout)
+}
+
+// analysisTimeoutError replaces err with a timeout error once ctx's deadline has passed
+func analysisTimeoutError(ctx context.Context, timeout time.Duration, err error) error {
+	if timeout > 0 && errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		return fmt.Errorf("analysis timed out after %s", timeout)
+	}
+	return err
+}
+
 // loadConfig loads the configuration from Viper (which is already initialized by root.go)
 func loadConfig() (*types.Config, error) {
 	cfg := &types.Config{}
@@ -260,9 +547,35 @@ func loadConfig() (*types.Config, error) {
 		return nil, fmt.Errorf("failed to unmarshal config: %w", err)
 	}
 
+	// Fail early on a broken prompt template rather than falling back mid-analysis
+	if cfg.AI.PromptTemplate != "" {
+		if _, err := ai.LoadPromptTemplate(cfg.AI.PromptTemplate); err != nil {
+			return nil, fmt.Errorf("invalid ai.prompt_template: %w", err)
+		}
+	}
+
+	// Rubrics from frameworks.rubrics_file fill in frameworks not configured inline
+	if err := config.ApplyRubricsFile(cfg); err != nil {
+		return nil, fmt.Errorf("invalid frameworks.rubrics_file: %w", err)
+	}
+
+	// A model the provider doesn't serve would otherwise fail opaquely at call time
+	if err := ai.ValidateModels(cfg); err != nil {
+		return nil, err
+	}
+
 	return cfg, nil
 }
 
+// exportFileMode returns the configured permission mode for exported files
+func exportFileMode() os.FileMode {
+	cfg, err := loadConfig()
+	if err != nil {
+		return types.DefaultExportFileMode
+	}
+	return cfg.Export.Mode()
+}
+
 // initializeAIEngine creates an AI engine based on the config
 func initializeAIEngine(cfg *types.Config) (ai.Engine, error) {
 	provider := cfg.AI.Provider
@@ -270,23 +583,28 @@ func initializeAIEngine(cfg *types.Config) (ai.Engine, error) {
 		provider = "openai" // Default
 	}
 
-	model := cfg.AI.Model
-	if model == "" {
-		if provider == "openai" {
-			model = "gpt-4"
-		} else if provider == "anthropic" {
-			model = "claude-3-opus-20240229"
-		}
-	}
+	model := cfg.AI.ModelFor(provider)
 
 	// Build provider configuration
 	providerConfig := types.ProviderConfig{
-		APIKey:      cfg.AI.APIKey,
-		Model:       model,
-		MaxTokens:   cfg.AI.MaxTokens,
-		Temperature: float64(cfg.AI.Temperature),
-		Timeout:     cfg.AI.Timeout,
-		MaxRetries:  3,
+		APIKey:         cfg.AI.APIKey,
+		Model:          model,
+		MaxTokens:      cfg.AI.MaxTokens,
+		Temperature:    float64(cfg.AI.Temperature),
+		Timeout:        cfg.AI.Timeout,
+		MaxRetries:     3,
+		RateLimit:      cfg.AI.RateLimit,
+		RateLimitBurst: cfg.AI.RateLimitBurst,
+		SystemPrompt:   cfg.AI.SystemPrompt,
+	}
+
+	// A key file or keyring entry keeps the key out of the config and environment
+	if providerConfig.APIKey == "" && cfg.AI.APIKeyFile != "" {
+		key, err := ai.ReadAPIKey(cfg.AI.APIKeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load ai.api_key_file: %w", err)
+		}
+		providerConfig.APIKey = key
 	}
 
 	// Override with environment variables if not set
@@ -314,6 +632,7 @@ func initializeAIEngine(cfg *types.Config) (ai.Engine, error) {
 	if providerConfig.Temperature == 0 {
 		providerConfig.Temperature = 0.3
 	}
+	applySamplingConfig(cfg, &providerConfig)
 
 	// Determine provider URL
 	providerURL := cfg.AI.ProviderURL
@@ -335,7 +654,13 @@ func initializeAIEngine(cfg *types.Config) (ai.Engine, error) {
 	// Validate API key (not required for local providers like Ollama)
 	requiresAPIKey := !strings.Contains(strings.ToLower(providerURL), "ollama://")
 	if requiresAPIKey && providerConfig.APIKey == "" {
-		return nil, fmt.Errorf("API key required for %s - set SDEK_%s_KEY or configure in config.yaml", provider, strings.ToUpper(provider))
+		return nil, errors.New(messages.T(i18n.ErrorAPIKeyRequired, provider, strings.ToUpper(provider)))
+	}
+
+	// Provider-specific settings keyed by URL scheme, e.g. providers.ollama.extra.num_ctx
+	scheme, _, _ := strings.Cut(providerURL, "://")
+	if settings, ok := cfg.Providers[scheme]; ok {
+		providerConfig.Extra = settings.Extra
 	}
 
 	// Create provider
@@ -345,18 +670,68 @@ func initializeAIEngine(cfg *types.Config) (ai.Engine, error) {
 	}
 
 	// Create engine
+	clock, err := outputClock()
+	if err != nil {
+		return nil, err
+	}
 	engine := ai.NewEngine(cfg, aiProvider)
+	ai.SetEngineClock(engine, clock)
+
+	// Prompts too long for the model are retried once with the fallback model
+	if fallbackModel := cfg.AI.ContextFallbackModel; fallbackModel != "" {
+		fallbackConfig := providerConfig
+		fallbackConfig.Model = fallbackModel
+		fallbackProvider, err := factory.CreateProvider(providerURL, fallbackConfig)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create context fallback provider for %s: %w", fallbackModel, err)
+		}
+		ai.SetContextFallback(engine, fallbackProvider, fallbackModel)
+	}
 	return engine, nil
 }
 
-// exportFinding saves the finding to a JSON file
-func exportFinding(finding *types.Finding, outputPath string) error {
+// sourceDateEpochEnv fixes the time stamped into findings, plans and reports,
+// following the reproducible-builds SOURCE_DATE_EPOCH convention
+const sourceDateEpochEnv = "SOURCE_DATE_EPOCH"
+
+// outputClock returns a clock fixed at $SOURCE_DATE_EPOCH (Unix seconds) when
+// it is set, so repeated runs (e.g. with --deterministic) produce identical
+// timestamps, and the system clock otherwise
+func outputClock() (types.Clock, error) {
+	value := os.Getenv(sourceDateEpochEnv)
+	if value == "" {
+		return types.SystemClock{}, nil
+	}
+	seconds, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s %q, must be Unix seconds: %w", sourceDateEpochEnv, value, err)
+	}
+	return types.FixedClock{Time: time.Unix(seconds, 0).UTC()}, nil
+}
+
+// applySamplingConfig sets the provider seed and, in deterministic mode, forces temperature 0.
+// Deterministic mode without a configured seed uses ai.DefaultDeterministicSeed; the resolved
+// seed is written back to cfg so the engine records it in findings and the audit log.
+func applySamplingConfig(cfg *types.Config, providerConfig *types.ProviderConfig) {
+	if cfg.AI.Deterministic {
+		providerConfig.Temperature = 0
+		if cfg.AI.Seed == nil {
+			seed := ai.DefaultDeterministicSeed
+			cfg.AI.Seed = &seed
+		}
+	}
+	providerConfig.Seed = cfg.AI.Seed
+}
+
+// exportFinding saves the finding to a JSON file, creating parent directories as needed
+func exportFinding(finding *types.Finding, outputPath string, mode os.FileMode) error {
+	finding.SchemaVersion = types.SchemaVersion
 	data, err := json.MarshalIndent(finding, "", "  ")
 	if err != nil {
 		return fmt.Errorf("failed to marshal finding: %w", err)
 	}
 
-	if err := os.WriteFile(outputPath, data, 0644); err != nil {
+	if err := report.WriteFile(outputPath, data, mode); err != nil {
 		return fmt.Errorf("failed to write file: %w", err)
 	}
 
@@ -364,41 +739,204 @@ func exportFinding(finding *types.Finding, outputPath string) error {
 }
 
 // displayFindingSummary shows a summary of the finding to the user
-func displayFindingSummary(finding *types.Finding, outputFile string) {
-	fmt.Println("\n✅ Analysis Complete!")
-	fmt.Println("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━")
-	fmt.Printf("Framework:       %s\n", finding.FrameworkID)
-	fmt.Printf("Control:         %s\n", finding.ControlID)
-	fmt.Printf("Confidence:      %.1f%%\n", finding.ConfidenceScore*100)
-	fmt.Printf("Residual Risk:   %s\n", finding.ResidualRisk)
+func displayFindingSummary(w io.Writer, finding *types.Finding, outputFile string) {
+	g := glyphsFor(w)
+	field := func(key i18n.Key, value any) {
+		fmt.Fprintf(w, "%-16s %v\n", messages.T(key)+":", value)
+	}
 
-	if finding.ReviewRequired {
-		fmt.Println("⚠️  Review Required: Low confidence score")
+	fmt.Fprintf(w, "\n%s%s\n", g.Done, messages.T(i18n.SummaryComplete))
+	fmt.Fprintln(w, g.Rule)
+	field(i18n.SummaryFramework, finding.FrameworkID)
+	field(i18n.SummaryControl, finding.ControlID)
+	field(i18n.SummaryConfidence, fmt.Sprintf("%.1f%%", finding.ConfidenceScore*100))
+	field(i18n.SummaryResidualRisk, finding.ResidualRisk)
+	if finding.Provider != "" {
+		field(i18n.SummaryProvider, finding.Provider+" "+finding.Model)
+	}
+	if finding.CacheHit {
+		field(i18n.SummarySource, messages.T(i18n.SummarySourceCache))
+	}
+	if finding.Seed != nil {
+		field(i18n.SummarySeed, *finding.Seed)
+	}
+	if len(finding.Provenance) > 0 {
+		field(i18n.SummarySources, formatSources(finding.Provenance))
+	}
+	if finding.Redactions != nil {
+		field(i18n.SummaryRedactions, formatRedactions(finding.Redactions))
 	}
 
-	fmt.Printf("\nMapped Controls: %d\n", len(finding.MappedControls))
+	if finding.ReviewReason != "" {
+		fmt.Fprintf(w, "%s%s\n", g.Warning, messages.T(i18n.SummaryReviewReason, finding.ReviewReason))
+	} else if finding.ReviewRequired {
+		fmt.Fprintf(w, "%s%s\n", g.Warning, messages.T(i18n.SummaryReviewRequired))
+	}
+	if finding.StaleEvidence {
+		fmt.Fprintf(w, "%s%s\n", g.Warning, messages.T(i18n.SummaryStaleEvidence))
+	}
+
+	fmt.Fprintln(w)
+	field(i18n.SummaryMappedControls, len(finding.MappedControls))
 	if len(finding.MappedControls) > 0 {
 		for _, ctrl := range finding.MappedControls {
-			fmt.Printf("  - %s\n", ctrl)
+			fmt.Fprintf(w, "  - %s\n", ctrl)
 		}
 	}
 
-	fmt.Printf("\nCitations:       %d\n", len(finding.Citations))
-	if len(finding.Citations) > 0 && len(finding.Citations) <= 5 {
-		for _, cite := range finding.Citations {
-			fmt.Printf("  - %s\n", cite)
+	citations := finding.Citations
+	if len(finding.CitationsDetailed) == len(finding.Citations) {
+		citations = finding.CitationsDetailed
+	}
+	fmt.Fprintln(w)
+	field(i18n.SummaryCitations, len(citations))
+	if len(citations) > 0 && len(citations) <= 5 {
+		for _, cite := range citations {
+			fmt.Fprintf(w, "  - %s\n", cite)
 		}
-	} else if len(finding.Citations) > 5 {
-		fmt.Printf("  (showing first 5 of %d)\n", len(finding.Citations))
+	} else if len(citations) > 5 {
+		fmt.Fprintf(w, "  %s\n", messages.T(i18n.SummaryCitationsFirst, 5, len(citations)))
 		for i := 0; i < 5; i++ {
-			fmt.Printf("  - %s\n", finding.Citations[i])
+			fmt.Fprintf(w, "  - %s\n", citations[i])
+		}
+	}
+
+	fmt.Fprintf(w, "\n%s:\n%s\n", messages.T(i18n.SummaryJustification), finding.Justification)
+
+	fmt.Fprintln(w, "\n"+g.Rule)
+	fmt.Fprintf(w, "%s%s\n", g.File, messages.T(i18n.SummarySaved, outputFile))
+}
+
+// maxSecretEventIDs caps how many event IDs the secrets warning lists
+const maxSecretEventIDs = 5
+
+// warnLikelySecrets warns when loaded evidence contains likely secrets. Redaction
+// keeps them out of the prompt, but they remain in the evidence files themselves.
+func warnLikelySecrets(w io.Writer, evidence types.EvidenceBundle) {
+	ids := ai.DetectSecrets(evidence)
+	if len(ids) == 0 {
+		return
+	}
+	slog.Warn("Evidence contains likely secrets", "events", len(ids), "total", len(evidence.Events))
+
+	listed := ids
+	if len(listed) > maxSecretEventIDs {
+		listed = listed[:maxSecretEventIDs]
+	}
+	more := ""
+	if len(ids) > len(listed) {
+		more = fmt.Sprintf(" and %d more", len(ids)-len(listed))
+	}
+	fmt.Fprintf(w, "⚠️  WARNING: %d of %d evidence events contain likely secrets (%s%s)\n", len(ids), len(evidence.Events), strings.Join(listed, ", "), more)
+	fmt.Fprintln(w, "   Secrets are redacted from AI prompts, but remove or rotate them in the evidence source.")
+}
+
+// embedEvidence attaches the events cited by finding. Content is redacted when
+// ai.redaction.enabled is set, and always for the engineer role, which never sees raw evidence.
+func embedEvidence(cfg *types.Config, finding *types.Finding, evidence types.EvidenceBundle) error {
+	redactionCfg := *cfg
+	if cfg.UserRole == types.RoleEngineer {
+		redactionCfg.AI.Redaction.Enabled = true
+	}
+
+	var redactor ai.Redactor
+	if redactionCfg.AI.Redaction.Enabled {
+		redactor = ai.NewRedactor(&redactionCfg)
+	}
+	return ai.EmbedCitedEvidence(finding, evidence, redactor)
+}
+
+// compareWithCache analyzes once from the cache and once fresh, and prints the
+// fields that differ. The fresh result only replaces the cached one when
+// updateCache is set. The fresh finding is returned.
+func compareWithCache(ctx context.Context, engine ai.Engine, preamble types.ContextPreamble, evidence types.EvidenceBundle, updateCache bool, w io.Writer) (*types.Finding, error) {
+	cached, err := engine.Analyze(ai.WithCacheMode(ctx, ai.CacheOnly), preamble, evidence)
+	if errors.Is(err, ai.ErrCacheMiss) {
+		return nil, fmt.Errorf("no cached finding to compare against; run without --compare-cache first")
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	mode := ai.CacheBypass
+	if updateCache {
+		mode = ai.CacheRefresh
+	}
+	fresh, err := engine.Analyze(ai.WithCacheMode(ctx, mode), preamble, evidence)
+	if err != nil {
+		return nil, err
+	}
+
+	printFindingDiff(w, diffFindings(cached, fresh))
+	return fresh, nil
+}
+
+// findingFieldDiff is a field whose value differs between two findings
+type findingFieldDiff struct {
+	Field  string
+	Cached string
+	Fresh  string
+}
+
+// diffFindings compares the analysis fields of a cached and a fresh finding
+func diffFindings(cached, fresh *types.Finding) []findingFieldDiff {
+	fields := []findingFieldDiff{
+		{"confidence", fmt.Sprintf("%.2f", cached.ConfidenceScore), fmt.Sprintf("%.2f", fresh.ConfidenceScore)},
+		{"residual_risk", cached.ResidualRisk, fresh.ResidualRisk},
+		{"mapped_controls", strings.Join(cached.MappedControls, ", "), strings.Join(fresh.MappedControls, ", ")},
+		{"summary", cached.Summary, fresh.Summary},
+	}
+
+	var diffs []findingFieldDiff
+	for _, field := range fields {
+		if field.Cached != field.Fresh {
+			diffs = append(diffs, field)
 		}
 	}
+	return diffs
+}
+
+// printFindingDiff writes one "cached → fresh" line per differing field
+func printFindingDiff(w io.Writer, diffs []findingFieldDiff) {
+	if len(diffs) == 0 {
+		fmt.Fprintln(w, "\n✅ Fresh analysis matches the cached finding")
+		return
+	}
+
+	fmt.Fprintf(w, "\n🔀 Fresh analysis differs from the cached finding in %d field(s):\n", len(diffs))
+	for _, diff := range diffs {
+		fmt.Fprintf(w, "   %s:\n", diff.Field)
+		fmt.Fprintf(w, "     - cached: %s\n", diff.Cached)
+		fmt.Fprintf(w, "     + fresh:  %s\n", diff.Fresh)
+	}
+}
+
+// formatSources renders provenance entries as "github(3), jira(2)"
+func formatSources(provenance []types.ProvenanceEntry) string {
+	parts := make([]string, len(provenance))
+	for i, entry := range provenance {
+		parts[i] = fmt.Sprintf("%s(%d)", entry.Source, entry.EventsUsed)
+	}
+	return strings.Join(parts, ", ")
+}
 
-	fmt.Printf("\nJustification:\n%s\n", finding.Justification)
+// formatRedactions renders a redaction summary as "5 (email 2, ip 1, secret 2)"
+func formatRedactions(summary *types.RedactionSummary) string {
+	if summary.Total == 0 {
+		return "0"
+	}
+
+	categories := make([]string, 0, len(summary.ByCategory))
+	for category := range summary.ByCategory {
+		categories = append(categories, category)
+	}
+	sort.Strings(categories)
 
-	fmt.Println("\n━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━")
-	fmt.Printf("📄 Finding saved to: %s\n", outputFile)
+	parts := make([]string, len(categories))
+	for i, category := range categories {
+		parts[i] = fmt.Sprintf("%s %d", category, summary.ByCategory[category])
+	}
+	return fmt.Sprintf("%d (%s)", summary.Total, strings.Join(parts, ", "))
 }
 
 func init() {
@@ -407,13 +945,24 @@ func init() {
 	// Required flags
 	aiAnalyzeCmd.Flags().String("framework", "", "Framework name (e.g., SOC2, ISO27001, PCI-DSS)")
 	aiAnalyzeCmd.Flags().String("section", "", "Section ID (e.g., CC6.1, A.9.4.2)")
-	aiAnalyzeCmd.Flags().String("excerpts-file", "", "Path to policy excerpts JSON file")
-	aiAnalyzeCmd.Flags().StringSlice("evidence-path", []string{}, "Evidence file paths (supports globs, can be specified multiple times)")
+	aiAnalyzeCmd.Flags().String("excerpts-file", "", "Path, directory or http(s) URL of the policy excerpts (.json or .yaml)")
+	aiAnalyzeCmd.Flags().StringSlice("evidence-path", []string{}, "Evidence file paths (supports globs, can be specified multiple times; - reads stdin)")
 
 	// Optional flags
 	aiAnalyzeCmd.Flags().Bool("no-cache", false, "Bypass cache and perform fresh analysis")
+	aiAnalyzeCmd.Flags().Bool("compare-cache", false, "Compare the cached finding with a fresh analysis and print the fields that differ")
+	aiAnalyzeCmd.Flags().Bool("update-cache", false, "With --compare-cache, store the fresh result in the cache")
 	aiAnalyzeCmd.Flags().String("output", "findings.json", "Output file for finding results")
 	aiAnalyzeCmd.Flags().BoolP("yes", "y", false, "Skip interactive preview and auto-approve analysis")
+	aiAnalyzeCmd.Flags().String("preview-format", previewFormatTUI, "Context preview format: tui (interactive) or json (printed to stderr, or stdout with --preview-only; never prompts)")
+	aiAnalyzeCmd.Flags().Bool("preview-only", false, "With --preview-format json, print the preview and stop before calling the AI provider")
+	aiAnalyzeCmd.Flags().Bool("timing", false, "Print time spent in each phase (load, redact, prompt-build, provider, parse)")
+	aiAnalyzeCmd.Flags().Bool("drop-untimestamped", false, "Drop evidence events without a timestamp instead of stamping them with the load time")
+	aiAnalyzeCmd.Flags().Bool("embed-evidence", false, "Embed the content of cited events in the finding (redacted when ai.redaction.enabled is set or the role is engineer)")
+	aiAnalyzeCmd.Flags().Bool("detailed-citations", false, "Also render citations with the source and date of each cited event, e.g. evt-1 (github, 2024-05-01)")
+	aiAnalyzeCmd.Flags().Duration("timeout", 0, "Maximum time for the whole analysis, including loading and redaction (e.g. 90s, 2m; 0 means no limit)")
+	aiAnalyzeCmd.Flags().Float64("confidence-threshold", 0, "Flag findings below this confidence (0-1) for review, overriding the configured rubric threshold")
+	aiAnalyzeCmd.Flags().Bool("deterministic", false, "Use temperature 0 and a fixed seed (ai.seed, default 42) for reproducible results")
 
 	aiAnalyzeCmd.MarkFlagRequired("framework")
 	aiAnalyzeCmd.MarkFlagRequired("section")
@@ -423,21 +972,55 @@ func init() {
 
 // Excerpt represents a policy excerpt from the excerpts file
 type Excerpt struct {
-	Framework       string   `json:"framework"`
-	Version         string   `json:"version"`
-	Section         string   `json:"section"`
-	Text            string   `json:"text"`
-	RelatedSections []string `json:"related_sections,omitempty"`
+	Framework       string   `json:"framework" yaml:"framework"`
+	Version         string   `json:"version" yaml:"version"`
+	Section         string   `json:"section" yaml:"section"`
+	Text            string   `json:"text" yaml:"text"`
+	RelatedSections []string `json:"related_sections,omitempty" yaml:"related_sections,omitempty"`
+
+	legacy bool // Loaded from the legacy map format, which has no framework or version
+}
+
+// legacyExcerptVersion is assumed for legacy map-format excerpts when no version is pinned
+const legacyExcerptVersion = "2023"
+
+// excerptVersion returns the excerpt's own version, falling back to the version
+// pinned in frameworks.versions and then, for legacy excerpts, to legacyExcerptVersion
+func excerptVersion(excerpt Excerpt, frameworks types.FrameworksConfig) string {
+	if excerpt.Version != "" {
+		return excerpt.Version
+	}
+	if pinned := frameworks.VersionFor(excerpt.Framework); pinned != "" {
+		return pinned
+	}
+	if excerpt.legacy {
+		return legacyExcerptVersion
+	}
+	return ""
 }
 
-// loadExcerpts loads policy excerpts from a JSON file
+// loadExcerpts loads and validates policy excerpts from a JSON or YAML file, a
+// directory of them, or an http(s) URL
 // Supports both array format and map format (legacy)
 func loadExcerpts(filepath string) ([]Excerpt, error) {
-	data, err := os.ReadFile(filepath)
+	excerpts, err := readExcerpts(filepath)
 	if err != nil {
-		return nil, fmt.Errorf("failed to read file: %w", err)
+		return nil, err
+	}
+
+	problems, warnings := validateExcerpts(excerpts)
+	for _, warning := range warnings {
+		slog.Warn("Excerpts file: "+warning, "path", filepath)
 	}
+	if len(problems) > 0 {
+		return nil, fmt.Errorf("invalid excerpts file %s: %s (run 'sdek excerpts validate %s' for details)", filepath, strings.Join(problems, "; "), filepath)
+	}
+
+	return excerpts, nil
+}
 
+// parseExcerpts decodes excerpts in the array format or the legacy map format
+func parseExcerpts(data []byte) ([]Excerpt, error) {
 	// Try array format first (new format)
 	var excerpts []Excerpt
 	if err := json.Unmarshal(data, &excerpts); err == nil {
@@ -458,10 +1041,10 @@ func loadExcerpts(filepath string) ([]Excerpt, error) {
 	excerpts = make([]Excerpt, 0, len(excerptMap))
 	for section, e := range excerptMap {
 		excerpts = append(excerpts, Excerpt{
-			Framework: "",     // Will be filled from command flag
-			Version:   "2023", // Default version for legacy format
+			Framework: "", // Will be filled from command flag
 			Section:   section,
 			Text:      e.Excerpt,
+			legacy:    true,
 		})
 	}
 
@@ -481,29 +1064,63 @@ func findExcerpt(excerpts []Excerpt, framework, section string) (Excerpt, bool)
 			e.Framework = framework // Fill in the framework from request
 			return e, true
 		}
-		// Otherwise require exact framework match
-		if e.Framework == framework {
+		// Otherwise require the same framework, allowing aliases such as "SOC-2"
+		if types.SameFramework(e.Framework, framework) {
 			return e, true
 		}
 	}
 	return Excerpt{}, false
 }
 
-// loadEvidenceFromPaths loads evidence events from file paths (supports globs)
-func loadEvidenceFromPaths(paths []string) (*types.EvidenceBundle, error) {
+// stdinEvidencePath is the --evidence-path value that reads evidence from stdin
+const stdinEvidencePath = "-"
+
+// loadEvidenceFromPaths loads evidence events from file paths (supports globs),
+// keeping only events from sources cfg.SourceAllowed accepts.
+// The path "-" reads a JSON array or NDJSON stream of events from stdin.
+func loadEvidenceFromPaths(paths []string, stdin io.Reader, cfg types.AIConfig) (*types.EvidenceBundle, error) {
+	bundle, _, err := loadEvidenceFromPathsSince(paths, stdin, time.Time{}, cfg)
+	return bundle, err
+}
+
+// loadEvidenceFromPathsSince is loadEvidenceFromPaths restricted to files
+// modified after since; a zero since loads every file. Stdin is always read.
+// It also returns the number of files skipped as unchanged.
+func loadEvidenceFromPathsSince(paths []string, stdin io.Reader, since time.Time, cfg types.AIConfig) (*types.EvidenceBundle, int, error) {
 	bundle := &types.EvidenceBundle{
 		Events: []types.EvidenceEvent{},
 	}
+	skipped := 0
 
 	for _, pattern := range paths {
+		if pattern == stdinEvidencePath {
+			data, err := io.ReadAll(stdin)
+			if err != nil {
+				return nil, 0, fmt.Errorf("failed to read evidence from stdin: %w", err)
+			}
+			events, err := ai.ParseEvidence(data)
+			if err != nil {
+				return nil, 0, fmt.Errorf("failed to parse evidence from stdin: %w", err)
+			}
+			bundle.Events = append(bundle.Events, events...)
+			continue
+		}
+
 		// Expand glob pattern
 		matches, err := filepath.Glob(pattern)
 		if err != nil {
-			return nil, fmt.Errorf("invalid glob pattern %s: %w", pattern, err)
+			return nil, 0, fmt.Errorf("invalid glob pattern %s: %w", pattern, err)
 		}
 
 		// Load each matched file
 		for _, path := range matches {
+			if !since.IsZero() {
+				if info, err := os.Stat(path); err == nil && !info.ModTime().After(since) {
+					slog.Debug("Skipped evidence file unchanged since last run", "path", path, "modified", info.ModTime())
+					skipped++
+					continue
+				}
+			}
 			events, err := loadEventsFromFile(path)
 			if err != nil {
 				slog.Warn("Failed to load evidence file", "path", path, "error", err)
@@ -513,20 +1130,38 @@ func loadEvidenceFromPaths(paths []string) (*types.EvidenceBundle, error) {
 		}
 	}
 
-	return bundle, nil
+	bundle.Events = filterAllowedSources(bundle.Events, cfg)
+	return bundle, skipped, nil
 }
 
-// loadEventsFromFile loads events from a single JSON file
+// filterAllowedSources drops events whose source isn't in ai.allowed_sources
+func filterAllowedSources(events []types.EvidenceEvent, cfg types.AIConfig) []types.EvidenceEvent {
+	if len(cfg.AllowedSources) == 0 {
+		return events
+	}
+
+	kept := events[:0]
+	dropped := make(map[string]int)
+	for _, event := range events {
+		if cfg.SourceAllowed(event.Source) {
+			kept = append(kept, event)
+		} else {
+			dropped[event.Source]++
+		}
+	}
+	if len(dropped) > 0 {
+		slog.Info("Dropped evidence from sources not in ai.allowed_sources", "dropped", len(events)-len(kept), "by_source", dropped, "allowed", cfg.AllowedSources)
+	}
+	return kept
+}
+
+// loadEventsFromFile loads events from a single evidence file, converting its
+// format with the matching evidence adapter (see ai.ParseEvidence)
 func loadEventsFromFile(filepath string) ([]types.EvidenceEvent, error) {
 	data, err := os.ReadFile(filepath)
 	if err != nil {
 		return nil, fmt.Errorf("failed to read file: %w", err)
 	}
 
-	var events []types.EvidenceEvent
-	if err := json.Unmarshal(data, &events); err != nil {
-		return nil, fmt.Errorf("failed to parse JSON: %w", err)
-	}
-
-	return events, nil
+	return ai.ParseEvidence(data)
 }
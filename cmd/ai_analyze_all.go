@@ -0,0 +1,576 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"path"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/pickjonathan/sdek-cli/internal/ai"
+	"github.com/pickjonathan/sdek-cli/internal/analyze"
+	"github.com/pickjonathan/sdek-cli/internal/report"
+	"github.com/pickjonathan/sdek-cli/pkg/types"
+	"github.com/spf13/cobra"
+)
+
+// aiAnalyzeAllCmd represents the 'sdek ai analyze-all' command
+var aiAnalyzeAllCmd = &cobra.Command{
+	Use:   "analyze-all",
+	Short: "Analyze evidence against every section in an excerpts file",
+	Long: `Analyze evidence against every section in a policy excerpts file.
+
+This runs the same context-injected analysis as 'sdek ai analyze' once per
+excerpt and writes all findings to a single JSON file. Use --control to
+restrict the run to a control family and avoid paying for sections you
+don't need.
+
+A --control value matches a section exactly, as a prefix ending at a dot
+("CC6" matches CC6.1 and CC6.2), or as a glob ("A.9.*").
+
+Examples:
+  # Analyze every SOC2 section in the excerpts file
+  sdek ai analyze-all --framework SOC2 \
+      --excerpts-file ./policies/soc2_excerpts.json \
+      --evidence-path ./evidence/*.json
+
+  # Only the CC6 and CC7 control families
+  sdek ai analyze-all --framework SOC2 \
+      --excerpts-file ./policies/soc2_excerpts.json \
+      --evidence-path ./evidence/*.json \
+      --control CC6 --control CC7
+
+  # Only re-analyze sections whose evidence changed since the last run
+  sdek ai analyze-all --framework SOC2 \
+      --excerpts-file ./policies/soc2_excerpts.json \
+      --evidence-path ./evidence/*.json \
+      --incremental-state .sdek-incremental.json
+
+  # Only load evidence files modified since the last run and merge the
+  # results into the previous findings
+  sdek ai analyze-all --framework SOC2 \
+      --excerpts-file ./policies/soc2_excerpts.json \
+      --evidence-path ./evidence/*.json \
+      --incremental-state .sdek-incremental.json --since-last-run
+
+  # Re-assess the controls of last quarter's report with new evidence,
+  # writing an updated report and the changes to its findings
+  sdek ai analyze-all --excerpts-file ./policies/soc2_excerpts.json \
+      --evidence-path ./evidence/q2/*.json \
+      --controls-from-report ./reports/compliance-q1.json \
+      --report-output ./reports/compliance-q2.json --diff-output ./reports/q1-q2-diff.json`,
+	PreRunE: func(cmd *cobra.Command, args []string) error {
+		excerptsFile, _ := cmd.Flags().GetString("excerpts-file")
+		evidencePaths, _ := cmd.Flags().GetStringSlice("evidence-path")
+
+		if excerptsFile == "" {
+			return fmt.Errorf("--excerpts-file is required")
+		}
+		if len(evidencePaths) == 0 {
+			return fmt.Errorf("--evidence-path is required (at least one path)")
+		}
+		if since, _ := cmd.Flags().GetBool("since-last-run"); since {
+			if statePath, _ := cmd.Flags().GetString("incremental-state"); statePath == "" {
+				return fmt.Errorf("--since-last-run requires --incremental-state")
+			}
+		}
+		if reportPath, _ := cmd.Flags().GetString("controls-from-report"); reportPath == "" {
+			if cmd.Flags().Changed("report-output") || cmd.Flags().Changed("diff-output") {
+				return fmt.Errorf("--report-output and --diff-output require --controls-from-report")
+			}
+		}
+		if _, err := os.Stat(excerptsFile); os.IsNotExist(err) && !isExcerptsURL(excerptsFile) {
+			return fmt.Errorf("excerpts file not found: %s", excerptsFile)
+		}
+		return nil
+	},
+	RunE: runAIAnalyzeAll,
+}
+
+func init() {
+	aiCmd.AddCommand(aiAnalyzeAllCmd)
+
+	aiAnalyzeAllCmd.Flags().String("framework", "", "Only analyze excerpts for this framework (required for legacy map-format excerpts)")
+	aiAnalyzeAllCmd.Flags().String("excerpts-file", "", "Path, directory or http(s) URL of the policy excerpts (.json or .yaml)")
+	aiAnalyzeAllCmd.Flags().StringSlice("evidence-path", []string{}, "Evidence file paths (supports globs, can be specified multiple times; - reads stdin)")
+	aiAnalyzeAllCmd.Flags().StringSlice("control", []string{}, "Only analyze sections matching this control ID, prefix or glob (can be specified multiple times)")
+	aiAnalyzeAllCmd.Flags().String("output", "findings.json", "Output file for finding results")
+	aiAnalyzeAllCmd.Flags().String("incremental-state", "", "State file recording evidence hashes per section; unchanged sections reuse the previous finding")
+	aiAnalyzeAllCmd.Flags().Bool("since-last-run", false, "Only load evidence files modified since the run recorded in --incremental-state, merging results into the previous findings")
+	aiAnalyzeAllCmd.Flags().Bool("embed-evidence", false, "Embed the content of cited events in each finding (redacted when ai.redaction.enabled is set or the role is engineer)")
+	aiAnalyzeAllCmd.Flags().Bool("detailed-citations", false, "Also render citations with the source and date of each cited event, e.g. evt-1 (github, 2024-05-01)")
+	aiAnalyzeAllCmd.Flags().Bool("drop-untimestamped", false, "Drop evidence events without a timestamp instead of stamping them with the load time")
+	aiAnalyzeAllCmd.Flags().String("controls-from-report", "", "Only analyze the controls of this JSON report, writing an updated report and the changes to its findings")
+	aiAnalyzeAllCmd.Flags().String("report-output", "report-updated.json", "Output file for the updated report (with --controls-from-report)")
+	aiAnalyzeAllCmd.Flags().String("diff-output", "", "Also write the changes to the report's findings to this JSON file (with --controls-from-report)")
+
+	aiAnalyzeAllCmd.MarkFlagRequired("excerpts-file")
+	aiAnalyzeAllCmd.MarkFlagRequired("evidence-path")
+}
+
+func runAIAnalyzeAll(cmd *cobra.Command, args []string) error {
+	framework, _ := cmd.Flags().GetString("framework")
+	excerptsFile, _ := cmd.Flags().GetString("excerpts-file")
+	evidencePaths, _ := cmd.Flags().GetStringSlice("evidence-path")
+	controls, _ := cmd.Flags().GetStringSlice("control")
+	outputFile, _ := cmd.Flags().GetString("output")
+
+	cfg, err := loadConfig()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+	if !cfg.AI.Enabled {
+		return fmt.Errorf("AI analysis is disabled in config. Set ai.enabled=true to use this command")
+	}
+
+	excerpts, err := loadExcerpts(excerptsFile)
+	if err != nil {
+		return fmt.Errorf("failed to load excerpts: %w", err)
+	}
+
+	selected := selectExcerpts(excerpts, framework, controls)
+	for _, e := range selected {
+		if e.Framework == "" {
+			return fmt.Errorf("--framework is required for legacy map-format excerpts in %s", excerptsFile)
+		}
+	}
+
+	// Re-assessments only analyze the controls of an existing report
+	reportPath, _ := cmd.Flags().GetString("controls-from-report")
+	var previous *report.Report
+	if reportPath != "" {
+		previous, err = report.LoadReport(reportPath)
+		if err != nil {
+			return err
+		}
+		var missing []report.ControlRef
+		selected, missing = selectReportControls(selected, report.Controls(previous))
+		for _, ref := range missing {
+			slog.Warn("Skipping report control without a matching excerpt", "framework", ref.FrameworkID, "control", ref.ControlID)
+		}
+		if len(selected) == 0 {
+			return fmt.Errorf("no excerpts in %s match the controls in %s", excerptsFile, reportPath)
+		}
+	}
+	if len(selected) == 0 {
+		return fmt.Errorf("no excerpts in %s match --framework %q and --control %v", excerptsFile, framework, controls)
+	}
+	slog.Info("Selected sections", "selected", len(selected), "total", len(excerpts))
+
+	// Incremental runs skip sections whose context and evidence haven't changed
+	var state *ai.IncrementalState
+	if statePath, _ := cmd.Flags().GetString("incremental-state"); statePath != "" {
+		state, err = ai.LoadIncrementalState(statePath)
+		if err != nil {
+			return err
+		}
+	}
+
+	engine, err := initializeAIEngine(cfg)
+	if err != nil {
+		return fmt.Errorf("failed to initialize AI engine: %w", err)
+	}
+
+	// With --since-last-run, files unchanged since the recorded run are skipped,
+	// unless a section is new or its context changed and must see all evidence
+	sinceLastRun, _ := cmd.Flags().GetBool("since-last-run")
+	var since time.Time
+	if sinceLastRun {
+		since = state.LastRun
+		if !since.IsZero() && needsFullEvidence(engine, cfg, selected, state) {
+			slog.Info("Loading all evidence: a section is new or its context changed since the last run")
+			since = time.Time{}
+		}
+	}
+
+	evidenceLoadTime := time.Now()
+	evidence, skippedFiles, err := loadEvidenceFromPathsSince(evidencePaths, cmd.InOrStdin(), since, cfg.AI)
+	if err != nil {
+		return fmt.Errorf("failed to load evidence: %w", err)
+	}
+	if skippedFiles > 0 {
+		slog.Info("Skipped evidence files unchanged since last run", "skipped", skippedFiles, "last_run", since)
+	}
+	dropUntimestamped, _ := cmd.Flags().GetBool("drop-untimestamped")
+	normalized, _ := ai.NormalizeEvidence(*evidence, ai.NormalizeOptions{
+		LoadTime:          evidenceLoadTime,
+		DropUntimestamped: dropUntimestamped,
+	})
+	if len(normalized.Events) == 0 && since.IsZero() {
+		return fmt.Errorf("no evidence events found in specified paths")
+	}
+	warnLikelySecrets(cmd.ErrOrStderr(), normalized)
+
+	fmt.Printf("\n🤖 Analyzing %d section(s) with AI context injection...\n", len(selected))
+	findings, failed := analyzeExcerpts(cmd.Context(), engine, cfg, selected, normalized, state, sinceLastRun)
+
+	if state != nil {
+		// Failed sections must see this run's evidence again next time
+		if failed == 0 {
+			state.LastRun = evidenceLoadTime
+		}
+		if err := state.Save(); err != nil {
+			slog.Warn("Failed to save incremental state", "error", err)
+		}
+		fmt.Printf("♻️  %s\n", state.Summary())
+	}
+
+	// Embedded after saving so the state file never holds evidence content
+	if embed, _ := cmd.Flags().GetBool("embed-evidence"); embed {
+		for _, finding := range findings {
+			if err := embedEvidence(cfg, finding, normalized); err != nil {
+				return fmt.Errorf("failed to embed evidence: %w", err)
+			}
+		}
+	}
+	if detailed, _ := cmd.Flags().GetBool("detailed-citations"); detailed {
+		for _, finding := range findings {
+			ai.DetailCitations(finding, normalized)
+		}
+	}
+
+	if err := exportFindings(findings, outputFile, cfg.Export.Mode()); err != nil {
+		return fmt.Errorf("failed to export findings: %w", err)
+	}
+
+	fmt.Printf("\n✅ Analyzed %d section(s)", len(findings))
+	if filtered := len(excerpts) - len(selected); filtered > 0 {
+		fmt.Printf(", %d filtered out", filtered)
+	}
+	if failed > 0 {
+		fmt.Printf(", %d failed", failed)
+	}
+	fmt.Printf("\n📄 Findings saved to: %s\n", outputFile)
+
+	if previous != nil {
+		reportOutput, _ := cmd.Flags().GetString("report-output")
+		diffOutput, _ := cmd.Flags().GetString("diff-output")
+		if err := writeUpdatedReport(cmd.OutOrStdout(), cfg, previous, findings, reportOutput, diffOutput); err != nil {
+			return err
+		}
+	}
+	if len(findings) > 0 {
+		fmt.Println()
+		printFindingsSummary(os.Stdout, summarizeFindings(findings))
+	}
+
+	if len(findings) == 0 {
+		return fmt.Errorf("all %d section(s) failed to analyze", failed)
+	}
+	return nil
+}
+
+// selectExcerpts returns the excerpts for framework (all frameworks if empty)
+// whose section matches one of controls (all sections if empty), ordered by
+// framework and section. Legacy excerpts take the framework from the flag.
+func selectExcerpts(excerpts []Excerpt, framework string, controls []string) []Excerpt {
+	var selected []Excerpt
+	for _, e := range excerpts {
+		if e.Framework == "" {
+			e.Framework = framework
+		} else if framework != "" && !types.SameFramework(e.Framework, framework) {
+			continue
+		}
+		if len(controls) > 0 && !matchesControlFilter(e.Section, controls) {
+			continue
+		}
+		selected = append(selected, e)
+	}
+
+	sort.SliceStable(selected, func(i, j int) bool {
+		if selected[i].Framework != selected[j].Framework {
+			return selected[i].Framework < selected[j].Framework
+		}
+		return selected[i].Section < selected[j].Section
+	})
+	return selected
+}
+
+// selectReportControls returns the excerpts whose section is one of the report's
+// controls, and the report controls that have no excerpt
+func selectReportControls(excerpts []Excerpt, controls []report.ControlRef) ([]Excerpt, []report.ControlRef) {
+	var selected []Excerpt
+	matched := make(map[int]bool, len(controls))
+	for _, e := range excerpts {
+		found := false
+		for i, ref := range controls {
+			if e.Section == ref.ControlID && types.SameFramework(e.Framework, ref.FrameworkID) {
+				matched[i] = true
+				found = true
+			}
+		}
+		if found {
+			selected = append(selected, e)
+		}
+	}
+
+	var missing []report.ControlRef
+	for i, ref := range controls {
+		if !matched[i] {
+			missing = append(missing, ref)
+		}
+	}
+	return selected, missing
+}
+
+// writeUpdatedReport replaces the findings of the re-analyzed controls in
+// previous, writes the updated report to reportPath and, if diffPath is set,
+// the changes to its findings, and prints the changes to w
+func writeUpdatedReport(w io.Writer, cfg *types.Config, previous *report.Report, findings []*types.Finding, reportPath, diffPath string) error {
+	fresh := make([]types.Finding, 0, len(findings))
+	for _, finding := range findings {
+		f := *finding
+		f.SchemaVersion = 0 // Only set on standalone findings files
+		fresh = append(fresh, f)
+	}
+
+	clock, err := outputClock()
+	if err != nil {
+		return err
+	}
+	updated := report.ReplaceFindings(previous, fresh)
+	updated.Metadata.GeneratedAt = clock.Now()
+	updated.Metadata.Version = GetVersion()
+	diff := report.Diff(previous, updated)
+
+	if err := writeJSONFile(reportPath, updated, cfg.Export.Mode()); err != nil {
+		return fmt.Errorf("failed to write updated report: %w", err)
+	}
+	fmt.Fprintf(w, "📄 Updated report saved to: %s\n", reportPath)
+	if diffPath != "" {
+		if err := writeJSONFile(diffPath, diff, cfg.Export.Mode()); err != nil {
+			return fmt.Errorf("failed to write report diff: %w", err)
+		}
+		fmt.Fprintf(w, "📄 Report diff saved to: %s\n", diffPath)
+	}
+
+	fmt.Fprintln(w)
+	printReportDiff(w, diff)
+	return nil
+}
+
+// writeJSONFile saves v as indented JSON, creating parent directories as needed
+func writeJSONFile(path string, v any, mode os.FileMode) error {
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal JSON: %w", err)
+	}
+	return report.WriteFile(path, data, mode)
+}
+
+// printReportDiff prints one line per changed finding, followed by the number
+// of unchanged findings and the change in weighted compliance
+func printReportDiff(w io.Writer, diff *report.ReportDiff) {
+	if len(diff.Changes) == 0 {
+		fmt.Fprintln(w, "No changes to the report's findings")
+	} else {
+		fmt.Fprintln(w, "Changes to the report's findings:")
+	}
+	for _, change := range diff.Changes {
+		control := change.FrameworkID + "/" + change.ControlID
+		switch change.Change {
+		case report.ChangeAdded:
+			fmt.Fprintf(w, "  + %-16s %s (%s)\n", control, change.New.ID, change.New.Severity)
+		case report.ChangeRemoved:
+			fmt.Fprintf(w, "  - %-16s %s (%s)\n", control, change.Old.ID, change.Old.Severity)
+		default:
+			fmt.Fprintf(w, "  ~ %-16s %s → %s (%s)\n", control, change.Old.ID, change.New.ID, strings.Join(change.Fields, ", "))
+		}
+	}
+	fmt.Fprintf(w, "%d finding(s) unchanged, weighted compliance %.1f%% → %.1f%%\n", diff.Unchanged, diff.OldWeightedCompliance, diff.NewWeightedCompliance)
+}
+
+// matchesControlFilter reports whether section matches any of patterns, either
+// exactly, as a prefix ending at a dot ("CC6" matches "CC6.1") or as a glob ("A.9.*")
+func matchesControlFilter(section string, patterns []string) bool {
+	for _, p := range patterns {
+		if section == p || strings.HasPrefix(section, p+".") {
+			return true
+		}
+		if matched, err := path.Match(p, section); err == nil && matched {
+			return true
+		}
+	}
+	return false
+}
+
+// analyzeExcerpts runs one analysis per excerpt, at most ai.concurrency.maxAnalyses
+// at a time, returning the findings in excerpt order and the number of excerpts
+// that failed. Failures are logged and don't stop the run.
+// With a non-nil state, excerpts whose context and evidence are unchanged since
+// the last run reuse the recorded finding instead of calling the provider.
+// With mergeNew, evidence holds only what is new since the last run and is
+// merged into the recorded findings (see IncrementalState.AnalyzeNew).
+func analyzeExcerpts(ctx context.Context, engine ai.Engine, cfg *types.Config, excerpts []Excerpt, evidence types.EvidenceBundle, state *ai.IncrementalState, mergeNew bool) ([]*types.Finding, int) {
+	results := make([]*types.Finding, len(excerpts))
+
+	ai.ForEachLimit(len(excerpts), cfg.AI.Concurrency, func(i int) {
+		excerpt := excerpts[i]
+		preamble, err := excerptPreamble(excerpt, cfg)
+		if err != nil {
+			slog.Warn("Skipping section with invalid excerpt", "framework", excerpt.Framework, "section", excerpt.Section, "error", err)
+			return
+		}
+
+		var finding *types.Finding
+		if state != nil && mergeNew {
+			finding, _, err = state.AnalyzeNew(ctx, engine, *preamble, evidence)
+		} else if state != nil {
+			finding, _, err = state.Analyze(ctx, engine, *preamble, evidence)
+		} else {
+			finding, err = engine.Analyze(ctx, *preamble, evidence)
+		}
+		if err != nil {
+			slog.Warn("AI analysis failed", "framework", excerpt.Framework, "section", excerpt.Section, "error", err)
+			return
+		}
+
+		analyze.FlagLowConfidence(finding, preamble.Rubrics.ConfidenceThreshold)
+		results[i] = finding
+	})
+
+	var findings []*types.Finding
+	failed := 0
+	for _, finding := range results {
+		if finding == nil {
+			failed++
+			continue
+		}
+		findings = append(findings, finding)
+	}
+
+	return findings, failed
+}
+
+// excerptPreamble builds the context preamble for analyzing excerpt
+func excerptPreamble(excerpt Excerpt, cfg *types.Config) (*types.ContextPreamble, error) {
+	return types.NewContextPreambleWithRubrics(
+		excerpt.Framework,
+		excerptVersion(excerpt, cfg.Frameworks),
+		excerpt.Section,
+		excerpt.Text,
+		excerpt.RelatedSections,
+		cfg.Frameworks.RubricsFor(excerpt.Framework),
+	)
+}
+
+// needsFullEvidence reports whether any excerpt must be analyzed from scratch
+// (see IncrementalState.NeedsFullEvidence), so --since-last-run has to load all
+// evidence rather than just the files changed since the last run
+func needsFullEvidence(engine ai.Engine, cfg *types.Config, excerpts []Excerpt, state *ai.IncrementalState) bool {
+	for _, excerpt := range excerpts {
+		preamble, err := excerptPreamble(excerpt, cfg)
+		if err != nil {
+			continue // Skipped with a warning when analyzed
+		}
+		if state.NeedsFullEvidence(engine, *preamble) {
+			return true
+		}
+	}
+	return false
+}
+
+// exportFindings saves findings to a JSON file as an array, creating parent directories as needed
+func exportFindings(findings []*types.Finding, outputPath string, mode os.FileMode) error {
+	if findings == nil {
+		findings = []*types.Finding{}
+	}
+	for _, finding := range findings {
+		finding.SchemaVersion = types.SchemaVersion
+	}
+
+	data, err := json.MarshalIndent(findings, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal findings: %w", err)
+	}
+
+	if err := report.WriteFile(outputPath, data, mode); err != nil {
+		return fmt.Errorf("failed to write file: %w", err)
+	}
+
+	return nil
+}
+
+// otherResidualRisk buckets residual risks that aren't a known level (kept as free text when not strict)
+const otherResidualRisk = "other"
+
+// maxHistogramBar is the widest bar printFindingsSummary draws
+const maxHistogramBar = 40
+
+// findingsSummary counts findings by severity and residual risk level
+type findingsSummary struct {
+	Total          int
+	BySeverity     map[string]int
+	ByResidualRisk map[string]int // Keyed by level, or otherResidualRisk
+	ReviewRequired int
+}
+
+// summarizeFindings counts findings by severity, by normalized residual risk
+// level and by whether they require review
+func summarizeFindings(findings []*types.Finding) findingsSummary {
+	summary := findingsSummary{
+		BySeverity:     make(map[string]int),
+		ByResidualRisk: make(map[string]int),
+	}
+	for _, finding := range findings {
+		if finding == nil {
+			continue
+		}
+		summary.Total++
+		summary.BySeverity[finding.Severity]++
+
+		risk := otherResidualRisk
+		if level, ok := types.NormalizeResidualRisk(finding.ResidualRisk); ok {
+			risk = string(level)
+		}
+		summary.ByResidualRisk[risk]++
+
+		if finding.ReviewRequired {
+			summary.ReviewRequired++
+		}
+	}
+	return summary
+}
+
+// printFindingsSummary prints histograms of findings by severity and residual
+// risk, highest first, followed by how many require review
+func printFindingsSummary(w io.Writer, summary findingsSummary) {
+	g := glyphsFor(w)
+
+	fmt.Fprintln(w, "Findings by severity:")
+	printHistogram(w, g.Bar, summary.BySeverity, []string{types.SeverityCritical, types.SeverityHigh, types.SeverityMedium, types.SeverityLow}, summary.Total)
+
+	fmt.Fprintln(w, "Findings by residual risk:")
+	risks := []string{string(types.ResidualRiskHigh), string(types.ResidualRiskMedium), string(types.ResidualRiskLow), otherResidualRisk}
+	printHistogram(w, g.Bar, summary.ByResidualRisk, risks, summary.Total)
+
+	if summary.ReviewRequired > 0 {
+		fmt.Fprintf(w, "%s%d of %d finding(s) require review\n", g.Warning, summary.ReviewRequired, summary.Total)
+	} else {
+		fmt.Fprintln(w, "No findings require review")
+	}
+}
+
+// printHistogram prints a row per key in order, skipping an empty
+// otherResidualRisk row, with bars of glyph scaled so that total fills maxHistogramBar
+func printHistogram(w io.Writer, glyph string, counts map[string]int, order []string, total int) {
+	for _, key := range order {
+		count := counts[key]
+		if key == otherResidualRisk && count == 0 {
+			continue
+		}
+		bar := 0
+		if total > 0 {
+			bar = count * maxHistogramBar / total
+			if count > 0 && bar == 0 {
+				bar = 1
+			}
+		}
+		fmt.Fprintf(w, "  %-9s %4d %s\n", key, count, strings.Repeat(glyph, bar))
+	}
+}
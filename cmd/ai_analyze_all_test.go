@@ -0,0 +1,445 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+	"unicode"
+
+	"github.com/pickjonathan/sdek-cli/internal/ai"
+	"github.com/pickjonathan/sdek-cli/internal/report"
+	"github.com/pickjonathan/sdek-cli/pkg/types"
+)
+
+func TestMatchesControlFilter(t *testing.T) {
+	tests := []struct {
+		section  string
+		patterns []string
+		want     bool
+	}{
+		{"CC6.1", []string{"CC6.1"}, true},
+		{"CC6.1", []string{"CC6"}, true},
+		{"CC6.1", []string{"CC7", "CC6"}, true},
+		{"CC61", []string{"CC6"}, false},
+		{"CC7.2", []string{"CC6"}, false},
+		{"A.9.4.2", []string{"A.9"}, true},
+		{"A.9.4.2", []string{"A.9.*"}, true},
+		{"A.12.1", []string{"A.9.*"}, false},
+		{"8.2.4", []string{"8.2.?"}, true},
+	}
+
+	for _, tt := range tests {
+		if got := matchesControlFilter(tt.section, tt.patterns); got != tt.want {
+			t.Errorf("matchesControlFilter(%q, %v) = %v, want %v", tt.section, tt.patterns, got, tt.want)
+		}
+	}
+}
+
+func TestSelectExcerpts(t *testing.T) {
+	excerpts := []Excerpt{
+		{Framework: "SOC2", Section: "CC7.2"},
+		{Framework: "SOC2", Section: "CC6.1"},
+		{Framework: "SOC2", Section: "CC6.2"},
+		{Framework: "ISO27001", Section: "A.9.4.2"},
+	}
+
+	tests := []struct {
+		name      string
+		framework string
+		controls  []string
+		want      []string
+	}{
+		{"no filters", "", nil, []string{"ISO27001/A.9.4.2", "SOC2/CC6.1", "SOC2/CC6.2", "SOC2/CC7.2"}},
+		{"framework", "soc-2", nil, []string{"SOC2/CC6.1", "SOC2/CC6.2", "SOC2/CC7.2"}},
+		{"control family", "SOC2", []string{"CC6"}, []string{"SOC2/CC6.1", "SOC2/CC6.2"}},
+		{"control across frameworks", "", []string{"CC7.2", "A.9.*"}, []string{"ISO27001/A.9.4.2", "SOC2/CC7.2"}},
+		{"no match", "SOC2", []string{"CC9"}, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			selected := selectExcerpts(excerpts, tt.framework, tt.controls)
+
+			var got []string
+			for _, e := range selected {
+				got = append(got, e.Framework+"/"+e.Section)
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("selectExcerpts() = %v, want %v", got, tt.want)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Errorf("selectExcerpts()[%d] = %s, want %s", i, got[i], tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestSelectExcerpts_LegacyTakesFrameworkFromFlag(t *testing.T) {
+	excerpts := []Excerpt{{Section: "CC6.1", legacy: true}}
+
+	selected := selectExcerpts(excerpts, "SOC2", []string{"CC6"})
+
+	if len(selected) != 1 || selected[0].Framework != "SOC2" {
+		t.Fatalf("expected legacy excerpt assigned to SOC2, got %+v", selected)
+	}
+}
+
+func TestAnalyzeExcerpts_OnlyMatchingControls(t *testing.T) {
+	text := "Logical access security software, infrastructure and architectures are implemented"
+	excerpts := []Excerpt{
+		{Framework: "SOC2", Version: "2017", Section: "CC6.1", Text: text},
+		{Framework: "SOC2", Version: "2017", Section: "CC6.2", Text: text},
+		{Framework: "SOC2", Version: "2017", Section: "CC7.2", Text: text},
+		{Framework: "SOC2", Version: "2017", Section: "CC8.1", Text: text},
+	}
+	cfg := &types.Config{
+		AI: types.AIConfig{
+			Enabled:  true,
+			Provider: "mock",
+			Mode:     types.AIModeContext,
+			CacheDir: t.TempDir(),
+		},
+	}
+	provider := ai.NewMockProvider()
+	engine := ai.NewEngine(cfg, provider)
+	evidence := types.EvidenceBundle{Events: []types.EvidenceEvent{
+		{ID: "evt-1", Source: "github", Type: "commit", Timestamp: time.Now(), Content: "Enable MFA on admin login"},
+	}}
+
+	selected := selectExcerpts(excerpts, "SOC2", []string{"CC6"})
+	findings, failed := analyzeExcerpts(context.Background(), engine, cfg, selected, evidence, nil, false)
+
+	if failed != 0 {
+		t.Fatalf("expected no failures, got %d", failed)
+	}
+	if provider.GetCallCount() != 2 {
+		t.Errorf("expected 2 provider calls, got %d", provider.GetCallCount())
+	}
+	if len(findings) != 2 {
+		t.Fatalf("expected 2 findings, got %d", len(findings))
+	}
+	for _, f := range findings {
+		if f.ControlID != "CC6.1" && f.ControlID != "CC6.2" {
+			t.Errorf("unexpected finding for control %s", f.ControlID)
+		}
+	}
+}
+
+// sectionCitingProvider answers like the mock provider but cites the event
+// named after the section being analyzed, e.g. evt-CC6.1 for CC6.1
+type sectionCitingProvider struct {
+	*ai.MockProvider
+}
+
+func (p sectionCitingProvider) AnalyzeWithContext(ctx context.Context, prompt string) (string, error) {
+	if _, err := p.MockProvider.AnalyzeWithContext(ctx, prompt); err != nil {
+		return "", err
+	}
+	section := ""
+	if m := regexp.MustCompile(`compliance with \S+ (\S+)\.\n`).FindStringSubmatch(prompt); m != nil {
+		section = m[1]
+	}
+	return fmt.Sprintf(`{"summary": "Reviewed %s", "confidence_score": 0.9, "residual_risk": "low", "citations": ["evt-%s"]}`, section, section), nil
+}
+
+func TestAnalyzeExcerpts_IncrementalSharedEvidence(t *testing.T) {
+	text := "Logical access security software, infrastructure and architectures are implemented"
+	excerpts := []Excerpt{
+		{Framework: "SOC2", Version: "2017", Section: "CC6.1", Text: text},
+		{Framework: "SOC2", Version: "2017", Section: "CC6.2", Text: text},
+		{Framework: "SOC2", Version: "2017", Section: "CC7.2", Text: text},
+	}
+	cfg := &types.Config{AI: types.AIConfig{Enabled: true, Provider: "mock", Mode: types.AIModeContext}}
+	ts := time.Date(2025, 1, 15, 10, 0, 0, 0, time.UTC)
+	evidence := types.EvidenceBundle{Events: []types.EvidenceEvent{
+		{ID: "evt-CC6.1", Source: "github", Type: "commit", Timestamp: ts, Content: "Enforce MFA on admin login"},
+		{ID: "evt-CC6.2", Source: "jira", Type: "ticket", Timestamp: ts, Content: "Quarterly access review completed"},
+		{ID: "evt-CC7.2", Source: "github", Type: "commit", Timestamp: ts, Content: "Alerting enabled for failed logins"},
+	}}
+	statePath := filepath.Join(t.TempDir(), "incremental.json")
+
+	// run analyzes every excerpt against the shared evidence with a fresh provider
+	run := func(evidence types.EvidenceBundle) (*ai.IncrementalState, *ai.MockProvider) {
+		t.Helper()
+		state, err := ai.LoadIncrementalState(statePath)
+		if err != nil {
+			t.Fatalf("LoadIncrementalState() error = %v", err)
+		}
+		provider := sectionCitingProvider{ai.NewMockProvider()}
+		_, failed := analyzeExcerpts(context.Background(), ai.NewEngine(cfg, provider), cfg, excerpts, evidence, state, false)
+		if failed != 0 {
+			t.Fatalf("expected no failures, got %d", failed)
+		}
+		if err := state.Save(); err != nil {
+			t.Fatalf("Save() error = %v", err)
+		}
+		return state, provider.MockProvider
+	}
+
+	run(evidence)
+
+	// Unchanged evidence reuses every finding
+	state, provider := run(evidence)
+	if provider.GetCallCount() != 0 {
+		t.Errorf("expected no provider calls for unchanged evidence, got %d", provider.GetCallCount())
+	}
+	if got := state.Summary(); got != "3 controls unchanged, 0 re-analyzed" {
+		t.Errorf("Summary() = %q", got)
+	}
+
+	// Every control analyzed the whole bundle, so changing any event re-analyzes them all
+	changed := types.EvidenceBundle{Events: append([]types.EvidenceEvent(nil), evidence.Events...)}
+	changed.Events[1].Content = "Quarterly access review skipped"
+	state, provider = run(changed)
+	if provider.GetCallCount() != 3 {
+		t.Errorf("expected a changed event to re-analyze every control, got %d calls", provider.GetCallCount())
+	}
+	if got := state.Summary(); got != "0 controls unchanged, 3 re-analyzed" {
+		t.Errorf("Summary() = %q", got)
+	}
+
+	// A new event may be relevant to any control
+	added := types.EvidenceBundle{Events: append(changed.Events, types.EvidenceEvent{
+		ID: "evt-new", Source: "github", Type: "commit", Timestamp: ts, Content: "Disable unused service accounts",
+	})}
+	if _, provider := run(added); provider.GetCallCount() != 3 {
+		t.Errorf("expected a new event to re-analyze every control, got %d calls", provider.GetCallCount())
+	}
+}
+
+func TestSelectReportControls(t *testing.T) {
+	excerpts := []Excerpt{
+		{Framework: "SOC2", Section: "CC6.1"},
+		{Framework: "SOC2", Section: "CC8.1"},
+		{Framework: "ISO27001", Section: "A.9.4.2"},
+	}
+	controls := []report.ControlRef{
+		{FrameworkID: "soc2", ControlID: "CC6.1"},
+		{FrameworkID: "soc2", ControlID: "CC7.2"},
+		{FrameworkID: "iso27001", ControlID: "A.9.4.2"},
+	}
+
+	selected, missing := selectReportControls(excerpts, controls)
+
+	var sections []string
+	for _, e := range selected {
+		sections = append(sections, e.Section)
+	}
+	if strings.Join(sections, ",") != "CC6.1,A.9.4.2" {
+		t.Errorf("expected CC6.1 and A.9.4.2 selected, got %v", sections)
+	}
+	if len(missing) != 1 || missing[0].ControlID != "CC7.2" {
+		t.Errorf("expected CC7.2 without an excerpt, got %+v", missing)
+	}
+}
+
+func TestWriteUpdatedReport_ReanalyzesReportControls(t *testing.T) {
+	// Last quarter's report: CC6.1 and CC6.2 had high findings, CC7.2 a medium one
+	frameworks := []types.Framework{{ID: "soc2", Name: "SOC 2"}}
+	controls := []types.Control{
+		{ID: "CC6.1", FrameworkID: "soc2", RiskStatus: "red"},
+		{ID: "CC6.2", FrameworkID: "soc2", RiskStatus: "red"},
+		{ID: "CC7.2", FrameworkID: "soc2", RiskStatus: "yellow"},
+	}
+	findings := []types.Finding{
+		{ID: "f-1", ControlID: "CC6.1", FrameworkID: "soc2", Severity: types.SeverityHigh, Status: types.StatusOpen},
+		{ID: "f-2", ControlID: "CC6.2", FrameworkID: "soc2", Severity: types.SeverityHigh, Status: types.StatusOpen},
+		{ID: "f-3", ControlID: "CC7.2", FrameworkID: "soc2", Severity: types.SeverityMedium, Status: types.StatusOpen},
+	}
+	previous, err := report.NewExporter("1.0.0").GenerateReport(nil, nil, frameworks, controls, nil, findings, "")
+	if err != nil {
+		t.Fatalf("GenerateReport failed: %v", err)
+	}
+
+	// Re-analyze with this quarter's evidence; CC7.2 has no excerpt
+	text := "Logical access security software, infrastructure and architectures are implemented"
+	excerpts := []Excerpt{
+		{Framework: "SOC2", Version: "2017", Section: "CC6.1", Text: text},
+		{Framework: "SOC2", Version: "2017", Section: "CC6.2", Text: text},
+		{Framework: "SOC2", Version: "2017", Section: "CC8.1", Text: text},
+	}
+	selected, missing := selectReportControls(excerpts, report.Controls(previous))
+	if len(selected) != 2 || len(missing) != 1 {
+		t.Fatalf("expected 2 selected and 1 missing control, got %d and %d", len(selected), len(missing))
+	}
+
+	cfg := &types.Config{
+		AI: types.AIConfig{
+			Enabled:  true,
+			Provider: "mock",
+			Mode:     types.AIModeContext,
+			CacheDir: t.TempDir(),
+		},
+	}
+	engine := ai.NewEngine(cfg, ai.NewMockProvider())
+	evidence := types.EvidenceBundle{Events: []types.EvidenceEvent{
+		{ID: "evt-1", Source: "github", Type: "commit", Timestamp: time.Now(), Content: "Enforce MFA for all admin logins"},
+	}}
+	fresh, failed := analyzeExcerpts(context.Background(), engine, cfg, selected, evidence, nil, false)
+	if failed != 0 || len(fresh) != 2 {
+		t.Fatalf("expected 2 fresh findings, got %d with %d failed", len(fresh), failed)
+	}
+
+	dir := t.TempDir()
+	reportPath := filepath.Join(dir, "report-updated.json")
+	diffPath := filepath.Join(dir, "diff.json")
+	var out bytes.Buffer
+	if err := writeUpdatedReport(&out, cfg, previous, fresh, reportPath, diffPath); err != nil {
+		t.Fatalf("writeUpdatedReport failed: %v", err)
+	}
+
+	// The updated report holds the fresh findings for the re-analyzed controls
+	updated, err := report.LoadReport(reportPath)
+	if err != nil {
+		t.Fatalf("failed to load updated report: %v", err)
+	}
+	byControl := make(map[string][]types.Finding)
+	for _, ctrl := range updated.Frameworks[0].Controls {
+		byControl[ctrl.Control.ID] = ctrl.Findings
+	}
+	for _, control := range []string{"CC6.1", "CC6.2"} {
+		if len(byControl[control]) != 1 || byControl[control][0].Mode != "ai" || byControl[control][0].FrameworkID != "soc2" {
+			t.Errorf("expected %s to hold one fresh soc2 AI finding, got %+v", control, byControl[control])
+		}
+	}
+	if len(byControl["CC7.2"]) != 1 || byControl["CC7.2"][0].ID != "f-3" {
+		t.Errorf("expected CC7.2 to keep f-3, got %+v", byControl["CC7.2"])
+	}
+	if updated.Summary.HighFindings != 0 || updated.Summary.TotalFindings != 3 {
+		t.Errorf("expected the high findings replaced, got %+v", updated.Summary)
+	}
+
+	// The diff shows both re-analyzed controls changing severity
+	data, err := os.ReadFile(diffPath)
+	if err != nil {
+		t.Fatalf("failed to read diff: %v", err)
+	}
+	var diff report.ReportDiff
+	if err := json.Unmarshal(data, &diff); err != nil {
+		t.Fatalf("failed to parse diff: %v", err)
+	}
+	if len(diff.Changes) != 2 || diff.Unchanged != 1 {
+		t.Fatalf("expected 2 changes and 1 unchanged finding, got %+v", diff)
+	}
+	for _, change := range diff.Changes {
+		if change.Change != report.ChangeChanged || change.Fields[0] != "severity" {
+			t.Errorf("expected a severity change, got %+v", change)
+		}
+	}
+	if diff.NewWeightedCompliance <= diff.OldWeightedCompliance {
+		t.Errorf("expected weighted compliance to rise, got %.1f -> %.1f", diff.OldWeightedCompliance, diff.NewWeightedCompliance)
+	}
+
+	for _, want := range []string{"~ soc2/CC6.1", "~ soc2/CC6.2", "1 finding(s) unchanged"} {
+		if !strings.Contains(out.String(), want) {
+			t.Errorf("expected output to contain %q, got:\n%s", want, out.String())
+		}
+	}
+}
+
+func TestSummarizeFindings(t *testing.T) {
+	findings := []*types.Finding{
+		{Severity: types.SeverityHigh, ResidualRisk: "high", ReviewRequired: true},
+		{Severity: types.SeverityHigh, ResidualRisk: "Elevated risk"},
+		{Severity: types.SeverityMedium, ResidualRisk: "moderate"},
+		{Severity: types.SeverityLow, ResidualRisk: "low", ReviewRequired: true},
+		{Severity: types.SeverityLow, ResidualRisk: "none"},
+		{Severity: types.SeverityMedium, ResidualRisk: "MFA not enforced for contractors", ReviewRequired: true},
+		nil,
+	}
+
+	summary := summarizeFindings(findings)
+
+	if summary.Total != 6 {
+		t.Errorf("Total = %d, want 6", summary.Total)
+	}
+	wantSeverity := map[string]int{types.SeverityHigh: 2, types.SeverityMedium: 2, types.SeverityLow: 2}
+	for severity, want := range wantSeverity {
+		if got := summary.BySeverity[severity]; got != want {
+			t.Errorf("BySeverity[%s] = %d, want %d", severity, got, want)
+		}
+	}
+	if got := summary.BySeverity[types.SeverityCritical]; got != 0 {
+		t.Errorf("BySeverity[critical] = %d, want 0", got)
+	}
+	wantRisk := map[string]int{"high": 2, "medium": 1, "low": 2, otherResidualRisk: 1}
+	for risk, want := range wantRisk {
+		if got := summary.ByResidualRisk[risk]; got != want {
+			t.Errorf("ByResidualRisk[%s] = %d, want %d", risk, got, want)
+		}
+	}
+	if summary.ReviewRequired != 3 {
+		t.Errorf("ReviewRequired = %d, want 3", summary.ReviewRequired)
+	}
+}
+
+func TestPrintFindingsSummary(t *testing.T) {
+	summary := summarizeFindings([]*types.Finding{
+		{Severity: types.SeverityHigh, ResidualRisk: "high", ReviewRequired: true},
+		{Severity: types.SeverityLow, ResidualRisk: "low"},
+		{Severity: types.SeverityLow, ResidualRisk: "low"},
+		{Severity: types.SeverityLow, ResidualRisk: "low"},
+	})
+
+	var buf bytes.Buffer
+	printFindingsSummary(&buf, summary)
+	output := buf.String()
+
+	// A buffer isn't a terminal, so the summary is plain ASCII
+	for i, r := range output {
+		if r > unicode.MaxASCII {
+			t.Fatalf("expected plain ASCII output, found %q at offset %d in:\n%s", r, i, output)
+		}
+	}
+	for _, want := range []string{
+		"  critical     0 \n",
+		"  high         1 " + strings.Repeat("#", 10) + "\n",
+		"  low          3 " + strings.Repeat("#", 30) + "\n",
+		"WARNING: 1 of 4 finding(s) require review",
+	} {
+		if !strings.Contains(output, want) {
+			t.Errorf("summary missing %q:\n%s", want, output)
+		}
+	}
+	if strings.Contains(output, otherResidualRisk) {
+		t.Errorf("summary should omit an empty %q row:\n%s", otherResidualRisk, output)
+	}
+}
+
+func TestNeedsFullEvidence(t *testing.T) {
+	text := "Logical access security software, infrastructure and architectures are implemented"
+	excerpts := []Excerpt{{Framework: "SOC2", Version: "2017", Section: "CC6.1", Text: text}}
+	cfg := &types.Config{AI: types.AIConfig{Enabled: true, Provider: "mock", Mode: types.AIModeContext}}
+	evidence := types.EvidenceBundle{Events: []types.EvidenceEvent{
+		{ID: "evt-1", Source: "github", Type: "commit", Timestamp: time.Date(2025, 1, 15, 10, 0, 0, 0, time.UTC), Content: "Enforce MFA on admin login"},
+	}}
+	engine := ai.NewEngine(cfg, ai.NewMockProvider())
+	state, err := ai.LoadIncrementalState(filepath.Join(t.TempDir(), "incremental.json"))
+	if err != nil {
+		t.Fatalf("LoadIncrementalState() error = %v", err)
+	}
+
+	if !needsFullEvidence(engine, cfg, excerpts, state) {
+		t.Error("expected a section without a recorded finding to need all evidence")
+	}
+	if _, failed := analyzeExcerpts(context.Background(), engine, cfg, excerpts, evidence, state, false); failed != 0 {
+		t.Fatalf("expected no failures, got %d", failed)
+	}
+	if needsFullEvidence(engine, cfg, excerpts, state) {
+		t.Error("expected an unchanged section to need only new evidence")
+	}
+
+	changed := []Excerpt{{Framework: "SOC2", Version: "2017", Section: "CC6.1", Text: text + " and reviewed quarterly"}}
+	if !needsFullEvidence(engine, cfg, changed, state) {
+		t.Error("expected a section whose excerpt changed to need all evidence")
+	}
+}
@@ -0,0 +1,122 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/pickjonathan/sdek-cli/internal/ai"
+	"github.com/spf13/cobra"
+)
+
+// aiCacheCmd represents the 'sdek ai cache' parent command
+var aiCacheCmd = &cobra.Command{
+	Use:   "cache",
+	Short: "Manage the AI analysis cache",
+	Long: `Manage cached AI analysis results.
+
+Cached results are reused when the same policy excerpt and evidence are
+analyzed again. After a policy update, invalidate the affected entries so
+the next analysis runs fresh.`,
+}
+
+// aiCacheInvalidateCmd represents the 'sdek ai cache invalidate' command
+var aiCacheInvalidateCmd = &cobra.Command{
+	Use:   "invalidate",
+	Short: "Remove cached results for a framework or control",
+	Long: `Remove cached AI analysis results for a framework, a control, or both.
+
+Only matching entries are removed; the rest of the cache is kept.`,
+	Example: `  # Invalidate a single control after a policy update
+  sdek ai cache invalidate --framework SOC2 --section CC6.1
+
+  # Invalidate every cached result for a framework
+  sdek ai cache invalidate --framework ISO27001`,
+	PreRunE: func(cmd *cobra.Command, args []string) error {
+		if cacheInvalidateFramework == "" && cacheInvalidateSection == "" {
+			return fmt.Errorf("at least one of --framework or --section is required")
+		}
+		return nil
+	},
+	RunE: runAICacheInvalidate,
+}
+
+// aiCachePruneCmd represents the 'sdek ai cache prune' command
+var aiCachePruneCmd = &cobra.Command{
+	Use:   "prune",
+	Short: "Evict the oldest cached results until the cache fits a size limit",
+	Long: `Evict the oldest cached AI analysis results until the cache directory is
+no larger than a size limit.
+
+The limit defaults to ai.cache_max_bytes, which is also enforced
+automatically whenever a new result is cached.`,
+	Example: `  # Shrink the cache to the configured ai.cache_max_bytes
+  sdek ai cache prune
+
+  # Shrink the cache to 10 MiB
+  sdek ai cache prune --max-bytes 10485760`,
+	RunE: runAICachePrune,
+}
+
+var (
+	cacheInvalidateFramework string
+	cacheInvalidateSection   string
+	cachePruneMaxBytes       int64
+)
+
+func init() {
+	aiCmd.AddCommand(aiCacheCmd)
+	aiCacheCmd.AddCommand(aiCacheInvalidateCmd)
+	aiCacheCmd.AddCommand(aiCachePruneCmd)
+
+	aiCacheInvalidateCmd.Flags().StringVar(&cacheInvalidateFramework, "framework", "", "Framework whose cached results to remove (e.g., SOC2)")
+	aiCacheInvalidateCmd.Flags().StringVar(&cacheInvalidateSection, "section", "", "Section/control ID whose cached results to remove (e.g., CC6.1)")
+
+	aiCachePruneCmd.Flags().Int64Var(&cachePruneMaxBytes, "max-bytes", 0, "Size limit in bytes (default: ai.cache_max_bytes)")
+}
+
+func runAICacheInvalidate(cmd *cobra.Command, args []string) error {
+	cfg, err := loadConfig()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	cache, err := ai.NewCache(cfg.AI.CacheDir)
+	if err != nil {
+		return fmt.Errorf("failed to open cache: %w", err)
+	}
+
+	removed, err := cache.Invalidate(cacheInvalidateFramework, cacheInvalidateSection)
+	if err != nil {
+		return fmt.Errorf("failed to invalidate cache: %w", err)
+	}
+
+	fmt.Printf("✓ Removed %d cached result(s)\n", removed)
+	return nil
+}
+
+func runAICachePrune(cmd *cobra.Command, args []string) error {
+	cfg, err := loadConfig()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	maxBytes := cachePruneMaxBytes
+	if maxBytes == 0 {
+		maxBytes = cfg.AI.CacheMaxBytes
+	}
+	if maxBytes <= 0 {
+		return fmt.Errorf("no cache size limit set; pass --max-bytes or set ai.cache_max_bytes")
+	}
+
+	cache, err := ai.NewCache(cfg.AI.CacheDir)
+	if err != nil {
+		return fmt.Errorf("failed to open cache: %w", err)
+	}
+
+	result, err := cache.Prune(maxBytes)
+	if err != nil {
+		return fmt.Errorf("failed to prune cache: %w", err)
+	}
+
+	fmt.Printf("✓ Removed %d cached result(s), freed %d bytes (%d bytes remaining)\n", result.Removed, result.FreedBytes, result.RemainingBytes)
+	return nil
+}
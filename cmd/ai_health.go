@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/pickjonathan/sdek-cli/internal/ai"
 	"github.com/pickjonathan/sdek-cli/internal/ai/factory"
 	"github.com/pickjonathan/sdek-cli/pkg/types"
 	"github.com/spf13/cobra"
@@ -31,7 +32,10 @@ Supports all provider schemes: openai://, anthropic://, gemini://, ollama://, et
   sdek ai health --verbose
 
   # Test specific provider URL
-  sdek ai health --provider-url "ollama://localhost:11434"`,
+  sdek ai health --provider-url "ollama://localhost:11434"
+
+  # Machine-readable result for CI (exits non-zero when unhealthy)
+  sdek ai health --format json`,
 	RunE: runAIHealth,
 }
 
@@ -39,6 +43,7 @@ var (
 	healthProviderURL string
 	healthVerbose     bool
 	healthTimeout     int
+	healthFormat      string
 )
 
 func init() {
@@ -47,10 +52,15 @@ func init() {
 	aiHealthCmd.Flags().StringVar(&healthProviderURL, "provider-url", "", "Override provider URL (e.g., ollama://localhost:11434)")
 	aiHealthCmd.Flags().BoolVarP(&healthVerbose, "verbose", "v", false, "Show detailed health information")
 	aiHealthCmd.Flags().IntVar(&healthTimeout, "timeout", 10, "Health check timeout in seconds")
+	aiHealthCmd.Flags().StringVar(&healthFormat, "format", "text", "Output format: text or json (name, type, status, latency and error of each probed target)")
 }
 
 func runAIHealth(cmd *cobra.Command, args []string) error {
+	if healthFormat != "text" && healthFormat != "json" {
+		return fmt.Errorf("invalid format '%s', must be one of: text, json", healthFormat)
+	}
 	ctx := context.Background()
+	out := cmd.OutOrStdout()
 
 	// Load configuration from Viper
 	cfg := &types.Config{}
@@ -96,58 +106,99 @@ func runAIHealth(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("failed to create provider: %w", err)
 	}
 
+	// Run health check with timeout
+	healthCtx, cancel := context.WithTimeout(ctx, time.Duration(healthTimeout)*time.Second)
+	defer cancel()
+
+	if healthFormat == "json" {
+		target, _, probeErr := probeProvider(healthCtx, providerURL, provider)
+		if err := writeJSON(out, []healthTarget{target}); err != nil {
+			return err
+		}
+		if probeErr != nil {
+			cmd.SilenceUsage = true // Keep stdout parseable; the exit code reports the failure
+			return fmt.Errorf("health check failed: %w", probeErr)
+		}
+		return nil
+	}
+
 	// Display provider info
-	fmt.Printf("AI Provider Health Check\n")
-	fmt.Printf("========================\n\n")
-	fmt.Printf("Provider URL: %s\n", providerURL)
+	fmt.Fprintf(out, "AI Provider Health Check\n")
+	fmt.Fprintf(out, "========================\n\n")
+	fmt.Fprintf(out, "Provider URL: %s\n", providerURL)
 	if providerConfig.Model != "" {
-		fmt.Printf("Model:        %s\n", providerConfig.Model)
+		fmt.Fprintf(out, "Model:        %s\n", providerConfig.Model)
 	}
 	if providerConfig.Endpoint != "" {
-		fmt.Printf("Endpoint:     %s\n", providerConfig.Endpoint)
+		fmt.Fprintf(out, "Endpoint:     %s\n", providerConfig.Endpoint)
 	}
-	fmt.Printf("Timeout:      %ds\n", healthTimeout)
-	fmt.Printf("\n")
-
-	// Run health check with timeout
-	healthCtx, cancel := context.WithTimeout(ctx, time.Duration(healthTimeout)*time.Second)
-	defer cancel()
+	fmt.Fprintf(out, "Timeout:      %ds\n", healthTimeout)
+	fmt.Fprintf(out, "\n")
 
-	fmt.Printf("Checking connectivity...")
-	startTime := time.Now()
-
-	// Execute health check (requires providers to implement Health method)
-	// For now, we'll call a simple test prompt
-	result, err := provider.AnalyzeWithContext(healthCtx, "Return OK if you can read this.")
-	latency := time.Since(startTime)
+	fmt.Fprintf(out, "Checking connectivity...")
+	target, result, err := probeProvider(healthCtx, providerURL, provider)
+	latency := time.Duration(target.LatencyMS) * time.Millisecond
 
 	if err != nil {
-		fmt.Printf(" ✗ FAILED\n\n")
-		fmt.Printf("Status:  ✗ Unhealthy\n")
-		fmt.Printf("Error:   %v\n", err)
-		fmt.Printf("Latency: %v\n", latency.Round(time.Millisecond))
+		fmt.Fprintf(out, " ✗ FAILED\n\n")
+		fmt.Fprintf(out, "Status:  ✗ Unhealthy\n")
+		fmt.Fprintf(out, "Error:   %v\n", err)
+		fmt.Fprintf(out, "Latency: %v\n", latency)
 		return fmt.Errorf("health check failed: %w", err)
 	}
 
-	fmt.Printf(" ✓ SUCCESS\n\n")
-	fmt.Printf("Status:  ✓ Healthy\n")
-	fmt.Printf("Latency: %v\n", latency.Round(time.Millisecond))
+	fmt.Fprintf(out, " ✓ SUCCESS\n\n")
+	fmt.Fprintf(out, "Status:  ✓ Healthy\n")
+	fmt.Fprintf(out, "Latency: %v\n", latency)
 
 	if healthVerbose {
-		fmt.Printf("\nDetailed Response:\n")
-		fmt.Printf("------------------\n")
+		fmt.Fprintf(out, "\nDetailed Response:\n")
+		fmt.Fprintf(out, "------------------\n")
 		if len(result) > 200 {
-			fmt.Printf("%s...\n", result[:200])
+			fmt.Fprintf(out, "%s...\n", result[:200])
 		} else {
-			fmt.Printf("%s\n", result)
+			fmt.Fprintf(out, "%s\n", result)
 		}
-		fmt.Printf("\nProvider Call Count: %d\n", provider.GetCallCount())
+		fmt.Fprintf(out, "\nProvider Call Count: %d\n", provider.GetCallCount())
 	}
 
-	fmt.Printf("\n✓ AI provider is healthy and ready to use\n")
+	fmt.Fprintf(out, "\n✓ AI provider is healthy and ready to use\n")
 	return nil
 }
 
+// Health statuses reported for each probed target
+const (
+	healthStatusHealthy   = "healthy"
+	healthStatusUnhealthy = "unhealthy"
+)
+
+// healthTarget is the result of probing one target, as emitted by --format json
+type healthTarget struct {
+	Name      string `json:"name"`
+	Type      string `json:"type"` // "ai_provider"
+	Status    string `json:"status"`
+	LatencyMS int64  `json:"latency_ms"`
+	Error     string `json:"error,omitempty"`
+}
+
+// probeProvider sends a short test prompt to provider and returns the probe
+// result together with the provider's response and error
+func probeProvider(ctx context.Context, name string, provider ai.Provider) (healthTarget, string, error) {
+	target := healthTarget{Name: name, Type: "ai_provider", Status: healthStatusHealthy}
+
+	// Execute health check (requires providers to implement Health method)
+	// For now, we'll call a simple test prompt
+	startTime := time.Now()
+	result, err := provider.AnalyzeWithContext(ctx, "Return OK if you can read this.")
+	target.LatencyMS = time.Since(startTime).Milliseconds()
+
+	if err != nil {
+		target.Status = healthStatusUnhealthy
+		target.Error = err.Error()
+	}
+	return target, result, err
+}
+
 // getProviderURL extracts the provider URL from config
 func getProviderURL(cfg *types.Config) string {
 	// Check for new provider_url field (Feature 006)
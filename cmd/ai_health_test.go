@@ -0,0 +1,84 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	_ "github.com/pickjonathan/sdek-cli/internal/ai/providers"
+)
+
+// runHealthJSON runs 'sdek ai health --format json' against an Ollama provider
+// served by handler and returns the decoded targets
+func runHealthJSON(t *testing.T, handler http.HandlerFunc) ([]healthTarget, error) {
+	t.Helper()
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	providerURL := "ollama://" + strings.TrimPrefix(server.URL, "http://")
+	t.Cleanup(func() { healthFormat, healthProviderURL = "text", "" })
+
+	rootCmd.SetArgs([]string{"ai", "health", "--format", "json", "--provider-url", providerURL, "--timeout", "5"})
+	stdout, stderr := new(bytes.Buffer), new(bytes.Buffer)
+	rootCmd.SetOut(stdout)
+	rootCmd.SetErr(stderr)
+	err := rootCmd.Execute()
+
+	var targets []healthTarget
+	if jsonErr := json.Unmarshal(stdout.Bytes(), &targets); jsonErr != nil {
+		t.Fatalf("failed to parse JSON output: %v\n%s", jsonErr, stdout.String())
+	}
+	for _, target := range targets {
+		if target.Name != providerURL {
+			t.Errorf("expected target name %s, got %s", providerURL, target.Name)
+		}
+	}
+	return targets, err
+}
+
+func TestAIHealth_JSONHealthy(t *testing.T) {
+	targets, err := runHealthJSON(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"response": "OK", "done": true}`))
+	})
+	if err != nil {
+		t.Fatalf("ai health failed: %v", err)
+	}
+
+	if len(targets) != 1 {
+		t.Fatalf("expected 1 target, got %d", len(targets))
+	}
+	target := targets[0]
+	if target.Type != "ai_provider" || target.Status != healthStatusHealthy || target.Error != "" {
+		t.Errorf("unexpected target: %+v", target)
+	}
+	if target.LatencyMS < 0 {
+		t.Errorf("expected a non-negative latency, got %d", target.LatencyMS)
+	}
+}
+
+func TestAIHealth_JSONUnhealthy(t *testing.T) {
+	targets, err := runHealthJSON(t, func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "model not loaded", http.StatusInternalServerError)
+	})
+	if err == nil {
+		t.Fatal("expected an error for an unhealthy provider")
+	}
+
+	if len(targets) != 1 {
+		t.Fatalf("expected 1 target, got %d", len(targets))
+	}
+	target := targets[0]
+	if target.Status != healthStatusUnhealthy || !strings.Contains(target.Error, "model not loaded") {
+		t.Errorf("unexpected target: %+v", target)
+	}
+}
+
+func TestAIHealth_InvalidFormat(t *testing.T) {
+	t.Cleanup(func() { healthFormat = "text" })
+	if _, err := runFrameworksCommand(t, "ai", "health", "--format", "yaml"); err == nil || !strings.Contains(err.Error(), "invalid format") {
+		t.Errorf("expected an invalid format error, got %v", err)
+	}
+}
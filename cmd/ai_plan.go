@@ -2,6 +2,7 @@ package cmd
 
 import (
 	"fmt"
+	"io"
 	"log/slog"
 	"os"
 	"strings"
@@ -40,7 +41,9 @@ determines what data to collect and from where, based on policy requirements.
 Connectors:
 The command requires at least one enabled connector in config.yaml (ai.connectors).
 Supported connectors: github, jira, aws, slack
-Configure connectors with API keys, endpoints, and rate limits as needed.`,
+Configure connectors with API keys, endpoints, and rate limits as needed.
+The plan only uses enabled connectors and MCP servers; items the AI proposes
+for other sources are dropped. Use --sources to choose the sources explicitly.`,
 	Example: `  # Generate plan with interactive approval
   sdek ai plan --framework SOC2 --section CC6.1 \
       --excerpts-file ./policies/soc2_excerpts.json
@@ -50,11 +53,26 @@ Configure connectors with API keys, endpoints, and rate limits as needed.`,
       --excerpts-file ./policies/iso_excerpts.json \
       --dry-run
 
-  # Auto-approve all plan items and execute
+  # Auto-approve plan items (except weak rationales) and execute
   sdek ai plan --framework PCI-DSS --section 8.2.4 \
       --excerpts-file ./policies/pci_excerpts.json \
       --approve-all
 
+  # Only let the plan query GitHub and Jira
+  sdek ai plan --framework SOC2 --section CC6.1 \
+      --excerpts-file ./policies/soc2_excerpts.json \
+      --sources github,jira
+
+  # Review at most the 5 most relevant plan items
+  sdek ai plan --framework SOC2 --section CC6.1 \
+      --excerpts-file ./policies/soc2_excerpts.json \
+      --max-items 5
+
+  # Show why each item was proposed and approved, with running budget totals
+  sdek ai plan --framework SOC2 --section CC6.1 \
+      --excerpts-file ./policies/soc2_excerpts.json \
+      --dry-run --explain-plan
+
   # Specify custom output file for finding results
   sdek ai plan --framework SOC2 --section CC6.1 \
       --excerpts-file ./policies/soc2_excerpts.json \
@@ -78,8 +96,8 @@ Note: Budget limits (max sources, API calls, tokens) are configured in config.ya
 			return fmt.Errorf("--excerpts-file is required")
 		}
 
-		// Check excerpts file exists
-		if _, err := os.Stat(excerptsFile); os.IsNotExist(err) {
+		// Check excerpts file exists (URLs are fetched when loading)
+		if _, err := os.Stat(excerptsFile); os.IsNotExist(err) && !isExcerptsURL(excerptsFile) {
 			return fmt.Errorf("excerpts file not found: %s", excerptsFile)
 		}
 
@@ -147,10 +165,14 @@ func init() {
 
 	aiPlanCmd.Flags().String("framework", "", "Framework name (e.g., SOC2, ISO27001, PCI-DSS)")
 	aiPlanCmd.Flags().String("section", "", "Section ID (e.g., CC6.1, A.9.4.2)")
-	aiPlanCmd.Flags().String("excerpts-file", "", "Path to policy excerpts JSON file")
+	aiPlanCmd.Flags().String("excerpts-file", "", "Path, directory or http(s) URL of the policy excerpts (.json or .yaml)")
 	aiPlanCmd.Flags().Bool("dry-run", false, "Preview plan without execution")
-	aiPlanCmd.Flags().Bool("approve-all", false, "Auto-approve all plan items without TUI")
+	aiPlanCmd.Flags().Bool("approve-all", false, "Auto-approve all plan items without TUI, except items with a weak rationale")
 	aiPlanCmd.Flags().String("output", "findings.json", "Output file path for finding results")
+	aiPlanCmd.Flags().StringSlice("sources", nil, "Sources the plan may use (default: enabled connectors and MCP servers)")
+	aiPlanCmd.Flags().Int("max-items", 0, "Keep only the N highest-signal plan items (0 = no cap)")
+	aiPlanCmd.Flags().Bool("explain-plan", false, "Print each plan item's rationale, signal strength, auto-approve decision and running budget totals")
+	viper.BindPFlag("ai.autonomous.maxItems", aiPlanCmd.Flags().Lookup("max-items"))
 
 	aiPlanCmd.MarkFlagRequired("framework")
 	aiPlanCmd.MarkFlagRequired("section")
@@ -167,6 +189,8 @@ func runAIPlan(cmd *cobra.Command, args []string) error {
 	dryRun, _ := cmd.Flags().GetBool("dry-run")
 	approveAll, _ := cmd.Flags().GetBool("approve-all")
 	outputFile, _ := cmd.Flags().GetString("output")
+	sources, _ := cmd.Flags().GetStringSlice("sources")
+	explainPlan, _ := cmd.Flags().GetBool("explain-plan")
 
 	slog.Info("Starting AI plan generation", "framework", framework, "section", section, "dryRun", dryRun, "approveAll", approveAll)
 
@@ -186,17 +210,18 @@ func runAIPlan(cmd *cobra.Command, args []string) error {
 	// Find the specific excerpt for this framework and section
 	excerpt, found := findExcerpt(excerpts, framework, section)
 	if !found {
-		return fmt.Errorf("no excerpt found for framework=%s section=%s in %s", framework, section, excerptsFile)
+		return fmt.Errorf("no excerpt found for framework=%s section=%s in %s%s", framework, section, excerptsFile, excerptSuggestion(excerpts, framework, section))
 	}
 
 	// Step 3: Build context preamble
 	slog.Info("Building context preamble", "framework", framework, "section", section)
-	preamble, err := types.NewContextPreamble(
+	preamble, err := types.NewContextPreambleWithRubrics(
 		framework,
-		excerpt.Version,
+		excerptVersion(excerpt, cfg.Frameworks),
 		section,
 		excerpt.Text,
 		excerpt.RelatedSections,
+		cfg.Frameworks.RubricsFor(framework),
 	)
 	if err != nil {
 		return fmt.Errorf("failed to create context preamble: %w", err)
@@ -207,12 +232,13 @@ func runAIPlan(cmd *cobra.Command, args []string) error {
 
 	// Build provider configuration
 	providerConfig := types.ProviderConfig{
-		APIKey:      cfg.AI.APIKey,
-		Model:       cfg.AI.Model,
-		MaxTokens:   cfg.AI.MaxTokens,
-		Temperature: float64(cfg.AI.Temperature),
-		Timeout:     cfg.AI.Timeout,
-		MaxRetries:  3, // Default retries
+		APIKey:       cfg.AI.APIKey,
+		Model:        cfg.AI.Model,
+		MaxTokens:    cfg.AI.MaxTokens,
+		Temperature:  float64(cfg.AI.Temperature),
+		Timeout:      cfg.AI.Timeout,
+		MaxRetries:   3, // Default retries
+		SystemPrompt: cfg.AI.SystemPrompt,
 	}
 
 	// Set defaults
@@ -225,6 +251,7 @@ func runAIPlan(cmd *cobra.Command, args []string) error {
 	if providerConfig.Temperature == 0 {
 		providerConfig.Temperature = 0.3
 	}
+	applySamplingConfig(cfg, &providerConfig)
 
 	// Determine provider URL
 	providerURL := cfg.AI.ProviderURL
@@ -267,6 +294,11 @@ func runAIPlan(cmd *cobra.Command, args []string) error {
 	if err != nil {
 		return fmt.Errorf("failed to create AI engine: %w", err)
 	}
+	clock, err := outputClock()
+	if err != nil {
+		return err
+	}
+	ai.SetEngineClock(engine, clock)
 
 	// Log enabled connectors
 	if len(cfg.AI.Connectors) > 0 {
@@ -283,13 +315,21 @@ func runAIPlan(cmd *cobra.Command, args []string) error {
 
 	// Step 5: Generate plan
 	slog.Info("Generating evidence collection plan")
-	plan, err := engine.ProposePlan(cmd.Context(), *preamble)
+	planCtx := cmd.Context()
+	if len(sources) > 0 {
+		planCtx = ai.WithPlanSources(planCtx, sources)
+	}
+	plan, err := engine.ProposePlan(planCtx, *preamble)
 	if err != nil {
 		return fmt.Errorf("failed to generate plan: %w", err)
 	}
 
 	slog.Info("Plan generated", "items", len(plan.Items), "autoApproved", countAutoApproved(plan))
 
+	if explainPlan {
+		printPlanExplanation(cmd.OutOrStdout(), ai.ExplainPlan(plan, cfg.AI))
+	}
+
 	// Step 6: Handle dry-run
 	if dryRun {
 		fmt.Println("\n=== Evidence Collection Plan (Dry Run) ===")
@@ -303,6 +343,8 @@ func runAIPlan(cmd *cobra.Command, args []string) error {
 			status := "pending"
 			if item.ApprovalStatus == types.ApprovalAutoApproved {
 				status = "auto-approved ✓"
+			} else if item.WeakRationale {
+				status = "pending ⚠ weak rationale"
 			}
 			fmt.Printf("  %d. [%s] %s: %s\n", i+1, status, item.Source, item.Query)
 			fmt.Printf("     Signal: %.2f, Rationale: %s\n", item.SignalStrength, item.Rationale)
@@ -313,12 +355,11 @@ func runAIPlan(cmd *cobra.Command, args []string) error {
 
 	// Step 7: Get approval (TUI or auto-approve)
 	if approveAll {
-		// Auto-approve all items
-		for i := range plan.Items {
-			plan.Items[i].ApprovalStatus = types.ApprovalApproved
+		approved, weak := approveAllItems(plan)
+		slog.Info("Auto-approved plan items", "count", approved)
+		if weak > 0 {
+			slog.Warn("Left plan items with a weak rationale pending; approve them in the TUI", "count", weak)
 		}
-		plan.Status = types.PlanApproved
-		slog.Info("Auto-approved all plan items", "count", len(plan.Items))
 	} else {
 		// Launch TUI for interactive approval
 		model := components.NewPlanApproval(plan)
@@ -339,7 +380,9 @@ func runAIPlan(cmd *cobra.Command, args []string) error {
 		slog.Info("Plan approved", "approved", approvedCount, "total", len(plan.Items))
 	}
 
-	// Step 8: Execute plan
+	// Step 8: Show the projected cost of the approved items, then execute the plan
+	fmt.Printf("\n💰 %s\n", formatPlanCostEstimate(engine.EstimatePlanCost(plan)))
+
 	slog.Info("Executing evidence collection plan")
 	bundle, err := engine.ExecutePlan(cmd.Context(), plan)
 	if err != nil {
@@ -363,7 +406,7 @@ func runAIPlan(cmd *cobra.Command, args []string) error {
 	finding.Mode = "autonomous"
 
 	// Step 11: Export finding
-	if err := exportFinding(finding, outputFile); err != nil {
+	if err := exportFinding(finding, outputFile, cfg.Export.Mode()); err != nil {
 		return fmt.Errorf("failed to export finding: %w", err)
 	}
 
@@ -394,6 +437,23 @@ func runAIPlan(cmd *cobra.Command, args []string) error {
 
 // Helper functions
 
+// approveAllItems approves every plan item for --approve-all except those with
+// a weak rationale, which stay pending as they need manual review. It returns
+// how many items were approved and how many were left pending.
+func approveAllItems(plan *types.EvidencePlan) (approved, weak int) {
+	for i := range plan.Items {
+		if plan.Items[i].WeakRationale {
+			plan.Items[i].ApprovalStatus = types.ApprovalPending
+			weak++
+			continue
+		}
+		plan.Items[i].ApprovalStatus = types.ApprovalApproved
+		approved++
+	}
+	plan.Status = types.PlanApproved
+	return approved, weak
+}
+
 func countAutoApproved(plan *types.EvidencePlan) int {
 	count := 0
 	for _, item := range plan.Items {
@@ -425,6 +485,50 @@ func countExecuted(plan *types.EvidencePlan) int {
 	return count
 }
 
+// printPlanExplanation prints why each plan item was proposed and approved,
+// and the budget used as items are added
+func printPlanExplanation(w io.Writer, explanation ai.PlanExplanation) {
+	fmt.Fprintln(w, "\n=== Plan Explanation ===")
+	for i, item := range explanation.Items {
+		fmt.Fprintf(w, "  %d. %s: %s\n", i+1, item.Source, item.Query)
+		rationale := item.Rationale
+		if strings.TrimSpace(rationale) == "" {
+			rationale = "(none)"
+		}
+		fmt.Fprintf(w, "     Rationale: %s\n", rationale)
+		fmt.Fprintf(w, "     Signal:    %.2f\n", item.SignalStrength)
+		fmt.Fprintf(w, "     Approval:  %s\n", item.Approval)
+		fmt.Fprintf(w, "     Budget:    items %s, API calls %s, tokens ~%s\n",
+			formatBudgetUsage(item.Items, explanation.Budgets.MaxSources),
+			formatBudgetUsage(item.Calls, explanation.Budgets.MaxAPICalls),
+			formatBudgetUsage(item.Tokens, explanation.Budgets.MaxTokens))
+	}
+}
+
+// formatBudgetUsage renders used against a budget limit as "3/50", or
+// "3 (no limit)" when the limit is 0
+func formatBudgetUsage(used, limit int) string {
+	if limit <= 0 {
+		return fmt.Sprintf("%d (no limit)", used)
+	}
+	return fmt.Sprintf("%d/%d", used, limit)
+}
+
+// formatPlanCostEstimate renders an estimate as
+// "Estimated cost: 2 approved item(s), 2 API call(s), ~9000 tokens, $0.27 (gpt-4)"
+func formatPlanCostEstimate(estimate ai.PlanCostEstimate) string {
+	cost := fmt.Sprintf("$%.2f", estimate.CostUSD)
+	if !estimate.Priced {
+		cost = "price unknown"
+	}
+	model := estimate.Model
+	if model == "" {
+		model = "default model"
+	}
+	return fmt.Sprintf("Estimated cost: %d approved item(s), %d API call(s), ~%d tokens, %s (%s)",
+		estimate.ApprovedItems, estimate.APICalls, estimate.Tokens, cost, model)
+}
+
 func confidenceLevel(score float64) string {
 	if score >= 0.8 {
 		return "high"
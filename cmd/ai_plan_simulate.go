@@ -0,0 +1,136 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"text/tabwriter"
+
+	"github.com/pickjonathan/sdek-cli/internal/ai"
+	"github.com/pickjonathan/sdek-cli/internal/ai/connectors"
+	"github.com/pickjonathan/sdek-cli/pkg/types"
+	"github.com/spf13/cobra"
+)
+
+// aiPlanSimulateCmd represents the 'sdek ai plan simulate' command
+var aiPlanSimulateCmd = &cobra.Command{
+	Use:   "simulate",
+	Short: "Estimate what a plan would collect using local evidence",
+	Long: `Run the items of an evidence collection plan against an existing evidence
+snapshot instead of live connectors, and report how many events each item's
+query matches.
+
+Queries are matched locally: free-text terms must appear in an event's
+content, since:/until: (or created:) bound its timestamp, and field:value
+filters match the event's type, source or metadata. Filters on fields the
+local evidence doesn't carry are ignored, so hit counts are estimates. No AI
+provider or connector is contacted.`,
+	Example: `  # Estimate hits for each plan item against saved evidence
+  sdek ai plan simulate --in plan.json --evidence-path ./evidence/*.json
+
+  # Emit the per-item results as JSON
+  sdek ai plan simulate --in plan.json --evidence-path ./evidence/*.json --format json`,
+	PreRunE: func(cmd *cobra.Command, args []string) error {
+		in, _ := cmd.Flags().GetString("in")
+		evidencePaths, _ := cmd.Flags().GetStringSlice("evidence-path")
+		format, _ := cmd.Flags().GetString("format")
+
+		if in == "" {
+			return fmt.Errorf("--in is required")
+		}
+		if len(evidencePaths) == 0 {
+			return fmt.Errorf("--evidence-path is required (at least one path)")
+		}
+		if format != "text" && format != "json" {
+			return fmt.Errorf("invalid format '%s', must be one of: text, json", format)
+		}
+		for _, path := range evidencePaths {
+			if path == stdinEvidencePath {
+				continue
+			}
+			matches, err := filepath.Glob(path)
+			if err != nil {
+				return fmt.Errorf("invalid evidence path pattern: %s: %w", path, err)
+			}
+			if len(matches) == 0 {
+				return fmt.Errorf("no files match evidence path: %s", path)
+			}
+		}
+		return nil
+	},
+	RunE: runAIPlanSimulate,
+}
+
+func init() {
+	aiPlanCmd.AddCommand(aiPlanSimulateCmd)
+
+	aiPlanSimulateCmd.Flags().String("in", "", "Plan JSON file to simulate")
+	aiPlanSimulateCmd.Flags().StringSlice("evidence-path", []string{}, "Evidence file paths (supports globs, can be specified multiple times; - reads stdin)")
+	aiPlanSimulateCmd.Flags().String("format", "text", "Output format: text or json")
+}
+
+func runAIPlanSimulate(cmd *cobra.Command, args []string) error {
+	in, _ := cmd.Flags().GetString("in")
+	evidencePaths, _ := cmd.Flags().GetStringSlice("evidence-path")
+	format, _ := cmd.Flags().GetString("format")
+
+	plan, err := loadPlan(in)
+	if err != nil {
+		return err
+	}
+
+	cfg, err := loadConfig()
+	if err != nil {
+		return err
+	}
+
+	evidence, err := loadEvidenceFromPaths(evidencePaths, cmd.InOrStdin(), cfg.AI)
+	if err != nil {
+		return fmt.Errorf("failed to load evidence: %w", err)
+	}
+
+	sim, err := ai.SimulatePlan(cmd.Context(), plan, connectors.NewFilesystemConnector(evidence.Events))
+	if err != nil {
+		return fmt.Errorf("simulation failed: %w", err)
+	}
+
+	out := cmd.OutOrStdout()
+	if format == "json" {
+		return writeJSON(out, sim)
+	}
+
+	fmt.Fprintf(out, "Simulating %s %s against %d local event(s)\n\n", plan.Framework, plan.Section, sim.Events)
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "#\tSOURCE\tHITS\tSTATUS\tQUERY")
+	for i, item := range sim.Items {
+		hits := fmt.Sprintf("%d", item.Hits)
+		if item.Error != "" {
+			hits = "error: " + item.Error
+		}
+		fmt.Fprintf(w, "%d\t%s\t%s\t%s\t%s\n", i+1, item.Source, hits, item.ApprovalStatus, item.Query)
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(out, "\n%d of %d event(s) matched by at least one item\n", sim.UniqueEvents, sim.Events)
+	return nil
+}
+
+// loadPlan reads an evidence plan from a JSON file
+func loadPlan(path string) (*types.EvidencePlan, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read plan: %w", err)
+	}
+
+	var plan types.EvidencePlan
+	if err := json.Unmarshal(data, &plan); err != nil {
+		return nil, fmt.Errorf("failed to parse plan %s: %w", path, err)
+	}
+	if len(plan.Items) == 0 {
+		return nil, fmt.Errorf("plan %s has no items", path)
+	}
+	return &plan, nil
+}
@@ -0,0 +1,73 @@
+package cmd
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/pickjonathan/sdek-cli/internal/ai"
+	"github.com/pickjonathan/sdek-cli/pkg/types"
+)
+
+func TestPrintPlanExplanation(t *testing.T) {
+	explanation := ai.PlanExplanation{
+		Budgets: types.BudgetLimits{MaxSources: 10, MaxAPICalls: 20},
+		Items: []ai.PlanItemExplanation{
+			{
+				Source: "github", Query: "MFA enforcement", Rationale: "PRs enabling MFA", SignalStrength: 0.8,
+				Approval: `auto-approved: query matched pattern "*mfa*" for github`, Pattern: "*mfa*",
+				Items: 1, Calls: 1, Tokens: 4800,
+			},
+			{
+				Source: "jira", Query: "access review", SignalStrength: 0.6,
+				Approval: "needs manual approval: no auto-approve patterns for jira",
+				Items:    2, Calls: 2, Tokens: 8400,
+			},
+		},
+	}
+
+	var out bytes.Buffer
+	printPlanExplanation(&out, explanation)
+	got := out.String()
+
+	for _, want := range []string{
+		"1. github: MFA enforcement",
+		"Signal:    0.80",
+		`auto-approved: query matched pattern "*mfa*" for github`,
+		"Budget:    items 1/10, API calls 1/20, tokens ~4800 (no limit)",
+		"Rationale: (none)",
+		"Budget:    items 2/10, API calls 2/20, tokens ~8400 (no limit)",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("explanation missing %q:\n%s", want, got)
+		}
+	}
+}
+
+func TestApproveAllItems_LeavesWeakRationalePending(t *testing.T) {
+	plan := &types.EvidencePlan{
+		Status: types.PlanPending,
+		Items: []types.PlanItem{
+			{Source: "github", Query: "mfa", Rationale: "MFA enforcement commits show CC6.1 coverage", ApprovalStatus: types.ApprovalPending},
+			{Source: "jira", Query: "access", Rationale: "", WeakRationale: true, ApprovalStatus: types.ApprovalPending},
+			{Source: "aws", Query: "iam", Rationale: "IAM policy changes", ApprovalStatus: types.ApprovalAutoApproved},
+		},
+	}
+
+	approved, weak := approveAllItems(plan)
+
+	if approved != 2 || weak != 1 {
+		t.Errorf("approveAllItems() = %d approved, %d weak, want 2 and 1", approved, weak)
+	}
+	if got := plan.Items[1].ApprovalStatus; got != types.ApprovalPending {
+		t.Errorf("weak rationale item status = %s, want %s", got, types.ApprovalPending)
+	}
+	for _, i := range []int{0, 2} {
+		if got := plan.Items[i].ApprovalStatus; got != types.ApprovalApproved {
+			t.Errorf("item %d status = %s, want %s", i, got, types.ApprovalApproved)
+		}
+	}
+	if plan.Status != types.PlanApproved {
+		t.Errorf("plan status = %s, want %s", plan.Status, types.PlanApproved)
+	}
+}
@@ -0,0 +1,90 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/pickjonathan/sdek-cli/internal/ai"
+	"github.com/pickjonathan/sdek-cli/internal/report"
+	"github.com/spf13/cobra"
+)
+
+// aiReplayCmd represents the 'sdek ai replay' command
+var aiReplayCmd = &cobra.Command{
+	Use:   "replay",
+	Short: "Re-generate findings from the audit log without calling providers",
+	Long: `Reconstruct findings from recorded AI analysis calls so audits are
+reproducible offline.
+
+Each successful analysis in the audit log references the cache entry that
+holds its response. Replay reads those entries and rebuilds the findings
+without contacting any provider. Caching must have been enabled when the
+audit log was written; replay fails if a recorded response is missing.`,
+	Example: `  # Replay the configured audit log
+  sdek ai replay
+
+  # Replay a specific audit log into a findings file
+  sdek ai replay --audit-log ~/.sdek/audit/ai.jsonl --output replayed.json`,
+	RunE: runAIReplay,
+}
+
+var (
+	replayAuditLog string
+	replayCacheDir string
+	replayOutput   string
+)
+
+func init() {
+	aiCmd.AddCommand(aiReplayCmd)
+
+	aiReplayCmd.Flags().StringVar(&replayAuditLog, "audit-log", "", "Audit log to replay (default: audit_log.path from config)")
+	aiReplayCmd.Flags().StringVar(&replayCacheDir, "cache-dir", "", "AI cache directory holding recorded responses (default: ai.cache_dir from config)")
+	aiReplayCmd.Flags().StringVar(&replayOutput, "output", "findings.json", "Output file for replayed findings")
+}
+
+func runAIReplay(cmd *cobra.Command, args []string) error {
+	cfg, err := loadConfig()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	auditLog := replayAuditLog
+	if auditLog == "" {
+		auditLog = cfg.AuditLog.Path
+	}
+	if auditLog == "" {
+		return fmt.Errorf("no audit log specified, use --audit-log or set audit_log.path in config")
+	}
+
+	cacheDir := replayCacheDir
+	if cacheDir == "" {
+		cacheDir = cfg.AI.CacheDir
+	}
+
+	entries, err := ai.ReadAuditLog(auditLog)
+	if err != nil {
+		return err
+	}
+
+	cache, err := ai.NewCache(cacheDir)
+	if err != nil {
+		return fmt.Errorf("failed to open cache: %w", err)
+	}
+
+	findings, err := ai.ReplayAuditLog(entries, cache)
+	if err != nil {
+		return fmt.Errorf("replay failed: %w", err)
+	}
+
+	data, err := json.MarshalIndent(findings, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal findings: %w", err)
+	}
+	if err := report.WriteFile(replayOutput, data, exportFileMode()); err != nil {
+		return fmt.Errorf("failed to write findings: %w", err)
+	}
+
+	fmt.Printf("✓ Replayed %d finding(s) from %d audit entries\n", len(findings), len(entries))
+	fmt.Printf("  Output: %s\n", replayOutput)
+	return nil
+}
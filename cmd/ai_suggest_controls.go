@@ -0,0 +1,161 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"path/filepath"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/pickjonathan/sdek-cli/internal/analyze"
+	"github.com/pickjonathan/sdek-cli/internal/i18n"
+	"github.com/pickjonathan/sdek-cli/pkg/types"
+	"github.com/spf13/cobra"
+)
+
+// aiSuggestControlsCmd represents the 'sdek ai suggest-controls' command
+var aiSuggestControlsCmd = &cobra.Command{
+	Use:   "suggest-controls",
+	Short: "Suggest which controls evidence supports, across all frameworks",
+	Long: `Map evidence against every control of every built-in framework and list
+the candidate controls it supports, ranked by confidence.
+
+Use this when you have evidence but don't know which section to analyze it
+against. Candidates come from keyword matching; with --ai each candidate
+control is re-analyzed by the configured AI provider (as in 'sdek analyze
+--ai'), and controls the provider doesn't confirm keep their heuristic score.`,
+	Example: `  # Which controls does this evidence support?
+  sdek ai suggest-controls --evidence-path firewall-change.json
+
+  # Refine the candidates with the AI provider and show all of them
+  sdek ai suggest-controls --evidence-path ./evidence/*.json --ai --limit 0
+
+  # Emit the ranked candidates as JSON
+  sdek ai suggest-controls --evidence-path firewall-change.json --format json`,
+	PreRunE: func(cmd *cobra.Command, args []string) error {
+		evidencePaths, _ := cmd.Flags().GetStringSlice("evidence-path")
+		format, _ := cmd.Flags().GetString("format")
+		limit, _ := cmd.Flags().GetInt("limit")
+
+		if len(evidencePaths) == 0 {
+			return fmt.Errorf("--evidence-path is required (at least one path)")
+		}
+		if format != "text" && format != "json" {
+			return fmt.Errorf("invalid format '%s', must be one of: text, json", format)
+		}
+		if limit < 0 {
+			return fmt.Errorf("--limit cannot be negative, got %d", limit)
+		}
+		for _, path := range evidencePaths {
+			if path == stdinEvidencePath {
+				continue
+			}
+			matches, err := filepath.Glob(path)
+			if err != nil {
+				return fmt.Errorf("invalid evidence path pattern: %s: %w", path, err)
+			}
+			if len(matches) == 0 {
+				return fmt.Errorf("no files match evidence path: %s", path)
+			}
+		}
+		return nil
+	},
+	RunE: runAISuggestControls,
+}
+
+func init() {
+	aiCmd.AddCommand(aiSuggestControlsCmd)
+
+	aiSuggestControlsCmd.Flags().StringSlice("evidence-path", []string{}, "Evidence file paths (supports globs, can be specified multiple times; - reads stdin)")
+	aiSuggestControlsCmd.Flags().Bool("ai", false, "Refine the candidate controls with the configured AI provider")
+	aiSuggestControlsCmd.Flags().Int("limit", 10, "Maximum number of controls to list (0 = all)")
+	aiSuggestControlsCmd.Flags().String("format", "text", "Output format: text or json")
+}
+
+func runAISuggestControls(cmd *cobra.Command, args []string) error {
+	evidencePaths, _ := cmd.Flags().GetStringSlice("evidence-path")
+	useAI, _ := cmd.Flags().GetBool("ai")
+	limit, _ := cmd.Flags().GetInt("limit")
+	format, _ := cmd.Flags().GetString("format")
+
+	cfg, err := loadConfig()
+	if err != nil {
+		return err
+	}
+
+	evidence, err := loadEvidenceFromPaths(evidencePaths, cmd.InOrStdin(), cfg.AI)
+	if err != nil {
+		return fmt.Errorf("failed to load evidence: %w", err)
+	}
+	if len(evidence.Events) == 0 {
+		return errors.New(messages.T(i18n.ErrorNoEvidenceEvents, strings.Join(evidencePaths, ", ")))
+	}
+
+	mapper := analyze.NewMapper()
+	if useAI {
+		aiMapper, err := initializeAIMapper(cfg)
+		if err != nil {
+			slog.Warn("Failed to initialize AI mapper, falling back to heuristic-only", "error", err)
+		} else {
+			mapper = aiMapper
+		}
+	}
+
+	mapped := mapper.MapEventsToControlsCtx(cmd.Context(), evidenceEventsToEvents(evidence.Events))
+	suggestions := mapper.SuggestControls(mapped)
+	total := len(suggestions)
+	if limit > 0 && len(suggestions) > limit {
+		suggestions = suggestions[:limit]
+	}
+
+	out := cmd.OutOrStdout()
+	if format == "json" {
+		return writeJSON(out, suggestions)
+	}
+
+	if total == 0 {
+		fmt.Fprintf(out, "No controls matched %d evidence event(s)\n", len(evidence.Events))
+		return nil
+	}
+
+	fmt.Fprintf(out, "Candidate controls for %d evidence event(s)\n\n", len(evidence.Events))
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "#\tFRAMEWORK\tCONTROL\tCONFIDENCE\tEVENTS\tTITLE\tMATCHED")
+	for i, s := range suggestions {
+		confidence := fmt.Sprintf("%.0f%%", s.Confidence)
+		if s.AIAnalyzed {
+			confidence += " (ai)"
+		}
+		fmt.Fprintf(w, "%d\t%s\t%s\t%s\t%d\t%s\t%s\n", i+1, s.FrameworkID, s.ControlID, confidence, len(s.EventIDs), s.Title, strings.Join(s.Keywords, ", "))
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+
+	if total > len(suggestions) {
+		fmt.Fprintf(out, "\nShowing %d of %d controls (use --limit 0 to show all)\n", len(suggestions), total)
+	}
+	return nil
+}
+
+// evidenceEventsToEvents converts evidence events to the events the keyword
+// mapper works on. The event type and source carry over; a "title" metadata
+// entry, if any, becomes the event title.
+func evidenceEventsToEvents(evidenceEvents []types.EvidenceEvent) []types.Event {
+	events := make([]types.Event, 0, len(evidenceEvents))
+	for _, ev := range evidenceEvents {
+		title, _ := ev.Metadata["title"].(string)
+		events = append(events, types.Event{
+			ID:        ev.ID,
+			SourceID:  ev.Source,
+			Timestamp: ev.Timestamp,
+			EventType: ev.Type,
+			Title:     title,
+			Content:   ev.Content,
+			Metadata:  ev.Metadata,
+			Labels:    ev.Labels,
+		})
+	}
+	return events
+}
@@ -0,0 +1,77 @@
+package cmd
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/pickjonathan/sdek-cli/internal/analyze"
+	"github.com/spf13/pflag"
+)
+
+// runSuggestControls runs 'sdek ai suggest-controls', clearing --evidence-path
+// afterwards since slice flags accumulate across executions of rootCmd
+func runSuggestControls(t *testing.T, args ...string) (string, error) {
+	t.Helper()
+	t.Cleanup(func() {
+		aiSuggestControlsCmd.Flags().Lookup("evidence-path").Value.(pflag.SliceValue).Replace(nil)
+	})
+	return runFrameworksCommand(t, append([]string{"ai", "suggest-controls"}, args...)...)
+}
+
+func TestAISuggestControls_FirewallEvidenceSuggestsPCIFirewallControl(t *testing.T) {
+	evidencePath := filepath.Join(t.TempDir(), "firewall-change.json")
+	evidence := `[
+		{"id": "gh-1", "source": "github", "type": "pr", "timestamp": "2024-02-01T00:00:00Z", "content": "Tighten firewall rules on the perimeter network"}
+	]`
+	if err := os.WriteFile(evidencePath, []byte(evidence), 0644); err != nil {
+		t.Fatalf("failed to write evidence: %v", err)
+	}
+
+	output, err := runSuggestControls(t, "--evidence-path", evidencePath, "--limit", "10", "--format", "json")
+	if err != nil {
+		t.Fatalf("suggest-controls failed: %v\n%s", err, output)
+	}
+
+	var suggestions []analyze.ControlSuggestion
+	if err := json.Unmarshal([]byte(output), &suggestions); err != nil {
+		t.Fatalf("failed to parse JSON output: %v\n%s", err, output)
+	}
+	if len(suggestions) == 0 {
+		t.Fatal("expected candidate controls for firewall evidence")
+	}
+
+	top := suggestions[0]
+	if top.FrameworkID != "pci_dss" || top.ControlID != "1.1" {
+		t.Errorf("expected PCI DSS 1.1 (Firewall Configuration) to rank first, got %s %s (%s)", top.FrameworkID, top.ControlID, top.Title)
+	}
+	if len(top.EventIDs) != 1 || top.EventIDs[0] != "gh-1" {
+		t.Errorf("expected the suggestion to cite gh-1, got %v", top.EventIDs)
+	}
+	for i := 1; i < len(suggestions); i++ {
+		if suggestions[i].Confidence > suggestions[i-1].Confidence {
+			t.Errorf("suggestions not ranked by confidence: %v then %v", suggestions[i-1].Confidence, suggestions[i].Confidence)
+		}
+	}
+}
+
+func TestAISuggestControls_TextOutputHonorsLimit(t *testing.T) {
+	evidencePath := filepath.Join(t.TempDir(), "firewall-change.json")
+	evidence := `[{"id": "gh-1", "source": "github", "content": "Tighten firewall rules on the perimeter network protecting cardholder data"}]`
+	if err := os.WriteFile(evidencePath, []byte(evidence), 0644); err != nil {
+		t.Fatalf("failed to write evidence: %v", err)
+	}
+
+	output, err := runSuggestControls(t, "--evidence-path", evidencePath, "--limit", "1", "--format", "text")
+	if err != nil {
+		t.Fatalf("suggest-controls failed: %v\n%s", err, output)
+	}
+	if !strings.Contains(output, "Firewall Configuration") {
+		t.Errorf("expected the firewall control in the output, got:\n%s", output)
+	}
+	if !strings.Contains(output, "Showing 1 of ") {
+		t.Errorf("expected a note that the list was truncated, got:\n%s", output)
+	}
+}
@@ -34,7 +34,13 @@ compliance posture analysis.`,
   sdek analyze
 
   # Run analysis with verbose logging
-  sdek analyze --verbose`,
+  sdek analyze --verbose
+
+  # Discard evidence mappings below 60% confidence
+  sdek analyze --min-confidence 0.6
+
+  # Use the control_id tagged on each event instead of keyword matching
+  sdek analyze --pre-mapped`,
 	RunE: runAnalyze,
 }
 
@@ -45,6 +51,9 @@ var (
 	aiCacheDir string
 	aiTimeout  int
 	noAI       bool
+
+	analyzeMinConf   float64
+	analyzePreMapped bool
 )
 
 func init() {
@@ -57,11 +66,18 @@ func init() {
 	analyzeCmd.Flags().StringVar(&aiCacheDir, "cache-dir", "", "AI cache directory (overrides config)")
 	analyzeCmd.Flags().IntVar(&aiTimeout, "ai-timeout", 0, "AI request timeout in seconds (overrides config)")
 	analyzeCmd.Flags().BoolVar(&noAI, "no-ai", false, "Disable AI analysis (force heuristic-only)")
+
+	analyzeCmd.Flags().Float64Var(&analyzeMinConf, "min-confidence", 0, "Discard evidence mappings below this confidence (0-1)")
+	analyzeCmd.Flags().BoolVar(&analyzePreMapped, "pre-mapped", false, "Skip keyword mapping and use the control_id (and optional framework_id) in each event's metadata")
 }
 
 func runAnalyze(cmd *cobra.Command, args []string) error {
 	slog.Info("Starting analyze command")
 
+	if analyzeMinConf < 0 || analyzeMinConf > 1 {
+		return fmt.Errorf("invalid min-confidence %.2f, must be between 0 and 1", analyzeMinConf)
+	}
+
 	// Load existing state
 	state, err := store.Load()
 	if err != nil {
@@ -102,7 +118,18 @@ func runAnalyze(cmd *cobra.Command, args []string) error {
 		mapper = analyze.NewMapper()
 	}
 
-	evidence := mapper.MapEventsToControls(state.Events)
+	var evidence []types.Evidence
+	if analyzePreMapped {
+		slog.Info("Using pre-mapped evidence, skipping keyword mapping")
+		evidence = mapper.MapPreMappedEventsCtx(cmd.Context(), state.Events)
+	} else {
+		evidence = mapper.MapEventsToControlsCtx(cmd.Context(), state.Events)
+	}
+	if analyzeMinConf > 0 {
+		before := len(evidence)
+		evidence = filterEvidenceByConfidence(evidence, analyzeMinConf)
+		slog.Info("Discarded low-confidence evidence", "min", analyzeMinConf, "discarded", before-len(evidence))
+	}
 	state.Evidence = evidence
 
 	slog.Info("Generated evidence mappings", "count", len(evidence))
@@ -339,6 +366,9 @@ func initializeAIMapper(config *types.Config) (*analyze.Mapper, error) {
 		return nil, fmt.Errorf("unsupported AI provider: %s", provider)
 	}
 	providerConfig.APIKey = apiKey
+	if config != nil {
+		providerConfig.SystemPrompt = config.AI.SystemPrompt
+	}
 
 	// Determine provider URL
 	var providerURL string
@@ -393,3 +423,14 @@ func initializeAIMapper(config *types.Config) (*analyze.Mapper, error) {
 
 	return mapper, nil
 }
+
+// filterEvidenceByConfidence returns evidence whose confidence score (0-100) is at least min (0-1)
+func filterEvidenceByConfidence(evidence []types.Evidence, min float64) []types.Evidence {
+	result := make([]types.Evidence, 0, len(evidence))
+	for _, ev := range evidence {
+		if ev.ConfidenceScore >= min*100 {
+			result = append(result, ev)
+		}
+	}
+	return result
+}
@@ -83,6 +83,9 @@ var configInitCmd = &cobra.Command{
 log-level: info
 verbose: false
 
+# Language of printed summaries and hints (en, es; also $SDEK_LANG)
+language: en
+
 # Data directory
 data-dir: ~/.sdek
 
@@ -110,6 +113,7 @@ sources:
 ui:
   theme: dark
   refresh-interval: 5s
+  interactive: true
 `
 
 		// Write default configuration
@@ -0,0 +1,42 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/pickjonathan/sdek-cli/internal/ai"
+	"github.com/pickjonathan/sdek-cli/internal/i18n"
+)
+
+// errorGuidance tells the user whether retrying a failed command makes sense,
+// based on the provider error behind err. It returns "" for errors that are
+// not provider errors.
+func errorGuidance(err error) string {
+	switch {
+	case errors.Is(err, ai.ErrProviderRateLimit):
+		return messages.T(i18n.ErrorRateLimit)
+	case errors.Is(err, ai.ErrProviderTimeout):
+		return messages.T(i18n.ErrorTimeout)
+	case errors.Is(err, ai.ErrProviderUnavailable):
+		return messages.T(i18n.ErrorUnavailable)
+	case errors.Is(err, ai.ErrProviderAuth):
+		return messages.T(i18n.ErrorAuth)
+	case errors.Is(err, ai.ErrProviderQuotaExceeded):
+		return messages.T(i18n.ErrorQuotaExceeded)
+	case errors.Is(err, ai.ErrInvalidJSON):
+		return messages.T(i18n.ErrorInvalidJSON)
+	case ai.IsRetryable(err):
+		return messages.T(i18n.ErrorRetryable)
+	case ai.IsFatalError(err):
+		return messages.T(i18n.ErrorFatal)
+	}
+	return ""
+}
+
+// printErrorGuidance writes the retry guidance for err, if any, to w
+func printErrorGuidance(w io.Writer, err error) {
+	if guidance := errorGuidance(err); guidance != "" {
+		fmt.Fprintln(w, messages.T(i18n.ErrorHint, guidance))
+	}
+}
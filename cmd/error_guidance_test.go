@@ -0,0 +1,61 @@
+package cmd
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/pickjonathan/sdek-cli/internal/ai"
+)
+
+// TestErrorGuidance verifies provider errors map to retry or fix guidance
+func TestErrorGuidance(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want string
+	}{
+		{"rate limit", ai.ErrProviderRateLimit, "This is transient"},
+		{"wrapped rate limit", fmt.Errorf("AI analysis failed: %w", ai.ErrProviderRateLimit), "This is transient"},
+		{"provider error rate limit", ai.NewProviderError(ai.CodeProviderRateLimit, "openai", nil), "This is transient"},
+		{"timeout", ai.ErrProviderTimeout, "This is transient"},
+		{"unavailable", ai.ErrProviderUnavailable, "This is transient"},
+		{"auth", ai.ErrProviderAuth, "fix your API key"},
+		{"wrapped auth", fmt.Errorf("AI analysis failed: %w", ai.NewProviderError(ai.CodeProviderAuth, "anthropic", errors.New("401"))), "fix your API key"},
+		{"quota", ai.ErrProviderQuotaExceeded, "Retrying won't help"},
+		{"invalid request", ai.ErrInvalidRequest, "Retrying won't help"},
+		{"unrelated", errors.New("failed to load state"), ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := errorGuidance(tt.err)
+			if tt.want == "" {
+				if got != "" {
+					t.Errorf("errorGuidance() = %q, want no guidance", got)
+				}
+				return
+			}
+			if !strings.Contains(got, tt.want) {
+				t.Errorf("errorGuidance() = %q, want it to contain %q", got, tt.want)
+			}
+		})
+	}
+}
+
+// TestPrintErrorGuidance verifies guidance is printed only for classified errors
+func TestPrintErrorGuidance(t *testing.T) {
+	var buf bytes.Buffer
+	printErrorGuidance(&buf, ai.ErrProviderAuth)
+	if !strings.HasPrefix(buf.String(), "Hint: Retrying won't help") {
+		t.Errorf("Expected auth hint, got %q", buf.String())
+	}
+
+	buf.Reset()
+	printErrorGuidance(&buf, errors.New("boom"))
+	if buf.Len() != 0 {
+		t.Errorf("Expected no output for an unclassified error, got %q", buf.String())
+	}
+}
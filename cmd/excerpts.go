@@ -0,0 +1,349 @@
+package cmd
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"io/fs"
+	"log/slog"
+	"net/http"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/pickjonathan/sdek-cli/pkg/types"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+)
+
+// maxExcerptsBytes caps the size of an excerpts file fetched from a URL
+const maxExcerptsBytes = 10 << 20 // 10 MiB
+
+var excerptsCmd = &cobra.Command{
+	Use:   "excerpts",
+	Short: "Work with policy excerpt files",
+	Long: `Work with the policy excerpt files passed to 'sdek ai analyze',
+'sdek ai analyze-all' and 'sdek ai plan' with --excerpts-file.`,
+}
+
+var excerptsValidateCmd = &cobra.Command{
+	Use:   "validate <file-dir-or-url>",
+	Short: "Check a policy excerpts file for problems",
+	Long: `Check a policy excerpts file before using it for analysis.
+
+Every excerpt needs a section and text, and a section may appear only once per
+framework. Excerpts without a version are reported as warnings, since the
+version then comes from frameworks.versions. The command exits with an error
+if any problem is found.
+
+A directory is checked as the merged set of its .json and .yaml files. An
+http(s) URL is fetched the same way as for --excerpts-file, using the
+excerpts.headers, excerpts.timeout and excerpts.cache_* settings.`,
+	Example: `  # Check an excerpts file
+  sdek excerpts validate ./policies/soc2_excerpts.json
+
+  # Check a directory of excerpt files
+  sdek excerpts validate ./policies/
+
+  # Check centrally hosted excerpts
+  sdek excerpts validate https://policies.example.com/soc2_excerpts.json`,
+	Args: cobra.ExactArgs(1),
+	RunE: runExcerptsValidate,
+}
+
+func init() {
+	rootCmd.AddCommand(excerptsCmd)
+	excerptsCmd.AddCommand(excerptsValidateCmd)
+}
+
+func runExcerptsValidate(cmd *cobra.Command, args []string) error {
+	path := args[0]
+
+	excerpts, err := readExcerpts(path)
+	if err != nil {
+		return fmt.Errorf("%s: %w", path, err)
+	}
+
+	out := cmd.OutOrStdout()
+	problems, warnings := validateExcerpts(excerpts)
+	for _, warning := range warnings {
+		fmt.Fprintf(out, "⚠️  %s\n", warning)
+	}
+	for _, problem := range problems {
+		fmt.Fprintf(out, "✗ %s\n", problem)
+	}
+	if len(problems) > 0 {
+		return fmt.Errorf("%s has %d problem(s)", path, len(problems))
+	}
+
+	fmt.Fprintf(out, "✓ %s: %d excerpt(s) are valid\n", path, len(excerpts))
+	return nil
+}
+
+// validateExcerpts checks that every excerpt has a section and text and that no
+// section appears twice for the same framework. It returns the problems that
+// make the file unusable and warnings about excerpts that rely on defaults.
+func validateExcerpts(excerpts []Excerpt) (problems, warnings []string) {
+	if len(excerpts) == 0 {
+		return []string{"no excerpts found"}, nil
+	}
+
+	legacy := false
+	for i, e := range excerpts {
+		label := excerptLabel(i, e)
+		if strings.TrimSpace(e.Section) == "" {
+			problems = append(problems, label+": section is required")
+		}
+		if strings.TrimSpace(e.Text) == "" {
+			problems = append(problems, label+": text is required")
+		}
+		if e.legacy {
+			legacy = true
+		} else if e.Version == "" {
+			warnings = append(warnings, label+": no version; frameworks.versions or the framework default is used")
+		}
+
+		for j := 0; j < i; j++ {
+			prev := excerpts[j]
+			if e.Section != "" && prev.Section == e.Section && types.SameFramework(prev.Framework, e.Framework) {
+				problems = append(problems, fmt.Sprintf("%s: duplicate section, also defined by excerpt %d", label, j+1))
+				break
+			}
+		}
+	}
+
+	if legacy {
+		warnings = append(warnings, "legacy map format has no framework or version; the framework comes from --framework")
+	}
+	return problems, warnings
+}
+
+// excerptLabel identifies the excerpt at index i in messages
+func excerptLabel(i int, e Excerpt) string {
+	name := strings.TrimSpace(e.Framework + " " + e.Section)
+	if name == "" {
+		return fmt.Sprintf("excerpt %d", i+1)
+	}
+	return fmt.Sprintf("excerpt %d (%s)", i+1, name)
+}
+
+// readExcerpts reads excerpts from a file, an http(s) URL or, for a directory,
+// every excerpt file within it
+func readExcerpts(path string) ([]Excerpt, error) {
+	if !isExcerptsURL(path) {
+		if info, err := os.Stat(path); err == nil && info.IsDir() {
+			return readExcerptsDir(path)
+		}
+	}
+
+	data, err := readExcerptsSource(path)
+	if err != nil {
+		return nil, err
+	}
+	return decodeExcerpts(path, data)
+}
+
+// readExcerptsDir reads every .json and .yaml file under dir in lexical order
+// and merges their excerpts. A section repeated with identical content is kept
+// once; one repeated with different content is an error.
+func readExcerptsDir(dir string) ([]Excerpt, error) {
+	var files []string
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !d.IsDir() && isExcerptsFile(path) {
+			files = append(files, path)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to read directory: %w", err)
+	}
+	if len(files) == 0 {
+		return nil, fmt.Errorf("no .json or .yaml excerpt files found in %s", dir)
+	}
+
+	var merged []Excerpt
+	var origins []string
+	for _, file := range files {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read file: %w", err)
+		}
+		excerpts, err := decodeExcerpts(file, data)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", file, err)
+		}
+
+	next:
+		for _, e := range excerpts {
+			for i, prev := range merged {
+				if prev.Section != e.Section || !types.SameFramework(prev.Framework, e.Framework) {
+					continue
+				}
+				if !sameExcerpt(prev, e) {
+					return nil, fmt.Errorf("conflicting excerpts for %s in %s and %s",
+						strings.TrimSpace(e.Framework+" "+e.Section), origins[i], file)
+				}
+				slog.Debug("Skipping duplicate excerpt", "section", e.Section, "file", file, "first_defined_in", origins[i])
+				continue next
+			}
+			merged = append(merged, e)
+			origins = append(origins, file)
+		}
+	}
+
+	return merged, nil
+}
+
+// sameExcerpt reports whether two excerpts for the same section have the same content
+func sameExcerpt(a, b Excerpt) bool {
+	return a.Version == b.Version &&
+		strings.TrimSpace(a.Text) == strings.TrimSpace(b.Text) &&
+		slices.Equal(a.RelatedSections, b.RelatedSections)
+}
+
+// isExcerptsFile reports whether path has an excerpts file extension
+func isExcerptsFile(path string) bool {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".json", ".yaml", ".yml":
+		return true
+	}
+	return false
+}
+
+// decodeExcerpts parses excerpts read from path, which are YAML when path has
+// a .yaml or .yml extension and JSON otherwise
+func decodeExcerpts(path string, data []byte) ([]Excerpt, error) {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		var excerpts []Excerpt
+		if err := yaml.Unmarshal(data, &excerpts); err != nil {
+			return nil, fmt.Errorf("failed to parse YAML (expected a list of excerpts): %w", err)
+		}
+		return excerpts, nil
+	}
+	return parseExcerpts(data)
+}
+
+// isExcerptsURL reports whether an --excerpts-file value is an http(s) URL
+func isExcerptsURL(path string) bool {
+	return strings.HasPrefix(path, "http://") || strings.HasPrefix(path, "https://")
+}
+
+// readExcerptsSource reads an excerpts file, or fetches it with the excerpts
+// config when path is an http(s) URL
+func readExcerptsSource(path string) ([]byte, error) {
+	if !isExcerptsURL(path) {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read file: %w", err)
+		}
+		return data, nil
+	}
+
+	cfg, err := loadConfig()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load config: %w", err)
+	}
+	return fetchExcerpts(path, cfg.Excerpts)
+}
+
+// fetchExcerpts downloads excerpts from url. A cached copy younger than
+// cfg.CacheTTL is used without fetching, and an older one is used if the fetch
+// fails, so analyses keep working while the policy server is unreachable.
+func fetchExcerpts(url string, cfg types.ExcerptsConfig) ([]byte, error) {
+	cachePath, err := excerptsCachePath(url, cfg.CacheDir)
+	if err != nil {
+		slog.Warn("Excerpts cache unavailable, fetching without it", "error", err)
+	}
+
+	if cachePath != "" && cfg.CacheTTL > 0 {
+		if info, err := os.Stat(cachePath); err == nil && time.Since(info.ModTime()) < time.Duration(cfg.CacheTTL)*time.Second {
+			if data, err := os.ReadFile(cachePath); err == nil {
+				slog.Debug("Using cached excerpts", "url", url, "path", cachePath)
+				return data, nil
+			}
+		}
+	}
+
+	data, err := downloadExcerpts(url, cfg)
+	if err != nil {
+		if cachePath != "" {
+			if cached, cacheErr := os.ReadFile(cachePath); cacheErr == nil {
+				slog.Warn("Failed to fetch excerpts, using cached copy", "url", url, "error", err)
+				return cached, nil
+			}
+		}
+		return nil, err
+	}
+
+	if cachePath != "" {
+		if err := os.MkdirAll(filepath.Dir(cachePath), 0700); err == nil {
+			err = os.WriteFile(cachePath, data, 0600)
+		}
+		if err != nil {
+			slog.Warn("Failed to cache fetched excerpts", "path", cachePath, "error", err)
+		}
+	}
+	return data, nil
+}
+
+// downloadExcerpts performs the HTTP request for fetchExcerpts
+func downloadExcerpts(url string, cfg types.ExcerptsConfig) ([]byte, error) {
+	timeout := cfg.Timeout
+	if timeout <= 0 {
+		timeout = types.DefaultExcerptsTimeout
+	}
+	client := &http.Client{Timeout: time.Duration(timeout) * time.Second}
+
+	req, err := http.NewRequest(http.MethodGet, url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("invalid excerpts URL %s: %w", url, err)
+	}
+	req.Header.Set("Accept", "application/json")
+	for name, value := range cfg.Headers {
+		req.Header.Set(name, os.ExpandEnv(value))
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch excerpts from %s: %w", url, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to fetch excerpts from %s: %s", url, resp.Status)
+	}
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxExcerptsBytes+1))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read excerpts from %s: %w", url, err)
+	}
+	if len(data) > maxExcerptsBytes {
+		return nil, fmt.Errorf("excerpts at %s exceed %d bytes", url, maxExcerptsBytes)
+	}
+	return data, nil
+}
+
+// excerptsCachePath returns the cache file for url in cacheDir, expanding a
+// leading ~, or "" when caching is disabled
+func excerptsCachePath(url, cacheDir string) (string, error) {
+	if cacheDir == "" {
+		return "", nil
+	}
+	if cacheDir == "~" || strings.HasPrefix(cacheDir, "~/") {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return "", fmt.Errorf("failed to expand %s: %w", cacheDir, err)
+		}
+		cacheDir = filepath.Join(home, strings.TrimPrefix(cacheDir, "~"))
+	}
+
+	sum := sha256.Sum256([]byte(url))
+	return filepath.Join(cacheDir, hex.EncodeToString(sum[:])+".json"), nil
+}
@@ -0,0 +1,364 @@
+package cmd
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/pickjonathan/sdek-cli/pkg/types"
+	"github.com/spf13/viper"
+)
+
+// runExcerptsValidateOn writes content to an excerpts file and runs 'sdek excerpts validate' on it
+func runExcerptsValidateOn(t *testing.T, content string) (string, error) {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "excerpts.json")
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatalf("failed to write excerpts: %v", err)
+	}
+
+	rootCmd.SetArgs([]string{"excerpts", "validate", path})
+	buf := new(bytes.Buffer)
+	rootCmd.SetOut(buf)
+	rootCmd.SetErr(buf)
+	err := rootCmd.Execute()
+	return buf.String(), err
+}
+
+func TestExcerptsValidate_ValidFile(t *testing.T) {
+	output, err := runExcerptsValidateOn(t, `[
+		{"framework": "SOC2", "version": "2017", "section": "CC6.1", "text": "Logical access security software is implemented"},
+		{"framework": "SOC2", "version": "2017", "section": "CC6.2", "text": "Users are registered before access is granted"},
+		{"framework": "ISO27001", "version": "2013", "section": "CC6.1", "text": "Same section ID in another framework"}
+	]`)
+	if err != nil {
+		t.Fatalf("excerpts validate failed: %v\n%s", err, output)
+	}
+	if !strings.Contains(output, "3 excerpt(s) are valid") {
+		t.Errorf("expected success summary, got:\n%s", output)
+	}
+	if strings.Contains(output, "⚠️") {
+		t.Errorf("expected no warnings, got:\n%s", output)
+	}
+}
+
+func TestExcerptsValidate_DuplicateSection(t *testing.T) {
+	output, err := runExcerptsValidateOn(t, `[
+		{"framework": "SOC2", "version": "2017", "section": "CC6.1", "text": "Logical access security software is implemented"},
+		{"framework": "SOC 2", "version": "2017", "section": "CC6.1", "text": "A second definition of the same control"}
+	]`)
+	if err == nil {
+		t.Fatalf("expected an error for a duplicate section, got:\n%s", output)
+	}
+	if !strings.Contains(output, "excerpt 2 (SOC 2 CC6.1): duplicate section, also defined by excerpt 1") {
+		t.Errorf("expected duplicate section problem, got:\n%s", output)
+	}
+}
+
+func TestExcerptsValidate_MissingText(t *testing.T) {
+	output, err := runExcerptsValidateOn(t, `[
+		{"framework": "SOC2", "version": "2017", "section": "CC6.1", "text": "Logical access security software is implemented"},
+		{"framework": "SOC2", "version": "2017", "section": "CC6.2", "text": "  "}
+	]`)
+	if err == nil {
+		t.Fatalf("expected an error for missing text, got:\n%s", output)
+	}
+	if !strings.Contains(output, "excerpt 2 (SOC2 CC6.2): text is required") {
+		t.Errorf("expected missing text problem, got:\n%s", output)
+	}
+}
+
+func TestExcerptsValidate_MissingVersionWarns(t *testing.T) {
+	output, err := runExcerptsValidateOn(t, `[
+		{"framework": "SOC2", "section": "CC6.1", "text": "Logical access security software is implemented"}
+	]`)
+	if err != nil {
+		t.Fatalf("a missing version should only warn: %v\n%s", err, output)
+	}
+	if !strings.Contains(output, "excerpt 1 (SOC2 CC6.1): no version") {
+		t.Errorf("expected missing version warning, got:\n%s", output)
+	}
+}
+
+func TestLoadExcerpts_RejectsInvalidFile(t *testing.T) {
+	tests := []struct {
+		name     string
+		excerpts string
+		want     string
+	}{
+		{
+			name:     "missing section",
+			excerpts: `[{"framework": "SOC2", "version": "2017", "text": "Logical access security software is implemented"}]`,
+			want:     "excerpt 1 (SOC2): section is required",
+		},
+		{
+			name:     "legacy entry without text",
+			excerpts: `{"CC6.1": {"control_id": "CC6.1", "title": "Access", "excerpt": ""}}`,
+			want:     "excerpt 1 (CC6.1): text is required",
+		},
+		{
+			name:     "empty file",
+			excerpts: `[]`,
+			want:     "no excerpts found",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "excerpts.json")
+			if err := os.WriteFile(path, []byte(tt.excerpts), 0644); err != nil {
+				t.Fatalf("failed to write excerpts: %v", err)
+			}
+
+			_, err := loadExcerpts(path)
+			if err == nil {
+				t.Fatal("loadExcerpts() expected an error")
+			}
+			if !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("loadExcerpts() error = %v, want it to contain %q", err, tt.want)
+			}
+		})
+	}
+}
+
+const remoteExcerpts = `[
+	{"framework": "SOC2", "version": "2017", "section": "CC6.1", "text": "Logical access security software is implemented"},
+	{"framework": "SOC2", "version": "2017", "section": "CC6.2", "text": "Users are registered before access is granted"}
+]`
+
+// newExcerptsServer serves remoteExcerpts when the request carries the expected
+// Authorization header, counting the requests it receives
+func newExcerptsServer(t *testing.T, status int) (*httptest.Server, *atomic.Int32) {
+	t.Helper()
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		if r.Header.Get("Authorization") != "Bearer policy-token" {
+			http.Error(w, "unauthorized", http.StatusUnauthorized)
+			return
+		}
+		if status != http.StatusOK {
+			http.Error(w, "unavailable", status)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(remoteExcerpts))
+	}))
+	t.Cleanup(server.Close)
+	return server, &requests
+}
+
+func TestFetchExcerpts_SendsHeadersAndCaches(t *testing.T) {
+	server, requests := newExcerptsServer(t, http.StatusOK)
+	t.Setenv("SDEK_TEST_POLICY_TOKEN", "policy-token")
+	cfg := types.ExcerptsConfig{
+		Timeout:  5,
+		CacheDir: t.TempDir(),
+		CacheTTL: 300,
+		Headers:  map[string]string{"Authorization": "Bearer ${SDEK_TEST_POLICY_TOKEN}"},
+	}
+
+	for i := 0; i < 2; i++ {
+		data, err := fetchExcerpts(server.URL+"/soc2.json", cfg)
+		if err != nil {
+			t.Fatalf("fetchExcerpts() error = %v", err)
+		}
+		if string(data) != remoteExcerpts {
+			t.Errorf("fetchExcerpts() = %s, want the served excerpts", data)
+		}
+	}
+
+	if got := requests.Load(); got != 1 {
+		t.Errorf("server received %d requests, want 1 (second fetch should hit the cache)", got)
+	}
+}
+
+func TestFetchExcerpts_RefetchesWithoutCacheTTL(t *testing.T) {
+	server, requests := newExcerptsServer(t, http.StatusOK)
+	cfg := types.ExcerptsConfig{
+		CacheDir: t.TempDir(),
+		Headers:  map[string]string{"Authorization": "Bearer policy-token"},
+	}
+
+	for i := 0; i < 2; i++ {
+		if _, err := fetchExcerpts(server.URL, cfg); err != nil {
+			t.Fatalf("fetchExcerpts() error = %v", err)
+		}
+	}
+
+	if got := requests.Load(); got != 2 {
+		t.Errorf("server received %d requests, want 2", got)
+	}
+}
+
+func TestFetchExcerpts_ErrorStatus(t *testing.T) {
+	tests := []struct {
+		name    string
+		status  int
+		headers map[string]string
+		want    string
+	}{
+		{name: "missing auth header", status: http.StatusOK, want: "401 Unauthorized"},
+		{name: "server error", status: http.StatusServiceUnavailable, headers: map[string]string{"Authorization": "Bearer policy-token"}, want: "503 Service Unavailable"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server, _ := newExcerptsServer(t, tt.status)
+			cfg := types.ExcerptsConfig{CacheDir: t.TempDir(), CacheTTL: 300, Headers: tt.headers}
+
+			_, err := fetchExcerpts(server.URL, cfg)
+			if err == nil {
+				t.Fatal("fetchExcerpts() expected an error")
+			}
+			if !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("fetchExcerpts() error = %v, want it to contain %q", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestFetchExcerpts_FallsBackToStaleCache(t *testing.T) {
+	server, _ := newExcerptsServer(t, http.StatusOK)
+	cfg := types.ExcerptsConfig{
+		CacheDir: t.TempDir(),
+		Headers:  map[string]string{"Authorization": "Bearer policy-token"},
+	}
+	if _, err := fetchExcerpts(server.URL, cfg); err != nil {
+		t.Fatalf("fetchExcerpts() error = %v", err)
+	}
+	url := server.URL
+	server.Close()
+
+	data, err := fetchExcerpts(url, cfg)
+	if err != nil {
+		t.Fatalf("fetchExcerpts() should fall back to the cached copy: %v", err)
+	}
+	if string(data) != remoteExcerpts {
+		t.Errorf("fetchExcerpts() = %s, want the cached excerpts", data)
+	}
+}
+
+func TestLoadExcerpts_FromURL(t *testing.T) {
+	server, _ := newExcerptsServer(t, http.StatusOK)
+	viper.Reset()
+	t.Cleanup(viper.Reset)
+	viper.Set("excerpts.cache_dir", t.TempDir())
+	viper.Set("excerpts.headers", map[string]string{"Authorization": "Bearer policy-token"})
+
+	excerpts, err := loadExcerpts(server.URL + "/soc2.json")
+	if err != nil {
+		t.Fatalf("loadExcerpts() error = %v", err)
+	}
+	if len(excerpts) != 2 || excerpts[0].Section != "CC6.1" || excerpts[1].Section != "CC6.2" {
+		t.Errorf("loadExcerpts() = %+v, want CC6.1 and CC6.2", excerpts)
+	}
+}
+
+// writeExcerptsDir writes files, keyed by relative path, into a new directory
+func writeExcerptsDir(t *testing.T, files map[string]string) string {
+	t.Helper()
+	dir := t.TempDir()
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatalf("failed to create directory: %v", err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatalf("failed to write %s: %v", name, err)
+		}
+	}
+	return dir
+}
+
+func TestLoadExcerpts_Directory(t *testing.T) {
+	dir := writeExcerptsDir(t, map[string]string{
+		"soc2.json": `[
+			{"framework": "SOC2", "version": "2017", "section": "CC6.1", "text": "Logical access security software is implemented"},
+			{"framework": "SOC2", "version": "2017", "section": "CC6.2", "text": "Users are registered before access is granted"}
+		]`,
+		"iso/iso27001.yaml": `
+- framework: ISO27001
+  version: 2013
+  section: A.9.4.2
+  text: Access to systems is controlled by a secure log-on procedure
+  related_sections: [A.9.4.1]
+`,
+		"soc2_more.yml": `
+- framework: SOC 2
+  version: "2017"
+  section: CC6.1
+  text: Logical access security software is implemented
+- framework: SOC2
+  version: "2017"
+  section: CC7.2
+  text: System components are monitored for anomalies
+`,
+		"README.md": "Not an excerpts file",
+	})
+
+	excerpts, err := loadExcerpts(dir)
+	if err != nil {
+		t.Fatalf("loadExcerpts() error = %v", err)
+	}
+
+	var got []string
+	for _, e := range excerpts {
+		got = append(got, e.Framework+" "+e.Version+" "+e.Section)
+	}
+	want := []string{"ISO27001 2013 A.9.4.2", "SOC2 2017 CC6.1", "SOC2 2017 CC6.2", "SOC2 2017 CC7.2"}
+	if strings.Join(got, ", ") != strings.Join(want, ", ") {
+		t.Errorf("loadExcerpts() = %v, want %v", got, want)
+	}
+	if len(excerpts[0].RelatedSections) != 1 || excerpts[0].RelatedSections[0] != "A.9.4.1" {
+		t.Errorf("related sections = %v, want [A.9.4.1]", excerpts[0].RelatedSections)
+	}
+}
+
+func TestLoadExcerpts_DirectoryConflictingDuplicate(t *testing.T) {
+	dir := writeExcerptsDir(t, map[string]string{
+		"a.json": `[{"framework": "SOC2", "version": "2017", "section": "CC6.1", "text": "Logical access security software is implemented"}]`,
+		"b.json": `[{"framework": "SOC-2", "version": "2017", "section": "CC6.1", "text": "A different definition of the same control"}]`,
+	})
+
+	_, err := loadExcerpts(dir)
+	if err == nil {
+		t.Fatal("loadExcerpts() expected an error for conflicting duplicates")
+	}
+	want := "conflicting excerpts for SOC-2 CC6.1 in " + filepath.Join(dir, "a.json") + " and " + filepath.Join(dir, "b.json")
+	if !strings.Contains(err.Error(), want) {
+		t.Errorf("loadExcerpts() error = %v, want it to contain %q", err, want)
+	}
+}
+
+func TestLoadExcerpts_DirectoryWithoutExcerptFiles(t *testing.T) {
+	dir := writeExcerptsDir(t, map[string]string{"notes.txt": "nothing here"})
+
+	_, err := loadExcerpts(dir)
+	if err == nil || !strings.Contains(err.Error(), "no .json or .yaml excerpt files found") {
+		t.Errorf("loadExcerpts() error = %v, want no excerpt files error", err)
+	}
+}
+
+func TestExcerptsValidate_Directory(t *testing.T) {
+	dir := writeExcerptsDir(t, map[string]string{
+		"a.json": `[{"framework": "SOC2", "version": "2017", "section": "CC6.1", "text": "Logical access security software is implemented"}]`,
+		"b.yaml": "- {framework: SOC2, version: \"2017\", section: CC6.2, text: Users are registered before access is granted}\n",
+	})
+
+	rootCmd.SetArgs([]string{"excerpts", "validate", dir})
+	buf := new(bytes.Buffer)
+	rootCmd.SetOut(buf)
+	rootCmd.SetErr(buf)
+	if err := rootCmd.Execute(); err != nil {
+		t.Fatalf("excerpts validate failed: %v\n%s", err, buf.String())
+	}
+	if !strings.Contains(buf.String(), "2 excerpt(s) are valid") {
+		t.Errorf("expected success summary, got:\n%s", buf.String())
+	}
+}
@@ -0,0 +1,25 @@
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+)
+
+var findingsCmd = &cobra.Command{
+	Use:   "findings",
+	Short: "Triage compliance findings",
+	Long: `Triage compliance findings stored in a findings file.
+
+Findings move through the triage workflow open → acknowledged → resolved.
+Any unresolved finding may be waived with a justification. Resolved and
+waived findings are excluded from the 'sdek report --fail-on' gate.
+
+Examples:
+  sdek findings set-status finding-123 acknowledged
+  sdek findings set-status finding-123 waived --reason "Compensating control in place"
+  sdek findings dedupe --file findings.json
+`,
+}
+
+func init() {
+	rootCmd.AddCommand(findingsCmd)
+}
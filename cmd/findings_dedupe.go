@@ -0,0 +1,63 @@
+package cmd
+
+import (
+	"fmt"
+	"log/slog"
+
+	"github.com/pickjonathan/sdek-cli/internal/report"
+	"github.com/spf13/cobra"
+)
+
+var findingsDedupeCmd = &cobra.Command{
+	Use:   "dedupe",
+	Short: "Merge duplicate findings in a findings file",
+	Long: `Merge findings that describe the same gap.
+
+Batched and multi-framework analysis can report the same logical finding more
+than once. Findings with the same control ID that cite at least one common
+event are merged: the highest-confidence finding is kept, and the citations and
+mapped controls of its duplicates are added to it. Findings without citations
+are left as they are.
+
+The findings file is rewritten in place unless --output is set.`,
+	Example: `  # Merge duplicates in the default findings file
+  sdek findings dedupe
+
+  # Write the merged findings to a new file
+  sdek findings dedupe --file all-findings.json --output deduped.json`,
+	Args: cobra.NoArgs,
+	RunE: runFindingsDedupe,
+}
+
+func init() {
+	findingsCmd.AddCommand(findingsDedupeCmd)
+
+	findingsDedupeCmd.Flags().String("file", "findings.json", "Findings file to deduplicate")
+	findingsDedupeCmd.Flags().String("output", "", "Write the merged findings here instead of updating --file")
+}
+
+func runFindingsDedupe(cmd *cobra.Command, args []string) error {
+	filePath, _ := cmd.Flags().GetString("file")
+	outputPath, _ := cmd.Flags().GetString("output")
+
+	findingsFile, err := report.LoadFindingsFile(filePath)
+	if err != nil {
+		return err
+	}
+
+	before := len(findingsFile.Findings)
+	findingsFile.Findings = report.DedupeFindings(findingsFile.Findings)
+	merged := before - len(findingsFile.Findings)
+
+	if outputPath != "" {
+		findingsFile.Path = outputPath
+	}
+	if err := findingsFile.Save(); err != nil {
+		return err
+	}
+
+	slog.Info("Findings deduplicated", "file", findingsFile.Path, "before", before, "after", len(findingsFile.Findings))
+	fmt.Fprintf(cmd.OutOrStdout(), "✓ Merged %d duplicate finding(s): %d → %d findings in %s\n", merged, before, len(findingsFile.Findings), findingsFile.Path)
+
+	return nil
+}
@@ -0,0 +1,101 @@
+package cmd
+
+import (
+	"fmt"
+	"log/slog"
+
+	"github.com/pickjonathan/sdek-cli/internal/report"
+	"github.com/pickjonathan/sdek-cli/internal/store"
+	"github.com/spf13/cobra"
+)
+
+var findingsSetStatusCmd = &cobra.Command{
+	Use:   "set-status <finding-id> <status>",
+	Short: "Update the triage status of a finding",
+	Long: `Update the triage status of a finding in the current state, where
+'sdek report' and its --fail-on gate read findings, or in a findings file
+given with --file.
+
+Valid transitions:
+  open         → acknowledged, in_progress, waived
+  in_progress  → acknowledged, resolved, waived
+  acknowledged → in_progress, resolved, waived
+  resolved     → open
+  waived       → open
+
+Waiving a finding requires --reason.`,
+	Example: `  # Acknowledge a finding in the current state
+  sdek findings set-status finding-123 acknowledged
+
+  # Waive a finding with a justification
+  sdek findings set-status finding-123 waived \
+      --reason "Accepted risk, see SEC-42" --file ./findings/soc2.json`,
+	Args: cobra.ExactArgs(2),
+	RunE: runFindingsSetStatus,
+}
+
+func init() {
+	findingsCmd.AddCommand(findingsSetStatusCmd)
+
+	findingsSetStatusCmd.Flags().String("file", "", "Findings file to update (default: the current state)")
+	findingsSetStatusCmd.Flags().String("reason", "", "Reason for the status change (required for waived)")
+}
+
+func runFindingsSetStatus(cmd *cobra.Command, args []string) error {
+	findingID := args[0]
+	status := args[1]
+	filePath, _ := cmd.Flags().GetString("file")
+	reason, _ := cmd.Flags().GetString("reason")
+
+	previous, err := updateFindingStatus(findingID, status, reason, filePath)
+	if err != nil {
+		return err
+	}
+
+	slog.Info("Finding status updated", "finding", findingID, "from", previous, "to", status)
+	fmt.Printf("✓ Finding %s: %s → %s\n", findingID, previous, status)
+	if reason != "" {
+		fmt.Printf("  Reason: %s\n", reason)
+	}
+
+	return nil
+}
+
+// updateFindingStatus sets the status of a finding in a findings file, or in
+// the current state when no file is given, and returns its previous status
+func updateFindingStatus(findingID, status, reason, filePath string) (string, error) {
+	if filePath != "" {
+		findingsFile, err := report.LoadFindingsFile(filePath)
+		if err != nil {
+			return "", err
+		}
+		finding, err := findingsFile.Find(findingID)
+		if err != nil {
+			return "", err
+		}
+		previous := finding.Status
+		if err := report.SetFindingStatus(finding, status, reason); err != nil {
+			return "", err
+		}
+		return previous, findingsFile.Save()
+	}
+
+	state, err := store.Load()
+	if err != nil {
+		return "", fmt.Errorf("failed to load state: %w", err)
+	}
+	for i := range state.Findings {
+		if state.Findings[i].ID != findingID {
+			continue
+		}
+		previous := state.Findings[i].Status
+		if err := report.SetFindingStatus(&state.Findings[i], status, reason); err != nil {
+			return "", err
+		}
+		if err := state.Save(); err != nil {
+			return "", fmt.Errorf("failed to save state: %w", err)
+		}
+		return previous, nil
+	}
+	return "", fmt.Errorf("finding not found: %s", findingID)
+}
@@ -0,0 +1,56 @@
+package cmd
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/pickjonathan/sdek-cli/internal/store"
+	"github.com/pickjonathan/sdek-cli/pkg/types"
+)
+
+// TestSetStatus_WaivedFindingPassesFailOn verifies a finding waived in the
+// state no longer blocks 'report --fail-on'
+func TestSetStatus_WaivedFindingPassesFailOn(t *testing.T) {
+	tmpDir := t.TempDir()
+	t.Setenv("HOME", tmpDir)
+
+	state := store.NewState()
+	state.Events = []types.Event{{ID: "evt-1", SourceID: "github", Timestamp: time.Now(), Title: "Enable MFA"}}
+	state.Findings = []types.Finding{{ID: "finding-1", ControlID: "CC6.1", FrameworkID: "soc2", Severity: types.SeverityHigh, Status: types.StatusOpen}}
+	if err := state.Save(); err != nil {
+		t.Fatalf("failed to save state: %v", err)
+	}
+
+	oldOutput, oldFailOn, oldBaseline := reportOutput, reportFailOn, reportBaseline
+	t.Cleanup(func() { reportOutput, reportFailOn, reportBaseline = oldOutput, oldFailOn, oldBaseline })
+	reportOutput = filepath.Join(tmpDir, "report.json")
+	reportFailOn = types.SeverityHigh
+	reportBaseline = filepath.Join(tmpDir, "baseline.json")
+
+	if err := runReport(reportCmd, nil); err == nil || !strings.Contains(err.Error(), "1 open finding(s)") {
+		t.Fatalf("expected the open high finding to block the report, got %v", err)
+	}
+
+	previous, err := updateFindingStatus("finding-1", types.StatusWaived, "Accepted risk, see SEC-42", "")
+	if err != nil {
+		t.Fatalf("updateFindingStatus() error = %v", err)
+	}
+	if previous != types.StatusOpen {
+		t.Errorf("previous status = %q, want %q", previous, types.StatusOpen)
+	}
+
+	if err := runReport(reportCmd, nil); err != nil {
+		t.Errorf("expected the waived finding to pass --fail-on high, got %v", err)
+	}
+}
+
+// TestSetStatus_UnknownFinding verifies an unknown finding ID is reported
+func TestSetStatus_UnknownFinding(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	if _, err := updateFindingStatus("missing", types.StatusAcknowledged, "", ""); err == nil || !strings.Contains(err.Error(), "finding not found: missing") {
+		t.Errorf("expected finding not found, got %v", err)
+	}
+}
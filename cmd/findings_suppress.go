@@ -0,0 +1,107 @@
+package cmd
+
+import (
+	"fmt"
+	"log/slog"
+
+	"github.com/pickjonathan/sdek-cli/internal/report"
+	"github.com/pickjonathan/sdek-cli/internal/store"
+	"github.com/pickjonathan/sdek-cli/pkg/types"
+	"github.com/spf13/cobra"
+)
+
+var findingsSuppressCmd = &cobra.Command{
+	Use:   "suppress <finding-id>",
+	Short: "Add a finding to the suppression baseline",
+	Long: `Add a finding to the suppression baseline so it is not re-flagged.
+
+The baseline stores a content hash of the finding (framework, control, title,
+and severity) together with a justification. When 'sdek report' runs, matching
+findings are marked as waived and skipped by the --fail-on gate.
+
+Use --whole-control to suppress every finding for the finding's control.
+
+By default the finding is looked up in the current state; use --file to look
+it up in a findings file written by 'sdek ai analyze'.`,
+	Example: `  # Suppress a known finding
+  sdek findings suppress CC6.1-moderate-risk --reason "Accepted risk, see SEC-42"
+
+  # Suppress all findings for the finding's control
+  sdek findings suppress CC6.1-moderate-risk --whole-control \
+      --reason "Control out of scope for this audit"`,
+	Args: cobra.ExactArgs(1),
+	RunE: runFindingsSuppress,
+}
+
+func init() {
+	findingsCmd.AddCommand(findingsSuppressCmd)
+
+	findingsSuppressCmd.Flags().String("reason", "", "Justification for the suppression (required)")
+	findingsSuppressCmd.Flags().String("baseline", report.DefaultBaselineFile, "Suppression baseline file to update")
+	findingsSuppressCmd.Flags().String("file", "", "Findings file to look up the finding in (default: current state)")
+	findingsSuppressCmd.Flags().Bool("whole-control", false, "Suppress all findings for the finding's control")
+	findingsSuppressCmd.MarkFlagRequired("reason")
+}
+
+func runFindingsSuppress(cmd *cobra.Command, args []string) error {
+	findingID := args[0]
+	reason, _ := cmd.Flags().GetString("reason")
+	baselinePath, _ := cmd.Flags().GetString("baseline")
+	filePath, _ := cmd.Flags().GetString("file")
+	wholeControl, _ := cmd.Flags().GetBool("whole-control")
+
+	finding, err := lookupFinding(findingID, filePath)
+	if err != nil {
+		return err
+	}
+
+	baseline, err := report.LoadBaseline(baselinePath)
+	if err != nil {
+		return err
+	}
+
+	if wholeControl {
+		err = baseline.SuppressControl(finding.FrameworkID, finding.ControlID, reason)
+	} else {
+		err = baseline.SuppressFinding(*finding, reason)
+	}
+	if err != nil {
+		return err
+	}
+
+	if err := baseline.Save(baselinePath); err != nil {
+		return err
+	}
+
+	slog.Info("Finding suppressed", "finding", findingID, "control", finding.ControlID, "baseline", baselinePath)
+	if wholeControl {
+		fmt.Printf("✓ Suppressed all findings for control %s\n", finding.ControlID)
+	} else {
+		fmt.Printf("✓ Suppressed finding %s\n", findingID)
+	}
+	fmt.Printf("  Baseline: %s (%d entries)\n", baselinePath, len(baseline.Entries))
+
+	return nil
+}
+
+// lookupFinding finds a finding by ID in a findings file, or in the current state when no file is given
+func lookupFinding(findingID, filePath string) (*types.Finding, error) {
+	if filePath != "" {
+		findingsFile, err := report.LoadFindingsFile(filePath)
+		if err != nil {
+			return nil, err
+		}
+		return findingsFile.Find(findingID)
+	}
+
+	state, err := store.Load()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load state: %w", err)
+	}
+	for i := range state.Findings {
+		if state.Findings[i].ID == findingID {
+			return &state.Findings[i], nil
+		}
+	}
+	return nil, fmt.Errorf("finding not found: %s", findingID)
+}
@@ -0,0 +1,169 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/pickjonathan/sdek-cli/internal/analyze"
+	"github.com/pickjonathan/sdek-cli/pkg/types"
+	"github.com/spf13/cobra"
+)
+
+var frameworksFormat string
+
+var frameworksCmd = &cobra.Command{
+	Use:   "frameworks",
+	Short: "List built-in compliance frameworks and controls",
+	Long: `List the compliance frameworks and controls built into sdek.
+
+Use the control IDs shown by 'sdek frameworks controls' as the --section
+value for 'sdek ai analyze' and 'sdek ai plan'.
+
+Examples:
+  sdek frameworks list                  # Frameworks with control counts
+  sdek frameworks controls soc2         # Control IDs and titles for SOC 2
+  sdek frameworks controls PCI-DSS --format json
+`,
+}
+
+var frameworksListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List built-in frameworks",
+	Args:  cobra.NoArgs,
+	RunE:  runFrameworksList,
+}
+
+var frameworksControlsCmd = &cobra.Command{
+	Use:   "controls <framework>",
+	Short: "List the controls of a framework",
+	Args:  cobra.ExactArgs(1),
+	RunE:  runFrameworksControls,
+}
+
+func init() {
+	rootCmd.AddCommand(frameworksCmd)
+	frameworksCmd.AddCommand(frameworksListCmd)
+	frameworksCmd.AddCommand(frameworksControlsCmd)
+
+	frameworksCmd.PersistentFlags().StringVar(&frameworksFormat, "format", "text", "Output format: text or json")
+}
+
+// frameworkSummary is the JSON representation of a framework in 'frameworks list'
+type frameworkSummary struct {
+	ID           string `json:"id"`
+	Name         string `json:"name"`
+	Description  string `json:"description"`
+	ControlCount int    `json:"control_count"`
+}
+
+// controlSummary is the JSON representation of a control in 'frameworks controls'
+type controlSummary struct {
+	ID       string `json:"id"`
+	Title    string `json:"title"`
+	Category string `json:"category,omitempty"`
+}
+
+func runFrameworksList(cmd *cobra.Command, args []string) error {
+	if err := validateFrameworksFormat(); err != nil {
+		return err
+	}
+
+	definitions := sortedFrameworkDefinitions()
+	summaries := make([]frameworkSummary, 0, len(definitions))
+	for _, fw := range definitions {
+		summaries = append(summaries, frameworkSummary{
+			ID:           fw.ID,
+			Name:         fw.Name,
+			Description:  fw.Description,
+			ControlCount: len(fw.Controls),
+		})
+	}
+
+	out := cmd.OutOrStdout()
+	if frameworksFormat == "json" {
+		return writeJSON(out, summaries)
+	}
+
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "ID\tNAME\tCONTROLS\tDESCRIPTION")
+	for _, s := range summaries {
+		fmt.Fprintf(w, "%s\t%s\t%d\t%s\n", s.ID, s.Name, s.ControlCount, s.Description)
+	}
+	return w.Flush()
+}
+
+func runFrameworksControls(cmd *cobra.Command, args []string) error {
+	if err := validateFrameworksFormat(); err != nil {
+		return err
+	}
+
+	fw, ok := findFrameworkDefinition(args[0])
+	if !ok {
+		ids := make([]string, 0)
+		for _, def := range sortedFrameworkDefinitions() {
+			ids = append(ids, def.ID)
+		}
+		return fmt.Errorf("unknown framework '%s', must be one of: %s", args[0], strings.Join(ids, ", "))
+	}
+
+	controls := make([]controlSummary, 0, len(fw.Controls))
+	for _, c := range fw.Controls {
+		controls = append(controls, controlSummary{ID: c.ID, Title: c.Title, Category: c.Category})
+	}
+
+	out := cmd.OutOrStdout()
+	if frameworksFormat == "json" {
+		return writeJSON(out, struct {
+			ID       string           `json:"id"`
+			Name     string           `json:"name"`
+			Controls []controlSummary `json:"controls"`
+		}{ID: fw.ID, Name: fw.Name, Controls: controls})
+	}
+
+	fmt.Fprintf(out, "%s (%s): %d controls\n\n", fw.Name, fw.ID, len(controls))
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "CONTROL\tCATEGORY\tTITLE")
+	for _, c := range controls {
+		fmt.Fprintf(w, "%s\t%s\t%s\n", c.ID, c.Category, c.Title)
+	}
+	return w.Flush()
+}
+
+func validateFrameworksFormat() error {
+	if frameworksFormat != "text" && frameworksFormat != "json" {
+		return fmt.Errorf("invalid format '%s', must be one of: text, json", frameworksFormat)
+	}
+	return nil
+}
+
+// sortedFrameworkDefinitions returns the built-in frameworks ordered by ID
+func sortedFrameworkDefinitions() []analyze.FrameworkDefinition {
+	definitions := analyze.GetFrameworkDefinitions()
+	sorted := make([]analyze.FrameworkDefinition, 0, len(definitions))
+	for _, fw := range definitions {
+		sorted = append(sorted, fw)
+	}
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].ID < sorted[j].ID })
+	return sorted
+}
+
+// findFrameworkDefinition looks up a built-in framework by ID, name or alias,
+// so "SOC2", "pci-dss" and "ISO 27001" all resolve
+func findFrameworkDefinition(name string) (analyze.FrameworkDefinition, bool) {
+	id, ok := types.NormalizeFramework(name)
+	if !ok {
+		return analyze.FrameworkDefinition{}, false
+	}
+	fw, ok := analyze.GetFrameworkDefinitions()[string(id)]
+	return fw, ok
+}
+
+func writeJSON(w io.Writer, v interface{}) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(v)
+}
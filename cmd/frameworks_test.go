@@ -0,0 +1,123 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func runFrameworksCommand(t *testing.T, args ...string) (string, error) {
+	t.Helper()
+	rootCmd.SetArgs(args)
+	buf := new(bytes.Buffer)
+	rootCmd.SetOut(buf)
+	rootCmd.SetErr(buf)
+	err := rootCmd.Execute()
+	return buf.String(), err
+}
+
+func TestFrameworksList_ControlCounts(t *testing.T) {
+	output, err := runFrameworksCommand(t, "frameworks", "list", "--format", "json")
+	if err != nil {
+		t.Fatalf("frameworks list failed: %v", err)
+	}
+
+	var summaries []frameworkSummary
+	if err := json.Unmarshal([]byte(output), &summaries); err != nil {
+		t.Fatalf("failed to parse JSON output: %v\n%s", err, output)
+	}
+
+	want := map[string]int{"soc2": 45, "iso27001": 64, "pci_dss": 15}
+	if len(summaries) != len(want) {
+		t.Fatalf("expected %d frameworks, got %d", len(want), len(summaries))
+	}
+	for _, s := range summaries {
+		if s.ControlCount != want[s.ID] {
+			t.Errorf("framework %s: expected %d controls, got %d", s.ID, want[s.ID], s.ControlCount)
+		}
+	}
+}
+
+func TestFrameworksList_Text(t *testing.T) {
+	output, err := runFrameworksCommand(t, "frameworks", "list", "--format", "text")
+	if err != nil {
+		t.Fatalf("frameworks list failed: %v", err)
+	}
+
+	for _, want := range []string{"ID", "CONTROLS", "soc2", "iso27001", "pci_dss"} {
+		if !strings.Contains(output, want) {
+			t.Errorf("expected output to contain %q, got:\n%s", want, output)
+		}
+	}
+}
+
+func TestFrameworksControls(t *testing.T) {
+	tests := []struct {
+		framework   string
+		wantID      string
+		wantControl string
+		wantCount   int
+	}{
+		{"soc2", "soc2", "CC6.1", 45},
+		{"SOC2", "soc2", "CC6.1", 45},
+		{"ISO 27001", "iso27001", "A.5.1", 64},
+		{"PCI-DSS", "pci_dss", "8.3", 15},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.framework, func(t *testing.T) {
+			output, err := runFrameworksCommand(t, "frameworks", "controls", tt.framework, "--format", "json")
+			if err != nil {
+				t.Fatalf("frameworks controls failed: %v", err)
+			}
+
+			var result struct {
+				ID       string           `json:"id"`
+				Controls []controlSummary `json:"controls"`
+			}
+			if err := json.Unmarshal([]byte(output), &result); err != nil {
+				t.Fatalf("failed to parse JSON output: %v\n%s", err, output)
+			}
+
+			if result.ID != tt.wantID {
+				t.Errorf("expected framework %s, got %s", tt.wantID, result.ID)
+			}
+			if len(result.Controls) != tt.wantCount {
+				t.Errorf("expected %d controls, got %d", tt.wantCount, len(result.Controls))
+			}
+			found := false
+			for _, c := range result.Controls {
+				if c.ID == tt.wantControl {
+					found = true
+					if c.Title == "" {
+						t.Errorf("control %s has no title", c.ID)
+					}
+				}
+			}
+			if !found {
+				t.Errorf("expected control %s in %s", tt.wantControl, tt.wantID)
+			}
+		})
+	}
+}
+
+func TestFrameworksControls_UnknownFramework(t *testing.T) {
+	_, err := runFrameworksCommand(t, "frameworks", "controls", "hipaa", "--format", "text")
+	if err == nil {
+		t.Fatal("expected error for unknown framework")
+	}
+	if !strings.Contains(err.Error(), "unknown framework 'hipaa'") {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestFrameworks_InvalidFormat(t *testing.T) {
+	_, err := runFrameworksCommand(t, "frameworks", "list", "--format", "yaml")
+	if err == nil {
+		t.Fatal("expected error for invalid format")
+	}
+	if !strings.Contains(err.Error(), "invalid format 'yaml'") {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
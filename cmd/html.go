@@ -106,7 +106,7 @@ func runHTML(cmd *cobra.Command, args []string) error {
 	fmt.Printf("   Output: %s\n\n", outputPath)
 
 	// Generate HTML
-	if err := report.GenerateHTML(inputPath, outputPath); err != nil {
+	if err := report.GenerateHTML(inputPath, outputPath, exportFileMode()); err != nil {
 		return fmt.Errorf("failed to generate HTML report: %w", err)
 	}
 
@@ -1,20 +1,30 @@
 package cmd
 
 import (
+	"errors"
 	"fmt"
 	"log/slog"
 	"os"
 	"path/filepath"
+	"strings"
 	"time"
 
+	"github.com/pickjonathan/sdek-cli/internal/i18n"
 	"github.com/pickjonathan/sdek-cli/internal/report"
 	"github.com/pickjonathan/sdek-cli/internal/store"
+	"github.com/pickjonathan/sdek-cli/pkg/types"
 	"github.com/spf13/cobra"
 )
 
 var (
-	reportOutput string
-	reportRole   string
+	reportOutput   string
+	reportRole     string
+	reportFailOn   string
+	reportBaseline string
+	reportMinConf  float64
+	reportLabels   []string
+	reportTimeline string
+	reportReview   bool
 )
 
 // reportCmd represents the report command
@@ -42,7 +52,22 @@ to show only relevant information.`,
   sdek report --role manager
 
   # Export report filtered for engineer view
-  sdek report --role engineer`,
+  sdek report --role engineer
+
+  # Exit with an error if any open high or critical findings remain
+  sdek report --fail-on high
+
+  # Drop evidence and AI findings below 70% confidence
+  sdek report --min-confidence 0.7
+
+  # Only include evidence and findings labeled env=prod and team=platform
+  sdek report --label env=prod --label team=platform
+
+  # Export a reviewer worklist: only findings that require review
+  sdek report --review-only
+
+  # Group recurring evidence (e.g. access reviews) into quarterly timelines
+  sdek report --timeline-period quarter`,
 	PreRunE: func(cmd *cobra.Command, args []string) error {
 		// Validate role if specified
 		if reportRole != "" {
@@ -58,6 +83,28 @@ to show only relevant information.`,
 				return fmt.Errorf("invalid role '%s', must be one of: manager, engineer", reportRole)
 			}
 		}
+
+		// Validate minimum confidence
+		if reportMinConf < 0 || reportMinConf > 1 {
+			return fmt.Errorf("invalid min-confidence %.2f, must be between 0 and 1", reportMinConf)
+		}
+
+		// Validate label selectors
+		if _, err := parseLabelSelector(reportLabels); err != nil {
+			return err
+		}
+
+		// Validate timeline period
+		if _, err := report.ParseEvidencePeriod(reportTimeline); err != nil {
+			return err
+		}
+
+		// Validate fail-on severity if specified
+		if reportFailOn != "" {
+			if _, err := report.CheckFailOn(nil, reportFailOn); err != nil {
+				return err
+			}
+		}
 		return nil
 	},
 	RunE: runReport,
@@ -72,6 +119,28 @@ func init() {
 
 	reportCmd.Flags().StringVarP(&reportOutput, "output", "o", defaultOutput, "Output file path for the report")
 	reportCmd.Flags().StringVar(&reportRole, "role", "", "Filter report by role (manager, engineer)")
+	reportCmd.Flags().StringVar(&reportFailOn, "fail-on", "", "Exit with an error if open findings at or above this severity exist (low, medium, high, critical)")
+	reportCmd.Flags().StringVar(&reportBaseline, "baseline", report.DefaultBaselineFile, "Suppression baseline file; matching findings are marked waived")
+	reportCmd.Flags().Float64Var(&reportMinConf, "min-confidence", 0, "Drop evidence and AI findings below this confidence (0-1)")
+	reportCmd.Flags().StringArrayVar(&reportLabels, "label", nil, "Only include evidence and findings with this label, as key=value (repeatable; all must match)")
+	reportCmd.Flags().BoolVar(&reportReview, "review-only", false, "Only include findings that require review, and their controls")
+	reportCmd.Flags().StringVar(&reportTimeline, "timeline-period", string(report.PeriodMonth), "Group recurring control evidence into a timeline by month or quarter")
+}
+
+// parseLabelSelector parses key=value labels into a selector; a key may only be given once
+func parseLabelSelector(labels []string) (map[string]string, error) {
+	selector := make(map[string]string, len(labels))
+	for _, label := range labels {
+		key, value, err := types.ParseLabel(label)
+		if err != nil {
+			return nil, err
+		}
+		if existing, ok := selector[key]; ok && existing != value {
+			return nil, fmt.Errorf("conflicting values for label %s: %s and %s", key, existing, value)
+		}
+		selector[key] = value
+	}
+	return selector, nil
 }
 
 func runReport(cmd *cobra.Command, args []string) error {
@@ -85,7 +154,7 @@ func runReport(cmd *cobra.Command, args []string) error {
 
 	// Check if we have data to report
 	if len(state.Events) == 0 {
-		return fmt.Errorf("no data found to report, run 'sdek seed --demo' first")
+		return errors.New(messages.T(i18n.ErrorNoReportData))
 	}
 
 	if len(state.Evidence) == 0 {
@@ -106,6 +175,28 @@ func runReport(cmd *cobra.Command, args []string) error {
 
 	// Create exporter
 	exporter := report.NewExporter(GetVersion())
+	clock, err := outputClock()
+	if err != nil {
+		return err
+	}
+	exporter.SetClock(clock)
+	if cfg, err := loadConfig(); err == nil {
+		exporter.SetScoringWeights(cfg.Scoring.Weights)
+		exporter.SetFrameworkVersions(cfg.Frameworks.Versions)
+	}
+
+	// Load suppression baseline (missing file means nothing is suppressed)
+	baseline, err := report.LoadBaseline(reportBaseline)
+	if err != nil {
+		return fmt.Errorf("failed to load baseline: %w", err)
+	}
+	exporter.SetBaseline(baseline)
+
+	timelinePeriod, err := report.ParseEvidencePeriod(reportTimeline)
+	if err != nil {
+		return err
+	}
+	exporter.SetTimelinePeriod(timelinePeriod)
 
 	// Generate report
 	slog.Info("Generating report", "role", role)
@@ -122,6 +213,28 @@ func runReport(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("failed to generate report: %w", err)
 	}
 
+	// Drop low-confidence evidence and findings
+	if reportMinConf > 0 {
+		slog.Info("Filtering report by confidence", "min", reportMinConf)
+		reportData = report.FilterByConfidence(reportData, reportMinConf)
+	}
+
+	// Keep only evidence and findings with the requested labels
+	if len(reportLabels) > 0 {
+		selector, err := parseLabelSelector(reportLabels)
+		if err != nil {
+			return err
+		}
+		slog.Info("Filtering report by labels", "labels", reportLabels)
+		reportData = report.FilterByLabels(reportData, selector)
+	}
+
+	// Keep only findings flagged for review, as a reviewer worklist
+	if reportReview {
+		slog.Info("Filtering report to findings requiring review")
+		reportData = report.FilterReviewRequired(reportData)
+	}
+
 	// Format report
 	formatter := report.NewFormatter()
 	formattedData, err := formatter.FormatJSON(reportData, true) // pretty print
@@ -131,7 +244,7 @@ func runReport(cmd *cobra.Command, args []string) error {
 
 	// Write report to file
 	slog.Info("Writing report to file", "path", reportOutput)
-	if err := os.WriteFile(reportOutput, formattedData, 0644); err != nil {
+	if err := report.WriteFile(reportOutput, formattedData, exportFileMode()); err != nil {
 		return fmt.Errorf("failed to write report file: %w", err)
 	}
 
@@ -144,30 +257,48 @@ func runReport(cmd *cobra.Command, args []string) error {
 	if reportRole != "" {
 		fmt.Printf("  Role filter: %s\n", reportRole)
 	}
+	if len(reportLabels) > 0 {
+		fmt.Printf("  Labels:      %s\n", strings.Join(reportLabels, ", "))
+	}
+	if reportReview {
+		fmt.Println("  Review only: yes")
+	}
 	fmt.Println()
 	fmt.Println("Report Contents:")
 	fmt.Printf("  Frameworks:  %d\n", len(state.Frameworks))
 	fmt.Printf("  Controls:    %d\n", len(state.Controls))
-	fmt.Printf("  Evidence:    %d\n", len(state.Evidence))
-	fmt.Printf("  Findings:    %d\n", len(state.Findings))
+	fmt.Printf("  Evidence:    %d\n", reportData.Summary.TotalEvidence)
+	fmt.Printf("  Findings:    %d\n", reportData.Summary.TotalFindings)
 	fmt.Printf("  Events:      %d\n", len(state.Events))
 	fmt.Println()
 
 	// Show compliance summary
 	fmt.Println("Compliance Summary:")
-	for _, fw := range state.Frameworks {
+	for _, fwReport := range reportData.Frameworks {
+		fw := fwReport.Framework
 		status := "✗"
 		if fw.CompliancePercentage >= 80 {
 			status = "✓"
 		} else if fw.CompliancePercentage >= 60 {
 			status = "⚠"
 		}
-		fmt.Printf("  %s %-15s %.1f%%\n", status, fw.Name, fw.CompliancePercentage)
+		fmt.Printf("  %s %-15s %.1f%% (weighted: %.1f%%)\n", status, fw.Name, fw.CompliancePercentage, fwReport.WeightedCompliance)
 	}
 	fmt.Println()
 
 	fmt.Printf("View the full report at: %s\n", reportOutput)
 
+	// Apply the fail-on gate (resolved, waived, and suppressed findings are skipped)
+	if reportFailOn != "" {
+		blocking, err := report.CheckFailOn(reportData.Findings, reportFailOn)
+		if err != nil {
+			return err
+		}
+		if len(blocking) > 0 {
+			return fmt.Errorf("%d open finding(s) at or above %s severity", len(blocking), reportFailOn)
+		}
+	}
+
 	slog.Info("Report command completed successfully")
 	return nil
 }
@@ -0,0 +1,76 @@
+package cmd
+
+import (
+	"fmt"
+	"text/tabwriter"
+
+	"github.com/pickjonathan/sdek-cli/internal/report"
+	"github.com/spf13/cobra"
+)
+
+var reportGapsCmd = &cobra.Command{
+	Use:   "gaps <report-file>",
+	Short: "List controls with no evidence in a report",
+	Long: `List the controls in a JSON report written by 'sdek report' that have no
+evidence mapped to them.
+
+The built-in framework definitions supply the full set of controls, so a
+control that never appears in the report is listed too. Controls with neither
+evidence nor findings are marked unassessed.`,
+	Example: `  # List coverage gaps in a report
+  sdek report gaps compliance-2024-10.json
+
+  # Emit the gaps as JSON for further processing
+  sdek report gaps compliance-2024-10.json --format json`,
+	Args: cobra.ExactArgs(1),
+	RunE: runReportGaps,
+}
+
+func init() {
+	reportCmd.AddCommand(reportGapsCmd)
+
+	reportGapsCmd.Flags().String("format", "text", "Output format: text or json")
+}
+
+func runReportGaps(cmd *cobra.Command, args []string) error {
+	reportPath := args[0]
+	format, _ := cmd.Flags().GetString("format")
+	if format != "text" && format != "json" {
+		return fmt.Errorf("invalid format '%s', must be one of: text, json", format)
+	}
+
+	reportData, err := report.LoadReport(reportPath)
+	if err != nil {
+		return err
+	}
+
+	gaps := report.CoverageGaps(reportData)
+
+	out := cmd.OutOrStdout()
+	if format == "json" {
+		return writeJSON(out, gaps)
+	}
+
+	if len(gaps) == 0 {
+		fmt.Fprintf(out, "✓ No coverage gaps: every control in %s has evidence\n", reportPath)
+		return nil
+	}
+
+	unassessed := 0
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "FRAMEWORK\tCONTROL\tSTATUS\tCATEGORY\tTITLE")
+	for _, gap := range gaps {
+		status := fmt.Sprintf("no evidence, %d finding(s)", gap.Findings)
+		if gap.Unassessed {
+			status = "unassessed"
+			unassessed++
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n", gap.FrameworkID, gap.ControlID, status, gap.Category, gap.Title)
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(out, "\n%d control(s) without evidence, %d unassessed\n", len(gaps), unassessed)
+	return nil
+}
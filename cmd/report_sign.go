@@ -0,0 +1,75 @@
+package cmd
+
+import (
+	"fmt"
+	"log/slog"
+
+	"github.com/pickjonathan/sdek-cli/internal/report"
+	"github.com/spf13/cobra"
+)
+
+var reportSignCmd = &cobra.Command{
+	Use:   "sign <report-file>",
+	Short: "Add an integrity hash and signature to a report",
+	Long: `Add a tamper-evident integrity hash to a JSON report written by 'sdek report'.
+
+The hash is a SHA256 of the report's canonical JSON (sorted keys, no
+whitespace), so any change to findings, evidence, or scores invalidates it.
+With --private-key the hash is also signed with an Ed25519 key, binding the
+report to its signer. Both are stored in the report's metadata.
+
+Generate a key pair with OpenSSL:
+  openssl genpkey -algorithm ed25519 -out report-signing.pem
+  openssl pkey -in report-signing.pem -pubout -out report-signing.pub.pem`,
+	Example: `  # Hash and sign a report in place
+  sdek report sign compliance-2024-10.json --private-key report-signing.pem
+
+  # Add only the integrity hash, writing to a new file
+  sdek report sign compliance-2024-10.json --output compliance-2024-10.signed.json`,
+	Args: cobra.ExactArgs(1),
+	RunE: runReportSign,
+}
+
+func init() {
+	reportCmd.AddCommand(reportSignCmd)
+
+	reportSignCmd.Flags().String("private-key", "", "Ed25519 private key (PKCS#8 PEM) used to sign the report")
+	reportSignCmd.Flags().String("output", "", "Write the signed report here instead of updating it in place")
+}
+
+func runReportSign(cmd *cobra.Command, args []string) error {
+	reportPath := args[0]
+	privateKeyPath, _ := cmd.Flags().GetString("private-key")
+	outputPath, _ := cmd.Flags().GetString("output")
+	if outputPath == "" {
+		outputPath = reportPath
+	}
+
+	reportData, err := report.LoadReport(reportPath)
+	if err != nil {
+		return err
+	}
+
+	if err := report.Sign(reportData, privateKeyPath); err != nil {
+		return fmt.Errorf("failed to sign report: %w", err)
+	}
+
+	formattedData, err := report.NewFormatter().FormatJSON(reportData, true)
+	if err != nil {
+		return fmt.Errorf("failed to format report: %w", err)
+	}
+
+	if err := report.WriteFile(outputPath, formattedData, exportFileMode()); err != nil {
+		return fmt.Errorf("failed to write report file: %w", err)
+	}
+
+	integrity := reportData.Metadata.Integrity
+	slog.Info("Report signed", "report", outputPath, "signed", integrity.Signature != "")
+	fmt.Printf("✓ Report hashed: %s\n", outputPath)
+	fmt.Printf("  %s: %s\n", integrity.HashAlgorithm, integrity.Hash)
+	if integrity.Signature != "" {
+		fmt.Printf("  Signed with %s key: %s\n", integrity.SignatureAlgorithm, privateKeyPath)
+	}
+
+	return nil
+}
@@ -192,3 +192,20 @@ func TestReportCommandOutputFormat(t *testing.T) {
 		}
 	}
 }
+
+func TestParseLabelSelector(t *testing.T) {
+	selector, err := parseLabelSelector([]string{"env=prod", "team=platform", "env=prod"})
+	if err != nil {
+		t.Fatalf("parseLabelSelector failed: %v", err)
+	}
+	if len(selector) != 2 || selector["env"] != "prod" || selector["team"] != "platform" {
+		t.Errorf("unexpected selector: %v", selector)
+	}
+
+	if _, err := parseLabelSelector([]string{"env=prod", "env=staging"}); err == nil {
+		t.Error("expected an error for conflicting label values")
+	}
+	if _, err := parseLabelSelector([]string{"prod"}); err == nil {
+		t.Error("expected an error for a label without a value")
+	}
+}
@@ -0,0 +1,53 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/pickjonathan/sdek-cli/internal/report"
+	"github.com/spf13/cobra"
+)
+
+var reportVerifyCmd = &cobra.Command{
+	Use:   "verify <report-file>",
+	Short: "Verify a report's integrity hash and signature",
+	Long: `Verify that a report signed with 'sdek report sign' has not been modified.
+
+The report's canonical hash is recomputed and compared with the stored hash.
+With --public-key the report must also carry a valid Ed25519 signature from
+the matching private key. The command exits with an error if verification fails.`,
+	Example: `  # Check the report has not been modified since it was hashed
+  sdek report verify compliance-2024-10.json
+
+  # Also check it was signed by the expected key
+  sdek report verify compliance-2024-10.json --public-key report-signing.pub.pem`,
+	Args: cobra.ExactArgs(1),
+	RunE: runReportVerify,
+}
+
+func init() {
+	reportCmd.AddCommand(reportVerifyCmd)
+
+	reportVerifyCmd.Flags().String("public-key", "", "Ed25519 public key (PKIX PEM) the report must be signed with")
+}
+
+func runReportVerify(cmd *cobra.Command, args []string) error {
+	reportPath := args[0]
+	publicKeyPath, _ := cmd.Flags().GetString("public-key")
+
+	reportData, err := report.LoadReport(reportPath)
+	if err != nil {
+		return err
+	}
+
+	if err := report.Verify(reportData, publicKeyPath); err != nil {
+		return fmt.Errorf("verification failed for %s: %w", reportPath, err)
+	}
+
+	fmt.Printf("✓ Report integrity verified: %s\n", reportPath)
+	fmt.Printf("  %s: %s\n", reportData.Metadata.Integrity.HashAlgorithm, reportData.Metadata.Integrity.Hash)
+	if publicKeyPath != "" {
+		fmt.Printf("  Signature valid for key: %s\n", publicKeyPath)
+	}
+
+	return nil
+}
@@ -1,21 +1,37 @@
 package cmd
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"log/slog"
 	"os"
 	"path/filepath"
 
+	"github.com/pickjonathan/sdek-cli/internal/ai"
+	"github.com/pickjonathan/sdek-cli/internal/config"
+	"github.com/pickjonathan/sdek-cli/internal/i18n"
+	"github.com/pickjonathan/sdek-cli/internal/telemetry"
+	"github.com/pickjonathan/sdek-cli/pkg/types"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 )
 
 var (
-	cfgFile  string
-	dataDir  string
-	logLevel string
-	verbose  bool
-	version  = "dev"
+	cfgFile     string
+	profileName string
+	dataDir     string
+	logLevel    string
+	verbose     bool
+	metricsAddr string
+	noColor     bool
+	version     = "dev"
+
+	// messages translates user-facing output into the configured language
+	messages = i18n.New(i18n.English)
+
+	// shutdownTelemetry flushes spans and stops the metrics server when the command finishes
+	shutdownTelemetry telemetry.ShutdownFunc = func(context.Context) error { return nil }
 )
 
 // rootCmd represents the base command when called without any subcommands
@@ -44,7 +60,10 @@ provides interactive visualization with export capabilities.`,
   sdek report --output ~/reports/compliance.json
 
   # Manage configuration
-  sdek config get export.enabled`,
+  sdek config get export.enabled
+
+  # Use the "prod" profile from the config file
+  sdek --profile prod report`,
 	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
 		// Initialize configuration
 		if err := initConfig(); err != nil {
@@ -56,6 +75,11 @@ provides interactive visualization with export capabilities.`,
 			return fmt.Errorf("failed to initialize logging: %w", err)
 		}
 
+		// Initialize tracing and metrics (no-op unless telemetry.otlp_endpoint or --metrics-addr is set)
+		if err := initTelemetry(cmd.Context()); err != nil {
+			return fmt.Errorf("failed to initialize telemetry: %w", err)
+		}
+
 		return nil
 	},
 }
@@ -63,15 +87,25 @@ provides interactive visualization with export capabilities.`,
 // Execute adds all child commands to the root command and sets flags appropriately.
 // This is called by main.main(). It only needs to happen once to the rootCmd.
 func Execute() error {
-	return rootCmd.Execute()
+	err := rootCmd.Execute()
+	if err != nil {
+		printErrorGuidance(rootCmd.ErrOrStderr(), err)
+	}
+	if shutdownErr := shutdownTelemetry(context.Background()); shutdownErr != nil {
+		slog.Warn("Failed to flush telemetry", "error", shutdownErr)
+	}
+	return err
 }
 
 func init() {
 	// Global flags
 	rootCmd.PersistentFlags().StringVar(&cfgFile, "config", "", "config file (default is $HOME/.sdek/config.yaml)")
+	rootCmd.PersistentFlags().StringVar(&profileName, "profile", "", "config profile to apply over the base config (default is $SDEK_PROFILE)")
 	rootCmd.PersistentFlags().StringVar(&dataDir, "data-dir", "", "data directory (default is $HOME/.sdek)")
 	rootCmd.PersistentFlags().StringVar(&logLevel, "log-level", "info", "log level (debug, info, warn, error)")
 	rootCmd.PersistentFlags().BoolVarP(&verbose, "verbose", "v", false, "verbose output")
+	rootCmd.PersistentFlags().StringVar(&metricsAddr, "metrics-addr", "", "serve Prometheus metrics on this address at /metrics while the command runs (e.g. :9090)")
+	rootCmd.PersistentFlags().BoolVar(&noColor, "no-color", false, "plain ASCII output without colors or emoji (also when NO_COLOR is set or stdout is not a terminal)")
 
 	// Version command
 	rootCmd.AddCommand(&cobra.Command{
@@ -117,6 +151,13 @@ func initConfig() error {
 	viper.SetEnvPrefix("SDEK")
 	viper.AutomaticEnv() // read in environment variables that match
 
+	viper.BindEnv("language", "SDEK_LANGUAGE", "SDEK_LANG")
+
+	// Settings whose zero value isn't the default
+	viper.SetDefault("ui.interactive", true)
+	viper.SetDefault("language", i18n.English)
+	viper.SetDefault("ai.autonomous.minRationaleChars", types.DefaultMinRationaleChars)
+
 	// If a config file is found, read it in
 	if err := viper.ReadInConfig(); err == nil {
 		if verbose {
@@ -124,6 +165,15 @@ func initConfig() error {
 		}
 	}
 
+	// A named profile overrides base settings for one environment (e.g. dev, prod)
+	if profile := config.SelectedProfile(profileName); profile != "" {
+		if err := config.ApplyProfile(viper.GetViper(), profile); err != nil {
+			return err
+		}
+	}
+
+	messages = i18n.New(viper.GetString("language"))
+
 	// Set data directory default
 	if dataDir == "" && !viper.IsSet("data-dir") {
 		home, err := os.UserHomeDir()
@@ -181,6 +231,37 @@ func initLogging() error {
 	return nil
 }
 
+// initTelemetry exports trace spans to telemetry.otlp_endpoint when it is set
+// and serves Prometheus metrics on --metrics-addr
+func initTelemetry(ctx context.Context) error {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	var cfg types.TelemetryConfig
+	if err := viper.UnmarshalKey("telemetry", &cfg); err != nil {
+		return fmt.Errorf("failed to read telemetry config: %w", err)
+	}
+
+	shutdownTracing, err := telemetry.Setup(ctx, cfg, version)
+	if err != nil {
+		return err
+	}
+	shutdownTelemetry = shutdownTracing
+
+	if metricsAddr == "" {
+		return nil
+	}
+	shutdownMetrics, err := telemetry.ServeMetrics(metricsAddr, ai.MetricsHandler())
+	if err != nil {
+		return err
+	}
+	shutdownTelemetry = func(ctx context.Context) error {
+		return errors.Join(shutdownMetrics(ctx), shutdownTracing(ctx))
+	}
+	return nil
+}
+
 // GetVersion returns the current version
 func GetVersion() string {
 	return version
@@ -6,6 +6,8 @@ import (
 	"path/filepath"
 	"testing"
 
+	"github.com/pickjonathan/sdek-cli/internal/i18n"
+	"github.com/pickjonathan/sdek-cli/pkg/types"
 	"github.com/spf13/viper"
 )
 
@@ -160,6 +162,92 @@ log:
 	if viper.GetString("log.level") != "debug" {
 		t.Errorf("expected log.level to be debug, got %s", viper.GetString("log.level"))
 	}
+	if got := viper.GetInt("ai.autonomous.minRationaleChars"); got != types.DefaultMinRationaleChars {
+		t.Errorf("expected ai.autonomous.minRationaleChars to default to %d, got %d", types.DefaultMinRationaleChars, got)
+	}
+}
+
+func TestInitConfig_Profile(t *testing.T) {
+	viper.Reset()
+	t.Cleanup(func() {
+		viper.Reset()
+		cfgFile = ""
+		profileName = ""
+	})
+	t.Setenv("SDEK_PROFILE", "")
+
+	cfgFile = filepath.Join(t.TempDir(), "config.yaml")
+	configContent := `ai:
+  enabled: true
+  provider: openai
+  model: gpt-4
+  timeout: 90
+profiles:
+  prod:
+    ai:
+      provider: anthropic
+      model: claude-3-5-sonnet-latest
+      anthropic_key: sk-ant-test
+`
+	if err := os.WriteFile(cfgFile, []byte(configContent), 0644); err != nil {
+		t.Fatalf("failed to create test config file: %v", err)
+	}
+	profileName = "prod"
+
+	if err := initConfig(); err != nil {
+		t.Fatalf("initConfig failed: %v", err)
+	}
+
+	cfg, err := loadConfig()
+	if err != nil {
+		t.Fatalf("loadConfig failed: %v", err)
+	}
+	if cfg.AI.Provider != "anthropic" || cfg.AI.Model != "claude-3-5-sonnet-latest" {
+		t.Errorf("expected profile to select anthropic claude-3-5-sonnet-latest, got %s %s", cfg.AI.Provider, cfg.AI.Model)
+	}
+	if cfg.AI.Timeout != 90 || !cfg.AI.Enabled {
+		t.Errorf("expected base ai settings to persist, got timeout=%d enabled=%v", cfg.AI.Timeout, cfg.AI.Enabled)
+	}
+}
+
+func TestInitConfig_Language(t *testing.T) {
+	t.Cleanup(func() {
+		viper.Reset()
+		cfgFile = ""
+		messages = i18n.New(i18n.English)
+	})
+	t.Setenv("SDEK_PROFILE", "")
+	cfgFile = filepath.Join(t.TempDir(), "config.yaml")
+	if err := os.WriteFile(cfgFile, []byte("log-level: info\n"), 0644); err != nil {
+		t.Fatalf("failed to create test config file: %v", err)
+	}
+
+	tests := []struct {
+		name    string
+		env     string
+		want    string
+		message string
+	}{
+		{name: "default is English", env: "", want: "en", message: "Analysis Complete!"},
+		{name: "SDEK_LANG locale", env: "es_ES.UTF-8", want: "es", message: "¡Análisis completado!"},
+		{name: "unsupported falls back to English", env: "fr", want: "en", message: "Analysis Complete!"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			viper.Reset()
+			t.Setenv("SDEK_LANG", tt.env)
+			if err := initConfig(); err != nil {
+				t.Fatalf("initConfig failed: %v", err)
+			}
+
+			if got := messages.Language(); got != tt.want {
+				t.Errorf("expected language %s, got %s", tt.want, got)
+			}
+			if got := messages.T(i18n.SummaryComplete); got != tt.message {
+				t.Errorf("expected %q, got %q", tt.message, got)
+			}
+		})
+	}
 }
 
 func TestInitLogging(t *testing.T) {
@@ -183,7 +183,7 @@ func runSeed(cmd *cobra.Command, args []string) error {
 	// Map events to controls (evidence generation)
 	slog.Info("Mapping events to controls")
 	mapper := analyze.NewMapper()
-	evidence := mapper.MapEventsToControls(allEvents)
+	evidence := mapper.MapEventsToControlsCtx(cmd.Context(), allEvents)
 	state.Evidence = evidence
 
 	// Calculate risk scores and update control statuses
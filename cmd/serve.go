@@ -0,0 +1,303 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"sync/atomic"
+	"syscall"
+	"time"
+
+	"github.com/pickjonathan/sdek-cli/internal/ai"
+	"github.com/pickjonathan/sdek-cli/internal/analyze"
+	"github.com/pickjonathan/sdek-cli/pkg/types"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+// defaultMaxRequestBytes caps the size of an analysis request body
+const defaultMaxRequestBytes = 10 << 20 // 10 MiB
+
+var (
+	serveAddr            string
+	serveMaxRequestBytes int64
+)
+
+// serveCmd represents the serve command
+var serveCmd = &cobra.Command{
+	Use:   "serve",
+	Short: "Run an HTTP server exposing the AI analysis API",
+	Long: `Run a long-lived HTTP server that analyzes evidence on request.
+
+POST /analyze accepts a JSON body with the policy excerpt and the evidence
+events, and returns the finding as JSON. Analyses use the AI engine configured
+in the config file, including the provider, redaction and cache settings.
+
+Request body:
+  {
+    "framework": "SOC2",
+    "version": "2017",
+    "section": "CC6.1",
+    "excerpt": "The entity implements logical access security...",
+    "related_sections": ["CC6.2"],
+    "evidence": [{"id": "evt-1", "source": "github", "type": "commit", "content": "..."}]
+  }
+
+Errors are returned as {"error": "..."} with status 400 for invalid requests,
+413 for bodies larger than --max-request-bytes, and 502 when the analysis fails.
+
+Send SIGHUP to reload the config file, e.g. after rotating a provider key or
+changing budgets. Requests in flight finish on the engine they started with. A
+config that fails to load or validate is rejected and the previous one stays active.`,
+	Example: `  # Serve on the default address
+  sdek serve
+
+  # Listen on all interfaces with a 1 MiB request limit
+  sdek serve --addr :8080 --max-request-bytes 1048576
+
+  # Analyze evidence over HTTP
+  curl -s -X POST localhost:8080/analyze -d @request.json
+
+  # Reload the config without restarting
+  kill -HUP $(pgrep -f "sdek serve")`,
+	RunE: runServe,
+}
+
+func init() {
+	rootCmd.AddCommand(serveCmd)
+
+	serveCmd.Flags().StringVar(&serveAddr, "addr", "127.0.0.1:8080", "Address to listen on")
+	serveCmd.Flags().Int64Var(&serveMaxRequestBytes, "max-request-bytes", defaultMaxRequestBytes, "Maximum size of an analysis request body in bytes")
+}
+
+func runServe(cmd *cobra.Command, args []string) error {
+	if serveMaxRequestBytes <= 0 {
+		return fmt.Errorf("--max-request-bytes must be positive, got %d", serveMaxRequestBytes)
+	}
+
+	cfg, engine, err := loadServeEngine()
+	if err != nil {
+		return err
+	}
+	analysis := newAnalysisServer(cfg, engine, serveMaxRequestBytes)
+
+	listener, err := net.Listen("tcp", serveAddr)
+	if err != nil {
+		return fmt.Errorf("failed to listen on %s: %w", serveAddr, err)
+	}
+
+	server := &http.Server{
+		Handler:           analysis.Handler(),
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	ctx, stop := signal.NotifyContext(cmd.Context(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	hangup := make(chan os.Signal, 1)
+	signal.Notify(hangup, syscall.SIGHUP)
+	defer signal.Stop(hangup)
+
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- server.Serve(listener)
+	}()
+	fmt.Fprintf(cmd.OutOrStdout(), "🚀 Serving the analysis API on http://%s (POST /analyze)\n", listener.Addr())
+
+serving:
+	for {
+		select {
+		case err := <-errCh:
+			return fmt.Errorf("server stopped: %w", err)
+		case <-hangup:
+			if err := analysis.Reload(reloadServeEngine); err != nil {
+				slog.Error("Config reload rejected, keeping the previous config", "error", err)
+				continue
+			}
+			slog.Info("Config reloaded")
+		case <-ctx.Done():
+			break serving
+		}
+	}
+
+	slog.Info("Shutting down analysis server")
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	if err := server.Shutdown(shutdownCtx); err != nil {
+		return fmt.Errorf("failed to shut down server: %w", err)
+	}
+	return nil
+}
+
+// serveAnalyzeRequest is the body of POST /analyze
+type serveAnalyzeRequest struct {
+	Framework       string                `json:"framework"`
+	Version         string                `json:"version"`
+	Section         string                `json:"section"`
+	Excerpt         string                `json:"excerpt"`
+	RelatedSections []string              `json:"related_sections,omitempty"`
+	Evidence        []types.EvidenceEvent `json:"evidence"`
+}
+
+// serveError is the body of every error response
+type serveError struct {
+	Error string `json:"error"`
+}
+
+// serveEngine is a config and the engine built from it
+type serveEngine struct {
+	cfg    *types.Config
+	engine ai.Engine
+}
+
+// analysisServer serves the analysis API from an engine that can be swapped
+// atomically on reload
+type analysisServer struct {
+	current         atomic.Pointer[serveEngine]
+	maxRequestBytes int64
+}
+
+// newAnalysisServer creates an analysisServer. Request bodies larger than
+// maxRequestBytes are rejected with 413.
+func newAnalysisServer(cfg *types.Config, engine ai.Engine, maxRequestBytes int64) *analysisServer {
+	s := &analysisServer{maxRequestBytes: maxRequestBytes}
+	s.current.Store(&serveEngine{cfg: cfg, engine: engine})
+	return s
+}
+
+// Reload swaps in the config and engine returned by load. If load fails the
+// current engine is kept and the error returned.
+func (s *analysisServer) Reload(load func() (*types.Config, ai.Engine, error)) error {
+	cfg, engine, err := load()
+	if err != nil {
+		return err
+	}
+	s.current.Store(&serveEngine{cfg: cfg, engine: engine})
+	return nil
+}
+
+// Handler routes the analysis API
+func (s *analysisServer) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/analyze", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			w.Header().Set("Allow", http.MethodPost)
+			writeServeError(w, http.StatusMethodNotAllowed, "method %s not allowed, use POST", r.Method)
+			return
+		}
+		current := s.current.Load()
+		handleAnalyze(w, r, current.cfg, current.engine, s.maxRequestBytes)
+	})
+	return mux
+}
+
+// loadServeEngine loads the config and builds the AI engine from it
+func loadServeEngine() (*types.Config, ai.Engine, error) {
+	cfg, err := loadConfig()
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to load config: %w", err)
+	}
+	if !cfg.AI.Enabled {
+		return nil, nil, fmt.Errorf("AI analysis is disabled in config. Set ai.enabled=true to use this command")
+	}
+
+	engine, err := initializeAIEngine(cfg)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to initialize AI engine: %w", err)
+	}
+	return cfg, engine, nil
+}
+
+// reloadServeEngine re-reads the config file and profile, validates them, then
+// loads the engine. Unlike at startup, a config file that can't be read or is
+// invalid is an error rather than silently keeping the values read earlier.
+func reloadServeEngine() (*types.Config, ai.Engine, error) {
+	if err := viper.ReadInConfig(); err != nil {
+		return nil, nil, fmt.Errorf("failed to read config: %w", err)
+	}
+	if err := initConfig(); err != nil {
+		return nil, nil, fmt.Errorf("failed to read config: %w", err)
+	}
+	if err := validateReloadedConfig(); err != nil {
+		return nil, nil, err
+	}
+	return loadServeEngine()
+}
+
+// validateReloadedConfig checks the re-read settings with types.ValidateConfig.
+// They are applied over the defaults so settings left unset aren't rejected.
+func validateReloadedConfig() error {
+	cfg := types.DefaultConfig()
+	if err := viper.Unmarshal(cfg); err != nil {
+		return fmt.Errorf("failed to unmarshal config: %w", err)
+	}
+	if err := types.ValidateConfig(cfg); err != nil {
+		return fmt.Errorf("invalid config: %w", err)
+	}
+	return nil
+}
+
+// handleAnalyze validates an analysis request, runs it through the engine and writes the finding
+func handleAnalyze(w http.ResponseWriter, r *http.Request, cfg *types.Config, engine ai.Engine, maxRequestBytes int64) {
+	var req serveAnalyzeRequest
+	decoder := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxRequestBytes))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&req); err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			writeServeError(w, http.StatusRequestEntityTooLarge, "request body exceeds %d bytes", maxRequestBytes)
+			return
+		}
+		writeServeError(w, http.StatusBadRequest, "invalid request body: %v", err)
+		return
+	}
+
+	if req.Framework == "" || req.Section == "" || req.Excerpt == "" {
+		writeServeError(w, http.StatusBadRequest, "framework, section and excerpt are required")
+		return
+	}
+
+	excerpt := Excerpt{Framework: req.Framework, Version: req.Version}
+	preamble, err := types.NewContextPreambleWithRubrics(req.Framework, excerptVersion(excerpt, cfg.Frameworks), req.Section, req.Excerpt, req.RelatedSections, cfg.Frameworks.RubricsFor(req.Framework))
+	if err != nil {
+		writeServeError(w, http.StatusBadRequest, "invalid context preamble: %v", err)
+		return
+	}
+
+	// Fill in missing event types and timestamps, as the CLI does for evidence files
+	evidence, _ := ai.NormalizeEvidence(types.EvidenceBundle{Events: req.Evidence}, ai.NormalizeOptions{LoadTime: time.Now()})
+	if len(evidence.Events) == 0 {
+		writeServeError(w, http.StatusBadRequest, "evidence must contain at least one event")
+		return
+	}
+
+	finding, err := engine.Analyze(r.Context(), *preamble, evidence)
+	if err != nil {
+		slog.Warn("Analysis request failed", "framework", req.Framework, "section", req.Section, "error", err)
+		writeServeError(w, http.StatusBadGateway, "AI analysis failed: %v", err)
+		return
+	}
+	analyze.FlagLowConfidence(finding, preamble.Rubrics.ConfidenceThreshold)
+
+	writeServeJSON(w, http.StatusOK, finding)
+}
+
+// writeServeError writes a JSON error response
+func writeServeError(w http.ResponseWriter, status int, format string, args ...interface{}) {
+	writeServeJSON(w, status, serveError{Error: fmt.Sprintf(format, args...)})
+}
+
+// writeServeJSON writes v as a JSON response with the given status
+func writeServeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		slog.Warn("Failed to write response", "error", err)
+	}
+}
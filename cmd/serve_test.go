@@ -0,0 +1,203 @@
+package cmd
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/pickjonathan/sdek-cli/internal/ai"
+	"github.com/pickjonathan/sdek-cli/pkg/types"
+	"github.com/spf13/viper"
+)
+
+func newTestServeHandler(t *testing.T, maxRequestBytes int64) (http.Handler, *ai.MockProvider) {
+	t.Helper()
+
+	cfg := &types.Config{
+		AI: types.AIConfig{
+			Enabled:  true,
+			Provider: "mock",
+			Mode:     types.AIModeContext,
+			CacheDir: t.TempDir(),
+		},
+	}
+	provider := ai.NewMockProvider()
+	return newAnalysisServer(cfg, ai.NewEngine(cfg, provider), maxRequestBytes).Handler(), provider
+}
+
+const serveAnalyzeBody = `{
+  "framework": "SOC2",
+  "version": "2017",
+  "section": "CC6.1",
+  "excerpt": "The entity implements logical access security software, infrastructure, and architectures",
+  "evidence": [{"id": "evt-1", "source": "github", "type": "commit", "timestamp": "2025-01-15T10:00:00Z", "content": "Enable MFA on admin login"}]
+}`
+
+func TestServeAnalyze(t *testing.T) {
+	handler, provider := newTestServeHandler(t, defaultMaxRequestBytes)
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/analyze", strings.NewReader(serveAnalyzeBody)))
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+	if ct := rec.Header().Get("Content-Type"); ct != "application/json" {
+		t.Errorf("expected JSON content type, got %q", ct)
+	}
+
+	var finding types.Finding
+	if err := json.Unmarshal(rec.Body.Bytes(), &finding); err != nil {
+		t.Fatalf("response is not a finding: %v", err)
+	}
+	if finding.ControlID != "CC6.1" || finding.FrameworkID != "SOC2" {
+		t.Errorf("unexpected finding %s/%s", finding.FrameworkID, finding.ControlID)
+	}
+	if len(finding.Citations) != 1 || finding.Citations[0] != "evt-1" {
+		t.Errorf("expected citation evt-1, got %v", finding.Citations)
+	}
+	if provider.GetCallCount() != 1 {
+		t.Errorf("expected one provider call, got %d", provider.GetCallCount())
+	}
+}
+
+func TestServeAnalyze_Errors(t *testing.T) {
+	tests := []struct {
+		name       string
+		method     string
+		body       string
+		maxBytes   int64
+		wantStatus int
+		wantError  string
+	}{
+		{"missing excerpt", http.MethodPost, `{"framework": "SOC2", "section": "CC6.1", "evidence": [{"id": "evt-1", "content": "x"}]}`, defaultMaxRequestBytes, http.StatusBadRequest, "framework, section and excerpt are required"},
+		{"no evidence", http.MethodPost, `{"framework": "SOC2", "version": "2017", "section": "CC6.1", "excerpt": "The entity implements logical access security software and architectures", "evidence": []}`, defaultMaxRequestBytes, http.StatusBadRequest, "at least one event"},
+		{"malformed JSON", http.MethodPost, `{"framework": `, defaultMaxRequestBytes, http.StatusBadRequest, "invalid request body"},
+		{"unknown field", http.MethodPost, `{"framwork": "SOC2"}`, defaultMaxRequestBytes, http.StatusBadRequest, "unknown field"},
+		{"body too large", http.MethodPost, serveAnalyzeBody, 64, http.StatusRequestEntityTooLarge, "exceeds 64 bytes"},
+		{"wrong method", http.MethodGet, "", defaultMaxRequestBytes, http.StatusMethodNotAllowed, "use POST"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler, provider := newTestServeHandler(t, tt.maxBytes)
+
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, httptest.NewRequest(tt.method, "/analyze", strings.NewReader(tt.body)))
+
+			if rec.Code != tt.wantStatus {
+				t.Errorf("expected status %d, got %d", tt.wantStatus, rec.Code)
+			}
+			var body serveError
+			if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil || !strings.Contains(body.Error, tt.wantError) {
+				t.Errorf("expected error containing %q, got %s", tt.wantError, rec.Body.String())
+			}
+			if provider.GetCallCount() != 0 {
+				t.Errorf("expected no provider calls, got %d", provider.GetCallCount())
+			}
+		})
+	}
+}
+
+func TestAnalysisServerReload(t *testing.T) {
+	cfg := &types.Config{AI: types.AIConfig{Enabled: true, Provider: "mock", Mode: types.AIModeContext, CacheDir: t.TempDir()}}
+	oldProvider, newProvider := ai.NewMockProvider(), ai.NewMockProvider()
+	server := newAnalysisServer(cfg, ai.NewEngine(cfg, oldProvider), defaultMaxRequestBytes)
+
+	err := server.Reload(func() (*types.Config, ai.Engine, error) {
+		newCfg := *cfg
+		newCfg.AI.NoCache = true
+		return &newCfg, ai.NewEngine(&newCfg, newProvider), nil
+	})
+	if err != nil {
+		t.Fatalf("Reload() error = %v", err)
+	}
+
+	rec := httptest.NewRecorder()
+	server.Handler().ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/analyze", strings.NewReader(serveAnalyzeBody)))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+	if oldProvider.GetCallCount() != 0 || newProvider.GetCallCount() != 1 {
+		t.Errorf("expected the reloaded engine to serve the request, got old=%d new=%d calls", oldProvider.GetCallCount(), newProvider.GetCallCount())
+	}
+}
+
+func TestAnalysisServerReload_InvalidConfigKeepsEngine(t *testing.T) {
+	tests := []struct {
+		name      string
+		config    string
+		wantError string
+	}{
+		{
+			name:      "model its provider doesn't serve",
+			config:    "ai:\n  enabled: true\n  provider: anthropic\n  model: gpt-4\n  anthropic_key: sk-ant-test\n",
+			wantError: "not a known anthropic model",
+		},
+		{
+			name:      "setting rejected by config validation",
+			config:    "ai:\n  enabled: true\n  provider: anthropic\n  model: claude-3-5-sonnet-latest\n  anthropic_key: sk-ant-test\n  timeout: -5\n",
+			wantError: "AI timeout must be positive",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			viper.Reset()
+			t.Cleanup(func() {
+				viper.Reset()
+				cfgFile = ""
+			})
+			t.Setenv("SDEK_PROFILE", "")
+
+			cfgFile = filepath.Join(t.TempDir(), "config.yaml")
+			if err := os.WriteFile(cfgFile, []byte(tt.config), 0644); err != nil {
+				t.Fatal(err)
+			}
+			viper.SetConfigFile(cfgFile)
+
+			cfg := &types.Config{AI: types.AIConfig{Enabled: true, Provider: "mock", Mode: types.AIModeContext, CacheDir: t.TempDir()}}
+			provider := ai.NewMockProvider()
+			server := newAnalysisServer(cfg, ai.NewEngine(cfg, provider), defaultMaxRequestBytes)
+
+			if err := server.Reload(reloadServeEngine); err == nil || !strings.Contains(err.Error(), tt.wantError) {
+				t.Fatalf("expected reload to be rejected with %q, got %v", tt.wantError, err)
+			}
+
+			rec := httptest.NewRecorder()
+			server.Handler().ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/analyze", strings.NewReader(serveAnalyzeBody)))
+			if rec.Code != http.StatusOK {
+				t.Fatalf("expected status 200, got %d: %s", rec.Code, rec.Body.String())
+			}
+			if provider.GetCallCount() != 1 {
+				t.Errorf("expected the previous engine to keep serving, got %d calls", provider.GetCallCount())
+			}
+		})
+	}
+}
+
+func TestValidateReloadedConfig_AcceptsValidConfig(t *testing.T) {
+	viper.Reset()
+	t.Cleanup(func() {
+		viper.Reset()
+		cfgFile = ""
+	})
+	t.Setenv("SDEK_PROFILE", "")
+
+	cfgFile = filepath.Join(t.TempDir(), "config.yaml")
+	valid := "ai:\n  enabled: true\n  provider: anthropic\n  model: claude-3-5-sonnet-latest\n  anthropic_key: sk-ant-test\n"
+	if err := os.WriteFile(cfgFile, []byte(valid), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := initConfig(); err != nil {
+		t.Fatalf("initConfig failed: %v", err)
+	}
+
+	if err := validateReloadedConfig(); err != nil {
+		t.Errorf("validateReloadedConfig() error = %v", err)
+	}
+}
@@ -0,0 +1,106 @@
+package cmd
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/pickjonathan/sdek-cli/internal/ai"
+	"github.com/pickjonathan/sdek-cli/pkg/types"
+)
+
+// excerptSuggestion returns a " (did you mean ...?)" hint for a framework/section
+// pair that matched no excerpt, or "" when nothing is close enough to suggest.
+// Frameworks are compared against the built-in frameworks and those in the
+// excerpts file; sections against the sections present for that framework.
+func excerptSuggestion(excerpts []Excerpt, framework, section string) string {
+	inFile := make(map[string]bool)
+	known := false
+	for _, e := range excerpts {
+		if e.Framework != "" {
+			inFile[e.Framework] = true
+			known = known || types.SameFramework(e.Framework, framework)
+		}
+	}
+
+	// Legacy excerpts carry no framework, so only an unknown framework that the
+	// file does name can explain the miss
+	suggestedFramework := ""
+	resolved := framework
+	if len(inFile) > 0 && !known {
+		candidates := builtinFrameworkNames()
+		for name := range inFile {
+			candidates = append(candidates, name)
+		}
+		if match := closestMatch(framework, candidates); match != "" {
+			suggestedFramework = match
+			resolved = match
+		}
+	}
+
+	var sections []string
+	for _, e := range excerpts {
+		if e.Framework == "" || types.SameFramework(e.Framework, resolved) {
+			sections = append(sections, e.Section)
+		}
+	}
+	suggestedSection := ""
+	if !containsString(sections, section) {
+		suggestedSection = closestMatch(section, sections)
+	}
+
+	switch {
+	case suggestedFramework != "" && suggestedSection != "":
+		return " (did you mean " + suggestedFramework + " " + suggestedSection + "?)"
+	case suggestedFramework != "":
+		return " (did you mean " + suggestedFramework + "?)"
+	case suggestedSection != "":
+		return " (did you mean " + suggestedSection + "?)"
+	}
+	return ""
+}
+
+// builtinFrameworkNames returns the built-in framework IDs in the form used by
+// --framework, e.g. "pci_dss" -> "PCI-DSS"
+func builtinFrameworkNames() []string {
+	definitions := sortedFrameworkDefinitions()
+	names := make([]string, 0, len(definitions))
+	for _, fw := range definitions {
+		names = append(names, strings.ToUpper(strings.ReplaceAll(fw.ID, "_", "-")))
+	}
+	return names
+}
+
+// closestMatch returns the candidate with the smallest case-insensitive edit
+// distance to input, or "" if none is within a third of the input's length.
+// Ties go to the alphabetically first candidate.
+func closestMatch(input string, candidates []string) string {
+	maxDistance := len([]rune(input)) / 3
+	if maxDistance < 1 {
+		maxDistance = 1
+	}
+
+	sorted := append([]string(nil), candidates...)
+	sort.Strings(sorted)
+
+	best := ""
+	bestDistance := maxDistance + 1
+	for _, candidate := range sorted {
+		if candidate == input {
+			continue
+		}
+		if d := ai.EditDistance(strings.ToLower(input), strings.ToLower(candidate)); d < bestDistance {
+			best = candidate
+			bestDistance = d
+		}
+	}
+	return best
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
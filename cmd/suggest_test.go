@@ -0,0 +1,63 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/spf13/viper"
+)
+
+func TestExcerptSuggestion(t *testing.T) {
+	excerpts := []Excerpt{
+		{Framework: "SOC2", Section: "CC6.1"},
+		{Framework: "SOC2", Section: "CC7.2"},
+		{Framework: "ISO27001", Section: "A.9.4.2"},
+	}
+	legacy := []Excerpt{
+		{Section: "CC6.1", legacy: true},
+		{Section: "CC8.1", legacy: true},
+	}
+
+	tests := []struct {
+		name      string
+		excerpts  []Excerpt
+		framework string
+		section   string
+		want      string
+	}{
+		{"near-miss framework", excerpts, "SOC3", "CC6.1", " (did you mean SOC2?)"},
+		{"framework alias needs no suggestion", excerpts, "soc-2", "CC6.1", ""},
+		{"built-in framework not in file", excerpts, "PCI-DS", "8.2.4", " (did you mean PCI-DSS?)"},
+		{"near-miss section", excerpts, "SOC2", "CC6.2", " (did you mean CC6.1?)"},
+		{"section from other framework is not suggested", excerpts, "SOC2", "A.9.4.3", ""},
+		{"framework and section", excerpts, "iso2701", "A.9.4.3", " (did you mean ISO27001 A.9.4.2?)"},
+		{"section under framework alias", excerpts, "ISO 27001", "A.9.4.3", " (did you mean A.9.4.2?)"},
+		{"nothing close", excerpts, "HIPAA", "164.312", ""},
+		{"legacy file suggests section only", legacy, "soc-2", "CC8.2", " (did you mean CC8.1?)"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := excerptSuggestion(tt.excerpts, tt.framework, tt.section); got != tt.want {
+				t.Errorf("excerptSuggestion(%q, %q) = %q, want %q", tt.framework, tt.section, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLoadConfig_AllowUnknownModel(t *testing.T) {
+	viper.Reset()
+	t.Cleanup(viper.Reset)
+	viper.Set("ai.enabled", true)
+	viper.Set("ai.provider", "anthropic")
+	viper.Set("ai.model", "gpt-4")
+
+	if _, err := loadConfig(); err == nil || !strings.Contains(err.Error(), "--allow-unknown-model") {
+		t.Fatalf("expected unknown model error mentioning the escape hatch, got %v", err)
+	}
+
+	viper.Set("ai.allow_unknown_model", true)
+	if _, err := loadConfig(); err != nil {
+		t.Errorf("expected --allow-unknown-model to skip the check, got %v", err)
+	}
+}
@@ -0,0 +1,37 @@
+package ai
+
+import (
+	"github.com/pickjonathan/sdek-cli/pkg/types"
+)
+
+// analysisTokensPerEventOverhead approximates the prompt tokens an evidence
+// event adds beyond its content (ID, source, type, timestamp)
+const analysisTokensPerEventOverhead = 20
+
+// AnalysisCostEstimate is the projected prompt size and cost of analyzing
+// evidence against one policy section
+type AnalysisCostEstimate struct {
+	Tokens  int     `json:"tokens"` // Approximate prompt tokens for the excerpt and evidence
+	CostUSD float64 `json:"cost_usd"`
+	Model   string  `json:"model"`
+	Priced  bool    `json:"priced"` // False when the model has no entry in the price table
+}
+
+// EstimateAnalysisCost estimates the prompt tokens and dollars of analyzing
+// evidence under preamble with model, at ~4 characters per token. Redaction
+// and the prompt template change the exact count, so this is a guide only.
+func EstimateAnalysisCost(preamble types.ContextPreamble, evidence types.EvidenceBundle, model string) AnalysisCostEstimate {
+	estimate := AnalysisCostEstimate{
+		Tokens: estimateTokens(preamble.Excerpt),
+		Model:  model,
+	}
+	for _, event := range evidence.Events {
+		estimate.Tokens += estimateTokens(event.Content) + analysisTokensPerEventOverhead
+	}
+
+	if price, ok := modelPrice(model); ok {
+		estimate.Priced = true
+		estimate.CostUSD = float64(estimate.Tokens) / 1_000_000 * price
+	}
+	return estimate
+}
@@ -0,0 +1,88 @@
+package ai
+
+import (
+	"bytes"
+	"fmt"
+	"log/slog"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+)
+
+// KeyringScheme prefixes ai.api_key_file values that name an OS keyring entry
+// rather than a file, as keyring://service/account.
+const KeyringScheme = "keyring://"
+
+// keyringLookup fetches a secret from the OS keyring; tests replace it
+var keyringLookup = lookupKeyring
+
+// ReadAPIKey resolves ai.api_key_file: either a path to a file holding the key
+// or a keyring://service/account reference. Surrounding whitespace is trimmed.
+// Files readable by other users are still read, but a warning is logged.
+func ReadAPIKey(ref string) (string, error) {
+	if strings.HasPrefix(ref, KeyringScheme) {
+		service, account, ok := strings.Cut(strings.TrimPrefix(ref, KeyringScheme), "/")
+		if !ok || service == "" || account == "" {
+			return "", fmt.Errorf("invalid keyring reference %q, expected keyring://service/account", ref)
+		}
+		key, err := keyringLookup(service, account)
+		if err != nil {
+			return "", fmt.Errorf("failed to read API key from keyring: %w", err)
+		}
+		return requireKey(key, ref)
+	}
+
+	path, err := expandHome(ref)
+	if err != nil {
+		return "", err
+	}
+
+	info, err := os.Stat(path)
+	if err != nil {
+		return "", fmt.Errorf("failed to read API key file: %w", err)
+	}
+	if info.Mode().Perm()&0004 != 0 {
+		slog.Warn("API key file is world-readable; restrict it with chmod 600", "path", path, "mode", info.Mode().Perm().String())
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", fmt.Errorf("failed to read API key file: %w", err)
+	}
+	return requireKey(string(data), path)
+}
+
+// requireKey trims key and rejects it if nothing is left
+func requireKey(key, source string) (string, error) {
+	key = strings.TrimSpace(key)
+	if key == "" {
+		return "", fmt.Errorf("API key from %s is empty", source)
+	}
+	return key, nil
+}
+
+// lookupKeyring reads a generic password using the platform's keyring CLI:
+// security(1) on macOS and secret-tool(1) (libsecret) elsewhere.
+func lookupKeyring(service, account string) (string, error) {
+	var cmd *exec.Cmd
+	switch runtime.GOOS {
+	case "darwin":
+		cmd = exec.Command("security", "find-generic-password", "-s", service, "-a", account, "-w")
+	case "linux", "freebsd", "openbsd", "netbsd":
+		cmd = exec.Command("secret-tool", "lookup", "service", service, "account", account)
+	default:
+		return "", fmt.Errorf("OS keyring is not supported on %s", runtime.GOOS)
+	}
+
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return "", fmt.Errorf("%s: %w: %s", cmd.Path, err, msg)
+		}
+		return "", fmt.Errorf("%s: %w", cmd.Path, err)
+	}
+	return string(out), nil
+}
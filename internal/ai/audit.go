@@ -0,0 +1,138 @@
+package ai
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Audit operations. An analysis logs an AuditOperationAnalyzeCall entry for
+// each provider call it makes (one per evidence chunk or retry), and, once its
+// finding is cached, one AuditOperationAnalyze entry referencing the cache
+// entry. Only the latter are replayed.
+const (
+	AuditOperationAnalyze     = "analyze"
+	AuditOperationAnalyzeCall = "analyze_call"
+)
+
+// Audit outcomes
+const (
+	AuditOutcomeSuccess = "success"
+	AuditOutcomeError   = "error"
+)
+
+// AuditEntry is a single provider call, or a completed analysis, in the audit log.
+// It records metadata only; prompts and responses are never written.
+// Analyses can be replayed from the cache entry referenced by CacheKey.
+type AuditEntry struct {
+	Timestamp      time.Time `json:"timestamp"`
+	Operation      string    `json:"operation"` // "analyze" | "analyze_call" | "plan" | "health"
+	Framework      string    `json:"framework,omitempty"`
+	ControlID      string    `json:"control_id,omitempty"`
+	CacheKey       string    `json:"cache_key,omitempty"` // Cache entry holding the response, used by replay
+	Provider       string    `json:"provider"`
+	Model          string    `json:"model,omitempty"`
+	Seed           *int      `json:"seed,omitempty"`  // Sampling seed, if configured
+	PromptHash     string    `json:"prompt_hash"`     // SHA256 of the redacted prompt; empty for "analyze" entries
+	PromptTokens   int       `json:"prompt_tokens"`   // Estimated (~4 characters per token)
+	ResponseTokens int       `json:"response_tokens"` // Estimated (~4 characters per token)
+	LatencyMs      int64     `json:"latency_ms"`
+	Outcome        string    `json:"outcome"`
+	ErrorCode      ErrorCode `json:"error_code,omitempty"` // Set for provider errors
+}
+
+// AuditLogger appends provider call entries to a JSON lines file.
+// It is safe for concurrent use.
+type AuditLogger struct {
+	path string
+	mu   sync.Mutex
+}
+
+// NewAuditLogger creates an audit logger writing to path, creating its directory if needed
+func NewAuditLogger(path string) (*AuditLogger, error) {
+	if path == "" {
+		return nil, fmt.Errorf("audit log path is required")
+	}
+
+	path, err := expandHome(path)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return nil, fmt.Errorf("failed to create audit log directory: %w", err)
+	}
+
+	return &AuditLogger{path: path}, nil
+}
+
+// Record appends an entry to the audit log
+func (a *AuditLogger) Record(entry AuditEntry) error {
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return fmt.Errorf("failed to marshal audit entry: %w", err)
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	f, err := os.OpenFile(a.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		return fmt.Errorf("failed to open audit log: %w", err)
+	}
+	defer f.Close()
+
+	if _, err := f.Write(append(data, '\n')); err != nil {
+		return fmt.Errorf("failed to write audit log: %w", err)
+	}
+
+	return nil
+}
+
+// completeAuditEntry fills in the call details of an audit entry from its prompt, response, and error
+func completeAuditEntry(entry *AuditEntry, provider, model, prompt, response string, latency time.Duration, callErr error) {
+	hash := sha256.Sum256([]byte(prompt))
+
+	entry.Timestamp = time.Now()
+	entry.Provider = provider
+	entry.Model = model
+	entry.PromptHash = hex.EncodeToString(hash[:])
+	entry.PromptTokens = estimateTokens(prompt)
+	entry.ResponseTokens = estimateTokens(response)
+	entry.LatencyMs = latency.Milliseconds()
+	entry.Outcome = AuditOutcomeSuccess
+
+	if callErr != nil {
+		// Only the error code is recorded; error messages may echo request content
+		entry.Outcome = AuditOutcomeError
+		var providerErr *ProviderError
+		if errors.As(callErr, &providerErr) {
+			entry.ErrorCode = providerErr.Code
+		}
+	}
+}
+
+// expandHome expands a leading ~ in path to the user's home directory
+func expandHome(path string) (string, error) {
+	if !strings.HasPrefix(path, "~") {
+		return path, nil
+	}
+
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to get home directory: %w", err)
+	}
+	return filepath.Join(homeDir, path[1:]), nil
+}
+
+// estimateTokens approximates the token count of text (~4 characters per token)
+func estimateTokens(text string) int {
+	return (len(text) + 3) / 4
+}
@@ -11,13 +11,23 @@ import (
 // against auto-approve policies.
 type AutoApproveMatcher interface {
 	Matches(source, query string) bool
+
+	// MatchedPattern returns the configured pattern that auto-approves
+	// source/query, and whether there is one
+	MatchedPattern(source, query string) (string, bool)
 }
 
 // autoApproveMatcher implements the AutoApproveMatcher interface.
 type autoApproveMatcher struct {
-	policy  map[string][]string    // Rules from config
-	enabled bool                   // Autonomous.Enabled flag
-	globs   map[string][]glob.Glob // Pre-compiled glob patterns per source
+	policy  map[string][]string          // Rules from config
+	enabled bool                         // Autonomous.Enabled flag
+	globs   map[string][]compiledPattern // Pre-compiled glob patterns per source
+}
+
+// compiledPattern is an auto-approve pattern with its compiled glob
+type compiledPattern struct {
+	pattern string
+	glob    glob.Glob
 }
 
 // NewAutoApproveMatcher creates a new AutoApproveMatcher instance.
@@ -29,7 +39,7 @@ func NewAutoApproveMatcher(cfg *types.Config) AutoApproveMatcher {
 	matcher := &autoApproveMatcher{
 		policy:  policy,
 		enabled: cfg.AI.Autonomous.Enabled,
-		globs:   make(map[string][]glob.Glob),
+		globs:   make(map[string][]compiledPattern),
 	}
 	matcher.compilePatterns()
 	return matcher
@@ -39,7 +49,7 @@ func NewAutoApproveMatcher(cfg *types.Config) AutoApproveMatcher {
 func (m *autoApproveMatcher) compilePatterns() {
 	for source, patterns := range m.policy {
 		sourceLower := strings.ToLower(source)
-		m.globs[sourceLower] = make([]glob.Glob, 0, len(patterns))
+		m.globs[sourceLower] = make([]compiledPattern, 0, len(patterns))
 
 		for _, pattern := range patterns {
 			// Compile pattern as case-insensitive
@@ -49,21 +59,27 @@ func (m *autoApproveMatcher) compilePatterns() {
 				// Skip invalid patterns (should be validated at config load time)
 				continue
 			}
-			m.globs[sourceLower] = append(m.globs[sourceLower], g)
+			m.globs[sourceLower] = append(m.globs[sourceLower], compiledPattern{pattern: pattern, glob: g})
 		}
 	}
 }
 
 // Matches checks if a source/query combination matches any auto-approve pattern.
 func (m *autoApproveMatcher) Matches(source, query string) bool {
+	_, ok := m.MatchedPattern(source, query)
+	return ok
+}
+
+// MatchedPattern returns the first auto-approve pattern for source that query matches.
+func (m *autoApproveMatcher) MatchedPattern(source, query string) (string, bool) {
 	// Policy must be enabled
 	if !m.enabled {
-		return false
+		return "", false
 	}
 
 	// Empty inputs don't match
 	if source == "" || query == "" {
-		return false
+		return "", false
 	}
 
 	// Case-insensitive lookup
@@ -73,20 +89,15 @@ func (m *autoApproveMatcher) Matches(source, query string) bool {
 	// Check if source exists in policy
 	patterns, exists := m.globs[sourceLower]
 	if !exists {
-		return false // Source not whitelisted
-	}
-
-	// Empty patterns for source
-	if len(patterns) == 0 {
-		return false
+		return "", false // Source not whitelisted
 	}
 
 	// Try each pattern
 	for _, pattern := range patterns {
-		if pattern.Match(queryLower) {
-			return true
+		if pattern.glob.Match(queryLower) {
+			return pattern.pattern, true
 		}
 	}
 
-	return false
+	return "", false
 }
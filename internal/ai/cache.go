@@ -7,17 +7,22 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"sort"
+	"strings"
 	"sync"
 	"time"
 
+	"github.com/pickjonathan/sdek-cli/internal/atomicfile"
 	"github.com/pickjonathan/sdek-cli/pkg/types"
 )
 
-// Cache manages AI analysis result caching with event-driven invalidation
+// Cache manages AI analysis result caching with event-driven invalidation.
+// It is safe for concurrent use; entries are written atomically via temp file + rename.
 type Cache struct {
 	dir         string
 	mu          sync.RWMutex
 	eventHashes map[string]string // eventID -> hash for invalidation tracking
+	maxBytes    int64             // Size cap enforced on Set; 0 means unlimited
 }
 
 // NewCache creates a new cache instance
@@ -73,13 +78,98 @@ func (c *Cache) Set(key string, result *CachedResult) error {
 		return fmt.Errorf("failed to marshal cache: %w", err)
 	}
 
-	if err := os.WriteFile(cachePath, data, 0644); err != nil {
+	if err := atomicfile.WriteFile(cachePath, data, 0644); err != nil {
 		return fmt.Errorf("failed to write cache: %w", err)
 	}
 
+	// Evicting is best effort; the new entry is already stored
+	if c.maxBytes > 0 {
+		_, _ = c.prune(c.maxBytes)
+	}
+
 	return nil
 }
 
+// SetMaxBytes caps the cache directory size. Once a Set pushes the cache past
+// the cap, the oldest entries (by CachedAt) are evicted. Zero disables the cap.
+func (c *Cache) SetMaxBytes(maxBytes int64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.maxBytes = maxBytes
+}
+
+// Prune evicts the oldest entries (by CachedAt) until the cache is at most
+// maxBytes in size, returning what was removed
+func (c *Cache) Prune(maxBytes int64) (PruneResult, error) {
+	if maxBytes <= 0 {
+		return PruneResult{}, fmt.Errorf("cache size limit must be positive, got %d", maxBytes)
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.prune(maxBytes)
+}
+
+// prune implements Prune; the caller must hold c.mu
+func (c *Cache) prune(maxBytes int64) (PruneResult, error) {
+	entries, err := os.ReadDir(c.dir)
+	if err != nil {
+		return PruneResult{}, fmt.Errorf("failed to read cache directory: %w", err)
+	}
+
+	type cacheFile struct {
+		path     string
+		size     int64
+		cachedAt time.Time
+	}
+
+	var files []cacheFile
+	var total int64
+	for _, entry := range entries {
+		if entry.IsDir() || filepath.Ext(entry.Name()) != ".json" {
+			continue
+		}
+		info, err := entry.Info()
+		if err != nil {
+			continue
+		}
+		files = append(files, cacheFile{path: filepath.Join(c.dir, entry.Name()), size: info.Size(), cachedAt: info.ModTime()})
+		total += info.Size()
+	}
+
+	result := PruneResult{RemainingBytes: total}
+	if total <= maxBytes {
+		return result, nil
+	}
+
+	// Only read entries once the cap is exceeded; fall back to mtime for entries without CachedAt
+	for i := range files {
+		data, err := os.ReadFile(files[i].path)
+		if err != nil {
+			continue
+		}
+		var meta struct{ CachedAt time.Time }
+		if err := json.Unmarshal(data, &meta); err == nil && !meta.CachedAt.IsZero() {
+			files[i].cachedAt = meta.CachedAt
+		}
+	}
+	sort.SliceStable(files, func(i, j int) bool { return files[i].cachedAt.Before(files[j].cachedAt) })
+
+	for _, f := range files {
+		if result.RemainingBytes <= maxBytes {
+			break
+		}
+		if err := os.Remove(f.path); err != nil && !os.IsNotExist(err) {
+			return result, fmt.Errorf("failed to remove cache file %s: %w", filepath.Base(f.path), err)
+		}
+		result.Removed++
+		result.FreedBytes += f.size
+		result.RemainingBytes -= f.size
+	}
+
+	return result, nil
+}
+
 // Delete removes a cached result by key
 func (c *Cache) Delete(key string) error {
 	c.mu.Lock()
@@ -188,6 +278,78 @@ func (c *Cache) InvalidateByEvents(eventIDs []string) (int, error) {
 	return invalidated, nil
 }
 
+// InvalidateByControl removes cache entries for a specific control (case-insensitive),
+// across all frameworks
+func (c *Cache) InvalidateByControl(controlID string) (int, error) {
+	return c.Invalidate("", controlID)
+}
+
+// InvalidatePrefix removes all cache entries for a framework (see Invalidate for matching).
+// Entries cached before the framework was recorded are not matched; use Clear for those.
+func (c *Cache) InvalidatePrefix(framework string) (int, error) {
+	return c.Invalidate(framework, "")
+}
+
+// Invalidate removes cache entries matching a framework and control ID. Frameworks
+// match by types.SameFramework, so "SOC2" also removes entries cached as "soc-2",
+// and control IDs match case-insensitively. An empty framework or control ID
+// matches any value; at least one must be set.
+func (c *Cache) Invalidate(framework, controlID string) (int, error) {
+	if framework == "" && controlID == "" {
+		return 0, fmt.Errorf("framework or control ID is required")
+	}
+
+	return c.invalidateWhere(func(result *CachedResult) bool {
+		if framework != "" && !types.SameFramework(result.Framework, framework) {
+			return false
+		}
+		if controlID != "" && !strings.EqualFold(result.ControlID, controlID) {
+			return false
+		}
+		return true
+	})
+}
+
+// invalidateWhere removes cache entries for which match returns true
+func (c *Cache) invalidateWhere(match func(result *CachedResult) bool) (int, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entries, err := os.ReadDir(c.dir)
+	if err != nil {
+		return 0, fmt.Errorf("failed to read cache directory: %w", err)
+	}
+
+	invalidated := 0
+	for _, entry := range entries {
+		if entry.IsDir() || filepath.Ext(entry.Name()) != ".json" {
+			continue
+		}
+
+		cachePath := filepath.Join(c.dir, entry.Name())
+		data, err := os.ReadFile(cachePath)
+		if err != nil {
+			continue // Skip files we can't read
+		}
+
+		var result CachedResult
+		if err := json.Unmarshal(data, &result); err != nil {
+			continue // Skip files we can't parse
+		}
+
+		if !match(&result) {
+			continue
+		}
+
+		if err := os.Remove(cachePath); err != nil && !os.IsNotExist(err) {
+			return invalidated, fmt.Errorf("failed to remove cache file %s: %w", entry.Name(), err)
+		}
+		invalidated++
+	}
+
+	return invalidated, nil
+}
+
 // TrackEvent tracks an event's hash for invalidation detection
 func (c *Cache) TrackEvent(event *types.Event) {
 	c.mu.Lock()
@@ -265,6 +427,13 @@ func (c *Cache) hashEvent(event *types.Event) string {
 	return hex.EncodeToString(h.Sum(nil))
 }
 
+// PruneResult describes the entries evicted by Prune
+type PruneResult struct {
+	Removed        int
+	FreedBytes     int64
+	RemainingBytes int64
+}
+
 // CacheStats contains cache statistics
 type CacheStats struct {
 	TotalEntries int
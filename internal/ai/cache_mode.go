@@ -0,0 +1,38 @@
+package ai
+
+import "context"
+
+// CacheMode controls how a single Analyze call uses the response cache.
+// It has no effect when caching is disabled by ai.no_cache or an empty cache dir,
+// except that CacheOnly then always misses.
+type CacheMode int
+
+const (
+	CacheReadWrite CacheMode = iota // Serve hits from the cache and store fresh results (default)
+	CacheOnly                       // Serve from the cache only; a miss returns ErrCacheMiss without calling the provider
+	CacheBypass                     // Always call the provider and leave the cache untouched
+	CacheRefresh                    // Always call the provider and overwrite the cached result
+)
+
+// reads reports whether cached results may be served in this mode
+func (m CacheMode) reads() bool {
+	return m == CacheReadWrite || m == CacheOnly
+}
+
+// writes reports whether fresh results are stored in this mode
+func (m CacheMode) writes() bool {
+	return m == CacheReadWrite || m == CacheRefresh
+}
+
+type cacheModeKey struct{}
+
+// WithCacheMode returns a context that makes the engine use the cache according to mode
+func WithCacheMode(ctx context.Context, mode CacheMode) context.Context {
+	return context.WithValue(ctx, cacheModeKey{}, mode)
+}
+
+// CacheModeFromContext returns the cache mode attached to ctx, or CacheReadWrite if there is none
+func CacheModeFromContext(ctx context.Context) CacheMode {
+	mode, _ := ctx.Value(cacheModeKey{}).(CacheMode)
+	return mode
+}
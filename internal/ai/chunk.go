@@ -0,0 +1,163 @@
+package ai
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/pickjonathan/sdek-cli/pkg/types"
+)
+
+const (
+	// promptOverheadTokens approximates the preamble, rubric and instructions
+	// that surround the evidence in an analysis prompt
+	promptOverheadTokens = 1024
+
+	// eventOverheadTokens approximates the ID, source, type and timestamp
+	// labels rendered alongside each event's content
+	eventOverheadTokens = 24
+)
+
+// EvidenceBudgetProvider is implemented by providers whose model has a context
+// window small enough that large evidence bundles must be analyzed in chunks
+type EvidenceBudgetProvider interface {
+	// EvidenceTokenBudget returns the evidence tokens that fit in one prompt; 0 means no limit
+	EvidenceTokenBudget() int
+}
+
+// ContextWindow returns the model context size set with Extra["num_ctx"], or 0 if unset
+func ContextWindow(config types.ProviderConfig) int {
+	window, err := strconv.Atoi(strings.TrimSpace(config.Extra["num_ctx"]))
+	if err != nil || window < 0 {
+		return 0
+	}
+	return window
+}
+
+// EvidenceTokenBudget returns how many evidence tokens fit in one prompt: the
+// context window less the MaxTokens reserved for the response and the prompt
+// overhead. It returns 0 (no limit) when no context window is configured.
+func EvidenceTokenBudget(config types.ProviderConfig) int {
+	window := ContextWindow(config)
+	if window == 0 {
+		return 0
+	}
+	budget := window - config.MaxTokens - promptOverheadTokens
+	if budget < 1 {
+		// Nothing fits; fall back to one event per prompt
+		budget = 1
+	}
+	return budget
+}
+
+// ChunkEvidence splits bundle into consecutive batches whose estimated size fits
+// within budget tokens. A single event larger than the budget gets a batch of its
+// own. A budget of 0 or less returns the bundle unsplit.
+func ChunkEvidence(bundle types.EvidenceBundle, budget int) []types.EvidenceBundle {
+	if budget <= 0 || len(bundle.Events) == 0 {
+		return []types.EvidenceBundle{bundle}
+	}
+
+	var chunks []types.EvidenceBundle
+	var current []types.EvidenceEvent
+	used := 0
+	for _, event := range bundle.Events {
+		tokens := estimateTokens(event.Content) + eventOverheadTokens
+		if len(current) > 0 && used+tokens > budget {
+			chunks = append(chunks, types.EvidenceBundle{Events: current})
+			current, used = nil, 0
+		}
+		current = append(current, event)
+		used += tokens
+	}
+	return append(chunks, types.EvidenceBundle{Events: current})
+}
+
+// ChunkFinding is the finding produced for one chunk of evidence
+type ChunkFinding struct {
+	Finding *types.Finding
+	Events  int // Events in the chunk, used to weight confidence
+}
+
+// MergeChunkFindings combines per-chunk findings into one: mapped controls and
+// citations are unioned in order, labels are unioned and sorted, confidence is
+// averaged weighted by event count, and the highest residual risk and severity
+// win. Identical summaries and justifications are kept once.
+func MergeChunkFindings(chunks []ChunkFinding) *types.Finding {
+	if len(chunks) == 0 {
+		return nil
+	}
+	if len(chunks) == 1 {
+		return chunks[0].Finding
+	}
+
+	merged := *chunks[0].Finding
+	merged.MappedControls = nil
+	merged.Citations = nil
+	merged.Labels = nil
+
+	var summaries, justifications []string
+	var weightedConfidence float64
+	totalEvents := 0
+	for _, chunk := range chunks {
+		f := chunk.Finding
+		merged.MappedControls = appendUnique(merged.MappedControls, f.MappedControls...)
+		merged.Citations = appendUnique(merged.Citations, f.Citations...)
+		merged.Labels = appendUnique(merged.Labels, f.Labels...)
+		summaries = appendUnique(summaries, f.Summary)
+		justifications = appendUnique(justifications, f.Justification)
+
+		weightedConfidence += f.ConfidenceScore * float64(chunk.Events)
+		totalEvents += chunk.Events
+
+		if riskRank[strings.ToLower(f.ResidualRisk)] > riskRank[strings.ToLower(merged.ResidualRisk)] {
+			merged.ResidualRisk = f.ResidualRisk
+		}
+		if severityRank[f.Severity] > severityRank[merged.Severity] {
+			merged.Severity = f.Severity
+		}
+	}
+
+	if totalEvents > 0 {
+		merged.ConfidenceScore = weightedConfidence / float64(totalEvents)
+	}
+	sort.Strings(merged.Labels)
+	merged.Summary = strings.Join(summaries, " ")
+	merged.Justification = strings.Join(justifications, " ")
+	return &merged
+}
+
+// appendUnique appends the non-empty values not already in list
+func appendUnique(list []string, values ...string) []string {
+	for _, v := range values {
+		if v == "" {
+			continue
+		}
+		found := false
+		for _, existing := range list {
+			if existing == v {
+				found = true
+				break
+			}
+		}
+		if !found {
+			list = append(list, v)
+		}
+	}
+	return list
+}
+
+// riskRank orders residual risk levels from lowest to highest
+var riskRank = map[string]int{
+	"low":    1,
+	"medium": 2,
+	"high":   3,
+}
+
+// severityRank orders finding severities from lowest to highest
+var severityRank = map[string]int{
+	types.SeverityLow:      1,
+	types.SeverityMedium:   2,
+	types.SeverityHigh:     3,
+	types.SeverityCritical: 4,
+}
@@ -0,0 +1,168 @@
+package connectors
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/pickjonathan/sdek-cli/pkg/types"
+)
+
+// FilesystemConnector answers queries from evidence already on disk instead
+// of a live API, so plans can be tried against an existing evidence snapshot.
+//
+// A query matches an event when every free-text term appears in its content,
+// its timestamp falls in the time range, and every filter matches. The type
+// and source filters match the event's type and source; other filters match
+// the event metadata field of the same name (or its plural, so label matches
+// labels). Filters on fields an event doesn't carry can't be checked locally
+// and are ignored, so hit counts are an upper bound on what the live source
+// would return.
+type FilesystemConnector struct {
+	events []types.EvidenceEvent
+}
+
+// NewFilesystemConnector creates a connector over previously loaded evidence events.
+func NewFilesystemConnector(events []types.EvidenceEvent) *FilesystemConnector {
+	return &FilesystemConnector{events: events}
+}
+
+// Name returns the connector identifier.
+func (f *FilesystemConnector) Name() string {
+	return "filesystem"
+}
+
+// Len returns the number of events the connector holds.
+func (f *FilesystemConnector) Len() int {
+	return len(f.events)
+}
+
+// Collect returns the events matching a string query (see ParseQuery) from any source.
+func (f *FilesystemConnector) Collect(ctx context.Context, query string) ([]types.EvidenceEvent, error) {
+	parsed, err := ParseQuery("", query)
+	if err != nil {
+		return nil, err
+	}
+	return f.CollectQuery(ctx, parsed)
+}
+
+// CollectQuery returns the events matching a structured query. A query source
+// other than "filesystem" restricts the match to events from that source.
+func (f *FilesystemConnector) CollectQuery(ctx context.Context, q ConnectorQuery) ([]types.EvidenceEvent, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	terms := textTerms(q.Text)
+	var matches []types.EvidenceEvent
+	for _, event := range f.events {
+		if q.Source != "" && q.Source != f.Name() && !strings.EqualFold(event.Source, q.Source) {
+			continue
+		}
+		if matchesQuery(event, q, terms) {
+			matches = append(matches, event)
+		}
+	}
+	return matches, nil
+}
+
+// Validate checks that evidence was loaded.
+func (f *FilesystemConnector) Validate(ctx context.Context) error {
+	if f.events == nil {
+		return fmt.Errorf("%w: no evidence loaded", ErrNotConfigured)
+	}
+	return nil
+}
+
+// matchesQuery reports whether event matches the time range, text terms and filters of q
+func matchesQuery(event types.EvidenceEvent, q ConnectorQuery, terms []string) bool {
+	if !q.Since.IsZero() && event.Timestamp.Before(q.Since) {
+		return false
+	}
+	if !q.Until.IsZero() && event.Timestamp.After(q.Until) {
+		return false
+	}
+
+	content := strings.ToLower(event.Content)
+	for _, term := range terms {
+		if !strings.Contains(content, term) {
+			return false
+		}
+	}
+
+	for _, key := range q.filterKeys() {
+		field, negate := strings.CutPrefix(key, "-")
+		values, ok := eventField(event, field)
+		if !ok {
+			continue
+		}
+		for _, want := range q.filterValues(key) {
+			if containsFold(values, want) == negate {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// eventField returns the values of a field of event, and whether the event has it
+func eventField(event types.EvidenceEvent, field string) ([]string, bool) {
+	switch field {
+	case "type":
+		return []string{event.Type}, true
+	case "source":
+		return []string{event.Source}, true
+	}
+
+	value, ok := event.Metadata[field]
+	if !ok {
+		value, ok = event.Metadata[field+"s"]
+	}
+	if !ok {
+		return nil, false
+	}
+
+	switch v := value.(type) {
+	case string:
+		return []string{v}, true
+	case []string:
+		return v, true
+	case []interface{}:
+		values := make([]string, 0, len(v))
+		for _, item := range v {
+			values = append(values, fmt.Sprint(item))
+		}
+		return values, true
+	default:
+		return []string{fmt.Sprint(v)}, true
+	}
+}
+
+// containsFold reports whether values contains want, ignoring case
+func containsFold(values []string, want string) bool {
+	for _, value := range values {
+		if strings.EqualFold(value, want) {
+			return true
+		}
+	}
+	return false
+}
+
+// textTerms splits free text into lowercase search terms, keeping quoted
+// phrases whole and dropping the AND/OR/NOT search operators
+func textTerms(text string) []string {
+	tokens, err := splitQuery(text)
+	if err != nil {
+		tokens = strings.Fields(text)
+	}
+	terms := make([]string, 0, len(tokens))
+	for _, token := range tokens {
+		if token == "AND" || token == "OR" || token == "NOT" {
+			continue // Search operators, not terms
+		}
+		if term := strings.ToLower(strings.Trim(token, `"`)); term != "" {
+			terms = append(terms, term)
+		}
+	}
+	return terms
+}
@@ -0,0 +1,78 @@
+package connectors
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/pickjonathan/sdek-cli/pkg/types"
+)
+
+func filesystemTestEvents() []types.EvidenceEvent {
+	return []types.EvidenceEvent{
+		{
+			ID: "gh-1", Source: "github", Type: "pr",
+			Timestamp: time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC),
+			Content:   "Enforce MFA for admin accounts",
+			Metadata:  map[string]interface{}{"labels": []interface{}{"security"}},
+		},
+		{
+			ID: "gh-2", Source: "github", Type: "commit",
+			Timestamp: time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC),
+			Content:   "Fix typo in README",
+		},
+		{
+			ID: "jira-1", Source: "jira", Type: "issue",
+			Timestamp: time.Date(2024, 2, 15, 0, 0, 0, 0, time.UTC),
+			Content:   "Quarterly access review for admin accounts",
+			Metadata:  map[string]interface{}{"labels": []string{"access-review"}, "state": "Done"},
+		},
+	}
+}
+
+func TestFilesystemConnector_CollectQuery(t *testing.T) {
+	fs := NewFilesystemConnector(filesystemTestEvents())
+
+	tests := []struct {
+		name    string
+		source  string
+		query   string
+		wantIDs []string
+	}{
+		{name: "text across sources", query: "admin accounts", wantIDs: []string{"gh-1", "jira-1"}},
+		{name: "source restricts", source: "github", query: "admin", wantIDs: []string{"gh-1"}},
+		{name: "type and label", source: "github", query: "type:pr label:security", wantIDs: []string{"gh-1"}},
+		{name: "negated filter", query: "-state:Done admin", wantIDs: []string{"gh-1"}},
+		{name: "time range", query: "since:2024-02-10 until:2024-12-31", wantIDs: []string{"gh-2", "jira-1"}},
+		{name: "unknown field ignored", source: "github", query: "repo:org/backend MFA", wantIDs: []string{"gh-1"}},
+		{name: "search operators are not terms", query: "MFA OR typo", wantIDs: nil},
+		{name: "no match", query: "encryption", wantIDs: nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			query, err := ParseQuery(tt.source, tt.query)
+			if err != nil {
+				t.Fatalf("ParseQuery failed: %v", err)
+			}
+			events, err := fs.CollectQuery(context.Background(), query)
+			if err != nil {
+				t.Fatalf("CollectQuery failed: %v", err)
+			}
+
+			var ids []string
+			for _, event := range events {
+				ids = append(ids, event.ID)
+			}
+			if len(ids) != len(tt.wantIDs) {
+				t.Fatalf("Matched %v, want %v", ids, tt.wantIDs)
+			}
+			for i := range ids {
+				if ids[i] != tt.wantIDs[i] {
+					t.Errorf("Matched %v, want %v", ids, tt.wantIDs)
+					break
+				}
+			}
+		})
+	}
+}
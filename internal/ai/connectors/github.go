@@ -47,20 +47,20 @@ func (g *GitHubConnector) Name() string {
 }
 
 // Collect retrieves evidence from GitHub using the provided query.
-// Query format: GitHub search syntax (e.g., "type:pr label:security", "is:issue author:username")
+// Query format: GitHub search syntax (e.g., "type:pr label:security", "is:issue author:username"),
+// parsed with ParseQuery and run through CollectQuery.
 func (g *GitHubConnector) Collect(ctx context.Context, query string) ([]types.EvidenceEvent, error) {
-	// Determine search type from query
-	searchType := "code"
-	if strings.Contains(query, "type:pr") {
-		searchType = "pr"
-		query = strings.ReplaceAll(query, "type:pr", "is:pr")
-	} else if strings.Contains(query, "type:issue") {
-		searchType = "issue"
-		query = strings.ReplaceAll(query, "type:issue", "is:issue")
-	} else if strings.Contains(query, "type:commit") {
-		searchType = "commit"
-		query = strings.ReplaceAll(query, "type:commit", "")
+	parsed, err := ParseQuery(g.Name(), query)
+	if err != nil {
+		return nil, err
 	}
+	return g.CollectQuery(ctx, parsed)
+}
+
+// CollectQuery retrieves evidence from GitHub for a structured query.
+// The "type" filter selects the search: "pr", "issue", "commit", or code search otherwise.
+func (g *GitHubConnector) CollectQuery(ctx context.Context, q ConnectorQuery) ([]types.EvidenceEvent, error) {
+	searchType, query := githubSearch(q)
 
 	// Build API URL
 	var endpoint string
@@ -124,7 +124,7 @@ func (g *GitHubConnector) Collect(ctx context.Context, query string) ([]types.Ev
 	// Convert to evidence events
 	events := make([]types.EvidenceEvent, 0, len(result.Items))
 	for _, item := range result.Items {
-		event, err := g.convertToEvent(searchType, item)
+		event, err := GitHubEvent(searchType, item)
 		if err != nil {
 			// Log error but continue processing other items
 			continue
@@ -135,8 +135,53 @@ func (g *GitHubConnector) Collect(ctx context.Context, query string) ([]types.Ev
 	return events, nil
 }
 
-// convertToEvent converts a GitHub API response item to an EvidenceEvent.
-func (g *GitHubConnector) convertToEvent(searchType string, item json.RawMessage) (types.EvidenceEvent, error) {
+// githubSearch returns the search type and GitHub search string for q. The
+// type filter becomes is:pr or is:issue (commits have their own endpoint) and
+// the time range becomes a created (author-date for commits) qualifier.
+func githubSearch(q ConnectorQuery) (searchType, query string) {
+	searchType = "code"
+	var parts []string
+	for _, key := range q.filterKeys() {
+		for _, value := range q.filterValues(key) {
+			if key == "type" {
+				switch value {
+				case "pr", "issue":
+					searchType = value
+					parts = append(parts, "is:"+value)
+					continue
+				case "commit":
+					searchType = value
+					continue
+				}
+			}
+			parts = append(parts, key+":"+quoteQueryValue(value))
+		}
+	}
+
+	if !q.Since.IsZero() || !q.Until.IsZero() {
+		qualifier := "created"
+		if searchType == "commit" {
+			qualifier = "author-date"
+		}
+		switch {
+		case q.Until.IsZero():
+			parts = append(parts, qualifier+":>="+formatQueryTime(q.Since))
+		case q.Since.IsZero():
+			parts = append(parts, qualifier+":<="+formatQueryTime(q.Until))
+		default:
+			parts = append(parts, qualifier+":"+formatQueryTime(q.Since)+".."+formatQueryTime(q.Until))
+		}
+	}
+
+	if q.Text != "" {
+		parts = append(parts, q.Text)
+	}
+	return searchType, strings.Join(parts, " ")
+}
+
+// GitHubEvent converts a GitHub API item (an issue, pull request or commit, as
+// given by itemType "issue", "pr" or "commit") to an EvidenceEvent.
+func GitHubEvent(itemType string, item json.RawMessage) (types.EvidenceEvent, error) {
 	// Parse common fields
 	var common struct {
 		ID        int64     `json:"id"`
@@ -165,7 +210,7 @@ func (g *GitHubConnector) convertToEvent(searchType string, item json.RawMessage
 	event := types.EvidenceEvent{
 		ID:        fmt.Sprintf("github-%d", common.ID),
 		Source:    "github",
-		Type:      searchType,
+		Type:      itemType,
 		Timestamp: common.CreatedAt,
 		Content:   "", // Will be populated below
 		Metadata: map[string]interface{}{
@@ -176,7 +221,7 @@ func (g *GitHubConnector) convertToEvent(searchType string, item json.RawMessage
 	}
 
 	// Add type-specific fields
-	switch searchType {
+	switch itemType {
 	case "pr", "issue":
 		var details struct {
 			Title  string `json:"title"`
@@ -204,7 +249,8 @@ func (g *GitHubConnector) convertToEvent(searchType string, item json.RawMessage
 			Commit struct {
 				Message string `json:"message"`
 				Author  struct {
-					Name string `json:"name"`
+					Name string    `json:"name"`
+					Date time.Time `json:"date"`
 				} `json:"author"`
 			} `json:"commit"`
 			SHA string `json:"sha"`
@@ -212,6 +258,12 @@ func (g *GitHubConnector) convertToEvent(searchType string, item json.RawMessage
 		if err := json.Unmarshal(item, &details); err == nil {
 			event.Content = details.Commit.Message
 			event.Metadata["sha"] = details.SHA
+			if common.ID == 0 && details.SHA != "" {
+				event.ID = "github-" + details.SHA // Commits have no numeric ID
+			}
+			if event.Timestamp.IsZero() {
+				event.Timestamp = details.Commit.Author.Date
+			}
 			event.Metadata["author_name"] = details.Commit.Author.Name
 		}
 	}
@@ -0,0 +1,272 @@
+package connectors
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/pickjonathan/sdek-cli/pkg/types"
+)
+
+// jiraTimeLayout is the timestamp format of Jira REST API fields
+const jiraTimeLayout = "2006-01-02T15:04:05.000-0700"
+
+// JiraConnector collects evidence from Jira issues using the search API.
+type JiraConnector struct {
+	config     Config
+	client     *http.Client
+	baseURL    string
+	apiToken   string
+	email      string // With an email, Jira Cloud basic auth is used instead of a bearer token
+	projectKey string // Default project for structured queries without a project filter
+}
+
+// NewJiraConnector creates a new Jira connector instance.
+func NewJiraConnector(cfg Config) (Connector, error) {
+	if cfg.Endpoint == "" {
+		return nil, fmt.Errorf("endpoint is required for Jira (e.g., https://your-domain.atlassian.net)")
+	}
+
+	timeout := time.Duration(cfg.Timeout) * time.Second
+	if timeout == 0 {
+		timeout = 30 * time.Second
+	}
+
+	email, _ := cfg.Extra["email"].(string)
+	projectKey, _ := cfg.Extra["project_key"].(string)
+
+	return &JiraConnector{
+		config:     cfg,
+		client:     &http.Client{Timeout: timeout},
+		baseURL:    strings.TrimSuffix(cfg.Endpoint, "/"),
+		apiToken:   cfg.APIKey,
+		email:      email,
+		projectKey: projectKey,
+	}, nil
+}
+
+// Name returns the connector identifier.
+func (j *JiraConnector) Name() string {
+	return "jira"
+}
+
+// Collect retrieves evidence from Jira using the provided query.
+// Query format: JQL (e.g., `project = SEC AND labels = access-review`), sent as is.
+func (j *JiraConnector) Collect(ctx context.Context, query string) ([]types.EvidenceEvent, error) {
+	if strings.TrimSpace(query) == "" {
+		return nil, fmt.Errorf("%w: empty JQL", ErrInvalidQuery)
+	}
+	return j.search(ctx, query)
+}
+
+// CollectQuery retrieves evidence from Jira for a structured query, translated to JQL.
+func (j *JiraConnector) CollectQuery(ctx context.Context, q ConnectorQuery) ([]types.EvidenceEvent, error) {
+	if _, ok := q.Filters["project"]; !ok && j.projectKey != "" {
+		filters := map[string]string{"project": j.projectKey}
+		for key, value := range q.Filters {
+			filters[key] = value
+		}
+		q.Filters = filters
+	}
+
+	jql := jiraJQL(q)
+	if jql == "" {
+		return nil, fmt.Errorf("%w: query has no filters, text or time range", ErrInvalidQuery)
+	}
+	return j.search(ctx, jql+" ORDER BY created DESC")
+}
+
+// jiraJQL translates q to JQL. Filters become equality clauses (the "type"
+// filter matches issuetype), text is matched with text ~ and the time range
+// bounds the created field.
+func jiraJQL(q ConnectorQuery) string {
+	var clauses []string
+	for _, key := range q.filterKeys() {
+		field, negate := strings.CutPrefix(key, "-")
+		if field == "type" {
+			field = "issuetype"
+		}
+		for _, value := range q.filterValues(key) {
+			op := "="
+			if negate {
+				op = "!="
+			}
+			clauses = append(clauses, fmt.Sprintf("%s %s %s", field, op, jqlQuote(value)))
+		}
+	}
+	if q.Text != "" {
+		clauses = append(clauses, "text ~ "+jqlQuote(q.Text))
+	}
+	if !q.Since.IsZero() {
+		clauses = append(clauses, "created >= "+jqlQuote(jqlTime(q.Since)))
+	}
+	if !q.Until.IsZero() {
+		clauses = append(clauses, "created <= "+jqlQuote(jqlTime(q.Until)))
+	}
+	return strings.Join(clauses, " AND ")
+}
+
+// jqlQuote double-quotes a JQL value, escaping quotes and backslashes
+func jqlQuote(value string) string {
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(value) + `"`
+}
+
+// jqlTime formats t as a JQL date, with the time of day when it is not midnight UTC
+func jqlTime(t time.Time) string {
+	t = t.UTC()
+	if t.Equal(t.Truncate(24 * time.Hour)) {
+		return t.Format("2006-01-02")
+	}
+	return t.Format("2006-01-02 15:04")
+}
+
+// search runs a JQL search and converts the matching issues to events.
+func (j *JiraConnector) search(ctx context.Context, jql string) ([]types.EvidenceEvent, error) {
+	apiURL := fmt.Sprintf("%s/rest/api/2/search?jql=%s&maxResults=100", j.baseURL, url.QueryEscape(jql))
+
+	req, err := http.NewRequestWithContext(ctx, "GET", apiURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	j.authorize(req)
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := j.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("jira API request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+	case http.StatusTooManyRequests:
+		return nil, ErrRateLimited
+	case http.StatusUnauthorized:
+		return nil, ErrAuthFailed
+	case http.StatusForbidden:
+		return nil, ErrPermissionDenied
+	case http.StatusBadRequest:
+		return nil, fmt.Errorf("%w: jira rejected JQL %q", ErrInvalidQuery, jql)
+	default:
+		return nil, fmt.Errorf("jira API returned status %d", resp.StatusCode)
+	}
+
+	var result struct {
+		Issues []json.RawMessage `json:"issues"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	events := make([]types.EvidenceEvent, 0, len(result.Issues))
+	for _, issue := range result.Issues {
+		event, err := j.convertToEvent(issue)
+		if err != nil {
+			// Skip malformed issues but keep the rest
+			continue
+		}
+		events = append(events, event)
+	}
+
+	return events, nil
+}
+
+// convertToEvent converts a Jira issue to an EvidenceEvent.
+func (j *JiraConnector) convertToEvent(item json.RawMessage) (types.EvidenceEvent, error) {
+	var issue struct {
+		ID     string `json:"id"`
+		Key    string `json:"key"`
+		Fields struct {
+			Summary     string   `json:"summary"`
+			Description string   `json:"description"`
+			Created     string   `json:"created"`
+			Labels      []string `json:"labels"`
+			Status      struct {
+				Name string `json:"name"`
+			} `json:"status"`
+			IssueType struct {
+				Name string `json:"name"`
+			} `json:"issuetype"`
+			Reporter struct {
+				DisplayName string `json:"displayName"`
+			} `json:"reporter"`
+		} `json:"fields"`
+	}
+	if err := json.Unmarshal(item, &issue); err != nil {
+		return types.EvidenceEvent{}, fmt.Errorf("failed to parse issue: %w", err)
+	}
+	if issue.Key == "" {
+		return types.EvidenceEvent{}, fmt.Errorf("issue has no key")
+	}
+
+	created, err := time.Parse(jiraTimeLayout, issue.Fields.Created)
+	if err != nil {
+		created, _ = time.Parse(time.RFC3339, issue.Fields.Created)
+	}
+
+	labels := issue.Fields.Labels
+	if labels == nil {
+		labels = []string{}
+	}
+
+	return types.EvidenceEvent{
+		ID:        "jira-" + issue.Key,
+		Source:    "jira",
+		Type:      "issue",
+		Timestamp: created,
+		Content:   issue.Fields.Summary + "\n\n" + issue.Fields.Description,
+		Metadata: map[string]interface{}{
+			"key":        issue.Key,
+			"html_url":   j.baseURL + "/browse/" + issue.Key,
+			"actor":      issue.Fields.Reporter.DisplayName,
+			"title":      issue.Fields.Summary,
+			"state":      issue.Fields.Status.Name,
+			"issue_type": issue.Fields.IssueType.Name,
+			"labels":     labels,
+		},
+	}, nil
+}
+
+// authorize adds credentials: basic auth with the configured email (Jira
+// Cloud), otherwise the token as a bearer token (Jira Server/Data Center)
+func (j *JiraConnector) authorize(req *http.Request) {
+	if j.email != "" {
+		req.SetBasicAuth(j.email, j.apiToken)
+		return
+	}
+	req.Header.Set("Authorization", "Bearer "+j.apiToken)
+}
+
+// Validate checks if the Jira connector is properly configured.
+func (j *JiraConnector) Validate(ctx context.Context) error {
+	if j.apiToken == "" {
+		return fmt.Errorf("Jira API token is required")
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "GET", j.baseURL+"/rest/api/2/myself", nil)
+	if err != nil {
+		return fmt.Errorf("failed to create validation request: %w", err)
+	}
+	j.authorize(req)
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := j.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("validation request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusUnauthorized {
+		return ErrAuthFailed
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("validation failed with status %d", resp.StatusCode)
+	}
+
+	return nil
+}
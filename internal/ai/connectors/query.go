@@ -0,0 +1,226 @@
+package connectors
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/pickjonathan/sdek-cli/pkg/types"
+)
+
+// ConnectorQuery is a structured evidence query. Connectors that implement
+// QueryCollector translate it into their own query language, so the same
+// filters and time range mean the same thing for every source.
+type ConnectorQuery struct {
+	// Source is the connector the query is meant for (e.g., "github", "jira")
+	Source string `json:"source"`
+
+	// Filters maps a field to the value it must match (e.g., "type": "pr",
+	// "label": "security"). A key starting with "-" excludes the value.
+	// Several values for one field are separated by commas and must all match.
+	Filters map[string]string `json:"filters,omitempty"`
+
+	// Text holds free-text search terms
+	Text string `json:"text,omitempty"`
+
+	// Since and Until bound when the evidence was created; zero means unbounded
+	Since time.Time `json:"since,omitempty"`
+	Until time.Time `json:"until,omitempty"`
+}
+
+// QueryCollector is implemented by connectors that accept structured queries.
+// Connectors without it are sent the query's String form.
+type QueryCollector interface {
+	// CollectQuery retrieves evidence events matching the structured query
+	CollectQuery(ctx context.Context, query ConnectorQuery) ([]types.EvidenceEvent, error)
+}
+
+// queryDateLayout is the date format used in query strings
+const queryDateLayout = "2006-01-02"
+
+// ParseQuery parses a string query of space-separated "field:value" filters
+// and free-text terms into a ConnectorQuery for source. Values containing
+// spaces are double-quoted (label:"needs review"). The time range is given
+// as since:DATE and until:DATE, or in GitHub form as created:>=DATE,
+// created:<=DATE or created:DATE..DATE (author-date for commits). "is:pr"
+// and "is:issue" are read as type filters. Repeated fields are combined into
+// a comma-separated value.
+func ParseQuery(source, raw string) (ConnectorQuery, error) {
+	query := ConnectorQuery{Source: source}
+	tokens, err := splitQuery(raw)
+	if err != nil {
+		return ConnectorQuery{}, err
+	}
+
+	var text []string
+	for _, token := range tokens {
+		key, value, ok := strings.Cut(token, ":")
+		if !ok || key == "" || strings.ContainsAny(key, `"`) {
+			text = append(text, token)
+			continue
+		}
+		key = strings.ToLower(key)
+		value = strings.Trim(value, `"`)
+		if value == "" {
+			return ConnectorQuery{}, fmt.Errorf("%w: empty value for %q", ErrInvalidQuery, key)
+		}
+
+		switch key {
+		case "since", "until":
+			t, err := parseQueryTime(value)
+			if err != nil {
+				return ConnectorQuery{}, fmt.Errorf("%w: %s: %v", ErrInvalidQuery, key, err)
+			}
+			if key == "since" {
+				query.Since = t
+			} else {
+				query.Until = t
+			}
+			continue
+		case "created", "author-date":
+			if since, until, ok := parseCreatedRange(value); ok {
+				query.Since, query.Until = since, until
+				continue
+			}
+		case "is":
+			if value == "pr" || value == "issue" {
+				key = "type"
+			}
+		}
+
+		if query.Filters == nil {
+			query.Filters = make(map[string]string)
+		}
+		if existing, ok := query.Filters[key]; ok {
+			value = existing + "," + value
+		}
+		query.Filters[key] = value
+	}
+	query.Text = strings.Join(text, " ")
+
+	if !query.Since.IsZero() && !query.Until.IsZero() && query.Until.Before(query.Since) {
+		return ConnectorQuery{}, fmt.Errorf("%w: until %s is before since %s", ErrInvalidQuery,
+			formatQueryTime(query.Until), formatQueryTime(query.Since))
+	}
+	return query, nil
+}
+
+// String renders the query in the syntax read by ParseQuery
+func (q ConnectorQuery) String() string {
+	var parts []string
+	for _, key := range q.filterKeys() {
+		for _, value := range q.filterValues(key) {
+			parts = append(parts, key+":"+quoteQueryValue(value))
+		}
+	}
+	if !q.Since.IsZero() {
+		parts = append(parts, "since:"+formatQueryTime(q.Since))
+	}
+	if !q.Until.IsZero() {
+		parts = append(parts, "until:"+formatQueryTime(q.Until))
+	}
+	if q.Text != "" {
+		parts = append(parts, q.Text)
+	}
+	return strings.Join(parts, " ")
+}
+
+// filterKeys returns the filter fields in sorted order
+func (q ConnectorQuery) filterKeys() []string {
+	keys := make([]string, 0, len(q.Filters))
+	for key := range q.Filters {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// filterValues splits the comma-separated values of a filter field
+func (q ConnectorQuery) filterValues(key string) []string {
+	var values []string
+	for _, value := range strings.Split(q.Filters[key], ",") {
+		if value = strings.TrimSpace(value); value != "" {
+			values = append(values, value)
+		}
+	}
+	return values
+}
+
+// splitQuery splits a query on spaces, keeping double-quoted runs together
+func splitQuery(raw string) ([]string, error) {
+	var tokens []string
+	var current strings.Builder
+	quoted := false
+	for _, r := range raw {
+		switch {
+		case r == '"':
+			quoted = !quoted
+			current.WriteRune(r)
+		case (r == ' ' || r == '\t' || r == '\n') && !quoted:
+			if current.Len() > 0 {
+				tokens = append(tokens, current.String())
+				current.Reset()
+			}
+		default:
+			current.WriteRune(r)
+		}
+	}
+	if quoted {
+		return nil, fmt.Errorf("%w: unterminated quote", ErrInvalidQuery)
+	}
+	if current.Len() > 0 {
+		tokens = append(tokens, current.String())
+	}
+	return tokens, nil
+}
+
+// parseCreatedRange reads a GitHub date qualifier (>=DATE, <=DATE or
+// DATE..DATE). Other forms are left to be passed through as a filter.
+func parseCreatedRange(value string) (since, until time.Time, ok bool) {
+	var err error
+	switch {
+	case strings.HasPrefix(value, ">="):
+		since, err = parseQueryTime(value[2:])
+	case strings.HasPrefix(value, "<="):
+		until, err = parseQueryTime(value[2:])
+	case strings.Contains(value, ".."):
+		start, end, _ := strings.Cut(value, "..")
+		if since, err = parseQueryTime(start); err == nil {
+			until, err = parseQueryTime(end)
+		}
+	default:
+		return time.Time{}, time.Time{}, false
+	}
+	return since, until, err == nil
+}
+
+// parseQueryTime parses a date (2006-01-02) or an RFC 3339 timestamp
+func parseQueryTime(value string) (time.Time, error) {
+	if t, err := time.Parse(queryDateLayout, value); err == nil {
+		return t, nil
+	}
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid date %q, use YYYY-MM-DD or RFC 3339", value)
+	}
+	return t.UTC(), nil
+}
+
+// formatQueryTime formats t as a date when it falls on midnight UTC, otherwise as RFC 3339
+func formatQueryTime(t time.Time) string {
+	t = t.UTC()
+	if t.Equal(t.Truncate(24 * time.Hour)) {
+		return t.Format(queryDateLayout)
+	}
+	return t.Format(time.RFC3339)
+}
+
+// quoteQueryValue double-quotes values containing spaces
+func quoteQueryValue(value string) string {
+	if strings.ContainsAny(value, " \t") {
+		return `"` + value + `"`
+	}
+	return value
+}
@@ -0,0 +1,235 @@
+package connectors
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/pickjonathan/sdek-cli/pkg/types"
+)
+
+func TestParseQuery(t *testing.T) {
+	tests := []struct {
+		name    string
+		raw     string
+		want    ConnectorQuery
+		wantErr bool
+	}{
+		{
+			name: "filters and text",
+			raw:  `type:pr label:security label:"needs review" access review`,
+			want: ConnectorQuery{
+				Source:  "github",
+				Filters: map[string]string{"type": "pr", "label": "security,needs review"},
+				Text:    "access review",
+			},
+		},
+		{
+			name: "since and until",
+			raw:  "since:2024-01-01 until:2024-03-31T12:00:00Z",
+			want: ConnectorQuery{
+				Source: "github",
+				Since:  time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+				Until:  time.Date(2024, 3, 31, 12, 0, 0, 0, time.UTC),
+			},
+		},
+		{
+			name: "github created range and is:issue",
+			raw:  "is:issue created:2024-01-01..2024-02-01",
+			want: ConnectorQuery{
+				Source:  "github",
+				Filters: map[string]string{"type": "issue"},
+				Since:   time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+				Until:   time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC),
+			},
+		},
+		{
+			name: "unrecognized created form stays a filter",
+			raw:  "created:>2024-01-01",
+			want: ConnectorQuery{Source: "github", Filters: map[string]string{"created": ">2024-01-01"}},
+		},
+		{name: "empty value", raw: "label:", wantErr: true},
+		{name: "bad date", raw: "since:yesterday", wantErr: true},
+		{name: "unterminated quote", raw: `label:"needs review`, wantErr: true},
+		{name: "until before since", raw: "since:2024-02-01 until:2024-01-01", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseQuery("github", tt.raw)
+			if tt.wantErr {
+				if !errors.Is(err, ErrInvalidQuery) {
+					t.Fatalf("Expected ErrInvalidQuery, got %v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseQuery failed: %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseQuery(%q) = %+v, want %+v", tt.raw, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestConnectorQuery_StringRoundTrip(t *testing.T) {
+	query := ConnectorQuery{
+		Source:  "github",
+		Filters: map[string]string{"label": "security,needs review", "-author": "bot"},
+		Text:    "mfa enforcement",
+		Since:   time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+	}
+
+	parsed, err := ParseQuery("github", query.String())
+	if err != nil {
+		t.Fatalf("ParseQuery(%q) failed: %v", query.String(), err)
+	}
+	if !reflect.DeepEqual(parsed, query) {
+		t.Errorf("Round trip of %q = %+v, want %+v", query.String(), parsed, query)
+	}
+}
+
+func TestGitHubConnector_StructuredQueryRoundTrip(t *testing.T) {
+	var gotPath, gotQuery string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotPath, gotQuery = r.URL.Path, r.URL.Query().Get("q")
+		w.Write([]byte(`{"items":[{"id":7,"html_url":"https://github.com/org/repo/pull/7","created_at":"2024-02-01T10:00:00Z","title":"Enforce MFA","body":"Adds MFA","state":"closed","user":{"login":"alice"},"labels":[{"name":"security"}]}]}`))
+	}))
+	defer server.Close()
+
+	connector, err := NewGitHubConnector(Config{APIKey: "token", Endpoint: server.URL})
+	if err != nil {
+		t.Fatalf("NewGitHubConnector failed: %v", err)
+	}
+
+	query := ConnectorQuery{
+		Source:  "github",
+		Filters: map[string]string{"type": "pr", "label": "security", "repo": "org/repo"},
+		Text:    "mfa",
+		Since:   time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+		Until:   time.Date(2024, 3, 31, 0, 0, 0, 0, time.UTC),
+	}
+	events, err := connector.(QueryCollector).CollectQuery(context.Background(), query)
+	if err != nil {
+		t.Fatalf("CollectQuery failed: %v", err)
+	}
+
+	if gotPath != "/search/issues" {
+		t.Errorf("Expected /search/issues, got %s", gotPath)
+	}
+	wantQuery := "label:security repo:org/repo is:pr created:2024-01-01..2024-03-31 mfa"
+	if gotQuery != wantQuery {
+		t.Errorf("Search string = %q, want %q", gotQuery, wantQuery)
+	}
+
+	// The search string GitHub received parses back into the structured query
+	parsed, err := ParseQuery("github", gotQuery)
+	if err != nil {
+		t.Fatalf("ParseQuery failed: %v", err)
+	}
+	if !reflect.DeepEqual(parsed, query) {
+		t.Errorf("Round trip = %+v, want %+v", parsed, query)
+	}
+
+	if len(events) != 1 || events[0].ID != "github-7" || events[0].Type != "pr" {
+		t.Errorf("Unexpected events: %+v", events)
+	}
+
+	// A string query takes the same path
+	if _, err := connector.Collect(context.Background(), "type:commit author-date:>=2024-01-01 fix"); err != nil {
+		t.Fatalf("Collect failed: %v", err)
+	}
+	if gotPath != "/search/commits" || gotQuery != "author-date:>=2024-01-01 fix" {
+		t.Errorf("Commit search = %s %q", gotPath, gotQuery)
+	}
+}
+
+func TestJiraConnector_StructuredQueryRoundTrip(t *testing.T) {
+	var gotJQL, gotAuth string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/rest/api/2/search" {
+			t.Errorf("Unexpected path %s", r.URL.Path)
+		}
+		gotJQL, gotAuth = r.URL.Query().Get("jql"), r.Header.Get("Authorization")
+		w.Write([]byte(`{"issues":[{"id":"10001","key":"SEC-42","fields":{"summary":"Quarterly access review","description":"Reviewed admin accounts","created":"2024-02-01T10:00:00.000+0000","labels":["access-review"],"status":{"name":"Done"},"issuetype":{"name":"Task"},"reporter":{"displayName":"Bob"}}}]}`))
+	}))
+	defer server.Close()
+
+	cfg := Config{APIKey: "token", Endpoint: server.URL, Extra: map[string]interface{}{"project_key": "SEC"}}
+	connector, err := NewJiraConnector(cfg)
+	if err != nil {
+		t.Fatalf("NewJiraConnector failed: %v", err)
+	}
+
+	query, err := ParseQuery("jira", `type:Task labels:access-review -status:"Won't Do" since:2024-01-01 until:2024-03-31 admin`)
+	if err != nil {
+		t.Fatalf("ParseQuery failed: %v", err)
+	}
+	events, err := connector.(QueryCollector).CollectQuery(context.Background(), query)
+	if err != nil {
+		t.Fatalf("CollectQuery failed: %v", err)
+	}
+
+	wantJQL := `status != "Won't Do" AND labels = "access-review" AND project = "SEC" AND issuetype = "Task" AND text ~ "admin" AND created >= "2024-01-01" AND created <= "2024-03-31" ORDER BY created DESC`
+	if gotJQL != wantJQL {
+		t.Errorf("JQL = %q, want %q", gotJQL, wantJQL)
+	}
+	if gotAuth != "Bearer token" {
+		t.Errorf("Authorization = %q, want bearer token", gotAuth)
+	}
+
+	if len(events) != 1 {
+		t.Fatalf("Expected 1 event, got %d", len(events))
+	}
+	event := events[0]
+	if event.ID != "jira-SEC-42" || event.Source != "jira" || event.Metadata["state"] != "Done" {
+		t.Errorf("Unexpected event: %+v", event)
+	}
+	if want := time.Date(2024, 2, 1, 10, 0, 0, 0, time.UTC); !event.Timestamp.Equal(want) {
+		t.Errorf("Timestamp = %v, want %v", event.Timestamp, want)
+	}
+
+	// String queries are JQL and are sent unchanged
+	if _, err := connector.Collect(context.Background(), `project = SEC AND labels = "sox"`); err != nil {
+		t.Fatalf("Collect failed: %v", err)
+	}
+	if gotJQL != `project = SEC AND labels = "sox"` {
+		t.Errorf("JQL = %q, want it unchanged", gotJQL)
+	}
+}
+
+func TestJiraJQL_Quoting(t *testing.T) {
+	got := jiraJQL(ConnectorQuery{Text: `say "hi" \ bye`})
+	if want := `text ~ "say \"hi\" \\ bye"`; got != want {
+		t.Errorf("jiraJQL = %q, want %q", got, want)
+	}
+}
+
+func TestRegistry_CollectQuery(t *testing.T) {
+	registry := NewRegistry()
+	cfg := DefaultConfig()
+	cfg.Extra = map[string]interface{}{"name": "mock"}
+	connector, _ := NewMockConnector(cfg)
+	mock := connector.(*MockConnector)
+	query := ConnectorQuery{Source: "mock", Filters: map[string]string{"label": "security"}, Text: "mfa"}
+	mock.SetEvents(query.String(), []types.EvidenceEvent{{ID: "mock-1"}, {ID: "mock-2"}})
+	registry.Register(mock)
+
+	// Connectors without CollectQuery get the string form
+	events, err := registry.CollectQuery(context.Background(), query)
+	if err != nil {
+		t.Fatalf("CollectQuery failed: %v", err)
+	}
+	if len(events) != 2 {
+		t.Errorf("Expected 2 events, got %d", len(events))
+	}
+
+	if _, err := registry.CollectQuery(context.Background(), ConnectorQuery{Source: "missing"}); !errors.Is(err, ErrSourceNotFound) {
+		t.Errorf("Expected ErrSourceNotFound, got %v", err)
+	}
+}
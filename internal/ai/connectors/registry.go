@@ -88,6 +88,28 @@ func (r *Registry) Collect(ctx context.Context, source string, query string) ([]
 	return events, nil
 }
 
+// CollectQuery routes a structured query to the connector named by query.Source.
+// Connectors that don't implement QueryCollector receive the query's String form.
+func (r *Registry) CollectQuery(ctx context.Context, query ConnectorQuery) ([]types.EvidenceEvent, error) {
+	connector := r.Get(query.Source)
+	if connector == nil {
+		return nil, fmt.Errorf("%w: %s", ErrSourceNotFound, query.Source)
+	}
+
+	var events []types.EvidenceEvent
+	var err error
+	if qc, ok := connector.(QueryCollector); ok {
+		events, err = qc.CollectQuery(ctx, query)
+	} else {
+		events, err = connector.Collect(ctx, query.String())
+	}
+	if err != nil {
+		return nil, fmt.Errorf("connector %s failed: %w", query.Source, err)
+	}
+
+	return events, nil
+}
+
 // ValidateAll validates all registered connectors.
 // Returns a map of connector name to validation error (nil if valid).
 func (r *Registry) ValidateAll(ctx context.Context) map[string]error {
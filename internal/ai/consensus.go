@@ -0,0 +1,152 @@
+package ai
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"math"
+	"sync"
+
+	"github.com/pickjonathan/sdek-cli/pkg/types"
+)
+
+// DefaultConsensusThreshold is the disagreement score above which a consensus
+// finding is flagged for review when AIConfig.ConsensusThreshold is unset
+const DefaultConsensusThreshold = 0.3
+
+// ConsensusResult is the outcome of analyzing the same evidence with several providers
+type ConsensusResult struct {
+	// Finding merges the provider findings: mapped controls and citations are
+	// unioned, confidence is averaged, and the highest residual risk and
+	// severity win. It is flagged for review when the providers disagree.
+	Finding *types.Finding
+
+	// Findings holds each provider's own finding, in the order the providers were given
+	Findings []*types.Finding
+
+	// Disagreement scores how far the providers diverge, from 0 (identical
+	// confidence, mapped controls and residual risk) to 1
+	Disagreement float64
+}
+
+// NamedProvider is implemented by providers that report the provider and model
+// they call, so consensus findings, metrics and audit entries name the provider
+// that produced them rather than the configured one
+type NamedProvider interface {
+	// ProviderName returns the provider identifier, such as "openai"
+	ProviderName() string
+
+	// ModelName returns the model in use; "" means the provider's default model
+	ModelName() string
+}
+
+// AnalyzeConsensus implements Engine.AnalyzeConsensus
+func (e *engineImpl) AnalyzeConsensus(ctx context.Context, preamble types.ContextPreamble, evidence types.EvidenceBundle, providers ...Provider) (*ConsensusResult, error) {
+	if len(providers) < 2 {
+		return nil, fmt.Errorf("%w: consensus analysis needs at least two providers, got %d", ErrInvalidRequest, len(providers))
+	}
+
+	// Each provider must answer for itself, so skip the shared analysis cache
+	ctx = WithCacheMode(ctx, CacheBypass)
+
+	findings := make([]*types.Finding, len(providers))
+	errs := make([]error, len(providers))
+	var wg sync.WaitGroup
+	for i, provider := range providers {
+		wg.Add(1)
+		go func(i int, provider Provider) {
+			defer wg.Done()
+			findings[i], errs[i] = e.forProvider(provider).Analyze(ctx, preamble, evidence)
+		}(i, provider)
+	}
+	wg.Wait()
+
+	for i, err := range errs {
+		if err != nil {
+			return nil, fmt.Errorf("consensus provider %d: %w", i+1, err)
+		}
+	}
+
+	chunks := make([]ChunkFinding, len(findings))
+	for i, finding := range findings {
+		chunks[i] = ChunkFinding{Finding: finding, Events: 1}
+	}
+	merged := MergeChunkFindings(chunks)
+
+	disagreement := consensusDisagreement(findings)
+	threshold := e.config.AI.ConsensusThreshold
+	if threshold <= 0 {
+		threshold = DefaultConsensusThreshold
+	}
+	if disagreement > threshold {
+		merged.ReviewRequired = true
+		slog.Warn("Providers disagree on analysis, flagging for review",
+			"control", preamble.Section,
+			"disagreement", disagreement,
+			"threshold", threshold)
+	}
+
+	return &ConsensusResult{
+		Finding:      merged,
+		Findings:     findings,
+		Disagreement: disagreement,
+	}, nil
+}
+
+// forProvider returns a copy of the engine that calls provider, labelled with
+// the provider and model it reports when it implements NamedProvider
+func (e *engineImpl) forProvider(provider Provider) *engineImpl {
+	named, ok := provider.(NamedProvider)
+	if !ok {
+		engine := *e
+		engine.provider = provider
+		return &engine
+	}
+	engine := e.withProvider(provider, named.ModelName())
+	engine.config.AI.Provider = named.ProviderName()
+	return engine
+}
+
+// consensusDisagreement averages three 0-1 measures across the findings: the
+// spread of confidence scores, the share of mapped controls not common to all
+// findings, and the spread of residual risk levels
+func consensusDisagreement(findings []*types.Finding) float64 {
+	minConfidence, maxConfidence := math.Inf(1), math.Inf(-1)
+	minRisk, maxRisk := math.MaxInt, math.MinInt
+	controlCounts := make(map[string]int)
+	for _, finding := range findings {
+		minConfidence = math.Min(minConfidence, finding.ConfidenceScore)
+		maxConfidence = math.Max(maxConfidence, finding.ConfidenceScore)
+
+		rank := riskRank[string(types.ResidualRiskMedium)]
+		if level, ok := types.NormalizeResidualRisk(finding.ResidualRisk); ok {
+			rank = riskRank[string(level)]
+		}
+		minRisk, maxRisk = min(minRisk, rank), max(maxRisk, rank)
+
+		seen := make(map[string]bool)
+		for _, control := range finding.MappedControls {
+			if !seen[control] {
+				seen[control] = true
+				controlCounts[control]++
+			}
+		}
+	}
+
+	confidence := math.Min(maxConfidence-minConfidence, 1)
+
+	var controls float64
+	if len(controlCounts) > 0 {
+		shared := 0
+		for _, count := range controlCounts {
+			if count == len(findings) {
+				shared++
+			}
+		}
+		controls = 1 - float64(shared)/float64(len(controlCounts))
+	}
+
+	risk := float64(maxRisk-minRisk) / float64(len(riskRank)-1)
+
+	return (confidence + controls + risk) / 3
+}
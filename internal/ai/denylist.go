@@ -0,0 +1,63 @@
+package ai
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/pickjonathan/sdek-cli/pkg/types"
+)
+
+// denylistPlaceholder replaces denylisted terms in redact mode
+const denylistPlaceholder = "[REDACTED:SECRET]"
+
+// denylistGuard scans final prompts for denylisted terms before they are sent to a provider.
+// Unlike the redactor, it runs on the whole prompt (including the framework excerpt) and
+// regardless of RedactionConfig.Enabled, so denylisted terms never leave the environment.
+type denylistGuard struct {
+	pattern *regexp.Regexp
+	block   bool
+}
+
+// newDenylistGuard creates a guard for the configured denylist, or nil if the denylist is empty
+func newDenylistGuard(cfg types.RedactionConfig) *denylistGuard {
+	terms := make([]string, 0, len(cfg.Denylist))
+	for _, term := range cfg.Denylist {
+		if strings.TrimSpace(term) != "" {
+			terms = append(terms, regexp.QuoteMeta(term))
+		}
+	}
+	if len(terms) == 0 {
+		return nil
+	}
+
+	// Longest terms first so overlapping terms are replaced whole
+	sort.SliceStable(terms, func(i, j int) bool {
+		return len(terms[i]) > len(terms[j])
+	})
+
+	return &denylistGuard{
+		pattern: regexp.MustCompile(`(?i)(?:` + strings.Join(terms, "|") + `)`),
+		block:   cfg.DenylistMode == types.DenylistModeBlock,
+	}
+}
+
+// Enforce returns the prompt with denylisted terms redacted, or ErrDenylistViolation in block mode.
+// Matching is case-insensitive. The matched terms are never included in the error.
+func (g *denylistGuard) Enforce(prompt string) (string, error) {
+	if g == nil {
+		return prompt, nil
+	}
+
+	matches := g.pattern.FindAllStringIndex(prompt, -1)
+	if len(matches) == 0 {
+		return prompt, nil
+	}
+
+	if g.block {
+		return "", fmt.Errorf("%w (%d occurrence(s))", ErrDenylistViolation, len(matches))
+	}
+
+	return g.pattern.ReplaceAllLiteralString(prompt, denylistPlaceholder), nil
+}
@@ -1,19 +1,32 @@
 package ai
 
 import (
+	"bytes"
 	"context"
 	"crypto/sha256"
 	"encoding/hex"
 	"encoding/json"
 	"fmt"
+	"io"
+	"log/slog"
 	"sort"
 	"strings"
+	"sync"
+	"text/template"
 	"time"
 
 	"github.com/pickjonathan/sdek-cli/internal/ai/connectors"
 	"github.com/pickjonathan/sdek-cli/pkg/types"
+	"go.opentelemetry.io/otel/trace"
 )
 
+// DefaultInvalidCitationThreshold is the fraction of unknown citations at which
+// a finding's confidence is reduced when AIConfig.InvalidCitationThreshold is unset
+const DefaultInvalidCitationThreshold = 0.25
+
+// DefaultDeterministicSeed is the sampling seed used in deterministic mode when AIConfig.Seed is unset
+const DefaultDeterministicSeed = 42
+
 // Engine is the core abstraction for AI provider integrations.
 // Implementations must support OpenAI and Anthropic initially.
 // All implementations MUST be safe for concurrent use.
@@ -31,6 +44,12 @@ type Engine interface {
 	// This is the primary analysis method for Feature 003
 	Analyze(ctx context.Context, preamble types.ContextPreamble, evidence types.EvidenceBundle) (*types.Finding, error)
 
+	// AnalyzeConsensus runs Analyze with each of the given providers in parallel,
+	// bypassing the cache, and merges their findings. The result carries a
+	// disagreement score; the merged finding is flagged for review when it
+	// exceeds AIConfig.ConsensusThreshold. At least two providers are required.
+	AnalyzeConsensus(ctx context.Context, preamble types.ContextPreamble, evidence types.EvidenceBundle, providers ...Provider) (*ConsensusResult, error)
+
 	// ProposePlan generates an evidence collection plan for autonomous mode (Feature 003)
 	// Plans are NOT cached (always fresh). Auto-approve policies are applied.
 	// Returns ErrNoPlanItems if provider returns empty plan.
@@ -44,6 +63,10 @@ type Engine interface {
 	// Returns ErrMCPConnectorFailed if all connector calls fail.
 	ExecutePlan(ctx context.Context, plan *types.EvidencePlan) (*types.EvidenceBundle, error)
 
+	// EstimatePlanCost estimates API calls, tokens and dollars for the approved items
+	// of a plan, priced for the configured model. Call it before ExecutePlan.
+	EstimatePlanCost(plan *types.EvidencePlan) PlanCostEstimate
+
 	// Provider returns the provider identifier ("openai" | "anthropic" | "mock").
 	Provider() string
 
@@ -78,8 +101,15 @@ type engineImpl struct {
 	provider           Provider
 	cache              *Cache
 	redactor           Redactor
+	denylist           *denylistGuard     // Final check on outgoing prompts; nil when the denylist is empty
+	promptTemplate     *template.Template // Custom analysis prompt; nil uses the built-in prompt
 	autoApproveMatcher AutoApproveMatcher
 	connector          MCPConnector // For ExecutePlan
+	audit              *AuditLogger // Optional provider call audit log
+	clock              types.Clock  // Timestamps findings and plans; see SetEngineClock
+
+	contextFallback      Provider // Retries prompts too long for the model; nil splits the evidence instead
+	contextFallbackModel string   // Model contextFallback calls
 }
 
 // NewEngine creates a new Engine instance with the given config and provider
@@ -95,6 +125,7 @@ func NewEngineWithConnector(cfg *types.Config, provider Provider, connector MCPC
 		// If cache creation fails, create in-memory cache
 		cache, _ = NewCache("")
 	}
+	cache.SetMaxBytes(cfg.AI.CacheMaxBytes)
 
 	// Initialize redactor
 	redactor := NewRedactor(cfg)
@@ -102,13 +133,55 @@ func NewEngineWithConnector(cfg *types.Config, provider Provider, connector MCPC
 	// Initialize auto-approve matcher
 	autoApproveMatcher := NewAutoApproveMatcher(cfg)
 
+	// Load custom prompt template (optional, falls back to the built-in prompt)
+	var promptTemplate *template.Template
+	if cfg.AI.PromptTemplate != "" {
+		promptTemplate, err = LoadPromptTemplate(cfg.AI.PromptTemplate)
+		if err != nil {
+			slog.Warn("Failed to load prompt template, using built-in prompt", "path", cfg.AI.PromptTemplate, "error", err)
+		}
+	}
+
+	// Initialize audit log (optional)
+	var audit *AuditLogger
+	if cfg.AuditLog.Path != "" {
+		audit, err = NewAuditLogger(cfg.AuditLog.Path)
+		if err != nil {
+			slog.Warn("Failed to initialize audit log, continuing without it", "path", cfg.AuditLog.Path, "error", err)
+		}
+	}
+
 	return &engineImpl{
 		config:             cfg,
 		provider:           provider,
 		cache:              cache,
 		redactor:           redactor,
+		denylist:           newDenylistGuard(cfg.AI.Redaction),
+		promptTemplate:     promptTemplate,
 		autoApproveMatcher: autoApproveMatcher,
 		connector:          connector,
+		audit:              audit,
+		clock:              types.SystemClock{},
+	}
+}
+
+// SetEngineClock replaces the clock an engine created by this package uses to
+// timestamp findings and plans and to judge evidence age. A fixed clock makes
+// output reproducible. Engines from other packages are left unchanged.
+func SetEngineClock(engine Engine, clock types.Clock) {
+	if impl, ok := engine.(*engineImpl); ok && clock != nil {
+		impl.clock = clock
+	}
+}
+
+// SetContextFallback sets the provider, calling the larger-context model, that
+// an analysis retries with when its prompt exceeds the model's context window.
+// Without one, the evidence is split into smaller chunks and retried instead.
+// Engines from other packages are left unchanged.
+func SetContextFallback(engine Engine, provider Provider, model string) {
+	if impl, ok := engine.(*engineImpl); ok && provider != nil {
+		impl.contextFallback = provider
+		impl.contextFallbackModel = model
 	}
 }
 
@@ -133,8 +206,8 @@ func buildConnectorRegistry(configs map[string]types.ConnectorConfig) (MCPConnec
 
 	// Register factories for available connectors
 	builder.RegisterFactory("github", connectors.NewGitHubConnector)
+	builder.RegisterFactory("jira", connectors.NewJiraConnector)
 	// TODO: Add more when implemented
-	// builder.RegisterFactory("jira", connectors.NewJiraConnector)
 	// builder.RegisterFactory("aws", connectors.NewAWSConnector)
 	// builder.RegisterFactory("slack", connectors.NewSlackConnector)
 
@@ -215,11 +288,11 @@ func (e *engineImpl) AnalyzeWithRequest(ctx context.Context, req *AnalysisReques
 		Justification: finding.Justification,
 		Confidence:    int(finding.ConfidenceScore * 100), // Convert 0-1 to 0-100
 		ResidualRisk:  string(finding.ResidualRisk),
-		Provider:      e.config.AI.Provider,
-		Model:         e.config.AI.Model,
+		Provider:      e.providerName(),
+		Model:         e.modelName(),
 		TokensUsed:    0, // Not tracked in Feature 003
 		Latency:       0, // Not tracked in Feature 003
-		Timestamp:     time.Now(),
+		Timestamp:     e.clock.Now(),
 		CacheHit:      false, // Cache hit detection would need to be added
 	}
 
@@ -228,18 +301,33 @@ func (e *engineImpl) AnalyzeWithRequest(ctx context.Context, req *AnalysisReques
 
 // Provider returns the provider name
 func (e *engineImpl) Provider() string {
-	return e.config.AI.Provider
+	return e.providerName()
 }
 
 // Health checks provider health
 func (e *engineImpl) Health(ctx context.Context) error {
 	// Basic health check - try a simple prompt
-	_, err := e.provider.AnalyzeWithContext(ctx, "Health check")
+	_, err := e.callProvider(ctx, AuditEntry{Operation: "health"}, "Health check")
 	return err
 }
 
 // Analyze performs AI analysis with context injection (Feature 003)
 func (e *engineImpl) Analyze(ctx context.Context, preamble types.ContextPreamble, evidence types.EvidenceBundle) (*types.Finding, error) {
+	attrs := append(e.providerAttributes(),
+		attrFramework.String(preamble.Framework),
+		attrControl.String(preamble.Section),
+		attrEvents.Int(len(evidence.Events)),
+	)
+	ctx, span := startSpan(ctx, SpanAnalyze, attrs...)
+	start := time.Now()
+	finding, err := e.analyze(ctx, preamble, evidence)
+	recordAnalysis(e.providerName(), start, err)
+	endSpan(span, err)
+	return finding, err
+}
+
+// analyze implements Analyze within its trace span
+func (e *engineImpl) analyze(ctx context.Context, preamble types.ContextPreamble, evidence types.EvidenceBundle) (*types.Finding, error) {
 	// Validate preamble
 	if err := preamble.Validate(); err != nil {
 		return nil, fmt.Errorf("invalid preamble: %w", err)
@@ -257,44 +345,124 @@ func (e *engineImpl) Analyze(ctx context.Context, preamble types.ContextPreamble
 		return e.createLowConfidenceFinding(preamble, "No evidence provided for analysis"), nil
 	}
 
+	timings := PhaseTimingsFromContext(ctx)
+
+	// Add the author, repository and URL of each event for the prompt to render
+	enriched := EnrichEvidence(evidence)
+
 	// Redact evidence
-	redactedEvents := make([]types.EvidenceEvent, len(evidence.Events))
-	for i, event := range evidence.Events {
-		redacted, _, err := e.redactor.Redact(event.Content)
+	redactStart := time.Now()
+	redactedEvents := make([]types.EvidenceEvent, len(enriched.Events))
+	redactions := &types.RedactionSummary{}
+	for i, event := range enriched.Events {
+		redacted, redactionMap, err := e.redactor.Redact(event.Content)
 		if err != nil {
 			return nil, fmt.Errorf("redaction failed: %w", err)
 		}
+		redactions.Add(redactionMap)
 		redactedEvents[i] = event
 		redactedEvents[i].Content = redacted
+		redactedEvents[i].Metadata, err = redactPromptMetadata(e.redactor, event.Metadata, redactions)
+		if err != nil {
+			return nil, fmt.Errorf("redaction failed: %w", err)
+		}
 	}
 	redactedEvidence := types.EvidenceBundle{Events: redactedEvents}
+	timings.Track(PhaseRedact, redactStart)
+	slog.Info("Redacted evidence", "events", len(redactedEvents), "redactions", redactions.Total, "by_category", redactions.ByCategory)
 
-	// Compute cache key
+	// Too little evidence isn't worth a provider call
+	if minEvents := e.config.AI.MinEventsForAI; len(redactedEvents) < minEvents {
+		finding := e.createLowConfidenceFinding(preamble, fmt.Sprintf("insufficient evidence (%d < %d)", len(redactedEvents), minEvents))
+		finding.Redactions = redactions
+		finding.Provenance = evidence.Provenance()
+		finding.RelatedControls = preamble.ControlIDs
+		finding.Labels = evidence.Labels()
+		return finding, nil
+	}
+
+	// Compute cache key. Findings of the context fallback model are cached
+	// under its own key, and served when the configured model has no entry.
 	cacheKey := e.computeCacheKey(preamble, redactedEvidence)
+	lookupKeys := []string{cacheKey}
+	var fallbackKey string
+	if e.contextFallback != nil {
+		fallbackKey = e.withProvider(e.contextFallback, e.contextFallbackModel).computeCacheKey(preamble, redactedEvidence)
+		lookupKeys = append(lookupKeys, fallbackKey)
+	}
+	cacheMode := CacheModeFromContext(ctx)
+	cacheEnabled := e.config.AI.CacheDir != "" && !e.config.AI.NoCache
 
 	// Check cache (unless NoCache is set)
-	if e.config.AI.CacheDir != "" && !e.config.AI.NoCache {
-		if cached, err := e.cache.Get(cacheKey); err == nil && cached != nil {
+	if cacheEnabled && cacheMode.reads() {
+		var cached *CachedResult
+		for _, key := range lookupKeys {
+			if entry, err := e.cache.Get(key); err == nil && entry != nil {
+				cached = entry
+				break
+			}
+		}
+		hit := cached != nil
+		recordCacheLookup(hit)
+		if hit {
+			trace.SpanFromContext(ctx).SetAttributes(attrCacheHit.Bool(true))
+
 			// Convert cached response to Finding
-			return e.responseToCachedFinding(cached, preamble), nil
+			finding := e.responseToCachedFinding(cached, preamble)
+			finding.Redactions = redactions
+			finding.Provenance = evidence.Provenance()
+			finding.RelatedControls = preamble.ControlIDs
+			finding.Labels = evidence.Labels()
+			e.requireCitations(finding)
+			e.markStaleEvidence(finding, evidence)
+			return finding, nil
 		}
-	} // Build prompt with context injection
-	prompt := e.buildPromptWithContext(preamble, redactedEvidence)
+	}
+	if cacheMode == CacheOnly {
+		return nil, ErrCacheMiss
+	}
 
-	// Call AI provider
-	responseText, err := e.provider.AnalyzeWithContext(ctx, prompt)
-	if err != nil {
-		return nil, err
+	// Models with a small context window analyze the evidence in chunks
+	chunks := ChunkEvidence(redactedEvidence, e.evidenceTokenBudget())
+	if len(chunks) > 1 {
+		slog.Info("Splitting evidence to fit the model context window", "events", len(redactedEvents), "chunks", len(chunks))
 	}
 
-	// Parse response to Finding
-	finding, err := e.parseResponseToFinding(responseText, preamble, evidence)
-	if err != nil {
-		return nil, fmt.Errorf("failed to parse AI response: %w", err)
+	results := make([]ChunkFinding, 0, len(chunks))
+	var latency time.Duration
+	model := e.modelName()
+	for _, chunk := range chunks {
+		chunkResults, chunkLatency, usedFallback, err := e.analyzeChunkAdapting(ctx, preamble, chunk)
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, chunkResults...)
+		latency += chunkLatency
+		if usedFallback {
+			model = e.contextFallbackModel
+			cacheKey = fallbackKey
+		}
 	}
+	cacheReadTokens := 0
+	for _, result := range results {
+		cacheReadTokens += result.Finding.CacheReadTokens
+	}
+	finding := MergeChunkFindings(results)
+	finding.CacheReadTokens = cacheReadTokens
 
-	// Set mode to "ai"
+	// Set mode to "ai" and record provenance of the analysis
 	finding.Mode = "ai"
+	finding.Provider = e.providerName()
+	finding.Model = model
+	finding.LatencyMs = int(latency.Milliseconds())
+	finding.Seed = e.config.AI.Seed
+	finding.Redactions = redactions
+	finding.Provenance = evidence.Provenance()
+	finding.RelatedControls = preamble.ControlIDs
+	finding.Labels = evidence.Labels()
+
+	// Drop citations that don't reference supplied evidence
+	e.validateCitations(finding, evidence)
 
 	// Set review flag based on confidence threshold
 	threshold := preamble.Rubrics.ConfidenceThreshold
@@ -302,17 +470,170 @@ func (e *engineImpl) Analyze(ctx context.Context, preamble types.ContextPreamble
 		finding.ReviewRequired = true
 	}
 
-	// Cache result
-	if e.config.AI.CacheDir != "" && !e.config.AI.NoCache {
+	// Cache result, and record the analysis for replay under the key it was cached under
+	if cacheEnabled && cacheMode.writes() {
 		cached := e.findingToCachedResult(cacheKey, finding)
-		_ = e.cache.Set(cacheKey, cached) // Ignore cache write errors
+		if err := e.cache.Set(cacheKey, cached); err == nil { // Ignore cache write errors
+			e.auditAnalysis(preamble, cacheKey, model, latency)
+		}
 	}
 
+	// Apply the citation minimum and flag stale evidence after caching, since
+	// the setting and staleness can change between runs
+	e.requireCitations(finding)
+	e.markStaleEvidence(finding, evidence)
+
 	return finding, nil
 }
 
+// auditAnalysis records a completed analysis in the audit log, if enabled. The
+// entry references the cache entry holding the finding, so replay reproduces
+// one finding per analysis however many provider calls it took.
+func (e *engineImpl) auditAnalysis(preamble types.ContextPreamble, cacheKey, model string, latency time.Duration) {
+	if e.audit == nil {
+		return
+	}
+	entry := AuditEntry{
+		Timestamp: time.Now(),
+		Operation: AuditOperationAnalyze,
+		Framework: preamble.Framework,
+		ControlID: preamble.Section,
+		CacheKey:  cacheKey,
+		Provider:  e.providerName(),
+		Model:     model,
+		Seed:      e.config.AI.Seed,
+		LatencyMs: latency.Milliseconds(),
+		Outcome:   AuditOutcomeSuccess,
+	}
+	if err := e.audit.Record(entry); err != nil {
+		slog.Warn("Failed to write audit log entry", "error", err)
+	}
+}
+
+// analyzeChunk sends one (already redacted) batch of evidence to the provider
+// and parses the response, returning the finding and the provider latency
+func (e *engineImpl) analyzeChunk(ctx context.Context, preamble types.ContextPreamble, evidence types.EvidenceBundle) (*types.Finding, time.Duration, error) {
+	timings := PhaseTimingsFromContext(ctx)
+
+	// Build prompt with context injection
+	promptStart := time.Now()
+	prompt := e.buildPromptWithContext(preamble, evidence)
+	timings.Track(PhasePromptBuild, promptStart)
+
+	// Call AI provider, letting providers with prompt caching cache the policy context
+	start := time.Now()
+	responseText, cacheReadTokens, err := e.callProviderCached(ctx, AuditEntry{
+		Operation: AuditOperationAnalyzeCall,
+		Framework: preamble.Framework,
+		ControlID: preamble.Section,
+	}, prompt, e.promptCachePrefix(preamble))
+	if err != nil {
+		return nil, 0, err
+	}
+	latency := time.Since(start)
+
+	// Parse response to Finding
+	parseStart := time.Now()
+	finding, err := e.parseResponseToFinding(responseText, preamble, evidence)
+	timings.Track(PhaseParse, parseStart)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to parse AI response: %w", err)
+	}
+	finding.CacheReadTokens = cacheReadTokens
+
+	return finding, latency, nil
+}
+
+// analyzeChunkAdapting analyzes one batch of evidence like analyzeChunk, but when
+// the provider rejects the prompt as too long for the model it retries once: with
+// the context fallback model if one is set, otherwise with the batch split in two.
+// It reports whether the fallback model produced the findings.
+func (e *engineImpl) analyzeChunkAdapting(ctx context.Context, preamble types.ContextPreamble, evidence types.EvidenceBundle) ([]ChunkFinding, time.Duration, bool, error) {
+	finding, latency, err := e.analyzeChunk(ctx, preamble, evidence)
+	if err == nil {
+		return []ChunkFinding{{Finding: finding, Events: len(evidence.Events)}}, latency, false, nil
+	}
+	if !IsContextLengthError(err) {
+		return nil, 0, false, err
+	}
+
+	if e.contextFallback != nil {
+		slog.Warn("Prompt exceeds the model context window, retrying with the fallback model", "model", e.modelName(), "fallback_model", e.contextFallbackModel, "events", len(evidence.Events))
+		finding, latency, err := e.withProvider(e.contextFallback, e.contextFallbackModel).analyzeChunk(ctx, preamble, evidence)
+		if err != nil {
+			return nil, 0, false, fmt.Errorf("retry with fallback model %s failed: %w", e.contextFallbackModel, err)
+		}
+		return []ChunkFinding{{Finding: finding, Events: len(evidence.Events)}}, latency, true, nil
+	}
+
+	if len(evidence.Events) < 2 {
+		return nil, 0, false, fmt.Errorf("%w; a single event cannot be split further, set ai.context_fallback_model to retry with a larger-context model", err)
+	}
+	half := len(evidence.Events) / 2
+	halves := []types.EvidenceBundle{{Events: evidence.Events[:half]}, {Events: evidence.Events[half:]}}
+	slog.Warn("Prompt exceeds the model context window, retrying in smaller chunks", "model", e.modelName(), "events", len(evidence.Events), "chunks", len(halves))
+
+	results := make([]ChunkFinding, len(halves))
+	latency = 0
+	for i, chunk := range halves {
+		chunkFinding, chunkLatency, err := e.analyzeChunk(ctx, preamble, chunk)
+		if err != nil {
+			return nil, 0, false, fmt.Errorf("retry in smaller chunks failed: %w", err)
+		}
+		results[i] = ChunkFinding{Finding: chunkFinding, Events: len(chunk.Events)}
+		latency += chunkLatency
+	}
+	return results, latency, false, nil
+}
+
+// withProvider returns a copy of the engine that calls provider and records
+// model as the model in use
+func (e *engineImpl) withProvider(provider Provider, model string) *engineImpl {
+	config := *e.config
+	config.AI.Model = model
+	engine := *e
+	engine.provider = provider
+	engine.config = &config
+	return &engine
+}
+
+// providerName returns the provider analyses are sent to: ai.provider, or
+// openai when unset, as initializeAIEngine resolves it
+func (e *engineImpl) providerName() string {
+	if e.config.AI.Provider == "" {
+		return types.AIProviderOpenAI
+	}
+	return e.config.AI.Provider
+}
+
+// modelName returns the model analyses use: ai.model, or the provider's
+// default model (see types.AIConfig.ModelFor)
+func (e *engineImpl) modelName() string {
+	return e.config.AI.ModelFor(e.providerName())
+}
+
+// evidenceTokenBudget returns the provider's per-prompt evidence budget, or 0 if it has none
+func (e *engineImpl) evidenceTokenBudget() int {
+	if p, ok := e.provider.(EvidenceBudgetProvider); ok {
+		return p.EvidenceTokenBudget()
+	}
+	return 0
+}
+
 // ProposePlan generates an evidence collection plan for autonomous mode (Feature 003)
 func (e *engineImpl) ProposePlan(ctx context.Context, preamble types.ContextPreamble) (*types.EvidencePlan, error) {
+	attrs := append(e.providerAttributes(),
+		attrFramework.String(preamble.Framework),
+		attrControl.String(preamble.Section),
+	)
+	ctx, span := startSpan(ctx, SpanProposePlan, attrs...)
+	plan, err := e.proposePlan(ctx, preamble)
+	endSpan(span, err)
+	return plan, err
+}
+
+// proposePlan implements ProposePlan within its trace span
+func (e *engineImpl) proposePlan(ctx context.Context, preamble types.ContextPreamble) (*types.EvidencePlan, error) {
 	// Validate preamble
 	if err := preamble.Validate(); err != nil {
 		return nil, fmt.Errorf("invalid preamble: %w", err)
@@ -325,21 +646,35 @@ func (e *engineImpl) ProposePlan(ctx context.Context, preamble types.ContextPrea
 	default:
 	}
 
-	// Build prompt for plan generation
-	prompt := e.buildPlanPrompt(preamble)
+	timings := PhaseTimingsFromContext(ctx)
+
+	// Build prompt for plan generation, limited to the sources that can be collected
+	sources := e.planSources(ctx)
+	promptStart := time.Now()
+	prompt := e.buildPlanPrompt(preamble, sources)
+	timings.Track(PhasePromptBuild, promptStart)
 
 	// Call AI provider to generate plan (no caching for plans - always fresh)
-	responseText, err := e.provider.AnalyzeWithContext(ctx, prompt)
+	responseText, err := e.callProvider(ctx, AuditEntry{
+		Operation: "plan",
+		Framework: preamble.Framework,
+		ControlID: preamble.Section,
+	}, prompt)
 	if err != nil {
 		return nil, err
 	}
 
 	// Parse response to plan items
+	parseStart := time.Now()
 	items, err := e.parsePlanResponse(responseText)
+	timings.Track(PhaseParse, parseStart)
 	if err != nil {
 		return nil, err
 	}
 
+	// The model may still propose sources it was told not to use
+	items = filterPlanSources(items, sources)
+
 	// Check if we got any items
 	if len(items) == 0 {
 		return nil, ErrNoPlanItems
@@ -353,10 +688,28 @@ func (e *engineImpl) ProposePlan(ctx context.Context, preamble types.ContextPrea
 		return items[i].Query < items[j].Query
 	})
 
-	// Apply auto-approve matcher
+	// Drop items too weak to be worth a connector call
+	items = dropWeakSignals(items, e.config.AI.Autonomous.MinSignalStrength)
+	if len(items) == 0 {
+		return nil, ErrNoPlanItems
+	}
+
+	// Apply auto-approve matcher. Items without a usable rationale always need
+	// a human to judge them, whatever the auto-approve policy says.
+	minRationale := e.config.AI.Autonomous.MinRationaleChars
 	for i := range items {
-		if e.autoApproveMatcher.Matches(items[i].Source, items[i].Query) {
+		if weakRationale(items[i].Rationale, minRationale) {
+			items[i].WeakRationale = true
+			items[i].ApprovalStatus = types.ApprovalPending
+			slog.Warn("Plan item has a missing or short rationale, requiring manual approval",
+				"source", items[i].Source,
+				"query", items[i].Query,
+				"rationale", items[i].Rationale)
+			continue
+		}
+		if pattern, ok := e.autoApproveMatcher.MatchedPattern(items[i].Source, items[i].Query); ok {
 			items[i].AutoApproved = true
+			items[i].AutoApprovePattern = pattern
 			items[i].ApprovalStatus = types.ApprovalAutoApproved
 		} else {
 			items[i].ApprovalStatus = types.ApprovalPending
@@ -376,6 +729,9 @@ func (e *engineImpl) ProposePlan(ctx context.Context, preamble types.ContextPrea
 		return nil, ErrBudgetExceeded
 	}
 
+	// Keep the plan small enough to review
+	items = capPlanItems(items, e.config.AI.Autonomous.MaxItems)
+
 	// Count unique sources for EstimatedSources
 	sourceSet := make(map[string]bool)
 	for _, item := range items {
@@ -384,7 +740,7 @@ func (e *engineImpl) ProposePlan(ctx context.Context, preamble types.ContextPrea
 
 	// Create plan
 	plan := &types.EvidencePlan{
-		ID:               fmt.Sprintf("plan-%d", time.Now().Unix()),
+		ID:               fmt.Sprintf("plan-%d", e.clock.Now().Unix()),
 		Framework:        preamble.Framework,
 		Section:          preamble.Section,
 		Items:            items,
@@ -392,15 +748,31 @@ func (e *engineImpl) ProposePlan(ctx context.Context, preamble types.ContextPrea
 		EstimatedCalls:   len(items),
 		EstimatedTokens:  0, // TODO: Calculate token estimate
 		Status:           types.PlanPending,
-		CreatedAt:        time.Now(),
-		UpdatedAt:        time.Now(),
+		CreatedAt:        e.clock.Now(),
+		UpdatedAt:        e.clock.Now(),
 	}
 
 	return plan, nil
 }
 
+// EstimatePlanCost estimates the cost of executing the approved items of plan
+func (e *engineImpl) EstimatePlanCost(plan *types.EvidencePlan) PlanCostEstimate {
+	return EstimatePlanCost(plan, e.modelName())
+}
+
 // ExecutePlan executes an approved evidence collection plan via MCP connectors (Feature 003)
 func (e *engineImpl) ExecutePlan(ctx context.Context, plan *types.EvidencePlan) (*types.EvidenceBundle, error) {
+	ctx, span := startSpan(ctx, SpanExecutePlan, attrFramework.String(plan.Framework), attrControl.String(plan.Section))
+	bundle, err := e.executePlan(ctx, plan)
+	if bundle != nil {
+		span.SetAttributes(attrEvents.Int(len(bundle.Events)))
+	}
+	endSpan(span, err)
+	return bundle, err
+}
+
+// executePlan implements ExecutePlan within its trace span
+func (e *engineImpl) executePlan(ctx context.Context, plan *types.EvidencePlan) (*types.EvidenceBundle, error) {
 	// Validate plan is approved
 	if plan.Status != types.PlanApproved {
 		return nil, ErrPlanNotApproved
@@ -449,45 +821,46 @@ func (e *engineImpl) ExecutePlan(ctx context.Context, plan *types.EvidencePlan)
 		return nil, fmt.Errorf("no MCP connector configured")
 	}
 
-	// Execute items in parallel with goroutines
+	// Execute items in parallel, at most ai.concurrency.maxAnalyses at a time
 	type result struct {
-		item   *types.PlanItem
 		events []types.EvidenceEvent
 		err    error
 	}
 
-	results := make(chan result, len(approvedItems))
+	results := make([]result, len(approvedItems))
+	ForEachLimit(len(approvedItems), e.config.AI.Concurrency, func(i int) {
+		item := approvedItems[i]
 
-	// Launch goroutines for each approved item
-	for _, item := range approvedItems {
-		go func(item *types.PlanItem) {
-			// Set status to running
-			item.ExecutionStatus = types.ExecRunning
+		// Set status to running
+		item.ExecutionStatus = types.ExecRunning
 
-			// Call MCP connector
-			events, err := e.connector.Collect(ctx, item.Source, item.Query)
+		// Call MCP connector
+		collectCtx, span := startSpan(ctx, SpanConnectorCollect, attrSource.String(item.Source))
+		collectStart := time.Now()
+		events, err := e.connector.Collect(collectCtx, item.Source, item.Query)
+		recordConnectorCall(item.Source, collectStart, err)
+		span.SetAttributes(attrEvents.Int(len(events)))
+		endSpan(span, err)
 
-			if err != nil {
-				// Handle error
-				item.ExecutionStatus = types.ExecFailed
-				item.Error = err.Error()
-				results <- result{item: item, events: nil, err: err}
-				return
-			}
+		if err != nil {
+			// Handle error
+			item.ExecutionStatus = types.ExecFailed
+			item.Error = err.Error()
+			results[i] = result{err: err}
+			return
+		}
 
-			// Success
-			item.ExecutionStatus = types.ExecComplete
-			item.EventsCollected = len(events)
-			results <- result{item: item, events: events, err: nil}
-		}(item)
-	}
+		// Success
+		item.ExecutionStatus = types.ExecComplete
+		item.EventsCollected = len(events)
+		results[i] = result{events: events}
+	})
 
-	// Collect results
+	// Collect results in plan order
 	allEvents := make([]types.EvidenceEvent, 0)
 	successCount := 0
 
-	for i := 0; i < len(approvedItems); i++ {
-		res := <-results
+	for _, res := range results {
 		if res.err == nil {
 			allEvents = append(allEvents, res.events...)
 			successCount++
@@ -499,6 +872,9 @@ func (e *engineImpl) ExecutePlan(ctx context.Context, plan *types.EvidencePlan)
 		return nil, ErrMCPConnectorFailed
 	}
 
+	// Overlapping items can return the same events
+	allEvents = dedupeEvents(allEvents)
+
 	// Return bundle with all collected events
 	bundle := &types.EvidenceBundle{
 		Events: allEvents,
@@ -507,8 +883,116 @@ func (e *engineImpl) ExecutePlan(ctx context.Context, plan *types.EvidencePlan)
 	return bundle, nil
 }
 
-// buildPlanPrompt creates a prompt for evidence plan generation
-func (e *engineImpl) buildPlanPrompt(preamble types.ContextPreamble) string {
+// callProvider sends a prompt to the provider and records the call in the audit log, if enabled.
+// Prompts passed here must already be redacted; only their hash is logged.
+// Denylisted terms are redacted or, in block mode, abort the call before it reaches the provider.
+// Prompts longer than ai.max_prompt_chars are rejected with ErrPromptTooLarge.
+func (e *engineImpl) callProvider(ctx context.Context, entry AuditEntry, prompt string) (string, error) {
+	response, _, err := e.callProviderCached(ctx, entry, prompt, "")
+	return response, err
+}
+
+// callProviderCached is callProvider for prompts starting with a stable prefix.
+// Providers implementing PromptCachingProvider get the prefix marked for caching
+// and report the prompt tokens read from their cache; other providers, or a
+// prompt the denylist rewrote, get the whole prompt.
+func (e *engineImpl) callProviderCached(ctx context.Context, entry AuditEntry, prompt, prefix string) (response string, cacheReadTokens int, err error) {
+	ctx, span := startSpan(ctx, SpanProviderCall, append(e.providerAttributes(), attrOperation.String(entry.Operation))...)
+	defer func() {
+		span.SetAttributes(attrPromptTokens.Int(estimateTokens(prompt)), attrResponseTokens.Int(estimateTokens(response)))
+		if err != nil {
+			recordProviderError(e.providerName(), err)
+		}
+		endSpan(span, err)
+	}()
+
+	prompt, err = e.denylist.Enforce(prompt)
+	if err != nil {
+		slog.Warn("Refusing to send prompt containing denylisted terms", "operation", entry.Operation, "framework", entry.Framework, "control", entry.ControlID)
+		return "", 0, err
+	}
+
+	if limit := e.config.AI.MaxPromptChars; limit > 0 && len(prompt) > limit {
+		slog.Warn("Refusing to send oversized prompt", "operation", entry.Operation, "chars", len(prompt), "max_prompt_chars", limit)
+		return "", 0, fmt.Errorf("%w: %d characters, limit is %d (ai.max_prompt_chars); analyze fewer evidence files, or set a context window (providers.ollama.extra.num_ctx) so evidence is split into chunks", ErrPromptTooLarge, len(prompt), limit)
+	}
+
+	start := time.Now()
+	if caching, ok := e.provider.(PromptCachingProvider); ok && prefix != "" && strings.HasPrefix(prompt, prefix) {
+		response, cacheReadTokens, err = caching.AnalyzeWithCachedPrefix(ctx, prefix, prompt[len(prefix):])
+	} else {
+		response, err = e.provider.AnalyzeWithContext(ctx, prompt)
+	}
+	PhaseTimingsFromContext(ctx).Track(PhaseProvider, start)
+	recordTokens(e.providerName(), prompt, response)
+	recordCacheReadTokens(e.providerName(), cacheReadTokens)
+
+	if e.audit != nil {
+		completeAuditEntry(&entry, e.providerName(), e.modelName(), prompt, response, time.Since(start), err)
+		entry.Seed = e.config.AI.Seed
+		if auditErr := e.audit.Record(entry); auditErr != nil {
+			slog.Warn("Failed to write audit log entry", "error", auditErr)
+		}
+	}
+
+	return response, cacheReadTokens, err
+}
+
+// validateCitations removes citations that don't match an event ID in the evidence bundle.
+// When the fraction of invalid citations reaches the configured threshold, the finding's
+// confidence is scaled down by the fraction of citations that were valid.
+func (e *engineImpl) validateCitations(finding *types.Finding, evidence types.EvidenceBundle) {
+	if len(finding.Citations) == 0 {
+		return
+	}
+
+	eventIDs := make(map[string]bool, len(evidence.Events))
+	for _, event := range evidence.Events {
+		eventIDs[event.ID] = true
+	}
+
+	valid := make([]string, 0, len(finding.Citations))
+	var invalid []string
+	for _, citation := range finding.Citations {
+		if eventIDs[citation] {
+			valid = append(valid, citation)
+		} else {
+			invalid = append(invalid, citation)
+		}
+	}
+
+	if len(invalid) == 0 {
+		return
+	}
+
+	total := len(finding.Citations)
+	finding.Citations = valid
+
+	threshold := e.config.AI.InvalidCitationThreshold
+	if threshold <= 0 {
+		threshold = DefaultInvalidCitationThreshold
+	}
+
+	invalidRatio := float64(len(invalid)) / float64(total)
+	if invalidRatio < threshold {
+		slog.Debug("Dropped unknown citations", "control", finding.ControlID, "invalid", invalid)
+		return
+	}
+
+	original := finding.ConfidenceScore
+	finding.ConfidenceScore = original * float64(len(valid)) / float64(total)
+
+	slog.Warn("AI response cited unknown evidence, lowering confidence",
+		"control", finding.ControlID,
+		"invalid", invalid,
+		"invalid_ratio", invalidRatio,
+		"original_confidence", original,
+		"confidence", finding.ConfidenceScore)
+}
+
+// buildPlanPrompt creates a prompt for evidence plan generation. A non-empty
+// sources list restricts the sources the model may propose.
+func (e *engineImpl) buildPlanPrompt(preamble types.ContextPreamble, sources []string) string {
 	var sb strings.Builder
 
 	sb.WriteString(fmt.Sprintf("You are creating an evidence collection plan for compliance with %s %s.\n\n", preamble.Framework, preamble.Section))
@@ -517,10 +1001,17 @@ func (e *engineImpl) buildPlanPrompt(preamble types.ContextPreamble) string {
 	sb.WriteString("\n\n")
 
 	sb.WriteString("Generate a list of evidence sources to query. For each source, provide:\n")
-	sb.WriteString("- source: System name (github, jira, aws, slack, etc.)\n")
+	if len(sources) > 0 {
+		sb.WriteString(fmt.Sprintf("- source: System name, one of: %s\n", strings.Join(sources, ", ")))
+	} else {
+		sb.WriteString("- source: System name (github, jira, aws, slack, etc.)\n")
+	}
 	sb.WriteString("- query: Search query or filter expression\n")
 	sb.WriteString("- signal_strength: Relevance score (0.0-1.0)\n")
 	sb.WriteString("- rationale: Why this source/query is relevant\n\n")
+	if len(sources) > 0 {
+		sb.WriteString("Only these sources are enabled; items for any other source will be discarded.\n\n")
+	}
 
 	sb.WriteString("Return your response as a JSON array of plan items:\n")
 	sb.WriteString(`[{"source": "github", "query": "type:pr label:security", "signal_strength": 0.9, "rationale": "Security PRs show access control implementations"}]`)
@@ -575,55 +1066,198 @@ func (e *engineImpl) parsePlanResponse(responseText string) ([]types.PlanItem, e
 
 // computeCacheKey generates a deterministic cache key from preamble and evidence
 func (e *engineImpl) computeCacheKey(preamble types.ContextPreamble, evidence types.EvidenceBundle) string {
+	return AnalysisCacheKey(e.analysisScope(), preamble, evidence)
+}
+
+// analysisScope returns the provider, model and prompts this engine analyzes with
+func (e *engineImpl) analysisScope() AnalysisScope {
+	scope := AnalysisScope{
+		Provider:     e.providerName(),
+		Model:        e.modelName(),
+		SystemPrompt: e.config.AI.SystemPrompt,
+		Temperature:  e.config.AI.Temperature,
+		Seed:         e.config.AI.Seed,
+	}
+	// Deterministic mode overrides the sampling settings sent to the provider
+	if e.config.AI.Deterministic {
+		scope.Temperature = 0
+		if scope.Seed == nil {
+			seed := DefaultDeterministicSeed
+			scope.Seed = &seed
+		}
+	}
+	// A custom prompt template changes the analysis, so results must not be shared with the built-in prompt
+	if e.promptTemplate != nil {
+		scope.TemplateSource = e.promptTemplate.Tree.Root.String()
+	}
+	if p, ok := e.provider.(SystemPromptProvider); ok {
+		scope.SystemPrompt = p.SystemPrompt()
+	}
+	return scope
+}
+
+// builtinPromptVersion identifies the built-in analysis prompt in cache keys.
+// Bump it when the built-in prompt changes so earlier cached findings miss.
+const builtinPromptVersion = "builtin-v2"
+
+// SystemPromptProvider is implemented by providers whose system message can be
+// overridden, so analyses are cached per system prompt actually sent
+type SystemPromptProvider interface {
+	// SystemPrompt returns the configured system message; "" means the built-in one
+	SystemPrompt() string
+}
+
+// AnalysisScope identifies what analyzes the inputs of a cached analysis
+type AnalysisScope struct {
+	// Provider and Model name the provider and model called
+	Provider string
+	Model    string
+
+	// TemplateSource is the custom prompt template, empty for the built-in prompt
+	TemplateSource string
+
+	// SystemPrompt is the system message override, empty for the provider's default
+	SystemPrompt string
+
+	// Temperature and Seed are the sampling settings; a nil Seed means unseeded sampling
+	Temperature float32
+	Seed        *int
+}
+
+// AnalysisCacheKey returns the analysis cache key: the ContextCacheKey of the
+// inputs scoped to the provider, model, prompts and sampling settings that
+// analyze them, so switching any of them never returns a finding produced by another.
+func AnalysisCacheKey(scope AnalysisScope, preamble types.ContextPreamble, evidence types.EvidenceBundle) string {
+	prompt := builtinPromptVersion
+	if scope.TemplateSource != "" {
+		prompt = "template-" + promptHash(scope.TemplateSource)
+	}
+	var systemPrompt string
+	if scope.SystemPrompt != "" {
+		systemPrompt = "system-" + promptHash(scope.SystemPrompt)
+	}
+	sampling := fmt.Sprintf("temperature-%g", scope.Temperature)
+	if scope.Seed != nil {
+		sampling += fmt.Sprintf(" seed-%d", *scope.Seed)
+	}
+
 	h := sha256.New()
+	for _, part := range []string{scope.Provider, scope.Model, prompt, systemPrompt, sampling, ContextCacheKey(preamble, evidence)} {
+		io.WriteString(h, part)
+		h.Write([]byte{0})
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
 
-	// Include framework, section, and excerpt
-	h.Write([]byte(preamble.Framework))
-	h.Write([]byte(preamble.Version))
-	h.Write([]byte(preamble.Section))
-	h.Write([]byte(preamble.Excerpt))
-
-	// Sort and include evidence events for determinism
-	events := make([]types.EvidenceEvent, len(evidence.Events))
-	copy(events, evidence.Events)
-	sort.Slice(events, func(i, j int) bool {
-		return events[i].ID < events[j].ID
+// promptHash returns the hex SHA256 of a prompt, so cache keys vary with it without embedding it
+func promptHash(prompt string) string {
+	sum := sha256.Sum256([]byte(prompt))
+	return hex.EncodeToString(sum[:])
+}
+
+// ContextCacheKey returns the cache key for analyzing evidence against preamble.
+// The key is independent of event order: each event is hashed once and the
+// fixed-size digests are sorted, so large bundles are neither copied nor re-sorted.
+// An event's digest covers its ID, content and the metadata the prompt renders.
+// The prompt itself is scoped by AnalysisCacheKey.
+func ContextCacheKey(preamble types.ContextPreamble, evidence types.EvidenceBundle) string {
+	eventHashes := make([][sha256.Size]byte, len(evidence.Events))
+	var buf []byte
+	for i := range evidence.Events {
+		event := &evidence.Events[i]
+		buf = append(buf[:0], event.ID...)
+		buf = append(buf, 0)
+		buf = append(buf, event.Content...)
+		// Metadata is rendered in the prompt, so it must change the key; events
+		// without any keep the key they had before metadata was rendered
+		if metadata := promptMetadata(*event); metadata != "" {
+			buf = append(buf, 0)
+			buf = append(buf, metadata...)
+		}
+		eventHashes[i] = sha256.Sum256(buf)
+	}
+	sort.Slice(eventHashes, func(i, j int) bool {
+		return bytes.Compare(eventHashes[i][:], eventHashes[j][:]) < 0
 	})
 
-	for _, event := range events {
-		h.Write([]byte(event.ID))
-		h.Write([]byte(event.Content))
+	h := sha256.New()
+
+	// Include framework, section, and excerpt
+	io.WriteString(h, preamble.Framework)
+	io.WriteString(h, preamble.Version)
+	io.WriteString(h, preamble.Section)
+	io.WriteString(h, preamble.Excerpt)
+	io.WriteString(h, preamble.Rubrics.Guidance) // Guidance changes the prompt, so it must change the key
+
+	for i := range eventHashes {
+		h.Write(eventHashes[i][:])
 	}
 
 	return hex.EncodeToString(h.Sum(nil))
 }
 
-// buildPromptWithContext creates a prompt with framework context injection
+// buildPromptWithContext creates a prompt with framework context injection.
+// A custom prompt template (AIConfig.PromptTemplate) replaces the built-in prompt when configured.
 func (e *engineImpl) buildPromptWithContext(preamble types.ContextPreamble, evidence types.EvidenceBundle) string {
-	var sb strings.Builder
+	if e.promptTemplate != nil {
+		var buf bytes.Buffer
+		err := e.promptTemplate.Execute(&buf, PromptTemplateData{
+			Preamble:           preamble,
+			Evidence:           evidence,
+			OutputInstructions: analysisOutputInstructions,
+		})
+		if err == nil {
+			return buf.String()
+		}
+		slog.Warn("Prompt template failed, using built-in prompt", "template", e.config.AI.PromptTemplate, "error", err)
+	}
 
-	sb.WriteString(fmt.Sprintf("You are analyzing evidence for compliance with %s %s.\n\n", preamble.Framework, preamble.Section))
-	sb.WriteString("Control Excerpt:\n")
-	sb.WriteString(preamble.Excerpt)
-	sb.WriteString("\n\n")
+	var sb strings.Builder
 
+	sb.WriteString(buildPolicyPrompt(preamble))
 	sb.WriteString("Evidence (redacted):\n")
 	for i, event := range evidence.Events {
 		sb.WriteString(fmt.Sprintf("%d. [%s/%s] %s\n", i+1, event.Source, event.Type, event.Content))
+		if metadata := promptMetadata(event); metadata != "" {
+			sb.WriteString(fmt.Sprintf("   Metadata: %s\n", metadata))
+		}
 	}
 	sb.WriteString("\n")
 
-	sb.WriteString("Provide your analysis in JSON format with the following fields:\n")
-	sb.WriteString("- summary: Brief summary of findings (50-200 words)\n")
-	sb.WriteString("- mapped_controls: List of control IDs that apply\n")
-	sb.WriteString("- confidence_score: Confidence level (0.0-1.0)\n")
-	sb.WriteString("- residual_risk: Risk level (low/medium/high)\n")
-	sb.WriteString("- justification: Detailed explanation (100-500 words)\n")
-	sb.WriteString("- citations: List of event IDs that support the finding\n")
+	sb.WriteString(analysisOutputInstructions)
 
 	return sb.String()
 }
 
+// buildPolicyPrompt builds the policy context that opens the built-in analysis
+// prompt, which every analysis of the same control shares
+func buildPolicyPrompt(preamble types.ContextPreamble) string {
+	var sb strings.Builder
+
+	sb.WriteString(fmt.Sprintf("You are analyzing evidence for compliance with %s %s.\n\n", preamble.Framework, preamble.Section))
+	sb.WriteString("Control Excerpt:\n")
+	sb.WriteString(preamble.Excerpt)
+	sb.WriteString("\n\n")
+
+	if guidance := strings.TrimSpace(preamble.Rubrics.Guidance); guidance != "" {
+		sb.WriteString("Scoring Guidance:\n")
+		sb.WriteString(guidance)
+		sb.WriteString("\n\n")
+	}
+
+	return sb.String()
+}
+
+// promptCachePrefix returns the part of the analysis prompt a provider may
+// cache across analyses of the control, or "" when a custom prompt template
+// leaves no known stable prefix
+func (e *engineImpl) promptCachePrefix(preamble types.ContextPreamble) string {
+	if e.promptTemplate != nil {
+		return ""
+	}
+	return buildPolicyPrompt(preamble)
+}
+
 // parseResponseToFinding converts AI response text to a Finding
 func (e *engineImpl) parseResponseToFinding(responseText string, preamble types.ContextPreamble, evidence types.EvidenceBundle) (*types.Finding, error) {
 	// Try to extract JSON from the response
@@ -652,22 +1286,27 @@ func (e *engineImpl) parseResponseToFinding(responseText string, preamble types.
 		return e.createBasicFinding(responseText, preamble, evidence), nil
 	}
 
+	residualRisk, severity, err := e.residualRisk(resp.ResidualRisk)
+	if err != nil {
+		return nil, err
+	}
+
 	// Create Finding from parsed response
 	finding := &types.Finding{
-		ID:              fmt.Sprintf("finding-%d", time.Now().Unix()),
+		ID:              fmt.Sprintf("finding-%d", e.clock.Now().Unix()),
 		ControlID:       preamble.Section,
 		FrameworkID:     preamble.Framework,
 		Title:           fmt.Sprintf("%s %s Analysis", preamble.Framework, preamble.Section),
 		Summary:         resp.Summary,
 		MappedControls:  resp.MappedControls,
 		ConfidenceScore: resp.ConfidenceScore,
-		ResidualRisk:    resp.ResidualRisk,
+		ResidualRisk:    residualRisk,
 		Justification:   resp.Justification,
 		Citations:       resp.Citations,
-		Severity:        e.riskToSeverity(resp.ResidualRisk),
+		Severity:        severity,
 		Status:          types.StatusOpen,
-		CreatedAt:       time.Now(),
-		UpdatedAt:       time.Now(),
+		CreatedAt:       e.clock.Now(),
+		UpdatedAt:       e.clock.Now(),
 	}
 
 	return finding, nil
@@ -682,7 +1321,7 @@ func (e *engineImpl) createBasicFinding(responseText string, preamble types.Cont
 	}
 
 	return &types.Finding{
-		ID:              fmt.Sprintf("finding-%d", time.Now().Unix()),
+		ID:              fmt.Sprintf("finding-%d", e.clock.Now().Unix()),
 		ControlID:       preamble.Section,
 		FrameworkID:     preamble.Framework,
 		Title:           fmt.Sprintf("%s %s Analysis", preamble.Framework, preamble.Section),
@@ -694,43 +1333,63 @@ func (e *engineImpl) createBasicFinding(responseText string, preamble types.Cont
 		Citations:       citations,
 		Severity:        types.SeverityMedium,
 		Status:          types.StatusOpen,
-		CreatedAt:       time.Now(),
-		UpdatedAt:       time.Now(),
+		CreatedAt:       e.clock.Now(),
+		UpdatedAt:       e.clock.Now(),
 	}
 }
 
-// riskToSeverity maps residual risk to finding severity
-func (e *engineImpl) riskToSeverity(risk string) string {
-	switch strings.ToLower(risk) {
-	case "low":
-		return types.SeverityLow
-	case "high":
-		return types.SeverityHigh
-	default:
-		return types.SeverityMedium
+// residualRisk normalizes a residual risk from provider output and maps it to
+// a finding severity. An unknown value is an error when
+// ai.strict_residual_risk is set; otherwise its text is kept and it maps to
+// medium severity.
+func (e *engineImpl) residualRisk(risk string) (string, string, error) {
+	if level, ok := types.NormalizeResidualRisk(risk); ok {
+		return string(level), level.Severity(), nil
 	}
+	if e.config.AI.StrictResidualRisk {
+		return "", "", fmt.Errorf("%w: %q, must be one of %v", ErrUnknownResidualRisk, risk, types.ValidResidualRisks)
+	}
+	slog.Debug("Unknown residual risk, using medium severity", "residual_risk", risk)
+	return risk, types.SeverityMedium, nil
 }
 
 // responseToCachedFinding converts a cached response to a Finding
 func (e *engineImpl) responseToCachedFinding(cached *CachedResult, preamble types.ContextPreamble) *types.Finding {
 	// Reconstruct the finding from cached data
 	// The Summary was stored in Justification field during caching
+	residualRisk := cached.Response.ResidualRisk
+	severity := types.SeverityMedium
+	if level, ok := types.NormalizeResidualRisk(residualRisk); ok {
+		residualRisk, severity = string(level), level.Severity()
+	}
 	finding := &types.Finding{
-		ID:              fmt.Sprintf("finding-%d", time.Now().Unix()),
+		ID:              fmt.Sprintf("finding-%d", e.clock.Now().Unix()),
 		ControlID:       cached.ControlID,
 		FrameworkID:     preamble.Framework,
 		Title:           fmt.Sprintf("%s %s Analysis", preamble.Framework, preamble.Section),
 		Summary:         cached.Response.Justification, // Summary was stored in Justification
 		MappedControls:  []string{preamble.Section},
 		ConfidenceScore: float64(cached.Response.Confidence) / 100.0,
-		ResidualRisk:    cached.Response.ResidualRisk,
+		ResidualRisk:    residualRisk,
 		Justification:   "AI analysis completed (cached result)",
 		Citations:       cached.Response.EvidenceLinks,
-		Severity:        e.riskToSeverity(cached.Response.ResidualRisk),
+		Severity:        severity,
 		Status:          types.StatusOpen,
-		CreatedAt:       time.Now(),
-		UpdatedAt:       time.Now(),
+		CreatedAt:       e.clock.Now(),
+		UpdatedAt:       e.clock.Now(),
 		Mode:            "ai",
+		Provider:        cached.Response.Provider,
+		Model:           cached.Response.Model,
+		LatencyMs:       cached.Response.Latency,
+		CacheHit:        true,
+	}
+
+	// Older cache entries may only carry provider/model in the entry metadata
+	if finding.Provider == "" {
+		finding.Provider = cached.Provider
+	}
+	if finding.Model == "" {
+		finding.Model = cached.ModelVersion
 	}
 
 	// Set review flag based on confidence
@@ -744,7 +1403,7 @@ func (e *engineImpl) responseToCachedFinding(cached *CachedResult, preamble type
 // createLowConfidenceFinding creates a finding with low confidence for empty or invalid evidence
 func (e *engineImpl) createLowConfidenceFinding(preamble types.ContextPreamble, reason string) *types.Finding {
 	return &types.Finding{
-		ID:              fmt.Sprintf("finding-%d", time.Now().Unix()),
+		ID:              fmt.Sprintf("finding-%d", e.clock.Now().Unix()),
 		ControlID:       preamble.Section,
 		FrameworkID:     preamble.Framework,
 		Title:           fmt.Sprintf("%s %s Analysis", preamble.Framework, preamble.Section),
@@ -756,8 +1415,8 @@ func (e *engineImpl) createLowConfidenceFinding(preamble types.ContextPreamble,
 		Citations:       []string{},
 		Severity:        types.SeverityHigh,
 		Status:          types.StatusOpen,
-		CreatedAt:       time.Now(),
-		UpdatedAt:       time.Now(),
+		CreatedAt:       e.clock.Now(),
+		UpdatedAt:       e.clock.Now(),
 		Mode:            "ai",
 		ReviewRequired:  true, // Always requires review
 	}
@@ -779,24 +1438,33 @@ func (e *engineImpl) findingToCachedResult(cacheKey string, finding *types.Findi
 			Justification: justification, // Store Summary here
 			Confidence:    int(finding.ConfidenceScore * 100),
 			ResidualRisk:  finding.ResidualRisk,
-			Provider:      e.config.AI.Provider,
-			Model:         e.config.AI.Model,
-			Timestamp:     time.Now(),
+			Provider:      finding.Provider,
+			Model:         finding.Model,
+			Latency:       finding.LatencyMs,
+			Timestamp:     e.clock.Now(),
 			CacheHit:      false,
 		},
-		CachedAt:  time.Now(),
-		ControlID: finding.ControlID,
-		Provider:  e.config.AI.Provider,
+		CachedAt:     e.clock.Now(),
+		ControlID:    finding.ControlID,
+		Framework:    finding.FrameworkID,
+		Provider:     finding.Provider,
+		ModelVersion: finding.Model,
 	}
 }
 
 // MockProvider is a mock implementation of Provider for testing
 type MockProvider struct {
+	mu              sync.Mutex
 	callCount       int
 	lastPrompt      string
 	confidenceScore float64
 	response        string
+	customResponse  bool // True once SetResponse is called
 	err             error
+	delay           time.Duration    // Simulated provider latency
+	evidenceBudget  int              // Evidence tokens per prompt; 0 means no limit
+	providerName    string           // Reported by ProviderName; "" reports "mock"
+	modelName       string           // Reported by ModelName
 	planItems       []types.PlanItem // For ProposePlan testing
 }
 
@@ -827,6 +1495,21 @@ func NewMockProvider() *MockProvider {
 
 // AnalyzeWithContext implements Provider.AnalyzeWithContext
 func (m *MockProvider) AnalyzeWithContext(ctx context.Context, prompt string) (string, error) {
+	m.mu.Lock()
+	delay := m.delay
+	m.mu.Unlock()
+
+	if delay > 0 {
+		select {
+		case <-time.After(delay):
+		case <-ctx.Done():
+			return "", ctx.Err()
+		}
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
 	m.callCount++
 	m.lastPrompt = prompt
 
@@ -862,6 +1545,11 @@ func (m *MockProvider) AnalyzeWithContext(ctx context.Context, prompt string) (s
 		return string(jsonBytes), nil
 	}
 
+	// Return the custom response if one was set
+	if m.customResponse {
+		return m.response, nil
+	}
+
 	// Use configured confidence score for analysis
 	responseWithConf := fmt.Sprintf(`{"summary": "Access controls implemented", "mapped_controls": ["CC6.1"], "confidence_score": %.2f, "residual_risk": "low", "justification": "Evidence shows proper implementation", "citations": ["evt-1"]}`, m.confidenceScore)
 
@@ -870,32 +1558,91 @@ func (m *MockProvider) AnalyzeWithContext(ctx context.Context, prompt string) (s
 
 // GetCallCount returns the number of times AnalyzeWithContext was called
 func (m *MockProvider) GetCallCount() int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
 	return m.callCount
 }
 
 // GetLastPrompt returns the last prompt sent to AnalyzeWithContext
 func (m *MockProvider) GetLastPrompt() string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
 	return m.lastPrompt
 }
 
 // SetConfidenceScore sets the confidence score for responses
 func (m *MockProvider) SetConfidenceScore(score float64) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
 	m.confidenceScore = score
 }
 
 // SetError sets an error to be returned by AnalyzeWithContext
 func (m *MockProvider) SetError(err error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
 	m.err = err
 }
 
 // SetPlanItems sets the plan items to be returned by ProposePlan (for testing)
 func (m *MockProvider) SetPlanItems(items []types.PlanItem) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
 	m.planItems = items
 }
 
+// SetDelay sets a delay to simulate a slow provider; the call returns early if the context is done
+func (m *MockProvider) SetDelay(delay time.Duration) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.delay = delay
+}
+
+// EvidenceTokenBudget implements EvidenceBudgetProvider
+func (m *MockProvider) EvidenceTokenBudget() int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.evidenceBudget
+}
+
+// SetEvidenceTokenBudget simulates a model context window that fits budget evidence tokens
+func (m *MockProvider) SetEvidenceTokenBudget(budget int) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.evidenceBudget = budget
+}
+
+// ProviderName implements NamedProvider
+func (m *MockProvider) ProviderName() string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.providerName == "" {
+		return "mock"
+	}
+	return m.providerName
+}
+
+// ModelName implements NamedProvider
+func (m *MockProvider) ModelName() string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.modelName
+}
+
+// SetIdentity sets the provider and model the mock reports, to simulate a real provider
+func (m *MockProvider) SetIdentity(provider, model string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.providerName = provider
+	m.modelName = model
+}
+
 // SetResponse sets a custom response to be returned
 func (m *MockProvider) SetResponse(response string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
 	m.response = response
+	m.customResponse = true
 }
 
 // MockMCPConnector is a mock implementation of MCPConnector for testing
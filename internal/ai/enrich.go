@@ -0,0 +1,146 @@
+package ai
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/pickjonathan/sdek-cli/pkg/types"
+)
+
+// Metadata keys EnrichEvidence populates and the analysis prompt renders
+const (
+	MetadataAuthor  = "author"
+	MetadataRepo    = "repo"
+	MetadataProject = "project"
+	MetadataState   = "state"
+	MetadataURL     = "url"
+)
+
+// promptMetadataKeys are the metadata keys rendered with each event in the
+// built-in analysis prompt, in order. Other metadata (raw payloads, bodies
+// that repeat the content) is left out to keep prompts short.
+var promptMetadataKeys = []string{MetadataAuthor, MetadataRepo, MetadataProject, MetadataState, MetadataURL}
+
+// metadataEnrichers fill in source-specific metadata, keyed by event source
+var metadataEnrichers = map[string]func(metadata map[string]interface{}, event types.EvidenceEvent){
+	"github": enrichGitHubMetadata,
+	"jira":   enrichJiraMetadata,
+}
+
+// EnrichEvidence fills in the human context of each event (author, repository
+// or project, state, URL) from what its source already recorded, e.g. the
+// repository named in a GitHub html_url or the project in a Jira issue key.
+// Values already set are kept. The input bundle is not modified.
+func EnrichEvidence(bundle types.EvidenceBundle) types.EvidenceBundle {
+	enriched := types.EvidenceBundle{Events: make([]types.EvidenceEvent, len(bundle.Events))}
+	for i, event := range bundle.Events {
+		metadata := make(map[string]interface{}, len(event.Metadata)+len(promptMetadataKeys))
+		for k, v := range event.Metadata {
+			metadata[k] = v
+		}
+
+		// Connectors record the author as actor and the link as html_url
+		setMetadata(metadata, MetadataAuthor, metadataString(event.Metadata, "actor"))
+		setMetadata(metadata, MetadataURL, metadataString(event.Metadata, "html_url"))
+		if enrich, ok := metadataEnrichers[event.Source]; ok {
+			enrich(metadata, event)
+		}
+
+		if len(metadata) > 0 {
+			event.Metadata = metadata
+		}
+		enriched.Events[i] = event
+	}
+	return enriched
+}
+
+// enrichGitHubMetadata sets the commit author and the owner/repo the event belongs to
+func enrichGitHubMetadata(metadata map[string]interface{}, event types.EvidenceEvent) {
+	setMetadata(metadata, MetadataAuthor, metadataString(event.Metadata, "author_name"))
+	for _, key := range []string{"html_url", "repository_url"} {
+		setMetadata(metadata, MetadataRepo, githubRepo(metadataString(event.Metadata, key)))
+	}
+}
+
+// enrichJiraMetadata sets the project of the issue, e.g. SEC for SEC-42
+func enrichJiraMetadata(metadata map[string]interface{}, event types.EvidenceEvent) {
+	if project, _, ok := strings.Cut(metadataString(event.Metadata, "key"), "-"); ok {
+		setMetadata(metadata, MetadataProject, project)
+	}
+}
+
+// githubRepo returns the owner/repo of a GitHub web or API URL, e.g.
+// https://github.com/acme/api/pull/7 or https://api.github.com/repos/acme/api,
+// or "" if rawURL names no repository
+func githubRepo(rawURL string) string {
+	u, err := url.Parse(rawURL)
+	if err != nil || u.Host == "" {
+		return ""
+	}
+	parts := strings.Split(strings.Trim(u.Path, "/"), "/")
+	if len(parts) > 0 && parts[0] == "repos" {
+		parts = parts[1:]
+	}
+	if len(parts) < 2 || parts[0] == "" || parts[1] == "" {
+		return ""
+	}
+	return parts[0] + "/" + parts[1]
+}
+
+// setMetadata sets key to value unless value is empty or key is already set
+func setMetadata(metadata map[string]interface{}, key, value string) {
+	if value == "" || metadataString(metadata, key) != "" {
+		return
+	}
+	metadata[key] = value
+}
+
+// metadataString returns the metadata value for key as text, or "" if unset
+func metadataString(metadata map[string]interface{}, key string) string {
+	value, ok := metadata[key]
+	if !ok || value == nil {
+		return ""
+	}
+	if s, ok := value.(string); ok {
+		return strings.TrimSpace(s)
+	}
+	return strings.TrimSpace(fmt.Sprint(value))
+}
+
+// promptMetadata renders the event's promptMetadataKeys as "key=value" pairs
+// separated by commas, or "" if it has none
+func promptMetadata(event types.EvidenceEvent) string {
+	var pairs []string
+	for _, key := range promptMetadataKeys {
+		if value := metadataString(event.Metadata, key); value != "" {
+			pairs = append(pairs, key+"="+value)
+		}
+	}
+	return strings.Join(pairs, ", ")
+}
+
+// redactPromptMetadata returns a copy of metadata with the values the prompt
+// renders redacted, adding what was redacted to summary
+func redactPromptMetadata(redactor Redactor, metadata map[string]interface{}, summary *types.RedactionSummary) (map[string]interface{}, error) {
+	if len(metadata) == 0 {
+		return metadata, nil
+	}
+	redacted := make(map[string]interface{}, len(metadata))
+	for k, v := range metadata {
+		redacted[k] = v
+	}
+	for _, key := range promptMetadataKeys {
+		value := metadataString(metadata, key)
+		if value == "" {
+			continue
+		}
+		text, redactionMap, err := redactor.Redact(value)
+		if err != nil {
+			return nil, err
+		}
+		summary.Add(redactionMap)
+		redacted[key] = text
+	}
+	return redacted, nil
+}
@@ -1,6 +1,10 @@
 package ai
 
-import "errors"
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
 
 // Request validation errors
 var (
@@ -24,6 +28,22 @@ var (
 
 	// ErrMCPConnectorFailed indicates all MCP connector calls failed
 	ErrMCPConnectorFailed = errors.New("ai: all MCP connector calls failed")
+
+	// ErrReplayMissingResponse indicates a recorded provider call has no stored response to replay
+	ErrReplayMissingResponse = errors.New("ai: recorded response not found for audit entry")
+
+	// ErrDenylistViolation indicates a prompt contained a denylisted term and was not sent
+	ErrDenylistViolation = errors.New("ai: prompt contains a denylisted term")
+
+	// ErrCacheMiss indicates a cache-only analysis found no cached result
+	ErrCacheMiss = errors.New("ai: no cached result for this analysis")
+
+	// ErrPromptTooLarge indicates a prompt exceeded ai.max_prompt_chars and was not sent
+	ErrPromptTooLarge = errors.New("ai: prompt exceeds the configured maximum size")
+
+	// ErrUnknownResidualRisk indicates the provider returned a residual risk that
+	// is not a known level while ai.strict_residual_risk is set
+	ErrUnknownResidualRisk = errors.New("ai: provider returned an unknown residual risk")
 )
 
 // Provider errors (retryable with backoff)
@@ -48,8 +68,91 @@ var (
 
 	// ErrProviderQuotaExceeded indicates the provider quota was exhausted
 	ErrProviderQuotaExceeded = errors.New("ai: provider quota exhausted")
+
+	// ErrContextLengthExceeded indicates the prompt did not fit the model's context window
+	ErrContextLengthExceeded = errors.New("ai: prompt exceeds the model context window")
 )
 
+// ErrorCode identifies the category of a provider error
+type ErrorCode string
+
+// Provider error codes, one per provider sentinel error
+const (
+	CodeProviderTimeout       ErrorCode = "provider_timeout"
+	CodeProviderRateLimit     ErrorCode = "provider_rate_limit"
+	CodeProviderUnavailable   ErrorCode = "provider_unavailable"
+	CodeProviderAuth          ErrorCode = "provider_auth"
+	CodeInvalidJSON           ErrorCode = "invalid_json"
+	CodeProviderQuotaExceeded ErrorCode = "provider_quota_exceeded"
+	CodeContextLengthExceeded ErrorCode = "context_length_exceeded"
+)
+
+// codeSentinels maps error codes to the sentinel errors they match with errors.Is
+var codeSentinels = map[ErrorCode]error{
+	CodeProviderTimeout:       ErrProviderTimeout,
+	CodeProviderRateLimit:     ErrProviderRateLimit,
+	CodeProviderUnavailable:   ErrProviderUnavailable,
+	CodeProviderAuth:          ErrProviderAuth,
+	CodeInvalidJSON:           ErrInvalidJSON,
+	CodeProviderQuotaExceeded: ErrProviderQuotaExceeded,
+	CodeContextLengthExceeded: ErrContextLengthExceeded,
+}
+
+// ProviderError is a provider failure enriched with the provider, model, and attempt count.
+// errors.Is matches the sentinel for its Code (e.g. ErrProviderRateLimit), so existing
+// checks keep working; use errors.As to access the details.
+type ProviderError struct {
+	Code     ErrorCode // Error category
+	Provider string    // Provider name, e.g. "openai"
+	Model    string    // Model in use, if known
+	Attempts int       // Number of attempts made, if retried
+	Cause    error     // Underlying error, if any
+}
+
+// NewProviderError creates a ProviderError for the given code, provider, and cause
+func NewProviderError(code ErrorCode, provider string, cause error) *ProviderError {
+	return &ProviderError{
+		Code:     code,
+		Provider: provider,
+		Cause:    cause,
+	}
+}
+
+// Error implements the error interface
+func (e *ProviderError) Error() string {
+	msg := string(e.Code)
+	if sentinel, ok := codeSentinels[e.Code]; ok {
+		msg = sentinel.Error()
+	}
+
+	detail := e.Provider
+	if e.Model != "" {
+		detail += "/" + e.Model
+	}
+	if detail != "" {
+		msg = fmt.Sprintf("%s [%s]", msg, detail)
+	}
+	if e.Attempts > 1 {
+		msg = fmt.Sprintf("%s after %d attempts", msg, e.Attempts)
+	}
+	if e.Cause != nil {
+		msg = fmt.Sprintf("%s: %v", msg, e.Cause)
+	}
+
+	return msg
+}
+
+// Unwrap returns the underlying cause
+func (e *ProviderError) Unwrap() error {
+	return e.Cause
+}
+
+// Is reports whether target is the sentinel error for this error's code
+func (e *ProviderError) Is(target error) bool {
+	sentinel, ok := codeSentinels[e.Code]
+	return ok && sentinel == target
+}
+
 // IsRetryable returns true if the error should be retried with backoff
 func IsRetryable(err error) bool {
 	return errors.Is(err, ErrProviderTimeout) ||
@@ -62,6 +165,42 @@ func IsFatalError(err error) bool {
 	return errors.Is(err, ErrProviderAuth) ||
 		errors.Is(err, ErrInvalidJSON) ||
 		errors.Is(err, ErrProviderQuotaExceeded) ||
+		IsContextLengthError(err) ||
 		errors.Is(err, ErrInvalidRequest) ||
 		errors.Is(err, ErrZeroEvents)
 }
+
+// contextLengthMarkers are lowercase fragments of the error codes and messages
+// providers return when a prompt is longer than the model's context window.
+// Looser phrases like "too many tokens" also appear in rate-limit errors.
+var contextLengthMarkers = []string{
+	"context_length_exceeded",
+	"maximum context length",
+	"prompt is too long",
+	"input is too long",
+}
+
+// IsContextLengthError returns true if the provider rejected the prompt as too
+// long for the model, either as ErrContextLengthExceeded or, for providers that
+// don't classify the error, by the wording of the provider's message
+func IsContextLengthError(err error) bool {
+	// ai.max_prompt_chars refusals never reach the provider
+	if err == nil || errors.Is(err, ErrPromptTooLarge) {
+		return false
+	}
+	if errors.Is(err, ErrContextLengthExceeded) {
+		return true
+	}
+	// The provider already classified it, e.g. as a rate limit
+	var providerErr *ProviderError
+	if errors.As(err, &providerErr) {
+		return false
+	}
+	msg := strings.ToLower(err.Error())
+	for _, marker := range contextLengthMarkers {
+		if strings.Contains(msg, marker) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,147 @@
+package ai
+
+import (
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/pickjonathan/sdek-cli/pkg/types"
+)
+
+// UnknownEventType is assigned to evidence events that don't state a type
+const UnknownEventType = "unknown"
+
+// NormalizeOptions controls how NormalizeEvidence fills in missing event fields
+type NormalizeOptions struct {
+	LoadTime          time.Time // Assigned to events without a timestamp
+	DropUntimestamped bool      // Drop events without a timestamp instead of assigning LoadTime
+}
+
+// NormalizationStats counts the adjustments made by NormalizeEvidence
+type NormalizationStats struct {
+	DefaultedTypes      int
+	DefaultedTimestamps int
+	DroppedEvents       int
+	TrimmedContent      int
+}
+
+// Adjusted reports whether any event was changed or dropped
+func (s NormalizationStats) Adjusted() bool {
+	return s.DefaultedTypes+s.DefaultedTimestamps+s.DroppedEvents+s.TrimmedContent > 0
+}
+
+// NormalizeEvidence fills in fields that evidence from disparate sources often
+// lacks: an empty Type becomes UnknownEventType, a zero Timestamp becomes
+// opts.LoadTime (or the event is dropped), and surrounding whitespace is trimmed
+// from Content. The input bundle is not modified.
+func NormalizeEvidence(bundle types.EvidenceBundle, opts NormalizeOptions) (types.EvidenceBundle, NormalizationStats) {
+	var stats NormalizationStats
+	normalized := types.EvidenceBundle{Events: make([]types.EvidenceEvent, 0, len(bundle.Events))}
+
+	for _, event := range bundle.Events {
+		if event.Timestamp.IsZero() {
+			if opts.DropUntimestamped {
+				slog.Debug("Dropped evidence event without timestamp", "event", event.ID)
+				stats.DroppedEvents++
+				continue
+			}
+			event.Timestamp = opts.LoadTime
+			stats.DefaultedTimestamps++
+		}
+
+		if strings.TrimSpace(event.Type) == "" {
+			event.Type = UnknownEventType
+			stats.DefaultedTypes++
+		}
+
+		if trimmed := strings.TrimSpace(event.Content); trimmed != event.Content {
+			event.Content = trimmed
+			stats.TrimmedContent++
+		}
+
+		normalized.Events = append(normalized.Events, event)
+	}
+
+	if stats.Adjusted() {
+		slog.Info("Normalized evidence events",
+			"defaulted_types", stats.DefaultedTypes,
+			"defaulted_timestamps", stats.DefaultedTimestamps,
+			"dropped", stats.DroppedEvents,
+			"trimmed_content", stats.TrimmedContent)
+	}
+
+	return normalized, stats
+}
+
+// EmbedCitedEvidence attaches the events cited by finding to finding.Evidence,
+// in citation order. Citations that don't match an event are skipped. With a
+// non-nil redactor, content is redacted before it is embedded.
+func EmbedCitedEvidence(finding *types.Finding, evidence types.EvidenceBundle, redactor Redactor) error {
+	events := make(map[string]types.EvidenceEvent, len(evidence.Events))
+	for _, event := range evidence.Events {
+		events[event.ID] = event
+	}
+
+	embedded := make([]types.CitedEvidence, 0, len(finding.Citations))
+	seen := make(map[string]bool)
+	for _, id := range finding.Citations {
+		event, ok := events[id]
+		if !ok || seen[id] {
+			continue
+		}
+		seen[id] = true
+
+		cited := types.CitedEvidence{
+			ID:        event.ID,
+			Source:    event.Source,
+			Type:      event.Type,
+			Timestamp: event.Timestamp,
+			Content:   event.Content,
+		}
+		if redactor != nil {
+			redacted, _, err := redactor.Redact(event.Content)
+			if err != nil {
+				return fmt.Errorf("failed to redact evidence %s: %w", event.ID, err)
+			}
+			cited.Content = redacted
+			cited.Redacted = true
+		}
+		embedded = append(embedded, cited)
+	}
+
+	finding.Evidence = embedded
+	return nil
+}
+
+// DetailCitations renders each of finding's citations with the source and date
+// of the event it cites, e.g. "evt-1 (github, 2024-05-01)", and stores them in
+// finding.CitationsDetailed in citation order. Citations that don't match an
+// event are rendered as "evt-9 (unknown event)"; the raw Citations are untouched.
+func DetailCitations(finding *types.Finding, evidence types.EvidenceBundle) {
+	events := make(map[string]types.EvidenceEvent, len(evidence.Events))
+	for _, event := range evidence.Events {
+		events[event.ID] = event
+	}
+
+	detailed := make([]string, 0, len(finding.Citations))
+	for _, id := range finding.Citations {
+		event, ok := events[id]
+		if !ok {
+			detailed = append(detailed, fmt.Sprintf("%s (unknown event)", id))
+			continue
+		}
+
+		source := event.Source
+		if source == "" {
+			source = "unknown source"
+		}
+		if event.Timestamp.IsZero() {
+			detailed = append(detailed, fmt.Sprintf("%s (%s)", id, source))
+			continue
+		}
+		detailed = append(detailed, fmt.Sprintf("%s (%s, %s)", id, source, event.Timestamp.UTC().Format("2006-01-02")))
+	}
+
+	finding.CitationsDetailed = detailed
+}
@@ -0,0 +1,293 @@
+package ai
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"sync"
+
+	"github.com/pickjonathan/sdek-cli/internal/ai/connectors"
+	"github.com/pickjonathan/sdek-cli/pkg/types"
+)
+
+// NativeEvidenceSchema is the schema of evidence files holding sdek evidence
+// events, as a JSON array, NDJSON or {"schema": "native", "events": [...]}
+const NativeEvidenceSchema = "native"
+
+// GitHubEvidenceSchema is the schema of GitHub REST API exports of issues,
+// pull requests and commits
+const GitHubEvidenceSchema = "github"
+
+// EvidenceAdapter converts one exporter's JSON format into evidence events
+type EvidenceAdapter interface {
+	// Schema returns the name matched against an evidence file's top-level "schema" field
+	Schema() string
+
+	// Parse converts the raw contents of an evidence file into events
+	Parse(data []byte) ([]types.EvidenceEvent, error)
+}
+
+// EvidenceDetector is implemented by adapters that can recognize their format
+// from the contents of an evidence file without a top-level "schema" field.
+// Detect must not claim native sdek events, which are the fallback.
+type EvidenceDetector interface {
+	// Detect reports whether data is in the adapter's format
+	Detect(data []byte) bool
+}
+
+// Evidence adapter registry, in registration order
+var (
+	evidenceAdapters   []EvidenceAdapter
+	evidenceAdaptersMu sync.RWMutex
+)
+
+func init() {
+	RegisterEvidenceAdapter(nativeEvidenceAdapter{})
+	RegisterEvidenceAdapter(githubEvidenceAdapter{})
+}
+
+// RegisterEvidenceAdapter registers adapter for evidence files whose top-level
+// "schema" field is adapter.Schema(), or, if it implements EvidenceDetector,
+// whose contents it detects. Registering a schema again replaces the earlier
+// adapter.
+func RegisterEvidenceAdapter(adapter EvidenceAdapter) {
+	if adapter == nil {
+		panic("ai.RegisterEvidenceAdapter: adapter is nil")
+	}
+
+	evidenceAdaptersMu.Lock()
+	defer evidenceAdaptersMu.Unlock()
+
+	for i, existing := range evidenceAdapters {
+		if existing.Schema() == adapter.Schema() {
+			evidenceAdapters[i] = adapter
+			return
+		}
+	}
+	evidenceAdapters = append(evidenceAdapters, adapter)
+}
+
+// EvidenceSchemas returns the names of the registered evidence schemas, sorted
+func EvidenceSchemas() []string {
+	evidenceAdaptersMu.RLock()
+	defer evidenceAdaptersMu.RUnlock()
+	return registeredSchemas()
+}
+
+// ParseEvidence converts the contents of an evidence file into events using
+// the adapter for its format: the one named by a top-level "schema" field if
+// the file is a JSON object with one, otherwise the first that detects the
+// contents (see EvidenceDetector), otherwise the native adapter. The file name
+// plays no part, so native files named like an exporter's still load. An
+// unregistered schema is an error.
+func ParseEvidence(data []byte) ([]types.EvidenceEvent, error) {
+	adapter, err := evidenceAdapterFor(data)
+	if err != nil {
+		return nil, err
+	}
+	return adapter.Parse(data)
+}
+
+// evidenceAdapterFor selects the adapter for an evidence file
+func evidenceAdapterFor(data []byte) (EvidenceAdapter, error) {
+	evidenceAdaptersMu.RLock()
+	defer evidenceAdaptersMu.RUnlock()
+
+	if schema := evidenceSchema(data); schema != "" {
+		for _, adapter := range evidenceAdapters {
+			if adapter.Schema() == schema {
+				return adapter, nil
+			}
+		}
+		return nil, fmt.Errorf("unknown evidence schema %q (registered: %v)", schema, registeredSchemas())
+	}
+
+	for _, adapter := range evidenceAdapters {
+		if detector, ok := adapter.(EvidenceDetector); ok && detector.Detect(data) {
+			return adapter, nil
+		}
+	}
+
+	return nativeEvidenceAdapter{}, nil
+}
+
+// registeredSchemas lists the registered schemas; the caller holds evidenceAdaptersMu
+func registeredSchemas() []string {
+	schemas := make([]string, 0, len(evidenceAdapters))
+	for _, adapter := range evidenceAdapters {
+		schemas = append(schemas, adapter.Schema())
+	}
+	sort.Strings(schemas)
+	return schemas
+}
+
+// evidenceSchema returns the top-level "schema" field of data if it is a
+// single JSON object, or "" otherwise (arrays, NDJSON, no schema field)
+func evidenceSchema(data []byte) string {
+	trimmed := bytes.TrimSpace(data)
+	if len(trimmed) == 0 || trimmed[0] != '{' {
+		return ""
+	}
+	var header struct {
+		Schema string `json:"schema"`
+	}
+	if err := json.Unmarshal(trimmed, &header); err != nil {
+		return ""
+	}
+	return header.Schema
+}
+
+// nativeEvidenceAdapter parses sdek evidence events from a JSON array, from
+// NDJSON (one event object per line), or from a {"events": [...]} object
+type nativeEvidenceAdapter struct{}
+
+func (nativeEvidenceAdapter) Schema() string {
+	return NativeEvidenceSchema
+}
+
+func (nativeEvidenceAdapter) Parse(data []byte) ([]types.EvidenceEvent, error) {
+	trimmed := bytes.TrimSpace(data)
+	if len(trimmed) == 0 {
+		return nil, nil
+	}
+
+	var events []types.EvidenceEvent
+	if trimmed[0] == '[' {
+		if err := json.Unmarshal(trimmed, &events); err != nil {
+			return nil, fmt.Errorf("failed to parse JSON: %w", err)
+		}
+		return events, nil
+	}
+
+	if evidenceSchema(trimmed) != "" {
+		var file struct {
+			Events []types.EvidenceEvent `json:"events"`
+		}
+		if err := json.Unmarshal(trimmed, &file); err != nil {
+			return nil, fmt.Errorf("failed to parse JSON: %w", err)
+		}
+		return file.Events, nil
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(trimmed))
+	for {
+		var event types.EvidenceEvent
+		if err := decoder.Decode(&event); err == io.EOF {
+			break
+		} else if err != nil {
+			return nil, fmt.Errorf("failed to parse NDJSON event %d: %w", len(events)+1, err)
+		}
+		events = append(events, event)
+	}
+
+	return events, nil
+}
+
+// githubEvidenceAdapter parses GitHub REST API exports: either an object
+// {"schema": "github", "issues": [...], "pull_requests": [...], "commits": [...]}
+// or a JSON array of API items, whose kind is inferred from their fields
+type githubEvidenceAdapter struct{}
+
+func (githubEvidenceAdapter) Schema() string {
+	return GitHubEvidenceSchema
+}
+
+// Detect recognizes a JSON array whose first item is a GitHub API item: one
+// with an html_url, or a commit's sha and commit fields. Native events have
+// neither.
+func (githubEvidenceAdapter) Detect(data []byte) bool {
+	trimmed := bytes.TrimSpace(data)
+	if len(trimmed) == 0 || trimmed[0] != '[' {
+		return false
+	}
+	var items []map[string]json.RawMessage
+	if err := json.Unmarshal(trimmed, &items); err != nil || len(items) == 0 {
+		return false
+	}
+	if _, ok := items[0]["html_url"]; ok {
+		return true
+	}
+	_, sha := items[0]["sha"]
+	_, commit := items[0]["commit"]
+	return sha && commit
+}
+
+func (githubEvidenceAdapter) Parse(data []byte) ([]types.EvidenceEvent, error) {
+	trimmed := bytes.TrimSpace(data)
+	if len(trimmed) == 0 {
+		return nil, nil
+	}
+
+	if trimmed[0] == '[' {
+		var items []json.RawMessage
+		if err := json.Unmarshal(trimmed, &items); err != nil {
+			return nil, fmt.Errorf("failed to parse GitHub export: %w", err)
+		}
+		return githubEvents("", items)
+	}
+
+	var export struct {
+		Issues       []json.RawMessage `json:"issues"`
+		PullRequests []json.RawMessage `json:"pull_requests"`
+		Commits      []json.RawMessage `json:"commits"`
+	}
+	if err := json.Unmarshal(trimmed, &export); err != nil {
+		return nil, fmt.Errorf("failed to parse GitHub export: %w", err)
+	}
+
+	var events []types.EvidenceEvent
+	for _, section := range []struct {
+		itemType string
+		items    []json.RawMessage
+	}{
+		{"issue", export.Issues},
+		{"pr", export.PullRequests},
+		{"commit", export.Commits},
+	} {
+		sectionEvents, err := githubEvents(section.itemType, section.items)
+		if err != nil {
+			return nil, err
+		}
+		events = append(events, sectionEvents...)
+	}
+	return events, nil
+}
+
+// githubEvents converts GitHub API items of itemType, or of their inferred
+// type when itemType is empty, to events
+func githubEvents(itemType string, items []json.RawMessage) ([]types.EvidenceEvent, error) {
+	events := make([]types.EvidenceEvent, 0, len(items))
+	for i, item := range items {
+		typ := itemType
+		if typ == "" {
+			typ = githubItemType(item)
+		}
+		event, err := connectors.GitHubEvent(typ, item)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse GitHub item %d: %w", i+1, err)
+		}
+		events = append(events, event)
+	}
+	return events, nil
+}
+
+// githubItemType infers whether a GitHub API item is a commit, a pull request or an issue
+func githubItemType(item json.RawMessage) string {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(item, &fields); err != nil {
+		return "issue"
+	}
+	if _, ok := fields["sha"]; ok {
+		if _, ok := fields["commit"]; ok {
+			return "commit"
+		}
+	}
+	for _, key := range []string{"pull_request", "merged_at", "head"} {
+		if _, ok := fields[key]; ok {
+			return "pr"
+		}
+	}
+	return "issue"
+}
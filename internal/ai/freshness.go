@@ -0,0 +1,59 @@
+package ai
+
+import (
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/pickjonathan/sdek-cli/pkg/types"
+)
+
+// markStaleEvidence flags a finding whose newest cited event is older than
+// ai.evidence_max_age_days and records why in StaleEvidenceNote, since old
+// evidence may no longer reflect the current controls. Findings without
+// citations are judged by the newest event in the bundle.
+func (e *engineImpl) markStaleEvidence(finding *types.Finding, evidence types.EvidenceBundle) {
+	maxAgeDays := e.config.AI.EvidenceMaxAgeDays
+	if maxAgeDays <= 0 || finding.StaleEvidence {
+		return
+	}
+
+	newest, ok := newestCitedEvent(finding.Citations, evidence)
+	if !ok {
+		return
+	}
+	age := e.clock.Now().Sub(newest)
+	if age <= time.Duration(maxAgeDays)*24*time.Hour {
+		return
+	}
+
+	days := int(age.Hours() / 24)
+	finding.StaleEvidence = true
+	finding.StaleEvidenceNote = fmt.Sprintf("newest cited event is %d days old (limit %d)", days, maxAgeDays)
+
+	slog.Warn("Finding is based on stale evidence",
+		"control", finding.ControlID,
+		"newest_event_age_days", days,
+		"evidence_max_age_days", maxAgeDays)
+}
+
+// newestCitedEvent returns the timestamp of the newest event cited, or of the
+// newest event in the bundle when there are no citations. Events without a
+// timestamp are ignored.
+func newestCitedEvent(citations []string, evidence types.EvidenceBundle) (time.Time, bool) {
+	cited := make(map[string]bool, len(citations))
+	for _, id := range citations {
+		cited[id] = true
+	}
+
+	var newest time.Time
+	for _, event := range evidence.Events {
+		if len(cited) > 0 && !cited[event.ID] {
+			continue
+		}
+		if event.Timestamp.After(newest) {
+			newest = event.Timestamp
+		}
+	}
+	return newest, !newest.IsZero()
+}
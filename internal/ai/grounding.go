@@ -0,0 +1,28 @@
+package ai
+
+import (
+	"fmt"
+	"log/slog"
+
+	"github.com/pickjonathan/sdek-cli/pkg/types"
+)
+
+// requireCitations flags a finding for review when it cites fewer events than
+// ai.min_citations and records why in ReviewReason, since a confident claim
+// that cites no evidence is not grounded in it. Citations must already be
+// validated against the evidence bundle.
+func (e *engineImpl) requireCitations(finding *types.Finding) {
+	minCitations := e.config.AI.MinCitations
+	if minCitations <= 0 || len(finding.Citations) >= minCitations {
+		return
+	}
+
+	finding.ReviewRequired = true
+	finding.ReviewReason = fmt.Sprintf("insufficient citations: %d cited, %d required", len(finding.Citations), minCitations)
+
+	slog.Warn("Finding cites too few events, flagging for review",
+		"control", finding.ControlID,
+		"citations", len(finding.Citations),
+		"min_citations", minCitations,
+		"confidence", finding.ConfidenceScore)
+}
@@ -0,0 +1,284 @@
+package ai
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/pickjonathan/sdek-cli/internal/atomicfile"
+	"github.com/pickjonathan/sdek-cli/pkg/types"
+)
+
+// IncrementalState records, per control, the events last analyzed and the
+// finding they produced. Re-running an analysis whose context, evidence and
+// analysis scope (provider, model, prompts and sampling settings, as in
+// AnalysisCacheKey) are unchanged reuses the recorded finding instead of
+// calling the provider. Every event in the control's bundle counts, so adding,
+// changing or removing any of them re-analyzes the control.
+// It is safe for concurrent use.
+type IncrementalState struct {
+	path string
+	mu   sync.Mutex
+
+	Controls map[string]IncrementalEntry `json:"controls"`
+
+	// LastRun is when evidence was last loaded for a run recorded in this state
+	LastRun time.Time `json:"last_run,omitempty"`
+
+	unchanged  int
+	reanalyzed int
+}
+
+// IncrementalEntry is the last analysis recorded for one control
+type IncrementalEntry struct {
+	EvidenceHash string            `json:"evidence_hash"`          // Hash of the analysis scope, the context and every event
+	ContextHash  string            `json:"context_hash,omitempty"` // Hash of the analysis scope and the context alone
+	Events       map[string]string `json:"events,omitempty"`       // Content hash of every event analyzed, by event ID
+	Finding      *types.Finding    `json:"finding"`
+	AnalyzedAt   time.Time         `json:"analyzed_at"`
+	EventCount   int               `json:"event_count,omitempty"` // Events behind the finding, used to weight merges
+}
+
+// LoadIncrementalState reads the state file at path. A missing file yields an empty state.
+func LoadIncrementalState(path string) (*IncrementalState, error) {
+	state := &IncrementalState{path: path, Controls: make(map[string]IncrementalEntry)}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return state, nil
+		}
+		return nil, fmt.Errorf("failed to read incremental state: %w", err)
+	}
+
+	if err := json.Unmarshal(data, state); err != nil {
+		return nil, fmt.Errorf("failed to parse incremental state %s: %w", path, err)
+	}
+	if state.Controls == nil {
+		state.Controls = make(map[string]IncrementalEntry)
+	}
+
+	return state, nil
+}
+
+// Analyze returns the recorded finding when the analysis scope of engine, the
+// context and the evidence of this control are unchanged since the last run;
+// otherwise it calls engine.Analyze and records the new finding. The returned
+// bool reports whether the finding was reused.
+func (s *IncrementalState) Analyze(ctx context.Context, engine Engine, preamble types.ContextPreamble, evidence types.EvidenceBundle) (*types.Finding, bool, error) {
+	key := incrementalKey(preamble)
+	events := eventHashes(evidence)
+	hash := evidenceHash(engine, preamble, events)
+	contextKey := contextHash(engine, preamble)
+
+	s.mu.Lock()
+	entry, ok := s.Controls[key]
+	s.mu.Unlock()
+
+	if ok && entry.Finding != nil && entry.EvidenceHash == hash {
+		s.mu.Lock()
+		s.unchanged++
+		s.mu.Unlock()
+		finding := *entry.Finding
+		return &finding, true, nil
+	}
+
+	finding, err := engine.Analyze(ctx, preamble, evidence)
+	if err != nil {
+		return nil, false, err
+	}
+
+	s.mu.Lock()
+	s.Controls[key] = IncrementalEntry{
+		EvidenceHash: hash,
+		ContextHash:  contextKey,
+		Events:       events,
+		Finding:      finding,
+		AnalyzedAt:   time.Now(),
+		EventCount:   len(evidence.Events),
+	}
+	s.reanalyzed++
+	s.mu.Unlock()
+
+	return finding, false, nil
+}
+
+// AnalyzeNew is Analyze for runs that only load evidence added since LastRun.
+// The events the control has not analyzed before, or whose content changed,
+// are analyzed on their own and merged with the recorded finding for the
+// control, as chunk findings are merged. With no such events the recorded
+// finding is reused. Without a recorded finding, or when the context or
+// analysis scope changed since it was recorded, it behaves like Analyze;
+// NeedsFullEvidence reports when that will happen.
+func (s *IncrementalState) AnalyzeNew(ctx context.Context, engine Engine, preamble types.ContextPreamble, evidence types.EvidenceBundle) (*types.Finding, bool, error) {
+	if s.NeedsFullEvidence(engine, preamble) {
+		return s.Analyze(ctx, engine, preamble, evidence)
+	}
+
+	key := incrementalKey(preamble)
+	s.mu.Lock()
+	entry := s.Controls[key]
+	s.mu.Unlock()
+
+	fresh := newEvents(evidence, entry.Events)
+	if len(fresh.Events) == 0 {
+		s.mu.Lock()
+		s.unchanged++
+		s.mu.Unlock()
+		finding := *entry.Finding
+		return &finding, true, nil
+	}
+
+	finding, err := engine.Analyze(ctx, preamble, fresh)
+	if err != nil {
+		return nil, false, err
+	}
+
+	// Entries written before event counts were recorded weigh as one event
+	priorEvents := max(entry.EventCount, 1)
+	merged := MergeChunkFindings([]ChunkFinding{
+		{Finding: entry.Finding, Events: priorEvents},
+		{Finding: finding, Events: len(fresh.Events)},
+	})
+	merged.CacheHit = finding.CacheHit
+
+	events := make(map[string]string, len(entry.Events)+len(fresh.Events))
+	for id, hash := range entry.Events {
+		events[id] = hash
+	}
+	for id, hash := range eventHashes(fresh) {
+		events[id] = hash
+	}
+
+	s.mu.Lock()
+	s.Controls[key] = IncrementalEntry{
+		EvidenceHash: evidenceHash(engine, preamble, events),
+		ContextHash:  entry.ContextHash,
+		Events:       events,
+		Finding:      merged,
+		AnalyzedAt:   time.Now(),
+		EventCount:   priorEvents + len(fresh.Events),
+	}
+	s.reanalyzed++
+	s.mu.Unlock()
+
+	return merged, false, nil
+}
+
+// NeedsFullEvidence reports whether AnalyzeNew would analyze the control from
+// scratch: no finding is recorded for it, or its context or the analysis scope
+// of engine changed since. Such a control must be given all of its evidence,
+// not just what is new since LastRun.
+func (s *IncrementalState) NeedsFullEvidence(engine Engine, preamble types.ContextPreamble) bool {
+	s.mu.Lock()
+	entry, ok := s.Controls[incrementalKey(preamble)]
+	s.mu.Unlock()
+
+	return !ok || entry.Finding == nil || entry.ContextHash != contextHash(engine, preamble)
+}
+
+// Counts returns how many controls were reused unchanged and how many were re-analyzed
+func (s *IncrementalState) Counts() (unchanged, reanalyzed int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.unchanged, s.reanalyzed
+}
+
+// Summary describes the run, e.g. "3 controls unchanged, 1 re-analyzed"
+func (s *IncrementalState) Summary() string {
+	unchanged, reanalyzed := s.Counts()
+	return fmt.Sprintf("%d controls unchanged, %d re-analyzed", unchanged, reanalyzed)
+}
+
+// Save writes the state back to its file atomically
+func (s *IncrementalState) Save() error {
+	s.mu.Lock()
+	data, err := json.MarshalIndent(s, "", "  ")
+	s.mu.Unlock()
+	if err != nil {
+		return fmt.Errorf("failed to marshal incremental state: %w", err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(s.path), 0755); err != nil {
+		return fmt.Errorf("failed to create incremental state directory: %w", err)
+	}
+	if err := atomicfile.WriteFile(s.path, data, 0644); err != nil {
+		return fmt.Errorf("failed to write incremental state: %w", err)
+	}
+
+	return nil
+}
+
+// incrementalKey identifies a control across runs, treating framework aliases as the same framework
+func incrementalKey(preamble types.ContextPreamble) string {
+	framework := preamble.Framework
+	if id, ok := types.NormalizeFramework(framework); ok {
+		framework = string(id)
+	}
+	return framework + "/" + preamble.Section
+}
+
+// eventHashes returns the content hash of each event in evidence, by event ID
+func eventHashes(evidence types.EvidenceBundle) map[string]string {
+	hashes := make(map[string]string, len(evidence.Events))
+	for _, event := range evidence.Events {
+		sum := sha256.Sum256([]byte(event.Content))
+		hashes[event.ID] = hex.EncodeToString(sum[:])
+	}
+	return hashes
+}
+
+// newEvents returns the events of evidence that are not in recorded, the event
+// hashes of an earlier run, or whose content changed since
+func newEvents(evidence types.EvidenceBundle, recorded map[string]string) types.EvidenceBundle {
+	var fresh types.EvidenceBundle
+	current := eventHashes(evidence)
+	for _, event := range evidence.Events {
+		if hash, ok := recorded[event.ID]; !ok || hash != current[event.ID] {
+			fresh.Events = append(fresh.Events, event)
+		}
+	}
+	return fresh
+}
+
+// scopedEngine is implemented by engines that report the scope their analyses are cached under
+type scopedEngine interface {
+	analysisScope() AnalysisScope
+}
+
+// contextHash hashes the analysis scope of engine and the context of preamble
+func contextHash(engine Engine, preamble types.ContextPreamble) string {
+	var scope AnalysisScope
+	if scoped, ok := engine.(scopedEngine); ok {
+		scope = scoped.analysisScope()
+	}
+	return AnalysisCacheKey(scope, preamble, types.EvidenceBundle{})
+}
+
+// evidenceHash hashes the analysis scope of engine, the context of preamble and
+// every event in events, the content hashes by event ID of the evidence analyzed
+func evidenceHash(engine Engine, preamble types.ContextPreamble, events map[string]string) string {
+	ids := make([]string, 0, len(events))
+	for id := range events {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	h := sha256.New()
+	io.WriteString(h, contextHash(engine, preamble))
+	for _, id := range ids {
+		h.Write([]byte{0})
+		io.WriteString(h, id)
+		h.Write([]byte{0})
+		io.WriteString(h, events[id])
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
@@ -0,0 +1,144 @@
+package ai
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// Analysis outcomes recorded by sdek_ai_analyses_total
+const (
+	outcomeSuccess = "success"
+	outcomeError   = "error"
+)
+
+// metricsRegistry holds the engine metrics and the Go runtime and process collectors
+var metricsRegistry = prometheus.NewRegistry()
+
+// Engine metrics, shared by every engine in the process
+var (
+	analysesTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "sdek_ai_analyses_total",
+		Help: "Analyses run, by provider and outcome (success or error).",
+	}, []string{"provider", "outcome"})
+
+	analysisDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "sdek_ai_analysis_duration_seconds",
+		Help:    "Time taken by an analysis, including cache lookups and provider calls.",
+		Buckets: prometheus.ExponentialBuckets(0.05, 2, 12), // 50ms to ~100s
+	}, []string{"provider"})
+
+	cacheLookupsTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "sdek_ai_cache_lookups_total",
+		Help: "Analysis cache lookups, by result (hit or miss). The hit ratio is hits over all lookups.",
+	}, []string{"result"})
+
+	providerErrorsTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "sdek_ai_provider_errors_total",
+		Help: "Failed provider calls, by provider and error type.",
+	}, []string{"provider", "type"})
+
+	tokensTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "sdek_ai_tokens_total",
+		Help: "Estimated tokens sent to and received from providers, by provider and direction (prompt or response), and prompt tokens providers read from their prompt cache (cache_read).",
+	}, []string{"provider", "direction"})
+
+	connectorDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "sdek_ai_connector_duration_seconds",
+		Help:    "Time taken by connector calls during plan execution, by source and outcome.",
+		Buckets: prometheus.DefBuckets,
+	}, []string{"source", "outcome"})
+)
+
+func init() {
+	metricsRegistry.MustRegister(
+		analysesTotal,
+		analysisDuration,
+		cacheLookupsTotal,
+		providerErrorsTotal,
+		tokensTotal,
+		connectorDuration,
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+	)
+}
+
+// MetricsHandler serves the engine metrics in the Prometheus text format
+func MetricsHandler() http.Handler {
+	return promhttp.HandlerFor(metricsRegistry, promhttp.HandlerOpts{})
+}
+
+// recordAnalysis counts a finished analysis and observes its duration
+func recordAnalysis(provider string, start time.Time, err error) {
+	outcome := outcomeSuccess
+	if err != nil {
+		outcome = outcomeError
+	}
+	analysesTotal.WithLabelValues(provider, outcome).Inc()
+	analysisDuration.WithLabelValues(provider).Observe(time.Since(start).Seconds())
+}
+
+// recordCacheLookup counts an analysis cache hit or miss
+func recordCacheLookup(hit bool) {
+	result := "miss"
+	if hit {
+		result = "hit"
+	}
+	cacheLookupsTotal.WithLabelValues(result).Inc()
+}
+
+// recordTokens counts the estimated tokens of a prompt sent to provider and its response
+func recordTokens(provider, prompt, response string) {
+	tokensTotal.WithLabelValues(provider, "prompt").Add(float64(estimateTokens(prompt)))
+	tokensTotal.WithLabelValues(provider, "response").Add(float64(estimateTokens(response)))
+}
+
+// recordCacheReadTokens counts prompt tokens a provider served from its prompt cache
+func recordCacheReadTokens(provider string, tokens int) {
+	if tokens > 0 {
+		tokensTotal.WithLabelValues(provider, "cache_read").Add(float64(tokens))
+	}
+}
+
+// recordProviderError counts a failed provider call by error type
+func recordProviderError(provider string, err error) {
+	providerErrorsTotal.WithLabelValues(provider, errorType(err)).Inc()
+}
+
+// recordConnectorCall observes the latency of one connector call
+func recordConnectorCall(source string, start time.Time, err error) {
+	outcome := outcomeSuccess
+	if err != nil {
+		outcome = outcomeError
+	}
+	connectorDuration.WithLabelValues(source, outcome).Observe(time.Since(start).Seconds())
+}
+
+// errorType returns the ErrorCode of a provider error, or a coarser category
+// for errors raised before or around the call
+func errorType(err error) string {
+	var providerErr *ProviderError
+	if errors.As(err, &providerErr) {
+		return string(providerErr.Code)
+	}
+	for code, sentinel := range codeSentinels {
+		if errors.Is(err, sentinel) {
+			return string(code)
+		}
+	}
+
+	switch {
+	case errors.Is(err, ErrDenylistViolation):
+		return "denylist"
+	case errors.Is(err, ErrPromptTooLarge):
+		return "prompt_too_large"
+	case errors.Is(err, context.DeadlineExceeded), errors.Is(err, context.Canceled):
+		return "canceled"
+	}
+	return "other"
+}
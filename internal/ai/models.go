@@ -0,0 +1,187 @@
+package ai
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/pickjonathan/sdek-cli/pkg/types"
+)
+
+// knownModels lists the models each hosted provider is known to serve. Local
+// providers such as Ollama run whatever models are installed and have no list.
+var knownModels = map[string][]string{
+	"openai": {
+		"gpt-4.1", "gpt-4.1-mini", "gpt-4.1-nano",
+		"gpt-4o", "gpt-4o-mini",
+		"gpt-4-turbo", "gpt-4-turbo-preview", "gpt-4",
+		"gpt-3.5-turbo",
+		"o1", "o1-mini", "o3", "o3-mini", "o4-mini",
+	},
+	"anthropic": {
+		"claude-opus-4-20250514", "claude-sonnet-4-20250514",
+		"claude-3-7-sonnet-20250219", "claude-3-7-sonnet-latest",
+		"claude-3-5-sonnet-20241022", "claude-3-5-sonnet-20240620", "claude-3-5-sonnet-latest",
+		"claude-3-5-haiku-20241022", "claude-3-5-haiku-latest",
+		"claude-3-opus-20240229", "claude-3-opus-latest",
+		"claude-3-sonnet-20240229",
+		"claude-3-haiku-20240307",
+	},
+	"gemini": {
+		"gemini-2.5-pro", "gemini-2.5-flash",
+		"gemini-2.0-flash", "gemini-2.0-flash-exp", "gemini-2.0-flash-lite",
+		"gemini-1.5-pro", "gemini-1.5-flash", "gemini-1.5-flash-8b",
+	},
+}
+
+// snapshotSuffix matches the date suffix of a pinned model snapshot, e.g.
+// "-2024-08-06" or "-0613", optionally marked "-preview"
+var snapshotSuffix = regexp.MustCompile(`^-\d{4}(?:-\d{2}-\d{2})?(?:-preview)?$`)
+
+// KnownModels returns the known models for provider, or nil if the provider
+// accepts any model name
+func KnownModels(provider string) []string {
+	return knownModels[strings.ToLower(provider)]
+}
+
+// IsKnownModel reports whether model is a known model of provider or a dated
+// snapshot of one (e.g. "gpt-4o-2024-08-06"). Providers without a model list
+// accept every model.
+func IsKnownModel(provider, model string) bool {
+	models := KnownModels(provider)
+	if models == nil {
+		return true
+	}
+	for _, known := range models {
+		if model == known || (strings.HasPrefix(model, known) && snapshotSuffix.MatchString(model[len(known):])) {
+			return true
+		}
+	}
+	return false
+}
+
+// ModelProvider returns the provider that serves model, or "" if no known
+// provider does
+func ModelProvider(model string) string {
+	providers := make([]string, 0, len(knownModels))
+	for provider := range knownModels {
+		providers = append(providers, provider)
+	}
+	sort.Strings(providers)
+
+	for _, provider := range providers {
+		if IsKnownModel(provider, model) {
+			return provider
+		}
+	}
+	return ""
+}
+
+// ValidateModels checks ai.model, ai.context_fallback_model and each
+// providers.<name>.model against the provider's known models when AI is
+// enabled, unless ai.allow_unknown_model is set
+func ValidateModels(cfg *types.Config) error {
+	if !cfg.AI.Enabled || cfg.AI.AllowUnknownModel {
+		return nil
+	}
+
+	if cfg.AI.Model != "" {
+		if err := checkModel("ai.model", modelProviderName(cfg), cfg.AI.Model); err != nil {
+			return err
+		}
+	}
+	// The fallback is served by the same provider as ai.model
+	if cfg.AI.ContextFallbackModel != "" {
+		if err := checkModel("ai.context_fallback_model", modelProviderName(cfg), cfg.AI.ContextFallbackModel); err != nil {
+			return err
+		}
+	}
+
+	names := make([]string, 0, len(cfg.Providers))
+	for name := range cfg.Providers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if model := cfg.Providers[name].Model; model != "" {
+			if err := checkModel("providers."+name+".model", name, model); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// modelProviderName returns the provider ai.model is sent to: the provider_url
+// scheme when set, otherwise ai.provider (default openai)
+func modelProviderName(cfg *types.Config) string {
+	if cfg.AI.ProviderURL != "" {
+		scheme, _, _ := strings.Cut(cfg.AI.ProviderURL, "://")
+		return strings.ToLower(scheme)
+	}
+	if cfg.AI.Provider == "" {
+		return types.AIProviderOpenAI
+	}
+	return strings.ToLower(cfg.AI.Provider)
+}
+
+// checkModel returns an error naming the closest known model when provider
+// doesn't serve model
+func checkModel(field, provider, model string) error {
+	if IsKnownModel(provider, model) {
+		return nil
+	}
+
+	msg := fmt.Sprintf("invalid %s: %q is not a known %s model", field, model, provider)
+	if owner := ModelProvider(model); owner != "" {
+		msg += fmt.Sprintf(" (%s serves it)", owner)
+	}
+	if suggestion := nearestModel(model, KnownModels(provider)); suggestion != "" {
+		msg += fmt.Sprintf("; did you mean %q?", suggestion)
+	}
+	return fmt.Errorf("%s - pass --allow-unknown-model or set ai.allow_unknown_model for models newer than sdek", msg)
+}
+
+// nearestModel returns the candidate with the smallest edit distance to model.
+// There is no distance cutoff: a known model is always suggested. Ties go to
+// the alphabetically first candidate.
+func nearestModel(model string, candidates []string) string {
+	sorted := append([]string(nil), candidates...)
+	sort.Strings(sorted)
+
+	best := ""
+	bestDistance := -1
+	for _, candidate := range sorted {
+		if d := EditDistance(strings.ToLower(model), candidate); bestDistance < 0 || d < bestDistance {
+			best = candidate
+			bestDistance = d
+		}
+	}
+	return best
+}
+
+// EditDistance returns the Levenshtein distance between a and b
+func EditDistance(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+
+	prev := make([]int, len(rb)+1)
+	curr := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+
+	for i := 1; i <= len(ra); i++ {
+		curr[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+
+	return prev[len(rb)]
+}
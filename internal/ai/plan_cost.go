@@ -0,0 +1,96 @@
+package ai
+
+import (
+	"math"
+	"sort"
+	"strings"
+
+	"github.com/pickjonathan/sdek-cli/pkg/types"
+)
+
+const (
+	// planExpectedEvents is the number of events a connector is expected to
+	// return for a plan item with signal strength 1.0
+	planExpectedEvents = 50
+
+	// planTokensPerEvent approximates the prompt tokens one evidence event adds
+	planTokensPerEvent = 120
+)
+
+// modelPrices maps model name prefixes to USD per million input tokens.
+// The longest matching prefix wins, so "gpt-4o-mini" is not priced as "gpt-4".
+var modelPrices = map[string]float64{
+	"gpt-4o-mini":       0.15,
+	"gpt-4o":            2.50,
+	"gpt-4-turbo":       10.00,
+	"gpt-4":             30.00,
+	"gpt-3.5-turbo":     0.50,
+	"claude-3-opus":     15.00,
+	"claude-3-5-sonnet": 3.00,
+	"claude-3-sonnet":   3.00,
+	"claude-3-5-haiku":  0.80,
+	"claude-3-haiku":    0.25,
+	"gemini-1.5-pro":    1.25,
+	"gemini-1.5-flash":  0.075,
+}
+
+// PlanCostEstimate is the projected cost of executing the approved items of a plan
+type PlanCostEstimate struct {
+	ApprovedItems int     `json:"approved_items"`
+	APICalls      int     `json:"api_calls"` // One connector call per approved item
+	Tokens        int     `json:"tokens"`    // Prompt tokens added by the collected evidence
+	CostUSD       float64 `json:"cost_usd"`
+	Model         string  `json:"model"`
+	Priced        bool    `json:"priced"` // False when the model has no entry in the price table
+}
+
+// EstimatePlanCost estimates calls, tokens and dollars for the approved and
+// auto-approved items of plan. Each item is expected to return
+// SignalStrength × planExpectedEvents events; pending and denied items are ignored.
+func EstimatePlanCost(plan *types.EvidencePlan, model string) PlanCostEstimate {
+	estimate := PlanCostEstimate{Model: model}
+	if plan == nil {
+		return estimate
+	}
+
+	for _, item := range plan.Items {
+		if item.ApprovalStatus != types.ApprovalApproved && item.ApprovalStatus != types.ApprovalAutoApproved {
+			continue
+		}
+		estimate.ApprovedItems++
+		estimate.APICalls++
+		estimate.Tokens += planItemTokens(item)
+	}
+
+	if price, ok := modelPrice(model); ok {
+		estimate.Priced = true
+		estimate.CostUSD = float64(estimate.Tokens) / 1_000_000 * price
+	}
+
+	return estimate
+}
+
+// planItemTokens estimates the tokens the events collected for item will add to the prompt
+func planItemTokens(item types.PlanItem) int {
+	signal := math.Max(0, math.Min(1, item.SignalStrength))
+	events := int(math.Round(signal * planExpectedEvents))
+	return events * planTokensPerEvent
+}
+
+// modelPrice returns the USD price per million input tokens for model
+func modelPrice(model string) (float64, bool) {
+	model = strings.ToLower(model)
+
+	prefixes := make([]string, 0, len(modelPrices))
+	for prefix := range modelPrices {
+		prefixes = append(prefixes, prefix)
+	}
+	sort.Slice(prefixes, func(i, j int) bool { return len(prefixes[i]) > len(prefixes[j]) })
+
+	for _, prefix := range prefixes {
+		if strings.HasPrefix(model, prefix) {
+			return modelPrices[prefix], true
+		}
+	}
+	return 0, false
+}
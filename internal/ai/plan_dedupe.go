@@ -0,0 +1,40 @@
+package ai
+
+import (
+	"log/slog"
+	"time"
+
+	"github.com/pickjonathan/sdek-cli/pkg/types"
+)
+
+// dedupeEvents removes events collected more than once, as happens when plan
+// items for the same source have overlapping queries. Events are identified
+// by source and ID, since IDs are only unique within a source (GitHub and
+// Jira may both number their events from 1), or by a hash of their source,
+// type, timestamp and content when they have no ID. The first occurrence is
+// kept, so plan order is preserved.
+func dedupeEvents(events []types.EvidenceEvent) []types.EvidenceEvent {
+	seen := make(map[string]bool, len(events))
+	unique := make([]types.EvidenceEvent, 0, len(events))
+	for _, event := range events {
+		key := eventKey(event)
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		unique = append(unique, event)
+	}
+
+	if removed := len(events) - len(unique); removed > 0 {
+		slog.Info("Removed duplicate events collected by plan items", "duplicates", removed, "events", len(unique))
+	}
+	return unique
+}
+
+// eventKey identifies an event for deduplication
+func eventKey(event types.EvidenceEvent) string {
+	if event.ID != "" {
+		return "id:" + event.Source + "\x00" + event.ID
+	}
+	return "content:" + hashString(event.Source+"\x00"+event.Type+"\x00"+event.Timestamp.UTC().Format(time.RFC3339Nano)+"\x00"+event.Content)
+}
@@ -0,0 +1,96 @@
+package ai
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/pickjonathan/sdek-cli/pkg/types"
+)
+
+// PlanItemExplanation explains why a plan item was proposed and how it was
+// approved, with the plan's budget usage up to and including the item
+type PlanItemExplanation struct {
+	Source         string  `json:"source"`
+	Query          string  `json:"query"`
+	Rationale      string  `json:"rationale"`
+	SignalStrength float64 `json:"signal_strength"`
+
+	ApprovalStatus types.ApprovalStatus `json:"approval_status"`
+	Approval       string               `json:"approval"`          // Why the item has its approval status
+	Pattern        string               `json:"pattern,omitempty"` // Auto-approve pattern the query matched
+
+	// Running totals as items are added, in plan order
+	Items  int `json:"items"`  // Counted against ai.budgets.maxSources
+	Calls  int `json:"calls"`  // Connector calls, counted against ai.budgets.maxAPICalls
+	Tokens int `json:"tokens"` // Estimated evidence tokens, counted against ai.budgets.maxTokens
+}
+
+// PlanExplanation explains each item of a proposed plan (see ExplainPlan)
+type PlanExplanation struct {
+	Items   []PlanItemExplanation `json:"items"`
+	Budgets types.BudgetLimits    `json:"budgets"`
+}
+
+// ExplainPlan explains, for each item of plan in order, its rationale and
+// signal strength, why it was or wasn't auto-approved under cfg, and the
+// running budget totals. Token totals use the same per-item estimate as
+// EstimatePlanCost.
+func ExplainPlan(plan *types.EvidencePlan, cfg types.AIConfig) PlanExplanation {
+	explanation := PlanExplanation{Budgets: cfg.Budgets}
+	if plan == nil {
+		return explanation
+	}
+
+	tokens := 0
+	for i, item := range plan.Items {
+		tokens += planItemTokens(item)
+		explanation.Items = append(explanation.Items, PlanItemExplanation{
+			Source:         item.Source,
+			Query:          item.Query,
+			Rationale:      item.Rationale,
+			SignalStrength: item.SignalStrength,
+			ApprovalStatus: item.ApprovalStatus,
+			Approval:       approvalReason(item, cfg.Autonomous),
+			Pattern:        item.AutoApprovePattern,
+			Items:          i + 1,
+			Calls:          i + 1,
+			Tokens:         tokens,
+		})
+	}
+	return explanation
+}
+
+// approvalReason describes why item has its approval status
+func approvalReason(item types.PlanItem, autonomous types.AutonomousConfig) string {
+	switch {
+	case item.ApprovalStatus == types.ApprovalAutoApproved && item.AutoApprovePattern != "":
+		return fmt.Sprintf("auto-approved: query matched pattern %q for %s", item.AutoApprovePattern, item.Source)
+	case item.ApprovalStatus == types.ApprovalAutoApproved:
+		return "auto-approved"
+	case item.WeakRationale:
+		return fmt.Sprintf("needs manual approval: rationale is missing or shorter than %d characters (ai.autonomous.minRationaleChars)", autonomous.MinRationaleChars)
+	case item.ApprovalStatus == types.ApprovalApproved:
+		return "approved"
+	case item.ApprovalStatus == types.ApprovalDenied:
+		return "denied"
+	case !autonomous.Enabled:
+		return "needs manual approval: auto-approve is off (ai.autonomous.enabled is false)"
+	}
+
+	patterns := sourcePatterns(autonomous.AutoApprove, item.Source)
+	if len(patterns) == 0 {
+		return fmt.Sprintf("needs manual approval: no auto-approve patterns for %s", item.Source)
+	}
+	return fmt.Sprintf("needs manual approval: query matched none of the %d auto-approve pattern(s) for %s", len(patterns), item.Source)
+}
+
+// sourcePatterns returns the auto-approve patterns for source, whose name is
+// matched case-insensitively as by the auto-approve matcher
+func sourcePatterns(policy types.AutoApproveConfig, source string) []string {
+	for name, patterns := range policy {
+		if strings.EqualFold(name, source) {
+			return patterns
+		}
+	}
+	return nil
+}
@@ -0,0 +1,14 @@
+package ai
+
+import (
+	"strings"
+	"unicode/utf8"
+)
+
+// weakRationale reports whether a plan item rationale is missing or shorter
+// than minChars characters. An empty rationale is always weak, even when
+// minChars is 0.
+func weakRationale(rationale string, minChars int) bool {
+	length := utf8.RuneCountInString(strings.TrimSpace(rationale))
+	return length == 0 || length < minChars
+}
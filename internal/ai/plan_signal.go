@@ -0,0 +1,66 @@
+package ai
+
+import (
+	"log/slog"
+	"sort"
+
+	"github.com/pickjonathan/sdek-cli/pkg/types"
+)
+
+// dropWeakSignals removes plan items with a signal strength below minSignal,
+// keeping the order of the rest. A minSignal of 0 keeps every item.
+func dropWeakSignals(items []types.PlanItem, minSignal float64) []types.PlanItem {
+	if minSignal <= 0 {
+		return items
+	}
+
+	kept := items[:0]
+	for _, item := range items {
+		if item.SignalStrength >= minSignal {
+			kept = append(kept, item)
+		}
+	}
+	if dropped := len(items) - len(kept); dropped > 0 {
+		slog.Info("Dropped plan items below the minimum signal strength",
+			"dropped", dropped,
+			"kept", len(kept),
+			"min_signal_strength", minSignal)
+	}
+	return kept
+}
+
+// capPlanItems keeps the maxItems items with the highest signal strength,
+// breaking ties by source then query, and returns them in their original order.
+// A maxItems of 0 keeps every item.
+func capPlanItems(items []types.PlanItem, maxItems int) []types.PlanItem {
+	if maxItems <= 0 || len(items) <= maxItems {
+		return items
+	}
+
+	ranked := make([]int, len(items))
+	for i := range ranked {
+		ranked[i] = i
+	}
+	sort.SliceStable(ranked, func(a, b int) bool {
+		x, y := items[ranked[a]], items[ranked[b]]
+		if x.SignalStrength != y.SignalStrength {
+			return x.SignalStrength > y.SignalStrength
+		}
+		if x.Source != y.Source {
+			return x.Source < y.Source
+		}
+		return x.Query < y.Query
+	})
+
+	keep := ranked[:maxItems]
+	sort.Ints(keep)
+	capped := make([]types.PlanItem, 0, maxItems)
+	for _, i := range keep {
+		capped = append(capped, items[i])
+	}
+
+	slog.Info("Capped plan to the highest-signal items",
+		"dropped", len(items)-maxItems,
+		"max_items", maxItems)
+	return capped
+}
@@ -0,0 +1,68 @@
+package ai
+
+import (
+	"context"
+	"strings"
+
+	"github.com/pickjonathan/sdek-cli/internal/ai/connectors"
+	"github.com/pickjonathan/sdek-cli/pkg/types"
+)
+
+// PlanItemSimulation is the estimated result of one plan item against local evidence
+type PlanItemSimulation struct {
+	Source         string               `json:"source"`
+	Query          string               `json:"query"`
+	ApprovalStatus types.ApprovalStatus `json:"approval_status"`
+	Hits           int                  `json:"hits"`
+	EventIDs       []string             `json:"event_ids,omitempty"`
+	Error          string               `json:"error,omitempty"` // Set when the query can't be parsed
+}
+
+// PlanSimulation is the estimated result of a plan against local evidence
+type PlanSimulation struct {
+	Items        []PlanItemSimulation `json:"items"`
+	Events       int                  `json:"events"`        // Events in the evidence snapshot
+	UniqueEvents int                  `json:"unique_events"` // Distinct events matched by any item
+}
+
+// SimulatePlan runs every item of plan, whatever its approval status, against
+// the evidence held by fs instead of live connectors. An item's query and
+// filters are parsed with connectors.ParseQuery and restricted to events from
+// the item's source.
+func SimulatePlan(ctx context.Context, plan *types.EvidencePlan, fs *connectors.FilesystemConnector) (*PlanSimulation, error) {
+	sim := &PlanSimulation{Items: []PlanItemSimulation{}, Events: fs.Len()}
+	if plan == nil {
+		return sim, nil
+	}
+
+	matched := make(map[string]bool)
+	for _, item := range plan.Items {
+		result := PlanItemSimulation{
+			Source:         item.Source,
+			Query:          item.Query,
+			ApprovalStatus: item.ApprovalStatus,
+		}
+
+		raw := strings.TrimSpace(item.Query + " " + strings.Join(item.Filters, " "))
+		query, err := connectors.ParseQuery(item.Source, raw)
+		if err != nil {
+			result.Error = err.Error()
+			sim.Items = append(sim.Items, result)
+			continue
+		}
+
+		hits, err := fs.CollectQuery(ctx, query)
+		if err != nil {
+			return nil, err
+		}
+		result.Hits = len(hits)
+		for _, event := range hits {
+			result.EventIDs = append(result.EventIDs, event.ID)
+			matched[event.ID] = true
+		}
+		sim.Items = append(sim.Items, result)
+	}
+	sim.UniqueEvents = len(matched)
+
+	return sim, nil
+}
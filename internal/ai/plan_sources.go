@@ -0,0 +1,82 @@
+package ai
+
+import (
+	"context"
+	"log/slog"
+	"sort"
+	"strings"
+
+	"github.com/pickjonathan/sdek-cli/pkg/types"
+)
+
+type planSourcesKey struct{}
+
+// WithPlanSources returns a context that restricts ProposePlan to the given
+// sources, overriding the connectors and MCP servers enabled in config
+func WithPlanSources(ctx context.Context, sources []string) context.Context {
+	return context.WithValue(ctx, planSourcesKey{}, sources)
+}
+
+// planSources returns the sources a plan may use: the override attached to ctx,
+// or else the enabled connectors and, when MCP is enabled, its servers. An
+// empty result means no restriction.
+func (e *engineImpl) planSources(ctx context.Context) []string {
+	if sources, ok := ctx.Value(planSourcesKey{}).([]string); ok && len(sources) > 0 {
+		return normalizeSources(sources)
+	}
+
+	var sources []string
+	for name, conn := range e.config.AI.Connectors {
+		if conn.Enabled {
+			sources = append(sources, name)
+		}
+	}
+	if e.config.MCP.Enabled {
+		for name := range e.config.MCP.Servers {
+			sources = append(sources, name)
+		}
+	}
+	return normalizeSources(sources)
+}
+
+// normalizeSources lowercases, deduplicates and sorts source names
+func normalizeSources(sources []string) []string {
+	seen := make(map[string]bool, len(sources))
+	normalized := make([]string, 0, len(sources))
+	for _, source := range sources {
+		source = strings.ToLower(strings.TrimSpace(source))
+		if source == "" || seen[source] {
+			continue
+		}
+		seen[source] = true
+		normalized = append(normalized, source)
+	}
+	sort.Strings(normalized)
+	return normalized
+}
+
+// filterPlanSources drops plan items whose source is not in sources, logging
+// each removal. An empty sources list keeps every item.
+func filterPlanSources(items []types.PlanItem, sources []string) []types.PlanItem {
+	if len(sources) == 0 {
+		return items
+	}
+
+	allowed := make(map[string]bool, len(sources))
+	for _, source := range sources {
+		allowed[source] = true
+	}
+
+	kept := items[:0]
+	for _, item := range items {
+		if !allowed[strings.ToLower(item.Source)] {
+			slog.Warn("Dropping plan item for a source that is not enabled",
+				"source", item.Source,
+				"query", item.Query,
+				"enabled_sources", strings.Join(sources, ","))
+			continue
+		}
+		kept = append(kept, item)
+	}
+	return kept
+}
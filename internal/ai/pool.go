@@ -0,0 +1,36 @@
+package ai
+
+import (
+	"sync"
+
+	"github.com/pickjonathan/sdek-cli/pkg/types"
+)
+
+// ForEachLimit calls fn(i) for every i in [0, n) with at most limits.Workers()
+// calls running at once, and returns when all calls have finished. It is the
+// worker pool shared by bulk analysis and plan execution; fn must be safe to
+// call concurrently and should write its result to index i rather than share state.
+func ForEachLimit(n int, limits types.ConcurrencyLimits, fn func(i int)) {
+	workers := limits.Workers()
+	if workers > n {
+		workers = n
+	}
+
+	indexes := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indexes {
+				fn(i)
+			}
+		}()
+	}
+
+	for i := 0; i < n; i++ {
+		indexes <- i
+	}
+	close(indexes)
+	wg.Wait()
+}
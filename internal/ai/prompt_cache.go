@@ -0,0 +1,13 @@
+package ai
+
+import "context"
+
+// PromptCachingProvider is implemented by providers that can cache a stable
+// prompt prefix between calls, such as Anthropic with prompt caching enabled.
+// The engine passes the policy context of the built-in analysis prompt as the
+// prefix, since every analysis of a control shares it.
+type PromptCachingProvider interface {
+	// AnalyzeWithCachedPrefix sends prefix followed by rest, marking prefix for
+	// caching, and returns the response and the prompt tokens read from the cache
+	AnalyzeWithCachedPrefix(ctx context.Context, prefix, rest string) (response string, cacheReadTokens int, err error)
+}
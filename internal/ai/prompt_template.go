@@ -0,0 +1,81 @@
+package ai
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"text/template"
+	"time"
+
+	"github.com/pickjonathan/sdek-cli/pkg/types"
+)
+
+// analysisOutputInstructions describes the JSON response the engine parses into a Finding.
+// Custom prompt templates should include it (as {{.OutputInstructions}}) to keep responses parseable.
+const analysisOutputInstructions = `Provide your analysis in JSON format with the following fields:
+- summary: Brief summary of findings (50-200 words)
+- mapped_controls: List of control IDs that apply
+- confidence_score: Confidence level (0.0-1.0)
+- residual_risk: Risk level (low/medium/high)
+- justification: Detailed explanation (100-500 words)
+- citations: List of event IDs that support the finding
+`
+
+// PromptTemplateData is the data available to custom analysis prompt templates.
+//
+// Example template:
+//
+//	Assess {{.Preamble.Framework}} {{.Preamble.Section}}:
+//	{{.Preamble.Excerpt}}
+//	{{range .Evidence.Events}}- [{{.ID}}] {{.Content}}
+//	{{end}}
+//	{{.OutputInstructions}}
+type PromptTemplateData struct {
+	Preamble           types.ContextPreamble
+	Evidence           types.EvidenceBundle // Event content is already redacted
+	OutputInstructions string
+}
+
+// LoadPromptTemplate reads and parses a text/template prompt file.
+// The template is also executed against sample data so that references to
+// unknown fields are reported when the template is loaded rather than mid-analysis.
+func LoadPromptTemplate(path string) (*template.Template, error) {
+	path, err := expandHome(path)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read prompt template: %w", err)
+	}
+
+	tmpl, err := template.New(filepath.Base(path)).Parse(string(data))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse prompt template: %w", err)
+	}
+
+	sample := PromptTemplateData{
+		Preamble: types.ContextPreamble{
+			Framework:  "SOC2",
+			Version:    "2017",
+			Section:    "CC6.1",
+			Excerpt:    "Sample control excerpt",
+			ControlIDs: []string{"CC6.1"},
+			Rubrics:    types.DefaultAnalysisRubrics(),
+			CreatedAt:  time.Now(),
+		},
+		Evidence: types.EvidenceBundle{
+			Events: []types.EvidenceEvent{
+				{ID: "evt-1", Source: "github", Type: "commit", Timestamp: time.Now(), Content: "Sample event"},
+			},
+		},
+		OutputInstructions: analysisOutputInstructions,
+	}
+	if err := tmpl.Execute(&bytes.Buffer{}, sample); err != nil {
+		return nil, fmt.Errorf("failed to execute prompt template: %w", err)
+	}
+
+	return tmpl, nil
+}
@@ -3,7 +3,14 @@ package providers
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/anthropics/anthropic-sdk-go"
@@ -27,7 +34,15 @@ type AnthropicEngine struct {
 	config  ai.AIConfig
 	limiter *RateLimiter
 
+	// promptCaching marks the stable system and policy excerpt blocks for
+	// Anthropic prompt caching (Extra["prompt_caching"])
+	promptCaching bool
+
+	// cacheReadTokens counts input tokens served from the prompt cache
+	cacheReadTokens atomic.Int64
+
 	// Testing/debugging fields
+	mu         sync.Mutex // Guards callCount and lastPrompt across concurrent analyses
 	callCount  int
 	lastPrompt string
 }
@@ -36,7 +51,7 @@ type AnthropicEngine struct {
 func NewAnthropicEngine(config types.ProviderConfig) (*AnthropicEngine, error) {
 	// Validate API key
 	if config.APIKey == "" {
-		return nil, ai.ErrProviderAuth
+		return nil, ai.NewProviderError(ai.CodeProviderAuth, "anthropic", fmt.Errorf("API key is required"))
 	}
 
 	// Set defaults if not provided
@@ -71,15 +86,17 @@ func NewAnthropicEngine(config types.ProviderConfig) (*AnthropicEngine, error) {
 		MaxTokens:    config.MaxTokens,
 		Temperature:  float32(config.Temperature),
 		Timeout:      config.Timeout,
-		RateLimit:    0, // Rate limiting handled by provider if configured
+		RateLimit:    config.RateLimit,
 		OpenAIKey:    "",
 		AnthropicKey: config.APIKey,
+		SystemPrompt: config.SystemPrompt,
 	}
 
 	return &AnthropicEngine{
-		client:  &client,
-		config:  legacyConfig,
-		limiter: NewRateLimiter(0), // No rate limiting by default for new system
+		client:        &client,
+		config:        legacyConfig,
+		limiter:       SharedRateLimiter("anthropic", config.Endpoint, config.RateLimit, config.RateLimitBurst),
+		promptCaching: promptCachingEnabled(config),
 	}, nil
 }
 
@@ -141,69 +158,22 @@ func (e *AnthropicEngine) Analyze(ctx context.Context, preamble types.ContextPre
 		defer cancel()
 	}
 
-	// Build the analysis prompt
-	prompt := e.buildContextAnalysisPrompt(preamble, evidence)
+	// Build the analysis prompt. The policy context is the same for every
+	// analysis of a control, so it goes in its own block that can be cached.
+	policyPrompt := e.buildContextPolicyPrompt(preamble)
+	evidencePrompt := e.buildContextEvidencePrompt(evidence)
 
 	// Define the tool schema for structured output
-	toolParam := anthropic.ToolParam{
-		Name:        "analyze_compliance_evidence",
-		Description: anthropic.String("Analyze evidence events against policy context for compliance"),
-		InputSchema: anthropic.ToolInputSchemaParam{
-			Properties: map[string]interface{}{
-				"title": map[string]interface{}{
-					"type":        "string",
-					"description": "Brief title summarizing the finding (20-100 chars)",
-				},
-				"summary": map[string]interface{}{
-					"type":        "string",
-					"description": "Summary of analysis and what was found (100-500 chars)",
-				},
-				"justification": map[string]interface{}{
-					"type":        "string",
-					"description": "Explanation of how evidence maps to policy requirements (100-1000 chars)",
-				},
-				"confidence_score": map[string]interface{}{
-					"type":        "number",
-					"description": "Confidence score (0.0-1.0)",
-					"minimum":     0,
-					"maximum":     1,
-				},
-				"residual_risk": map[string]interface{}{
-					"type":        "string",
-					"description": "Any gaps, concerns, or remaining risks (0-500 chars)",
-				},
-				"mapped_controls": map[string]interface{}{
-					"type":        "array",
-					"items":       map[string]interface{}{"type": "string"},
-					"description": "List of control IDs that this evidence supports",
-				},
-				"citations": map[string]interface{}{
-					"type":        "array",
-					"items":       map[string]interface{}{"type": "string"},
-					"description": "Event IDs or sources cited in the analysis",
-				},
-				"severity": map[string]interface{}{
-					"type":        "string",
-					"enum":        []string{"low", "medium", "high", "critical"},
-					"description": "Severity level based on gaps and risks",
-				},
-			},
-			Required: []string{"title", "summary", "justification", "confidence_score", "mapped_controls"},
-		},
-	}
+	toolParam := anthropicTool(ai.AnalysisToolSchema())
 
 	// Make the API call
 	msg, err := e.client.Messages.New(ctx, anthropic.MessageNewParams{
 		Model:       anthropic.Model(e.config.Model),
 		MaxTokens:   int64(e.config.MaxTokens),
 		Temperature: anthropic.Float(float64(e.config.Temperature)),
-		System: []anthropic.TextBlockParam{
-			{
-				Text: "You are an expert compliance analyst. Analyze evidence against policy requirements and provide detailed findings.",
-			},
-		},
+		System:      e.systemBlocks(e.systemPrompt("You are an expert compliance analyst. Analyze evidence against policy requirements and provide detailed findings.")),
 		Messages: []anthropic.MessageParam{
-			anthropic.NewUserMessage(anthropic.NewTextBlock(prompt)),
+			anthropic.NewUserMessage(e.contextBlocks(policyPrompt, evidencePrompt)...),
 		},
 		Tools: []anthropic.ToolUnionParam{{OfTool: &toolParam}},
 	})
@@ -211,6 +181,7 @@ func (e *AnthropicEngine) Analyze(ctx context.Context, preamble types.ContextPre
 	if err != nil {
 		return nil, fmt.Errorf("Anthropic API call failed: %w", err)
 	}
+	e.recordUsage(msg.Usage)
 
 	// Parse the tool use response
 	if len(msg.Content) == 0 {
@@ -273,18 +244,8 @@ func (e *AnthropicEngine) Analyze(ctx context.Context, preamble types.ContextPre
 		Mode:            "ai",
 	}
 
-	// Build provenance from evidence sources
-	sourceCount := make(map[string]int)
-	for _, event := range evidence.Events {
-		sourceCount[event.Source]++
-	}
-	for source, count := range sourceCount {
-		finding.Provenance = append(finding.Provenance, types.ProvenanceEntry{
-			Source:     source,
-			Query:      "", // Not tracked at event level
-			EventsUsed: count,
-		})
-	}
+	// Build provenance from evidence sources (queries aren't tracked at event level)
+	finding.Provenance = evidence.Provenance()
 
 	return finding, nil
 }
@@ -295,12 +256,23 @@ func (e *AnthropicEngine) ProposePlan(ctx context.Context, preamble types.Contex
 	return nil, fmt.Errorf("Feature 003 not yet implemented for Anthropic provider")
 }
 
+// AnalyzeConsensus implements ai.Engine.AnalyzeConsensus
+// This is a stub that returns an error - use an engine from ai.NewEngine for consensus analysis
+func (e *AnthropicEngine) AnalyzeConsensus(ctx context.Context, preamble types.ContextPreamble, evidence types.EvidenceBundle, providers ...ai.Provider) (*ai.ConsensusResult, error) {
+	return nil, fmt.Errorf("consensus analysis not implemented for Anthropic provider")
+}
+
 // ExecutePlan implements ai.Engine.ExecutePlan (Feature 003)
 // This is a stub that returns an error - Anthropic provider needs Feature 003 implementation
 func (e *AnthropicEngine) ExecutePlan(ctx context.Context, plan *types.EvidencePlan) (*types.EvidenceBundle, error) {
 	return nil, fmt.Errorf("Feature 003 not yet implemented for Anthropic provider")
 }
 
+// EstimatePlanCost implements ai.Engine.EstimatePlanCost (Feature 003)
+func (e *AnthropicEngine) EstimatePlanCost(plan *types.EvidencePlan) ai.PlanCostEstimate {
+	return ai.EstimatePlanCost(plan, e.config.Model)
+}
+
 // Provider implements ai.Engine.Provider
 func (e *AnthropicEngine) Provider() string {
 	return "anthropic"
@@ -321,13 +293,13 @@ func (e *AnthropicEngine) Health(ctx context.Context) error {
 	if err != nil {
 		// Check for auth errors
 		if isAnthropicAuthError(err) {
-			return ai.ErrProviderAuth
+			return e.providerError(ai.CodeProviderAuth, err)
 		}
 		// Check for quota errors
 		if isAnthropicQuotaError(err) {
-			return ai.ErrProviderQuotaExceeded
+			return e.providerError(ai.CodeProviderQuotaExceeded, err)
 		}
-		return ai.ErrProviderUnavailable
+		return e.providerError(ai.CodeProviderUnavailable, err)
 	}
 	return nil
 }
@@ -336,9 +308,11 @@ func (e *AnthropicEngine) Health(ctx context.Context) error {
 func (e *AnthropicEngine) analyzeWithRetry(ctx context.Context, req *ai.AnalysisRequest) (*ai.AnalysisResponse, error) {
 	var response *ai.AnalysisResponse
 	var lastErr error
+	attempts := 0
 
 	operation := func() error {
 		var err error
+		attempts++
 		response, err = e.performAnalysis(ctx, req)
 		lastErr = err
 
@@ -359,6 +333,11 @@ func (e *AnthropicEngine) analyzeWithRetry(ctx context.Context, req *ai.Analysis
 	// Perform retry with backoff
 	err := backoff.Retry(operation, backoff.WithContext(bo, ctx))
 	if err != nil {
+		// Record how many attempts were made before giving up
+		var providerErr *ai.ProviderError
+		if errors.As(lastErr, &providerErr) {
+			providerErr.Attempts = attempts
+		}
 		return nil, lastErr
 	}
 
@@ -371,45 +350,14 @@ func (e *AnthropicEngine) performAnalysis(ctx context.Context, req *ai.AnalysisR
 	systemPrompt, userPrompt := e.buildPrompt(req)
 
 	// Define the tool schema for structured output
-	toolParam := anthropic.ToolParam{
-		Name:        "analyze_evidence",
-		Description: anthropic.String("Analyze events for compliance control evidence"),
-		InputSchema: anthropic.ToolInputSchemaParam{
-			Properties: map[string]interface{}{
-				"evidence_links": map[string]interface{}{
-					"type":        "array",
-					"items":       map[string]interface{}{"type": "string"},
-					"description": "Event IDs that support the control",
-				},
-				"justification": map[string]interface{}{
-					"type":        "string",
-					"description": "Explanation of relevance (50-500 chars)",
-				},
-				"confidence": map[string]interface{}{
-					"type":        "integer",
-					"description": "Confidence score (0-100)",
-					"minimum":     0,
-					"maximum":     100,
-				},
-				"residual_risk": map[string]interface{}{
-					"type":        "string",
-					"description": "Notes on gaps or concerns (0-500 chars)",
-				},
-			},
-			Required: []string{"evidence_links", "justification", "confidence"},
-		},
-	}
+	toolParam := anthropicTool(ai.EvidenceToolSchema())
 
 	// Make the API call
 	msg, err := e.client.Messages.New(ctx, anthropic.MessageNewParams{
 		Model:       anthropic.Model(e.config.Model),
 		MaxTokens:   int64(e.config.MaxTokens),
 		Temperature: anthropic.Float(float64(e.config.Temperature)),
-		System: []anthropic.TextBlockParam{
-			{
-				Text: systemPrompt,
-			},
-		},
+		System:      e.systemBlocks(systemPrompt),
 		Messages: []anthropic.MessageParam{
 			anthropic.NewUserMessage(anthropic.NewTextBlock(userPrompt)),
 		},
@@ -419,10 +367,11 @@ func (e *AnthropicEngine) performAnalysis(ctx context.Context, req *ai.AnalysisR
 	if err != nil {
 		return nil, e.handleError(err)
 	}
+	e.recordUsage(msg.Usage)
 
 	// Parse the tool use response
 	if len(msg.Content) == 0 {
-		return nil, ai.ErrInvalidJSON
+		return nil, e.providerError(ai.CodeInvalidJSON, fmt.Errorf("empty response content"))
 	}
 
 	var toolUse *anthropic.ToolUseBlock
@@ -435,7 +384,7 @@ func (e *AnthropicEngine) performAnalysis(ctx context.Context, req *ai.AnalysisR
 	}
 
 	if toolUse == nil {
-		return nil, ai.ErrInvalidJSON
+		return nil, e.providerError(ai.CodeInvalidJSON, fmt.Errorf("no tool use block in response"))
 	}
 
 	// Parse the JSON input
@@ -448,11 +397,11 @@ func (e *AnthropicEngine) performAnalysis(ctx context.Context, req *ai.AnalysisR
 
 	inputJSON, err := json.Marshal(toolUse.Input)
 	if err != nil {
-		return nil, ai.ErrInvalidJSON
+		return nil, e.providerError(ai.CodeInvalidJSON, err)
 	}
 
 	if err := json.Unmarshal(inputJSON, &result); err != nil {
-		return nil, ai.ErrInvalidJSON
+		return nil, e.providerError(ai.CodeInvalidJSON, err)
 	}
 
 	// Build response
@@ -464,7 +413,7 @@ func (e *AnthropicEngine) performAnalysis(ctx context.Context, req *ai.AnalysisR
 		ResidualRisk:  result.ResidualRisk,
 		Provider:      "anthropic",
 		Model:         string(msg.Model),
-		TokensUsed:    int(msg.Usage.InputTokens + msg.Usage.OutputTokens),
+		TokensUsed:    int(msg.Usage.InputTokens + msg.Usage.CacheReadInputTokens + msg.Usage.CacheCreationInputTokens + msg.Usage.OutputTokens),
 		Timestamp:     time.Now(),
 		CacheHit:      false,
 	}, nil
@@ -473,6 +422,12 @@ func (e *AnthropicEngine) performAnalysis(ctx context.Context, req *ai.AnalysisR
 // buildPrompt constructs the prompt for Anthropic
 // buildContextAnalysisPrompt builds a prompt for Feature 003 context-based analysis
 func (e *AnthropicEngine) buildContextAnalysisPrompt(preamble types.ContextPreamble, evidence types.EvidenceBundle) string {
+	return e.buildContextPolicyPrompt(preamble) + e.buildContextEvidencePrompt(evidence)
+}
+
+// buildContextPolicyPrompt builds the policy context part of the analysis prompt,
+// which is shared by every analysis of the same control
+func (e *AnthropicEngine) buildContextPolicyPrompt(preamble types.ContextPreamble) string {
 	prompt := fmt.Sprintf(`Analyze the following evidence against policy requirements for %s %s.
 
 Framework: %s
@@ -486,7 +441,12 @@ Policy Excerpt:
 		prompt += fmt.Sprintf("\nRelated Controls: %v\n", preamble.ControlIDs)
 	}
 
-	prompt += "\nEvidence Events:\n"
+	return prompt
+}
+
+// buildContextEvidencePrompt builds the evidence and output instructions part of the analysis prompt
+func (e *AnthropicEngine) buildContextEvidencePrompt(evidence types.EvidenceBundle) string {
+	prompt := "\nEvidence Events:\n"
 	for i, event := range evidence.Events {
 		prompt += fmt.Sprintf("\n%d. [%s/%s] %s\n   ID: %s\n   Content: %s\n",
 			i+1, event.Source, event.Type, event.Timestamp.Format(time.RFC3339),
@@ -519,7 +479,7 @@ Provide a comprehensive compliance analysis including:
 }
 
 func (e *AnthropicEngine) buildPrompt(req *ai.AnalysisRequest) (system, user string) {
-	system = "You are a compliance analyst. Analyze events and map them to compliance controls."
+	system = e.systemPrompt("You are a compliance analyst. Analyze events and map them to compliance controls.")
 
 	user = fmt.Sprintf(`Analyze the following events for compliance with control %s (%s) in the %s framework.
 
@@ -562,26 +522,68 @@ func (e *AnthropicEngine) handleError(err error) error {
 		return nil
 	}
 
+	// Classify by HTTP status first: a 429's message can mention tokens too
+	switch status := anthropicStatusCode(err); {
+	case status == http.StatusUnauthorized || status == http.StatusForbidden:
+		return e.providerError(ai.CodeProviderAuth, err)
+	case status == http.StatusTooManyRequests:
+		return e.providerError(ai.CodeProviderRateLimit, err)
+	case status >= http.StatusInternalServerError:
+		return e.providerError(ai.CodeProviderUnavailable, err)
+	}
+
 	// Check for specific error types
+	if isAnthropicContextLengthError(err) {
+		return e.providerError(ai.CodeContextLengthExceeded, err)
+	}
 	if isAnthropicAuthError(err) {
-		return ai.ErrProviderAuth
+		return e.providerError(ai.CodeProviderAuth, err)
 	}
 	if isAnthropicRateLimitError(err) {
-		return ai.ErrProviderRateLimit
+		return e.providerError(ai.CodeProviderRateLimit, err)
 	}
 	if isAnthropicQuotaError(err) {
-		return ai.ErrProviderQuotaExceeded
+		return e.providerError(ai.CodeProviderQuotaExceeded, err)
 	}
 	if isAnthropicTimeoutError(err) {
-		return ai.ErrProviderTimeout
+		return e.providerError(ai.CodeProviderTimeout, err)
 	}
 	if isAnthropicServerError(err) {
-		return ai.ErrProviderUnavailable
+		return e.providerError(ai.CodeProviderUnavailable, err)
 	}
 
 	return fmt.Errorf("anthropic api error: %w", err)
 }
 
+// providerError wraps err in an ai.ProviderError tagged with this provider and model
+func (e *AnthropicEngine) providerError(code ai.ErrorCode, err error) error {
+	return &ai.ProviderError{
+		Code:     code,
+		Provider: "anthropic",
+		Model:    e.config.Model,
+		Cause:    err,
+	}
+}
+
+// anthropicStatusCode returns the HTTP status of an Anthropic API error, or 0 if
+// err didn't come from an API response
+func anthropicStatusCode(err error) int {
+	var apiErr *anthropic.Error
+	if errors.As(err, &apiErr) {
+		return apiErr.StatusCode
+	}
+	return 0
+}
+
+// isAnthropicContextLengthError returns true if Anthropic rejected the prompt as
+// too long for the model, which it only does with a 400
+func isAnthropicContextLengthError(err error) bool {
+	if status := anthropicStatusCode(err); status != 0 && status != http.StatusBadRequest {
+		return false
+	}
+	return ai.IsContextLengthError(err)
+}
+
 // Error detection helpers
 func isAnthropicAuthError(err error) bool {
 	if err == nil {
@@ -630,14 +632,28 @@ func isAnthropicServerError(err error) bool {
 // This is a simpler interface for autonomous mode that takes a pre-formatted prompt
 // and returns the raw AI response as a string
 func (e *AnthropicEngine) AnalyzeWithContext(ctx context.Context, prompt string) (string, error) {
+	response, _, err := e.analyzeText(ctx, prompt, []anthropic.ContentBlockParamUnion{anthropic.NewTextBlock(prompt)})
+	return response, err
+}
+
+// AnalyzeWithCachedPrefix implements ai.PromptCachingProvider. With prompt
+// caching enabled the prefix (the policy context) is sent as its own block
+// marked for caching, as in Analyze; otherwise it behaves like AnalyzeWithContext.
+func (e *AnthropicEngine) AnalyzeWithCachedPrefix(ctx context.Context, prefix, rest string) (string, int, error) {
+	return e.analyzeText(ctx, prefix+rest, e.contextBlocks(prefix, rest))
+}
+
+// analyzeText sends the user message content and returns the text response and
+// the input tokens read from the prompt cache
+func (e *AnthropicEngine) analyzeText(ctx context.Context, prompt string, content []anthropic.ContentBlockParamUnion) (string, int, error) {
 	// Validate prompt
 	if prompt == "" {
-		return "", fmt.Errorf("prompt cannot be empty")
+		return "", 0, fmt.Errorf("prompt cannot be empty")
 	}
 
 	// Wait for rate limiter
 	if err := e.limiter.Wait(ctx); err != nil {
-		return "", err
+		return "", 0, err
 	}
 
 	// Set timeout from config if not already set
@@ -648,8 +664,10 @@ func (e *AnthropicEngine) AnalyzeWithContext(ctx context.Context, prompt string)
 	}
 
 	// Track for testing
+	e.mu.Lock()
 	e.callCount++
 	e.lastPrompt = prompt
+	e.mu.Unlock()
 
 	// Make API call with retry
 	var resp *anthropic.Message
@@ -659,15 +677,15 @@ func (e *AnthropicEngine) AnalyzeWithContext(ctx context.Context, prompt string)
 			Model:       anthropic.Model(e.config.Model),
 			MaxTokens:   int64(e.config.MaxTokens),
 			Temperature: anthropic.Float(float64(e.config.Temperature)),
-			System: []anthropic.TextBlockParam{
-				{
-					Text: "You are an expert compliance analyst. Analyze evidence and provide detailed, policy-grounded findings.",
-				},
-			},
+			System:      e.systemBlocks(e.systemPrompt("You are an expert compliance analyst. Analyze evidence and provide detailed, policy-grounded findings.")),
 			Messages: []anthropic.MessageParam{
-				anthropic.NewUserMessage(anthropic.NewTextBlock(prompt)),
+				anthropic.NewUserMessage(content...),
 			},
 		})
+		// A prompt too long for the model fails the same way every time
+		if isAnthropicContextLengthError(err) {
+			return backoff.Permanent(err)
+		}
 		return err
 	}
 
@@ -676,29 +694,118 @@ func (e *AnthropicEngine) AnalyzeWithContext(ctx context.Context, prompt string)
 
 	err := backoff.Retry(operation, bo)
 	if err != nil {
-		return "", e.handleError(err)
+		return "", 0, e.handleError(err)
 	}
+	e.recordUsage(resp.Usage)
 
 	if len(resp.Content) == 0 {
-		return "", fmt.Errorf("no content in Anthropic response")
+		return "", 0, fmt.Errorf("no content in Anthropic response")
 	}
 
 	// Extract text from the first content block
 	if block := resp.Content[0].AsAny(); block != nil {
 		if textBlock, ok := block.(anthropic.TextBlock); ok {
-			return textBlock.Text, nil
+			return textBlock.Text, int(resp.Usage.CacheReadInputTokens), nil
 		}
 	}
 
-	return "", fmt.Errorf("unexpected content type in Anthropic response")
+	return "", 0, fmt.Errorf("unexpected content type in Anthropic response")
+}
+
+// systemPrompt returns the configured system prompt, or defaultPrompt if none is set
+func (e *AnthropicEngine) systemPrompt(defaultPrompt string) string {
+	if e.config.SystemPrompt != "" {
+		return e.config.SystemPrompt
+	}
+	return defaultPrompt
+}
+
+// anthropicTool adapts a provider-neutral tool schema to an Anthropic tool definition
+func anthropicTool(schema ai.ToolSchema) anthropic.ToolParam {
+	return anthropic.ToolParam{
+		Name:        schema.Name,
+		Description: anthropic.String(schema.Description),
+		InputSchema: anthropic.ToolInputSchemaParam{
+			Properties: schema.Properties,
+			Required:   schema.Required,
+		},
+	}
+}
+
+// promptCachingEnabled reports whether Extra["prompt_caching"] turns on Anthropic prompt caching
+func promptCachingEnabled(config types.ProviderConfig) bool {
+	enabled, err := strconv.ParseBool(strings.TrimSpace(config.Extra["prompt_caching"]))
+	return err == nil && enabled
+}
+
+// systemBlocks returns the system prompt as a text block, marked for caching
+// when prompt caching is enabled. Anthropic caches the tools and system prompt
+// up to the marked block.
+func (e *AnthropicEngine) systemBlocks(text string) []anthropic.TextBlockParam {
+	block := anthropic.TextBlockParam{Text: text}
+	if e.promptCaching {
+		block.CacheControl = anthropic.NewCacheControlEphemeralParam()
+	}
+	return []anthropic.TextBlockParam{block}
+}
+
+// contextBlocks returns the user message content for a context analysis. With
+// prompt caching the policy context is a separate block marked for caching, so
+// analyses of the same control with different evidence reuse it.
+func (e *AnthropicEngine) contextBlocks(policyPrompt, evidencePrompt string) []anthropic.ContentBlockParamUnion {
+	if !e.promptCaching {
+		return []anthropic.ContentBlockParamUnion{anthropic.NewTextBlock(policyPrompt + evidencePrompt)}
+	}
+	policy := anthropic.TextBlockParam{Text: policyPrompt, CacheControl: anthropic.NewCacheControlEphemeralParam()}
+	return []anthropic.ContentBlockParamUnion{
+		{OfText: &policy},
+		anthropic.NewTextBlock(evidencePrompt),
+	}
+}
+
+// recordUsage tracks input tokens read from and written to the prompt cache
+func (e *AnthropicEngine) recordUsage(usage anthropic.Usage) {
+	if usage.CacheReadInputTokens == 0 && usage.CacheCreationInputTokens == 0 {
+		return
+	}
+	e.cacheReadTokens.Add(usage.CacheReadInputTokens)
+	slog.Debug("Anthropic prompt cache usage",
+		"cache_read_tokens", usage.CacheReadInputTokens,
+		"cache_creation_tokens", usage.CacheCreationInputTokens,
+		"input_tokens", usage.InputTokens)
+}
+
+// CacheReadTokens returns the input tokens served from Anthropic's prompt cache
+// across all calls, which are billed at a fraction of the normal input rate
+func (e *AnthropicEngine) CacheReadTokens() int64 {
+	return e.cacheReadTokens.Load()
+}
+
+// SystemPrompt implements ai.SystemPromptProvider
+func (e *AnthropicEngine) SystemPrompt() string {
+	return e.config.SystemPrompt
+}
+
+// ProviderName implements ai.NamedProvider
+func (e *AnthropicEngine) ProviderName() string {
+	return "anthropic"
+}
+
+// ModelName implements ai.NamedProvider
+func (e *AnthropicEngine) ModelName() string {
+	return e.config.Model
 }
 
 // GetCallCount implements ai.Provider.GetCallCount
 func (e *AnthropicEngine) GetCallCount() int {
+	e.mu.Lock()
+	defer e.mu.Unlock()
 	return e.callCount
 }
 
 // GetLastPrompt implements ai.Provider.GetLastPrompt
 func (e *AnthropicEngine) GetLastPrompt() string {
+	e.mu.Lock()
+	defer e.mu.Unlock()
 	return e.lastPrompt
 }
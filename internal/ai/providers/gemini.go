@@ -3,6 +3,7 @@ package providers
 import (
 	"context"
 	"fmt"
+	"sync"
 	"time"
 
 	"github.com/google/generative-ai-go/genai"
@@ -26,6 +27,7 @@ type GeminiProvider struct {
 	config     types.ProviderConfig
 	modelName  string
 	limiter    *RateLimiter
+	mu         sync.Mutex // Guards callCount and lastPrompt across concurrent analyses
 	callCount  int
 	lastPrompt string
 }
@@ -34,7 +36,7 @@ type GeminiProvider struct {
 func NewGeminiProvider(config types.ProviderConfig) (*GeminiProvider, error) {
 	// Validate API key
 	if config.APIKey == "" {
-		return nil, ai.ErrProviderAuth
+		return nil, ai.NewProviderError(ai.CodeProviderAuth, "gemini", fmt.Errorf("API key is required"))
 	}
 
 	// Set defaults if not provided
@@ -65,7 +67,7 @@ func NewGeminiProvider(config types.ProviderConfig) (*GeminiProvider, error) {
 		client:    client,
 		config:    config,
 		modelName: config.Model,
-		limiter:   NewRateLimiter(0), // No rate limiting by default
+		limiter:   SharedRateLimiter("gemini", config.Endpoint, config.RateLimit, config.RateLimitBurst),
 	}, nil
 }
 
@@ -89,8 +91,10 @@ func (p *GeminiProvider) AnalyzeWithContext(ctx context.Context, prompt string)
 	}
 
 	// Track for testing
+	p.mu.Lock()
 	p.callCount++
 	p.lastPrompt = prompt
+	p.mu.Unlock()
 
 	// Get model
 	model := p.client.GenerativeModel(p.modelName)
@@ -147,19 +151,33 @@ func (p *GeminiProvider) Health(ctx context.Context) error {
 	}
 
 	if resp == nil {
-		return ai.ErrProviderUnavailable
+		return p.providerError(ai.CodeProviderUnavailable, fmt.Errorf("empty health check response"))
 	}
 
 	return nil
 }
 
+// ProviderName implements ai.NamedProvider
+func (p *GeminiProvider) ProviderName() string {
+	return "gemini"
+}
+
+// ModelName implements ai.NamedProvider
+func (p *GeminiProvider) ModelName() string {
+	return p.modelName
+}
+
 // GetCallCount implements ai.Provider.GetCallCount
 func (p *GeminiProvider) GetCallCount() int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
 	return p.callCount
 }
 
 // GetLastPrompt implements ai.Provider.GetLastPrompt
 func (p *GeminiProvider) GetLastPrompt() string {
+	p.mu.Lock()
+	defer p.mu.Unlock()
 	return p.lastPrompt
 }
 
@@ -182,20 +200,30 @@ func (p *GeminiProvider) handleError(err error) error {
 	// Check for specific error types
 	switch {
 	case contains(errStr, "API key"):
-		return ai.ErrProviderAuth
+		return p.providerError(ai.CodeProviderAuth, err)
 	case contains(errStr, "quota"):
-		return ai.ErrProviderQuotaExceeded
+		return p.providerError(ai.CodeProviderQuotaExceeded, err)
 	case contains(errStr, "rate limit"):
-		return ai.ErrProviderRateLimit
+		return p.providerError(ai.CodeProviderRateLimit, err)
 	case contains(errStr, "timeout"), contains(errStr, "deadline"):
-		return ai.ErrProviderTimeout
+		return p.providerError(ai.CodeProviderTimeout, err)
 	case contains(errStr, "unavailable"), contains(errStr, "503"):
-		return ai.ErrProviderUnavailable
+		return p.providerError(ai.CodeProviderUnavailable, err)
 	default:
 		return fmt.Errorf("gemini api error: %w", err)
 	}
 }
 
+// providerError wraps err in an ai.ProviderError tagged with this provider and model
+func (p *GeminiProvider) providerError(code ai.ErrorCode, err error) error {
+	return &ai.ProviderError{
+		Code:     code,
+		Provider: "gemini",
+		Model:    p.modelName,
+		Cause:    err,
+	}
+}
+
 // contains is a helper function to check if a string contains a substring (case-insensitive)
 func contains(s, substr string) bool {
 	return len(s) >= len(substr) &&
@@ -8,6 +8,7 @@ import (
 	"io"
 	"net/http"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/pickjonathan/sdek-cli/internal/ai"
@@ -30,6 +31,7 @@ type OllamaProvider struct {
 	config     types.ProviderConfig
 	client     *http.Client
 	limiter    *RateLimiter
+	mu         sync.Mutex // Guards callCount and lastPrompt across concurrent analyses
 	callCount  int
 	lastPrompt string
 }
@@ -84,7 +86,7 @@ func NewOllamaProvider(config types.ProviderConfig) (*OllamaProvider, error) {
 		modelName: config.Model,
 		config:    config,
 		client:    client,
-		limiter:   NewRateLimiter(0), // No rate limiting for local models
+		limiter:   SharedRateLimiter("ollama", baseURL, config.RateLimit, config.RateLimitBurst),
 	}, nil
 }
 
@@ -101,8 +103,10 @@ func (p *OllamaProvider) AnalyzeWithContext(ctx context.Context, prompt string)
 	}
 
 	// Track for testing
+	p.mu.Lock()
 	p.callCount++
 	p.lastPrompt = prompt
+	p.mu.Unlock()
 
 	// Build request
 	reqBody := OllamaGenerateRequest{
@@ -115,11 +119,21 @@ func (p *OllamaProvider) AnalyzeWithContext(ctx context.Context, prompt string)
 		},
 	}
 
+	// Seeded sampling for reproducible output
+	if p.config.Seed != nil {
+		reqBody.Options["seed"] = *p.config.Seed
+	}
+
 	// Add extra options from config
 	for k, v := range p.config.Extra {
 		reqBody.Options[k] = v
 	}
 
+	// Ollama expects num_ctx as a number, not a string
+	if window := ai.ContextWindow(p.config); window > 0 {
+		reqBody.Options["num_ctx"] = window
+	}
+
 	// Marshal request
 	jsonData, err := json.Marshal(reqBody)
 	if err != nil {
@@ -177,12 +191,12 @@ func (p *OllamaProvider) Health(ctx context.Context) error {
 
 	resp, err := p.client.Do(req)
 	if err != nil {
-		return ai.ErrProviderUnavailable
+		return p.providerError(ai.CodeProviderUnavailable, err)
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
-		return ai.ErrProviderUnavailable
+		return p.providerError(ai.CodeProviderUnavailable, fmt.Errorf("health check returned status %d", resp.StatusCode))
 	}
 
 	// Verify our model is available
@@ -212,13 +226,33 @@ func (p *OllamaProvider) Health(ctx context.Context) error {
 	return nil
 }
 
+// EvidenceTokenBudget implements ai.EvidenceBudgetProvider using the num_ctx
+// context window, so bundles too large for a local model are analyzed in chunks
+func (p *OllamaProvider) EvidenceTokenBudget() int {
+	return ai.EvidenceTokenBudget(p.config)
+}
+
+// ProviderName implements ai.NamedProvider
+func (p *OllamaProvider) ProviderName() string {
+	return "ollama"
+}
+
+// ModelName implements ai.NamedProvider
+func (p *OllamaProvider) ModelName() string {
+	return p.modelName
+}
+
 // GetCallCount implements ai.Provider.GetCallCount
 func (p *OllamaProvider) GetCallCount() int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
 	return p.callCount
 }
 
 // GetLastPrompt implements ai.Provider.GetLastPrompt
 func (p *OllamaProvider) GetLastPrompt() string {
+	p.mu.Lock()
+	defer p.mu.Unlock()
 	return p.lastPrompt
 }
 
@@ -233,12 +267,22 @@ func (p *OllamaProvider) handleError(err error) error {
 	// Check for specific error types
 	switch {
 	case contains(errStr, "connection refused"), contains(errStr, "no such host"):
-		return ai.ErrProviderUnavailable
+		return p.providerError(ai.CodeProviderUnavailable, err)
 	case contains(errStr, "timeout"), contains(errStr, "deadline"):
-		return ai.ErrProviderTimeout
+		return p.providerError(ai.CodeProviderTimeout, err)
 	case contains(errStr, "model not found"), contains(errStr, "404"):
 		return fmt.Errorf("model %q not found: ensure it's pulled with 'ollama pull %s'", p.modelName, p.modelName)
 	default:
 		return fmt.Errorf("ollama error: %w", err)
 	}
 }
+
+// providerError wraps err in an ai.ProviderError tagged with this provider and model
+func (p *OllamaProvider) providerError(code ai.ErrorCode, err error) error {
+	return &ai.ProviderError{
+		Code:     code,
+		Provider: "ollama",
+		Model:    p.modelName,
+		Cause:    err,
+	}
+}
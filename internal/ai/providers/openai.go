@@ -3,8 +3,12 @@ package providers
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"math"
+	"net/http"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/cenkalti/backoff/v4"
@@ -28,6 +32,7 @@ type OpenAIEngine struct {
 	limiter *RateLimiter
 
 	// Testing/debugging fields
+	mu         sync.Mutex // Guards callCount and lastPrompt across concurrent analyses
 	callCount  int
 	lastPrompt string
 }
@@ -36,7 +41,7 @@ type OpenAIEngine struct {
 func NewOpenAIEngine(config types.ProviderConfig) (*OpenAIEngine, error) {
 	// Validate API key
 	if config.APIKey == "" {
-		return nil, ai.ErrProviderAuth
+		return nil, ai.NewProviderError(ai.CodeProviderAuth, "openai", fmt.Errorf("API key is required"))
 	}
 
 	// Set defaults if not provided
@@ -69,15 +74,17 @@ func NewOpenAIEngine(config types.ProviderConfig) (*OpenAIEngine, error) {
 		MaxTokens:    config.MaxTokens,
 		Temperature:  float32(config.Temperature),
 		Timeout:      config.Timeout,
-		RateLimit:    0, // Rate limiting handled by provider if configured
+		RateLimit:    config.RateLimit,
 		OpenAIKey:    config.APIKey,
 		AnthropicKey: "",
+		SystemPrompt: config.SystemPrompt,
+		Seed:         config.Seed,
 	}
 
 	return &OpenAIEngine{
 		client:  client,
 		config:  legacyConfig,
-		limiter: NewRateLimiter(0), // No rate limiting by default for new system
+		limiter: SharedRateLimiter("openai", config.Endpoint, config.RateLimit, config.RateLimitBurst),
 	}, nil
 }
 
@@ -143,53 +150,8 @@ func (e *OpenAIEngine) Analyze(ctx context.Context, preamble types.ContextPreamb
 	prompt := e.buildContextAnalysisPrompt(preamble, evidence)
 
 	// Define the function schema for structured output
-	functionDef := openai.FunctionDefinition{
-		Name:        "analyze_compliance_evidence",
-		Description: "Analyze evidence events against policy context for compliance",
-		Parameters: map[string]interface{}{
-			"type": "object",
-			"properties": map[string]interface{}{
-				"title": map[string]interface{}{
-					"type":        "string",
-					"description": "Brief title summarizing the finding (20-100 chars)",
-				},
-				"summary": map[string]interface{}{
-					"type":        "string",
-					"description": "Summary of analysis and what was found (100-500 chars)",
-				},
-				"justification": map[string]interface{}{
-					"type":        "string",
-					"description": "Explanation of how evidence maps to policy requirements (100-1000 chars)",
-				},
-				"confidence_score": map[string]interface{}{
-					"type":        "number",
-					"description": "Confidence score (0.0-1.0)",
-					"minimum":     0,
-					"maximum":     1,
-				},
-				"residual_risk": map[string]interface{}{
-					"type":        "string",
-					"description": "Any gaps, concerns, or remaining risks (0-500 chars)",
-				},
-				"mapped_controls": map[string]interface{}{
-					"type":        "array",
-					"items":       map[string]interface{}{"type": "string"},
-					"description": "List of control IDs that this evidence supports",
-				},
-				"citations": map[string]interface{}{
-					"type":        "array",
-					"items":       map[string]interface{}{"type": "string"},
-					"description": "Event IDs or sources cited in the analysis",
-				},
-				"severity": map[string]interface{}{
-					"type":        "string",
-					"enum":        []string{"low", "medium", "high", "critical"},
-					"description": "Severity level based on gaps and risks",
-				},
-			},
-			"required": []string{"title", "summary", "justification", "confidence_score", "mapped_controls"},
-		},
-	}
+	schema := ai.AnalysisToolSchema()
+	functionDef := openAIFunction(schema)
 
 	// Make the API call
 	chatReq := openai.ChatCompletionRequest{
@@ -197,7 +159,7 @@ func (e *OpenAIEngine) Analyze(ctx context.Context, preamble types.ContextPreamb
 		Messages: []openai.ChatCompletionMessage{
 			{
 				Role:    openai.ChatMessageRoleSystem,
-				Content: "You are an expert compliance analyst. Analyze evidence against policy requirements and provide detailed findings.",
+				Content: e.systemPrompt("You are an expert compliance analyst. Analyze evidence against policy requirements and provide detailed findings."),
 			},
 			{
 				Role:    openai.ChatMessageRoleUser,
@@ -206,9 +168,10 @@ func (e *OpenAIEngine) Analyze(ctx context.Context, preamble types.ContextPreamb
 		},
 		Functions: []openai.FunctionDefinition{functionDef},
 		FunctionCall: &openai.FunctionCall{
-			Name: "analyze_compliance_evidence",
+			Name: schema.Name,
 		},
-		Temperature: float32(e.config.Temperature),
+		Temperature: e.temperature(),
+		Seed:        e.config.Seed,
 	}
 
 	// Use MaxCompletionTokens for GPT-5 and o1 models, MaxTokens for others
@@ -271,18 +234,8 @@ func (e *OpenAIEngine) Analyze(ctx context.Context, preamble types.ContextPreamb
 		Mode:            "ai",
 	}
 
-	// Build provenance from evidence sources
-	sourceCount := make(map[string]int)
-	for _, event := range evidence.Events {
-		sourceCount[event.Source]++
-	}
-	for source, count := range sourceCount {
-		finding.Provenance = append(finding.Provenance, types.ProvenanceEntry{
-			Source:     source,
-			Query:      "", // Not tracked at event level
-			EventsUsed: count,
-		})
-	}
+	// Build provenance from evidence sources (queries aren't tracked at event level)
+	finding.Provenance = evidence.Provenance()
 
 	return finding, nil
 }
@@ -293,12 +246,23 @@ func (e *OpenAIEngine) ProposePlan(ctx context.Context, preamble types.ContextPr
 	return nil, fmt.Errorf("Feature 003 not yet implemented for OpenAI provider")
 }
 
+// AnalyzeConsensus implements ai.Engine.AnalyzeConsensus
+// This is a stub that returns an error - use an engine from ai.NewEngine for consensus analysis
+func (e *OpenAIEngine) AnalyzeConsensus(ctx context.Context, preamble types.ContextPreamble, evidence types.EvidenceBundle, providers ...ai.Provider) (*ai.ConsensusResult, error) {
+	return nil, fmt.Errorf("consensus analysis not implemented for OpenAI provider")
+}
+
 // ExecutePlan implements ai.Engine.ExecutePlan (Feature 003)
 // This is a stub that returns an error - OpenAI provider needs Feature 003 implementation
 func (e *OpenAIEngine) ExecutePlan(ctx context.Context, plan *types.EvidencePlan) (*types.EvidenceBundle, error) {
 	return nil, fmt.Errorf("Feature 003 not yet implemented for OpenAI provider")
 }
 
+// EstimatePlanCost implements ai.Engine.EstimatePlanCost (Feature 003)
+func (e *OpenAIEngine) EstimatePlanCost(plan *types.EvidencePlan) ai.PlanCostEstimate {
+	return ai.EstimatePlanCost(plan, e.config.Model)
+}
+
 // Provider implements ai.Engine.Provider
 func (e *OpenAIEngine) Provider() string {
 	return "openai"
@@ -311,13 +275,13 @@ func (e *OpenAIEngine) Health(ctx context.Context) error {
 	if err != nil {
 		// Check for auth errors
 		if isAuthError(err) {
-			return ai.ErrProviderAuth
+			return e.providerError(ai.CodeProviderAuth, err)
 		}
 		// Check for quota errors
 		if isQuotaError(err) {
-			return ai.ErrProviderQuotaExceeded
+			return e.providerError(ai.CodeProviderQuotaExceeded, err)
 		}
-		return ai.ErrProviderUnavailable
+		return e.providerError(ai.CodeProviderUnavailable, err)
 	}
 	return nil
 }
@@ -326,9 +290,11 @@ func (e *OpenAIEngine) Health(ctx context.Context) error {
 func (e *OpenAIEngine) analyzeWithRetry(ctx context.Context, req *ai.AnalysisRequest) (*ai.AnalysisResponse, error) {
 	var response *ai.AnalysisResponse
 	var lastErr error
+	attempts := 0
 
 	operation := func() error {
 		var err error
+		attempts++
 		response, err = e.performAnalysis(ctx, req)
 		lastErr = err
 
@@ -349,6 +315,11 @@ func (e *OpenAIEngine) analyzeWithRetry(ctx context.Context, req *ai.AnalysisReq
 	// Perform retry with backoff
 	err := backoff.Retry(operation, backoff.WithContext(bo, ctx))
 	if err != nil {
+		// Record how many attempts were made before giving up
+		var providerErr *ai.ProviderError
+		if errors.As(lastErr, &providerErr) {
+			providerErr.Attempts = attempts
+		}
 		return nil, lastErr
 	}
 
@@ -361,35 +332,8 @@ func (e *OpenAIEngine) performAnalysis(ctx context.Context, req *ai.AnalysisRequ
 	prompt := e.buildPrompt(req)
 
 	// Define the function schema for structured output
-	functionDef := openai.FunctionDefinition{
-		Name:        "analyze_evidence",
-		Description: "Analyze events for compliance control evidence",
-		Parameters: map[string]interface{}{
-			"type": "object",
-			"properties": map[string]interface{}{
-				"evidence_links": map[string]interface{}{
-					"type":        "array",
-					"items":       map[string]interface{}{"type": "string"},
-					"description": "Event IDs that support the control",
-				},
-				"justification": map[string]interface{}{
-					"type":        "string",
-					"description": "Explanation of relevance (50-500 chars)",
-				},
-				"confidence": map[string]interface{}{
-					"type":        "integer",
-					"description": "Confidence score (0-100)",
-					"minimum":     0,
-					"maximum":     100,
-				},
-				"residual_risk": map[string]interface{}{
-					"type":        "string",
-					"description": "Notes on gaps or concerns (0-500 chars)",
-				},
-			},
-			"required": []string{"evidence_links", "justification", "confidence"},
-		},
-	}
+	schema := ai.EvidenceToolSchema()
+	functionDef := openAIFunction(schema)
 
 	// Make the API call
 	chatReq := openai.ChatCompletionRequest{
@@ -397,7 +341,7 @@ func (e *OpenAIEngine) performAnalysis(ctx context.Context, req *ai.AnalysisRequ
 		Messages: []openai.ChatCompletionMessage{
 			{
 				Role:    openai.ChatMessageRoleSystem,
-				Content: "You are a compliance analyst. Analyze events and map them to compliance controls.",
+				Content: e.systemPrompt("You are a compliance analyst. Analyze events and map them to compliance controls."),
 			},
 			{
 				Role:    openai.ChatMessageRoleUser,
@@ -406,9 +350,10 @@ func (e *OpenAIEngine) performAnalysis(ctx context.Context, req *ai.AnalysisRequ
 		},
 		Functions: []openai.FunctionDefinition{functionDef},
 		FunctionCall: &openai.FunctionCall{
-			Name: "analyze_evidence",
+			Name: schema.Name,
 		},
-		Temperature: float32(e.config.Temperature),
+		Temperature: e.temperature(),
+		Seed:        e.config.Seed,
 	}
 
 	// Use MaxCompletionTokens for GPT-5 and o1 models, MaxTokens for others
@@ -425,12 +370,12 @@ func (e *OpenAIEngine) performAnalysis(ctx context.Context, req *ai.AnalysisRequ
 
 	// Parse the function call response
 	if len(resp.Choices) == 0 {
-		return nil, ai.ErrInvalidJSON
+		return nil, e.providerError(ai.CodeInvalidJSON, fmt.Errorf("no choices in response"))
 	}
 
 	choice := resp.Choices[0]
 	if choice.Message.FunctionCall == nil {
-		return nil, ai.ErrInvalidJSON
+		return nil, e.providerError(ai.CodeInvalidJSON, fmt.Errorf("no function call in response"))
 	}
 
 	// Parse the JSON arguments
@@ -442,7 +387,7 @@ func (e *OpenAIEngine) performAnalysis(ctx context.Context, req *ai.AnalysisRequ
 	}
 
 	if err := json.Unmarshal([]byte(choice.Message.FunctionCall.Arguments), &result); err != nil {
-		return nil, ai.ErrInvalidJSON
+		return nil, e.providerError(ai.CodeInvalidJSON, err)
 	}
 
 	// Build response
@@ -550,26 +495,91 @@ func (e *OpenAIEngine) handleError(err error) error {
 		return nil
 	}
 
+	// Classify by HTTP status first: a 429's message can mention tokens too
+	switch status := openAIStatusCode(err); {
+	case status == http.StatusUnauthorized || status == http.StatusForbidden:
+		return e.providerError(ai.CodeProviderAuth, err)
+	case status == http.StatusTooManyRequests:
+		if openAIErrorCode(err) == "insufficient_quota" {
+			return e.providerError(ai.CodeProviderQuotaExceeded, err)
+		}
+		return e.providerError(ai.CodeProviderRateLimit, err)
+	case status >= http.StatusInternalServerError:
+		return e.providerError(ai.CodeProviderUnavailable, err)
+	}
+
 	// Check for specific error types
+	if isContextLengthError(err) {
+		return e.providerError(ai.CodeContextLengthExceeded, err)
+	}
 	if isAuthError(err) {
-		return ai.ErrProviderAuth
+		return e.providerError(ai.CodeProviderAuth, err)
 	}
 	if isRateLimitError(err) {
-		return ai.ErrProviderRateLimit
+		return e.providerError(ai.CodeProviderRateLimit, err)
 	}
 	if isQuotaError(err) {
-		return ai.ErrProviderQuotaExceeded
+		return e.providerError(ai.CodeProviderQuotaExceeded, err)
 	}
 	if isTimeoutError(err) {
-		return ai.ErrProviderTimeout
+		return e.providerError(ai.CodeProviderTimeout, err)
 	}
 	if isServerError(err) {
-		return ai.ErrProviderUnavailable
+		return e.providerError(ai.CodeProviderUnavailable, err)
 	}
 
 	return fmt.Errorf("openai api error: %w", err)
 }
 
+// providerError wraps err in an ai.ProviderError tagged with this provider and model
+func (e *OpenAIEngine) providerError(code ai.ErrorCode, err error) error {
+	return &ai.ProviderError{
+		Code:     code,
+		Provider: "openai",
+		Model:    e.config.Model,
+		Cause:    err,
+	}
+}
+
+// openAIStatusCode returns the HTTP status of an OpenAI API error, or 0 if err
+// didn't come from an API response
+func openAIStatusCode(err error) int {
+	var apiErr *openai.APIError
+	if errors.As(err, &apiErr) {
+		return apiErr.HTTPStatusCode
+	}
+	var reqErr *openai.RequestError
+	if errors.As(err, &reqErr) {
+		return reqErr.HTTPStatusCode
+	}
+	return 0
+}
+
+// openAIErrorCode returns the error code of an OpenAI API error (e.g.
+// "context_length_exceeded"), or "" if it has none
+func openAIErrorCode(err error) string {
+	var apiErr *openai.APIError
+	if errors.As(err, &apiErr) {
+		if code, ok := apiErr.Code.(string); ok {
+			return code
+		}
+	}
+	return ""
+}
+
+// isContextLengthError returns true if OpenAI rejected the prompt as too long
+// for the model. API errors must be a 400 and, when they carry a code, have the
+// context_length_exceeded code; other errors fall back to ai.IsContextLengthError.
+func isContextLengthError(err error) bool {
+	if status := openAIStatusCode(err); status != 0 && status != http.StatusBadRequest {
+		return false
+	}
+	if code := openAIErrorCode(err); code != "" {
+		return code == "context_length_exceeded"
+	}
+	return ai.IsContextLengthError(err)
+}
+
 // Error detection helpers
 func isAuthError(err error) bool {
 	return err != nil && (err.Error() == "401" || err.Error() == "403")
@@ -617,8 +627,10 @@ func (e *OpenAIEngine) AnalyzeWithContext(ctx context.Context, prompt string) (s
 	}
 
 	// Track for testing
+	e.mu.Lock()
 	e.callCount++
 	e.lastPrompt = prompt
+	e.mu.Unlock()
 
 	// Build request
 	chatReq := openai.ChatCompletionRequest{
@@ -626,14 +638,15 @@ func (e *OpenAIEngine) AnalyzeWithContext(ctx context.Context, prompt string) (s
 		Messages: []openai.ChatCompletionMessage{
 			{
 				Role:    openai.ChatMessageRoleSystem,
-				Content: "You are an expert compliance analyst. Analyze evidence and provide detailed, policy-grounded findings.",
+				Content: e.systemPrompt("You are an expert compliance analyst. Analyze evidence and provide detailed, policy-grounded findings."),
 			},
 			{
 				Role:    openai.ChatMessageRoleUser,
 				Content: prompt,
 			},
 		},
-		Temperature: float32(e.config.Temperature),
+		Temperature: e.temperature(),
+		Seed:        e.config.Seed,
 	}
 
 	// Use MaxCompletionTokens for GPT-5 and o1 models, MaxTokens for others
@@ -648,6 +661,10 @@ func (e *OpenAIEngine) AnalyzeWithContext(ctx context.Context, prompt string) (s
 	operation := func() error {
 		var err error
 		resp, err = e.client.CreateChatCompletion(ctx, chatReq)
+		// A prompt too long for the model fails the same way every time
+		if isContextLengthError(err) {
+			return backoff.Permanent(err)
+		}
 		return err
 	}
 
@@ -666,16 +683,62 @@ func (e *OpenAIEngine) AnalyzeWithContext(ctx context.Context, prompt string) (s
 	return resp.Choices[0].Message.Content, nil
 }
 
+// temperature returns the configured sampling temperature for a request.
+// The client omits a zero temperature (so the API default of 1 applies);
+// the smallest non-zero value is sent instead, which the API treats as 0.
+func (e *OpenAIEngine) temperature() float32 {
+	if e.config.Temperature == 0 {
+		return math.SmallestNonzeroFloat32
+	}
+	return e.config.Temperature
+}
+
+// systemPrompt returns the configured system prompt, or defaultPrompt if none is set
+func (e *OpenAIEngine) systemPrompt(defaultPrompt string) string {
+	if e.config.SystemPrompt != "" {
+		return e.config.SystemPrompt
+	}
+	return defaultPrompt
+}
+
+// SystemPrompt implements ai.SystemPromptProvider
+func (e *OpenAIEngine) SystemPrompt() string {
+	return e.config.SystemPrompt
+}
+
+// ProviderName implements ai.NamedProvider
+func (e *OpenAIEngine) ProviderName() string {
+	return "openai"
+}
+
+// ModelName implements ai.NamedProvider
+func (e *OpenAIEngine) ModelName() string {
+	return e.config.Model
+}
+
 // GetCallCount implements ai.Provider.GetCallCount
 func (e *OpenAIEngine) GetCallCount() int {
+	e.mu.Lock()
+	defer e.mu.Unlock()
 	return e.callCount
 }
 
 // GetLastPrompt implements ai.Provider.GetLastPrompt
 func (e *OpenAIEngine) GetLastPrompt() string {
+	e.mu.Lock()
+	defer e.mu.Unlock()
 	return e.lastPrompt
 }
 
+// openAIFunction adapts a provider-neutral tool schema to an OpenAI function definition
+func openAIFunction(schema ai.ToolSchema) openai.FunctionDefinition {
+	return openai.FunctionDefinition{
+		Name:        schema.Name,
+		Description: schema.Description,
+		Parameters:  schema.Parameters(),
+	}
+}
+
 // usesMaxCompletionTokens returns true if the model requires MaxCompletionTokens instead of MaxTokens
 // GPT-5 and o1-series models require MaxCompletionTokens
 func (e *OpenAIEngine) usesMaxCompletionTokens() bool {
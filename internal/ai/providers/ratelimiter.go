@@ -2,38 +2,80 @@ package providers
 
 import (
 	"context"
+	"sync"
 
 	"golang.org/x/time/rate"
 )
 
-// RateLimiter wraps rate.Limiter for AI provider rate limiting
+// RateLimiter is a token bucket for AI provider rate limiting. The bucket holds
+// up to burst tokens and refills at the configured rate, so a burst of requests
+// proceeds immediately and later requests wait for tokens to refill.
 type RateLimiter struct {
 	limiter *rate.Limiter
 }
 
 // NewRateLimiter creates a new rate limiter
-// rateLimit is requests per minute (0 = unlimited)
-func NewRateLimiter(rateLimit int) *RateLimiter {
+// rateLimit is requests per minute (0 = unlimited); burst is the bucket capacity
+// (0 = rateLimit/60, at least 1)
+func NewRateLimiter(rateLimit, burst int) *RateLimiter {
+	limit, burst := rateLimitParams(rateLimit, burst)
+	return &RateLimiter{
+		limiter: rate.NewLimiter(limit, burst),
+	}
+}
+
+// rateLimitParams converts requests per minute and a burst to a rate.Limiter limit and burst
+func rateLimitParams(rateLimit, burst int) (rate.Limit, int) {
 	if rateLimit <= 0 {
 		// Unlimited rate
-		return &RateLimiter{
-			limiter: rate.NewLimiter(rate.Inf, 1),
+		return rate.Inf, 1
+	}
+
+	if burst <= 0 {
+		burst = 1
+		if rateLimit > 60 {
+			burst = rateLimit / 60
 		}
 	}
 
 	// Convert requests per minute to requests per second
 	rps := float64(rateLimit) / 60.0
-	burst := 1
-	if rateLimit > 60 {
-		burst = rateLimit / 60
-	}
-
-	return &RateLimiter{
-		limiter: rate.NewLimiter(rate.Limit(rps), burst),
-	}
+	return rate.Limit(rps), burst
 }
 
 // Wait blocks until the rate limiter allows an action
 func (rl *RateLimiter) Wait(ctx context.Context) error {
 	return rl.limiter.Wait(ctx)
 }
+
+// sharedLimiters holds one limiter per provider endpoint for the whole process
+var (
+	sharedLimitersMu sync.Mutex
+	sharedLimiters   = make(map[string]*RateLimiter)
+)
+
+// SharedRateLimiter returns the process-wide rate limiter for provider and
+// endpoint, so every engine and concurrent analysis calling the same endpoint
+// draws from one budget. rateLimit and burst are as for NewRateLimiter; when the
+// limiter already exists it is updated to them, so the most recently created
+// provider's setting applies.
+func SharedRateLimiter(provider, endpoint string, rateLimit, burst int) *RateLimiter {
+	key := provider + "|" + endpoint
+
+	sharedLimitersMu.Lock()
+	defer sharedLimitersMu.Unlock()
+
+	rl, ok := sharedLimiters[key]
+	if !ok {
+		rl = NewRateLimiter(rateLimit, burst)
+		sharedLimiters[key] = rl
+		return rl
+	}
+
+	limit, burst := rateLimitParams(rateLimit, burst)
+	if rl.limiter.Limit() != limit || rl.limiter.Burst() != burst {
+		rl.limiter.SetLimit(limit)
+		rl.limiter.SetBurst(burst)
+	}
+	return rl
+}
@@ -19,6 +19,13 @@ type Redactor interface {
 type redactor struct {
 	config   *types.Config
 	patterns map[string]*regexp.Regexp
+	locale   []localeRedaction // Locale-specific redactions, applied after emails
+}
+
+// localeRedaction is a redaction pattern enabled by a locale.
+type localeRedaction struct {
+	pattern     string
+	placeholder string
 }
 
 // Redaction pattern types
@@ -29,6 +36,34 @@ const (
 	patternPhone      = "phone"
 	patternAWSKey     = "awskey"
 	patternGenericKey = "generickey"
+	patternIBAN       = "iban"
+	patternEUVAT      = "euvat"
+	patternUKNINO     = "uknino"
+	patternIntlPhone  = "intlphone"
+)
+
+// localeRedactions maps each redaction locale to the patterns it enables.
+var localeRedactions = map[string][]localeRedaction{
+	types.RedactionLocaleEU: {
+		{patternIBAN, "[REDACTED:PII:IBAN]"},
+		{patternEUVAT, "[REDACTED:PII:VAT]"},
+	},
+	types.RedactionLocaleUK: {
+		{patternIBAN, "[REDACTED:PII:IBAN]"},
+		{patternUKNINO, "[REDACTED:PII:NINO]"},
+	},
+	types.RedactionLocaleIntl: {
+		{patternIntlPhone, "[REDACTED:PII:PHONE]"},
+	},
+}
+
+// Secret patterns, shared by the redactor and DetectSecrets
+var (
+	// AWS Access Key pattern
+	awsKeyPattern = regexp.MustCompile(`\bAKIA[0-9A-Z]{16}\b`)
+
+	// Generic API key pattern (32+ character alphanumeric strings)
+	genericKeyPattern = regexp.MustCompile(`\b[a-zA-Z0-9]{32,}\b`)
 )
 
 // NewRedactor creates a new Redactor instance.
@@ -55,11 +90,32 @@ func (r *redactor) compilePatterns() {
 	// Phone pattern (matches various formats: 555-123-4567, (555) 123-4567, 5551234567)
 	r.patterns[patternPhone] = regexp.MustCompile(`\b(?:\(?\d{3}\)?[-.\s]?)?\d{3}[-.\s]?\d{4}\b`)
 
-	// AWS Access Key pattern
-	r.patterns[patternAWSKey] = regexp.MustCompile(`\bAKIA[0-9A-Z]{16}\b`)
+	// Secret patterns are shared with DetectSecrets
+	r.patterns[patternAWSKey] = awsKeyPattern
+	r.patterns[patternGenericKey] = genericKeyPattern
 
-	// Generic API key pattern (32+ character alphanumeric strings)
-	r.patterns[patternGenericKey] = regexp.MustCompile(`\b[a-zA-Z0-9]{32,}\b`)
+	// IBAN pattern (country code, check digits, 11-30 alphanumerics, optionally grouped by 4)
+	r.patterns[patternIBAN] = regexp.MustCompile(`\b[A-Z]{2}\d{2}(?: ?[A-Z0-9]{4}){2,7}(?: ?[A-Z0-9]{1,3})?\b`)
+
+	// EU VAT number pattern (member state prefix followed by a digit-led identifier)
+	r.patterns[patternEUVAT] = regexp.MustCompile(`\b(?:AT|BE|BG|CY|CZ|DE|DK|EE|EL|ES|FI|FR|HR|HU|IE|IT|LT|LU|LV|MT|NL|PL|PT|RO|SE|SI|SK)U?\d[0-9A-Z]{7,11}\b`)
+
+	// UK National Insurance number pattern (e.g., AB 12 34 56 C)
+	r.patterns[patternUKNINO] = regexp.MustCompile(`\b[A-CEGHJ-PR-TW-Z][A-CEGHJ-NPR-TW-Z] ?\d{2} ?\d{2} ?\d{2} ?[A-D]\b`)
+
+	// International phone pattern (+country code, e.g., +44 20 7946 0958, +49-30-1234567)
+	r.patterns[patternIntlPhone] = regexp.MustCompile(`\+\d{1,3}[-.\s]?\(?\d{1,4}\)?(?:[-.\s]?\d{2,4}){2,4}\b`)
+
+	// Enable locale-specific redactions (deduplicated, in config order)
+	seen := make(map[string]bool)
+	for _, locale := range r.config.AI.Redaction.Locales {
+		for _, lr := range localeRedactions[strings.ToLower(locale)] {
+			if !seen[lr.pattern] {
+				seen[lr.pattern] = true
+				r.locale = append(r.locale, lr)
+			}
+		}
+	}
 }
 
 // Redact redacts PII and secrets from the input text.
@@ -101,6 +157,11 @@ func (r *redactor) Redact(text string) (string, *types.RedactionMap, error) {
 	// 2. Emails
 	result = r.redactPattern(result, patternEmail, "[REDACTED:PII:EMAIL]", types.RedactionPII, redactionMap)
 
+	// Locale-specific patterns (before IPs/phones so they aren't partially matched)
+	for _, lr := range r.locale {
+		result = r.redactPattern(result, lr.pattern, lr.placeholder, types.RedactionPII, redactionMap)
+	}
+
 	// 3. IPv6 (must come before IPv4 to avoid partial matches)
 	result = r.redactPattern(result, patternIPv6, "[REDACTED:PII:IP]", types.RedactionPII, redactionMap)
 
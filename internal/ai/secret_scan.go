@@ -0,0 +1,39 @@
+package ai
+
+import (
+	"strings"
+
+	"github.com/pickjonathan/sdek-cli/pkg/types"
+)
+
+// DetectSecrets returns the IDs of events whose content matches a secret
+// pattern, in bundle order. Long hexadecimal strings such as commit SHAs and
+// checksums match the generic key pattern too, but are not reported, so the
+// result stays useful for commit-heavy evidence. Redaction is unaffected.
+func DetectSecrets(bundle types.EvidenceBundle) []string {
+	var ids []string
+	for _, event := range bundle.Events {
+		if containsSecret(event.Content) {
+			ids = append(ids, event.ID)
+		}
+	}
+	return ids
+}
+
+// containsSecret reports whether text holds a likely secret
+func containsSecret(text string) bool {
+	if awsKeyPattern.MatchString(text) {
+		return true
+	}
+	for _, match := range genericKeyPattern.FindAllString(text, -1) {
+		if !isHex(match) {
+			return true
+		}
+	}
+	return false
+}
+
+// isHex reports whether s consists only of hexadecimal digits
+func isHex(s string) bool {
+	return strings.Trim(s, "0123456789abcdefABCDEF") == ""
+}
@@ -0,0 +1,96 @@
+package ai
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+)
+
+// Phase identifies a stage of an analysis run for timing purposes
+type Phase string
+
+const (
+	PhaseLoad        Phase = "load"         // Reading excerpts, evidence and config
+	PhaseRedact      Phase = "redact"       // Redacting evidence content
+	PhasePromptBuild Phase = "prompt-build" // Rendering the provider prompt
+	PhaseProvider    Phase = "provider"     // Waiting on the AI provider
+	PhaseParse       Phase = "parse"        // Parsing the provider response
+)
+
+// Phases lists all phases in the order they occur during an analysis
+var Phases = []Phase{PhaseLoad, PhaseRedact, PhasePromptBuild, PhaseProvider, PhaseParse}
+
+// PhaseTimings accumulates time spent in each analysis phase.
+// It is safe for concurrent use and a nil *PhaseTimings ignores all recordings.
+type PhaseTimings struct {
+	mu        sync.Mutex
+	durations map[Phase]time.Duration
+}
+
+// NewPhaseTimings creates an empty timing breakdown
+func NewPhaseTimings() *PhaseTimings {
+	return &PhaseTimings{durations: make(map[Phase]time.Duration)}
+}
+
+// Add records d against phase; repeated calls accumulate
+func (t *PhaseTimings) Add(phase Phase, d time.Duration) {
+	if t == nil {
+		return
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.durations[phase] += d
+}
+
+// Track records the time elapsed since start against phase
+func (t *PhaseTimings) Track(phase Phase, start time.Time) {
+	t.Add(phase, time.Since(start))
+}
+
+// Get returns the total time recorded for phase
+func (t *PhaseTimings) Get(phase Phase) time.Duration {
+	if t == nil {
+		return 0
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.durations[phase]
+}
+
+// Total returns the sum of all recorded phases
+func (t *PhaseTimings) Total() time.Duration {
+	if t == nil {
+		return 0
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	var total time.Duration
+	for _, d := range t.durations {
+		total += d
+	}
+	return total
+}
+
+// Print writes a human-readable breakdown of all phases to w
+func (t *PhaseTimings) Print(w io.Writer) {
+	fmt.Fprintln(w, "\n⏱  Timing:")
+	for _, phase := range Phases {
+		fmt.Fprintf(w, "   %-13s %v\n", phase, t.Get(phase).Round(time.Microsecond))
+	}
+	fmt.Fprintf(w, "   %-13s %v\n", "total", t.Total().Round(time.Microsecond))
+}
+
+type phaseTimingsKey struct{}
+
+// WithPhaseTimings returns a context that makes the engine record phase durations into t
+func WithPhaseTimings(ctx context.Context, t *PhaseTimings) context.Context {
+	return context.WithValue(ctx, phaseTimingsKey{}, t)
+}
+
+// PhaseTimingsFromContext returns the timings attached to ctx, or nil if there are none
+func PhaseTimingsFromContext(ctx context.Context) *PhaseTimings {
+	t, _ := ctx.Value(phaseTimingsKey{}).(*PhaseTimings)
+	return t
+}
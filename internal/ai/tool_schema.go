@@ -0,0 +1,102 @@
+package ai
+
+// ToolSchema is a provider-neutral description of a function/tool the model
+// is asked to call for structured output. Providers adapt it to their own
+// type (OpenAI FunctionDefinition, Anthropic ToolParam) so the schema is
+// defined once.
+type ToolSchema struct {
+	Name        string
+	Description string
+	Properties  map[string]interface{} // JSON Schema properties of the arguments object
+	Required    []string
+}
+
+// Parameters returns the tool's arguments as a JSON Schema object
+func (s ToolSchema) Parameters() map[string]interface{} {
+	return map[string]interface{}{
+		"type":       "object",
+		"properties": s.Properties,
+		"required":   s.Required,
+	}
+}
+
+// AnalysisToolSchema returns the schema of the tool used to analyze an
+// evidence bundle against a context preamble. Its arguments map onto
+// types.Finding.
+func AnalysisToolSchema() ToolSchema {
+	return ToolSchema{
+		Name:        "analyze_compliance_evidence",
+		Description: "Analyze evidence events against policy context for compliance",
+		Properties: map[string]interface{}{
+			"title": map[string]interface{}{
+				"type":        "string",
+				"description": "Brief title summarizing the finding (20-100 chars)",
+			},
+			"summary": map[string]interface{}{
+				"type":        "string",
+				"description": "Summary of analysis and what was found (100-500 chars)",
+			},
+			"justification": map[string]interface{}{
+				"type":        "string",
+				"description": "Explanation of how evidence maps to policy requirements (100-1000 chars)",
+			},
+			"confidence_score": map[string]interface{}{
+				"type":        "number",
+				"description": "Confidence score (0.0-1.0)",
+				"minimum":     0,
+				"maximum":     1,
+			},
+			"residual_risk": map[string]interface{}{
+				"type":        "string",
+				"description": "Any gaps, concerns, or remaining risks (0-500 chars)",
+			},
+			"mapped_controls": map[string]interface{}{
+				"type":        "array",
+				"items":       map[string]interface{}{"type": "string"},
+				"description": "List of control IDs that this evidence supports",
+			},
+			"citations": map[string]interface{}{
+				"type":        "array",
+				"items":       map[string]interface{}{"type": "string"},
+				"description": "Event IDs or sources cited in the analysis",
+			},
+			"severity": map[string]interface{}{
+				"type":        "string",
+				"enum":        []string{"low", "medium", "high", "critical"},
+				"description": "Severity level based on gaps and risks",
+			},
+		},
+		Required: []string{"title", "summary", "justification", "confidence_score", "mapped_controls"},
+	}
+}
+
+// EvidenceToolSchema returns the schema of the tool used for single-control
+// AnalysisRequest analysis. Its arguments map onto AnalysisResponse.
+func EvidenceToolSchema() ToolSchema {
+	return ToolSchema{
+		Name:        "analyze_evidence",
+		Description: "Analyze events for compliance control evidence",
+		Properties: map[string]interface{}{
+			"evidence_links": map[string]interface{}{
+				"type":        "array",
+				"items":       map[string]interface{}{"type": "string"},
+				"description": "Event IDs that support the control",
+			},
+			"justification": map[string]interface{}{
+				"type":        "string",
+				"description": "Explanation of relevance (50-500 chars)",
+			},
+			"confidence": map[string]interface{}{
+				"type":        "integer",
+				"description": "Confidence score (0-100)",
+				"minimum":     0,
+				"maximum":     100,
+			},
+			"residual_risk": map[string]interface{}{
+				"type":        "string",
+				"description": "Notes on gaps or concerns (0-500 chars)",
+			},
+		},
+		Required: []string{"evidence_links", "justification", "confidence"},
+	}
+}
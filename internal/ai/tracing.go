@@ -0,0 +1,59 @@
+package ai
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// tracerName is the instrumentation scope of the engine's spans
+const tracerName = "github.com/pickjonathan/sdek-cli/internal/ai"
+
+// Span names for the engine's traced operations
+const (
+	SpanAnalyze          = "ai.analyze"
+	SpanProposePlan      = "ai.propose_plan"
+	SpanExecutePlan      = "ai.execute_plan"
+	SpanConnectorCollect = "ai.connector.collect"
+	SpanProviderCall     = "ai.provider.call"
+)
+
+// Span attribute keys
+const (
+	attrProvider       = attribute.Key("ai.provider")
+	attrModel          = attribute.Key("ai.model")
+	attrFramework      = attribute.Key("ai.framework")
+	attrControl        = attribute.Key("ai.control")
+	attrOperation      = attribute.Key("ai.operation")
+	attrEvents         = attribute.Key("ai.events")
+	attrCacheHit       = attribute.Key("ai.cache_hit")
+	attrSource         = attribute.Key("ai.connector.source")
+	attrPromptTokens   = attribute.Key("ai.tokens.prompt")
+	attrResponseTokens = attribute.Key("ai.tokens.response")
+)
+
+// startSpan starts a span from the global tracer provider, which is a no-op
+// unless telemetry is configured
+func startSpan(ctx context.Context, name string, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	return otel.Tracer(tracerName).Start(ctx, name, trace.WithAttributes(attrs...))
+}
+
+// endSpan records err, if any, on span and ends it
+func endSpan(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
+
+// providerAttributes identifies the configured provider and model
+func (e *engineImpl) providerAttributes() []attribute.KeyValue {
+	return []attribute.KeyValue{
+		attrProvider.String(e.providerName()),
+		attrModel.String(e.modelName()),
+	}
+}
@@ -16,6 +16,12 @@ type AIConfig struct {
 	Timeout     int     // Request timeout in seconds (default: 60)
 	RateLimit   int     // Max requests per minute (default: 10)
 
+	// SystemPrompt overrides the built-in system message when set
+	SystemPrompt string
+
+	// Seed is sent with requests when set, for reproducible sampling
+	Seed *int
+
 	// API credentials (from env vars or config)
 	OpenAIKey    string `mapstructure:"openai_key"`
 	AnthropicKey string `mapstructure:"anthropic_key"`
@@ -77,6 +83,7 @@ type CachedResult struct {
 	CachedAt     time.Time // Cache entry creation time
 	EventIDs     []string  // Event IDs for invalidation tracking
 	ControlID    string    // Control ID for invalidation tracking
+	Framework    string    // Framework for invalidation tracking
 	Provider     string    // AI provider used
 	ModelVersion string    // Model version for compatibility
 }
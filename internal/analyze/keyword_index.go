@@ -0,0 +1,92 @@
+package analyze
+
+import (
+	"sort"
+	"strings"
+)
+
+// keywordGramSize is the length of the byte n-grams used to index control keywords
+const keywordGramSize = 3
+
+// controlRef identifies a control within a framework
+type controlRef struct {
+	frameworkID string
+	control     int // Index into FrameworkDefinition.Controls
+}
+
+// keywordIndex is an inverted index from keyword n-grams to controls.
+//
+// Keywords match by case-insensitive substring, so the index cannot key on whole
+// words. Instead each keyword is filed under its first n-gram: a keyword can only
+// occur in a text containing that n-gram, so controls not reached through any of
+// the text's n-grams cannot match and are skipped. Candidates are always a superset
+// of matching controls; callers still run the full keyword check on each one.
+type keywordIndex struct {
+	controls []controlRef
+	byGram   map[string][]int // n-gram -> indexes into controls
+	always   []int            // Controls with a keyword shorter than an n-gram
+}
+
+// newKeywordIndex indexes the keywords of every control in frameworks
+func newKeywordIndex(frameworks map[string]FrameworkDefinition) *keywordIndex {
+	idx := &keywordIndex{byGram: make(map[string][]int)}
+
+	// Iterate frameworks in a fixed order so candidate lists are deterministic
+	frameworkIDs := make([]string, 0, len(frameworks))
+	for id := range frameworks {
+		frameworkIDs = append(frameworkIDs, id)
+	}
+	sort.Strings(frameworkIDs)
+
+	for _, frameworkID := range frameworkIDs {
+		for i, control := range frameworks[frameworkID].Controls {
+			ref := len(idx.controls)
+			idx.controls = append(idx.controls, controlRef{frameworkID: frameworkID, control: i})
+
+			grams := make(map[string]bool)
+			short := false
+			for _, keyword := range control.Keywords {
+				keyword = strings.ToLower(keyword)
+				if len(keyword) < keywordGramSize {
+					short = true
+					continue
+				}
+				grams[keyword[:keywordGramSize]] = true
+			}
+
+			if short {
+				idx.always = append(idx.always, ref)
+				continue
+			}
+			for gram := range grams {
+				idx.byGram[gram] = append(idx.byGram[gram], ref)
+			}
+		}
+	}
+
+	return idx
+}
+
+// candidates returns the controls that could match searchText, which must already be lowercased
+func (idx *keywordIndex) candidates(searchText string) []controlRef {
+	seen := make([]bool, len(idx.controls))
+	var refs []controlRef
+
+	add := func(ref int) {
+		if !seen[ref] {
+			seen[ref] = true
+			refs = append(refs, idx.controls[ref])
+		}
+	}
+
+	for _, ref := range idx.always {
+		add(ref)
+	}
+	for i := 0; i+keywordGramSize <= len(searchText); i++ {
+		for _, ref := range idx.byGram[searchText[i:i+keywordGramSize]] {
+			add(ref)
+		}
+	}
+
+	return refs
+}
@@ -3,8 +3,11 @@ package analyze
 import (
 	"context"
 	"log/slog"
+	"runtime"
+	"sort"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/google/uuid"
@@ -22,13 +25,17 @@ type Mapper struct {
 	policyLoader  *policy.Loader
 	promptGen     *ai.PromptGenerator
 	aiEnabled     bool
+	workers       int           // Heuristic mapping workers; 0 uses GOMAXPROCS
+	keywordIndex  *keywordIndex // Pre-filter for heuristic mapping; nil checks every control
 }
 
 // NewMapper creates a new evidence mapper with heuristic-only analysis
 func NewMapper() *Mapper {
+	frameworks := GetFrameworkDefinitions()
 	return &Mapper{
-		frameworks: GetFrameworkDefinitions(),
-		aiEnabled:  false,
+		frameworks:   frameworks,
+		aiEnabled:    false,
+		keywordIndex: newKeywordIndex(frameworks),
 	}
 }
 
@@ -36,9 +43,11 @@ func NewMapper() *Mapper {
 func NewMapperWithAI(engine ai.Engine, cache *ai.Cache) *Mapper {
 	privacyFilter := ai.NewPrivacyFilter()
 	policyLoader := policy.NewLoader()
+	frameworks := GetFrameworkDefinitions()
 
 	return &Mapper{
-		frameworks:    GetFrameworkDefinitions(),
+		frameworks:    frameworks,
+		keywordIndex:  newKeywordIndex(frameworks),
 		aiEngine:      engine,
 		cache:         cache,
 		privacyFilter: privacyFilter,
@@ -50,23 +59,37 @@ func NewMapperWithAI(engine ai.Engine, cache *ai.Cache) *Mapper {
 
 // MapEventsToControls maps events to controls across all frameworks
 // If AI is enabled, uses AI-enhanced analysis with fallback to heuristics
+//
+// Deprecated: AI calls made by this method cannot be cancelled. Use MapEventsToControlsCtx.
 func (m *Mapper) MapEventsToControls(events []types.Event) []types.Evidence {
+	return m.MapEventsToControlsCtx(context.Background(), events)
+}
+
+// MapEventsToControlsCtx maps events to controls across all frameworks.
+// If AI is enabled, uses AI-enhanced analysis with fallback to heuristics.
+// Once ctx is cancelled or its deadline passes, no further AI calls are made and
+// the remaining controls keep their heuristic evidence.
+func (m *Mapper) MapEventsToControlsCtx(ctx context.Context, events []types.Event) []types.Evidence {
 	if m.aiEnabled {
-		return m.mapEventsWithAI(context.Background(), events)
+		return m.mapEventsWithAI(ctx, events)
 	}
 	return m.mapEventsHeuristic(events)
 }
 
 // mapEventsWithAI performs AI-enhanced evidence mapping with heuristic fallback
 func (m *Mapper) mapEventsWithAI(ctx context.Context, events []types.Event) []types.Evidence {
-	var evidenceList []types.Evidence
-
 	// First pass: Get heuristic mappings for all events
-	heuristicEvidence := m.mapEventsHeuristic(events)
+	return m.enhanceWithAI(ctx, events, m.mapEventsHeuristic(events))
+}
+
+// enhanceWithAI analyzes the events behind each control's evidence with the AI
+// engine. Controls the AI can't analyze keep the evidence they were given.
+func (m *Mapper) enhanceWithAI(ctx context.Context, events []types.Event, baseEvidence []types.Evidence) []types.Evidence {
+	var evidenceList []types.Evidence
 
 	// Group evidence by control for batch AI analysis
 	controlEvidence := make(map[string][]types.Evidence)
-	for _, ev := range heuristicEvidence {
+	for _, ev := range baseEvidence {
 		key := ev.FrameworkID + ":" + ev.ControlID
 		controlEvidence[key] = append(controlEvidence[key], ev)
 	}
@@ -77,6 +100,12 @@ func (m *Mapper) mapEventsWithAI(ctx context.Context, events []types.Event) []ty
 			continue
 		}
 
+		// Stop calling the AI once the caller gives up; keep heuristic results
+		if ctx.Err() != nil {
+			evidenceList = append(evidenceList, evidences...)
+			continue
+		}
+
 		// Get framework and control IDs
 		firstEv := evidences[0]
 		control := m.GetControlDefinition(firstEv.FrameworkID, firstEv.ControlID)
@@ -108,48 +137,139 @@ func (m *Mapper) mapEventsWithAI(ctx context.Context, events []types.Event) []ty
 		}
 	}
 
+	if err := ctx.Err(); err != nil {
+		slog.Warn("AI mapping stopped early, remaining controls use heuristic evidence", "error", err)
+	}
+
 	return evidenceList
 }
 
-// mapEventsHeuristic performs traditional keyword-based mapping
+// minParallelEvents is the event count below which heuristic mapping runs serially,
+// since goroutine overhead outweighs the work for small inputs
+const minParallelEvents = 64
+
+// mapEventsHeuristic performs traditional keyword-based mapping.
+// Events are mapped in parallel by a bounded worker pool; evidence is returned
+// sorted by event ID, then control ID, then framework ID regardless of worker count.
 func (m *Mapper) mapEventsHeuristic(events []types.Event) []types.Evidence {
+	workers := m.workers
+	if workers <= 0 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+	if workers > len(events) {
+		workers = len(events)
+	}
+	if workers <= 1 || len(events) < minParallelEvents {
+		return m.mapEventsHeuristicSerial(events)
+	}
+
+	// Each event's evidence lands in its own slot, so workers never share a slice
+	results := make([][]types.Evidence, len(events))
+	indexes := make(chan int)
+
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indexes {
+				results[i] = m.mapEventHeuristic(events[i])
+			}
+		}()
+	}
+	for i := range events {
+		indexes <- i
+	}
+	close(indexes)
+	wg.Wait()
+
 	var evidenceList []types.Evidence
+	for _, evidence := range results {
+		evidenceList = append(evidenceList, evidence...)
+	}
+	sortEvidence(evidenceList)
+
+	return evidenceList
+}
 
+// mapEventsHeuristicSerial maps events one at a time, producing the same ordering as mapEventsHeuristic
+func (m *Mapper) mapEventsHeuristicSerial(events []types.Event) []types.Evidence {
+	var evidenceList []types.Evidence
 	for _, event := range events {
-		// Check each framework
-		for frameworkID, framework := range m.frameworks {
-			// Check each control in the framework
-			for _, control := range framework.Controls {
-				// Check if event matches control keywords
-				if m.matchesKeywords(event, control.Keywords) {
-					confidenceScore := m.calculateConfidence(event, control)
-					confidenceLevel := GetConfidenceLevel(confidenceScore)
-					matchedKeywords := m.getMatchedKeywords(event, control.Keywords)
-
-					evidence := types.Evidence{
-						ID:                  uuid.New().String(),
-						ControlID:           control.ID,
-						FrameworkID:         frameworkID,
-						EventID:             event.ID,
-						MappedAt:            time.Now(),
-						ConfidenceScore:     float64(confidenceScore),
-						ConfidenceLevel:     strings.ToLower(confidenceLevel),
-						Keywords:            matchedKeywords,
-						Reasoning:           m.generateReasoning(event, control, matchedKeywords),
-						HeuristicConfidence: confidenceScore,
-						CombinedConfidence:  confidenceScore,
-						AnalysisMethod:      "heuristic-only",
-					}
-
-					evidenceList = append(evidenceList, evidence)
-				}
+		evidenceList = append(evidenceList, m.mapEventHeuristic(event)...)
+	}
+	sortEvidence(evidenceList)
+
+	return evidenceList
+}
+
+// mapEventHeuristic maps a single event against every control whose keywords it could match
+func (m *Mapper) mapEventHeuristic(event types.Event) []types.Evidence {
+	var evidenceList []types.Evidence
+
+	// Check each candidate control
+	for _, ref := range m.candidateControls(event) {
+		control := m.frameworks[ref.frameworkID].Controls[ref.control]
+
+		// Check if event matches control keywords
+		if m.matchesKeywords(event, control.Keywords) {
+			confidenceScore := m.calculateConfidence(event, control)
+			confidenceLevel := GetConfidenceLevel(confidenceScore)
+			matchedKeywords := m.getMatchedKeywords(event, control.Keywords)
+
+			evidence := types.Evidence{
+				ID:                  uuid.New().String(),
+				ControlID:           control.ID,
+				FrameworkID:         ref.frameworkID,
+				EventID:             event.ID,
+				MappedAt:            time.Now(),
+				ConfidenceScore:     float64(confidenceScore),
+				ConfidenceLevel:     strings.ToLower(confidenceLevel),
+				Keywords:            matchedKeywords,
+				Reasoning:           m.generateReasoning(event, control, matchedKeywords),
+				HeuristicConfidence: confidenceScore,
+				CombinedConfidence:  confidenceScore,
+				AnalysisMethod:      "heuristic-only",
+				Labels:              event.Labels,
 			}
+
+			evidenceList = append(evidenceList, evidence)
 		}
 	}
 
 	return evidenceList
 }
 
+// candidateControls returns the controls event could match, using the keyword index when available
+func (m *Mapper) candidateControls(event types.Event) []controlRef {
+	if m.keywordIndex != nil {
+		return m.keywordIndex.candidates(strings.ToLower(event.Title + " " + event.Content))
+	}
+
+	var refs []controlRef
+	for frameworkID, framework := range m.frameworks {
+		for i := range framework.Controls {
+			refs = append(refs, controlRef{frameworkID: frameworkID, control: i})
+		}
+	}
+	return refs
+}
+
+// sortEvidence orders evidence by event ID, then control ID, then framework ID.
+// The sort is stable so repeated mappings of the same event keep their input order.
+func sortEvidence(evidence []types.Evidence) {
+	sort.SliceStable(evidence, func(i, j int) bool {
+		a, b := evidence[i], evidence[j]
+		if a.EventID != b.EventID {
+			return a.EventID < b.EventID
+		}
+		if a.ControlID != b.ControlID {
+			return a.ControlID < b.ControlID
+		}
+		return a.FrameworkID < b.FrameworkID
+	})
+}
+
 // analyzeControlWithAI performs AI analysis for a specific control and its events
 func (m *Mapper) analyzeControlWithAI(ctx context.Context, frameworkID string, control ControlDefinition, events []types.Event) []types.Evidence {
 	if len(events) == 0 {
@@ -221,6 +341,7 @@ func (m *Mapper) analyzeControlWithAI(ctx context.Context, frameworkID string, c
 		CachedAt:     time.Now(),
 		EventIDs:     extractEventIDs(events),
 		ControlID:    control.ID,
+		Framework:    frameworkID,
 		Provider:     response.Provider,
 		ModelVersion: response.Model,
 	}
@@ -295,6 +416,7 @@ func (m *Mapper) convertAIResponseToEvidence(response ai.AnalysisResponse, event
 			HeuristicConfidence: heuristicScore,
 			CombinedConfidence:  combinedScore,
 			AnalysisMethod:      analysisMethod,
+			Labels:              matchedEvent.Labels,
 		}
 
 		slog.Debug("Created AI-enhanced evidence", "evidenceID", evidence.ID, "eventID", matchedEvent.ID, "control", control.ID, "aiAnalyzed", evidence.AIAnalyzed)
@@ -419,6 +541,7 @@ func (m *Mapper) MapEventToFramework(event types.Event, frameworkID string) []ty
 				ConfidenceLevel: strings.ToLower(confidenceLevel),
 				Keywords:        matchedKeywords,
 				Reasoning:       m.generateReasoning(event, control, matchedKeywords),
+				Labels:          event.Labels,
 			}
 
 			evidenceList = append(evidenceList, evidence)
@@ -490,18 +613,17 @@ func (m *Mapper) generateReasoning(event types.Event, control ControlDefinition,
 // constructFullControlID constructs the full control ID for policy lookup
 // Examples: "soc2" + "CC6.1" -> "SOC2-CC6.1", "iso27001" + "A.9.1" -> "ISO27001-A.9.1"
 func (m *Mapper) constructFullControlID(frameworkID, controlID string) string {
-	// Normalize framework ID to uppercase and replace underscores with hyphens
-	normalized := strings.ToUpper(strings.ReplaceAll(frameworkID, "_", "-"))
+	canonical, _ := types.NormalizeFramework(frameworkID)
 
-	// Handle special cases
-	switch normalized {
-	case "SOC2":
+	switch canonical {
+	case types.FrameworkSOC2:
 		return "SOC2-" + controlID
-	case "ISO27001":
+	case types.FrameworkISO27001:
 		return "ISO27001-" + controlID
-	case "PCI-DSS", "PCIDSS":
+	case types.FrameworkPCIDSS:
 		return "PCI-DSS-" + controlID
 	default:
-		return normalized + "-" + controlID
+		// Unknown framework: uppercase it and replace underscores with hyphens
+		return strings.ToUpper(strings.ReplaceAll(frameworkID, "_", "-")) + "-" + controlID
 	}
 }
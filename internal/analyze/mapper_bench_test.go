@@ -0,0 +1,114 @@
+package analyze
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/pickjonathan/sdek-cli/pkg/types"
+)
+
+// BenchmarkMapEventsToControls measures keyword mapping across all frameworks
+// for representative event volumes
+func BenchmarkMapEventsToControls(b *testing.B) {
+	for _, n := range []int{10, 100, 1000} {
+		b.Run(fmt.Sprintf("events=%d", n), func(b *testing.B) {
+			mapper := NewMapper()
+			events := generateBenchmarkEvents(n)
+
+			b.ResetTimer()
+			b.ReportAllocs()
+
+			for i := 0; i < b.N; i++ {
+				_ = mapper.MapEventsToControls(events)
+			}
+		})
+	}
+}
+
+// generateBenchmarkEvents creates a mix of event types that match several controls
+func generateBenchmarkEvents(count int) []types.Event {
+	templates := []struct {
+		source    string
+		eventType string
+		title     string
+		content   string
+	}{
+		{types.SourceTypeGit, types.EventTypeCommit, "Add authentication system", "Implement OAuth authentication with multi-factor support"},
+		{types.SourceTypeDocs, types.EventTypeDocumentChange, "Update encryption policy", "Document new TLS 1.3 encryption requirements"},
+		{types.SourceTypeGit, types.EventTypeCommit, "Fix typo in README", "Correct spelling in getting started guide"},
+	}
+
+	baseTime := time.Now().AddDate(0, 0, -30)
+	events := make([]types.Event, count)
+	for i := 0; i < count; i++ {
+		tmpl := templates[i%len(templates)]
+		events[i] = types.Event{
+			ID:        fmt.Sprintf("event-%d", i),
+			SourceID:  tmpl.source,
+			Timestamp: baseTime.Add(time.Duration(i) * time.Minute),
+			EventType: tmpl.eventType,
+			Title:     tmpl.title,
+			Content:   tmpl.content,
+			Author:    "Alice",
+			Metadata:  map[string]interface{}{},
+		}
+	}
+	return events
+}
+
+// BenchmarkMapEventsHeuristic compares serial mapping with the worker pool
+func BenchmarkMapEventsHeuristic(b *testing.B) {
+	events := generateBenchmarkEvents(1000)
+
+	b.Run("serial", func(b *testing.B) {
+		mapper := NewMapper()
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			_ = mapper.mapEventsHeuristicSerial(events)
+		}
+	})
+
+	b.Run("parallel", func(b *testing.B) {
+		mapper := NewMapper()
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			_ = mapper.mapEventsHeuristic(events)
+		}
+	})
+}
+
+// BenchmarkMapEventsPreFilter compares keyword-indexed mapping with checking every control.
+// controls/event reports how many controls had their keywords compared per event.
+func BenchmarkMapEventsPreFilter(b *testing.B) {
+	events := generateBenchmarkEvents(1000)
+
+	for _, tc := range []struct {
+		name    string
+		indexed bool
+	}{
+		{name: "indexed", indexed: true},
+		{name: "full-scan", indexed: false},
+	} {
+		b.Run(tc.name, func(b *testing.B) {
+			mapper := NewMapper()
+			if !tc.indexed {
+				mapper.keywordIndex = nil
+			}
+
+			checked := 0
+			for _, event := range events {
+				checked += len(mapper.candidateControls(event))
+			}
+
+			b.ResetTimer()
+			b.ReportAllocs()
+
+			for i := 0; i < b.N; i++ {
+				_ = mapper.mapEventsHeuristicSerial(events)
+			}
+
+			b.ReportMetric(float64(checked)/float64(len(events)), "controls/event")
+		})
+	}
+}
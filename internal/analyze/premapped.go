@@ -0,0 +1,101 @@
+package analyze
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/pickjonathan/sdek-cli/pkg/types"
+)
+
+// Event metadata keys that tag an event with the control it is evidence for
+const (
+	MetadataControlID   = "control_id"
+	MetadataFrameworkID = "framework_id" // Optional; without it every framework defining the control matches
+)
+
+// preMappedConfidence is the confidence given to evidence whose control was chosen by the event source
+const preMappedConfidence = 100
+
+// MapPreMappedEventsCtx turns events already tagged with a control (see
+// MetadataControlID) into evidence without keyword matching. With AI enabled,
+// each tagged control is still analyzed, falling back to the pre-mapped
+// evidence as MapEventsToControlsCtx does. Untagged events and tags naming an
+// unknown control are skipped.
+func (m *Mapper) MapPreMappedEventsCtx(ctx context.Context, events []types.Event) []types.Evidence {
+	var evidenceList []types.Evidence
+	skipped := 0
+	for _, event := range events {
+		evidence := m.mapPreMappedEvent(event)
+		if len(evidence) == 0 {
+			skipped++
+			continue
+		}
+		evidenceList = append(evidenceList, evidence...)
+	}
+	sortEvidence(evidenceList)
+
+	if skipped > 0 {
+		slog.Warn("Skipped events without a known control tag", "skipped", skipped, "key", MetadataControlID)
+	}
+
+	if m.aiEnabled {
+		return m.enhanceWithAI(ctx, events, evidenceList)
+	}
+	return evidenceList
+}
+
+// mapPreMappedEvent returns evidence for the control event is tagged with
+func (m *Mapper) mapPreMappedEvent(event types.Event) []types.Evidence {
+	controlID := metadataString(event, MetadataControlID)
+	if controlID == "" {
+		return nil
+	}
+	frameworkID := metadataString(event, MetadataFrameworkID)
+
+	frameworkIDs := make([]string, 0, len(m.frameworks))
+	for id := range m.frameworks {
+		if frameworkID == "" || types.SameFramework(id, frameworkID) {
+			frameworkIDs = append(frameworkIDs, id)
+		}
+	}
+	sort.Strings(frameworkIDs)
+
+	var evidenceList []types.Evidence
+	for _, id := range frameworkIDs {
+		control := m.GetControlDefinition(id, controlID)
+		if control == nil {
+			continue
+		}
+		evidenceList = append(evidenceList, types.Evidence{
+			ID:                  uuid.New().String(),
+			ControlID:           control.ID,
+			FrameworkID:         id,
+			EventID:             event.ID,
+			MappedAt:            time.Now(),
+			ConfidenceScore:     preMappedConfidence,
+			ConfidenceLevel:     strings.ToLower(GetConfidenceLevel(preMappedConfidence)),
+			Keywords:            m.getMatchedKeywords(event, control.Keywords),
+			Reasoning:           fmt.Sprintf("Event is tagged with control %s", control.ID),
+			HeuristicConfidence: preMappedConfidence,
+			CombinedConfidence:  preMappedConfidence,
+			AnalysisMethod:      "pre-mapped",
+			Labels:              event.Labels,
+		})
+	}
+
+	if len(evidenceList) == 0 {
+		slog.Debug("Event tagged with unknown control", "event", event.ID, "control", controlID, "framework", frameworkID)
+	}
+	return evidenceList
+}
+
+// metadataString returns the trimmed string value of an event metadata key
+func metadataString(event types.Event, key string) string {
+	value, _ := event.Metadata[key].(string)
+	return strings.TrimSpace(value)
+}
@@ -103,6 +103,7 @@ func (r *RiskScorer) GenerateFindingsForControl(control types.Control, evidenceL
 					Description: "Evidence exists but confidence level is insufficient",
 					Severity:    types.SeverityMedium,
 					Status:      types.StatusOpen,
+					Labels:      evidenceLabels(evidenceList),
 				}
 				findings = append(findings, finding)
 			}
@@ -119,6 +120,7 @@ func (r *RiskScorer) GenerateFindingsForControl(control types.Control, evidenceL
 			Description: "Control has moderate risk - additional evidence or remediation needed",
 			Severity:    types.SeverityMedium,
 			Status:      types.StatusOpen,
+			Labels:      evidenceLabels(evidenceList),
 		}
 		findings = append(findings, finding)
 	}
@@ -126,6 +128,15 @@ func (r *RiskScorer) GenerateFindingsForControl(control types.Control, evidenceL
 	return findings
 }
 
+// evidenceLabels returns the labels of the evidence as sorted key=value pairs
+func evidenceLabels(evidenceList []types.Evidence) []string {
+	labels := make([]map[string]string, 0, len(evidenceList))
+	for _, evidence := range evidenceList {
+		labels = append(labels, evidence.Labels)
+	}
+	return types.LabelPairs(labels...)
+}
+
 // CalculateOverallCompliance calculates compliance percentage for a framework
 func (r *RiskScorer) CalculateOverallCompliance(controls []types.Control) float64 {
 	if len(controls) == 0 {
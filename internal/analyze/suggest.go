@@ -0,0 +1,82 @@
+package analyze
+
+import (
+	"sort"
+
+	"github.com/pickjonathan/sdek-cli/pkg/types"
+)
+
+// ControlSuggestion is a control that mapped evidence may support
+type ControlSuggestion struct {
+	FrameworkID string   `json:"framework_id"`
+	ControlID   string   `json:"control_id"`
+	Title       string   `json:"title"`
+	Confidence  float64  `json:"confidence"` // Highest confidence (0-100) of the evidence mapped to the control
+	EventIDs    []string `json:"event_ids"`  // Events mapped to the control, in evidence order
+	Keywords    []string `json:"keywords"`   // Control keywords the events matched
+	AIAnalyzed  bool     `json:"ai_analyzed"`
+}
+
+// SuggestControls groups evidence by control and ranks the controls by
+// confidence, then by the number of supporting events, then by framework and
+// control ID. It is the reverse of analyzing a section: given evidence, which
+// controls across all frameworks does it support?
+func (m *Mapper) SuggestControls(evidence []types.Evidence) []ControlSuggestion {
+	type key struct{ framework, control string }
+	byControl := make(map[key]*ControlSuggestion)
+	var order []key
+
+	for _, ev := range evidence {
+		k := key{ev.FrameworkID, ev.ControlID}
+		s, ok := byControl[k]
+		if !ok {
+			s = &ControlSuggestion{FrameworkID: ev.FrameworkID, ControlID: ev.ControlID}
+			if control := m.GetControlDefinition(ev.FrameworkID, ev.ControlID); control != nil {
+				s.Title = control.Title
+			}
+			byControl[k] = s
+			order = append(order, k)
+		}
+
+		if ev.ConfidenceScore > s.Confidence {
+			s.Confidence = ev.ConfidenceScore
+		}
+		if !containsString(s.EventIDs, ev.EventID) {
+			s.EventIDs = append(s.EventIDs, ev.EventID)
+		}
+		for _, keyword := range ev.Keywords {
+			if !containsString(s.Keywords, keyword) {
+				s.Keywords = append(s.Keywords, keyword)
+			}
+		}
+		s.AIAnalyzed = s.AIAnalyzed || ev.AIAnalyzed
+	}
+
+	suggestions := make([]ControlSuggestion, 0, len(order))
+	for _, k := range order {
+		suggestions = append(suggestions, *byControl[k])
+	}
+	sort.SliceStable(suggestions, func(i, j int) bool {
+		a, b := suggestions[i], suggestions[j]
+		if a.Confidence != b.Confidence {
+			return a.Confidence > b.Confidence
+		}
+		if len(a.EventIDs) != len(b.EventIDs) {
+			return len(a.EventIDs) > len(b.EventIDs)
+		}
+		if a.FrameworkID != b.FrameworkID {
+			return a.FrameworkID < b.FrameworkID
+		}
+		return a.ControlID < b.ControlID
+	})
+	return suggestions
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
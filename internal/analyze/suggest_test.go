@@ -0,0 +1,45 @@
+package analyze
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/pickjonathan/sdek-cli/pkg/types"
+)
+
+func TestSuggestControls_GroupsAndRanks(t *testing.T) {
+	mapper := NewMapper()
+	evidence := []types.Evidence{
+		{EventID: "e1", FrameworkID: "soc2", ControlID: "CC6.7", ConfidenceScore: 45, Keywords: []string{"firewall"}},
+		{EventID: "e1", FrameworkID: "pci_dss", ControlID: "1.1", ConfidenceScore: 65, Keywords: []string{"firewall", "perimeter"}},
+		{EventID: "e2", FrameworkID: "pci_dss", ControlID: "1.1", ConfidenceScore: 40, Keywords: []string{"firewall"}},
+		{EventID: "e2", FrameworkID: "iso27001", ControlID: "A.8.20", ConfidenceScore: 45, Keywords: []string{"firewall"}},
+		{EventID: "e3", FrameworkID: "iso27001", ControlID: "A.8.20", ConfidenceScore: 45, Keywords: []string{"network security"}},
+	}
+
+	suggestions := mapper.SuggestControls(evidence)
+
+	var got []string
+	for _, s := range suggestions {
+		got = append(got, s.FrameworkID+" "+s.ControlID)
+	}
+	// Highest confidence first; equal confidence ranks the control with more events first
+	want := []string{"pci_dss 1.1", "iso27001 A.8.20", "soc2 CC6.7"}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("SuggestControls() order = %v, want %v", got, want)
+	}
+
+	top := suggestions[0]
+	if top.Title != "Firewall Configuration" {
+		t.Errorf("Title = %q, want the control definition's title", top.Title)
+	}
+	if top.Confidence != 65 {
+		t.Errorf("Confidence = %v, want the highest evidence confidence 65", top.Confidence)
+	}
+	if !reflect.DeepEqual(top.EventIDs, []string{"e1", "e2"}) {
+		t.Errorf("EventIDs = %v, want [e1 e2]", top.EventIDs)
+	}
+	if !reflect.DeepEqual(top.Keywords, []string{"firewall", "perimeter"}) {
+		t.Errorf("Keywords = %v, want [firewall perimeter]", top.Keywords)
+	}
+}
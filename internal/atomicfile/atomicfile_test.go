@@ -0,0 +1,106 @@
+package atomicfile
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// TestWriteFile_TightensExistingFile verifies the mode is applied to a file that already exists
+func TestWriteFile_TightensExistingFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "finding.json")
+	if err := os.WriteFile(path, []byte("{}"), 0644); err != nil {
+		t.Fatalf("failed to create file: %v", err)
+	}
+
+	if err := WriteFile(path, []byte(`{"id": "f-1"}`), 0600); err != nil {
+		t.Fatalf("WriteFile() error = %v", err)
+	}
+
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatalf("failed to stat file: %v", err)
+	}
+	if info.Mode().Perm() != 0600 {
+		t.Errorf("expected mode 0600, got %o", info.Mode().Perm())
+	}
+}
+
+// TestWriteFile_InterruptedWriteKeepsTarget verifies a write that fails before the
+// rename leaves the previous file intact and no temp file behind
+func TestWriteFile_InterruptedWriteKeepsTarget(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "report.json")
+	if err := os.WriteFile(path, []byte(`{"version": "old"}`), 0644); err != nil {
+		t.Fatalf("failed to create file: %v", err)
+	}
+
+	var tmpPath string
+	renameFile = func(oldpath, newpath string) error {
+		tmpPath = oldpath
+		return errors.New("interrupted")
+	}
+	defer func() { renameFile = os.Rename }()
+
+	err := WriteFile(path, []byte(`{"version": "new", "frameworks": []}`), 0644)
+	if err == nil {
+		t.Fatal("expected WriteFile to fail")
+	}
+
+	if filepath.Dir(tmpPath) != dir || tmpPath == path {
+		t.Errorf("expected a temp file next to the target, got %q", tmpPath)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("failed to read target: %v", err)
+	}
+	if string(data) != `{"version": "old"}` {
+		t.Errorf("target was modified by interrupted write: %s", data)
+	}
+	entries, _ := os.ReadDir(dir)
+	for _, e := range entries {
+		if strings.HasSuffix(e.Name(), ".tmp") {
+			t.Errorf("temp file left behind: %s", e.Name())
+		}
+	}
+}
+
+// TestWriteFile_TempFileHoldsCompleteData verifies the temp file is fully written before it replaces the target
+func TestWriteFile_TempFileHoldsCompleteData(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "finding.json")
+	want := `{"id": "finding-1", "control_id": "CC6.1"}`
+
+	renamed := false
+	renameFile = func(oldpath, newpath string) error {
+		renamed = true
+		if _, err := os.Stat(newpath); !os.IsNotExist(err) {
+			t.Errorf("target exists before rename: %v", err)
+		}
+		data, err := os.ReadFile(oldpath)
+		if err != nil {
+			t.Fatalf("failed to read temp file: %v", err)
+		}
+		if string(data) != want {
+			t.Errorf("temp file holds %q, want %q", data, want)
+		}
+		return os.Rename(oldpath, newpath)
+	}
+	defer func() { renameFile = os.Rename }()
+
+	if err := WriteFile(path, []byte(want), 0644); err != nil {
+		t.Fatalf("WriteFile() error = %v", err)
+	}
+
+	if !renamed {
+		t.Fatal("expected the write to go through a temp file")
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("failed to read target: %v", err)
+	}
+	if string(data) != want {
+		t.Errorf("target holds %q, want %q", data, want)
+	}
+}
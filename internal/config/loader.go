@@ -6,13 +6,15 @@ import (
 	"path/filepath"
 	"strings"
 
+	"github.com/pickjonathan/sdek-cli/internal/i18n"
 	"github.com/pickjonathan/sdek-cli/pkg/types"
 	"github.com/spf13/viper"
 )
 
 // ConfigLoader handles loading configuration from multiple sources
 type ConfigLoader struct {
-	v *viper.Viper
+	v       *viper.Viper
+	profile string // Named profile merged over the config file; empty falls back to SDEK_PROFILE
 }
 
 // NewConfigLoader creates a new configuration loader
@@ -25,7 +27,7 @@ func NewConfigLoader() *ConfigLoader {
 // Load loads configuration with the following precedence:
 // 1. Command-line flags (highest priority)
 // 2. Environment variables (SDEK_*)
-// 3. Config file ($HOME/.sdek/config.yaml)
+// 3. Config file ($HOME/.sdek/config.yaml), with the selected profile merged over it
 // 4. Default values (lowest priority)
 func (cl *ConfigLoader) Load() (*types.Config, error) {
 	// Set default values
@@ -35,6 +37,7 @@ func (cl *ConfigLoader) Load() (*types.Config, error) {
 	cl.v.SetEnvPrefix("SDEK")
 	cl.v.SetEnvKeyReplacer(strings.NewReplacer(".", "_"))
 	cl.v.AutomaticEnv()
+	cl.v.BindEnv("language", "SDEK_LANGUAGE", "SDEK_LANG")
 
 	// Set config file location
 	if err := cl.configureConfigFile(); err != nil {
@@ -50,6 +53,13 @@ func (cl *ConfigLoader) Load() (*types.Config, error) {
 		// Config file not found; using defaults and env vars
 	}
 
+	// Merge the selected profile over the base config file settings
+	if profile := SelectedProfile(cl.profile); profile != "" {
+		if err := ApplyProfile(cl.v, profile); err != nil {
+			return nil, err
+		}
+	}
+
 	// Unmarshal into Config struct
 	config := &types.Config{}
 	if err := cl.v.Unmarshal(config); err != nil {
@@ -59,16 +69,24 @@ func (cl *ConfigLoader) Load() (*types.Config, error) {
 	return config, nil
 }
 
+// SetProfile selects the named profile to merge over the config file on Load
+func (cl *ConfigLoader) SetProfile(name string) {
+	cl.profile = name
+}
+
 // setDefaults sets default configuration values
 func (cl *ConfigLoader) setDefaults() {
 	cl.v.SetDefault("data_dir", "$HOME/.sdek")
 	cl.v.SetDefault("log_level", "info")
 	cl.v.SetDefault("theme", "dark")
+	cl.v.SetDefault("ui.interactive", true)
+	cl.v.SetDefault("language", i18n.English)
 	cl.v.SetDefault("user_role", types.RoleComplianceManager)
 
 	// Export defaults
 	cl.v.SetDefault("export.default_path", "$HOME/sdek/reports")
 	cl.v.SetDefault("export.format", "json")
+	cl.v.SetDefault("export.file_mode", "0644")
 
 	// Sources defaults (all enabled by default)
 	cl.v.SetDefault("sources.enabled", types.ValidSourceTypes)
@@ -88,12 +106,22 @@ func (cl *ConfigLoader) setDefaults() {
 	cl.v.SetDefault("ai.timeout", 60)                // 60 seconds
 	cl.v.SetDefault("ai.rate_limit", 10)             // 10 requests per minute
 	cl.v.SetDefault("ai.cache_dir", "$HOME/.sdek/cache/ai")
+	cl.v.SetDefault("ai.cache_max_bytes", types.DefaultCacheMaxBytes)
+	cl.v.SetDefault("ai.min_events_for_ai", 1)
+	cl.v.SetDefault("ai.max_prompt_chars", 0)
+	cl.v.SetDefault("ai.context_fallback_model", "")
+	cl.v.SetDefault("ai.rate_limit_burst", 0)
+	cl.v.SetDefault("ai.allow_unknown_model", false)
+	cl.v.SetDefault("ai.strict_residual_risk", false)
+	cl.v.SetDefault("ai.evidence_max_age_days", 0)
+	cl.v.SetDefault("ai.min_citations", 0)
+	cl.v.SetDefault("ai.allowed_sources", []string{})
 	cl.v.SetDefault("ai.openai_key", "")    // Must be set via env or config
 	cl.v.SetDefault("ai.anthropic_key", "") // Must be set via env or config
 	cl.v.SetDefault("ai.apiKey", "")        // Feature 003: Unified API key field
 
 	// Feature 003: Concurrency defaults
-	cl.v.SetDefault("ai.concurrency.maxAnalyses", 25)
+	cl.v.SetDefault("ai.concurrency.maxAnalyses", types.DefaultMaxAnalyses)
 
 	// Feature 003: Budget defaults
 	cl.v.SetDefault("ai.budgets.maxSources", 50)
@@ -103,10 +131,38 @@ func (cl *ConfigLoader) setDefaults() {
 	// Feature 003: Autonomous mode defaults
 	cl.v.SetDefault("ai.autonomous.enabled", false)
 	cl.v.SetDefault("ai.autonomous.autoApprove", map[string][]string{})
+	cl.v.SetDefault("ai.autonomous.minRationaleChars", types.DefaultMinRationaleChars)
+	cl.v.SetDefault("ai.autonomous.minSignalStrength", 0.0)
+	cl.v.SetDefault("ai.autonomous.maxItems", 0)
 
 	// Feature 003: Redaction defaults
 	cl.v.SetDefault("ai.redaction.enabled", true)
 	cl.v.SetDefault("ai.redaction.denylist", []string{})
+	cl.v.SetDefault("ai.redaction.locales", []string{})
+	cl.v.SetDefault("ai.redaction.denylist_mode", types.DenylistModeRedact)
+	cl.v.SetDefault("ai.invalid_citation_threshold", 0.25)
+	cl.v.SetDefault("ai.consensus_threshold", 0.3)
+	cl.v.SetDefault("ai.prompt_template", "") // Empty uses the built-in prompt
+	cl.v.SetDefault("ai.system_prompt", "")   // Empty uses each provider's built-in system message
+	cl.v.SetDefault("ai.deterministic", false)
+
+	// Scoring defaults
+	weights := types.DefaultSeverityWeights()
+	cl.v.SetDefault("scoring.weights.critical", weights.Critical)
+	cl.v.SetDefault("scoring.weights.high", weights.High)
+	cl.v.SetDefault("scoring.weights.medium", weights.Medium)
+	cl.v.SetDefault("scoring.weights.low", weights.Low)
+
+	// Audit log defaults (disabled unless a path is set)
+	cl.v.SetDefault("audit_log.path", "")
+
+	// Telemetry defaults (tracing disabled unless an endpoint is set)
+	cl.v.SetDefault("telemetry.otlp_endpoint", "")
+
+	// Remote excerpt fetching defaults
+	cl.v.SetDefault("excerpts.timeout", types.DefaultExcerptsTimeout)
+	cl.v.SetDefault("excerpts.cache_dir", types.DefaultExcerptsCacheDir)
+	cl.v.SetDefault("excerpts.cache_ttl", types.DefaultExcerptsCacheTTL)
 }
 
 // configureConfigFile sets up the config file path
@@ -170,14 +226,28 @@ func (cl *ConfigLoader) WriteConfig(config *types.Config) error {
 	cl.v.Set("data_dir", config.DataDir)
 	cl.v.Set("log_level", config.LogLevel)
 	cl.v.Set("theme", config.Theme)
+	cl.v.Set("ui.interactive", config.UI.Interactive)
+	cl.v.Set("language", config.Language)
 	cl.v.Set("user_role", config.UserRole)
 
 	cl.v.Set("export.default_path", config.Export.DefaultPath)
 	cl.v.Set("export.format", config.Export.Format)
+	if config.Export.FileMode != "" {
+		cl.v.Set("export.file_mode", config.Export.FileMode)
+	}
 
 	cl.v.Set("sources.enabled", config.Sources.Enabled)
 
 	cl.v.Set("frameworks.enabled", config.Frameworks.Enabled)
+	if len(config.Frameworks.Versions) > 0 {
+		cl.v.Set("frameworks.versions", config.Frameworks.Versions)
+	}
+	if len(config.Frameworks.Rubrics) > 0 {
+		cl.v.Set("frameworks.rubrics", config.Frameworks.Rubrics)
+	}
+	if config.Frameworks.RubricsFile != "" {
+		cl.v.Set("frameworks.rubrics_file", config.Frameworks.RubricsFile)
+	}
 
 	// AI configuration (Feature 002 + 003: AI Evidence Analysis + Context Injection)
 	cl.v.Set("ai.enabled", config.AI.Enabled)
@@ -187,9 +257,13 @@ func (cl *ConfigLoader) WriteConfig(config *types.Config) error {
 	cl.v.Set("ai.timeout", config.AI.Timeout)
 	cl.v.Set("ai.rate_limit", config.AI.RateLimit)
 	cl.v.Set("ai.cache_dir", config.AI.CacheDir)
+	cl.v.Set("ai.cache_max_bytes", config.AI.CacheMaxBytes)
 	cl.v.Set("ai.openai_key", config.AI.OpenAIKey)
 	cl.v.Set("ai.anthropic_key", config.AI.AnthropicKey)
 	cl.v.Set("ai.apiKey", config.AI.APIKey)
+	if config.AI.APIKeyFile != "" {
+		cl.v.Set("ai.api_key_file", config.AI.APIKeyFile)
+	}
 
 	// Feature 003: Concurrency settings
 	cl.v.Set("ai.concurrency.maxAnalyses", config.AI.Concurrency.MaxAnalyses)
@@ -202,10 +276,53 @@ func (cl *ConfigLoader) WriteConfig(config *types.Config) error {
 	// Feature 003: Autonomous mode settings
 	cl.v.Set("ai.autonomous.enabled", config.AI.Autonomous.Enabled)
 	cl.v.Set("ai.autonomous.autoApprove", config.AI.Autonomous.AutoApprove)
+	cl.v.Set("ai.autonomous.minRationaleChars", config.AI.Autonomous.MinRationaleChars)
+	cl.v.Set("ai.autonomous.minSignalStrength", config.AI.Autonomous.MinSignalStrength)
+	cl.v.Set("ai.autonomous.maxItems", config.AI.Autonomous.MaxItems)
 
 	// Feature 003: Redaction settings
 	cl.v.Set("ai.redaction.enabled", config.AI.Redaction.Enabled)
 	cl.v.Set("ai.redaction.denylist", config.AI.Redaction.Denylist)
+	cl.v.Set("ai.redaction.locales", config.AI.Redaction.Locales)
+	cl.v.Set("ai.redaction.denylist_mode", config.AI.Redaction.DenylistMode)
+	cl.v.Set("ai.invalid_citation_threshold", config.AI.InvalidCitationThreshold)
+	cl.v.Set("ai.consensus_threshold", config.AI.ConsensusThreshold)
+	cl.v.Set("ai.prompt_template", config.AI.PromptTemplate)
+	cl.v.Set("ai.system_prompt", config.AI.SystemPrompt)
+	cl.v.Set("ai.deterministic", config.AI.Deterministic)
+	cl.v.Set("ai.min_events_for_ai", config.AI.MinEventsForAI)
+	cl.v.Set("ai.max_prompt_chars", config.AI.MaxPromptChars)
+	cl.v.Set("ai.context_fallback_model", config.AI.ContextFallbackModel)
+	cl.v.Set("ai.rate_limit_burst", config.AI.RateLimitBurst)
+	cl.v.Set("ai.allow_unknown_model", config.AI.AllowUnknownModel)
+	cl.v.Set("ai.strict_residual_risk", config.AI.StrictResidualRisk)
+	cl.v.Set("ai.evidence_max_age_days", config.AI.EvidenceMaxAgeDays)
+	cl.v.Set("ai.min_citations", config.AI.MinCitations)
+	cl.v.Set("ai.allowed_sources", config.AI.AllowedSources)
+	if len(config.AI.ProviderDefaults) > 0 {
+		cl.v.Set("ai.provider_defaults", config.AI.ProviderDefaults)
+	}
+	if config.AI.Seed != nil {
+		cl.v.Set("ai.seed", *config.AI.Seed)
+	}
+
+	// Scoring settings
+	cl.v.Set("scoring.weights.critical", config.Scoring.Weights.Critical)
+	cl.v.Set("scoring.weights.high", config.Scoring.Weights.High)
+	cl.v.Set("scoring.weights.medium", config.Scoring.Weights.Medium)
+	cl.v.Set("scoring.weights.low", config.Scoring.Weights.Low)
+
+	// Audit log settings
+	cl.v.Set("audit_log.path", config.AuditLog.Path)
+
+	// Telemetry settings
+	cl.v.Set("telemetry.otlp_endpoint", config.Telemetry.OTLPEndpoint)
+
+	// Remote excerpt settings
+	cl.v.Set("excerpts.timeout", config.Excerpts.Timeout)
+	cl.v.Set("excerpts.cache_dir", config.Excerpts.CacheDir)
+	cl.v.Set("excerpts.cache_ttl", config.Excerpts.CacheTTL)
+	cl.v.Set("excerpts.headers", config.Excerpts.Headers)
 
 	// Ensure config directory exists
 	if err := cl.configureConfigFile(); err != nil {
@@ -162,6 +162,29 @@ func TestLoadFromEnvironment(t *testing.T) {
 	}
 }
 
+func TestLoadLanguage(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("SDEK_LANGUAGE", "")
+	t.Setenv("SDEK_LANG", "")
+
+	config, err := NewConfigLoader().Load()
+	if err != nil {
+		t.Fatalf("Failed to load config: %v", err)
+	}
+	if config.Language != "en" {
+		t.Errorf("Expected default language 'en', got '%s'", config.Language)
+	}
+
+	t.Setenv("SDEK_LANG", "es_ES.UTF-8")
+	config, err = NewConfigLoader().Load()
+	if err != nil {
+		t.Fatalf("Failed to load config: %v", err)
+	}
+	if config.Language != "es_ES.UTF-8" {
+		t.Errorf("Expected language from SDEK_LANG, got '%s'", config.Language)
+	}
+}
+
 func TestPrecedenceOrder(t *testing.T) {
 	// Create temp directory
 	tmpDir := t.TempDir()
@@ -0,0 +1,62 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/pickjonathan/sdek-cli/pkg/types"
+	"github.com/spf13/viper"
+)
+
+// ProfileEnv selects a config profile when --profile is not given
+const ProfileEnv = "SDEK_PROFILE"
+
+// SelectedProfile returns flagValue if set, otherwise the SDEK_PROFILE environment variable
+func SelectedProfile(flagValue string) string {
+	if flagValue != "" {
+		return flagValue
+	}
+	return os.Getenv(ProfileEnv)
+}
+
+// ApplyProfile deep-merges the settings under profiles.<name> in the config
+// file over the base settings held by v, then validates the merged result.
+// Environment variables and flags still take precedence over the profile.
+func ApplyProfile(v *viper.Viper, name string) error {
+	key := "profiles." + strings.ToLower(name)
+	if !v.IsSet(key) {
+		return fmt.Errorf("profile %q not found in config file%s", name, availableProfiles(v))
+	}
+
+	if err := v.MergeConfigMap(v.GetStringMap(key)); err != nil {
+		return fmt.Errorf("failed to apply profile %q: %w", name, err)
+	}
+
+	// Unset fields keep their defaults so a partial base config still validates
+	merged := types.DefaultConfig()
+	if err := v.Unmarshal(merged); err != nil {
+		return fmt.Errorf("failed to unmarshal profile %q: %w", name, err)
+	}
+	if err := types.ValidateConfig(merged); err != nil {
+		return fmt.Errorf("invalid configuration with profile %q: %w", name, err)
+	}
+
+	return nil
+}
+
+// availableProfiles lists the profiles defined in the config file for error messages
+func availableProfiles(v *viper.Viper) string {
+	profiles := v.GetStringMap("profiles")
+	if len(profiles) == 0 {
+		return " (no profiles defined)"
+	}
+
+	names := make([]string, 0, len(profiles))
+	for name := range profiles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return fmt.Sprintf(" (available: %s)", strings.Join(names, ", "))
+}
@@ -0,0 +1,135 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/pickjonathan/sdek-cli/pkg/types"
+)
+
+const profileConfig = `
+log_level: info
+ai:
+  enabled: true
+  provider: openai
+  model: gpt-4
+  timeout: 90
+  concurrency:
+    maxAnalyses: 10
+profiles:
+  prod:
+    log_level: warn
+    ai:
+      provider: anthropic
+      model: claude-3-5-sonnet-20241022
+      anthropic_key: sk-ant-test
+  broken:
+    ai:
+      provider: bogus
+`
+
+// writeProfileConfig writes profileConfig to a temp HOME and returns a loader reading it
+func writeProfileConfig(t *testing.T) *ConfigLoader {
+	t.Helper()
+
+	tmpDir := t.TempDir()
+	t.Setenv("HOME", tmpDir)
+	t.Setenv(ProfileEnv, "")
+
+	configDir := filepath.Join(tmpDir, ".sdek")
+	if err := os.MkdirAll(configDir, 0755); err != nil {
+		t.Fatalf("Failed to create config directory: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(configDir, "config.yaml"), []byte(profileConfig), 0644); err != nil {
+		t.Fatalf("Failed to write config file: %v", err)
+	}
+
+	return NewConfigLoader()
+}
+
+func TestLoadWithProfile(t *testing.T) {
+	loader := writeProfileConfig(t)
+	loader.SetProfile("prod")
+
+	config, err := loader.Load()
+	if err != nil {
+		t.Fatalf("Failed to load config: %v", err)
+	}
+
+	// Profile overrides
+	if config.AI.Provider != types.AIProviderAnthropic {
+		t.Errorf("Expected provider '%s', got '%s'", types.AIProviderAnthropic, config.AI.Provider)
+	}
+	if config.AI.Model != "claude-3-5-sonnet-20241022" {
+		t.Errorf("Expected profile model, got '%s'", config.AI.Model)
+	}
+	if config.LogLevel != "warn" {
+		t.Errorf("Expected log level 'warn', got '%s'", config.LogLevel)
+	}
+
+	// Base settings the profile doesn't mention persist
+	if !config.AI.Enabled {
+		t.Error("Expected ai.enabled from the base config to persist")
+	}
+	if config.AI.Timeout != 90 {
+		t.Errorf("Expected timeout 90 from the base config, got %d", config.AI.Timeout)
+	}
+	if config.AI.Concurrency.MaxAnalyses != 10 {
+		t.Errorf("Expected maxAnalyses 10 from the base config, got %d", config.AI.Concurrency.MaxAnalyses)
+	}
+}
+
+func TestLoadWithProfileFromEnvironment(t *testing.T) {
+	loader := writeProfileConfig(t)
+	t.Setenv(ProfileEnv, "prod")
+
+	config, err := loader.Load()
+	if err != nil {
+		t.Fatalf("Failed to load config: %v", err)
+	}
+
+	if config.AI.Provider != types.AIProviderAnthropic {
+		t.Errorf("Expected SDEK_PROFILE to select provider '%s', got '%s'", types.AIProviderAnthropic, config.AI.Provider)
+	}
+}
+
+func TestLoadWithoutProfile(t *testing.T) {
+	loader := writeProfileConfig(t)
+
+	config, err := loader.Load()
+	if err != nil {
+		t.Fatalf("Failed to load config: %v", err)
+	}
+
+	if config.AI.Provider != types.AIProviderOpenAI {
+		t.Errorf("Expected base provider '%s', got '%s'", types.AIProviderOpenAI, config.AI.Provider)
+	}
+}
+
+func TestLoadWithUnknownProfile(t *testing.T) {
+	loader := writeProfileConfig(t)
+	loader.SetProfile("staging")
+
+	_, err := loader.Load()
+	if err == nil {
+		t.Fatal("Expected an error for an unknown profile")
+	}
+	if !strings.Contains(err.Error(), `profile "staging" not found`) || !strings.Contains(err.Error(), "available: broken, prod") {
+		t.Errorf("Unexpected error: %v", err)
+	}
+}
+
+func TestLoadWithInvalidProfile(t *testing.T) {
+	loader := writeProfileConfig(t)
+	loader.SetProfile("broken")
+
+	_, err := loader.Load()
+	if err == nil {
+		t.Fatal("Expected the merged config to fail validation")
+	}
+	if !strings.Contains(err.Error(), `invalid configuration with profile "broken"`) {
+		t.Errorf("Unexpected error: %v", err)
+	}
+}
@@ -0,0 +1,67 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/pickjonathan/sdek-cli/pkg/types"
+	"gopkg.in/yaml.v3"
+)
+
+// LoadRubricsFile reads per-framework analysis rubrics from a YAML or JSON file
+// keyed by framework ID, e.g.
+//
+//	soc2:
+//	  confidence_threshold: 0.75
+//	  guidance: Only count evidence from the last 12 months.
+func LoadRubricsFile(path string) (map[string]types.AnalysisRubrics, error) {
+	if strings.HasPrefix(path, "~") {
+		homeDir, err := os.UserHomeDir()
+		if err != nil {
+			return nil, fmt.Errorf("failed to get home directory: %w", err)
+		}
+		path = filepath.Join(homeDir, path[1:])
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read rubrics file: %w", err)
+	}
+
+	var rubrics map[string]types.AnalysisRubrics
+	if err := yaml.Unmarshal(data, &rubrics); err != nil {
+		return nil, fmt.Errorf("failed to parse rubrics file %s: %w", path, err)
+	}
+
+	return rubrics, nil
+}
+
+// ApplyRubricsFile merges the rubrics in cfg.Frameworks.RubricsFile under
+// cfg.Frameworks.Rubrics; frameworks already configured inline keep their
+// settings. The merged rubrics are validated.
+func ApplyRubricsFile(cfg *types.Config) error {
+	if cfg.Frameworks.RubricsFile != "" {
+		rubrics, err := LoadRubricsFile(cfg.Frameworks.RubricsFile)
+		if err != nil {
+			return err
+		}
+		if cfg.Frameworks.Rubrics == nil {
+			cfg.Frameworks.Rubrics = make(map[string]types.AnalysisRubrics, len(rubrics))
+		}
+		for framework, r := range rubrics {
+			if _, ok := cfg.Frameworks.Rubrics[framework]; !ok {
+				cfg.Frameworks.Rubrics[framework] = r
+			}
+		}
+	}
+
+	for framework, r := range cfg.Frameworks.Rubrics {
+		if r.ConfidenceThreshold < 0 || r.ConfidenceThreshold > 1 {
+			return fmt.Errorf("invalid rubrics for %s: confidence_threshold must be between 0 and 1, got %.2f", framework, r.ConfidenceThreshold)
+		}
+	}
+
+	return nil
+}
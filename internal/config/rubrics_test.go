@@ -0,0 +1,77 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/pickjonathan/sdek-cli/pkg/types"
+)
+
+// writeRubricsFile writes content to a rubrics file in a temp directory
+func writeRubricsFile(t *testing.T, name, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatalf("failed to write rubrics file: %v", err)
+	}
+	return path
+}
+
+// TestLoadRubricsFile verifies YAML and JSON rubrics files are parsed
+func TestLoadRubricsFile(t *testing.T) {
+	tests := []struct {
+		name    string
+		file    string
+		content string
+	}{
+		{"yaml", "rubrics.yaml", "soc2:\n  confidence_threshold: 0.75\n  guidance: Count only production evidence.\n"},
+		{"json", "rubrics.json", `{"soc2": {"confidence_threshold": 0.75, "guidance": "Count only production evidence."}}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rubrics, err := LoadRubricsFile(writeRubricsFile(t, tt.file, tt.content))
+			if err != nil {
+				t.Fatalf("LoadRubricsFile() error = %v", err)
+			}
+			soc2 := rubrics["soc2"]
+			if soc2.ConfidenceThreshold != 0.75 || soc2.Guidance != "Count only production evidence." {
+				t.Errorf("soc2 rubrics = %+v", soc2)
+			}
+		})
+	}
+}
+
+// TestApplyRubricsFile verifies file rubrics fill in frameworks not configured inline
+func TestApplyRubricsFile(t *testing.T) {
+	cfg := types.DefaultConfig()
+	cfg.Frameworks.Rubrics = map[string]types.AnalysisRubrics{"soc2": {ConfidenceThreshold: 0.9}}
+	cfg.Frameworks.RubricsFile = writeRubricsFile(t, "rubrics.yaml", "soc2:\n  confidence_threshold: 0.5\niso27001:\n  confidence_threshold: 0.7\n")
+
+	if err := ApplyRubricsFile(cfg); err != nil {
+		t.Fatalf("ApplyRubricsFile() error = %v", err)
+	}
+
+	if got := cfg.Frameworks.Rubrics["soc2"].ConfidenceThreshold; got != 0.9 {
+		t.Errorf("soc2 threshold = %v, want inline 0.9", got)
+	}
+	if got := cfg.Frameworks.Rubrics["iso27001"].ConfidenceThreshold; got != 0.7 {
+		t.Errorf("iso27001 threshold = %v, want 0.7 from file", got)
+	}
+}
+
+// TestApplyRubricsFile_Invalid verifies out-of-range thresholds and unreadable files are rejected
+func TestApplyRubricsFile_Invalid(t *testing.T) {
+	cfg := types.DefaultConfig()
+	cfg.Frameworks.RubricsFile = writeRubricsFile(t, "rubrics.yaml", "soc2:\n  confidence_threshold: 2\n")
+	if err := ApplyRubricsFile(cfg); err == nil {
+		t.Error("Expected error for a threshold above 1")
+	}
+
+	cfg = types.DefaultConfig()
+	cfg.Frameworks.RubricsFile = filepath.Join(t.TempDir(), "missing.yaml")
+	if err := ApplyRubricsFile(cfg); err == nil {
+		t.Error("Expected error for a missing rubrics file")
+	}
+}
@@ -109,14 +109,9 @@ func (v *Validator) ValidateFrameworks(enabled []string) error {
 		return fmt.Errorf("at least one framework must be enabled")
 	}
 
-	// Check each enabled framework is valid
-	validFrameworks := make(map[string]bool)
-	for _, f := range types.ValidFrameworkIDs {
-		validFrameworks[f] = true
-	}
-
+	// Check each enabled framework is valid, accepting aliases such as "SOC-2"
 	for _, framework := range enabled {
-		if !validFrameworks[framework] {
+		if _, ok := types.NormalizeFramework(framework); !ok {
 			return fmt.Errorf("invalid framework: %s (valid frameworks: %v)", framework, types.ValidFrameworkIDs)
 		}
 	}
@@ -210,5 +205,10 @@ func (v *Validator) ValidateAIConfig(ai *types.AIConfig) error {
 		return fmt.Errorf("ai.cache_dir cannot be empty")
 	}
 
+	// Validate concurrency (worker pools need at least one worker)
+	if ai.Concurrency.MaxAnalyses < 1 {
+		return fmt.Errorf("ai.concurrency.maxAnalyses must be positive, got: %d", ai.Concurrency.MaxAnalyses)
+	}
+
 	return nil
 }
@@ -171,6 +171,11 @@ func TestValidateFrameworks(t *testing.T) {
 			frameworks: []string{types.FrameworkSOC2, types.FrameworkISO27001, types.FrameworkPCIDSS},
 			wantErr:    false,
 		},
+		{
+			name:       "framework aliases",
+			frameworks: []string{"SOC2", "ISO 27001", "PCI-DSS"},
+			wantErr:    false,
+		},
 		{
 			name:       "invalid framework",
 			frameworks: []string{"invalid"},
@@ -487,3 +492,32 @@ func TestValidateFullConfig(t *testing.T) {
 		})
 	}
 }
+
+func TestValidateAIConfig_Concurrency(t *testing.T) {
+	validator := NewValidator()
+
+	tests := []struct {
+		name        string
+		maxAnalyses int
+		wantErr     bool
+	}{
+		{name: "default", maxAnalyses: types.DefaultMaxAnalyses, wantErr: false},
+		{name: "single worker", maxAnalyses: 1, wantErr: false},
+		{name: "zero", maxAnalyses: 0, wantErr: true},
+		{name: "negative", maxAnalyses: -1, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ai := types.DefaultConfig().AI
+			ai.Enabled = true
+			ai.OpenAIKey = "sk-test"
+			ai.Concurrency.MaxAnalyses = tt.maxAnalyses
+
+			err := validator.ValidateAIConfig(&ai)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ValidateAIConfig() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,74 @@
+package i18n
+
+// catalogs holds the messages of each supported language, by language code.
+// English must cover every key; other languages may leave keys out.
+var catalogs = map[string]map[Key]string{
+	English: {
+		SummaryComplete:       "Analysis Complete!",
+		SummaryFramework:      "Framework",
+		SummaryControl:        "Control",
+		SummaryConfidence:     "Confidence",
+		SummaryResidualRisk:   "Residual Risk",
+		SummaryProvider:       "Provider",
+		SummarySource:         "Source",
+		SummarySourceCache:    "cache",
+		SummarySeed:           "Seed",
+		SummarySources:        "Sources",
+		SummaryRedactions:     "Redactions",
+		SummaryReviewRequired: "Review required",
+		SummaryReviewReason:   "Review required: %s",
+		SummaryStaleEvidence:  "Stale Evidence: newest cited event exceeds ai.evidence_max_age_days",
+		SummaryMappedControls: "Mapped Controls",
+		SummaryCitations:      "Citations",
+		SummaryCitationsFirst: "(showing first %d of %d)",
+		SummaryJustification:  "Justification",
+		SummarySaved:          "Finding saved to: %s",
+
+		ErrorHint:             "Hint: %s",
+		ErrorRateLimit:        "This is transient: the provider is rate limiting requests. Wait a minute and retry.",
+		ErrorTimeout:          "This is transient: the provider did not respond in time. Retry, or raise ai.timeout if it keeps happening.",
+		ErrorUnavailable:      "This is transient: the provider is having an outage. Retry later.",
+		ErrorAuth:             "Retrying won't help: fix your API key (SDEK_OPENAI_KEY, SDEK_ANTHROPIC_KEY or the ai.*_key config settings) and run the command again.",
+		ErrorQuotaExceeded:    "Retrying won't help: the provider quota is exhausted. Raise the quota or wait for it to reset.",
+		ErrorInvalidJSON:      "Retrying won't help: the provider returned a malformed response. Try a different ai.model or check ai.prompt_template.",
+		ErrorRetryable:        "This is transient. Retry the command.",
+		ErrorFatal:            "Retrying won't help: fix the request or configuration first.",
+		ErrorNoReportData:     "no data found to report, run 'sdek seed --demo' first",
+		ErrorAPIKeyRequired:   "API key required for %s - set SDEK_%s_KEY, ai.api_key_file, or configure in config.yaml",
+		ErrorNoEvidenceEvents: "no evidence events found in %s",
+	},
+	"es": {
+		SummaryComplete:       "¡Análisis completado!",
+		SummaryFramework:      "Marco",
+		SummaryControl:        "Control",
+		SummaryConfidence:     "Confianza",
+		SummaryResidualRisk:   "Riesgo residual",
+		SummaryProvider:       "Proveedor",
+		SummarySource:         "Origen",
+		SummarySourceCache:    "caché",
+		SummarySeed:           "Semilla",
+		SummarySources:        "Fuentes",
+		SummaryRedactions:     "Redacciones",
+		SummaryReviewRequired: "Revisión necesaria",
+		SummaryReviewReason:   "Revisión necesaria: %s",
+		SummaryStaleEvidence:  "Evidencia obsoleta: el evento citado más reciente supera ai.evidence_max_age_days",
+		SummaryMappedControls: "Controles asignados",
+		SummaryCitations:      "Citas",
+		SummaryCitationsFirst: "(se muestran las primeras %d de %d)",
+		SummaryJustification:  "Justificación",
+		SummarySaved:          "Hallazgo guardado en: %s",
+
+		ErrorHint:             "Sugerencia: %s",
+		ErrorRateLimit:        "Es transitorio: el proveedor está limitando las solicitudes. Espere un minuto y vuelva a intentarlo.",
+		ErrorTimeout:          "Es transitorio: el proveedor no respondió a tiempo. Vuelva a intentarlo o aumente ai.timeout si sigue ocurriendo.",
+		ErrorUnavailable:      "Es transitorio: el proveedor sufre una interrupción. Vuelva a intentarlo más tarde.",
+		ErrorAuth:             "Reintentar no servirá: corrija su clave de API (SDEK_OPENAI_KEY, SDEK_ANTHROPIC_KEY o los ajustes ai.*_key) y vuelva a ejecutar el comando.",
+		ErrorQuotaExceeded:    "Reintentar no servirá: la cuota del proveedor está agotada. Aumente la cuota o espere a que se restablezca.",
+		ErrorInvalidJSON:      "Reintentar no servirá: el proveedor devolvió una respuesta mal formada. Pruebe otro ai.model o revise ai.prompt_template.",
+		ErrorRetryable:        "Es transitorio. Vuelva a ejecutar el comando.",
+		ErrorFatal:            "Reintentar no servirá: corrija primero la solicitud o la configuración.",
+		ErrorNoReportData:     "no hay datos para el informe, ejecute primero 'sdek seed --demo'",
+		ErrorAPIKeyRequired:   "se requiere una clave de API para %s: defina SDEK_%s_KEY, ai.api_key_file o configúrela en config.yaml",
+		ErrorNoEvidenceEvents: "no se encontraron eventos de evidencia en %s",
+	},
+}
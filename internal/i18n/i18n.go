@@ -0,0 +1,116 @@
+// Package i18n translates the messages sdek prints for users: command
+// summaries, prompts and error hints. Log output stays in English.
+package i18n
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// English is the default language, and the fallback for messages a catalog
+// does not translate
+const English = "en"
+
+// Key identifies a user-facing message
+type Key string
+
+// Messages printed by the ai analyze finding summary
+const (
+	SummaryComplete       Key = "summary.complete"
+	SummaryFramework      Key = "summary.framework"
+	SummaryControl        Key = "summary.control"
+	SummaryConfidence     Key = "summary.confidence"
+	SummaryResidualRisk   Key = "summary.residual_risk"
+	SummaryProvider       Key = "summary.provider"
+	SummarySource         Key = "summary.source"
+	SummarySourceCache    Key = "summary.source_cache"
+	SummarySeed           Key = "summary.seed"
+	SummarySources        Key = "summary.sources"
+	SummaryRedactions     Key = "summary.redactions"
+	SummaryReviewRequired Key = "summary.review_required"
+	SummaryReviewReason   Key = "summary.review_reason"
+	SummaryStaleEvidence  Key = "summary.stale_evidence"
+	SummaryMappedControls Key = "summary.mapped_controls"
+	SummaryCitations      Key = "summary.citations"
+	SummaryCitationsFirst Key = "summary.citations_first"
+	SummaryJustification  Key = "summary.justification"
+	SummarySaved          Key = "summary.saved"
+)
+
+// Hints printed after a command fails with a provider error
+const (
+	ErrorHint             Key = "error.hint"
+	ErrorRateLimit        Key = "error.rate_limit"
+	ErrorTimeout          Key = "error.timeout"
+	ErrorUnavailable      Key = "error.unavailable"
+	ErrorAuth             Key = "error.auth"
+	ErrorQuotaExceeded    Key = "error.quota_exceeded"
+	ErrorInvalidJSON      Key = "error.invalid_json"
+	ErrorRetryable        Key = "error.retryable"
+	ErrorFatal            Key = "error.fatal"
+	ErrorNoReportData     Key = "error.no_report_data"
+	ErrorAPIKeyRequired   Key = "error.api_key_required"
+	ErrorNoEvidenceEvents Key = "error.no_evidence_events"
+)
+
+// Catalog looks up messages in one language
+type Catalog struct {
+	lang string
+}
+
+// New returns the catalog for lang, given as a language code ("es") or a
+// locale ("es_ES.UTF-8", "es-MX"). Unsupported or empty languages get the
+// English catalog.
+func New(lang string) Catalog {
+	code := Normalize(lang)
+	if _, ok := catalogs[code]; !ok {
+		code = English
+	}
+	return Catalog{lang: code}
+}
+
+// Language returns the catalog's language code
+func (c Catalog) Language() string {
+	if c.lang == "" {
+		return English
+	}
+	return c.lang
+}
+
+// T returns the message for key, formatted with args as by fmt.Sprintf.
+// Messages missing from the catalog fall back to English, and keys missing
+// from English are returned as is.
+func (c Catalog) T(key Key, args ...any) string {
+	msg, ok := catalogs[c.Language()][key]
+	if !ok {
+		msg, ok = catalogs[English][key]
+	}
+	if !ok {
+		msg = string(key)
+	}
+	if len(args) == 0 {
+		return msg
+	}
+	return fmt.Sprintf(msg, args...)
+}
+
+// Normalize reduces a language code or locale to its lowercase language,
+// e.g. "es_ES.UTF-8" to "es"
+func Normalize(lang string) string {
+	lang = strings.ToLower(strings.TrimSpace(lang))
+	if i := strings.IndexAny(lang, "_-.@"); i >= 0 {
+		lang = lang[:i]
+	}
+	return lang
+}
+
+// Languages returns the codes of the languages with a catalog, sorted
+func Languages() []string {
+	langs := make([]string, 0, len(catalogs))
+	for lang := range catalogs {
+		langs = append(langs, lang)
+	}
+	sort.Strings(langs)
+	return langs
+}
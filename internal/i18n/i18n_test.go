@@ -0,0 +1,67 @@
+package i18n
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestCatalog_SwitchingLanguageChangesMessages(t *testing.T) {
+	if got := New("en").T(SummaryComplete); got != "Analysis Complete!" {
+		t.Errorf("en: got %q", got)
+	}
+	if got := New("es").T(SummaryComplete); got != "¡Análisis completado!" {
+		t.Errorf("es: got %q", got)
+	}
+	if got := New("es").T(SummarySaved, "finding.json"); got != "Hallazgo guardado en: finding.json" {
+		t.Errorf("es with args: got %q", got)
+	}
+}
+
+func TestNew_NormalizesLocales(t *testing.T) {
+	tests := map[string]string{
+		"":            English,
+		"es":          "es",
+		"ES":          "es",
+		"es_ES.UTF-8": "es",
+		"es-MX":       "es",
+		"fr":          English, // unsupported
+		"C":           English,
+	}
+	for lang, want := range tests {
+		if got := New(lang).Language(); got != want {
+			t.Errorf("New(%q).Language() = %q, want %q", lang, got, want)
+		}
+	}
+}
+
+func TestCatalog_FallsBackToEnglish(t *testing.T) {
+	const untranslated Key = "test.untranslated"
+	catalogs[English][untranslated] = "Only in English: %d"
+	t.Cleanup(func() { delete(catalogs[English], untranslated) })
+
+	if got := New("es").T(untranslated, 3); got != "Only in English: 3" {
+		t.Errorf("missing Spanish message: got %q, want the English one", got)
+	}
+	if got := New("fr").T(SummaryComplete); got != "Analysis Complete!" {
+		t.Errorf("unsupported language: got %q, want English", got)
+	}
+	if got := (Catalog{}).T(SummaryComplete); got != "Analysis Complete!" {
+		t.Errorf("zero Catalog: got %q, want English", got)
+	}
+	if got := New("es").T("no.such.key"); got != "no.such.key" {
+		t.Errorf("unknown key: got %q, want the key itself", got)
+	}
+}
+
+func TestCatalogs_OnlyTranslateEnglishKeys(t *testing.T) {
+	for lang, messages := range catalogs {
+		for key := range messages {
+			if _, ok := catalogs[English][key]; !ok {
+				t.Errorf("%s translates %q, which has no English message", lang, key)
+			}
+		}
+	}
+	if got := Languages(); !reflect.DeepEqual(got, []string{"en", "es"}) {
+		t.Errorf("Languages() = %v", got)
+	}
+}
@@ -74,16 +74,19 @@ func (t *HTTPTransport) Initialize(ctx context.Context, config types.MCPServerCo
 	return nil
 }
 
-// Send sends a JSON-RPC request via HTTP POST
+// Send sends a JSON-RPC request via HTTP POST.
+// Requests are independent, so concurrent calls are sent in parallel; callers
+// bound them (see MCPManager.ExecuteTool).
 func (t *HTTPTransport) Send(ctx context.Context, request *JSONRPCRequest) (*JSONRPCResponse, error) {
 	t.mu.Lock()
-	defer t.mu.Unlock()
+	closed, client := t.closed, t.client
+	t.mu.Unlock()
 
-	if t.closed {
+	if closed {
 		return nil, ErrConnectionClosed
 	}
 
-	if t.client == nil {
+	if client == nil {
 		return nil, fmt.Errorf("transport not initialized")
 	}
 
@@ -110,7 +113,7 @@ func (t *HTTPTransport) Send(ctx context.Context, request *JSONRPCRequest) (*JSO
 	}
 
 	// Send request
-	httpResp, err := t.client.Do(httpReq)
+	httpResp, err := client.Do(httpReq)
 	if err != nil {
 		return nil, fmt.Errorf("%w: HTTP request failed: %v", ErrTransportFailed, err)
 	}
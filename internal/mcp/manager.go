@@ -51,14 +51,22 @@ type MCPManager struct {
 	mu      sync.RWMutex
 	stopCh  chan struct{}
 	wg      sync.WaitGroup
+
+	// callSlots bounds in-flight tool calls across all servers to config.MaxConcurrent
+	callSlots chan struct{}
 }
 
-// NewMCPManager creates a new MCP manager
+// NewMCPManager creates a new MCP manager. An unset MaxConcurrent uses the
+// default from types.DefaultMCPConfig, so tool calls are always bounded.
 func NewMCPManager(config types.MCPConfig) *MCPManager {
+	if config.MaxConcurrent <= 0 {
+		config.MaxConcurrent = types.DefaultMCPConfig().MaxConcurrent
+	}
 	return &MCPManager{
-		config:  config,
-		servers: make(map[string]*MCPServer),
-		stopCh:  make(chan struct{}),
+		config:    config,
+		servers:   make(map[string]*MCPServer),
+		stopCh:    make(chan struct{}),
+		callSlots: make(chan struct{}, config.MaxConcurrent),
 	}
 }
 
@@ -156,11 +164,18 @@ func (m *MCPManager) ExecuteTool(ctx context.Context, serverName, toolName strin
 
 	var lastErr error
 	for attempt := 0; attempt < maxRetries; attempt++ {
+		// Wait for a free call slot, so callers such as ExecutePlan are
+		// throttled instead of flooding servers with requests
+		if err := m.acquireCallSlot(ctx); err != nil {
+			return nil, err
+		}
+
 		startTime := time.Now()
 
 		// Call the tool
 		result, err := server.Client.CallTool(ctx, toolName, arguments)
 		latency := time.Since(startTime).Milliseconds()
+		m.releaseCallSlot()
 
 		// Update stats
 		server.mu.Lock()
@@ -208,6 +223,22 @@ func (m *MCPManager) ExecuteTool(ctx context.Context, serverName, toolName strin
 	return nil, fmt.Errorf("server %s failed after %d retries: %w", serverName, maxRetries, lastErr)
 }
 
+// acquireCallSlot blocks until fewer than config.MaxConcurrent tool calls are
+// in flight, or until ctx is done
+func (m *MCPManager) acquireCallSlot(ctx context.Context) error {
+	select {
+	case m.callSlots <- struct{}{}:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// releaseCallSlot frees a slot taken by acquireCallSlot
+func (m *MCPManager) releaseCallSlot() {
+	<-m.callSlots
+}
+
 // Health checks the health of a specific server or all servers
 func (m *MCPManager) Health(serverName string) (ServerStatus, error) {
 	m.mu.RLock()
@@ -0,0 +1,171 @@
+package report
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/pickjonathan/sdek-cli/pkg/types"
+)
+
+// DefaultBaselineFile is the default suppression baseline path (relative to the working directory)
+const DefaultBaselineFile = ".sdek-baseline.json"
+
+// Baseline lists accepted findings that should not be re-flagged on every run.
+// Entries match either a finding content hash or an entire control.
+type Baseline struct {
+	Entries []BaselineEntry `json:"entries"`
+}
+
+// BaselineEntry is a single suppression with its justification
+type BaselineEntry struct {
+	FindingHash   string    `json:"finding_hash,omitempty"`
+	FrameworkID   string    `json:"framework_id,omitempty"`
+	ControlID     string    `json:"control_id,omitempty"`
+	Justification string    `json:"justification"`
+	CreatedAt     time.Time `json:"created_at"`
+}
+
+// FindingHash returns a stable content hash for a finding.
+// Finding IDs are not stable across AI runs, so the hash covers the
+// framework, control, title, and severity instead.
+func FindingHash(finding types.Finding) string {
+	h := sha256.New()
+	h.Write([]byte(strings.ToLower(finding.FrameworkID)))
+	h.Write([]byte{0})
+	h.Write([]byte(finding.ControlID))
+	h.Write([]byte{0})
+	h.Write([]byte(finding.Title))
+	h.Write([]byte{0})
+	h.Write([]byte(finding.Severity))
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// LoadBaseline reads a baseline file. A missing file yields an empty baseline.
+func LoadBaseline(path string) (*Baseline, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return &Baseline{}, nil
+		}
+		return nil, fmt.Errorf("failed to read baseline file: %w", err)
+	}
+
+	var baseline Baseline
+	if err := json.Unmarshal(data, &baseline); err != nil {
+		return nil, fmt.Errorf("failed to parse baseline file: %w", err)
+	}
+
+	return &baseline, nil
+}
+
+// Save writes the baseline to disk
+func (b *Baseline) Save(path string) error {
+	if dir := filepath.Dir(path); dir != "" {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			return fmt.Errorf("failed to create baseline directory: %w", err)
+		}
+	}
+
+	data, err := json.MarshalIndent(b, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal baseline: %w", err)
+	}
+
+	if err := WriteFile(path, data, 0644); err != nil {
+		return fmt.Errorf("failed to write baseline file: %w", err)
+	}
+
+	return nil
+}
+
+// SuppressFinding appends a content-hash entry for the finding
+func (b *Baseline) SuppressFinding(finding types.Finding, justification string) error {
+	justification = strings.TrimSpace(justification)
+	if justification == "" {
+		return fmt.Errorf("a justification is required to suppress finding %s", finding.ID)
+	}
+
+	hash := FindingHash(finding)
+	for _, entry := range b.Entries {
+		if entry.FindingHash == hash {
+			return fmt.Errorf("finding %s is already suppressed", finding.ID)
+		}
+	}
+
+	b.Entries = append(b.Entries, BaselineEntry{
+		FindingHash:   hash,
+		FrameworkID:   finding.FrameworkID,
+		ControlID:     finding.ControlID,
+		Justification: justification,
+		CreatedAt:     time.Now(),
+	})
+	return nil
+}
+
+// SuppressControl appends an entry that suppresses all findings for a control
+func (b *Baseline) SuppressControl(frameworkID, controlID, justification string) error {
+	justification = strings.TrimSpace(justification)
+	if justification == "" {
+		return fmt.Errorf("a justification is required to suppress control %s", controlID)
+	}
+	if controlID == "" {
+		return fmt.Errorf("control ID cannot be empty")
+	}
+
+	b.Entries = append(b.Entries, BaselineEntry{
+		FrameworkID:   frameworkID,
+		ControlID:     controlID,
+		Justification: justification,
+		CreatedAt:     time.Now(),
+	})
+	return nil
+}
+
+// Match returns the baseline entry that suppresses the finding, if any
+func (b *Baseline) Match(finding types.Finding) (BaselineEntry, bool) {
+	if b == nil {
+		return BaselineEntry{}, false
+	}
+
+	hash := FindingHash(finding)
+	for _, entry := range b.Entries {
+		if entry.FindingHash != "" {
+			if entry.FindingHash == hash {
+				return entry, true
+			}
+			continue
+		}
+
+		// Control-level entry (framework is optional)
+		if entry.ControlID != finding.ControlID {
+			continue
+		}
+		if entry.FrameworkID == "" || strings.EqualFold(entry.FrameworkID, finding.FrameworkID) {
+			return entry, true
+		}
+	}
+
+	return BaselineEntry{}, false
+}
+
+// Apply marks matching findings as waived, using the entry justification as the reason.
+// Returns the number of findings suppressed.
+func (b *Baseline) Apply(findings []types.Finding) int {
+	suppressed := 0
+	for i := range findings {
+		entry, ok := b.Match(findings[i])
+		if !ok {
+			continue
+		}
+		findings[i].Status = types.StatusWaived
+		findings[i].StatusReason = entry.Justification
+		suppressed++
+	}
+	return suppressed
+}
@@ -0,0 +1,150 @@
+package report
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/pickjonathan/sdek-cli/pkg/types"
+)
+
+// TestBaselineSuppressedFindingSkipsGate verifies a suppressed finding no longer fails the gate
+func TestBaselineSuppressedFindingSkipsGate(t *testing.T) {
+	findings := []types.Finding{
+		{ID: "f-1", ControlID: "CC6.1", FrameworkID: types.FrameworkSOC2, Title: "Moderate Risk", Severity: types.SeverityHigh, Status: types.StatusOpen},
+		{ID: "f-2", ControlID: "CC6.2", FrameworkID: types.FrameworkSOC2, Title: "Moderate Risk", Severity: types.SeverityMedium, Status: types.StatusOpen},
+	}
+
+	blocking, err := CheckFailOn(findings, types.SeverityHigh)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(blocking) != 1 {
+		t.Fatalf("Expected 1 blocking finding before suppression, got %d", len(blocking))
+	}
+
+	baseline := &Baseline{}
+	if err := baseline.SuppressFinding(findings[0], "Accepted risk"); err != nil {
+		t.Fatalf("Failed to suppress finding: %v", err)
+	}
+
+	exporter := NewExporter("1.0.0")
+	exporter.SetBaseline(baseline)
+
+	report, err := exporter.GenerateReport(nil, nil, nil, nil, nil, findings, "")
+	if err != nil {
+		t.Fatalf("Failed to generate report: %v", err)
+	}
+
+	if report.Findings[0].Status != types.StatusWaived {
+		t.Errorf("Expected suppressed finding to be waived, got %s", report.Findings[0].Status)
+	}
+	if report.Findings[0].StatusReason != "Accepted risk" {
+		t.Errorf("Expected justification as status reason, got %q", report.Findings[0].StatusReason)
+	}
+	if report.Findings[1].Status != types.StatusOpen {
+		t.Errorf("Expected unrelated finding to stay open, got %s", report.Findings[1].Status)
+	}
+	if findings[0].Status != types.StatusOpen {
+		t.Error("Expected caller findings to be left unchanged")
+	}
+
+	blocking, err = CheckFailOn(report.Findings, types.SeverityHigh)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(blocking) != 0 {
+		t.Errorf("Expected suppressed finding to be skipped by the gate, got %d blocking", len(blocking))
+	}
+}
+
+// TestBaselineMatching verifies hash and control-level suppression
+func TestBaselineMatching(t *testing.T) {
+	finding := types.Finding{ID: "finding-123", ControlID: "CC6.1", FrameworkID: "SOC2", Title: "Access Review", Severity: types.SeverityHigh}
+
+	t.Run("hash ignores finding ID", func(t *testing.T) {
+		baseline := &Baseline{}
+		if err := baseline.SuppressFinding(finding, "Accepted"); err != nil {
+			t.Fatalf("Failed to suppress: %v", err)
+		}
+
+		rerun := finding
+		rerun.ID = "finding-456"
+		if _, ok := baseline.Match(rerun); !ok {
+			t.Error("Expected re-run finding with new ID to match")
+		}
+
+		escalated := finding
+		escalated.Severity = types.SeverityCritical
+		if _, ok := baseline.Match(escalated); ok {
+			t.Error("Expected finding with changed severity not to match")
+		}
+	})
+
+	t.Run("control entry matches any finding for the control", func(t *testing.T) {
+		baseline := &Baseline{}
+		if err := baseline.SuppressControl("soc2", "CC6.1", "Out of scope"); err != nil {
+			t.Fatalf("Failed to suppress control: %v", err)
+		}
+
+		other := finding
+		other.Title = "Different issue"
+		if _, ok := baseline.Match(other); !ok {
+			t.Error("Expected control-level entry to match")
+		}
+
+		other.ControlID = "CC6.2"
+		if _, ok := baseline.Match(other); ok {
+			t.Error("Expected different control not to match")
+		}
+	})
+
+	t.Run("justification required", func(t *testing.T) {
+		baseline := &Baseline{}
+		if err := baseline.SuppressFinding(finding, " "); err == nil {
+			t.Error("Expected error without justification")
+		}
+		if err := baseline.SuppressControl("SOC2", "CC6.1", ""); err == nil {
+			t.Error("Expected error without justification")
+		}
+	})
+
+	t.Run("duplicate suppression rejected", func(t *testing.T) {
+		baseline := &Baseline{}
+		_ = baseline.SuppressFinding(finding, "Accepted")
+		if err := baseline.SuppressFinding(finding, "Accepted again"); err == nil {
+			t.Error("Expected error for duplicate suppression")
+		}
+	})
+}
+
+// TestBaselineSaveLoad verifies baseline persistence
+func TestBaselineSaveLoad(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "nested", "baseline.json")
+
+	missing, err := LoadBaseline(path)
+	if err != nil {
+		t.Fatalf("Expected missing baseline to load empty, got: %v", err)
+	}
+	if len(missing.Entries) != 0 {
+		t.Errorf("Expected empty baseline, got %d entries", len(missing.Entries))
+	}
+
+	finding := types.Finding{ID: "f-1", ControlID: "CC6.1", FrameworkID: "SOC2", Title: "Gap", Severity: types.SeverityLow}
+	if err := missing.SuppressFinding(finding, "Accepted"); err != nil {
+		t.Fatalf("Failed to suppress: %v", err)
+	}
+	if err := missing.Save(path); err != nil {
+		t.Fatalf("Failed to save baseline: %v", err)
+	}
+
+	loaded, err := LoadBaseline(path)
+	if err != nil {
+		t.Fatalf("Failed to load baseline: %v", err)
+	}
+	if len(loaded.Entries) != 1 {
+		t.Fatalf("Expected 1 entry, got %d", len(loaded.Entries))
+	}
+	if loaded.Entries[0].FindingHash != FindingHash(finding) {
+		t.Error("Expected persisted hash to match finding")
+	}
+}
@@ -0,0 +1,95 @@
+package report
+
+import (
+	"github.com/pickjonathan/sdek-cli/pkg/types"
+)
+
+// FilterByConfidence returns a copy of the report without evidence and AI findings
+// whose confidence is below min (0-1). Evidence scores are on a 0-100 scale and are
+// compared against min*100. Heuristic findings carry no confidence score and are kept.
+// Summary totals, severity counts, and weighted compliance are recomputed.
+//
+// The result can be passed to Formatter.FilterByRole; apply the confidence filter
+// first so the summary reflects the full data set.
+func FilterByConfidence(report *Report, min float64) *Report {
+	return filterReport(report,
+		func(ev types.Evidence) bool { return ev.ConfidenceScore >= min*100 },
+		func(finding types.Finding) bool { return meetsConfidence(finding, min) })
+}
+
+// filterReport returns a copy of the report with only the evidence and findings
+// the keep functions accept, recomputing summary totals, severity counts and
+// weighted compliance
+func filterReport(report *Report, keepEvidence func(types.Evidence) bool, keepFinding func(types.Finding) bool) *Report {
+	filtered := &Report{
+		Metadata: report.Metadata,
+		Summary:  report.Summary,
+		Sources:  report.Sources,
+		Events:   report.Events,
+		weights:  report.weights,
+
+		timelinePeriod: report.timelinePeriod,
+	}
+
+	if report.Findings != nil {
+		filtered.Findings = make([]types.Finding, 0, len(report.Findings))
+		for _, finding := range report.Findings {
+			if keepFinding(finding) {
+				filtered.Findings = append(filtered.Findings, finding)
+			}
+		}
+	}
+
+	eventTimes := eventTimestamps(report.Events)
+
+	// Filter evidence and findings within each control
+	filtered.Frameworks = make([]FrameworkReport, 0, len(report.Frameworks))
+	for _, fw := range report.Frameworks {
+		var controls []ControlReport
+		if fw.Controls != nil {
+			controls = make([]ControlReport, 0, len(fw.Controls))
+		}
+
+		for _, ctrl := range fw.Controls {
+			var evidence []types.Evidence
+			for _, ev := range ctrl.Evidence {
+				if keepEvidence(ev) {
+					evidence = append(evidence, ev)
+				} else {
+					filtered.Summary.TotalEvidence--
+				}
+			}
+
+			var findings []types.Finding
+			for _, finding := range ctrl.Findings {
+				if keepFinding(finding) {
+					findings = append(findings, finding)
+				}
+			}
+
+			controls = append(controls, ControlReport{
+				Control:  ctrl.Control,
+				Evidence: evidence,
+				Findings: findings,
+				Timeline: controlTimeline(evidence, eventTimes, report.timelinePeriod),
+			})
+		}
+
+		filtered.Frameworks = append(filtered.Frameworks, FrameworkReport{
+			Framework:          fw.Framework,
+			WeightedCompliance: fw.WeightedCompliance,
+			Controls:           controls,
+		})
+	}
+
+	recomputeSummary(filtered)
+	return filtered
+}
+
+// meetsConfidence reports whether a finding should be kept at the given minimum confidence
+func meetsConfidence(finding types.Finding, min float64) bool {
+	if finding.Mode != "ai" {
+		return true
+	}
+	return finding.ConfidenceScore >= min
+}
@@ -0,0 +1,101 @@
+package report
+
+import (
+	"testing"
+
+	"github.com/pickjonathan/sdek-cli/pkg/types"
+)
+
+// confidenceTestReport builds a report with mixed-confidence evidence and findings
+func confidenceTestReport(t *testing.T) *Report {
+	t.Helper()
+
+	frameworks := []types.Framework{{ID: "soc2", Name: "SOC 2"}}
+	controls := []types.Control{
+		{ID: "CC6.1", FrameworkID: "soc2", RiskStatus: "red"},
+		{ID: "CC6.2", FrameworkID: "soc2", RiskStatus: "green"},
+	}
+	evidence := []types.Evidence{
+		{ID: "ev-1", ControlID: "CC6.1", FrameworkID: "soc2", ConfidenceScore: 90},
+		{ID: "ev-2", ControlID: "CC6.1", FrameworkID: "soc2", ConfidenceScore: 40},
+		{ID: "ev-3", ControlID: "CC6.2", FrameworkID: "soc2", ConfidenceScore: 70},
+	}
+	findings := []types.Finding{
+		{ID: "f-1", ControlID: "CC6.1", FrameworkID: "soc2", Severity: types.SeverityHigh, Status: types.StatusOpen, Mode: "ai", ConfidenceScore: 0.9},
+		{ID: "f-2", ControlID: "CC6.1", FrameworkID: "soc2", Severity: types.SeverityCritical, Status: types.StatusOpen, Mode: "ai", ConfidenceScore: 0.3},
+		{ID: "f-3", ControlID: "CC6.2", FrameworkID: "soc2", Severity: types.SeverityLow, Status: types.StatusOpen},
+	}
+
+	report, err := NewExporter("1.0.0").GenerateReport(nil, nil, frameworks, controls, evidence, findings, "")
+	if err != nil {
+		t.Fatalf("GenerateReport failed: %v", err)
+	}
+	return report
+}
+
+// TestFilterByConfidence verifies items below the threshold are removed and totals update
+func TestFilterByConfidence(t *testing.T) {
+	original := confidenceTestReport(t)
+	filtered := FilterByConfidence(original, 0.6)
+
+	if filtered.Summary.TotalEvidence != 2 {
+		t.Errorf("Expected 2 evidence, got %d", filtered.Summary.TotalEvidence)
+	}
+	if filtered.Summary.TotalFindings != 2 {
+		t.Errorf("Expected 2 findings, got %d", filtered.Summary.TotalFindings)
+	}
+	if filtered.Summary.CriticalFindings != 0 {
+		t.Errorf("Expected 0 critical findings, got %d", filtered.Summary.CriticalFindings)
+	}
+	if filtered.Summary.HighFindings != 1 {
+		t.Errorf("Expected 1 high finding, got %d", filtered.Summary.HighFindings)
+	}
+
+	// Heuristic finding without a confidence score is kept
+	ids := make(map[string]bool)
+	for _, f := range filtered.Findings {
+		ids[f.ID] = true
+	}
+	if !ids["f-1"] || !ids["f-3"] || ids["f-2"] {
+		t.Errorf("Unexpected findings after filtering: %v", ids)
+	}
+
+	ctrl := filtered.Frameworks[0].Controls[0]
+	if len(ctrl.Evidence) != 1 || ctrl.Evidence[0].ID != "ev-1" {
+		t.Errorf("Expected only ev-1 for CC6.1, got %v", ctrl.Evidence)
+	}
+	if len(ctrl.Findings) != 1 || ctrl.Findings[0].ID != "f-1" {
+		t.Errorf("Expected only f-1 for CC6.1, got %v", ctrl.Findings)
+	}
+
+	// Removing the critical finding raises weighted compliance
+	if filtered.Summary.WeightedCompliance <= original.Summary.WeightedCompliance {
+		t.Errorf("Expected weighted compliance to increase, got %.2f (was %.2f)",
+			filtered.Summary.WeightedCompliance, original.Summary.WeightedCompliance)
+	}
+	if filtered.Frameworks[0].WeightedCompliance != filtered.Summary.WeightedCompliance {
+		t.Errorf("Expected framework weighted compliance %.2f, got %.2f",
+			filtered.Summary.WeightedCompliance, filtered.Frameworks[0].WeightedCompliance)
+	}
+
+	// Original report is untouched
+	if original.Summary.TotalFindings != 3 || len(original.Findings) != 3 {
+		t.Error("Expected original report to be unchanged")
+	}
+}
+
+// TestFilterByConfidenceWithRole verifies confidence filtering composes with role filtering
+func TestFilterByConfidenceWithRole(t *testing.T) {
+	formatter := NewFormatter()
+	filtered := formatter.FilterByRole(FilterByConfidence(confidenceTestReport(t), 0.6), types.RoleEngineer)
+
+	if len(filtered.Findings) != 1 || filtered.Findings[0].ID != "f-1" {
+		t.Errorf("Expected only f-1 in engineer view, got %v", filtered.Findings)
+	}
+	if filtered.Summary.TotalFindings != 2 {
+		t.Errorf("Expected summary to reflect confidence filter, got %d findings", filtered.Summary.TotalFindings)
+	}
+	if filtered.Metadata.Role != types.RoleEngineer {
+		t.Errorf("Expected role %s, got %s", types.RoleEngineer, filtered.Metadata.Role)
+	}
+}
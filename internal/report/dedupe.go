@@ -0,0 +1,89 @@
+package report
+
+import (
+	"github.com/pickjonathan/sdek-cli/pkg/types"
+)
+
+// DedupeFindings collapses findings that describe the same gap: findings with the
+// same control ID that cite at least one common event are merged into one. The
+// merged finding keeps the fields of the highest-confidence duplicate, with the
+// citations and mapped controls of all duplicates combined. Findings without
+// citations are never merged. Order follows each group's first occurrence.
+func DedupeFindings(findings []types.Finding) []types.Finding {
+	type group struct {
+		best      types.Finding
+		citations []string
+		controls  []string
+		cited     map[string]bool
+		merged    bool
+	}
+
+	var groups []*group
+	for _, finding := range findings {
+		var match *group
+		for _, g := range groups {
+			if g.best.ControlID == finding.ControlID && citesAny(g.cited, finding.Citations) {
+				match = g
+				break
+			}
+		}
+
+		if match == nil {
+			g := &group{best: finding, cited: make(map[string]bool)}
+			g.citations = unionStrings(nil, finding.Citations)
+			g.controls = unionStrings(nil, finding.MappedControls)
+			for _, id := range finding.Citations {
+				g.cited[id] = true
+			}
+			groups = append(groups, g)
+			continue
+		}
+
+		match.merged = true
+		if finding.ConfidenceScore > match.best.ConfidenceScore {
+			match.best = finding
+		}
+		match.citations = unionStrings(match.citations, finding.Citations)
+		match.controls = unionStrings(match.controls, finding.MappedControls)
+		for _, id := range finding.Citations {
+			match.cited[id] = true
+		}
+	}
+
+	deduped := make([]types.Finding, len(groups))
+	for i, g := range groups {
+		deduped[i] = g.best
+		if g.merged {
+			deduped[i].Citations = g.citations
+			deduped[i].MappedControls = g.controls
+		}
+	}
+	return deduped
+}
+
+// citesAny reports whether any of citations is in cited
+func citesAny(cited map[string]bool, citations []string) bool {
+	for _, id := range citations {
+		if cited[id] {
+			return true
+		}
+	}
+	return false
+}
+
+// unionStrings appends the values not already in list, preserving order
+func unionStrings(list, values []string) []string {
+	for _, v := range values {
+		found := false
+		for _, existing := range list {
+			if existing == v {
+				found = true
+				break
+			}
+		}
+		if !found {
+			list = append(list, v)
+		}
+	}
+	return list
+}
@@ -0,0 +1,71 @@
+package report
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/pickjonathan/sdek-cli/pkg/types"
+)
+
+// TestDedupeFindingsMergesNearDuplicates verifies findings for the same control with shared citations are merged
+func TestDedupeFindingsMergesNearDuplicates(t *testing.T) {
+	findings := []types.Finding{
+		{ID: "f-1", ControlID: "CC6.1", Summary: "MFA partially enforced", ConfidenceScore: 0.6, Citations: []string{"evt-1", "evt-2"}, MappedControls: []string{"CC6.1"}},
+		{ID: "f-2", ControlID: "CC6.1", Summary: "MFA enforced for admins", ConfidenceScore: 0.9, Citations: []string{"evt-2", "evt-3"}, MappedControls: []string{"CC6.1", "CC6.2"}},
+	}
+
+	deduped := DedupeFindings(findings)
+
+	if len(deduped) != 1 {
+		t.Fatalf("Expected 1 finding after dedupe, got %d", len(deduped))
+	}
+	merged := deduped[0]
+	if merged.ID != "f-2" || merged.Summary != "MFA enforced for admins" {
+		t.Errorf("Expected the highest-confidence finding to be kept, got %s (%q)", merged.ID, merged.Summary)
+	}
+	if want := []string{"evt-1", "evt-2", "evt-3"}; !reflect.DeepEqual(merged.Citations, want) {
+		t.Errorf("Expected citations %v, got %v", want, merged.Citations)
+	}
+	if want := []string{"CC6.1", "CC6.2"}; !reflect.DeepEqual(merged.MappedControls, want) {
+		t.Errorf("Expected mapped controls %v, got %v", want, merged.MappedControls)
+	}
+	if !reflect.DeepEqual(findings[1].Citations, []string{"evt-2", "evt-3"}) {
+		t.Error("Expected input findings to be left unchanged")
+	}
+}
+
+// TestDedupeFindingsKeepsDistinctFindings verifies unrelated findings are not merged
+func TestDedupeFindingsKeepsDistinctFindings(t *testing.T) {
+	findings := []types.Finding{
+		{ID: "f-1", ControlID: "CC6.1", Citations: []string{"evt-1"}},
+		{ID: "f-2", ControlID: "CC6.1", Citations: []string{"evt-2"}},       // same control, no shared citation
+		{ID: "f-3", ControlID: "CC7.2", Citations: []string{"evt-1"}},       // shared citation, different control
+		{ID: "f-4", ControlID: "CC6.1", Citations: []string{}},              // no citations
+		{ID: "f-5", ControlID: "CC6.1", Citations: nil, Summary: "uncited"}, // no citations
+	}
+
+	deduped := DedupeFindings(findings)
+
+	if !reflect.DeepEqual(deduped, findings) {
+		t.Errorf("Expected distinct findings to be left intact, got %+v", deduped)
+	}
+}
+
+// TestDedupeFindingsMergesChains verifies a finding overlapping an already merged group joins it
+func TestDedupeFindingsMergesChains(t *testing.T) {
+	findings := []types.Finding{
+		{ID: "f-1", ControlID: "CC6.1", ConfidenceScore: 0.5, Citations: []string{"evt-1"}},
+		{ID: "f-2", ControlID: "CC7.2", ConfidenceScore: 0.7, Citations: []string{"evt-9"}},
+		{ID: "f-3", ControlID: "CC6.1", ConfidenceScore: 0.8, Citations: []string{"evt-1", "evt-2"}},
+		{ID: "f-4", ControlID: "CC6.1", ConfidenceScore: 0.4, Citations: []string{"evt-2"}},
+	}
+
+	deduped := DedupeFindings(findings)
+
+	if len(deduped) != 2 {
+		t.Fatalf("Expected 2 findings after dedupe, got %d", len(deduped))
+	}
+	if deduped[0].ID != "f-3" || deduped[1].ID != "f-2" {
+		t.Errorf("Expected groups in first-occurrence order [f-3 f-2], got [%s %s]", deduped[0].ID, deduped[1].ID)
+	}
+}
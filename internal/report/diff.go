@@ -0,0 +1,220 @@
+package report
+
+import (
+	"reflect"
+
+	"github.com/pickjonathan/sdek-cli/pkg/types"
+)
+
+// Controls returns the controls assessed in the report, in report order
+func Controls(report *Report) []ControlRef {
+	var refs []ControlRef
+	for _, fw := range report.Frameworks {
+		for _, ctrl := range fw.Controls {
+			refs = append(refs, ControlRef{FrameworkID: fw.Framework.ID, ControlID: ctrl.Control.ID})
+		}
+	}
+	return refs
+}
+
+// ReplaceFindings returns a copy of the report in which every control with a
+// finding in findings has its findings replaced by them, e.g. after analyzing
+// the control again with fresh evidence. Findings belong to a control when the
+// control ID matches and the framework names the same framework (see
+// types.SameFramework); they take the report's framework ID, and findings for
+// controls not in the report are ignored. Summary finding counts and weighted
+// compliance are recomputed, while control risk statuses and evidence are kept.
+// The copy is unsigned, since its content no longer matches any signature.
+func ReplaceFindings(report *Report, findings []types.Finding) *Report {
+	// Key the new findings by the report control they belong to
+	replacements := make(map[string][]types.Finding)
+	for _, ref := range Controls(report) {
+		for _, finding := range findings {
+			if finding.ControlID == ref.ControlID && types.SameFramework(finding.FrameworkID, ref.FrameworkID) {
+				finding.FrameworkID = ref.FrameworkID
+				key := controlKey(ref.FrameworkID, ref.ControlID)
+				replacements[key] = append(replacements[key], finding)
+			}
+		}
+	}
+
+	updated := filterReport(report,
+		func(types.Evidence) bool { return true },
+		func(finding types.Finding) bool {
+			_, replaced := replacements[controlKey(finding.FrameworkID, finding.ControlID)]
+			return !replaced
+		})
+	updated.Metadata.Integrity = nil
+
+	for i := range updated.Frameworks {
+		fw := &updated.Frameworks[i]
+		for j := range fw.Controls {
+			ctrl := &fw.Controls[j]
+			added := replacements[controlKey(fw.Framework.ID, ctrl.Control.ID)]
+			ctrl.Findings = append(ctrl.Findings, added...)
+			if updated.Findings != nil {
+				updated.Findings = append(updated.Findings, added...)
+			}
+		}
+	}
+
+	recomputeSummary(updated)
+	return updated
+}
+
+// Kinds of FindingChange
+const (
+	ChangeAdded   = "added"
+	ChangeRemoved = "removed"
+	ChangeChanged = "changed"
+)
+
+// FindingChange is a finding that was added, removed or changed between two reports
+type FindingChange struct {
+	FrameworkID string         `json:"framework_id"`
+	ControlID   string         `json:"control_id"`
+	Change      string         `json:"change"`           // ChangeAdded, ChangeRemoved or ChangeChanged
+	Fields      []string       `json:"fields,omitempty"` // JSON names of the fields that changed
+	Old         *types.Finding `json:"old,omitempty"`
+	New         *types.Finding `json:"new,omitempty"`
+}
+
+// ReportDiff lists how the findings and compliance of a report changed
+type ReportDiff struct {
+	Changes   []FindingChange `json:"changes"`
+	Unchanged int             `json:"unchanged"` // Findings present in both reports with no changed fields
+
+	OldWeightedCompliance float64 `json:"old_weighted_compliance_percentage"`
+	NewWeightedCompliance float64 `json:"new_weighted_compliance_percentage"`
+}
+
+// diffFields are the finding fields Diff compares, by JSON name. Free text
+// such as the summary is left out, since every analysis words it differently.
+var diffFields = []struct {
+	name  string
+	value func(types.Finding) any
+}{
+	{"severity", func(f types.Finding) any { return f.Severity }},
+	{"status", func(f types.Finding) any { return f.Status }},
+	{"confidence_score", func(f types.Finding) any { return f.ConfidenceScore }},
+	{"residual_risk", func(f types.Finding) any { return f.ResidualRisk }},
+	{"review_required", func(f types.Finding) any { return f.ReviewRequired }},
+	{"citations", func(f types.Finding) any { return f.Citations }},
+}
+
+// Diff compares the findings of two reports control by control. Findings with
+// the same ID are compared with each other; the remaining findings of a control
+// are paired in order, so a control re-analyzed into a finding with a new ID
+// shows as changed rather than as one removal and one addition. Changes are
+// listed in the order of the controls in old, then of controls only in updated.
+func Diff(old, updated *Report) *ReportDiff {
+	diff := &ReportDiff{
+		Changes:               []FindingChange{},
+		OldWeightedCompliance: old.Summary.WeightedCompliance,
+		NewWeightedCompliance: updated.Summary.WeightedCompliance,
+	}
+
+	oldFindings, order := findingsByControl(old, nil)
+	newFindings, order := findingsByControl(updated, order)
+
+	for _, key := range order {
+		before, after := oldFindings[key], newFindings[key]
+
+		// Pair findings by ID first, then the rest in order
+		var pairs [][2]*types.Finding
+		matched := make(map[int]bool)
+		var unmatched, added []*types.Finding
+		for i := range before {
+			j := indexOfFinding(after, before[i].ID, matched)
+			if j < 0 {
+				unmatched = append(unmatched, &before[i])
+				continue
+			}
+			matched[j] = true
+			pairs = append(pairs, [2]*types.Finding{&before[i], &after[j]})
+		}
+		for j := range after {
+			if matched[j] {
+				continue
+			}
+			if len(unmatched) > 0 {
+				pairs = append(pairs, [2]*types.Finding{unmatched[0], &after[j]})
+				unmatched = unmatched[1:]
+				continue
+			}
+			added = append(added, &after[j])
+		}
+
+		for _, pair := range pairs {
+			fields := changedFields(*pair[0], *pair[1])
+			if len(fields) == 0 {
+				diff.Unchanged++
+				continue
+			}
+			diff.Changes = append(diff.Changes, FindingChange{
+				FrameworkID: pair[1].FrameworkID,
+				ControlID:   pair[1].ControlID,
+				Change:      ChangeChanged,
+				Fields:      fields,
+				Old:         pair[0],
+				New:         pair[1],
+			})
+		}
+		for _, finding := range added {
+			diff.Changes = append(diff.Changes, FindingChange{FrameworkID: finding.FrameworkID, ControlID: finding.ControlID, Change: ChangeAdded, New: finding})
+		}
+		for _, finding := range unmatched {
+			diff.Changes = append(diff.Changes, FindingChange{FrameworkID: finding.FrameworkID, ControlID: finding.ControlID, Change: ChangeRemoved, Old: finding})
+		}
+	}
+
+	return diff
+}
+
+// findingsByControl groups the report's control findings by control, appending
+// controls not yet in order to it
+func findingsByControl(report *Report, order []string) (map[string][]types.Finding, []string) {
+	seen := make(map[string]bool, len(order))
+	for _, key := range order {
+		seen[key] = true
+	}
+
+	findings := make(map[string][]types.Finding)
+	for _, fw := range report.Frameworks {
+		for _, ctrl := range fw.Controls {
+			key := controlKey(fw.Framework.ID, ctrl.Control.ID)
+			findings[key] = append(findings[key], ctrl.Findings...)
+			if !seen[key] {
+				seen[key] = true
+				order = append(order, key)
+			}
+		}
+	}
+	return findings, order
+}
+
+// indexOfFinding returns the index of the unmatched finding with the given ID, or -1
+func indexOfFinding(findings []types.Finding, id string, matched map[int]bool) int {
+	for i, finding := range findings {
+		if !matched[i] && finding.ID == id {
+			return i
+		}
+	}
+	return -1
+}
+
+// changedFields returns the JSON names of the diffFields that differ between before and after
+func changedFields(before, after types.Finding) []string {
+	var fields []string
+	for _, field := range diffFields {
+		if !reflect.DeepEqual(field.value(before), field.value(after)) {
+			fields = append(fields, field.name)
+		}
+	}
+	return fields
+}
+
+// controlKey identifies a control across frameworks, as in calculateWeightedCompliance
+func controlKey(frameworkID, controlID string) string {
+	return frameworkID + ":" + controlID
+}
@@ -0,0 +1,145 @@
+package report
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/pickjonathan/sdek-cli/pkg/types"
+)
+
+// TestControls verifies controls are listed in report order
+func TestControls(t *testing.T) {
+	got := Controls(reviewTestReport(t))
+	want := []ControlRef{
+		{FrameworkID: "soc2", ControlID: "CC6.1"},
+		{FrameworkID: "soc2", ControlID: "CC6.2"},
+		{FrameworkID: "iso27001", ControlID: "A.9.4.2"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Controls() = %+v, want %+v", got, want)
+	}
+}
+
+// reanalyzedFinding is an AI finding for CC6.1 as analyze-all returns it,
+// with the framework named as in the excerpts file
+func reanalyzedFinding() types.Finding {
+	return types.Finding{
+		ID:              "finding-new",
+		ControlID:       "CC6.1",
+		FrameworkID:     "SOC2",
+		Severity:        types.SeverityLow,
+		Status:          types.StatusOpen,
+		ConfidenceScore: 0.9,
+		ResidualRisk:    "low",
+		Citations:       []string{"evt-9"},
+		Mode:            "ai",
+	}
+}
+
+// TestReplaceFindings verifies re-analyzed controls get only their new findings
+func TestReplaceFindings(t *testing.T) {
+	report := reviewTestReport(t)
+	unknown := reanalyzedFinding()
+	unknown.ControlID = "CC9.9"
+
+	updated := ReplaceFindings(report, []types.Finding{reanalyzedFinding(), unknown})
+
+	cc61 := updated.Frameworks[0].Controls[0]
+	if len(cc61.Findings) != 1 || cc61.Findings[0].ID != "finding-new" {
+		t.Fatalf("Expected CC6.1 to hold only the new finding, got %+v", cc61.Findings)
+	}
+	if cc61.Findings[0].FrameworkID != "soc2" {
+		t.Errorf("Expected the new finding to take the report framework ID, got %q", cc61.Findings[0].FrameworkID)
+	}
+	if len(updated.Frameworks[0].Controls[1].Findings) != 1 || len(updated.Frameworks[1].Controls[0].Findings) != 1 {
+		t.Errorf("Expected other controls to keep their findings")
+	}
+
+	var ids []string
+	for _, finding := range updated.Findings {
+		ids = append(ids, finding.ID)
+	}
+	if !reflect.DeepEqual(ids, []string{"f-3", "f-4", "finding-new"}) {
+		t.Errorf("Expected top-level findings f-3, f-4, finding-new, got %v", ids)
+	}
+
+	summary := updated.Summary
+	if summary.TotalFindings != 3 || summary.HighFindings != 0 || summary.LowFindings != 1 || summary.MediumFindings != 1 || summary.CriticalFindings != 1 {
+		t.Errorf("Unexpected finding counts: %+v", summary)
+	}
+	if summary.TotalControls != 3 || summary.TotalEvidence != 4 {
+		t.Errorf("Expected controls and evidence to be kept, got %+v", summary)
+	}
+	if summary.WeightedCompliance <= report.Summary.WeightedCompliance {
+		t.Errorf("Expected a low finding in place of high and low ones to raise weighted compliance, got %.1f from %.1f", summary.WeightedCompliance, report.Summary.WeightedCompliance)
+	}
+
+	// The original report is unchanged
+	if len(report.Findings) != 4 || len(report.Frameworks[0].Controls[0].Findings) != 2 {
+		t.Errorf("Original report modified")
+	}
+}
+
+// TestDiff verifies a re-analyzed control shows as changed and removed findings as removed
+func TestDiff(t *testing.T) {
+	report := reviewTestReport(t)
+	updated := ReplaceFindings(report, []types.Finding{reanalyzedFinding()})
+
+	diff := Diff(report, updated)
+
+	if diff.Unchanged != 2 {
+		t.Errorf("Expected f-3 and f-4 unchanged, got %d", diff.Unchanged)
+	}
+	if len(diff.Changes) != 2 {
+		t.Fatalf("Expected 2 changes, got %+v", diff.Changes)
+	}
+
+	changed := diff.Changes[0]
+	if changed.Change != ChangeChanged || changed.ControlID != "CC6.1" || changed.Old.ID != "f-1" || changed.New.ID != "finding-new" {
+		t.Errorf("Expected f-1 changed into finding-new, got %+v", changed)
+	}
+	wantFields := []string{"severity", "confidence_score", "residual_risk", "review_required", "citations"}
+	if !reflect.DeepEqual(changed.Fields, wantFields) {
+		t.Errorf("Changed fields = %v, want %v", changed.Fields, wantFields)
+	}
+
+	removed := diff.Changes[1]
+	if removed.Change != ChangeRemoved || removed.Old.ID != "f-2" || removed.New != nil {
+		t.Errorf("Expected f-2 removed, got %+v", removed)
+	}
+
+	if diff.OldWeightedCompliance != report.Summary.WeightedCompliance || diff.NewWeightedCompliance != updated.Summary.WeightedCompliance {
+		t.Errorf("Unexpected compliance: %.1f -> %.1f", diff.OldWeightedCompliance, diff.NewWeightedCompliance)
+	}
+}
+
+// TestDiff_AddedFindings verifies new findings beyond a control's old ones are added
+func TestDiff_AddedFindings(t *testing.T) {
+	report := reviewTestReport(t)
+	finding := reanalyzedFinding()
+	finding.ControlID = "CC6.2"
+	extra := reanalyzedFinding()
+	extra.ID = "finding-extra"
+	extra.ControlID = "CC6.2"
+
+	diff := Diff(report, ReplaceFindings(report, []types.Finding{finding, extra}))
+
+	var kinds []string
+	for _, change := range diff.Changes {
+		kinds = append(kinds, change.ControlID+" "+change.Change)
+	}
+	if !reflect.DeepEqual(kinds, []string{"CC6.2 changed", "CC6.2 added"}) {
+		t.Errorf("Changes = %v, want CC6.2 changed then added", kinds)
+	}
+}
+
+// TestDiff_Identical verifies identical reports have no changes
+func TestDiff_Identical(t *testing.T) {
+	report := reviewTestReport(t)
+
+	diff := Diff(report, report)
+
+	if len(diff.Changes) != 0 || diff.Unchanged != 4 {
+		t.Errorf("Expected 4 unchanged findings and no changes, got %+v", diff)
+	}
+}
@@ -4,7 +4,6 @@ import (
 	"encoding/json"
 	"fmt"
 	"os"
-	"path/filepath"
 	"time"
 
 	"github.com/pickjonathan/sdek-cli/pkg/types"
@@ -18,34 +17,53 @@ type Report struct {
 	Sources    []types.Source    `json:"sources,omitempty"`
 	Events     []types.Event     `json:"events,omitempty"`
 	Findings   []types.Finding   `json:"findings,omitempty"`
+
+	// weights used to compute weighted compliance, kept for recalculation after filtering
+	weights types.SeverityWeights
+
+	// timelinePeriod groups control evidence timelines, kept for recalculation after filtering
+	timelinePeriod EvidencePeriod
+
+	// original is the JSON the report was loaded from before migration, which
+	// its integrity hash covers (see LoadReport)
+	original []byte
 }
 
 // ReportMetadata contains report generation information
 type ReportMetadata struct {
-	GeneratedAt time.Time `json:"generated_at"`
-	Version     string    `json:"version"`
-	Role        string    `json:"role,omitempty"`
+	GeneratedAt   time.Time `json:"generated_at"`
+	Version       string    `json:"version"`
+	SchemaVersion int       `json:"schema_version"` // Output format version, see Migrate
+	Role          string    `json:"role,omitempty"`
+
+	// FrameworkVersions lists the framework versions pinned in config (frameworks.versions)
+	FrameworkVersions map[string]string `json:"framework_versions,omitempty"`
+
+	// Integrity is set by Sign and checked by Verify
+	Integrity *ReportIntegrity `json:"integrity,omitempty"`
 }
 
 // ReportSummary contains high-level statistics
 type ReportSummary struct {
-	TotalSources      int     `json:"total_sources"`
-	TotalEvents       int     `json:"total_events"`
-	TotalFrameworks   int     `json:"total_frameworks"`
-	TotalControls     int     `json:"total_controls"`
-	TotalEvidence     int     `json:"total_evidence"`
-	TotalFindings     int     `json:"total_findings"`
-	OverallCompliance float64 `json:"overall_compliance_percentage"`
-	CriticalFindings  int     `json:"critical_findings"`
-	HighFindings      int     `json:"high_findings"`
-	MediumFindings    int     `json:"medium_findings"`
-	LowFindings       int     `json:"low_findings"`
+	TotalSources       int     `json:"total_sources"`
+	TotalEvents        int     `json:"total_events"`
+	TotalFrameworks    int     `json:"total_frameworks"`
+	TotalControls      int     `json:"total_controls"`
+	TotalEvidence      int     `json:"total_evidence"`
+	TotalFindings      int     `json:"total_findings"`
+	OverallCompliance  float64 `json:"overall_compliance_percentage"`
+	WeightedCompliance float64 `json:"weighted_compliance_percentage"`
+	CriticalFindings   int     `json:"critical_findings"`
+	HighFindings       int     `json:"high_findings"`
+	MediumFindings     int     `json:"medium_findings"`
+	LowFindings        int     `json:"low_findings"`
 }
 
 // FrameworkReport contains framework-specific analysis
 type FrameworkReport struct {
-	Framework types.Framework `json:"framework"`
-	Controls  []ControlReport `json:"controls"`
+	Framework          types.Framework `json:"framework"`
+	WeightedCompliance float64         `json:"weighted_compliance_percentage"`
+	Controls           []ControlReport `json:"controls"`
 }
 
 // ControlReport contains control-specific details
@@ -53,17 +71,71 @@ type ControlReport struct {
 	Control  types.Control    `json:"control"`
 	Evidence []types.Evidence `json:"evidence"`
 	Findings []types.Finding  `json:"findings"`
+
+	// Timeline groups the evidence by period when it spans more than one
+	// (see GroupEvidenceByPeriod); the evidence list stays flat
+	Timeline []EvidenceBucket `json:"timeline,omitempty"`
 }
 
 // Exporter generates compliance reports
 type Exporter struct {
-	version string
+	version           string
+	weights           types.SeverityWeights
+	baseline          *Baseline
+	frameworkVersions map[string]string
+	fileMode          os.FileMode
+	clock             types.Clock
+	timelinePeriod    EvidencePeriod
 }
 
 // NewExporter creates a new report exporter
 func NewExporter(version string) *Exporter {
 	return &Exporter{
-		version: version,
+		version:        version,
+		weights:        types.DefaultSeverityWeights(),
+		fileMode:       types.DefaultExportFileMode,
+		clock:          types.SystemClock{},
+		timelinePeriod: PeriodMonth,
+	}
+}
+
+// SetScoringWeights overrides the severity weights used for weighted compliance.
+// Zero-value weights are ignored and the defaults are kept.
+func (e *Exporter) SetScoringWeights(weights types.SeverityWeights) {
+	if weights.IsZero() {
+		return
+	}
+	e.weights = weights
+}
+
+// SetBaseline sets the suppression baseline applied to findings during report generation
+func (e *Exporter) SetBaseline(baseline *Baseline) {
+	e.baseline = baseline
+}
+
+// SetFrameworkVersions records the pinned framework versions in report metadata
+func (e *Exporter) SetFrameworkVersions(versions map[string]string) {
+	e.frameworkVersions = versions
+}
+
+// SetFileMode sets the permissions used by ExportToFile
+func (e *Exporter) SetFileMode(mode os.FileMode) {
+	e.fileMode = mode
+}
+
+// SetClock sets the clock used for the report's generation time. A fixed
+// clock makes reports generated from the same data byte-identical.
+func (e *Exporter) SetClock(clock types.Clock) {
+	if clock != nil {
+		e.clock = clock
+	}
+}
+
+// SetTimelinePeriod sets whether control evidence timelines are grouped by
+// month (the default) or quarter
+func (e *Exporter) SetTimelinePeriod(period EvidencePeriod) {
+	if period != "" {
+		e.timelinePeriod = period
 	}
 }
 
@@ -77,24 +149,37 @@ func (e *Exporter) GenerateReport(
 	findings []types.Finding,
 	role string,
 ) (*Report, error) {
+	// Mark baseline-suppressed findings as waived (without mutating the caller's slice)
+	if e.baseline != nil && len(e.baseline.Entries) > 0 {
+		suppressed := make([]types.Finding, len(findings))
+		copy(suppressed, findings)
+		e.baseline.Apply(suppressed)
+		findings = suppressed
+	}
+
 	// Calculate summary statistics
 	summary := e.calculateSummary(sources, events, frameworks, controls, evidence, findings)
 
 	// Group data by framework
-	frameworkReports := e.groupByFramework(frameworks, controls, evidence, findings)
+	frameworkReports := e.groupByFramework(frameworks, controls, evidence, findings, eventTimestamps(events))
 
 	// Create report
 	report := &Report{
 		Metadata: ReportMetadata{
-			GeneratedAt: time.Now(),
-			Version:     e.version,
-			Role:        role,
+			GeneratedAt:       e.clock.Now(),
+			Version:           e.version,
+			SchemaVersion:     types.SchemaVersion,
+			Role:              role,
+			FrameworkVersions: e.frameworkVersions,
 		},
 		Summary:    summary,
 		Frameworks: frameworkReports,
 		Sources:    sources,
 		Events:     events,
 		Findings:   findings,
+		weights:    e.weights,
+
+		timelinePeriod: e.timelinePeriod,
 	}
 
 	return report, nil
@@ -118,7 +203,28 @@ func (e *Exporter) calculateSummary(
 		TotalFindings:   len(findings),
 	}
 
-	// Count findings by severity
+	countFindings(&summary, findings)
+
+	// Calculate overall compliance
+	if len(controls) > 0 {
+		greenCount := 0
+		for _, control := range controls {
+			if control.RiskStatus == "green" {
+				greenCount++
+			}
+		}
+		summary.OverallCompliance = float64(greenCount) / float64(len(controls)) * 100
+	}
+	summary.WeightedCompliance = e.calculateWeightedCompliance(controls, findings)
+
+	return summary
+}
+
+// countFindings sets the summary's total and per-severity finding counts
+func countFindings(summary *ReportSummary, findings []types.Finding) {
+	summary.TotalFindings = len(findings)
+	summary.CriticalFindings, summary.HighFindings = 0, 0
+	summary.MediumFindings, summary.LowFindings = 0, 0
 	for _, finding := range findings {
 		switch finding.Severity {
 		case types.SeverityCritical:
@@ -131,19 +237,97 @@ func (e *Exporter) calculateSummary(
 			summary.LowFindings++
 		}
 	}
+}
 
-	// Calculate overall compliance
-	if len(controls) > 0 {
-		greenCount := 0
-		for _, control := range controls {
+// recomputeSummary brings a report's summary and framework compliance up to
+// date after its frameworks, controls or findings were filtered or replaced.
+// Finding counts come from the top-level findings, or from the control findings
+// when the report has none. Frameworks without control detail (summary-only
+// reports) keep their compliance, and the control totals and compliance
+// percentages are left alone when no framework has control detail. Evidence
+// totals are the caller's to adjust.
+func recomputeSummary(report *Report) {
+	weights := report.weights
+	if weights.IsZero() {
+		weights = types.DefaultSeverityWeights()
+	}
+	exporter := &Exporter{weights: weights}
+
+	// A report without frameworks has no controls to leave alone
+	detailed := len(report.Frameworks) == 0
+	var allControls []types.Control
+	var controlFindings []types.Finding
+	for i := range report.Frameworks {
+		fw := &report.Frameworks[i]
+		if fw.Controls == nil {
+			continue
+		}
+		detailed = true
+
+		var fwControls []types.Control
+		var fwFindings []types.Finding
+		for _, ctrl := range fw.Controls {
+			fwControls = append(fwControls, ctrl.Control)
+			fwFindings = append(fwFindings, ctrl.Findings...)
+		}
+		fw.WeightedCompliance = exporter.calculateWeightedCompliance(fwControls, fwFindings)
+		allControls = append(allControls, fwControls...)
+		controlFindings = append(controlFindings, fwFindings...)
+	}
+
+	findings := report.Findings
+	if findings == nil {
+		if !detailed {
+			return
+		}
+		findings = controlFindings
+	}
+	countFindings(&report.Summary, findings)
+	report.Summary.TotalFrameworks = len(report.Frameworks)
+	if !detailed {
+		return
+	}
+
+	report.Summary.TotalControls = len(allControls)
+	report.Summary.OverallCompliance = 0
+	if len(allControls) > 0 {
+		green := 0
+		for _, control := range allControls {
 			if control.RiskStatus == "green" {
-				greenCount++
+				green++
 			}
 		}
-		summary.OverallCompliance = float64(greenCount) / float64(len(controls)) * 100
+		report.Summary.OverallCompliance = float64(green) / float64(len(allControls)) * 100
 	}
+	report.Summary.WeightedCompliance = exporter.calculateWeightedCompliance(allControls, findings)
+}
 
-	return summary
+// calculateWeightedCompliance computes a compliance percentage where each control
+// contributes 1.0 minus the severity weights of its open findings (floored at 0)
+func (e *Exporter) calculateWeightedCompliance(controls []types.Control, findings []types.Finding) float64 {
+	if len(controls) == 0 {
+		return 0
+	}
+
+	// Sum open finding weights per control
+	penalties := make(map[string]float64)
+	for _, finding := range findings {
+		if !isOpenFinding(finding) {
+			continue
+		}
+		key := finding.FrameworkID + ":" + finding.ControlID
+		penalties[key] += e.weights.ForSeverity(finding.Severity)
+	}
+
+	total := 0.0
+	for _, control := range controls {
+		credit := 1.0 - penalties[control.FrameworkID+":"+control.ID]
+		if credit > 0 {
+			total += credit
+		}
+	}
+
+	return total / float64(len(controls)) * 100
 }
 
 // groupByFramework organizes controls, evidence, and findings by framework
@@ -152,6 +336,7 @@ func (e *Exporter) groupByFramework(
 	controls []types.Control,
 	evidence []types.Evidence,
 	findings []types.Finding,
+	eventTimes map[string]time.Time,
 ) []FrameworkReport {
 	frameworkReports := make([]FrameworkReport, 0, len(frameworks))
 
@@ -187,12 +372,14 @@ func (e *Exporter) groupByFramework(
 				Control:  control,
 				Evidence: controlEvidence,
 				Findings: controlFindings,
+				Timeline: controlTimeline(controlEvidence, eventTimes, e.timelinePeriod),
 			})
 		}
 
 		frameworkReports = append(frameworkReports, FrameworkReport{
-			Framework: fw,
-			Controls:  controlReports,
+			Framework:          fw,
+			WeightedCompliance: e.calculateWeightedCompliance(frameworkControls, findings),
+			Controls:           controlReports,
 		})
 	}
 
@@ -201,20 +388,14 @@ func (e *Exporter) groupByFramework(
 
 // ExportToFile saves the report to a JSON file
 func (e *Exporter) ExportToFile(report *Report, filePath string) error {
-	// Ensure directory exists
-	dir := filepath.Dir(filePath)
-	if err := os.MkdirAll(dir, 0755); err != nil {
-		return fmt.Errorf("failed to create directory: %w", err)
-	}
-
 	// Marshal to JSON with indentation
 	data, err := json.MarshalIndent(report, "", "  ")
 	if err != nil {
 		return fmt.Errorf("failed to marshal report: %w", err)
 	}
 
-	// Write to file
-	if err := os.WriteFile(filePath, data, 0644); err != nil {
+	// Write to file, creating the directory if needed
+	if err := WriteFile(filePath, data, e.fileMode); err != nil {
 		return fmt.Errorf("failed to write file: %w", err)
 	}
 
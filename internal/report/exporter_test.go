@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 	"time"
 
@@ -170,7 +171,7 @@ func TestGroupByFramework(t *testing.T) {
 		{ID: "f-1", ControlID: "CC6.2", FrameworkID: types.FrameworkSOC2},
 	}
 
-	reports := exporter.groupByFramework(frameworks, controls, evidence, findings)
+	reports := exporter.groupByFramework(frameworks, controls, evidence, findings, nil)
 
 	if len(reports) != 2 {
 		t.Fatalf("Expected 2 framework reports, got %d", len(reports))
@@ -318,3 +319,196 @@ func TestEmptyReport(t *testing.T) {
 		t.Errorf("Expected 0 compliance, got %.2f", report.Summary.OverallCompliance)
 	}
 }
+
+// TestWeightedCompliance compares weighted and unweighted compliance for mixed-severity findings
+func TestWeightedCompliance(t *testing.T) {
+	frameworks := []types.Framework{
+		{ID: types.FrameworkSOC2, Name: "SOC 2", ControlCount: 4},
+	}
+
+	controls := []types.Control{
+		{ID: "CC1.1", FrameworkID: types.FrameworkSOC2, RiskStatus: types.RiskStatusRed},
+		{ID: "CC1.2", FrameworkID: types.FrameworkSOC2, RiskStatus: types.RiskStatusYellow},
+		{ID: "CC1.3", FrameworkID: types.FrameworkSOC2, RiskStatus: types.RiskStatusGreen},
+		{ID: "CC1.4", FrameworkID: types.FrameworkSOC2, RiskStatus: types.RiskStatusGreen},
+	}
+
+	findings := []types.Finding{
+		{ID: "f-1", ControlID: "CC1.1", FrameworkID: types.FrameworkSOC2, Severity: types.SeverityCritical, Status: types.StatusOpen},
+		{ID: "f-2", ControlID: "CC1.2", FrameworkID: types.FrameworkSOC2, Severity: types.SeverityLow, Status: types.StatusOpen},
+		// Resolved findings do not subtract from the weighted score
+		{ID: "f-3", ControlID: "CC1.3", FrameworkID: types.FrameworkSOC2, Severity: types.SeverityCritical, Status: types.StatusResolved},
+	}
+
+	t.Run("default weights", func(t *testing.T) {
+		exporter := NewExporter("1.0.0")
+
+		report, err := exporter.GenerateReport(nil, nil, frameworks, controls, nil, findings, "")
+		if err != nil {
+			t.Fatalf("Failed to generate report: %v", err)
+		}
+
+		if report.Summary.OverallCompliance != 50.0 {
+			t.Errorf("Expected unweighted compliance 50.00, got %.2f", report.Summary.OverallCompliance)
+		}
+
+		// (0 + 0.9 + 1 + 1) / 4
+		if diff := report.Summary.WeightedCompliance - 72.5; diff > 0.001 || diff < -0.001 {
+			t.Errorf("Expected weighted compliance 72.50, got %.2f", report.Summary.WeightedCompliance)
+		}
+
+		if report.Frameworks[0].WeightedCompliance != report.Summary.WeightedCompliance {
+			t.Errorf("Expected framework weighted compliance %.2f, got %.2f",
+				report.Summary.WeightedCompliance, report.Frameworks[0].WeightedCompliance)
+		}
+	})
+
+	t.Run("custom weights", func(t *testing.T) {
+		exporter := NewExporter("1.0.0")
+		exporter.SetScoringWeights(types.SeverityWeights{Critical: 1, High: 1, Medium: 1, Low: 1})
+
+		report, err := exporter.GenerateReport(nil, nil, frameworks, controls, nil, findings, "")
+		if err != nil {
+			t.Fatalf("Failed to generate report: %v", err)
+		}
+
+		// (0 + 0 + 1 + 1) / 4
+		if report.Summary.WeightedCompliance != 50.0 {
+			t.Errorf("Expected weighted compliance 50.00, got %.2f", report.Summary.WeightedCompliance)
+		}
+		if report.Summary.OverallCompliance != 50.0 {
+			t.Errorf("Expected unweighted compliance to stay 50.00, got %.2f", report.Summary.OverallCompliance)
+		}
+	})
+
+	t.Run("zero weights keep defaults", func(t *testing.T) {
+		exporter := NewExporter("1.0.0")
+		exporter.SetScoringWeights(types.SeverityWeights{})
+
+		if exporter.weights != types.DefaultSeverityWeights() {
+			t.Errorf("Expected default weights, got %+v", exporter.weights)
+		}
+	})
+}
+
+// TestGenerateReport_FrameworkVersions verifies pinned framework versions appear in report metadata
+func TestGenerateReport_FrameworkVersions(t *testing.T) {
+	exporter := NewExporter("1.0.0")
+	exporter.SetFrameworkVersions(map[string]string{types.FrameworkSOC2: "2017"})
+
+	report, err := exporter.GenerateReport(nil, nil, nil, nil, nil, nil, "all")
+	if err != nil {
+		t.Fatalf("GenerateReport() error = %v", err)
+	}
+
+	if got := report.Metadata.FrameworkVersions[types.FrameworkSOC2]; got != "2017" {
+		t.Errorf("Expected pinned SOC2 version 2017 in metadata, got %q", got)
+	}
+
+	data, err := exporter.ExportToJSON(report, false)
+	if err != nil {
+		t.Fatalf("ExportToJSON() error = %v", err)
+	}
+	if !strings.Contains(string(data), `"framework_versions":{"soc2":"2017"}`) {
+		t.Errorf("Expected framework_versions in exported JSON, got %s", data)
+	}
+}
+
+// TestExportToFile_NestedDirectoryAndMode verifies missing directories are created and the file mode is applied
+func TestExportToFile_NestedDirectoryAndMode(t *testing.T) {
+	exporter := NewExporter("1.0.0")
+	exporter.SetFileMode(0600)
+	path := filepath.Join(t.TempDir(), "reports", "2025", "q1", "report.json")
+
+	if err := exporter.ExportToFile(&Report{}, path); err != nil {
+		t.Fatalf("ExportToFile() error = %v", err)
+	}
+
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatalf("expected report file to exist: %v", err)
+	}
+	if info.Mode().Perm() != 0600 {
+		t.Errorf("expected mode 0600, got %o", info.Mode().Perm())
+	}
+}
+
+// TestGenerateHTML_NestedDirectoryAndMode verifies the HTML report is written into a new directory with the given mode
+func TestGenerateHTML_NestedDirectoryAndMode(t *testing.T) {
+	dir := t.TempDir()
+	jsonPath := filepath.Join(dir, "report.json")
+	if err := NewExporter("1.0.0").ExportToFile(&Report{}, jsonPath); err != nil {
+		t.Fatalf("failed to write JSON report: %v", err)
+	}
+	htmlPath := filepath.Join(dir, "dashboards", "compliance.html")
+
+	if err := GenerateHTML(jsonPath, htmlPath, 0640); err != nil {
+		t.Fatalf("GenerateHTML() error = %v", err)
+	}
+
+	info, err := os.Stat(htmlPath)
+	if err != nil {
+		t.Fatalf("expected HTML file to exist: %v", err)
+	}
+	if info.Mode().Perm() != 0640 {
+		t.Errorf("expected mode 0640, got %o", info.Mode().Perm())
+	}
+}
+
+// TestGenerateReport_FixedClockIsReproducible verifies that reports generated
+// from the same data with a fixed clock are byte-identical
+func TestGenerateReport_FixedClockIsReproducible(t *testing.T) {
+	fixed := time.Date(2024, 6, 30, 12, 0, 0, 0, time.UTC)
+	eventTime := time.Date(2024, 6, 1, 9, 0, 0, 0, time.UTC)
+
+	generate := func() []byte {
+		exporter := NewExporter("1.0.0")
+		exporter.SetClock(types.FixedClock{Time: fixed})
+		report, err := exporter.GenerateReport(
+			[]types.Source{{ID: "src-1", Name: "Git Repo", Type: types.SourceTypeGit}},
+			[]types.Event{{ID: "evt-1", SourceID: "src-1", Title: "Test Event", Timestamp: eventTime}},
+			[]types.Framework{{ID: types.FrameworkSOC2, Name: "SOC 2"}},
+			[]types.Control{{ID: "CC6.1", FrameworkID: types.FrameworkSOC2, Title: "Test Control", RiskStatus: "green"}},
+			[]types.Evidence{{ID: "ev-1", ControlID: "CC6.1", FrameworkID: types.FrameworkSOC2, EventID: "evt-1"}},
+			[]types.Finding{{ID: "f-1", ControlID: "CC6.1", FrameworkID: types.FrameworkSOC2, Severity: types.SeverityLow, CreatedAt: eventTime}},
+			types.RoleComplianceManager,
+		)
+		if err != nil {
+			t.Fatalf("Failed to generate report: %v", err)
+		}
+		if !report.Metadata.GeneratedAt.Equal(fixed) {
+			t.Errorf("GeneratedAt = %v, want %v", report.Metadata.GeneratedAt, fixed)
+		}
+
+		data, err := exporter.ExportToJSON(report, true)
+		if err != nil {
+			t.Fatalf("Failed to export report: %v", err)
+		}
+		return data
+	}
+
+	first, second := generate(), generate()
+	if string(first) != string(second) {
+		t.Errorf("Reports differ with a fixed clock:\n%s\n---\n%s", first, second)
+	}
+}
+
+// TestRecomputeSummary_MatchesGeneratedReport verifies recomputing an unchanged
+// report's summary reproduces what GenerateReport computed, so filtered and
+// updated reports summarize the same way as fresh ones
+func TestRecomputeSummary_MatchesGeneratedReport(t *testing.T) {
+	report := reviewTestReport(t)
+	want := report.Summary
+	wantFrameworks := []float64{report.Frameworks[0].WeightedCompliance, report.Frameworks[1].WeightedCompliance}
+
+	recomputeSummary(report)
+
+	if report.Summary != want {
+		t.Errorf("Expected summary %+v, got %+v", want, report.Summary)
+	}
+	for i, fw := range report.Frameworks {
+		if fw.WeightedCompliance != wantFrameworks[i] {
+			t.Errorf("Expected %s weighted compliance %.2f, got %.2f", fw.Framework.ID, wantFrameworks[i], fw.WeightedCompliance)
+		}
+	}
+}
@@ -0,0 +1,79 @@
+package report
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/pickjonathan/sdek-cli/pkg/types"
+)
+
+// FindingsFile holds findings loaded from disk.
+// Files written by 'sdek ai analyze' contain a single finding object,
+// while aggregated files contain an array; the original shape is preserved on save.
+type FindingsFile struct {
+	Path     string
+	Findings []types.Finding
+	single   bool
+}
+
+// LoadFindingsFile reads a findings file containing a finding object or an array
+// of findings, upgrading findings written with an older schema version
+func LoadFindingsFile(path string) (*FindingsFile, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read findings file: %w", err)
+	}
+
+	file := &FindingsFile{Path: path}
+
+	trimmed := bytes.TrimSpace(data)
+	if len(trimmed) > 0 && trimmed[0] == '{' {
+		var finding types.Finding
+		if err := json.Unmarshal(trimmed, &finding); err != nil {
+			return nil, fmt.Errorf("failed to parse findings file: %w", err)
+		}
+		file.Findings = []types.Finding{finding}
+		file.single = true
+	} else if err := json.Unmarshal(trimmed, &file.Findings); err != nil {
+		return nil, fmt.Errorf("failed to parse findings file: %w", err)
+	}
+
+	if err := migrateFindings(file.Findings); err != nil {
+		return nil, fmt.Errorf("failed to migrate findings file: %w", err)
+	}
+
+	return file, nil
+}
+
+// Find returns the finding with the given ID
+func (f *FindingsFile) Find(id string) (*types.Finding, error) {
+	for i := range f.Findings {
+		if f.Findings[i].ID == id {
+			return &f.Findings[i], nil
+		}
+	}
+	return nil, fmt.Errorf("finding not found: %s", id)
+}
+
+// Save writes the findings back to disk in their original shape
+func (f *FindingsFile) Save() error {
+	var data []byte
+	var err error
+
+	if f.single && len(f.Findings) == 1 {
+		data, err = json.MarshalIndent(f.Findings[0], "", "  ")
+	} else {
+		data, err = json.MarshalIndent(f.Findings, "", "  ")
+	}
+	if err != nil {
+		return fmt.Errorf("failed to marshal findings: %w", err)
+	}
+
+	if err := WriteFile(f.Path, data, 0644); err != nil {
+		return fmt.Errorf("failed to write findings file: %w", err)
+	}
+
+	return nil
+}
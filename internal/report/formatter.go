@@ -22,6 +22,7 @@ func (f *Formatter) FilterByRole(report *Report, role string) *Report {
 		Metadata:   report.Metadata,
 		Summary:    report.Summary,
 		Frameworks: make([]FrameworkReport, len(report.Frameworks)),
+		weights:    report.weights,
 	}
 
 	// Update role in metadata
@@ -42,7 +43,7 @@ func (f *Formatter) FilterByRole(report *Report, role string) *Report {
 		// Engineers see filtered view - no raw sources/events, only findings and evidence
 		filtered.Sources = nil
 		filtered.Events = nil
-		filtered.Findings = f.filterCriticalAndHighFindings(report.Findings)
+		filtered.Findings = f.filterRawEvidence(f.filterCriticalAndHighFindings(report.Findings))
 		filtered.Frameworks = f.filterControlsWithFindings(report.Frameworks)
 		return filtered
 
@@ -67,6 +68,23 @@ func (f *Formatter) filterCriticalAndHighFindings(findings []types.Finding) []ty
 	return filtered
 }
 
+// filterRawEvidence drops embedded evidence that was not redacted
+func (f *Formatter) filterRawEvidence(findings []types.Finding) []types.Finding {
+	for i := range findings {
+		if len(findings[i].Evidence) == 0 {
+			continue
+		}
+		redacted := make([]types.CitedEvidence, 0, len(findings[i].Evidence))
+		for _, ev := range findings[i].Evidence {
+			if ev.Redacted {
+				redacted = append(redacted, ev)
+			}
+		}
+		findings[i].Evidence = redacted
+	}
+	return findings
+}
+
 // filterControlsWithFindings returns only controls that have findings
 func (f *Formatter) filterControlsWithFindings(frameworks []FrameworkReport) []FrameworkReport {
 	filtered := make([]FrameworkReport, 0, len(frameworks))
@@ -81,8 +99,9 @@ func (f *Formatter) filterControlsWithFindings(frameworks []FrameworkReport) []F
 
 		if len(controls) > 0 {
 			filtered = append(filtered, FrameworkReport{
-				Framework: fw.Framework,
-				Controls:  controls,
+				Framework:          fw.Framework,
+				WeightedCompliance: fw.WeightedCompliance,
+				Controls:           controls,
 			})
 		}
 	}
@@ -96,8 +115,9 @@ func (f *Formatter) filterToSummaryOnly(frameworks []FrameworkReport) []Framewor
 
 	for _, fw := range frameworks {
 		filtered = append(filtered, FrameworkReport{
-			Framework: fw.Framework,
-			Controls:  nil, // Remove all control details
+			Framework:          fw.Framework,
+			WeightedCompliance: fw.WeightedCompliance,
+			Controls:           nil, // Remove all control details
 		})
 	}
 
@@ -132,6 +152,7 @@ func (f *Formatter) GetFrameworkSummaries(report *Report) []FrameworkSummary {
 			Name:                 fw.Framework.Name,
 			TotalControls:        len(fw.Controls),
 			CompliancePercentage: fw.Framework.CompliancePercentage,
+			WeightedCompliance:   fw.WeightedCompliance,
 		}
 
 		// Count controls by risk status
@@ -166,6 +187,7 @@ type FrameworkSummary struct {
 	TotalEvidence        int     `json:"total_evidence"`
 	TotalFindings        int     `json:"total_findings"`
 	CompliancePercentage float64 `json:"compliance_percentage"`
+	WeightedCompliance   float64 `json:"weighted_compliance_percentage"`
 }
 
 // FormatCSV exports evidence data as CSV with AI analysis fields
@@ -248,6 +270,7 @@ func (f *Formatter) FormatMarkdown(report *Report) string {
 	md += fmt.Sprintf("- **Total Controls:** %d\n", report.Summary.TotalControls)
 	md += fmt.Sprintf("- **Total Evidence:** %d\n", report.Summary.TotalEvidence)
 	md += fmt.Sprintf("- **Overall Compliance:** %.2f%%\n", report.Summary.OverallCompliance)
+	md += fmt.Sprintf("- **Weighted Compliance:** %.2f%%\n", report.Summary.WeightedCompliance)
 	md += fmt.Sprintf("- **Findings:** %d Critical, %d High, %d Medium, %d Low\n\n",
 		report.Summary.CriticalFindings,
 		report.Summary.HighFindings,
@@ -258,7 +281,7 @@ func (f *Formatter) FormatMarkdown(report *Report) string {
 	// Frameworks
 	for _, fw := range report.Frameworks {
 		md += fmt.Sprintf("## Framework: %s\n\n", fw.Framework.Name)
-		md += fmt.Sprintf("**Compliance:** %.2f%%\n\n", fw.Framework.CompliancePercentage)
+		md += fmt.Sprintf("**Compliance:** %.2f%% (weighted: %.2f%%)\n\n", fw.Framework.CompliancePercentage, fw.WeightedCompliance)
 
 		for _, ctrl := range fw.Controls {
 			md += fmt.Sprintf("### Control: %s - %s\n\n", ctrl.Control.ID, ctrl.Control.Title)
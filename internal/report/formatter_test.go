@@ -92,6 +92,30 @@ func TestFilterByRoleEngineer(t *testing.T) {
 	}
 }
 
+// TestFilterByRoleEngineerDropsRawEvidence verifies engineers never see unredacted embedded evidence
+func TestFilterByRoleEngineerDropsRawEvidence(t *testing.T) {
+	formatter := NewFormatter()
+
+	report := createTestReport()
+	report.Findings[0].Evidence = []types.CitedEvidence{
+		{ID: "evt-1", Content: "raw", Redacted: false},
+		{ID: "evt-2", Content: "[REDACTED:PII:EMAIL]", Redacted: true},
+	}
+
+	filtered := formatter.FilterByRole(report, types.RoleEngineer)
+
+	evidence := filtered.Findings[0].Evidence
+	if len(evidence) != 1 || evidence[0].ID != "evt-2" {
+		t.Errorf("Expected only redacted evidence for engineer role, got %+v", evidence)
+	}
+
+	// The compliance manager view keeps raw evidence
+	full := formatter.FilterByRole(report, types.RoleComplianceManager)
+	if len(full.Findings[0].Evidence) != 2 {
+		t.Errorf("Expected 2 evidence entries for compliance manager, got %d", len(full.Findings[0].Evidence))
+	}
+}
+
 // TestFilterByRoleUnknown verifies unknown role gets minimal view
 func TestFilterByRoleUnknown(t *testing.T) {
 	formatter := NewFormatter()
@@ -0,0 +1,78 @@
+package report
+
+import (
+	"github.com/pickjonathan/sdek-cli/internal/analyze"
+	"github.com/pickjonathan/sdek-cli/pkg/types"
+)
+
+// ControlGap is a control with no evidence in a report
+type ControlGap struct {
+	FrameworkID string `json:"framework_id"`
+	ControlID   string `json:"control_id"`
+	Title       string `json:"title"`
+	Category    string `json:"category,omitempty"`
+
+	// Findings counts findings recorded against the control despite it having no evidence
+	Findings int `json:"findings"`
+
+	// Unassessed is true when the control has neither evidence nor findings
+	Unassessed bool `json:"unassessed"`
+}
+
+// CoverageGaps lists the controls of each framework in the report that have no
+// evidence. The built-in framework definitions supply the full control
+// universe, so controls missing from the report entirely are gaps too.
+// Controls are listed in framework definition order, followed by any
+// report-only controls in report order.
+func CoverageGaps(report *Report) []ControlGap {
+	if report == nil {
+		return nil
+	}
+
+	definitions := analyze.GetFrameworkDefinitions()
+	gaps := make([]ControlGap, 0)
+	for _, fwReport := range report.Frameworks {
+		reported := make(map[string]ControlReport, len(fwReport.Controls))
+		for _, cr := range fwReport.Controls {
+			reported[cr.Control.ID] = cr
+		}
+
+		seen := make(map[string]bool)
+		for _, def := range definitions {
+			if !types.SameFramework(def.ID, fwReport.Framework.ID) {
+				continue
+			}
+			for _, control := range def.Controls {
+				seen[control.ID] = true
+				cr, ok := reported[control.ID]
+				if ok && len(cr.Evidence) > 0 {
+					continue
+				}
+				gaps = append(gaps, ControlGap{
+					FrameworkID: fwReport.Framework.ID,
+					ControlID:   control.ID,
+					Title:       control.Title,
+					Category:    control.Category,
+					Findings:    len(cr.Findings),
+					Unassessed:  len(cr.Findings) == 0,
+				})
+			}
+		}
+
+		for _, cr := range fwReport.Controls {
+			if seen[cr.Control.ID] || len(cr.Evidence) > 0 {
+				continue
+			}
+			gaps = append(gaps, ControlGap{
+				FrameworkID: fwReport.Framework.ID,
+				ControlID:   cr.Control.ID,
+				Title:       cr.Control.Title,
+				Category:    cr.Control.Category,
+				Findings:    len(cr.Findings),
+				Unassessed:  len(cr.Findings) == 0,
+			})
+		}
+	}
+
+	return gaps
+}
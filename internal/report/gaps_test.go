@@ -0,0 +1,108 @@
+package report
+
+import (
+	"testing"
+
+	"github.com/pickjonathan/sdek-cli/internal/analyze"
+	"github.com/pickjonathan/sdek-cli/pkg/types"
+)
+
+// gapsTestReport builds a SOC 2 report with evidence for CC6.1, a finding but no
+// evidence for CC6.2, and a custom control that is not in the framework definition
+func gapsTestReport(t *testing.T) *Report {
+	t.Helper()
+
+	frameworks := []types.Framework{{ID: types.FrameworkSOC2, Name: "SOC 2"}}
+	controls := []types.Control{
+		{ID: "CC6.1", FrameworkID: types.FrameworkSOC2, Title: "Logical and Physical Access Controls"},
+		{ID: "CC6.2", FrameworkID: types.FrameworkSOC2, Title: "Access Authorization"},
+		{ID: "CUSTOM-1", FrameworkID: types.FrameworkSOC2, Title: "Custom control"},
+	}
+	evidence := []types.Evidence{
+		{ID: "ev-1", ControlID: "CC6.1", FrameworkID: types.FrameworkSOC2, ConfidenceScore: 90},
+	}
+	findings := []types.Finding{
+		{ID: "f-1", ControlID: "CC6.2", FrameworkID: types.FrameworkSOC2, Severity: types.SeverityHigh, Status: types.StatusOpen},
+	}
+
+	report, err := NewExporter("1.0.0").GenerateReport(nil, nil, frameworks, controls, evidence, findings, "")
+	if err != nil {
+		t.Fatalf("GenerateReport failed: %v", err)
+	}
+	return report
+}
+
+// TestCoverageGaps verifies every defined control without evidence is a gap
+func TestCoverageGaps(t *testing.T) {
+	gaps := CoverageGaps(gapsTestReport(t))
+
+	byControl := make(map[string]ControlGap)
+	for _, gap := range gaps {
+		byControl[gap.ControlID] = gap
+	}
+
+	// Every SOC 2 control except CC6.1, plus the custom control
+	want := len(analyze.GetSOC2Framework().Controls)
+	if len(gaps) != want {
+		t.Errorf("Expected %d gaps, got %d", want, len(gaps))
+	}
+
+	if _, ok := byControl["CC6.1"]; ok {
+		t.Error("CC6.1 has evidence and should not be a gap")
+	}
+
+	cc62, ok := byControl["CC6.2"]
+	if !ok {
+		t.Fatal("CC6.2 has no evidence and should be a gap")
+	}
+	if cc62.Findings != 1 || cc62.Unassessed {
+		t.Errorf("CC6.2 gap = %+v, want 1 finding and assessed", cc62)
+	}
+
+	// CC6.3 is defined for SOC 2 but absent from the report
+	cc63, ok := byControl["CC6.3"]
+	if !ok {
+		t.Fatal("CC6.3 is absent from the report and should be a gap")
+	}
+	if !cc63.Unassessed || cc63.Title == "" || cc63.FrameworkID != types.FrameworkSOC2 {
+		t.Errorf("CC6.3 gap = %+v, want an unassessed SOC 2 control with its title", cc63)
+	}
+
+	custom, ok := byControl["CUSTOM-1"]
+	if !ok || !custom.Unassessed {
+		t.Errorf("CUSTOM-1 gap = %+v (found %v), want an unassessed gap", custom, ok)
+	}
+	if gaps[len(gaps)-1].ControlID != "CUSTOM-1" {
+		t.Errorf("Expected report-only controls last, got %s", gaps[len(gaps)-1].ControlID)
+	}
+}
+
+// TestCoverageGaps_OnlyReportedFrameworks verifies frameworks absent from the report are not listed
+func TestCoverageGaps_OnlyReportedFrameworks(t *testing.T) {
+	for _, gap := range CoverageGaps(gapsTestReport(t)) {
+		if gap.FrameworkID != types.FrameworkSOC2 {
+			t.Errorf("Unexpected gap for framework %s: %s", gap.FrameworkID, gap.ControlID)
+		}
+	}
+}
+
+// TestCoverageGaps_FullCoverage verifies a report with evidence for every control has no gaps
+func TestCoverageGaps_FullCoverage(t *testing.T) {
+	def := analyze.GetPCIDSSFramework()
+	frameworks := []types.Framework{{ID: def.ID, Name: def.Name}}
+	var controls []types.Control
+	var evidence []types.Evidence
+	for _, c := range def.Controls {
+		controls = append(controls, types.Control{ID: c.ID, FrameworkID: def.ID})
+		evidence = append(evidence, types.Evidence{ID: "ev-" + c.ID, ControlID: c.ID, FrameworkID: def.ID})
+	}
+
+	report, err := NewExporter("1.0.0").GenerateReport(nil, nil, frameworks, controls, evidence, nil, "")
+	if err != nil {
+		t.Fatalf("GenerateReport failed: %v", err)
+	}
+
+	if gaps := CoverageGaps(report); len(gaps) != 0 {
+		t.Errorf("Expected no gaps, got %d: %+v", len(gaps), gaps)
+	}
+}
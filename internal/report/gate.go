@@ -0,0 +1,36 @@
+package report
+
+import (
+	"fmt"
+
+	"github.com/pickjonathan/sdek-cli/pkg/types"
+)
+
+// severityRank orders finding severities from lowest to highest
+var severityRank = map[string]int{
+	types.SeverityLow:      1,
+	types.SeverityMedium:   2,
+	types.SeverityHigh:     3,
+	types.SeverityCritical: 4,
+}
+
+// CheckFailOn returns the open findings at or above the given severity.
+// Resolved and waived findings never fail the gate.
+func CheckFailOn(findings []types.Finding, failOn string) ([]types.Finding, error) {
+	threshold, ok := severityRank[failOn]
+	if !ok {
+		return nil, fmt.Errorf("invalid fail-on severity: %s, must be one of low, medium, high, critical", failOn)
+	}
+
+	var blocking []types.Finding
+	for _, finding := range findings {
+		if !isOpenFinding(finding) {
+			continue
+		}
+		if severityRank[finding.Severity] >= threshold {
+			blocking = append(blocking, finding)
+		}
+	}
+
+	return blocking, nil
+}
@@ -8,25 +8,26 @@ import (
 	"strings"
 )
 
-// GenerateHTML generates an interactive HTML report from a JSON report file
-func GenerateHTML(jsonPath, outputPath string) error {
+// GenerateHTML generates an interactive HTML report from a JSON report file,
+// writing it with the given permissions and creating parent directories as needed
+func GenerateHTML(jsonPath, outputPath string, mode os.FileMode) error {
 	// Read the JSON report
 	data, err := os.ReadFile(jsonPath)
 	if err != nil {
 		return fmt.Errorf("failed to read JSON report: %w", err)
 	}
 
-	// Parse the report
-	var report Report
-	if err := json.Unmarshal(data, &report); err != nil {
+	// Parse the report, upgrading older schema versions
+	report, err := Migrate(data)
+	if err != nil {
 		return fmt.Errorf("failed to parse JSON report: %w", err)
 	}
 
 	// Generate HTML
-	html := generateHTMLContent(report)
+	html := generateHTMLContent(*report)
 
 	// Write to file
-	if err := os.WriteFile(outputPath, []byte(html), 0644); err != nil {
+	if err := WriteFile(outputPath, []byte(html), mode); err != nil {
 		return fmt.Errorf("failed to write HTML file: %w", err)
 	}
 
@@ -36,16 +37,19 @@ func GenerateHTML(jsonPath, outputPath string) error {
 func generateHTMLContent(report Report) string {
 	// Convert report to JSON for embedding
 	reportJSON, _ := json.Marshal(report)
+	relatedJSON, _ := json.Marshal(GetRelatedControls(&report))
 
 	tmpl := template.Must(template.New("report").Parse(htmlTemplate))
 	var buf strings.Builder
 
 	data := struct {
-		ReportJSON template.JS
-		Title      string
+		ReportJSON  template.JS
+		RelatedJSON template.JS
+		Title       string
 	}{
-		ReportJSON: template.JS(reportJSON), // Use template.JS for safe JavaScript embedding
-		Title:      "SDEK Compliance Report",
+		ReportJSON:  template.JS(reportJSON), // Use template.JS for safe JavaScript embedding
+		RelatedJSON: template.JS(relatedJSON),
+		Title:       "SDEK Compliance Report",
 	}
 
 	tmpl.Execute(&buf, data)
@@ -397,6 +401,43 @@ const htmlTemplate = `<!DOCTYPE html>
             padding: 30px;
         }
 
+        .timeline {
+            display: flex;
+            gap: 6px;
+            overflow-x: auto;
+            margin: 15px 0;
+            padding-bottom: 5px;
+        }
+
+        .timeline-period {
+            flex: 0 0 auto;
+            min-width: 64px;
+            padding: 8px;
+            text-align: center;
+            background: #f8f9fa;
+            border-radius: 8px;
+            border-bottom: 4px solid #667eea;
+        }
+
+        .timeline-period.empty {
+            border-bottom-color: #dc3545;
+            color: #999;
+        }
+
+        .timeline-count {
+            font-weight: bold;
+            color: #667eea;
+        }
+
+        .timeline-period.empty .timeline-count {
+            color: #dc3545;
+        }
+
+        .timeline-label {
+            font-size: 0.8em;
+            color: #666;
+        }
+
         .modal-close {
             float: right;
             font-size: 1.5em;
@@ -437,6 +478,7 @@ const htmlTemplate = `<!DOCTYPE html>
             <div class="tab" onclick="switchTab('frameworks')">Frameworks</div>
             <div class="tab" onclick="switchTab('findings')">Findings</div>
             <div class="tab" onclick="switchTab('evidence')">Evidence</div>
+            <div class="tab" onclick="switchTab('related')">Related Controls</div>
         </div>
 
         <div class="content">
@@ -446,6 +488,7 @@ const htmlTemplate = `<!DOCTYPE html>
             <div id="frameworks-tab" class="tab-content" style="display:none;"></div>
             <div id="findings-tab" class="tab-content" style="display:none;"></div>
             <div id="evidence-tab" class="tab-content" style="display:none;"></div>
+            <div id="related-tab" class="tab-content" style="display:none;"></div>
         </div>
     </div>
 
@@ -458,6 +501,7 @@ const htmlTemplate = `<!DOCTYPE html>
 
     <script>
         const reportData = {{.ReportJSON}};
+        const relatedControls = {{.RelatedJSON}};
         let currentFilter = 'all';
 
         function init() {
@@ -466,6 +510,7 @@ const htmlTemplate = `<!DOCTYPE html>
             renderFrameworks();
             renderFindings();
             renderEvidence();
+            renderRelated();
         }
 
         function renderSummary() {
@@ -712,6 +757,53 @@ const htmlTemplate = `<!DOCTYPE html>
             document.getElementById('evidenceList').innerHTML = html;
         }
 
+        function describeRelation(rel) {
+            const reasons = [];
+            if (rel.shared_events && rel.shared_events.length > 0) {
+                reasons.push(` + "`" + `${rel.shared_events.length} shared event${rel.shared_events.length === 1 ? '' : 's'}` + "`" + `);
+            }
+            if (rel.cross_referenced) {
+                reasons.push('cross-referenced');
+            }
+            return reasons.join(', ');
+        }
+
+        function renderRelated() {
+            const container = document.getElementById('related-tab');
+            let html = '<h2 style="margin-bottom: 20px;">Related Controls</h2>';
+
+            if (!relatedControls || relatedControls.length === 0) {
+                html += '<p style="text-align: center; color: #666; padding: 40px;">No controls share evidence or cross-reference each other.</p>';
+                container.innerHTML = html;
+                return;
+            }
+
+            relatedControls.forEach(rel => {
+                html += ` + "`" + `
+                    <div class="evidence-item">
+                        <div class="evidence-header">
+                            <strong>${rel.from.framework_id} ${rel.from.control_id} ↔ ${rel.to.framework_id} ${rel.to.control_id}</strong>
+                            <span style="color: #667eea; font-weight: 600;">${describeRelation(rel)}</span>
+                        </div>
+                    </div>
+                ` + "`" + `;
+            });
+
+            container.innerHTML = html;
+        }
+
+        function relatedTo(frameworkId, controlId) {
+            const related = [];
+            (relatedControls || []).forEach(rel => {
+                if (rel.from.framework_id === frameworkId && rel.from.control_id === controlId) {
+                    related.push({ control: rel.to, relation: rel });
+                } else if (rel.to.framework_id === frameworkId && rel.to.control_id === controlId) {
+                    related.push({ control: rel.from, relation: rel });
+                }
+            });
+            return related;
+        }
+
         function countAIEvidence() {
             let count = 0;
             reportData.frameworks.forEach(fwReport => {
@@ -765,9 +857,23 @@ const htmlTemplate = `<!DOCTYPE html>
                         <div style="color: #666;">Confidence</div>
                     </div>
                 </div>
-                
-                <h3 style="margin-top: 25px;">🔍 Evidence (${evidenceCount})</h3>
             ` + "`" + `;
+
+            if (controlData.timeline && controlData.timeline.length > 0) {
+                html += ` + "`" + `<h3 style="margin-top: 25px;">📅 Timeline</h3><div class="timeline">` + "`" + `;
+                controlData.timeline.forEach(bucket => {
+                    const count = bucket.evidence_ids ? bucket.evidence_ids.length : 0;
+                    html += ` + "`" + `
+                        <div class="timeline-period ${count === 0 ? 'empty' : ''}" title="${count} evidence in ${bucket.period}">
+                            <div class="timeline-count">${count}</div>
+                            <div class="timeline-label">${bucket.period}</div>
+                        </div>
+                    ` + "`" + `;
+                });
+                html += '</div>';
+            }
+
+            html += ` + "`" + `<h3 style="margin-top: 25px;">🔍 Evidence (${evidenceCount})</h3>` + "`" + `;
             
             if (controlData.evidence) {
                 controlData.evidence.forEach(ev => {
@@ -785,6 +891,15 @@ const htmlTemplate = `<!DOCTYPE html>
                     ` + "`" + `;
                 });
             }
+
+            const related = relatedTo(frameworkId, controlId);
+            if (related.length > 0) {
+                html += ` + "`" + `<h3 style="margin-top: 25px;">🔗 Related Controls (${related.length})</h3><ul style="margin: 10px 0 0 20px;">` + "`" + `;
+                related.forEach(item => {
+                    html += ` + "`" + `<li>${item.control.framework_id} ${item.control.control_id} (${describeRelation(item.relation)})</li>` + "`" + `;
+                });
+                html += '</ul>';
+            }
             
             document.getElementById('modalBody').innerHTML = html;
             document.getElementById('detailModal').classList.add('active');
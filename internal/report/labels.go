@@ -0,0 +1,30 @@
+package report
+
+import (
+	"github.com/pickjonathan/sdek-cli/pkg/types"
+)
+
+// FilterByLabels returns a copy of the report with only the evidence and
+// findings carrying every label in selector (key to value). Findings match on
+// the key=value labels collected from their evidence, so findings without
+// labeled evidence are dropped. Summary totals, severity counts, and weighted
+// compliance are recomputed, as with FilterByConfidence.
+func FilterByLabels(report *Report, selector map[string]string) *Report {
+	return filterReport(report,
+		func(ev types.Evidence) bool { return types.HasLabels(ev.Labels, selector) },
+		func(finding types.Finding) bool { return findingHasLabels(finding, selector) })
+}
+
+// findingHasLabels reports whether the finding carries every label in selector
+func findingHasLabels(finding types.Finding, selector map[string]string) bool {
+	labels := make(map[string]bool, len(finding.Labels))
+	for _, label := range finding.Labels {
+		labels[label] = true
+	}
+	for key, value := range selector {
+		if !labels[types.FormatLabel(key, value)] {
+			return false
+		}
+	}
+	return true
+}
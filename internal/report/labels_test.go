@@ -0,0 +1,88 @@
+package report
+
+import (
+	"testing"
+
+	"github.com/pickjonathan/sdek-cli/pkg/types"
+)
+
+// labelsTestReport builds a report with evidence and findings from prod and staging
+func labelsTestReport(t *testing.T) *Report {
+	t.Helper()
+
+	prod := map[string]string{"env": "prod", "team": "platform"}
+	staging := map[string]string{"env": "staging", "team": "platform"}
+
+	frameworks := []types.Framework{{ID: "soc2", Name: "SOC 2"}}
+	controls := []types.Control{
+		{ID: "CC6.1", FrameworkID: "soc2", RiskStatus: "green"},
+		{ID: "CC6.2", FrameworkID: "soc2", RiskStatus: "yellow"},
+	}
+	evidence := []types.Evidence{
+		{ID: "ev-1", ControlID: "CC6.1", FrameworkID: "soc2", ConfidenceScore: 90, Labels: prod},
+		{ID: "ev-2", ControlID: "CC6.1", FrameworkID: "soc2", ConfidenceScore: 80, Labels: staging},
+		{ID: "ev-3", ControlID: "CC6.2", FrameworkID: "soc2", ConfidenceScore: 70},
+	}
+	findings := []types.Finding{
+		{ID: "f-1", ControlID: "CC6.1", FrameworkID: "soc2", Severity: types.SeverityHigh, Status: types.StatusOpen, Labels: types.LabelPairs(prod, staging)},
+		{ID: "f-2", ControlID: "CC6.1", FrameworkID: "soc2", Severity: types.SeverityLow, Status: types.StatusOpen, Labels: types.LabelPairs(staging)},
+		{ID: "f-3", ControlID: "CC6.2", FrameworkID: "soc2", Severity: types.SeverityMedium, Status: types.StatusOpen},
+	}
+
+	report, err := NewExporter("1.0.0").GenerateReport(nil, nil, frameworks, controls, evidence, findings, "")
+	if err != nil {
+		t.Fatalf("GenerateReport failed: %v", err)
+	}
+	return report
+}
+
+// TestFilterByLabels verifies only evidence and findings with the selected labels remain
+func TestFilterByLabels(t *testing.T) {
+	report := labelsTestReport(t)
+	filtered := FilterByLabels(report, map[string]string{"env": "prod"})
+
+	var evidenceIDs []string
+	for _, fw := range filtered.Frameworks {
+		for _, ctrl := range fw.Controls {
+			for _, ev := range ctrl.Evidence {
+				evidenceIDs = append(evidenceIDs, ev.ID)
+			}
+		}
+	}
+	if len(evidenceIDs) != 1 || evidenceIDs[0] != "ev-1" {
+		t.Errorf("Expected only ev-1, got %v", evidenceIDs)
+	}
+
+	if len(filtered.Findings) != 1 || filtered.Findings[0].ID != "f-1" {
+		t.Fatalf("Expected only f-1, got %+v", filtered.Findings)
+	}
+	if filtered.Summary.TotalEvidence != 1 {
+		t.Errorf("Expected TotalEvidence 1, got %d", filtered.Summary.TotalEvidence)
+	}
+	if filtered.Summary.TotalFindings != 1 || filtered.Summary.HighFindings != 1 || filtered.Summary.MediumFindings != 0 || filtered.Summary.LowFindings != 0 {
+		t.Errorf("Unexpected finding counts: %+v", filtered.Summary)
+	}
+
+	// The original report is unchanged
+	if len(report.Findings) != 3 {
+		t.Errorf("Original report modified: %d findings", len(report.Findings))
+	}
+}
+
+// TestFilterByLabels_AllMustMatch verifies every label in the selector is required
+func TestFilterByLabels_AllMustMatch(t *testing.T) {
+	filtered := FilterByLabels(labelsTestReport(t), map[string]string{"env": "staging", "team": "platform"})
+
+	var ids []string
+	for _, finding := range filtered.Findings {
+		ids = append(ids, finding.ID)
+	}
+	if len(ids) != 2 || ids[0] != "f-1" || ids[1] != "f-2" {
+		t.Errorf("Expected f-1 and f-2, got %v", ids)
+	}
+
+	filtered = FilterByLabels(labelsTestReport(t), map[string]string{"env": "staging", "team": "security"})
+	if len(filtered.Findings) != 0 || filtered.Summary.TotalEvidence != 0 {
+		t.Errorf("Expected nothing to match, got %d findings and %d evidence", len(filtered.Findings), filtered.Summary.TotalEvidence)
+	}
+}
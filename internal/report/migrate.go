@@ -0,0 +1,80 @@
+package report
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/pickjonathan/sdek-cli/pkg/types"
+)
+
+// Migrate parses a JSON report written by any supported version of sdek and
+// upgrades it to the current schema. Reports without metadata.schema_version
+// are treated as version 1. Reports from a newer version are rejected rather
+// than silently losing fields.
+func Migrate(data []byte) (*Report, error) {
+	var report Report
+	if err := json.Unmarshal(data, &report); err != nil {
+		return nil, fmt.Errorf("failed to parse report: %w", err)
+	}
+
+	version, err := checkSchemaVersion(report.Metadata.SchemaVersion)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range report.Findings {
+		migrateFinding(&report.Findings[i], version)
+	}
+	for i := range report.Frameworks {
+		for j := range report.Frameworks[i].Controls {
+			findings := report.Frameworks[i].Controls[j].Findings
+			for k := range findings {
+				migrateFinding(&findings[k], version)
+			}
+		}
+	}
+
+	report.Metadata.SchemaVersion = types.SchemaVersion
+	return &report, nil
+}
+
+// migrateFindings upgrades findings loaded from a findings file. Each finding
+// carries its own schema_version, since files may mix output from several runs.
+func migrateFindings(findings []types.Finding) error {
+	for i := range findings {
+		version, err := checkSchemaVersion(findings[i].SchemaVersion)
+		if err != nil {
+			return err
+		}
+		migrateFinding(&findings[i], version)
+		findings[i].SchemaVersion = types.SchemaVersion
+	}
+	return nil
+}
+
+// checkSchemaVersion maps a missing version to 1 and rejects versions newer than this build
+func checkSchemaVersion(version int) (int, error) {
+	if version == 0 {
+		return 1, nil
+	}
+	if version > types.SchemaVersion {
+		return 0, fmt.Errorf("schema version %d is newer than the supported version %d, upgrade sdek", version, types.SchemaVersion)
+	}
+	return version, nil
+}
+
+// migrateFinding upgrades a finding written with the given schema version in place
+func migrateFinding(f *types.Finding, version int) {
+	if version < 2 {
+		// Version 1 output could omit status and leave the list fields null
+		if f.Status == "" {
+			f.Status = types.StatusOpen
+		}
+		if f.MappedControls == nil {
+			f.MappedControls = []string{}
+		}
+		if f.Citations == nil {
+			f.Citations = []string{}
+		}
+	}
+}
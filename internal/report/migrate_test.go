@@ -0,0 +1,152 @@
+package report
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/pickjonathan/sdek-cli/pkg/types"
+)
+
+// v1Report is a report as written before schema versioning: no schema_version,
+// findings without status and with null list fields
+const v1Report = `{
+  "metadata": {"generated_at": "2025-01-15T10:00:00Z", "version": "0.9.0", "role": "compliance_manager"},
+  "summary": {"total_findings": 1, "high_findings": 1},
+  "frameworks": [
+    {
+      "framework": {"id": "soc2"},
+      "controls": [
+        {"control": {"id": "CC6.1"}, "evidence": [], "findings": [{"id": "f1", "control_id": "CC6.1", "severity": "high", "citations": null}]}
+      ]
+    }
+  ],
+  "findings": [{"id": "f1", "control_id": "CC6.1", "severity": "high", "citations": null}]
+}`
+
+func TestMigrate_V1Report(t *testing.T) {
+	report, err := Migrate([]byte(v1Report))
+	if err != nil {
+		t.Fatalf("Migrate() error = %v", err)
+	}
+
+	if report.Metadata.SchemaVersion != types.SchemaVersion {
+		t.Errorf("Expected schema version %d, got %d", types.SchemaVersion, report.Metadata.SchemaVersion)
+	}
+	if report.Metadata.Version != "0.9.0" || report.Summary.HighFindings != 1 {
+		t.Errorf("Expected existing fields to be preserved, got %+v", report.Metadata)
+	}
+
+	nested := report.Frameworks[0].Controls[0].Findings[0]
+	for _, f := range []types.Finding{report.Findings[0], nested} {
+		if f.Status != types.StatusOpen {
+			t.Errorf("Expected status %q, got %q", types.StatusOpen, f.Status)
+		}
+		if f.Citations == nil || f.MappedControls == nil {
+			t.Error("Expected null list fields to become empty slices")
+		}
+		if f.Severity != types.SeverityHigh {
+			t.Errorf("Expected severity to be preserved, got %q", f.Severity)
+		}
+	}
+}
+
+func TestMigrate_CurrentVersionUnchanged(t *testing.T) {
+	data := `{"metadata": {"schema_version": 2}, "findings": [{"id": "f1", "status": "waived", "status_reason": "accepted"}]}`
+
+	report, err := Migrate([]byte(data))
+	if err != nil {
+		t.Fatalf("Migrate() error = %v", err)
+	}
+
+	if report.Findings[0].Status != types.StatusWaived {
+		t.Errorf("Expected status to be preserved, got %q", report.Findings[0].Status)
+	}
+}
+
+func TestMigrate_RejectsNewerVersion(t *testing.T) {
+	_, err := Migrate([]byte(`{"metadata": {"schema_version": 99}}`))
+	if err == nil || !strings.Contains(err.Error(), "newer than the supported version") {
+		t.Errorf("Expected newer-version error, got %v", err)
+	}
+}
+
+func TestLoadReport_MigratesV1Report(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "report.json")
+	if err := os.WriteFile(path, []byte(v1Report), 0644); err != nil {
+		t.Fatalf("Failed to write report: %v", err)
+	}
+
+	report, err := LoadReport(path)
+	if err != nil {
+		t.Fatalf("LoadReport() error = %v", err)
+	}
+
+	if report.Metadata.SchemaVersion != types.SchemaVersion {
+		t.Errorf("Expected schema version %d, got %d", types.SchemaVersion, report.Metadata.SchemaVersion)
+	}
+	if status := report.Frameworks[0].Controls[0].Findings[0].Status; status != types.StatusOpen {
+		t.Errorf("Expected status %q, got %q", types.StatusOpen, status)
+	}
+}
+
+// TestLoadReport_VerifiesV1SignedReport verifies a report signed before migration
+// still verifies after LoadReport upgrades it, and tampering is still detected
+func TestLoadReport_VerifiesV1SignedReport(t *testing.T) {
+	dir := t.TempDir()
+	var written Report
+	if err := json.Unmarshal([]byte(v1Report), &written); err != nil {
+		t.Fatalf("Failed to parse report: %v", err)
+	}
+	if err := Sign(&written, ""); err != nil {
+		t.Fatalf("Failed to sign report: %v", err)
+	}
+	data, err := json.Marshal(&written)
+	if err != nil {
+		t.Fatalf("Failed to marshal report: %v", err)
+	}
+	path := filepath.Join(dir, "report.json")
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		t.Fatalf("Failed to write report: %v", err)
+	}
+
+	report, err := LoadReport(path)
+	if err != nil {
+		t.Fatalf("LoadReport() error = %v", err)
+	}
+	if err := Verify(report, ""); err != nil {
+		t.Errorf("Expected the v1 report to verify after migration, got %v", err)
+	}
+
+	report.Findings[0].Severity = types.SeverityLow
+	if err := Verify(report, ""); !errors.Is(err, ErrReportTampered) {
+		t.Errorf("Expected ErrReportTampered after tampering with a finding, got %v", err)
+	}
+}
+
+func TestLoadFindingsFile_MigratesV1Findings(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "findings.json")
+	if err := os.WriteFile(path, []byte(`[{"id": "f1", "control_id": "CC6.1"}, {"id": "f2", "status": "resolved", "schema_version": 2}]`), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	file, err := LoadFindingsFile(path)
+	if err != nil {
+		t.Fatalf("LoadFindingsFile() error = %v", err)
+	}
+
+	if file.Findings[0].Status != types.StatusOpen {
+		t.Errorf("Expected v1 finding to default to %q, got %q", types.StatusOpen, file.Findings[0].Status)
+	}
+	if file.Findings[1].Status != types.StatusResolved {
+		t.Errorf("Expected v2 finding status to be preserved, got %q", file.Findings[1].Status)
+	}
+	for _, f := range file.Findings {
+		if f.SchemaVersion != types.SchemaVersion {
+			t.Errorf("%s: expected schema version %d, got %d", f.ID, types.SchemaVersion, f.SchemaVersion)
+		}
+	}
+}
@@ -0,0 +1,132 @@
+package report
+
+import (
+	"sort"
+
+	"github.com/pickjonathan/sdek-cli/pkg/types"
+)
+
+// ControlRef identifies a control within a framework
+type ControlRef struct {
+	FrameworkID string `json:"framework_id"`
+	ControlID   string `json:"control_id"`
+}
+
+// less orders refs by framework, then control
+func (r ControlRef) less(other ControlRef) bool {
+	if r.FrameworkID != other.FrameworkID {
+		return r.FrameworkID < other.FrameworkID
+	}
+	return r.ControlID < other.ControlID
+}
+
+// ControlRelation is an undirected edge between two related controls. From
+// always sorts before To.
+type ControlRelation struct {
+	From ControlRef `json:"from"`
+	To   ControlRef `json:"to"`
+
+	// SharedEvents lists the events mapped as evidence to both controls
+	SharedEvents []string `json:"shared_events,omitempty"`
+
+	// CrossReferenced is true when a finding for one control names the other
+	// as a related section or mapped control
+	CrossReferenced bool `json:"cross_referenced"`
+}
+
+// GetRelatedControls builds the related-controls graph of a report: controls
+// are linked when their evidence comes from the same event, or when a finding
+// for one cross-references the other. Cross-references are resolved within
+// the finding's framework. Edges are sorted by From, then To.
+func GetRelatedControls(report *Report) []ControlRelation {
+	if report == nil {
+		return nil
+	}
+
+	type edgeKey struct{ from, to ControlRef }
+	edges := make(map[edgeKey]*ControlRelation)
+	edge := func(a, b ControlRef) *ControlRelation {
+		if b.less(a) {
+			a, b = b, a
+		}
+		key := edgeKey{a, b}
+		if edges[key] == nil {
+			edges[key] = &ControlRelation{From: a, To: b}
+		}
+		return edges[key]
+	}
+
+	// Controls whose evidence shares an event
+	eventControls := make(map[string][]ControlRef)
+	var eventIDs []string
+	for _, fwReport := range report.Frameworks {
+		for _, cr := range fwReport.Controls {
+			ref := ControlRef{FrameworkID: fwReport.Framework.ID, ControlID: cr.Control.ID}
+			seen := make(map[string]bool)
+			for _, ev := range cr.Evidence {
+				if ev.EventID == "" || seen[ev.EventID] {
+					continue
+				}
+				seen[ev.EventID] = true
+				if eventControls[ev.EventID] == nil {
+					eventIDs = append(eventIDs, ev.EventID)
+				}
+				eventControls[ev.EventID] = append(eventControls[ev.EventID], ref)
+			}
+		}
+	}
+	sort.Strings(eventIDs)
+	for _, eventID := range eventIDs {
+		refs := eventControls[eventID]
+		for i := range refs {
+			for j := i + 1; j < len(refs); j++ {
+				if refs[i] == refs[j] {
+					continue
+				}
+				e := edge(refs[i], refs[j])
+				e.SharedEvents = append(e.SharedEvents, eventID)
+			}
+		}
+	}
+
+	// Controls cross-referenced by findings
+	for _, finding := range report.Findings {
+		if finding.ControlID == "" {
+			continue
+		}
+		frameworkID := reportFrameworkID(report, finding.FrameworkID)
+		from := ControlRef{FrameworkID: frameworkID, ControlID: finding.ControlID}
+		for _, list := range [][]string{finding.RelatedControls, finding.MappedControls} {
+			for _, controlID := range list {
+				if controlID == "" || controlID == finding.ControlID {
+					continue
+				}
+				edge(from, ControlRef{FrameworkID: frameworkID, ControlID: controlID}).CrossReferenced = true
+			}
+		}
+	}
+
+	relations := make([]ControlRelation, 0, len(edges))
+	for _, e := range edges {
+		relations = append(relations, *e)
+	}
+	sort.Slice(relations, func(i, j int) bool {
+		a, b := relations[i], relations[j]
+		if a.From != b.From {
+			return a.From.less(b.From)
+		}
+		return a.To.less(b.To)
+	})
+	return relations
+}
+
+// reportFrameworkID returns the ID the report uses for frameworkID, so findings
+// written as "SOC2" line up with a report framework "soc2"
+func reportFrameworkID(report *Report, frameworkID string) string {
+	for _, fwReport := range report.Frameworks {
+		if types.SameFramework(fwReport.Framework.ID, frameworkID) {
+			return fwReport.Framework.ID
+		}
+	}
+	return frameworkID
+}
@@ -0,0 +1,114 @@
+package report
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/pickjonathan/sdek-cli/pkg/types"
+)
+
+// relatedTestReport builds a SOC 2 report where CC6.1 and CC6.2 share an event
+// and an AI finding for CC6.1 names CC7.2 as a related section
+func relatedTestReport(t *testing.T) *Report {
+	t.Helper()
+
+	frameworks := []types.Framework{{ID: types.FrameworkSOC2, Name: "SOC 2"}}
+	controls := []types.Control{
+		{ID: "CC6.1", FrameworkID: types.FrameworkSOC2},
+		{ID: "CC6.2", FrameworkID: types.FrameworkSOC2},
+		{ID: "CC7.2", FrameworkID: types.FrameworkSOC2},
+	}
+	evidence := []types.Evidence{
+		{ID: "ev-1", ControlID: "CC6.1", FrameworkID: types.FrameworkSOC2, EventID: "evt-1"},
+		{ID: "ev-2", ControlID: "CC6.2", FrameworkID: types.FrameworkSOC2, EventID: "evt-1"},
+		{ID: "ev-3", ControlID: "CC7.2", FrameworkID: types.FrameworkSOC2, EventID: "evt-2"},
+	}
+	findings := []types.Finding{
+		{
+			ID:              "f-1",
+			ControlID:       "CC6.1",
+			FrameworkID:     "SOC2",
+			MappedControls:  []string{"CC6.1"},
+			RelatedControls: []string{"CC7.2"},
+		},
+	}
+
+	report, err := NewExporter("1.0.0").GenerateReport(nil, nil, frameworks, controls, evidence, findings, "")
+	if err != nil {
+		t.Fatalf("GenerateReport failed: %v", err)
+	}
+	return report
+}
+
+// TestGetRelatedControls verifies shared evidence and related sections produce edges
+func TestGetRelatedControls(t *testing.T) {
+	got := GetRelatedControls(relatedTestReport(t))
+
+	want := []ControlRelation{
+		{
+			From:         ControlRef{FrameworkID: types.FrameworkSOC2, ControlID: "CC6.1"},
+			To:           ControlRef{FrameworkID: types.FrameworkSOC2, ControlID: "CC6.2"},
+			SharedEvents: []string{"evt-1"},
+		},
+		{
+			From:            ControlRef{FrameworkID: types.FrameworkSOC2, ControlID: "CC6.1"},
+			To:              ControlRef{FrameworkID: types.FrameworkSOC2, ControlID: "CC7.2"},
+			CrossReferenced: true,
+		},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("GetRelatedControls() = %+v, want %+v", got, want)
+	}
+}
+
+// TestGetRelatedControls_CrossFramework verifies an event mapped to two frameworks links their controls
+func TestGetRelatedControls_CrossFramework(t *testing.T) {
+	frameworks := []types.Framework{{ID: types.FrameworkSOC2}, {ID: types.FrameworkISO27001}}
+	controls := []types.Control{
+		{ID: "CC6.1", FrameworkID: types.FrameworkSOC2},
+		{ID: "A.5.15", FrameworkID: types.FrameworkISO27001},
+	}
+	evidence := []types.Evidence{
+		{ID: "ev-1", ControlID: "CC6.1", FrameworkID: types.FrameworkSOC2, EventID: "evt-1"},
+		{ID: "ev-2", ControlID: "A.5.15", FrameworkID: types.FrameworkISO27001, EventID: "evt-1"},
+		{ID: "ev-3", ControlID: "A.5.15", FrameworkID: types.FrameworkISO27001, EventID: "evt-1"},
+	}
+	report, err := NewExporter("1.0.0").GenerateReport(nil, nil, frameworks, controls, evidence, nil, "")
+	if err != nil {
+		t.Fatalf("GenerateReport failed: %v", err)
+	}
+
+	got := GetRelatedControls(report)
+
+	if len(got) != 1 {
+		t.Fatalf("Expected 1 edge, got %d: %+v", len(got), got)
+	}
+	if got[0].From.FrameworkID != types.FrameworkISO27001 || got[0].To.FrameworkID != types.FrameworkSOC2 {
+		t.Errorf("Expected an edge from ISO 27001 to SOC 2, got %+v", got[0])
+	}
+	if !reflect.DeepEqual(got[0].SharedEvents, []string{"evt-1"}) {
+		t.Errorf("Expected evt-1 to be shared once, got %v", got[0].SharedEvents)
+	}
+}
+
+// TestGetRelatedControls_NoRelations verifies unrelated controls produce no edges
+func TestGetRelatedControls_NoRelations(t *testing.T) {
+	if got := GetRelatedControls(&Report{}); len(got) != 0 {
+		t.Errorf("Expected no edges, got %+v", got)
+	}
+	if got := GetRelatedControls(nil); got != nil {
+		t.Errorf("Expected nil for a nil report, got %+v", got)
+	}
+}
+
+// TestGenerateHTMLContent_RelatedControls verifies the related-controls graph is embedded in the HTML report
+func TestGenerateHTMLContent_RelatedControls(t *testing.T) {
+	html := generateHTMLContent(*relatedTestReport(t))
+
+	for _, want := range []string{"const relatedControls = [", `"shared_events":["evt-1"]`, "Related Controls"} {
+		if !strings.Contains(html, want) {
+			t.Errorf("Expected HTML to contain %q", want)
+		}
+	}
+}
@@ -0,0 +1,38 @@
+package report
+
+import (
+	"github.com/pickjonathan/sdek-cli/pkg/types"
+)
+
+// FilterReviewRequired returns a copy of the report as a reviewer worklist:
+// only findings flagged ReviewRequired, and only the controls (with their
+// evidence) and frameworks those findings belong to. Summary totals, severity
+// counts, and compliance percentages are recomputed over what remains.
+func FilterReviewRequired(report *Report) *Report {
+	filtered := filterReport(report,
+		func(types.Evidence) bool { return true },
+		func(finding types.Finding) bool { return finding.ReviewRequired })
+
+	// Drop controls without review findings, and frameworks left without controls
+	frameworks := make([]FrameworkReport, 0, len(filtered.Frameworks))
+	for _, fw := range filtered.Frameworks {
+		var controls []ControlReport
+		for _, ctrl := range fw.Controls {
+			if len(ctrl.Findings) == 0 {
+				filtered.Summary.TotalEvidence -= len(ctrl.Evidence)
+				continue
+			}
+			controls = append(controls, ctrl)
+		}
+		if len(controls) == 0 {
+			continue
+		}
+
+		fw.Controls = controls
+		frameworks = append(frameworks, fw)
+	}
+	filtered.Frameworks = frameworks
+
+	recomputeSummary(filtered)
+	return filtered
+}
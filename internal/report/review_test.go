@@ -0,0 +1,102 @@
+package report
+
+import (
+	"testing"
+
+	"github.com/pickjonathan/sdek-cli/pkg/types"
+)
+
+// reviewTestReport builds a report across two frameworks where only CC6.1 has
+// findings flagged for review
+func reviewTestReport(t *testing.T) *Report {
+	t.Helper()
+
+	frameworks := []types.Framework{{ID: "soc2", Name: "SOC 2"}, {ID: "iso27001", Name: "ISO 27001"}}
+	controls := []types.Control{
+		{ID: "CC6.1", FrameworkID: "soc2", RiskStatus: "yellow"},
+		{ID: "CC6.2", FrameworkID: "soc2", RiskStatus: "green"},
+		{ID: "A.9.4.2", FrameworkID: "iso27001", RiskStatus: "red"},
+	}
+	evidence := []types.Evidence{
+		{ID: "ev-1", ControlID: "CC6.1", FrameworkID: "soc2"},
+		{ID: "ev-2", ControlID: "CC6.1", FrameworkID: "soc2"},
+		{ID: "ev-3", ControlID: "CC6.2", FrameworkID: "soc2"},
+		{ID: "ev-4", ControlID: "A.9.4.2", FrameworkID: "iso27001"},
+	}
+	findings := []types.Finding{
+		{ID: "f-1", ControlID: "CC6.1", FrameworkID: "soc2", Severity: types.SeverityHigh, Status: types.StatusOpen, ReviewRequired: true},
+		{ID: "f-2", ControlID: "CC6.1", FrameworkID: "soc2", Severity: types.SeverityLow, Status: types.StatusOpen},
+		{ID: "f-3", ControlID: "CC6.2", FrameworkID: "soc2", Severity: types.SeverityMedium, Status: types.StatusOpen},
+		{ID: "f-4", ControlID: "A.9.4.2", FrameworkID: "iso27001", Severity: types.SeverityCritical, Status: types.StatusOpen},
+	}
+
+	report, err := NewExporter("1.0.0").GenerateReport(nil, nil, frameworks, controls, evidence, findings, "")
+	if err != nil {
+		t.Fatalf("GenerateReport failed: %v", err)
+	}
+	return report
+}
+
+// TestFilterReviewRequired verifies only review findings and their controls remain
+func TestFilterReviewRequired(t *testing.T) {
+	report := reviewTestReport(t)
+	filtered := FilterReviewRequired(report)
+
+	if len(filtered.Findings) != 1 || filtered.Findings[0].ID != "f-1" {
+		t.Fatalf("Expected only f-1, got %+v", filtered.Findings)
+	}
+	if len(filtered.Frameworks) != 1 || filtered.Frameworks[0].Framework.ID != "soc2" {
+		t.Fatalf("Expected only the soc2 framework, got %+v", filtered.Frameworks)
+	}
+	controls := filtered.Frameworks[0].Controls
+	if len(controls) != 1 || controls[0].Control.ID != "CC6.1" {
+		t.Fatalf("Expected only control CC6.1, got %+v", controls)
+	}
+	if len(controls[0].Findings) != 1 || controls[0].Findings[0].ID != "f-1" {
+		t.Errorf("Expected CC6.1 to keep only f-1, got %+v", controls[0].Findings)
+	}
+	if len(controls[0].Evidence) != 2 {
+		t.Errorf("Expected CC6.1 to keep its 2 evidence, got %d", len(controls[0].Evidence))
+	}
+
+	// The original report is unchanged
+	if len(report.Findings) != 4 || len(report.Frameworks) != 2 {
+		t.Errorf("Original report modified: %d findings, %d frameworks", len(report.Findings), len(report.Frameworks))
+	}
+}
+
+// TestFilterReviewRequired_RecomputesSummary verifies totals cover only the worklist
+func TestFilterReviewRequired_RecomputesSummary(t *testing.T) {
+	filtered := FilterReviewRequired(reviewTestReport(t))
+	summary := filtered.Summary
+
+	if summary.TotalFindings != 1 || summary.HighFindings != 1 || summary.MediumFindings != 0 || summary.LowFindings != 0 || summary.CriticalFindings != 0 {
+		t.Errorf("Unexpected finding counts: %+v", summary)
+	}
+	if summary.TotalFrameworks != 1 || summary.TotalControls != 1 || summary.TotalEvidence != 2 {
+		t.Errorf("Expected 1 framework, 1 control, 2 evidence, got %d, %d, %d", summary.TotalFrameworks, summary.TotalControls, summary.TotalEvidence)
+	}
+	if summary.OverallCompliance != 0 {
+		t.Errorf("Expected 0%% compliance (CC6.1 is yellow), got %.1f", summary.OverallCompliance)
+	}
+
+	// CC6.1 keeps only the open high finding: 1.0 - 0.5 credit
+	if summary.WeightedCompliance != 50 || filtered.Frameworks[0].WeightedCompliance != 50 {
+		t.Errorf("Expected weighted compliance 50, got summary %.1f and framework %.1f", summary.WeightedCompliance, filtered.Frameworks[0].WeightedCompliance)
+	}
+}
+
+// TestFilterReviewRequired_NothingToReview verifies an empty worklist has zero totals
+func TestFilterReviewRequired_NothingToReview(t *testing.T) {
+	report := reviewTestReport(t)
+	report.Findings[0].ReviewRequired = false
+	report.Frameworks[0].Controls[0].Findings[0].ReviewRequired = false
+
+	filtered := FilterReviewRequired(report)
+	if len(filtered.Findings) != 0 || len(filtered.Frameworks) != 0 {
+		t.Errorf("Expected an empty worklist, got %d findings and %d frameworks", len(filtered.Findings), len(filtered.Frameworks))
+	}
+	if filtered.Summary.TotalControls != 0 || filtered.Summary.TotalEvidence != 0 || filtered.Summary.TotalFindings != 0 {
+		t.Errorf("Expected zero totals, got %+v", filtered.Summary)
+	}
+}
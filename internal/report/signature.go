@@ -0,0 +1,251 @@
+package report
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"os"
+)
+
+// Integrity algorithms
+const (
+	IntegrityHashSHA256       = "sha256"
+	IntegritySignatureEd25519 = "ed25519"
+)
+
+// Verification errors
+var (
+	// ErrReportUnsigned indicates the report has no integrity hash
+	ErrReportUnsigned = errors.New("report: no integrity hash, sign the report first")
+
+	// ErrReportTampered indicates the report content no longer matches its integrity hash
+	ErrReportTampered = errors.New("report: content does not match integrity hash")
+
+	// ErrSignatureInvalid indicates the report signature is missing or was not made by the given key
+	ErrSignatureInvalid = errors.New("report: signature verification failed")
+)
+
+// ReportIntegrity holds a report's content hash and optional signature.
+// The hash covers the canonical JSON of the whole report as written, including
+// fields sdek does not know, with this field removed.
+type ReportIntegrity struct {
+	HashAlgorithm      string `json:"hash_algorithm"`
+	Hash               string `json:"hash"`
+	SignatureAlgorithm string `json:"signature_algorithm,omitempty"`
+	Signature          string `json:"signature,omitempty"` // Base64 signature over the hash bytes
+}
+
+// LoadReport reads a JSON report from disk, upgrading reports written with an
+// older schema version (see Migrate). The file content is kept so Verify can
+// check a signature made before the upgrade.
+func LoadReport(path string) (*Report, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read report: %w", err)
+	}
+
+	report, err := Migrate(data)
+	if err != nil {
+		return nil, err
+	}
+	report.original = data
+
+	return report, nil
+}
+
+// CanonicalHash returns the SHA256 of the report's canonical JSON form (see
+// canonicalJSONHash), as the report would be written
+func CanonicalHash(report *Report) ([]byte, error) {
+	data, err := json.Marshal(report)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal report: %w", err)
+	}
+	return canonicalJSONHash(data)
+}
+
+// canonicalJSONHash returns the SHA256 of report JSON in canonical form: sorted
+// object keys and no insignificant whitespace, without metadata.integrity so the
+// hash can be stored in the report. It works on generic values rather than the
+// Report struct, so every field written is covered, whether or not this version
+// of sdek knows it.
+func canonicalJSONHash(data []byte) ([]byte, error) {
+	// encoding/json sorts map keys on output, and UseNumber keeps numbers
+	// exactly as they were written
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	var generic map[string]interface{}
+	if err := decoder.Decode(&generic); err != nil {
+		return nil, fmt.Errorf("failed to canonicalize report: %w", err)
+	}
+	if metadata, ok := generic["metadata"].(map[string]interface{}); ok {
+		delete(metadata, "integrity")
+	}
+
+	canonical, err := json.Marshal(generic)
+	if err != nil {
+		return nil, fmt.Errorf("failed to canonicalize report: %w", err)
+	}
+
+	sum := sha256.Sum256(canonical)
+	return sum[:], nil
+}
+
+// Sign computes the report's canonical hash and stores it in the report metadata.
+// If privateKeyPath is set, the hash is also signed with that Ed25519 key (PKCS#8 PEM).
+func Sign(report *Report, privateKeyPath string) error {
+	hash, err := CanonicalHash(report)
+	if err != nil {
+		return err
+	}
+
+	integrity := &ReportIntegrity{
+		HashAlgorithm: IntegrityHashSHA256,
+		Hash:          hex.EncodeToString(hash),
+	}
+
+	if privateKeyPath != "" {
+		key, err := loadPrivateKey(privateKeyPath)
+		if err != nil {
+			return err
+		}
+		integrity.SignatureAlgorithm = IntegritySignatureEd25519
+		integrity.Signature = base64.StdEncoding.EncodeToString(ed25519.Sign(key, hash))
+	}
+
+	report.Metadata.Integrity = integrity
+	report.original = nil // The hash now covers the report as it is
+	return nil
+}
+
+// Verify checks that the report content matches its integrity hash.
+// If publicKeyPath is set, the report must also carry a valid signature from the
+// matching Ed25519 key (PKIX PEM); otherwise only the hash is checked.
+func Verify(report *Report, publicKeyPath string) error {
+	integrity := report.Metadata.Integrity
+	if integrity == nil || integrity.Hash == "" {
+		return ErrReportUnsigned
+	}
+	if integrity.HashAlgorithm != IntegrityHashSHA256 {
+		return fmt.Errorf("unsupported hash algorithm: %s", integrity.HashAlgorithm)
+	}
+
+	hash, err := integrityHash(report)
+	if err != nil {
+		return err
+	}
+	if hex.EncodeToString(hash) != integrity.Hash {
+		return ErrReportTampered
+	}
+
+	if publicKeyPath == "" {
+		return nil
+	}
+
+	key, err := loadPublicKey(publicKeyPath)
+	if err != nil {
+		return err
+	}
+
+	if integrity.Signature == "" {
+		return fmt.Errorf("%w: report is not signed", ErrSignatureInvalid)
+	}
+	if integrity.SignatureAlgorithm != IntegritySignatureEd25519 {
+		return fmt.Errorf("unsupported signature algorithm: %s", integrity.SignatureAlgorithm)
+	}
+
+	signature, err := base64.StdEncoding.DecodeString(integrity.Signature)
+	if err != nil {
+		return fmt.Errorf("%w: malformed signature: %v", ErrSignatureInvalid, err)
+	}
+	if !ed25519.Verify(key, hash, signature) {
+		return ErrSignatureInvalid
+	}
+
+	return nil
+}
+
+// integrityHash returns the hash Verify checks against the stored one: that of
+// the file content, when the report was loaded by LoadReport and is unchanged
+// since. Hashing the content rather than the decoded report covers fields the
+// Report struct drops, and keeps verifying reports written before migration or
+// before fields were added to the struct.
+func integrityHash(report *Report) ([]byte, error) {
+	hash, err := CanonicalHash(report)
+	if err != nil || report.original == nil {
+		return hash, err
+	}
+
+	loaded, err := Migrate(report.original)
+	if err != nil {
+		return nil, err
+	}
+	loadedHash, err := CanonicalHash(loaded)
+	if err != nil {
+		return nil, err
+	}
+	if !bytes.Equal(hash, loadedHash) {
+		// Changed after loading: verify what the report holds now
+		return hash, nil
+	}
+
+	return canonicalJSONHash(report.original)
+}
+
+// loadPrivateKey reads an Ed25519 private key from a PKCS#8 PEM file
+func loadPrivateKey(path string) (ed25519.PrivateKey, error) {
+	block, err := readPEM(path)
+	if err != nil {
+		return nil, err
+	}
+
+	parsed, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse private key: %w", err)
+	}
+
+	key, ok := parsed.(ed25519.PrivateKey)
+	if !ok {
+		return nil, fmt.Errorf("unsupported private key type %T, only Ed25519 keys are supported", parsed)
+	}
+	return key, nil
+}
+
+// loadPublicKey reads an Ed25519 public key from a PKIX PEM file
+func loadPublicKey(path string) (ed25519.PublicKey, error) {
+	block, err := readPEM(path)
+	if err != nil {
+		return nil, err
+	}
+
+	parsed, err := x509.ParsePKIXPublicKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse public key: %w", err)
+	}
+
+	key, ok := parsed.(ed25519.PublicKey)
+	if !ok {
+		return nil, fmt.Errorf("unsupported public key type %T, only Ed25519 keys are supported", parsed)
+	}
+	return key, nil
+}
+
+// readPEM reads the first PEM block from a file
+func readPEM(path string) (*pem.Block, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read key file: %w", err)
+	}
+
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, fmt.Errorf("no PEM data found in key file: %s", path)
+	}
+	return block, nil
+}
@@ -0,0 +1,272 @@
+package report
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/pickjonathan/sdek-cli/pkg/types"
+)
+
+// writeTestKeyPair writes an Ed25519 key pair as PEM files and returns their paths
+func writeTestKeyPair(t *testing.T, dir, name string) (string, string) {
+	t.Helper()
+
+	publicKey, privateKey, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatalf("Failed to generate key: %v", err)
+	}
+
+	privateDER, err := x509.MarshalPKCS8PrivateKey(privateKey)
+	if err != nil {
+		t.Fatalf("Failed to marshal private key: %v", err)
+	}
+	publicDER, err := x509.MarshalPKIXPublicKey(publicKey)
+	if err != nil {
+		t.Fatalf("Failed to marshal public key: %v", err)
+	}
+
+	privatePath := filepath.Join(dir, name+".pem")
+	publicPath := filepath.Join(dir, name+".pub.pem")
+	if err := os.WriteFile(privatePath, pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: privateDER}), 0600); err != nil {
+		t.Fatalf("Failed to write private key: %v", err)
+	}
+	if err := os.WriteFile(publicPath, pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: publicDER}), 0644); err != nil {
+		t.Fatalf("Failed to write public key: %v", err)
+	}
+
+	return privatePath, publicPath
+}
+
+func newSignatureTestReport(t *testing.T) *Report {
+	t.Helper()
+
+	findings := []types.Finding{
+		{ID: "f-1", ControlID: "CC6.1", FrameworkID: types.FrameworkSOC2, Title: "Missing MFA", Severity: types.SeverityHigh, Status: types.StatusOpen, CreatedAt: time.Now()},
+		{ID: "f-2", ControlID: "CC7.2", FrameworkID: types.FrameworkSOC2, Title: "Stale alerts", Severity: types.SeverityLow, Status: types.StatusOpen, CreatedAt: time.Now()},
+	}
+
+	report, err := NewExporter("1.0.0").GenerateReport(nil, nil, nil, nil, nil, findings, "")
+	if err != nil {
+		t.Fatalf("Failed to generate report: %v", err)
+	}
+	return report
+}
+
+// TestSignAndVerify verifies a signed report round-trips through disk and verifies
+func TestSignAndVerify(t *testing.T) {
+	dir := t.TempDir()
+	privatePath, publicPath := writeTestKeyPair(t, dir, "signing")
+	report := newSignatureTestReport(t)
+
+	if err := Sign(report, privatePath); err != nil {
+		t.Fatalf("Failed to sign report: %v", err)
+	}
+
+	integrity := report.Metadata.Integrity
+	if integrity == nil || integrity.Hash == "" || integrity.Signature == "" {
+		t.Fatalf("Expected hash and signature in metadata, got %+v", integrity)
+	}
+
+	reportPath := filepath.Join(dir, "report.json")
+	if err := NewExporter("1.0.0").ExportToFile(report, reportPath); err != nil {
+		t.Fatalf("Failed to write report: %v", err)
+	}
+
+	loaded, err := LoadReport(reportPath)
+	if err != nil {
+		t.Fatalf("Failed to load report: %v", err)
+	}
+
+	if err := Verify(loaded, publicPath); err != nil {
+		t.Errorf("Expected signed report to verify, got %v", err)
+	}
+	if err := Verify(loaded, ""); err != nil {
+		t.Errorf("Expected hash-only verification to pass, got %v", err)
+	}
+}
+
+// TestVerifyDetectsTamperedFinding verifies that modifying a finding invalidates the report
+func TestVerifyDetectsTamperedFinding(t *testing.T) {
+	dir := t.TempDir()
+	privatePath, publicPath := writeTestKeyPair(t, dir, "signing")
+	report := newSignatureTestReport(t)
+
+	if err := Sign(report, privatePath); err != nil {
+		t.Fatalf("Failed to sign report: %v", err)
+	}
+
+	report.Findings[0].Severity = types.SeverityLow
+
+	err := Verify(report, publicPath)
+	if !errors.Is(err, ErrReportTampered) {
+		t.Errorf("Expected ErrReportTampered after tampering with a finding, got %v", err)
+	}
+
+	// Recomputing the hash without the private key must not produce a valid signature
+	if err := Sign(report, ""); err != nil {
+		t.Fatalf("Failed to rehash report: %v", err)
+	}
+	err = Verify(report, publicPath)
+	if !errors.Is(err, ErrSignatureInvalid) {
+		t.Errorf("Expected ErrSignatureInvalid for rehashed report, got %v", err)
+	}
+}
+
+// TestVerifyRejectsOtherKey verifies a signature from a different key is rejected
+func TestVerifyRejectsOtherKey(t *testing.T) {
+	dir := t.TempDir()
+	privatePath, _ := writeTestKeyPair(t, dir, "signing")
+	_, otherPublicPath := writeTestKeyPair(t, dir, "other")
+	report := newSignatureTestReport(t)
+
+	if err := Sign(report, privatePath); err != nil {
+		t.Fatalf("Failed to sign report: %v", err)
+	}
+
+	if err := Verify(report, otherPublicPath); !errors.Is(err, ErrSignatureInvalid) {
+		t.Errorf("Expected ErrSignatureInvalid for a different key, got %v", err)
+	}
+}
+
+// TestVerifyUnsignedReport verifies an unsigned report is reported as such
+func TestVerifyUnsignedReport(t *testing.T) {
+	report := newSignatureTestReport(t)
+
+	if err := Verify(report, ""); !errors.Is(err, ErrReportUnsigned) {
+		t.Errorf("Expected ErrReportUnsigned, got %v", err)
+	}
+}
+
+// TestCanonicalHashDeterministic verifies the hash is stable and ignores formatting
+func TestCanonicalHashDeterministic(t *testing.T) {
+	report := newSignatureTestReport(t)
+
+	first, err := CanonicalHash(report)
+	if err != nil {
+		t.Fatalf("Failed to hash report: %v", err)
+	}
+
+	// Compact and indented encodings of the same report must hash identically
+	dir := t.TempDir()
+	indentedPath := filepath.Join(dir, "indented.json")
+	if err := NewExporter("1.0.0").ExportToFile(report, indentedPath); err != nil {
+		t.Fatalf("Failed to write report: %v", err)
+	}
+	loaded, err := LoadReport(indentedPath)
+	if err != nil {
+		t.Fatalf("Failed to load report: %v", err)
+	}
+
+	second, err := CanonicalHash(loaded)
+	if err != nil {
+		t.Fatalf("Failed to hash loaded report: %v", err)
+	}
+
+	if string(first) != string(second) {
+		t.Error("Expected canonical hash to be identical after a disk round trip")
+	}
+
+	// The integrity field itself is excluded from the hash
+	if err := Sign(report, ""); err != nil {
+		t.Fatalf("Failed to sign report: %v", err)
+	}
+	third, err := CanonicalHash(report)
+	if err != nil {
+		t.Fatalf("Failed to hash signed report: %v", err)
+	}
+	if string(first) != string(third) {
+		t.Error("Expected integrity metadata to be excluded from the hash")
+	}
+}
+
+// writeSignedReportJSON signs a test report, applies edit to its JSON as generic
+// values, and writes the result, returning its path
+func writeSignedReportJSON(t *testing.T, dir string, edit func(map[string]interface{})) string {
+	t.Helper()
+
+	report := newSignatureTestReport(t)
+	if err := Sign(report, ""); err != nil {
+		t.Fatalf("Failed to sign report: %v", err)
+	}
+	data, err := json.Marshal(report)
+	if err != nil {
+		t.Fatalf("Failed to marshal report: %v", err)
+	}
+	var generic map[string]interface{}
+	if err := json.Unmarshal(data, &generic); err != nil {
+		t.Fatalf("Failed to parse report: %v", err)
+	}
+	edit(generic)
+	if data, err = json.MarshalIndent(generic, "", "  "); err != nil {
+		t.Fatalf("Failed to marshal report: %v", err)
+	}
+
+	path := filepath.Join(dir, "report.json")
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		t.Fatalf("Failed to write report: %v", err)
+	}
+	return path
+}
+
+// TestVerifyDetectsAddedUnknownField verifies a field added after signing fails
+// verification even though the Report struct does not know it
+func TestVerifyDetectsAddedUnknownField(t *testing.T) {
+	for name, edit := range map[string]func(map[string]interface{}){
+		"top level": func(report map[string]interface{}) {
+			report["approved_by"] = "mallory"
+		},
+		"finding": func(report map[string]interface{}) {
+			finding := report["findings"].([]interface{})[0].(map[string]interface{})
+			finding["exception"] = "accepted"
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			loaded, err := LoadReport(writeSignedReportJSON(t, t.TempDir(), edit))
+			if err != nil {
+				t.Fatalf("Failed to load report: %v", err)
+			}
+
+			if err := Verify(loaded, ""); !errors.Is(err, ErrReportTampered) {
+				t.Errorf("Expected ErrReportTampered after adding an unknown field, got %v", err)
+			}
+		})
+	}
+}
+
+// TestVerifyReportWithFieldsUnknownToThisVersion verifies a report hashed with
+// fields the Report struct does not have, as a newer version would write,
+// still verifies
+func TestVerifyReportWithFieldsUnknownToThisVersion(t *testing.T) {
+	var data []byte
+	path := writeSignedReportJSON(t, t.TempDir(), func(report map[string]interface{}) {
+		report["future_field"] = map[string]interface{}{"enabled": true}
+
+		var err error
+		if data, err = json.Marshal(report); err != nil {
+			t.Fatalf("Failed to marshal report: %v", err)
+		}
+		hash, err := canonicalJSONHash(data)
+		if err != nil {
+			t.Fatalf("Failed to hash report: %v", err)
+		}
+		integrity := report["metadata"].(map[string]interface{})["integrity"].(map[string]interface{})
+		integrity["hash"] = hex.EncodeToString(hash)
+	})
+
+	loaded, err := LoadReport(path)
+	if err != nil {
+		t.Fatalf("Failed to load report: %v", err)
+	}
+	if err := Verify(loaded, ""); err != nil {
+		t.Errorf("Expected the report to verify, got %v", err)
+	}
+}
@@ -0,0 +1,134 @@
+package report
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/pickjonathan/sdek-cli/pkg/types"
+)
+
+// EvidencePeriod is the length of the periods a control's evidence timeline
+// is grouped into
+type EvidencePeriod string
+
+const (
+	PeriodMonth   EvidencePeriod = "month"
+	PeriodQuarter EvidencePeriod = "quarter"
+)
+
+// ParseEvidencePeriod validates a timeline period name
+func ParseEvidencePeriod(name string) (EvidencePeriod, error) {
+	switch period := EvidencePeriod(name); period {
+	case PeriodMonth, PeriodQuarter:
+		return period, nil
+	}
+	return "", fmt.Errorf("invalid timeline period '%s', must be one of: month, quarter", name)
+}
+
+// EvidenceBucket holds the evidence of a control dated within one period
+type EvidenceBucket struct {
+	Period      string    `json:"period"` // "2024-03" for months, "2024-Q1" for quarters
+	Start       time.Time `json:"start"`
+	EvidenceIDs []string  `json:"evidence_ids"`
+}
+
+// GroupEvidenceByPeriod buckets evidence by the month or quarter (UTC) of its
+// MappedAt time. Buckets run from the earliest to the latest period with
+// evidence; periods in between without evidence are included with no
+// evidence IDs, so gaps in recurring evidence stand out. Evidence without a
+// time is skipped.
+func GroupEvidenceByPeriod(evidence []types.Evidence, period EvidencePeriod) []EvidenceBucket {
+	return groupEvidenceByTime(evidence, period, func(ev types.Evidence) time.Time { return ev.MappedAt })
+}
+
+// groupEvidenceByTime is GroupEvidenceByPeriod with the time of each piece of
+// evidence given by at
+func groupEvidenceByTime(evidence []types.Evidence, period EvidencePeriod, at func(types.Evidence) time.Time) []EvidenceBucket {
+	byStart := make(map[time.Time][]string)
+	var first, last time.Time
+	for _, ev := range evidence {
+		t := at(ev)
+		if t.IsZero() {
+			continue
+		}
+		start := periodStart(t, period)
+		if len(byStart) == 0 || start.Before(first) {
+			first = start
+		}
+		if len(byStart) == 0 || start.After(last) {
+			last = start
+		}
+		byStart[start] = append(byStart[start], ev.ID)
+	}
+	if len(byStart) == 0 {
+		return nil
+	}
+
+	var buckets []EvidenceBucket
+	for start := first; !start.After(last); start = nextPeriod(start, period) {
+		ids := byStart[start]
+		if ids == nil {
+			ids = []string{}
+		}
+		buckets = append(buckets, EvidenceBucket{
+			Period:      periodLabel(start, period),
+			Start:       start,
+			EvidenceIDs: ids,
+		})
+	}
+	return buckets
+}
+
+// controlTimeline groups a control's evidence by the time of the event it was
+// mapped from (its MappedAt time if the event is unknown). Controls whose
+// evidence falls within a single period get no timeline.
+func controlTimeline(evidence []types.Evidence, eventTimes map[string]time.Time, period EvidencePeriod) []EvidenceBucket {
+	if period == "" {
+		period = PeriodMonth
+	}
+	buckets := groupEvidenceByTime(evidence, period, func(ev types.Evidence) time.Time {
+		if t, ok := eventTimes[ev.EventID]; ok && !t.IsZero() {
+			return t
+		}
+		return ev.MappedAt
+	})
+	if len(buckets) < 2 {
+		return nil
+	}
+	return buckets
+}
+
+// eventTimestamps maps event IDs to their timestamps
+func eventTimestamps(events []types.Event) map[string]time.Time {
+	times := make(map[string]time.Time, len(events))
+	for _, event := range events {
+		times[event.ID] = event.Timestamp
+	}
+	return times
+}
+
+// periodStart returns the start (UTC) of the month or quarter containing t
+func periodStart(t time.Time, period EvidencePeriod) time.Time {
+	t = t.UTC()
+	month := t.Month()
+	if period == PeriodQuarter {
+		month -= (month - 1) % 3
+	}
+	return time.Date(t.Year(), month, 1, 0, 0, 0, 0, time.UTC)
+}
+
+// nextPeriod returns the start of the period after the one starting at start
+func nextPeriod(start time.Time, period EvidencePeriod) time.Time {
+	if period == PeriodQuarter {
+		return start.AddDate(0, 3, 0)
+	}
+	return start.AddDate(0, 1, 0)
+}
+
+// periodLabel names the period starting at start, e.g. "2024-03" or "2024-Q1"
+func periodLabel(start time.Time, period EvidencePeriod) string {
+	if period == PeriodQuarter {
+		return fmt.Sprintf("%d-Q%d", start.Year(), (int(start.Month())-1)/3+1)
+	}
+	return start.Format("2006-01")
+}
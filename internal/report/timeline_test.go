@@ -0,0 +1,144 @@
+package report
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/pickjonathan/sdek-cli/pkg/types"
+)
+
+func date(year int, month time.Month, day int) time.Time {
+	return time.Date(year, month, day, 12, 0, 0, 0, time.UTC)
+}
+
+func TestGroupEvidenceByPeriod_Months(t *testing.T) {
+	evidence := []types.Evidence{
+		{ID: "ev-feb", MappedAt: date(2024, time.February, 28)},
+		{ID: "ev-jan-1", MappedAt: date(2024, time.January, 1)},
+		{ID: "ev-undated"},
+		{ID: "ev-apr", MappedAt: date(2024, time.April, 15)},
+		{ID: "ev-jan-31", MappedAt: date(2024, time.January, 31)},
+	}
+
+	buckets := GroupEvidenceByPeriod(evidence, PeriodMonth)
+
+	want := map[string][]string{
+		"2024-01": {"ev-jan-1", "ev-jan-31"},
+		"2024-02": {"ev-feb"},
+		"2024-03": {},
+		"2024-04": {"ev-apr"},
+	}
+	var periods []string
+	for _, b := range buckets {
+		periods = append(periods, b.Period)
+		if !reflect.DeepEqual(b.EvidenceIDs, want[b.Period]) {
+			t.Errorf("%s: got evidence %v, want %v", b.Period, b.EvidenceIDs, want[b.Period])
+		}
+	}
+	if got := strings.Join(periods, ","); got != "2024-01,2024-02,2024-03,2024-04" {
+		t.Errorf("got periods %s, want months January to April in order", got)
+	}
+	if !buckets[0].Start.Equal(time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("got first bucket start %v, want 2024-01-01", buckets[0].Start)
+	}
+}
+
+func TestGroupEvidenceByPeriod_Quarters(t *testing.T) {
+	evidence := []types.Evidence{
+		{ID: "ev-q4", MappedAt: date(2023, time.December, 31)},
+		{ID: "ev-q1", MappedAt: date(2024, time.March, 31)},
+		{ID: "ev-q2", MappedAt: date(2024, time.April, 1)},
+		// 23:30 on June 30 in UTC-5 is July 1 in UTC
+		{ID: "ev-q3", MappedAt: time.Date(2024, time.June, 30, 23, 30, 0, 0, time.FixedZone("EST", -5*3600))},
+	}
+
+	buckets := GroupEvidenceByPeriod(evidence, PeriodQuarter)
+
+	want := []EvidenceBucket{
+		{Period: "2023-Q4", Start: time.Date(2023, time.October, 1, 0, 0, 0, 0, time.UTC), EvidenceIDs: []string{"ev-q4"}},
+		{Period: "2024-Q1", Start: time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC), EvidenceIDs: []string{"ev-q1"}},
+		{Period: "2024-Q2", Start: time.Date(2024, time.April, 1, 0, 0, 0, 0, time.UTC), EvidenceIDs: []string{"ev-q2"}},
+		{Period: "2024-Q3", Start: time.Date(2024, time.July, 1, 0, 0, 0, 0, time.UTC), EvidenceIDs: []string{"ev-q3"}},
+	}
+	if !reflect.DeepEqual(buckets, want) {
+		t.Errorf("got buckets %+v, want %+v", buckets, want)
+	}
+}
+
+func TestGroupEvidenceByPeriod_NoDatedEvidence(t *testing.T) {
+	if buckets := GroupEvidenceByPeriod([]types.Evidence{{ID: "ev-1"}}, PeriodMonth); buckets != nil {
+		t.Errorf("got %+v, want no buckets", buckets)
+	}
+}
+
+func TestGenerateReport_ControlTimelineUsesEventTimes(t *testing.T) {
+	mapped := date(2024, time.June, 1) // every piece of evidence was mapped in the same run
+	events := []types.Event{
+		{ID: "evt-jan", Timestamp: date(2024, time.January, 10)},
+		{ID: "evt-feb", Timestamp: date(2024, time.February, 10)},
+		{ID: "evt-apr", Timestamp: date(2024, time.April, 10)},
+	}
+	frameworks := []types.Framework{{ID: types.FrameworkSOC2, Name: "SOC 2"}}
+	controls := []types.Control{
+		{ID: "CC6.2", FrameworkID: types.FrameworkSOC2},
+		{ID: "CC7.2", FrameworkID: types.FrameworkSOC2},
+	}
+	evidence := []types.Evidence{
+		{ID: "ev-1", ControlID: "CC6.2", FrameworkID: types.FrameworkSOC2, EventID: "evt-jan", MappedAt: mapped, ConfidenceScore: 90},
+		{ID: "ev-2", ControlID: "CC6.2", FrameworkID: types.FrameworkSOC2, EventID: "evt-feb", MappedAt: mapped, ConfidenceScore: 40},
+		{ID: "ev-3", ControlID: "CC6.2", FrameworkID: types.FrameworkSOC2, EventID: "evt-apr", MappedAt: mapped, ConfidenceScore: 90},
+		{ID: "ev-4", ControlID: "CC7.2", FrameworkID: types.FrameworkSOC2, EventID: "evt-jan", MappedAt: mapped, ConfidenceScore: 90},
+	}
+
+	exporter := NewExporter("1.0.0")
+	exporter.SetTimelinePeriod(PeriodQuarter)
+	report, err := exporter.GenerateReport(nil, events, frameworks, controls, evidence, nil, "")
+	if err != nil {
+		t.Fatalf("GenerateReport failed: %v", err)
+	}
+
+	recurring, single := report.Frameworks[0].Controls[0], report.Frameworks[0].Controls[1]
+	if got := timelineSummary(recurring.Timeline); got != "2024-Q1:2,2024-Q2:1" {
+		t.Errorf("CC6.2 timeline = %s, want 2024-Q1:2,2024-Q2:1", got)
+	}
+	if single.Timeline != nil {
+		t.Errorf("CC7.2 has evidence in one period, got timeline %+v", single.Timeline)
+	}
+
+	// Filtering rebuilds the timeline from the remaining evidence
+	filtered := FilterByConfidence(report, 0.5)
+	if got := timelineSummary(filtered.Frameworks[0].Controls[0].Timeline); got != "2024-Q1:1,2024-Q2:1" {
+		t.Errorf("filtered CC6.2 timeline = %s, want 2024-Q1:1,2024-Q2:1", got)
+	}
+
+	// The HTML control detail renders the embedded timeline
+	html := generateHTMLContent(*report)
+	for _, want := range []string{`"timeline":[{"period":"2024-Q1"`, "controlData.timeline", "Timeline"} {
+		if !strings.Contains(html, want) {
+			t.Errorf("Expected HTML to contain %q", want)
+		}
+	}
+}
+
+func TestParseEvidencePeriod(t *testing.T) {
+	for _, name := range []string{"month", "quarter"} {
+		if period, err := ParseEvidencePeriod(name); err != nil || string(period) != name {
+			t.Errorf("ParseEvidencePeriod(%q) = %q, %v", name, period, err)
+		}
+	}
+	if _, err := ParseEvidencePeriod("week"); err == nil || !strings.Contains(err.Error(), "must be one of: month, quarter") {
+		t.Errorf("ParseEvidencePeriod(week) error = %v", err)
+	}
+}
+
+// timelineSummary renders buckets as "period:count,..."
+func timelineSummary(buckets []EvidenceBucket) string {
+	var parts []string
+	for _, b := range buckets {
+		parts = append(parts, fmt.Sprintf("%s:%d", b.Period, len(b.EvidenceIDs)))
+	}
+	return strings.Join(parts, ",")
+}
@@ -0,0 +1,65 @@
+package report
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/pickjonathan/sdek-cli/pkg/types"
+)
+
+// statusTransitions defines the allowed finding triage transitions.
+// Findings move open → acknowledged → resolved; any unresolved finding
+// may be waived, and resolved/waived findings may be reopened.
+var statusTransitions = map[string][]string{
+	types.StatusOpen:         {types.StatusAcknowledged, types.StatusInProgress, types.StatusWaived},
+	types.StatusInProgress:   {types.StatusAcknowledged, types.StatusResolved, types.StatusWaived},
+	types.StatusAcknowledged: {types.StatusInProgress, types.StatusResolved, types.StatusWaived},
+	types.StatusResolved:     {types.StatusOpen},
+	types.StatusWaived:       {types.StatusOpen},
+}
+
+// SetFindingStatus transitions a finding to a new triage status.
+// A non-empty reason is required when waiving a finding.
+func SetFindingStatus(finding *types.Finding, status, reason string) error {
+	if finding == nil {
+		return fmt.Errorf("finding cannot be nil")
+	}
+
+	current := finding.Status
+	if current == "" {
+		current = types.StatusOpen
+	}
+
+	allowed, ok := statusTransitions[current]
+	if !ok {
+		return fmt.Errorf("finding %s has unknown status: %s", finding.ID, current)
+	}
+
+	valid := false
+	for _, s := range allowed {
+		if s == status {
+			valid = true
+			break
+		}
+	}
+	if !valid {
+		return fmt.Errorf("invalid status transition for finding %s: %s → %s (allowed: %v)", finding.ID, current, status, allowed)
+	}
+
+	reason = strings.TrimSpace(reason)
+	if status == types.StatusWaived && reason == "" {
+		return fmt.Errorf("a reason is required to waive finding %s", finding.ID)
+	}
+
+	finding.Status = status
+	finding.StatusReason = reason
+	finding.UpdatedAt = time.Now()
+
+	return nil
+}
+
+// isOpenFinding reports whether a finding still counts against compliance
+func isOpenFinding(finding types.Finding) bool {
+	return finding.Status != types.StatusResolved && finding.Status != types.StatusWaived
+}
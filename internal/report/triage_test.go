@@ -0,0 +1,180 @@
+package report
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/pickjonathan/sdek-cli/pkg/types"
+)
+
+// TestSetFindingStatusValidTransitions verifies the allowed triage workflow
+func TestSetFindingStatusValidTransitions(t *testing.T) {
+	tests := []struct {
+		name   string
+		from   string
+		to     string
+		reason string
+	}{
+		{"open to acknowledged", types.StatusOpen, types.StatusAcknowledged, ""},
+		{"acknowledged to resolved", types.StatusAcknowledged, types.StatusResolved, ""},
+		{"open to waived with reason", types.StatusOpen, types.StatusWaived, "Compensating control"},
+		{"acknowledged to waived with reason", types.StatusAcknowledged, types.StatusWaived, "Accepted risk"},
+		{"resolved reopened", types.StatusResolved, types.StatusOpen, ""},
+		{"waived reopened", types.StatusWaived, types.StatusOpen, ""},
+		{"empty status treated as open", "", types.StatusAcknowledged, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			finding := &types.Finding{ID: "f-1", Status: tt.from}
+
+			if err := SetFindingStatus(finding, tt.to, tt.reason); err != nil {
+				t.Fatalf("Expected transition %s → %s to succeed, got: %v", tt.from, tt.to, err)
+			}
+			if finding.Status != tt.to {
+				t.Errorf("Expected status %s, got %s", tt.to, finding.Status)
+			}
+			if finding.StatusReason != tt.reason {
+				t.Errorf("Expected reason %q, got %q", tt.reason, finding.StatusReason)
+			}
+			if finding.UpdatedAt.IsZero() {
+				t.Error("Expected UpdatedAt to be set")
+			}
+		})
+	}
+}
+
+// TestSetFindingStatusInvalidTransitions verifies rejected transitions leave the finding unchanged
+func TestSetFindingStatusInvalidTransitions(t *testing.T) {
+	tests := []struct {
+		name   string
+		from   string
+		to     string
+		reason string
+	}{
+		{"open to resolved skips acknowledgement", types.StatusOpen, types.StatusResolved, ""},
+		{"waived without reason", types.StatusOpen, types.StatusWaived, ""},
+		{"waived with blank reason", types.StatusOpen, types.StatusWaived, "   "},
+		{"resolved to acknowledged", types.StatusResolved, types.StatusAcknowledged, ""},
+		{"unknown target status", types.StatusOpen, "closed", ""},
+		{"unknown current status", "bogus", types.StatusOpen, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			finding := &types.Finding{ID: "f-1", Status: tt.from}
+
+			if err := SetFindingStatus(finding, tt.to, tt.reason); err == nil {
+				t.Fatalf("Expected transition %s → %s to fail", tt.from, tt.to)
+			}
+			if finding.Status != tt.from {
+				t.Errorf("Expected status to remain %s, got %s", tt.from, finding.Status)
+			}
+		})
+	}
+
+	if err := SetFindingStatus(nil, types.StatusAcknowledged, ""); err == nil {
+		t.Error("Expected error for nil finding")
+	}
+}
+
+// TestCheckFailOn verifies the fail-on gate skips resolved and waived findings
+func TestCheckFailOn(t *testing.T) {
+	findings := []types.Finding{
+		{ID: "f-1", Severity: types.SeverityCritical, Status: types.StatusWaived},
+		{ID: "f-2", Severity: types.SeverityHigh, Status: types.StatusResolved},
+		{ID: "f-3", Severity: types.SeverityHigh, Status: types.StatusAcknowledged},
+		{ID: "f-4", Severity: types.SeverityMedium, Status: types.StatusOpen},
+		{ID: "f-5", Severity: types.SeverityLow, Status: types.StatusOpen},
+	}
+
+	tests := []struct {
+		failOn   string
+		expected []string
+	}{
+		{types.SeverityCritical, nil},
+		{types.SeverityHigh, []string{"f-3"}},
+		{types.SeverityMedium, []string{"f-3", "f-4"}},
+		{types.SeverityLow, []string{"f-3", "f-4", "f-5"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.failOn, func(t *testing.T) {
+			blocking, err := CheckFailOn(findings, tt.failOn)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if len(blocking) != len(tt.expected) {
+				t.Fatalf("Expected %d blocking findings, got %d", len(tt.expected), len(blocking))
+			}
+			for i, id := range tt.expected {
+				if blocking[i].ID != id {
+					t.Errorf("Expected blocking finding %s, got %s", id, blocking[i].ID)
+				}
+			}
+		})
+	}
+
+	if _, err := CheckFailOn(findings, "severe"); err == nil {
+		t.Error("Expected error for invalid severity")
+	}
+}
+
+// TestFindingsFileRoundTrip verifies single-object and array findings files keep their shape
+func TestFindingsFileRoundTrip(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	t.Run("single finding", func(t *testing.T) {
+		path := filepath.Join(tmpDir, "single.json")
+		if err := os.WriteFile(path, []byte(`{"id": "f-1", "status": "open"}`), 0644); err != nil {
+			t.Fatalf("Failed to write fixture: %v", err)
+		}
+
+		file, err := LoadFindingsFile(path)
+		if err != nil {
+			t.Fatalf("Failed to load findings: %v", err)
+		}
+		finding, err := file.Find("f-1")
+		if err != nil {
+			t.Fatalf("Failed to find finding: %v", err)
+		}
+		if err := SetFindingStatus(finding, types.StatusAcknowledged, ""); err != nil {
+			t.Fatalf("Failed to set status: %v", err)
+		}
+		if err := file.Save(); err != nil {
+			t.Fatalf("Failed to save findings: %v", err)
+		}
+
+		data, _ := os.ReadFile(path)
+		if data[0] != '{' {
+			t.Errorf("Expected single finding object to be preserved, got %s", data[:1])
+		}
+
+		reloaded, err := LoadFindingsFile(path)
+		if err != nil {
+			t.Fatalf("Failed to reload findings: %v", err)
+		}
+		if reloaded.Findings[0].Status != types.StatusAcknowledged {
+			t.Errorf("Expected persisted status acknowledged, got %s", reloaded.Findings[0].Status)
+		}
+	})
+
+	t.Run("finding array", func(t *testing.T) {
+		path := filepath.Join(tmpDir, "array.json")
+		if err := os.WriteFile(path, []byte(`[{"id": "f-1"}, {"id": "f-2"}]`), 0644); err != nil {
+			t.Fatalf("Failed to write fixture: %v", err)
+		}
+
+		file, err := LoadFindingsFile(path)
+		if err != nil {
+			t.Fatalf("Failed to load findings: %v", err)
+		}
+		if len(file.Findings) != 2 {
+			t.Fatalf("Expected 2 findings, got %d", len(file.Findings))
+		}
+		if _, err := file.Find("missing"); err == nil {
+			t.Error("Expected error for missing finding")
+		}
+	})
+}
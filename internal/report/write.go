@@ -0,0 +1,13 @@
+package report
+
+import (
+	"os"
+
+	"github.com/pickjonathan/sdek-cli/internal/atomicfile"
+)
+
+// WriteFile atomically writes data to path with the given permissions, creating
+// parent directories as needed (see atomicfile.WriteFile)
+func WriteFile(path string, data []byte, mode os.FileMode) error {
+	return atomicfile.WriteFile(path, data, mode)
+}
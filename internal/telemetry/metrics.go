@@ -0,0 +1,36 @@
+package telemetry
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"net"
+	"net/http"
+	"time"
+)
+
+// MetricsPath is the path Prometheus metrics are served on
+const MetricsPath = "/metrics"
+
+// ServeMetrics serves handler at MetricsPath on addr (e.g. ":9090") in the
+// background until the returned shutdown is called. The listener is opened
+// before returning, so an address already in use is reported immediately.
+func ServeMetrics(addr string, handler http.Handler) (ShutdownFunc, error) {
+	listener, err := net.Listen("tcp", addr)
+	if err != nil {
+		return nil, fmt.Errorf("failed to listen on %s: %w", addr, err)
+	}
+
+	mux := http.NewServeMux()
+	mux.Handle(MetricsPath, handler)
+	server := &http.Server{Handler: mux, ReadHeaderTimeout: 10 * time.Second}
+
+	go func() {
+		if err := server.Serve(listener); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			slog.Warn("Metrics server stopped", "addr", addr, "error", err)
+		}
+	}()
+	slog.Debug("Serving metrics", "addr", listener.Addr().String(), "path", MetricsPath)
+
+	return server.Shutdown, nil
+}
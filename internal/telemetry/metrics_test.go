@@ -0,0 +1,50 @@
+package telemetry
+
+import (
+	"context"
+	"io"
+	"net"
+	"net/http"
+	"testing"
+)
+
+func TestServeMetrics(t *testing.T) {
+	// Reserve a free port, then serve on it
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	addr := listener.Addr().String()
+	listener.Close()
+
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		io.WriteString(w, "sdek_test_metric 1\n")
+	})
+	shutdown, err := ServeMetrics(addr, handler)
+	if err != nil {
+		t.Fatalf("ServeMetrics() error = %v", err)
+	}
+	defer shutdown(context.Background())
+
+	resp, err := http.Get("http://" + addr + MetricsPath)
+	if err != nil {
+		t.Fatalf("scrape failed: %v", err)
+	}
+	defer resp.Body.Close()
+	body, _ := io.ReadAll(resp.Body)
+	if string(body) != "sdek_test_metric 1\n" {
+		t.Errorf("unexpected metrics body %q", body)
+	}
+}
+
+func TestServeMetrics_AddressInUse(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer listener.Close()
+
+	if _, err := ServeMetrics(listener.Addr().String(), http.NotFoundHandler()); err == nil {
+		t.Error("Expected an error for an address already in use")
+	}
+}
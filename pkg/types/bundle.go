@@ -1,6 +1,7 @@
 package types
 
 import (
+	"sort"
 	"time"
 )
 
@@ -19,4 +20,30 @@ type EvidenceEvent struct {
 	Timestamp time.Time              `json:"timestamp"`
 	Content   string                 `json:"content"`
 	Metadata  map[string]interface{} `json:"metadata,omitempty"`
+	Labels    map[string]string      `json:"labels,omitempty"` // e.g. env=prod, team=platform; carried into findings
+}
+
+// Provenance counts the bundle's events per source, most events first (ties by
+// source name). Events without a source are counted under "unknown".
+func (b EvidenceBundle) Provenance() []ProvenanceEntry {
+	counts := make(map[string]int)
+	for _, event := range b.Events {
+		source := event.Source
+		if source == "" {
+			source = "unknown"
+		}
+		counts[source]++
+	}
+
+	entries := make([]ProvenanceEntry, 0, len(counts))
+	for source, count := range counts {
+		entries = append(entries, ProvenanceEntry{Source: source, EventsUsed: count})
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		if entries[i].EventsUsed != entries[j].EventsUsed {
+			return entries[i].EventsUsed > entries[j].EventsUsed
+		}
+		return entries[i].Source < entries[j].Source
+	})
+	return entries
 }
@@ -1,6 +1,12 @@
 package types
 
-import "fmt"
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+)
 
 // Config represents the application configuration
 type Config struct {
@@ -14,17 +20,127 @@ type Config struct {
 	AI         AIConfig                   `json:"ai" mapstructure:"ai"`
 	MCP        MCPConfig                  `json:"mcp" mapstructure:"mcp"`                             // Feature 006: MCP configuration
 	Providers  map[string]ProviderConfig  `json:"providers,omitempty" mapstructure:"providers"`      // Feature 006: AI provider configs
+	Scoring    ScoringConfig              `json:"scoring" mapstructure:"scoring"`
+	AuditLog   AuditLogConfig             `json:"audit_log" mapstructure:"audit_log"`
+	Telemetry  TelemetryConfig           `json:"telemetry" mapstructure:"telemetry"`
+	Excerpts   ExcerptsConfig            `json:"excerpts" mapstructure:"excerpts"`
+	UI         UIConfig                   `json:"ui" mapstructure:"ui"`
+
+	// Language of printed summaries, prompts and error hints, as a language
+	// code or locale (e.g. "es", "es_ES.UTF-8"); also read from SDEK_LANG.
+	// Unsupported languages and untranslated messages fall back to English.
+	Language string `json:"language" mapstructure:"language"`
+}
+
+// UIConfig configures interactive terminal behavior
+type UIConfig struct {
+	// Interactive enables prompts such as the ai analyze context preview. When
+	// false, commands behave as if --yes was passed (default: true).
+	Interactive bool `json:"interactive" mapstructure:"interactive"`
+}
+
+// AuditLogConfig configures the AI provider call audit log
+type AuditLogConfig struct {
+	Path string `json:"path" mapstructure:"path"` // JSON lines file; empty disables audit logging
 }
 
+// TelemetryConfig configures OpenTelemetry tracing
+type TelemetryConfig struct {
+	OTLPEndpoint string `json:"otlp_endpoint" mapstructure:"otlp_endpoint"` // OTLP/HTTP collector URL; empty disables tracing
+}
+
+// ExcerptsConfig configures fetching policy excerpts when --excerpts-file is an http(s) URL
+type ExcerptsConfig struct {
+	Timeout  int               `json:"timeout" mapstructure:"timeout"`     // Fetch timeout in seconds
+	CacheDir string            `json:"cache_dir" mapstructure:"cache_dir"` // Fetched excerpts are kept here; empty disables caching
+	CacheTTL int               `json:"cache_ttl" mapstructure:"cache_ttl"` // Seconds a cached copy is used without refetching (0 = always refetch)
+	Headers  map[string]string `json:"headers" mapstructure:"headers"`     // Request headers, e.g. Authorization; values expand ${ENV_VAR}
+}
+
+// Default excerpt fetching settings
+const (
+	DefaultExcerptsTimeout  = 30
+	DefaultExcerptsCacheDir = "~/.sdek/cache/excerpts"
+	DefaultExcerptsCacheTTL = 300
+)
+
 // ExportConfig contains export-related settings
 type ExportConfig struct {
 	DefaultPath string `json:"default_path" mapstructure:"default_path"`
 	Format      string `json:"format" mapstructure:"format"`
+
+	// FileMode is the octal permission mode for exported files, e.g. "0600" (default "0644")
+	FileMode string `json:"file_mode,omitempty" mapstructure:"file_mode"`
+}
+
+// DefaultExportFileMode is the permission mode for exported files when export.file_mode is unset
+const DefaultExportFileMode os.FileMode = 0644
+
+// Mode returns the configured export file mode, or DefaultExportFileMode if unset or invalid
+func (e ExportConfig) Mode() os.FileMode {
+	mode, err := parseFileMode(e.FileMode)
+	if err != nil {
+		return DefaultExportFileMode
+	}
+	return mode
+}
+
+// parseFileMode parses an octal permission string such as "0640"; empty means DefaultExportFileMode
+func parseFileMode(s string) (os.FileMode, error) {
+	if s == "" {
+		return DefaultExportFileMode, nil
+	}
+	mode, err := strconv.ParseUint(strings.TrimPrefix(s, "0o"), 8, 32)
+	if err != nil || mode > 0777 {
+		return 0, fmt.Errorf("invalid file mode %q", s)
+	}
+	return os.FileMode(mode), nil
 }
 
 // FrameworksConfig contains framework-related settings
 type FrameworksConfig struct {
 	Enabled []string `json:"enabled" mapstructure:"enabled"`
+
+	// Versions pins the framework version assumed when a policy excerpt
+	// doesn't state one, keyed by framework ID (e.g. soc2: "2017")
+	Versions map[string]string `json:"versions,omitempty" mapstructure:"versions"`
+
+	// Rubrics overrides the analysis rubrics per framework ID; unset fields keep
+	// the defaults. A confidence threshold of 0 is treated as unset.
+	Rubrics map[string]AnalysisRubrics `json:"rubrics,omitempty" mapstructure:"rubrics"`
+
+	// RubricsFile is a YAML or JSON file of rubrics keyed by framework ID, in the
+	// same format as Rubrics. Entries in Rubrics take precedence.
+	RubricsFile string `json:"rubrics_file,omitempty" mapstructure:"rubrics_file"`
+}
+
+// RubricsFor returns the analysis rubrics for framework: the defaults with any
+// configured override applied. Framework names are matched with SameFramework.
+func (f FrameworksConfig) RubricsFor(framework string) AnalysisRubrics {
+	rubrics := DefaultAnalysisRubrics()
+	if override, ok := f.Rubrics[framework]; ok {
+		return rubrics.Merge(override)
+	}
+	for id, override := range f.Rubrics {
+		if SameFramework(id, framework) {
+			return rubrics.Merge(override)
+		}
+	}
+	return rubrics
+}
+
+// VersionFor returns the version pinned for framework, or "" if none is pinned.
+// Framework names are matched with SameFramework, so "PCI-DSS" finds "pci_dss".
+func (f FrameworksConfig) VersionFor(framework string) string {
+	if version, ok := f.Versions[framework]; ok {
+		return version
+	}
+	for id, version := range f.Versions {
+		if SameFramework(id, framework) {
+			return version
+		}
+	}
+	return ""
 }
 
 // SourcesConfig contains source-related settings
@@ -32,6 +148,52 @@ type SourcesConfig struct {
 	Enabled []string `json:"enabled" mapstructure:"enabled"`
 }
 
+// ScoringConfig contains compliance scoring settings
+type ScoringConfig struct {
+	Weights SeverityWeights `json:"weights" mapstructure:"weights"`
+}
+
+// SeverityWeights defines how much an open finding of each severity subtracts
+// from its control's contribution to the weighted compliance percentage.
+// A control contributes 1.0 minus the sum of its open finding weights (floored at 0).
+type SeverityWeights struct {
+	Critical float64 `json:"critical" mapstructure:"critical"`
+	High     float64 `json:"high" mapstructure:"high"`
+	Medium   float64 `json:"medium" mapstructure:"medium"`
+	Low      float64 `json:"low" mapstructure:"low"`
+}
+
+// DefaultSeverityWeights returns the default severity weights
+func DefaultSeverityWeights() SeverityWeights {
+	return SeverityWeights{
+		Critical: 1.0,
+		High:     0.5,
+		Medium:   0.25,
+		Low:      0.1,
+	}
+}
+
+// IsZero returns true if no weights are configured
+func (w SeverityWeights) IsZero() bool {
+	return w.Critical == 0 && w.High == 0 && w.Medium == 0 && w.Low == 0
+}
+
+// ForSeverity returns the weight for the given finding severity
+func (w SeverityWeights) ForSeverity(severity string) float64 {
+	switch severity {
+	case SeverityCritical:
+		return w.Critical
+	case SeverityHigh:
+		return w.High
+	case SeverityMedium:
+		return w.Medium
+	case SeverityLow:
+		return w.Low
+	default:
+		return 0
+	}
+}
+
 // AIConfig contains AI analysis settings (Feature 002 + 003 + 006: AI Evidence Analysis + Context Injection + Provider Switching)
 type AIConfig struct {
 	Enabled      bool                       `json:"enabled" mapstructure:"enabled"`
@@ -47,19 +209,135 @@ type AIConfig struct {
 	Timeout      int                        `json:"timeout" mapstructure:"timeout"`             // seconds
 	RateLimit    int                        `json:"rate_limit" mapstructure:"rate_limit"`       // requests per minute
 	CacheDir     string                     `json:"cache_dir" mapstructure:"cache_dir"`         // cache directory path
+	CacheMaxBytes int64                     `json:"cache_max_bytes" mapstructure:"cache_max_bytes"` // evict oldest entries above this size (0 = unlimited)
 	NoCache      bool                       `json:"no_cache" mapstructure:"no_cache"`           // Feature 003: Disable caching
 	Concurrency  ConcurrencyLimits          `json:"concurrency" mapstructure:"concurrency"`     // Feature 003: Concurrency limits
 	Budgets      BudgetLimits               `json:"budgets" mapstructure:"budgets"`             // Feature 003: Budget limits
 	Autonomous   AutonomousConfig           `json:"autonomous" mapstructure:"autonomous"`       // Feature 003: Autonomous mode config
 	Redaction    RedactionConfig            `json:"redaction" mapstructure:"redaction"`         // Feature 003: Redaction settings
 	Connectors   map[string]ConnectorConfig `json:"connectors" mapstructure:"connectors"`       // Feature 003: MCP connector config
+
+	// InvalidCitationThreshold is the fraction of citations referencing unknown
+	// events at which a finding's confidence is reduced (default: 0.25)
+	InvalidCitationThreshold float64 `json:"invalid_citation_threshold" mapstructure:"invalid_citation_threshold"`
+
+	// ConsensusThreshold is the disagreement score (0-1) above which a consensus
+	// analysis across providers is flagged for review (default: 0.3)
+	ConsensusThreshold float64 `json:"consensus_threshold" mapstructure:"consensus_threshold"`
+
+	// PromptTemplate is an optional text/template file replacing the built-in analysis prompt
+	PromptTemplate string `json:"prompt_template" mapstructure:"prompt_template"`
+
+	// SystemPrompt overrides the providers' built-in system message (empty uses the default)
+	SystemPrompt string `json:"system_prompt" mapstructure:"system_prompt"`
+
+	// Seed is sent to providers that support seeded sampling, for reproducible analyses
+	Seed *int `json:"seed,omitempty" mapstructure:"seed"`
+
+	// Deterministic forces temperature 0 (and a default seed when none is set)
+	Deterministic bool `json:"deterministic" mapstructure:"deterministic"`
+
+	// APIKeyFile reads the provider API key from a file, or from the OS keyring
+	// when given as keyring://service/account, instead of the config or environment
+	APIKeyFile string `json:"api_key_file,omitempty" mapstructure:"api_key_file"`
+
+	// MinEventsForAI skips the provider call, returning a low-confidence finding,
+	// when the evidence bundle has fewer events than this (default: 1)
+	MinEventsForAI int `json:"min_events_for_ai" mapstructure:"min_events_for_ai"`
+
+	// MaxPromptChars rejects prompts longer than this many characters before
+	// they are sent to the provider (0 = unlimited)
+	MaxPromptChars int `json:"max_prompt_chars" mapstructure:"max_prompt_chars"`
+
+	// ContextFallbackModel is a larger-context model of the same provider that an
+	// analysis retries with once when the prompt exceeds the model's context
+	// window (empty = retry by splitting the evidence into smaller chunks)
+	ContextFallbackModel string `json:"context_fallback_model,omitempty" mapstructure:"context_fallback_model"`
+
+	// RateLimitBurst is how many requests may be sent at once before ai.rate_limit
+	// throttles them (0 derives it from the rate: rate_limit/60, at least 1)
+	RateLimitBurst int `json:"rate_limit_burst" mapstructure:"rate_limit_burst"`
+
+	// AllowUnknownModel skips checking the model against the provider's known
+	// models, for model releases newer than sdek
+	AllowUnknownModel bool `json:"allow_unknown_model" mapstructure:"allow_unknown_model"`
+
+	// StrictResidualRisk fails an analysis whose residual_risk is not a known
+	// level or synonym, instead of keeping the text and using medium severity
+	StrictResidualRisk bool `json:"strict_residual_risk" mapstructure:"strict_residual_risk"`
+
+	// EvidenceMaxAgeDays flags a finding as built on stale evidence when its
+	// newest cited event is older than this many days (0 = no check)
+	EvidenceMaxAgeDays int `json:"evidence_max_age_days" mapstructure:"evidence_max_age_days"`
+
+	// MinCitations flags a finding for review when it cites fewer events than
+	// this, guarding against claims not grounded in evidence (0 = no check)
+	MinCitations int `json:"min_citations" mapstructure:"min_citations"`
+
+	// AllowedSources restricts loaded evidence to events from these sources
+	// (e.g. github, jira); events from other sources are dropped when evidence
+	// files are read. Empty allows every source.
+	AllowedSources []string `json:"allowed_sources,omitempty" mapstructure:"allowed_sources"`
+
+	// ProviderDefaults overrides the model used for a provider when ai.model is
+	// unset, keyed by provider name (see DefaultProviderModels for the built-ins)
+	ProviderDefaults map[string]string `json:"provider_defaults,omitempty" mapstructure:"provider_defaults"`
+}
+
+// DefaultProviderModels is the model used for each provider when neither
+// ai.model nor ai.provider_defaults names one
+var DefaultProviderModels = map[string]string{
+	"openai":    "gpt-4",
+	"anthropic": "claude-3-opus-20240229",
 }
 
+// DefaultModel returns the default model for provider: the ProviderDefaults
+// entry if set, otherwise the built-in default ("" if there is none)
+func (c AIConfig) DefaultModel(provider string) string {
+	if model := c.ProviderDefaults[provider]; model != "" {
+		return model
+	}
+	return DefaultProviderModels[provider]
+}
+
+// ModelFor returns the model to use with provider: Model if set, otherwise DefaultModel
+func (c AIConfig) ModelFor(provider string) string {
+	if c.Model != "" {
+		return c.Model
+	}
+	return c.DefaultModel(provider)
+}
+
+// SourceAllowed reports whether evidence from source may be loaded: true when
+// AllowedSources is empty or lists source (case-insensitively)
+func (c AIConfig) SourceAllowed(source string) bool {
+	if len(c.AllowedSources) == 0 {
+		return true
+	}
+	for _, allowed := range c.AllowedSources {
+		if strings.EqualFold(strings.TrimSpace(allowed), source) {
+			return true
+		}
+	}
+	return false
+}
+
+// DefaultMaxAnalyses is the number of concurrent analyses or connector calls when ai.concurrency.maxAnalyses is unset
+const DefaultMaxAnalyses = 25
+
 // ConcurrencyLimits defines concurrency constraints for AI operations (Feature 003)
 type ConcurrencyLimits struct {
 	MaxAnalyses int `json:"maxAnalyses" mapstructure:"maxAnalyses"` // Default: 25
 }
 
+// Workers returns the worker pool size for MaxAnalyses, or DefaultMaxAnalyses if it isn't positive
+func (c ConcurrencyLimits) Workers() int {
+	if c.MaxAnalyses < 1 {
+		return DefaultMaxAnalyses
+	}
+	return c.MaxAnalyses
+}
+
 // BudgetLimits defines resource constraints for AI operations (Feature 003)
 type BudgetLimits struct {
 	MaxSources  int `json:"maxSources" mapstructure:"maxSources"`   // Default: 50
@@ -71,8 +349,24 @@ type BudgetLimits struct {
 type AutonomousConfig struct {
 	Enabled     bool              `json:"enabled" mapstructure:"enabled"`
 	AutoApprove AutoApproveConfig `json:"autoApprove" mapstructure:"autoApprove"`
+
+	// MinRationaleChars is the shortest plan item rationale that counts as an
+	// explanation. Items with shorter or missing rationales are flagged and
+	// always need manual approval.
+	MinRationaleChars int `json:"minRationaleChars" mapstructure:"minRationaleChars"`
+
+	// MinSignalStrength drops plan items the AI rates below this relevance
+	// (0.0-1.0, default 0.0 keeps every item)
+	MinSignalStrength float64 `json:"minSignalStrength" mapstructure:"minSignalStrength"`
+
+	// MaxItems keeps only the highest-signal plan items, for a plan small
+	// enough to review (0 = no cap)
+	MaxItems int `json:"maxItems" mapstructure:"maxItems"`
 }
 
+// DefaultMinRationaleChars is the default ai.autonomous.minRationaleChars
+const DefaultMinRationaleChars = 20
+
 // AutoApproveConfig defines auto-approval policy for evidence plans (Feature 003)
 // It's a map of source name to list of glob patterns
 type AutoApproveConfig map[string][]string // source -> patterns
@@ -81,6 +375,11 @@ type AutoApproveConfig map[string][]string // source -> patterns
 type RedactionConfig struct {
 	Enabled  bool     `json:"enabled" mapstructure:"enabled"`   // Default: true
 	Denylist []string `json:"denylist" mapstructure:"denylist"` // Exact match strings
+	Locales  []string `json:"locales" mapstructure:"locales"`   // Additional locale pattern sets (eu, uk, intl)
+
+	// DenylistMode controls what happens when a denylist term is found in a prompt
+	// about to be sent to a provider: "redact" (default) or "block"
+	DenylistMode string `json:"denylist_mode" mapstructure:"denylist_mode"`
 }
 
 // ConnectorConfig defines configuration for MCP evidence connectors (Feature 003)
@@ -112,6 +411,28 @@ const (
 // ValidAIModes is the list of valid AI modes
 var ValidAIModes = []string{AIModeDisabled, AIModeContext, AIModeAutonomous}
 
+// DefaultCacheMaxBytes caps the on-disk AI cache when ai.cache_max_bytes is not configured
+const DefaultCacheMaxBytes int64 = 100 << 20 // 100 MiB
+
+// Redaction locale constants. The default patterns (email, IP, US phone, keys) are always applied.
+const (
+	RedactionLocaleEU   = "eu"   // IBANs, EU VAT numbers
+	RedactionLocaleUK   = "uk"   // IBANs, National Insurance numbers
+	RedactionLocaleIntl = "intl" // International (+country code) phone numbers
+)
+
+// ValidRedactionLocales is the list of valid redaction locales
+var ValidRedactionLocales = []string{RedactionLocaleEU, RedactionLocaleUK, RedactionLocaleIntl}
+
+// Denylist mode constants
+const (
+	DenylistModeRedact = "redact" // Replace denylisted terms before sending
+	DenylistModeBlock  = "block"  // Abort the provider call
+)
+
+// ValidDenylistModes is the list of valid denylist modes
+var ValidDenylistModes = []string{DenylistModeRedact, DenylistModeBlock}
+
 // DefaultConfig returns a Config with default values
 func DefaultConfig() *Config {
 	return &Config{
@@ -122,6 +443,7 @@ func DefaultConfig() *Config {
 		Export: ExportConfig{
 			DefaultPath: "$HOME/sdek/reports",
 			Format:      "json",
+			FileMode:    "0644",
 		},
 		Frameworks: FrameworksConfig{
 			Enabled: []string{FrameworkSOC2, FrameworkISO27001, FrameworkPCIDSS},
@@ -137,8 +459,10 @@ func DefaultConfig() *Config {
 			Timeout:   60, // 60 seconds
 			RateLimit: 10, // 10 requests per minute
 			CacheDir:  "$HOME/.sdek/cache/ai",
+			CacheMaxBytes: DefaultCacheMaxBytes,
+			MinEventsForAI: 1,
 			Concurrency: ConcurrencyLimits{
-				MaxAnalyses: 25,
+				MaxAnalyses: DefaultMaxAnalyses,
 			},
 			Budgets: BudgetLimits{
 				MaxSources:  50,
@@ -146,13 +470,17 @@ func DefaultConfig() *Config {
 				MaxTokens:   250000,
 			},
 			Autonomous: AutonomousConfig{
-				Enabled:     false,
-				AutoApprove: make(AutoApproveConfig),
+				Enabled:           false,
+				AutoApprove:       make(AutoApproveConfig),
+				MinRationaleChars: DefaultMinRationaleChars,
 			},
 			Redaction: RedactionConfig{
-				Enabled:  true,
-				Denylist: []string{},
+				Enabled:      true,
+				Denylist:     []string{},
+				DenylistMode: DenylistModeRedact,
 			},
+			InvalidCitationThreshold: 0.25,
+			ConsensusThreshold:       0.3,
 			Connectors: map[string]ConnectorConfig{
 				"github": {
 					Enabled:   false,
@@ -175,10 +503,52 @@ func DefaultConfig() *Config {
 		},
 		MCP:       DefaultMCPConfig(),      // Feature 006: MCP default config
 		Providers: make(map[string]ProviderConfig), // Feature 006: Empty providers map
+		Scoring: ScoringConfig{
+			Weights: DefaultSeverityWeights(),
+		},
+		Excerpts: ExcerptsConfig{
+			Timeout:  DefaultExcerptsTimeout,
+			CacheDir: DefaultExcerptsCacheDir,
+			CacheTTL: DefaultExcerptsCacheTTL,
+		},
+		UI: UIConfig{
+			Interactive: true,
+		},
 	}
 }
 
-// ValidateConfig checks if a Config meets all validation rules
+// ErrInvalidConfig is matched by errors.Is for every ConfigValidationError
+var ErrInvalidConfig = errors.New("invalid configuration")
+
+// ConfigValidationError reports a configuration setting that failed validation.
+// Use errors.As to retrieve the field path and offending value.
+type ConfigValidationError struct {
+	Field   string      // Dotted config key, e.g. "ai.connectors.github.timeout"
+	Value   interface{} // The rejected value
+	Message string      // Why the value was rejected
+}
+
+// Error implements the error interface
+func (e *ConfigValidationError) Error() string {
+	return e.Field + ": " + e.Message
+}
+
+// Is reports whether target is ErrInvalidConfig
+func (e *ConfigValidationError) Is(target error) bool {
+	return target == ErrInvalidConfig
+}
+
+// invalidField creates a ConfigValidationError for field with a formatted message
+func invalidField(field string, value interface{}, format string, args ...interface{}) *ConfigValidationError {
+	return &ConfigValidationError{
+		Field:   field,
+		Value:   value,
+		Message: fmt.Sprintf(format, args...),
+	}
+}
+
+// ValidateConfig checks if a Config meets all validation rules.
+// Invalid settings are reported as a *ConfigValidationError naming the offending field.
 func ValidateConfig(c *Config) error {
 	if c == nil {
 		return fmt.Errorf("config cannot be nil")
@@ -194,7 +564,7 @@ func ValidateConfig(c *Config) error {
 		}
 	}
 	if !valid {
-		return fmt.Errorf("invalid log level: %s, must be one of %v", c.LogLevel, validLogLevels)
+		return invalidField("log_level", c.LogLevel, "invalid log level: %s, must be one of %v", c.LogLevel, validLogLevels)
 	}
 
 	// Validate theme
@@ -207,7 +577,7 @@ func ValidateConfig(c *Config) error {
 		}
 	}
 	if !valid {
-		return fmt.Errorf("invalid theme: %s, must be one of %v", c.Theme, validThemes)
+		return invalidField("theme", c.Theme, "invalid theme: %s, must be one of %v", c.Theme, validThemes)
 	}
 
 	// Validate user role
@@ -220,7 +590,7 @@ func ValidateConfig(c *Config) error {
 		}
 	}
 	if !valid {
-		return fmt.Errorf("invalid user role: %s, must be one of %v", c.UserRole, validRoles)
+		return invalidField("user_role", c.UserRole, "invalid user role: %s, must be one of %v", c.UserRole, validRoles)
 	}
 
 	// Validate export format
@@ -233,25 +603,23 @@ func ValidateConfig(c *Config) error {
 		}
 	}
 	if !valid {
-		return fmt.Errorf("invalid export format: %s, must be one of %v", c.Export.Format, validFormats)
+		return invalidField("export.format", c.Export.Format, "invalid export format: %s, must be one of %v", c.Export.Format, validFormats)
+	}
+
+	// Validate export file mode
+	if _, err := parseFileMode(c.Export.FileMode); err != nil {
+		return invalidField("export.file_mode", c.Export.FileMode, "invalid file mode: %s, must be an octal permission such as 0600", c.Export.FileMode)
 	}
 
 	// Validate enabled frameworks
-	for _, fw := range c.Frameworks.Enabled {
-		valid = false
-		for _, validFW := range ValidFrameworkIDs {
-			if fw == validFW {
-				valid = true
-				break
-			}
-		}
-		if !valid {
-			return fmt.Errorf("invalid framework: %s, must be one of %v", fw, ValidFrameworkIDs)
+	for i, fw := range c.Frameworks.Enabled {
+		if _, ok := NormalizeFramework(fw); !ok {
+			return invalidField(fmt.Sprintf("frameworks.enabled[%d]", i), fw, "invalid framework: %s, must be one of %v", fw, ValidFrameworkIDs)
 		}
 	}
 
 	// Validate enabled sources
-	for _, src := range c.Sources.Enabled {
+	for i, src := range c.Sources.Enabled {
 		valid = false
 		for _, validSrc := range ValidSourceTypes {
 			if src == validSrc {
@@ -260,10 +628,33 @@ func ValidateConfig(c *Config) error {
 			}
 		}
 		if !valid {
-			return fmt.Errorf("invalid source: %s, must be one of %v", src, ValidSourceTypes)
+			return invalidField(fmt.Sprintf("sources.enabled[%d]", i), src, "invalid source: %s, must be one of %v", src, ValidSourceTypes)
+		}
+	}
+
+	// Validate pinned framework versions
+	for framework, version := range c.Frameworks.Versions {
+		if strings.TrimSpace(version) == "" {
+			return invalidField("frameworks.versions."+framework, version, "pinned version for framework %s cannot be empty", framework)
 		}
 	}
 
+	// Validate per-framework rubrics
+	for framework, rubrics := range c.Frameworks.Rubrics {
+		if rubrics.ConfidenceThreshold < 0 || rubrics.ConfidenceThreshold > 1 {
+			return invalidField("frameworks.rubrics."+framework+".confidence_threshold", rubrics.ConfidenceThreshold, "confidence threshold must be between 0 and 1, got %.2f", rubrics.ConfidenceThreshold)
+		}
+		if rubrics.RequiredCitations < 0 {
+			return invalidField("frameworks.rubrics."+framework+".required_citations", rubrics.RequiredCitations, "required citations cannot be negative, got %d", rubrics.RequiredCitations)
+		}
+	}
+
+	// Validate scoring weights
+	weights := c.Scoring.Weights
+	if weights.Critical < 0 || weights.High < 0 || weights.Medium < 0 || weights.Low < 0 {
+		return invalidField("scoring.weights", weights, "scoring weights cannot be negative, got %+v", weights)
+	}
+
 	// Validate AI config
 	if c.AI.Enabled {
 		// Validate provider
@@ -275,7 +666,7 @@ func ValidateConfig(c *Config) error {
 			}
 		}
 		if !valid {
-			return fmt.Errorf("invalid AI provider: %s, must be one of %v", c.AI.Provider, ValidAIProviders)
+			return invalidField("ai.provider", c.AI.Provider, "invalid AI provider: %s, must be one of %v", c.AI.Provider, ValidAIProviders)
 		}
 
 		// Validate mode (Feature 003)
@@ -287,46 +678,123 @@ func ValidateConfig(c *Config) error {
 			}
 		}
 		if !valid {
-			return fmt.Errorf("invalid AI mode: %s, must be one of %v", c.AI.Mode, ValidAIModes)
+			return invalidField("ai.mode", c.AI.Mode, "invalid AI mode: %s, must be one of %v", c.AI.Mode, ValidAIModes)
 		}
 
 		// Validate model is not empty
 		if c.AI.Model == "" {
-			return fmt.Errorf("AI model cannot be empty when AI is enabled")
+			return invalidField("ai.model", c.AI.Model, "AI model cannot be empty when AI is enabled")
 		}
 
 		// Validate timeout
 		if c.AI.Timeout <= 0 {
-			return fmt.Errorf("AI timeout must be positive, got %d", c.AI.Timeout)
+			return invalidField("ai.timeout", c.AI.Timeout, "AI timeout must be positive, got %d", c.AI.Timeout)
 		}
 
 		// Validate rate limit
 		if c.AI.RateLimit < 0 {
-			return fmt.Errorf("AI rate limit cannot be negative, got %d", c.AI.RateLimit)
+			return invalidField("ai.rate_limit", c.AI.RateLimit, "AI rate limit cannot be negative, got %d", c.AI.RateLimit)
+		}
+
+		if c.AI.RateLimitBurst < 0 {
+			return invalidField("ai.rate_limit_burst", c.AI.RateLimitBurst, "AI rate_limit_burst cannot be negative, got %d", c.AI.RateLimitBurst)
+		}
+
+		// Validate minimum evidence threshold
+		if c.AI.MinEventsForAI < 0 {
+			return invalidField("ai.min_events_for_ai", c.AI.MinEventsForAI, "AI min_events_for_ai cannot be negative, got %d", c.AI.MinEventsForAI)
+		}
+
+		// Validate evidence freshness limit
+		if c.AI.EvidenceMaxAgeDays < 0 {
+			return invalidField("ai.evidence_max_age_days", c.AI.EvidenceMaxAgeDays, "AI evidence_max_age_days cannot be negative, got %d", c.AI.EvidenceMaxAgeDays)
+		}
+
+		// Validate citation minimum
+		if c.AI.MinCitations < 0 {
+			return invalidField("ai.min_citations", c.AI.MinCitations, "AI min_citations cannot be negative, got %d", c.AI.MinCitations)
+		}
+
+		// Validate prompt size limit
+		if c.AI.MaxPromptChars < 0 {
+			return invalidField("ai.max_prompt_chars", c.AI.MaxPromptChars, "AI max_prompt_chars cannot be negative, got %d", c.AI.MaxPromptChars)
+		}
+
+		// Validate cache size limit
+		if c.AI.CacheMaxBytes < 0 {
+			return invalidField("ai.cache_max_bytes", c.AI.CacheMaxBytes, "AI cache_max_bytes cannot be negative, got %d", c.AI.CacheMaxBytes)
 		}
 
 		// Validate API keys
-		if c.AI.Provider == AIProviderOpenAI && c.AI.OpenAIKey == "" && c.AI.APIKey == "" {
-			return fmt.Errorf("OpenAI API key required when provider is openai")
+		if c.AI.Provider == AIProviderOpenAI && c.AI.OpenAIKey == "" && c.AI.APIKey == "" && c.AI.APIKeyFile == "" {
+			return invalidField("ai.openai_key", "", "OpenAI API key required when provider is openai")
 		}
-		if c.AI.Provider == AIProviderAnthropic && c.AI.AnthropicKey == "" && c.AI.APIKey == "" {
-			return fmt.Errorf("Anthropic API key required when provider is anthropic")
+		if c.AI.Provider == AIProviderAnthropic && c.AI.AnthropicKey == "" && c.AI.APIKey == "" && c.AI.APIKeyFile == "" {
+			return invalidField("ai.anthropic_key", "", "Anthropic API key required when provider is anthropic")
+		}
+
+		// Validate citation threshold
+		if c.AI.InvalidCitationThreshold < 0 || c.AI.InvalidCitationThreshold > 1 {
+			return invalidField("ai.invalid_citation_threshold", c.AI.InvalidCitationThreshold, "AI invalid_citation_threshold must be between 0 and 1, got %f", c.AI.InvalidCitationThreshold)
+		}
+
+		// Validate consensus threshold
+		if c.AI.ConsensusThreshold < 0 || c.AI.ConsensusThreshold > 1 {
+			return invalidField("ai.consensus_threshold", c.AI.ConsensusThreshold, "AI consensus_threshold must be between 0 and 1, got %f", c.AI.ConsensusThreshold)
+		}
+
+		// Validate redaction locales
+		for i, locale := range c.AI.Redaction.Locales {
+			valid = false
+			for _, l := range ValidRedactionLocales {
+				if strings.EqualFold(locale, l) {
+					valid = true
+					break
+				}
+			}
+			if !valid {
+				return invalidField(fmt.Sprintf("ai.redaction.locales[%d]", i), locale, "invalid redaction locale: %s, must be one of %v", locale, ValidRedactionLocales)
+			}
+		}
+
+		// Validate denylist mode (empty means redact)
+		if c.AI.Redaction.DenylistMode != "" {
+			valid = false
+			for _, m := range ValidDenylistModes {
+				if c.AI.Redaction.DenylistMode == m {
+					valid = true
+					break
+				}
+			}
+			if !valid {
+				return invalidField("ai.redaction.denylist_mode", c.AI.Redaction.DenylistMode, "invalid denylist mode: %s, must be one of %v", c.AI.Redaction.DenylistMode, ValidDenylistModes)
+			}
 		}
 
 		// Validate concurrency limits (Feature 003)
 		if c.AI.Concurrency.MaxAnalyses <= 0 {
-			return fmt.Errorf("AI concurrency.maxAnalyses must be positive, got %d", c.AI.Concurrency.MaxAnalyses)
+			return invalidField("ai.concurrency.maxAnalyses", c.AI.Concurrency.MaxAnalyses, "AI concurrency.maxAnalyses must be positive, got %d", c.AI.Concurrency.MaxAnalyses)
 		}
 
 		// Validate budget limits (Feature 003)
 		if c.AI.Budgets.MaxSources <= 0 {
-			return fmt.Errorf("AI budgets.maxSources must be positive, got %d", c.AI.Budgets.MaxSources)
+			return invalidField("ai.budgets.maxSources", c.AI.Budgets.MaxSources, "AI budgets.maxSources must be positive, got %d", c.AI.Budgets.MaxSources)
 		}
 		if c.AI.Budgets.MaxAPICalls <= 0 {
-			return fmt.Errorf("AI budgets.maxAPICalls must be positive, got %d", c.AI.Budgets.MaxAPICalls)
+			return invalidField("ai.budgets.maxAPICalls", c.AI.Budgets.MaxAPICalls, "AI budgets.maxAPICalls must be positive, got %d", c.AI.Budgets.MaxAPICalls)
 		}
 		if c.AI.Budgets.MaxTokens <= 0 {
-			return fmt.Errorf("AI budgets.maxTokens must be positive, got %d", c.AI.Budgets.MaxTokens)
+			return invalidField("ai.budgets.maxTokens", c.AI.Budgets.MaxTokens, "AI budgets.maxTokens must be positive, got %d", c.AI.Budgets.MaxTokens)
+		}
+
+		if c.AI.Autonomous.MinRationaleChars < 0 {
+			return invalidField("ai.autonomous.minRationaleChars", c.AI.Autonomous.MinRationaleChars, "AI autonomous.minRationaleChars cannot be negative, got %d", c.AI.Autonomous.MinRationaleChars)
+		}
+		if c.AI.Autonomous.MinSignalStrength < 0 || c.AI.Autonomous.MinSignalStrength > 1 {
+			return invalidField("ai.autonomous.minSignalStrength", c.AI.Autonomous.MinSignalStrength, "AI autonomous.minSignalStrength must be between 0.0 and 1.0, got %v", c.AI.Autonomous.MinSignalStrength)
+		}
+		if c.AI.Autonomous.MaxItems < 0 {
+			return invalidField("ai.autonomous.maxItems", c.AI.Autonomous.MaxItems, "AI autonomous.maxItems cannot be negative, got %d", c.AI.Autonomous.MaxItems)
 		}
 
 		// Validate connector configs (Feature 003)
@@ -342,17 +810,17 @@ func ValidateConfig(c *Config) error {
 					}
 				}
 				if !valid {
-					return fmt.Errorf("invalid connector name: %s, must be one of %v", name, validConnectors)
+					return invalidField("ai.connectors."+name, name, "invalid connector name: %s, must be one of %v", name, validConnectors)
 				}
 
 				// Validate timeout if set
 				if conn.Timeout < 0 {
-					return fmt.Errorf("connector %s: timeout cannot be negative, got %d", name, conn.Timeout)
+					return invalidField("ai.connectors."+name+".timeout", conn.Timeout, "connector %s: timeout cannot be negative, got %d", name, conn.Timeout)
 				}
 
 				// Validate rate limit if set
 				if conn.RateLimit < 0 {
-					return fmt.Errorf("connector %s: rate_limit cannot be negative, got %d", name, conn.RateLimit)
+					return invalidField("ai.connectors."+name+".rate_limit", conn.RateLimit, "connector %s: rate_limit cannot be negative, got %d", name, conn.RateLimit)
 				}
 
 				// Warn if enabled but no API key (unless it's a test)
@@ -364,6 +832,14 @@ func ValidateConfig(c *Config) error {
 		}
 	}
 
+	// Validate excerpt fetching
+	if c.Excerpts.Timeout < 0 {
+		return invalidField("excerpts.timeout", c.Excerpts.Timeout, "excerpts timeout cannot be negative, got %d", c.Excerpts.Timeout)
+	}
+	if c.Excerpts.CacheTTL < 0 {
+		return invalidField("excerpts.cache_ttl", c.Excerpts.CacheTTL, "excerpts cache_ttl cannot be negative, got %d", c.Excerpts.CacheTTL)
+	}
+
 	return nil
 }
 
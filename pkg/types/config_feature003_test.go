@@ -241,6 +241,99 @@ func TestValidateAIConfigFeature003(t *testing.T) {
 			wantErr: true,
 			errMsg:  "budgets.maxTokens must be positive",
 		},
+		{
+			name: "valid redaction locales",
+			config: &Config{
+				LogLevel: "info",
+				Theme:    "dark",
+				UserRole: RoleComplianceManager,
+				Export:   ExportConfig{Format: "json"},
+				AI: AIConfig{
+					Enabled:  true,
+					Provider: AIProviderOpenAI,
+					Model:    "gpt-4",
+					APIKey:   "test-key",
+					Mode:     AIModeContext,
+					Timeout:  60,
+					Redaction: RedactionConfig{
+						Enabled: true,
+						Locales: []string{"eu", "UK", "intl"},
+					},
+					Concurrency: ConcurrencyLimits{
+						MaxAnalyses: 25,
+					},
+					Budgets: BudgetLimits{
+						MaxSources:  50,
+						MaxAPICalls: 500,
+						MaxTokens:   250000,
+					},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "unknown redaction locale",
+			config: &Config{
+				LogLevel: "info",
+				Theme:    "dark",
+				UserRole: RoleComplianceManager,
+				Export:   ExportConfig{Format: "json"},
+				AI: AIConfig{
+					Enabled:  true,
+					Provider: AIProviderOpenAI,
+					Model:    "gpt-4",
+					APIKey:   "test-key",
+					Mode:     AIModeContext,
+					Timeout:  60,
+					Redaction: RedactionConfig{
+						Enabled: true,
+						Locales: []string{"eu", "mars"},
+					},
+					Concurrency: ConcurrencyLimits{
+						MaxAnalyses: 25,
+					},
+					Budgets: BudgetLimits{
+						MaxSources:  50,
+						MaxAPICalls: 500,
+						MaxTokens:   250000,
+					},
+				},
+			},
+			wantErr: true,
+			errMsg:  "invalid redaction locale: mars",
+		},
+		{
+			name: "unknown denylist mode",
+			config: &Config{
+				LogLevel: "info",
+				Theme:    "dark",
+				UserRole: RoleComplianceManager,
+				Export:   ExportConfig{Format: "json"},
+				AI: AIConfig{
+					Enabled:  true,
+					Provider: AIProviderOpenAI,
+					Model:    "gpt-4",
+					APIKey:   "test-key",
+					Mode:     AIModeContext,
+					Timeout:  60,
+					Redaction: RedactionConfig{
+						Enabled:      true,
+						Denylist:     []string{"codename"},
+						DenylistMode: "warn",
+					},
+					Concurrency: ConcurrencyLimits{
+						MaxAnalyses: 25,
+					},
+					Budgets: BudgetLimits{
+						MaxSources:  50,
+						MaxAPICalls: 500,
+						MaxTokens:   250000,
+					},
+				},
+			},
+			wantErr: true,
+			errMsg:  "invalid denylist mode: warn",
+		},
 	}
 
 	for _, tt := range tests {
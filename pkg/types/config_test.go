@@ -1,6 +1,11 @@
 package types
 
-import "testing"
+import (
+	"errors"
+	"os"
+	"strings"
+	"testing"
+)
 
 func TestValidateConfig(t *testing.T) {
 	tests := []struct {
@@ -355,6 +360,24 @@ func TestValidateAIConfig(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		{
+			name: "AI enabled with API key file - valid",
+			config: &Config{
+				LogLevel: "info",
+				Theme:    "dark",
+				UserRole: RoleComplianceManager,
+				Export:   ExportConfig{Format: "json"},
+				AI: AIConfig{
+					Enabled:    true,
+					Provider:   AIProviderOpenAI,
+					Model:      "gpt-4",
+					APIKeyFile: "keyring://sdek/openai",
+					Timeout:    60,
+					RateLimit:  10,
+				},
+			},
+			wantErr: false,
+		},
 		{
 			name: "invalid AI provider",
 			config: &Config{
@@ -743,3 +766,305 @@ func TestBackwardCompatibilityFeature003(t *testing.T) {
 		t.Error("Feature 006 Providers map not initialized")
 	}
 }
+
+// TestValidateConfig_FieldPath verifies validation errors identify the offending setting
+func TestValidateConfig_FieldPath(t *testing.T) {
+	enabledAI := func(mutate func(c *Config)) *Config {
+		c := DefaultConfig()
+		c.AI.Enabled = true
+		c.AI.Provider = AIProviderOpenAI
+		c.AI.Model = "gpt-4o"
+		c.AI.Mode = AIModeContext
+		c.AI.OpenAIKey = "sk-test"
+		mutate(c)
+		return c
+	}
+
+	tests := []struct {
+		name      string
+		config    *Config
+		wantField string
+		wantValue interface{}
+	}{
+		{
+			name: "log level",
+			config: func() *Config {
+				c := DefaultConfig()
+				c.LogLevel = "verbose"
+				return c
+			}(),
+			wantField: "log_level",
+			wantValue: "verbose",
+		},
+		{
+			name: "second enabled framework",
+			config: func() *Config {
+				c := DefaultConfig()
+				c.Frameworks.Enabled = []string{"soc2", "hipaa"}
+				return c
+			}(),
+			wantField: "frameworks.enabled[1]",
+			wantValue: "hipaa",
+		},
+		{
+			name: "export file mode",
+			config: func() *Config {
+				c := DefaultConfig()
+				c.Export.FileMode = "0999"
+				return c
+			}(),
+			wantField: "export.file_mode",
+			wantValue: "0999",
+		},
+		{
+			name: "excerpts timeout",
+			config: func() *Config {
+				c := DefaultConfig()
+				c.Excerpts.Timeout = -1
+				return c
+			}(),
+			wantField: "excerpts.timeout",
+			wantValue: -1,
+		},
+		{
+			name:      "AI timeout",
+			config:    enabledAI(func(c *Config) { c.AI.Timeout = 0 }),
+			wantField: "ai.timeout",
+			wantValue: 0,
+		},
+		{
+			name:      "AI concurrency zero",
+			config:    enabledAI(func(c *Config) { c.AI.Concurrency.MaxAnalyses = 0 }),
+			wantField: "ai.concurrency.maxAnalyses",
+			wantValue: 0,
+		},
+		{
+			name:      "AI concurrency negative",
+			config:    enabledAI(func(c *Config) { c.AI.Concurrency.MaxAnalyses = -3 }),
+			wantField: "ai.concurrency.maxAnalyses",
+			wantValue: -3,
+		},
+		{
+			name:      "AI minimum events",
+			config:    enabledAI(func(c *Config) { c.AI.MinEventsForAI = -1 }),
+			wantField: "ai.min_events_for_ai",
+			wantValue: -1,
+		},
+		{
+			name:      "AI evidence maximum age",
+			config:    enabledAI(func(c *Config) { c.AI.EvidenceMaxAgeDays = -1 }),
+			wantField: "ai.evidence_max_age_days",
+			wantValue: -1,
+		},
+		{
+			name:      "AI consensus threshold",
+			config:    enabledAI(func(c *Config) { c.AI.ConsensusThreshold = 1.5 }),
+			wantField: "ai.consensus_threshold",
+			wantValue: 1.5,
+		},
+		{
+			name:      "AI prompt size limit",
+			config:    enabledAI(func(c *Config) { c.AI.MaxPromptChars = -1 }),
+			wantField: "ai.max_prompt_chars",
+			wantValue: -1,
+		},
+		{
+			name:      "AI rate limit burst",
+			config:    enabledAI(func(c *Config) { c.AI.RateLimitBurst = -1 }),
+			wantField: "ai.rate_limit_burst",
+			wantValue: -1,
+		},
+		{
+			name:      "AI minimum rationale length",
+			config:    enabledAI(func(c *Config) { c.AI.Autonomous.MinRationaleChars = -1 }),
+			wantField: "ai.autonomous.minRationaleChars",
+			wantValue: -1,
+		},
+		{
+			name:      "AI minimum signal strength",
+			config:    enabledAI(func(c *Config) { c.AI.Autonomous.MinSignalStrength = 1.5 }),
+			wantField: "ai.autonomous.minSignalStrength",
+			wantValue: 1.5,
+		},
+		{
+			name:      "AI maximum plan items",
+			config:    enabledAI(func(c *Config) { c.AI.Autonomous.MaxItems = -1 }),
+			wantField: "ai.autonomous.maxItems",
+			wantValue: -1,
+		},
+		{
+			name:      "AI cache size limit",
+			config:    enabledAI(func(c *Config) { c.AI.CacheMaxBytes = -1 }),
+			wantField: "ai.cache_max_bytes",
+			wantValue: int64(-1),
+		},
+		{
+			name:      "denylist mode",
+			config:    enabledAI(func(c *Config) { c.AI.Redaction.DenylistMode = "warn" }),
+			wantField: "ai.redaction.denylist_mode",
+			wantValue: "warn",
+		},
+		{
+			name: "empty pinned framework version",
+			config: func() *Config {
+				c := DefaultConfig()
+				c.Frameworks.Versions = map[string]string{"soc2": ""}
+				return c
+			}(),
+			wantField: "frameworks.versions.soc2",
+			wantValue: "",
+		},
+		{
+			name: "framework rubric confidence threshold",
+			config: func() *Config {
+				c := DefaultConfig()
+				c.Frameworks.Rubrics = map[string]AnalysisRubrics{"soc2": {ConfidenceThreshold: 1.5}}
+				return c
+			}(),
+			wantField: "frameworks.rubrics.soc2.confidence_threshold",
+			wantValue: 1.5,
+		},
+		{
+			name: "connector timeout",
+			config: enabledAI(func(c *Config) {
+				c.AI.Connectors = map[string]ConnectorConfig{"github": {Enabled: true, Timeout: -5}}
+			}),
+			wantField: "ai.connectors.github.timeout",
+			wantValue: -5,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateConfig(tt.config)
+			if err == nil {
+				t.Fatal("ValidateConfig() expected an error")
+			}
+
+			if !errors.Is(err, ErrInvalidConfig) {
+				t.Errorf("errors.Is(err, ErrInvalidConfig) = false for %v", err)
+			}
+
+			var validationErr *ConfigValidationError
+			if !errors.As(err, &validationErr) {
+				t.Fatalf("ValidateConfig() error = %T, want *ConfigValidationError", err)
+			}
+			if validationErr.Field != tt.wantField {
+				t.Errorf("Field = %q, want %q", validationErr.Field, tt.wantField)
+			}
+			if validationErr.Value != tt.wantValue {
+				t.Errorf("Value = %v, want %v", validationErr.Value, tt.wantValue)
+			}
+			if !strings.HasPrefix(err.Error(), tt.wantField+": ") {
+				t.Errorf("Error() = %q, want it prefixed with the field path", err.Error())
+			}
+		})
+	}
+}
+
+// TestFrameworksConfig_VersionFor verifies pinned version lookup
+func TestFrameworksConfig_VersionFor(t *testing.T) {
+	frameworks := FrameworksConfig{Versions: map[string]string{
+		FrameworkSOC2:     "2017",
+		FrameworkPCIDSS:   "4.0",
+		FrameworkISO27001: "2022",
+	}}
+
+	tests := []struct {
+		framework string
+		want      string
+	}{
+		{framework: "soc2", want: "2017"},
+		{framework: "SOC2", want: "2017"},
+		{framework: "PCI-DSS", want: "4.0"},
+		{framework: "ISO 27001", want: "2022"},
+		{framework: "hipaa", want: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.framework, func(t *testing.T) {
+			if got := frameworks.VersionFor(tt.framework); got != tt.want {
+				t.Errorf("VersionFor(%q) = %q, want %q", tt.framework, got, tt.want)
+			}
+		})
+	}
+
+	if got := (FrameworksConfig{}).VersionFor("soc2"); got != "" {
+		t.Errorf("VersionFor() with no pins = %q, want empty", got)
+	}
+}
+
+func TestExportConfig_Mode(t *testing.T) {
+	tests := []struct {
+		fileMode string
+		want     os.FileMode
+	}{
+		{"", DefaultExportFileMode},
+		{"0644", 0644},
+		{"0600", 0600},
+		{"640", 0640},
+		{"0o640", 0640},
+		{"not-octal", DefaultExportFileMode},
+		{"01777", DefaultExportFileMode},
+	}
+
+	for _, tt := range tests {
+		got := ExportConfig{FileMode: tt.fileMode}.Mode()
+		if got != tt.want {
+			t.Errorf("ExportConfig{FileMode: %q}.Mode() = %o, want %o", tt.fileMode, got, tt.want)
+		}
+	}
+}
+
+// TestFrameworksConfig_RubricsFor verifies per-framework rubric overrides are merged over the defaults
+func TestFrameworksConfig_RubricsFor(t *testing.T) {
+	frameworks := FrameworksConfig{Rubrics: map[string]AnalysisRubrics{
+		FrameworkSOC2: {ConfidenceThreshold: 0.8, Guidance: "Require evidence from the audit period"},
+	}}
+
+	got := frameworks.RubricsFor("SOC2")
+	if got.ConfidenceThreshold != 0.8 || got.Guidance != "Require evidence from the audit period" {
+		t.Errorf("RubricsFor(SOC2) = %+v, want threshold 0.8 with guidance", got)
+	}
+	defaults := DefaultAnalysisRubrics()
+	if got.RequiredCitations != defaults.RequiredCitations || len(got.RiskLevels) != len(defaults.RiskLevels) {
+		t.Errorf("RubricsFor(SOC2) = %+v, want unset fields to keep defaults %+v", got, defaults)
+	}
+
+	if got := frameworks.RubricsFor("iso27001"); got.ConfidenceThreshold != defaults.ConfidenceThreshold || got.Guidance != "" {
+		t.Errorf("RubricsFor(iso27001) = %+v, want defaults", got)
+	}
+}
+
+func TestAIConfig_ModelFor(t *testing.T) {
+	cfg := AIConfig{ProviderDefaults: map[string]string{"openai": "gpt-4o"}}
+
+	if got := cfg.ModelFor("openai"); got != "gpt-4o" {
+		t.Errorf("ModelFor(openai) = %q, want the overridden default gpt-4o", got)
+	}
+	if got := cfg.ModelFor("anthropic"); got != "claude-3-opus-20240229" {
+		t.Errorf("ModelFor(anthropic) = %q, want the built-in default", got)
+	}
+	if got := cfg.ModelFor("ollama"); got != "" {
+		t.Errorf("ModelFor(ollama) = %q, want no default", got)
+	}
+
+	cfg.Model = "gpt-4-turbo"
+	if got := cfg.ModelFor("openai"); got != "gpt-4-turbo" {
+		t.Errorf("ModelFor(openai) = %q, want ai.model to take precedence", got)
+	}
+}
+
+func TestAIConfig_SourceAllowed(t *testing.T) {
+	if !(AIConfig{}).SourceAllowed("slack") {
+		t.Error("SourceAllowed(slack) = false, want every source allowed without ai.allowed_sources")
+	}
+
+	cfg := AIConfig{AllowedSources: []string{"github", "Jira"}}
+	if !cfg.SourceAllowed("github") || !cfg.SourceAllowed("jira") {
+		t.Error("SourceAllowed() = false for a listed source")
+	}
+	if cfg.SourceAllowed("slack") {
+		t.Error("SourceAllowed(slack) = true, want unlisted sources rejected")
+	}
+}
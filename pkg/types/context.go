@@ -27,9 +27,39 @@ type ContextPreamble struct {
 
 // AnalysisRubrics defines confidence and risk evaluation criteria for AI analysis.
 type AnalysisRubrics struct {
-	ConfidenceThreshold float64  `json:"confidence_threshold"` // Default: 0.6
-	RiskLevels          []string `json:"risk_levels"`          // ["low", "medium", "high"]
-	RequiredCitations   int      `json:"required_citations"`   // Min citations for high confidence
+	ConfidenceThreshold float64  `json:"confidence_threshold" mapstructure:"confidence_threshold" yaml:"confidence_threshold"` // Default: 0.6
+	RiskLevels          []string `json:"risk_levels" mapstructure:"risk_levels" yaml:"risk_levels"`                            // ["low", "medium", "high"]
+	RequiredCitations   int      `json:"required_citations" mapstructure:"required_citations" yaml:"required_citations"`       // Min citations for high confidence
+
+	// Guidance is scoring guidance injected into the analysis prompt, e.g. what
+	// counts as sufficient evidence for this framework
+	Guidance string `json:"guidance,omitempty" mapstructure:"guidance" yaml:"guidance,omitempty"`
+}
+
+// Merge returns r with the non-zero fields of override applied
+func (r AnalysisRubrics) Merge(override AnalysisRubrics) AnalysisRubrics {
+	if override.ConfidenceThreshold != 0 {
+		r.ConfidenceThreshold = override.ConfidenceThreshold
+	}
+	if len(override.RiskLevels) > 0 {
+		r.RiskLevels = override.RiskLevels
+	}
+	if override.RequiredCitations != 0 {
+		r.RequiredCitations = override.RequiredCitations
+	}
+	if override.Guidance != "" {
+		r.Guidance = override.Guidance
+	}
+	return r
+}
+
+// DefaultAnalysisRubrics returns the rubrics used when none are specified.
+func DefaultAnalysisRubrics() AnalysisRubrics {
+	return AnalysisRubrics{
+		ConfidenceThreshold: 0.6,
+		RiskLevels:          []string{"low", "medium", "high"},
+		RequiredCitations:   3,
+	}
 }
 
 // Validation constants
@@ -48,14 +78,7 @@ func NewContextPreamble(
 	excerpt string,
 	controlIDs []string,
 ) (*ContextPreamble, error) {
-	// Use default rubrics
-	defaultRubrics := AnalysisRubrics{
-		ConfidenceThreshold: 0.6,
-		RiskLevels:          []string{"low", "medium", "high"},
-		RequiredCitations:   3,
-	}
-
-	return NewContextPreambleWithRubrics(framework, version, section, excerpt, controlIDs, defaultRubrics)
+	return NewContextPreambleWithRubrics(framework, version, section, excerpt, controlIDs, DefaultAnalysisRubrics())
 }
 
 // NewContextPreambleWithRubrics creates a new ContextPreamble with custom rubrics.
@@ -17,6 +17,7 @@ type Event struct {
 	Content   string                 `json:"content"`
 	Author    string                 `json:"author"`
 	Metadata  map[string]interface{} `json:"metadata"`
+	Labels    map[string]string      `json:"labels,omitempty"` // e.g. env=prod, team=platform
 }
 
 // Event type constants
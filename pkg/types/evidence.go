@@ -25,6 +25,9 @@ type Evidence struct {
 	HeuristicConfidence int    `json:"heuristic_confidence"` // Original keyword-based score (0-100)
 	CombinedConfidence  int    `json:"combined_confidence"`  // Weighted average (70% AI + 30% heuristic)
 	AnalysisMethod      string `json:"analysis_method"`      // "ai+heuristic" | "heuristic-only" | "no-ai"
+
+	// Labels copied from the mapped event (e.g. env=prod, team=platform)
+	Labels map[string]string `json:"labels,omitempty"`
 }
 
 // Confidence level constants
@@ -19,17 +19,41 @@ type Finding struct {
 	AssignedTo  string    `json:"assigned_to"`
 
 	// AI Analysis fields (Feature 003)
-	Summary         string            `json:"summary"`
-	MappedControls  []string          `json:"mapped_controls"`
-	ConfidenceScore float64           `json:"confidence_score"`
-	ResidualRisk    string            `json:"residual_risk"`
-	Justification   string            `json:"justification"`
-	Citations       []string          `json:"citations"`
-	ReviewRequired  bool              `json:"review_required"`
-	Mode            string            `json:"mode"` // "ai" or "heuristics"
-	Provenance      []ProvenanceEntry `json:"provenance,omitempty"`
+	Summary           string            `json:"summary"`
+	MappedControls    []string          `json:"mapped_controls"`
+	RelatedControls   []string          `json:"related_controls,omitempty"` // Related sections named in the analysis context
+	Labels            []string          `json:"labels,omitempty"`           // key=value labels of the evidence behind the finding
+	ConfidenceScore   float64           `json:"confidence_score"`
+	ResidualRisk      string            `json:"residual_risk"`
+	Justification     string            `json:"justification"`
+	Citations         []string          `json:"citations"`
+	CitationsDetailed []string          `json:"citations_detailed,omitempty"` // Citations with event source and date, rendered on request
+	ReviewRequired    bool              `json:"review_required"`
+	ReviewReason      string            `json:"review_reason,omitempty"` // Why a check flagged the finding for review, such as too few citations
+	Mode              string            `json:"mode"`                    // "ai" or "heuristics"
+	Provenance        []ProvenanceEntry `json:"provenance,omitempty"`
+	Provider          string            `json:"provider,omitempty"`            // AI provider that produced the analysis
+	Model             string            `json:"model,omitempty"`               // Model that produced the analysis
+	LatencyMs         int               `json:"latency_ms,omitempty"`          // Provider response time of the original analysis
+	CacheReadTokens   int               `json:"cache_read_tokens,omitempty"`   // Prompt tokens the provider served from its prompt cache
+	CacheHit          bool              `json:"cache_hit"`                     // True if served from cache
+	Seed              *int              `json:"seed,omitempty"`                // Sampling seed sent to the provider, if any
+	Redactions        *RedactionSummary `json:"redactions,omitempty"`          // Redactions applied to the evidence before analysis
+	Evidence          []CitedEvidence   `json:"evidence,omitempty"`            // Cited event contents, embedded on request
+	StaleEvidence     bool              `json:"stale_evidence,omitempty"`      // Newest cited event is older than ai.evidence_max_age_days
+	StaleEvidenceNote string            `json:"stale_evidence_note,omitempty"` // How old the newest cited event is, when StaleEvidence is set
+
+	// Triage fields
+	StatusReason string `json:"status_reason,omitempty"` // Required when waived
+
+	// SchemaVersion is set when findings are written to their own file
+	SchemaVersion int `json:"schema_version,omitempty"`
 }
 
+// SchemaVersion is the current version of the findings and report JSON format.
+// Files written before versioning carry no schema_version and are version 1.
+const SchemaVersion = 2
+
 // ProvenanceEntry represents a source that contributed to the finding.
 type ProvenanceEntry struct {
 	Source     string `json:"source"`      // "github", "aws", etc.
@@ -37,6 +61,17 @@ type ProvenanceEntry struct {
 	EventsUsed int    `json:"events_used"` // Count of events from this source
 }
 
+// CitedEvidence is the content of an event cited by a finding, embedded so
+// reviewers don't have to cross-reference evidence files.
+type CitedEvidence struct {
+	ID        string    `json:"id"`
+	Source    string    `json:"source"`
+	Type      string    `json:"type"`
+	Timestamp time.Time `json:"timestamp"`
+	Content   string    `json:"content"`
+	Redacted  bool      `json:"redacted"` // Content was passed through the redactor
+}
+
 // Severity constants
 const (
 	SeverityLow      = "low"
@@ -47,11 +82,16 @@ const (
 
 // Status constants
 const (
-	StatusOpen       = "open"
-	StatusInProgress = "in_progress"
-	StatusResolved   = "resolved"
+	StatusOpen         = "open"
+	StatusInProgress   = "in_progress"
+	StatusAcknowledged = "acknowledged"
+	StatusResolved     = "resolved"
+	StatusWaived       = "waived"
 )
 
+// ValidFindingStatuses contains all valid finding statuses
+var ValidFindingStatuses = []string{StatusOpen, StatusInProgress, StatusAcknowledged, StatusResolved, StatusWaived}
+
 // ValidateFinding checks if a Finding meets all validation rules
 func ValidateFinding(f *Finding) error {
 	if f == nil {
@@ -83,16 +123,15 @@ func ValidateFinding(f *Finding) error {
 	}
 
 	// Validate status
-	validStatuses := []string{StatusOpen, StatusInProgress, StatusResolved}
 	valid = false
-	for _, s := range validStatuses {
+	for _, s := range ValidFindingStatuses {
 		if f.Status == s {
 			valid = true
 			break
 		}
 	}
 	if !valid {
-		return fmt.Errorf("invalid status: %s, must be one of %v", f.Status, validStatuses)
+		return fmt.Errorf("invalid status: %s, must be one of %v", f.Status, ValidFindingStatuses)
 	}
 
 	// Validate title
@@ -121,9 +160,8 @@ func NewFinding(id, controlID, frameworkID, title, severity string) *Finding {
 
 // UpdateStatus updates the finding status and UpdatedAt timestamp
 func (f *Finding) UpdateStatus(status string) error {
-	validStatuses := []string{StatusOpen, StatusInProgress, StatusResolved}
 	valid := false
-	for _, s := range validStatuses {
+	for _, s := range ValidFindingStatuses {
 		if status == s {
 			valid = true
 			break
@@ -1,6 +1,10 @@
 package types
 
-import "fmt"
+import (
+	"fmt"
+	"strings"
+	"unicode"
+)
 
 // Framework represents a compliance standard
 type Framework struct {
@@ -27,6 +31,63 @@ var ValidFrameworkIDs = []string{
 	FrameworkPCIDSS,
 }
 
+// FrameworkID is a canonical framework identifier, one of ValidFrameworkIDs
+type FrameworkID string
+
+// frameworkAliases maps normalized framework names to their canonical IDs
+var frameworkAliases = map[string]FrameworkID{
+	"soc2":            FrameworkSOC2,
+	"soc2type1":       FrameworkSOC2,
+	"soc2type2":       FrameworkSOC2,
+	"soc2typei":       FrameworkSOC2,
+	"soc2typeii":      FrameworkSOC2,
+	"aicpasoc2":       FrameworkSOC2,
+	"iso27001":        FrameworkISO27001,
+	"iso270012013":    FrameworkISO27001,
+	"iso270012022":    FrameworkISO27001,
+	"isoiec270012013": FrameworkISO27001,
+	"isoiec270012022": FrameworkISO27001,
+	"isoiec27001":     FrameworkISO27001,
+	"iso27k":          FrameworkISO27001,
+	"pcidss":          FrameworkPCIDSS,
+	"pci":             FrameworkPCIDSS,
+	"pcidssv4":        FrameworkPCIDSS,
+	"pcidss40":        FrameworkPCIDSS,
+	"pcidssv40":       FrameworkPCIDSS,
+}
+
+// NormalizeFramework maps a framework name to its canonical ID. Matching ignores
+// case and punctuation and accepts common aliases, so "SOC2", "soc-2" and
+// "SOC 2 Type II" all return FrameworkSOC2. It returns false for unknown frameworks.
+func NormalizeFramework(name string) (FrameworkID, bool) {
+	id, ok := frameworkAliases[normalizeFrameworkID(name)]
+	return id, ok
+}
+
+// normalizeFrameworkID lowercases id and drops everything except letters and digits
+func normalizeFrameworkID(id string) string {
+	return strings.Map(func(r rune) rune {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			return unicode.ToLower(r)
+		}
+		return -1
+	}, id)
+}
+
+// frameworkKey returns the canonical ID of a known framework, or the normalized
+// name of an unknown one, so two names for the same framework compare equal
+func frameworkKey(name string) string {
+	if id, ok := NormalizeFramework(name); ok {
+		return string(id)
+	}
+	return normalizeFrameworkID(name)
+}
+
+// SameFramework reports whether a and b name the same framework
+func SameFramework(a, b string) bool {
+	return frameworkKey(a) == frameworkKey(b)
+}
+
 // ValidateFramework checks if a Framework meets all validation rules
 func ValidateFramework(f *Framework) error {
 	if f == nil {
@@ -91,3 +91,44 @@ func TestNewFramework(t *testing.T) {
 		t.Errorf("expected initial compliance 0, got %.2f", framework.CompliancePercentage)
 	}
 }
+
+func TestNormalizeFramework(t *testing.T) {
+	tests := []struct {
+		name   string
+		want   FrameworkID
+		wantOK bool
+	}{
+		{"soc2", FrameworkSOC2, true},
+		{"SOC2", FrameworkSOC2, true},
+		{"SOC-2", FrameworkSOC2, true},
+		{"SOC 2 Type II", FrameworkSOC2, true},
+		{"iso27001", FrameworkISO27001, true},
+		{"ISO/IEC 27001:2022", FrameworkISO27001, true},
+		{"pci_dss", FrameworkPCIDSS, true},
+		{"PCI-DSS", FrameworkPCIDSS, true},
+		{"PCI DSS v4.0", FrameworkPCIDSS, true},
+		{"hipaa", "", false},
+		{"", "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := NormalizeFramework(tt.name)
+			if got != tt.want || ok != tt.wantOK {
+				t.Errorf("NormalizeFramework(%q) = (%q, %v), want (%q, %v)", tt.name, got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestSameFramework(t *testing.T) {
+	if !SameFramework("SOC2", "soc-2") {
+		t.Error("expected SOC2 and soc-2 to be the same framework")
+	}
+	if !SameFramework("HIPAA", "hipaa") {
+		t.Error("expected unknown frameworks to match case-insensitively")
+	}
+	if SameFramework("SOC2", "ISO27001") {
+		t.Error("expected SOC2 and ISO27001 to differ")
+	}
+}
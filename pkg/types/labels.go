@@ -0,0 +1,59 @@
+package types
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// FormatLabel renders a label as "key=value"
+func FormatLabel(key, value string) string {
+	return key + "=" + value
+}
+
+// ParseLabel parses a "key=value" label. The key must be non-empty; the value may be empty.
+func ParseLabel(label string) (key, value string, err error) {
+	key, value, ok := strings.Cut(label, "=")
+	key = strings.TrimSpace(key)
+	if !ok || key == "" {
+		return "", "", fmt.Errorf("invalid label %q, expected key=value", label)
+	}
+	return key, strings.TrimSpace(value), nil
+}
+
+// LabelPairs merges label maps into sorted, unique "key=value" pairs. A key
+// with different values in different maps yields one pair per value.
+func LabelPairs(labels ...map[string]string) []string {
+	seen := make(map[string]bool)
+	var pairs []string
+	for _, m := range labels {
+		for key, value := range m {
+			pair := FormatLabel(key, value)
+			if !seen[pair] {
+				seen[pair] = true
+				pairs = append(pairs, pair)
+			}
+		}
+	}
+	sort.Strings(pairs)
+	return pairs
+}
+
+// HasLabels reports whether labels has every key with the value given in selector
+func HasLabels(labels, selector map[string]string) bool {
+	for key, value := range selector {
+		if got, ok := labels[key]; !ok || got != value {
+			return false
+		}
+	}
+	return true
+}
+
+// Labels returns the labels of the bundle's events as sorted "key=value" pairs
+func (b EvidenceBundle) Labels() []string {
+	labels := make([]map[string]string, 0, len(b.Events))
+	for _, event := range b.Events {
+		labels = append(labels, event.Labels)
+	}
+	return LabelPairs(labels...)
+}
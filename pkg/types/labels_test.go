@@ -0,0 +1,50 @@
+package types
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseLabel(t *testing.T) {
+	tests := []struct {
+		input     string
+		wantKey   string
+		wantValue string
+		wantErr   bool
+	}{
+		{input: "env=prod", wantKey: "env", wantValue: "prod"},
+		{input: " team = platform ", wantKey: "team", wantValue: "platform"},
+		{input: "release=", wantKey: "release", wantValue: ""},
+		{input: "url=a=b", wantKey: "url", wantValue: "a=b"},
+		{input: "env", wantErr: true},
+		{input: "=prod", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			key, value, err := ParseLabel(tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseLabel(%q) error = %v, wantErr %v", tt.input, err, tt.wantErr)
+			}
+			if key != tt.wantKey || value != tt.wantValue {
+				t.Errorf("ParseLabel(%q) = %q, %q, want %q, %q", tt.input, key, value, tt.wantKey, tt.wantValue)
+			}
+		})
+	}
+}
+
+func TestEvidenceBundle_Labels(t *testing.T) {
+	bundle := EvidenceBundle{Events: []EvidenceEvent{
+		{ID: "1", Labels: map[string]string{"env": "prod", "team": "platform"}},
+		{ID: "2", Labels: map[string]string{"env": "staging", "team": "platform"}},
+		{ID: "3"},
+	}}
+
+	want := []string{"env=prod", "env=staging", "team=platform"}
+	if got := bundle.Labels(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Labels() = %v, want %v", got, want)
+	}
+	if got := (EvidenceBundle{}).Labels(); got != nil {
+		t.Errorf("Labels() of an empty bundle = %v, want nil", got)
+	}
+}
@@ -9,7 +9,8 @@ type MCPConfig struct {
 	// PreferMCP determines if MCP tools take precedence over legacy connectors
 	PreferMCP bool `yaml:"prefer_mcp" json:"prefer_mcp" mapstructure:"prefer_mcp"`
 
-	// MaxConcurrent sets the maximum concurrent MCP server connections (1-100)
+	// MaxConcurrent sets the maximum number of tool calls in flight at once
+	// across all MCP servers (1-100); further calls wait for a free slot
 	MaxConcurrent int `yaml:"max_concurrent" json:"max_concurrent" mapstructure:"max_concurrent"`
 
 	// HealthCheckInterval sets seconds between health checks (minimum 60)
@@ -37,8 +37,12 @@ type PlanItem struct {
 	Rationale      string  `json:"rationale"`       // Why this source/query
 
 	// Approval
-	ApprovalStatus ApprovalStatus `json:"approval_status"` // pending|approved|denied|auto_approved
-	AutoApproved   bool           `json:"auto_approved"`   // Matched auto-approve policy
+	ApprovalStatus ApprovalStatus `json:"approval_status"`          // pending|approved|denied|auto_approved
+	AutoApproved   bool           `json:"auto_approved"`            // Matched auto-approve policy
+	WeakRationale  bool           `json:"weak_rationale,omitempty"` // Rationale missing or too short; needs manual approval
+
+	// AutoApprovePattern is the ai.autonomous.autoApprove pattern the query matched
+	AutoApprovePattern string `json:"auto_approve_pattern,omitempty"`
 
 	// Execution
 	ExecutionStatus ExecStatus `json:"execution_status,omitempty"` // pending|running|complete|failed
@@ -26,6 +26,18 @@ type ProviderConfig struct {
 	// MaxTokens is the maximum response tokens (default 4096)
 	MaxTokens int `yaml:"max_tokens" json:"max_tokens" mapstructure:"max_tokens"`
 
+	// Seed requests deterministic sampling where supported (OpenAI, Ollama); nil leaves it unset
+	Seed *int `yaml:"seed,omitempty" json:"seed,omitempty" mapstructure:"seed"`
+
+	// RateLimit caps requests per minute, shared by every client of the same provider endpoint (0 = unlimited)
+	RateLimit int `yaml:"rate_limit,omitempty" json:"rate_limit,omitempty" mapstructure:"rate_limit"`
+
+	// RateLimitBurst is how many requests may be sent at once before RateLimit applies (0 derives it from RateLimit)
+	RateLimitBurst int `yaml:"rate_limit_burst,omitempty" json:"rate_limit_burst,omitempty" mapstructure:"rate_limit_burst"`
+
+	// SystemPrompt overrides the provider's built-in system message (empty uses the default)
+	SystemPrompt string `yaml:"system_prompt,omitempty" json:"system_prompt,omitempty" mapstructure:"system_prompt"`
+
 	// Extra contains provider-specific settings
 	Extra map[string]string `yaml:"extra,omitempty" json:"extra,omitempty" mapstructure:"extra"`
 }
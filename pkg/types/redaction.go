@@ -1,6 +1,7 @@
 package types
 
 import (
+	"strings"
 	"time"
 )
 
@@ -14,6 +15,7 @@ type RedactionMap struct {
 	// Statistics (safe to log/export)
 	TotalRedactions int             `json:"total_redactions"`
 	RedactionTypes  []RedactionType `json:"redaction_types"`
+	Categories      map[string]int  `json:"categories"` // Redactions per category, e.g. "email", "ip", "secret"
 }
 
 // RedactionEntry represents a single redacted piece of content.
@@ -39,6 +41,7 @@ func NewRedactionMap() *RedactionMap {
 		entries:         make(map[string]RedactionEntry),
 		TotalRedactions: 0,
 		RedactionTypes:  []RedactionType{},
+		Categories:      make(map[string]int),
 	}
 }
 
@@ -46,6 +49,7 @@ func NewRedactionMap() *RedactionMap {
 func (rm *RedactionMap) AddEntry(hash string, entry RedactionEntry) {
 	rm.entries[hash] = entry
 	rm.TotalRedactions++
+	rm.Categories[RedactionCategory(entry.Placeholder)]++
 
 	// Add type if not already present
 	typeExists := false
@@ -75,3 +79,34 @@ func (rm *RedactionMap) HasType(redactionType RedactionType) bool {
 	}
 	return false
 }
+
+// RedactionCategory derives a category from a placeholder: "[REDACTED:PII:EMAIL]"
+// becomes "email" and "[REDACTED:SECRET]" becomes "secret".
+func RedactionCategory(placeholder string) string {
+	name := strings.TrimSuffix(strings.TrimPrefix(placeholder, "[REDACTED:"), "]")
+	if i := strings.LastIndex(name, ":"); i >= 0 {
+		name = name[i+1:]
+	}
+	return strings.ToLower(name)
+}
+
+// RedactionSummary totals the redactions applied across an evidence bundle.
+// It holds counts only and is safe to export alongside a finding.
+type RedactionSummary struct {
+	Total      int            `json:"total"`
+	ByCategory map[string]int `json:"by_category,omitempty"`
+}
+
+// Add accumulates the counts from one redaction map.
+func (s *RedactionSummary) Add(rm *RedactionMap) {
+	if rm == nil || rm.TotalRedactions == 0 {
+		return
+	}
+	if s.ByCategory == nil {
+		s.ByCategory = make(map[string]int)
+	}
+	s.Total += rm.TotalRedactions
+	for category, n := range rm.Categories {
+		s.ByCategory[category] += n
+	}
+}
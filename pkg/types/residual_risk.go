@@ -0,0 +1,66 @@
+package types
+
+import "strings"
+
+// ResidualRisk is the risk that remains for a control after the evidence is
+// taken into account
+type ResidualRisk string
+
+// Residual risk levels
+const (
+	ResidualRiskLow    ResidualRisk = "low"
+	ResidualRiskMedium ResidualRisk = "medium"
+	ResidualRiskHigh   ResidualRisk = "high"
+)
+
+// ValidResidualRisks contains all valid residual risk levels, lowest first
+var ValidResidualRisks = []ResidualRisk{ResidualRiskLow, ResidualRiskMedium, ResidualRiskHigh}
+
+// residualRiskSynonyms maps lowercased words models use for risk to a level
+var residualRiskSynonyms = map[string]ResidualRisk{
+	"low":           ResidualRiskLow,
+	"none":          ResidualRiskLow,
+	"no risk":       ResidualRiskLow,
+	"minimal":       ResidualRiskLow,
+	"minor":         ResidualRiskLow,
+	"negligible":    ResidualRiskLow,
+	"insignificant": ResidualRiskLow,
+	"medium":        ResidualRiskMedium,
+	"med":           ResidualRiskMedium,
+	"moderate":      ResidualRiskMedium,
+	"intermediate":  ResidualRiskMedium,
+	"high":          ResidualRiskHigh,
+	"elevated":      ResidualRiskHigh,
+	"significant":   ResidualRiskHigh,
+	"severe":        ResidualRiskHigh,
+	"critical":      ResidualRiskHigh,
+}
+
+// NormalizeResidualRisk maps a residual risk from provider output to its level.
+// Matching ignores case, surrounding whitespace and punctuation and a trailing
+// "risk", and accepts synonyms, so "Minimal", "moderate risk" and "HIGH." all
+// resolve. It returns false for unknown values.
+func NormalizeResidualRisk(risk string) (ResidualRisk, bool) {
+	key := strings.ToLower(strings.Trim(strings.TrimSpace(risk), ".!-_ "))
+	if level, ok := residualRiskSynonyms[key]; ok {
+		return level, true
+	}
+	if trimmed := strings.TrimSpace(strings.TrimSuffix(key, "risk")); trimmed != key {
+		level, ok := residualRiskSynonyms[trimmed]
+		return level, ok
+	}
+	return "", false
+}
+
+// Severity returns the finding severity for the residual risk level, medium for
+// anything that is not a valid level
+func (r ResidualRisk) Severity() string {
+	switch r {
+	case ResidualRiskLow:
+		return SeverityLow
+	case ResidualRiskHigh:
+		return SeverityHigh
+	default:
+		return SeverityMedium
+	}
+}
@@ -0,0 +1,54 @@
+package types
+
+import "testing"
+
+func TestNormalizeResidualRisk(t *testing.T) {
+	tests := []struct {
+		risk   string
+		want   ResidualRisk
+		wantOK bool
+	}{
+		{"low", ResidualRiskLow, true},
+		{"LOW", ResidualRiskLow, true},
+		{"  Medium ", ResidualRiskMedium, true},
+		{"High.", ResidualRiskHigh, true},
+		{"minimal", ResidualRiskLow, true},
+		{"Negligible", ResidualRiskLow, true},
+		{"none", ResidualRiskLow, true},
+		{"moderate risk", ResidualRiskMedium, true},
+		{"MED", ResidualRiskMedium, true},
+		{"Elevated", ResidualRiskHigh, true},
+		{"critical", ResidualRiskHigh, true},
+		{"severe risk", ResidualRiskHigh, true},
+		{"MFA is not enforced for contractors", "", false},
+		{"risk", "", false},
+		{"", "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.risk, func(t *testing.T) {
+			got, ok := NormalizeResidualRisk(tt.risk)
+			if got != tt.want || ok != tt.wantOK {
+				t.Errorf("NormalizeResidualRisk(%q) = (%q, %v), want (%q, %v)", tt.risk, got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestResidualRiskSeverity(t *testing.T) {
+	tests := []struct {
+		risk ResidualRisk
+		want string
+	}{
+		{ResidualRiskLow, SeverityLow},
+		{ResidualRiskMedium, SeverityMedium},
+		{ResidualRiskHigh, SeverityHigh},
+		{"unknown", SeverityMedium},
+	}
+
+	for _, tt := range tests {
+		if got := tt.risk.Severity(); got != tt.want {
+			t.Errorf("ResidualRisk(%q).Severity() = %q, want %q", tt.risk, got, tt.want)
+		}
+	}
+}
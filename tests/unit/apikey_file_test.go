@@ -0,0 +1,66 @@
+package unit
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/pickjonathan/sdek-cli/internal/ai"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadAPIKey_FromFile(t *testing.T) {
+	// Arrange
+	path := filepath.Join(t.TempDir(), "openai.key")
+	require.NoError(t, os.WriteFile(path, []byte("sk-test-key-123\n"), 0600))
+
+	// Act
+	key, err := ai.ReadAPIKey(path)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, "sk-test-key-123", key, "trailing newline should be trimmed")
+}
+
+func TestReadAPIKey_WorldReadableFileStillLoads(t *testing.T) {
+	// Arrange
+	path := filepath.Join(t.TempDir(), "anthropic.key")
+	require.NoError(t, os.WriteFile(path, []byte("sk-ant-test"), 0644))
+	require.NoError(t, os.Chmod(path, 0644))
+
+	// Act
+	key, err := ai.ReadAPIKey(path)
+
+	// Assert
+	require.NoError(t, err, "a world-readable key file is warned about, not rejected")
+	assert.Equal(t, "sk-ant-test", key)
+}
+
+func TestReadAPIKey_Errors(t *testing.T) {
+	dir := t.TempDir()
+	emptyPath := filepath.Join(dir, "empty.key")
+	require.NoError(t, os.WriteFile(emptyPath, []byte("  \n"), 0600))
+
+	tests := []struct {
+		name    string
+		ref     string
+		wantErr string
+	}{
+		{name: "missing file", ref: filepath.Join(dir, "missing.key"), wantErr: "failed to read API key file"},
+		{name: "empty file", ref: emptyPath, wantErr: "is empty"},
+		{name: "keyring without account", ref: "keyring://sdek", wantErr: "invalid keyring reference"},
+		{name: "keyring without service", ref: "keyring:///openai", wantErr: "invalid keyring reference"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			_, err := ai.ReadAPIKey(tt.ref)
+
+			// Assert
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
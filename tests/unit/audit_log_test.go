@@ -0,0 +1,154 @@
+package unit
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/pickjonathan/sdek-cli/internal/ai"
+	"github.com/pickjonathan/sdek-cli/pkg/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// readAuditEntries parses every JSON line in the audit log
+func readAuditEntries(t *testing.T, path string) []ai.AuditEntry {
+	t.Helper()
+
+	f, err := os.Open(path)
+	require.NoError(t, err)
+	defer f.Close()
+
+	var entries []ai.AuditEntry
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		var entry ai.AuditEntry
+		require.NoError(t, json.Unmarshal(scanner.Bytes(), &entry))
+		entries = append(entries, entry)
+	}
+	require.NoError(t, scanner.Err())
+
+	return entries
+}
+
+// auditEntriesFor returns the audit entries of the given operation
+func auditEntriesFor(entries []ai.AuditEntry, operation string) []ai.AuditEntry {
+	var matched []ai.AuditEntry
+	for _, entry := range entries {
+		if entry.Operation == operation {
+			matched = append(matched, entry)
+		}
+	}
+	return matched
+}
+
+func TestAnalyze_WritesAuditLogEntryPerCall(t *testing.T) {
+	// Arrange
+	auditPath := filepath.Join(t.TempDir(), "audit", "ai.jsonl")
+	cfg := &types.Config{
+		AI: types.AIConfig{
+			Enabled:  true,
+			Provider: "anthropic",
+			Model:    "claude-3-5-sonnet-20241022",
+			Mode:     types.AIModeContext,
+			Redaction: types.RedactionConfig{
+				Enabled: true,
+			},
+		},
+		AuditLog: types.AuditLogConfig{Path: auditPath},
+	}
+	engine := ai.NewEngine(cfg, ai.NewMockProvider())
+
+	evidence := types.EvidenceBundle{
+		Events: []types.EvidenceEvent{
+			{
+				ID:        "evt-1",
+				Source:    "slack",
+				Timestamp: time.Now(),
+				Type:      "message",
+				Content:   "User john.doe@company.com requested access from IP 192.168.1.100",
+			},
+		},
+	}
+
+	// Act
+	for _, section := range []string{"CC6.1", "CC6.2"} {
+		preamble, err := types.NewContextPreamble(
+			"SOC2",
+			"2017",
+			section,
+			"Access controls shall be implemented to ensure that only authorized individuals can access sensitive data.",
+			nil,
+		)
+		require.NoError(t, err)
+
+		_, err = engine.Analyze(context.Background(), *preamble, evidence)
+		require.NoError(t, err)
+	}
+
+	// Assert
+	entries := readAuditEntries(t, auditPath)
+	require.Len(t, entries, 2, "Expected one audit entry per provider call")
+
+	for _, entry := range entries {
+		assert.Equal(t, ai.AuditOperationAnalyzeCall, entry.Operation, "Without a cache the analyses are not replayable")
+		assert.Equal(t, "anthropic", entry.Provider)
+		assert.Equal(t, "claude-3-5-sonnet-20241022", entry.Model)
+		assert.Equal(t, ai.AuditOutcomeSuccess, entry.Outcome)
+		assert.Len(t, entry.PromptHash, 64, "Prompt hash should be a SHA256 hex digest")
+		assert.Greater(t, entry.PromptTokens, 0)
+		assert.False(t, entry.Timestamp.IsZero())
+	}
+	assert.NotEqual(t, entries[0].PromptHash, entries[1].PromptHash)
+
+	raw, err := os.ReadFile(auditPath)
+	require.NoError(t, err)
+	assert.NotContains(t, string(raw), "john.doe@company.com", "Audit log must not contain raw PII")
+	assert.NotContains(t, string(raw), "192.168.1.100", "Audit log must not contain raw PII")
+	assert.NotContains(t, string(raw), "requested access", "Audit log must not contain prompt content")
+}
+
+func TestAnalyze_AuditLogRecordsProviderErrorCode(t *testing.T) {
+	// Arrange
+	auditPath := filepath.Join(t.TempDir(), "ai.jsonl")
+	cfg := &types.Config{
+		AI: types.AIConfig{
+			Enabled:  true,
+			Provider: "openai",
+			Mode:     types.AIModeContext,
+		},
+		AuditLog: types.AuditLogConfig{Path: auditPath},
+	}
+	mockProvider := ai.NewMockProvider()
+	mockProvider.SetError(ai.NewProviderError(ai.CodeProviderRateLimit, "openai", nil))
+	engine := ai.NewEngine(cfg, mockProvider)
+
+	preamble, err := types.NewContextPreamble(
+		"SOC2",
+		"2017",
+		"CC6.1",
+		"Access controls shall be implemented to ensure that only authorized individuals can access sensitive data.",
+		nil,
+	)
+	require.NoError(t, err)
+
+	evidence := types.EvidenceBundle{
+		Events: []types.EvidenceEvent{
+			{ID: "evt-1", Source: "github", Content: "Added authentication"},
+		},
+	}
+
+	// Act
+	_, err = engine.Analyze(context.Background(), *preamble, evidence)
+	require.Error(t, err)
+
+	// Assert
+	entries := readAuditEntries(t, auditPath)
+	require.Len(t, entries, 1)
+	assert.Equal(t, ai.AuditOutcomeError, entries[0].Outcome)
+	assert.Equal(t, ai.CodeProviderRateLimit, entries[0].ErrorCode)
+}
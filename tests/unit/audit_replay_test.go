@@ -0,0 +1,241 @@
+package unit
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/pickjonathan/sdek-cli/internal/ai"
+	"github.com/pickjonathan/sdek-cli/pkg/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const syntheticAuditLog = `{"timestamp":"2025-01-15T10:00:00Z","operation":"analyze","framework":"SOC2","control_id":"CC6.1","cache_key":"key-cc61","provider":"openai","model":"gpt-4o","prompt_hash":"abc","prompt_tokens":100,"response_tokens":20,"latency_ms":900,"outcome":"success"}
+{"timestamp":"2025-01-15T10:01:00Z","operation":"plan","framework":"SOC2","control_id":"CC6.1","provider":"openai","model":"gpt-4o","prompt_hash":"def","prompt_tokens":50,"response_tokens":30,"latency_ms":700,"outcome":"success"}
+{"timestamp":"2025-01-15T10:02:00Z","operation":"analyze","framework":"SOC2","control_id":"CC7.2","cache_key":"key-cc72","provider":"openai","model":"gpt-4o","prompt_hash":"ghi","prompt_tokens":120,"response_tokens":0,"latency_ms":30000,"outcome":"error","error_code":"provider_timeout"}
+{"timestamp":"2025-01-15T10:03:00Z","operation":"analyze","framework":"ISO27001","control_id":"A.9.4.2","cache_key":"key-a942","provider":"openai","model":"gpt-4o","prompt_hash":"jkl","prompt_tokens":110,"response_tokens":25,"latency_ms":1100,"outcome":"success"}
+`
+
+// writeSyntheticAuditLog writes the synthetic audit log and returns its path
+func writeSyntheticAuditLog(t *testing.T) string {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), "audit.jsonl")
+	require.NoError(t, os.WriteFile(path, []byte(syntheticAuditLog), 0600))
+	return path
+}
+
+func TestReplayAuditLog_SyntheticLog(t *testing.T) {
+	cache, err := ai.NewCache(t.TempDir())
+	require.NoError(t, err)
+
+	require.NoError(t, cache.Set("key-cc61", &ai.CachedResult{
+		CacheKey:  "key-cc61",
+		ControlID: "CC6.1",
+		Framework: "SOC2",
+		Response: ai.AnalysisResponse{
+			EvidenceLinks: []string{"evt-1", "evt-2"},
+			Justification: "MFA enforced for admin access",
+			Confidence:    85,
+			ResidualRisk:  "low",
+			Provider:      "openai",
+			Model:         "gpt-4o",
+		},
+	}))
+	require.NoError(t, cache.Set("key-a942", &ai.CachedResult{
+		CacheKey:  "key-a942",
+		ControlID: "A.9.4.2",
+		Framework: "ISO27001",
+		Response: ai.AnalysisResponse{
+			EvidenceLinks: []string{"evt-9"},
+			Justification: "Password policy partially documented",
+			Confidence:    40,
+			ResidualRisk:  "high",
+			Provider:      "openai",
+			Model:         "gpt-4o",
+		},
+	}))
+
+	entries, err := ai.ReadAuditLog(writeSyntheticAuditLog(t))
+	require.NoError(t, err)
+	require.Len(t, entries, 4)
+
+	findings, err := ai.ReplayAuditLog(entries, cache)
+	require.NoError(t, err)
+	require.Len(t, findings, 2, "Plan calls and failed calls should be skipped")
+
+	assert.Equal(t, "SOC2", findings[0].FrameworkID)
+	assert.Equal(t, "CC6.1", findings[0].ControlID)
+	assert.Equal(t, "MFA enforced for admin access", findings[0].Summary)
+	assert.Equal(t, []string{"evt-1", "evt-2"}, findings[0].Citations)
+	assert.InDelta(t, 0.85, findings[0].ConfidenceScore, 0.001)
+	assert.Equal(t, types.SeverityLow, findings[0].Severity)
+	assert.Equal(t, "openai", findings[0].Provider)
+	assert.False(t, findings[0].ReviewRequired)
+	assert.Equal(t, entries[0].Timestamp, findings[0].CreatedAt, "Replay should use the recorded call time")
+
+	assert.Equal(t, "ISO27001", findings[1].FrameworkID)
+	assert.Equal(t, types.SeverityHigh, findings[1].Severity)
+	assert.True(t, findings[1].ReviewRequired, "Low confidence replayed finding should require review")
+
+	// Replaying again yields identical findings
+	again, err := ai.ReplayAuditLog(entries, cache)
+	require.NoError(t, err)
+	assert.Equal(t, findings, again)
+}
+
+func TestReplayAuditLog_MissingResponse(t *testing.T) {
+	cache, err := ai.NewCache(t.TempDir())
+	require.NoError(t, err)
+
+	require.NoError(t, cache.Set("key-cc61", &ai.CachedResult{
+		CacheKey:  "key-cc61",
+		ControlID: "CC6.1",
+		Response:  ai.AnalysisResponse{Confidence: 85, ResidualRisk: "low"},
+	}))
+
+	entries, err := ai.ReadAuditLog(writeSyntheticAuditLog(t))
+	require.NoError(t, err)
+
+	_, err = ai.ReplayAuditLog(entries, cache)
+	require.Error(t, err)
+	assert.True(t, errors.Is(err, ai.ErrReplayMissingResponse))
+	assert.Contains(t, err.Error(), "A.9.4.2", "Error should identify the missing entry")
+}
+
+func TestReplayAuditLog_MatchesLiveAnalysis(t *testing.T) {
+	// Arrange: run a live analysis with audit logging and caching enabled
+	auditPath := filepath.Join(t.TempDir(), "audit.jsonl")
+	cfg := &types.Config{
+		AI: types.AIConfig{
+			Enabled:  true,
+			Provider: "mock",
+			Mode:     types.AIModeContext,
+			CacheDir: t.TempDir(),
+		},
+		AuditLog: types.AuditLogConfig{Path: auditPath},
+	}
+	engine := ai.NewEngine(cfg, ai.NewMockProvider())
+
+	preamble, err := types.NewContextPreamble(
+		"SOC2",
+		"2017",
+		"CC6.1",
+		"Access controls shall be implemented to ensure that only authorized individuals can access sensitive data.",
+		nil,
+	)
+	require.NoError(t, err)
+
+	evidence := types.EvidenceBundle{
+		Events: []types.EvidenceEvent{
+			{ID: "evt-1", Source: "github", Timestamp: time.Now(), Content: "Added authentication"},
+		},
+	}
+
+	live, err := engine.Analyze(context.Background(), *preamble, evidence)
+	require.NoError(t, err)
+
+	// Act
+	entries, err := ai.ReadAuditLog(auditPath)
+	require.NoError(t, err)
+	cache, err := ai.NewCache(cfg.AI.CacheDir)
+	require.NoError(t, err)
+	findings, err := ai.ReplayAuditLog(entries, cache)
+
+	// Assert
+	require.NoError(t, err)
+	require.Len(t, findings, 1)
+	assert.Equal(t, live.Summary, findings[0].Summary)
+	assert.Equal(t, live.Citations, findings[0].Citations)
+	assert.Equal(t, live.ConfidenceScore, findings[0].ConfidenceScore)
+	assert.Equal(t, live.Severity, findings[0].Severity)
+}
+
+func TestReplayAuditLog_DistinctIDsWithinTheSameSecond(t *testing.T) {
+	// Arrange: two analyses recorded in the same second
+	cache, err := ai.NewCache(t.TempDir())
+	require.NoError(t, err)
+	for _, key := range []string{"key-cc61", "key-cc62"} {
+		require.NoError(t, cache.Set(key, &ai.CachedResult{
+			CacheKey: key,
+			Response: ai.AnalysisResponse{Confidence: 85, ResidualRisk: "low"},
+		}))
+	}
+	timestamp := time.Date(2025, 1, 15, 10, 0, 0, 0, time.UTC)
+	entries := []ai.AuditEntry{
+		{Timestamp: timestamp, Operation: "analyze", Framework: "SOC2", ControlID: "CC6.1", CacheKey: "key-cc61", Outcome: ai.AuditOutcomeSuccess},
+		{Timestamp: timestamp.Add(300 * time.Millisecond), Operation: "analyze", Framework: "SOC2", ControlID: "CC6.2", CacheKey: "key-cc62", Outcome: ai.AuditOutcomeSuccess},
+	}
+
+	// Act
+	findings, err := ai.ReplayAuditLog(entries, cache)
+
+	// Assert
+	require.NoError(t, err)
+	require.Len(t, findings, 2)
+	assert.NotEqual(t, findings[0].ID, findings[1].ID)
+}
+
+// replayTestEngine returns a caching engine that writes its audit log to auditPath
+func replayTestEngine(t *testing.T, auditPath string, provider ai.Provider) (ai.Engine, *ai.Cache) {
+	t.Helper()
+	cfg := &types.Config{
+		AI: types.AIConfig{
+			Enabled:  true,
+			Provider: "mock",
+			Model:    "small-context-model",
+			Mode:     types.AIModeContext,
+			CacheDir: t.TempDir(),
+		},
+		AuditLog: types.AuditLogConfig{Path: auditPath},
+	}
+	cache, err := ai.NewCache(cfg.AI.CacheDir)
+	require.NoError(t, err)
+	return ai.NewEngine(cfg, provider), cache
+}
+
+func TestReplayAuditLog_ChunkedAnalysisReplaysOnce(t *testing.T) {
+	// Arrange: a context length error splits the analysis into two provider calls
+	auditPath := filepath.Join(t.TempDir(), "audit.jsonl")
+	provider := &contextLimitedProvider{MockProvider: ai.NewMockProvider(), maxEvents: 5}
+	engine, cache := replayTestEngine(t, auditPath, provider)
+	live, err := engine.Analyze(context.Background(), contextLengthTestPreamble(t), largeEvidenceBundle(10, 400))
+	require.NoError(t, err)
+
+	// Act
+	entries, err := ai.ReadAuditLog(auditPath)
+	require.NoError(t, err)
+	findings, err := ai.ReplayAuditLog(entries, cache)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Len(t, auditEntriesFor(entries, ai.AuditOperationAnalyzeCall), 3, "the rejected call and one per half")
+	require.Len(t, findings, 1, "one finding per analysis, not per chunk")
+	assert.Equal(t, live.Summary, findings[0].Summary)
+}
+
+func TestReplayAuditLog_ContextFallbackAnalysisReplays(t *testing.T) {
+	// Arrange: the analysis is retried with the fallback model and cached under its key
+	auditPath := filepath.Join(t.TempDir(), "audit.jsonl")
+	provider := &contextLimitedProvider{MockProvider: ai.NewMockProvider(), maxEvents: 5}
+	engine, cache := replayTestEngine(t, auditPath, provider)
+	ai.SetContextFallback(engine, ai.NewMockProvider(), "large-context-model")
+	_, err := engine.Analyze(context.Background(), contextLengthTestPreamble(t), largeEvidenceBundle(10, 400))
+	require.NoError(t, err)
+
+	// Act
+	entries, err := ai.ReadAuditLog(auditPath)
+	require.NoError(t, err)
+	findings, err := ai.ReplayAuditLog(entries, cache)
+
+	// Assert
+	require.NoError(t, err)
+	require.Len(t, findings, 1)
+	analyses := auditEntriesFor(entries, ai.AuditOperationAnalyze)
+	require.Len(t, analyses, 1)
+	assert.Equal(t, "large-context-model", analyses[0].Model)
+}
@@ -0,0 +1,131 @@
+package unit
+
+import (
+	"testing"
+	"time"
+
+	"github.com/pickjonathan/sdek-cli/internal/ai"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// seedInvalidationCache populates a cache with entries across frameworks and controls
+func seedInvalidationCache(t *testing.T) *ai.Cache {
+	t.Helper()
+
+	cache, err := ai.NewCache(t.TempDir())
+	require.NoError(t, err)
+
+	entries := map[string][2]string{
+		"soc2-cc61":   {"SOC2", "CC6.1"},
+		"soc2-cc62":   {"SOC2", "CC6.2"},
+		"iso-a942":    {"ISO27001", "A.9.4.2"},
+		"pci-cc61":    {"PCI-DSS", "CC6.1"},
+		"soc2-legacy": {"", "CC7.1"},
+	}
+	for key, e := range entries {
+		require.NoError(t, cache.Set(key, &ai.CachedResult{
+			CacheKey:  key,
+			CachedAt:  time.Now(),
+			Framework: e[0],
+			ControlID: e[1],
+		}))
+	}
+
+	return cache
+}
+
+// cachedKeys returns which of the given keys are still present in the cache
+func cachedKeys(t *testing.T, cache *ai.Cache, keys ...string) map[string]bool {
+	t.Helper()
+
+	present := make(map[string]bool)
+	for _, key := range keys {
+		result, err := cache.Get(key)
+		require.NoError(t, err)
+		present[key] = result != nil
+	}
+	return present
+}
+
+func TestCache_InvalidateByControl(t *testing.T) {
+	cache := seedInvalidationCache(t)
+
+	removed, err := cache.InvalidateByControl("cc6.1")
+	require.NoError(t, err)
+	assert.Equal(t, 2, removed, "Should remove CC6.1 entries across frameworks")
+
+	present := cachedKeys(t, cache, "soc2-cc61", "soc2-cc62", "iso-a942", "pci-cc61", "soc2-legacy")
+	assert.Equal(t, map[string]bool{
+		"soc2-cc61":   false,
+		"soc2-cc62":   true,
+		"iso-a942":    true,
+		"pci-cc61":    false,
+		"soc2-legacy": true,
+	}, present)
+}
+
+func TestCache_InvalidatePrefix(t *testing.T) {
+	cache := seedInvalidationCache(t)
+
+	removed, err := cache.InvalidatePrefix("soc2")
+	require.NoError(t, err)
+	assert.Equal(t, 2, removed, "Should remove only SOC2 entries")
+
+	present := cachedKeys(t, cache, "soc2-cc61", "soc2-cc62", "iso-a942", "pci-cc61", "soc2-legacy")
+	assert.Equal(t, map[string]bool{
+		"soc2-cc61":   false,
+		"soc2-cc62":   false,
+		"iso-a942":    true,
+		"pci-cc61":    true,
+		"soc2-legacy": true,
+	}, present)
+}
+
+func TestCache_InvalidateFrameworkAndControl(t *testing.T) {
+	cache := seedInvalidationCache(t)
+
+	removed, err := cache.Invalidate("SOC2", "CC6.1")
+	require.NoError(t, err)
+	assert.Equal(t, 1, removed, "Should remove only the SOC2 CC6.1 entry")
+
+	present := cachedKeys(t, cache, "soc2-cc61", "pci-cc61")
+	assert.False(t, present["soc2-cc61"])
+	assert.True(t, present["pci-cc61"])
+
+	stats, err := cache.Stats()
+	require.NoError(t, err)
+	assert.Equal(t, 4, stats.TotalEntries)
+}
+
+func TestCache_InvalidateRequiresFilter(t *testing.T) {
+	cache := seedInvalidationCache(t)
+
+	_, err := cache.Invalidate("", "")
+	assert.Error(t, err)
+
+	stats, err := cache.Stats()
+	require.NoError(t, err)
+	assert.Equal(t, 5, stats.TotalEntries, "No entries should be removed without a filter")
+}
+
+func TestCache_InvalidatePrefixMatchesFrameworkAliases(t *testing.T) {
+	cache := seedInvalidationCache(t)
+
+	removed, err := cache.InvalidatePrefix("SOC 2")
+	require.NoError(t, err)
+	assert.Equal(t, 2, removed, "SOC 2 should match entries cached as SOC2")
+
+	removed, err = cache.Invalidate("pci dss", "cc6.1")
+	require.NoError(t, err)
+	assert.Equal(t, 1, removed, "pci dss should match entries cached as PCI-DSS")
+
+	present := cachedKeys(t, cache, "soc2-cc61", "soc2-cc62", "iso-a942", "pci-cc61", "soc2-legacy")
+	assert.Equal(t, map[string]bool{
+		"soc2-cc61":   false,
+		"soc2-cc62":   false,
+		"iso-a942":    true,
+		"pci-cc61":    false,
+		"soc2-legacy": true,
+	}, present)
+}
@@ -0,0 +1,458 @@
+package unit
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/pickjonathan/sdek-cli/internal/ai"
+	"github.com/pickjonathan/sdek-cli/pkg/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newCacheKeyTestInputs() (types.ContextPreamble, types.EvidenceBundle) {
+	preamble := types.ContextPreamble{
+		Framework: "SOC2",
+		Version:   "2017",
+		Section:   "CC6.1",
+		Excerpt:   "Access controls shall be implemented to ensure that only authorized individuals can access sensitive data.",
+	}
+	evidence := types.EvidenceBundle{Events: []types.EvidenceEvent{
+		{ID: "evt-1", Source: "github", Content: "Enforce MFA on admin login"},
+		{ID: "evt-2", Source: "jira", Content: "Quarterly access review completed"},
+		{ID: "evt-3", Source: "github", Content: "Rotate deploy keys"},
+	}}
+	return preamble, evidence
+}
+
+func TestContextCacheKey_StableAcrossRuns(t *testing.T) {
+	// Arrange
+	preamble, evidence := newCacheKeyTestInputs()
+
+	// Act
+	key := ai.ContextCacheKey(preamble, evidence)
+
+	// Assert - a changed value would silently invalidate every cached finding
+	assert.Equal(t, "c4def56d3b59e0df235f26e0a99edfe93d99f9dfb0fb5d4222b31f8fdab9e82f", key)
+	for i := 0; i < 10; i++ {
+		assert.Equal(t, key, ai.ContextCacheKey(preamble, evidence))
+	}
+}
+
+func TestContextCacheKey_IndependentOfEventOrder(t *testing.T) {
+	// Arrange
+	preamble, evidence := newCacheKeyTestInputs()
+	events := evidence.Events
+	reordered := types.EvidenceBundle{Events: []types.EvidenceEvent{events[2], events[0], events[1]}}
+
+	// Act
+	key := ai.ContextCacheKey(preamble, evidence)
+	reorderedKey := ai.ContextCacheKey(preamble, reordered)
+
+	// Assert
+	assert.Equal(t, key, reorderedKey)
+	assert.Equal(t, "evt-1", evidence.Events[0].ID, "input events must not be reordered")
+}
+
+func TestContextCacheKey_ChangesWithInputs(t *testing.T) {
+	preamble, evidence := newCacheKeyTestInputs()
+	baseKey := ai.ContextCacheKey(preamble, evidence)
+
+	tests := []struct {
+		name   string
+		mutate func(p *types.ContextPreamble, e *types.EvidenceBundle)
+	}{
+		{
+			name: "event content",
+			mutate: func(p *types.ContextPreamble, e *types.EvidenceBundle) {
+				e.Events[1].Content = "Quarterly access review skipped"
+			},
+		},
+		{
+			name: "event ID",
+			mutate: func(p *types.ContextPreamble, e *types.EvidenceBundle) {
+				e.Events[0].ID = "evt-9"
+			},
+		},
+		{
+			name: "ID and content boundary",
+			mutate: func(p *types.ContextPreamble, e *types.EvidenceBundle) {
+				e.Events[0].ID = "evt-1E"
+				e.Events[0].Content = "nforce MFA on admin login"
+			},
+		},
+		{
+			name: "extra event",
+			mutate: func(p *types.ContextPreamble, e *types.EvidenceBundle) {
+				e.Events = append(e.Events, types.EvidenceEvent{ID: "evt-4", Content: "New firewall rule"})
+			},
+		},
+		{
+			name: "rendered metadata",
+			mutate: func(p *types.ContextPreamble, e *types.EvidenceBundle) {
+				e.Events[0].Metadata = map[string]interface{}{"author": "alice"}
+			},
+		},
+		{
+			name: "content and metadata boundary",
+			mutate: func(p *types.ContextPreamble, e *types.EvidenceBundle) {
+				e.Events[0].Content = "Enforce MFA on admin logi"
+				e.Events[0].Metadata = map[string]interface{}{"author": "n"}
+			},
+		},
+		{
+			name: "section",
+			mutate: func(p *types.ContextPreamble, e *types.EvidenceBundle) {
+				p.Section = "CC6.2"
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			p, e := newCacheKeyTestInputs()
+
+			// Act
+			tt.mutate(&p, &e)
+			key := ai.ContextCacheKey(p, e)
+
+			// Assert
+			assert.NotEqual(t, baseKey, key)
+		})
+	}
+}
+
+func TestContextCacheKey_ChangesWithEachRenderedMetadataKey(t *testing.T) {
+	preamble, evidence := newCacheKeyTestInputs()
+	evidence.Events[0].Metadata = map[string]interface{}{
+		"author": "alice",
+		"repo":   "acme/api",
+		"state":  "merged",
+		"url":    "https://github.com/acme/api/pull/7",
+	}
+	baseKey := ai.ContextCacheKey(preamble, evidence)
+
+	for _, key := range []string{"author", "repo", "state", "url"} {
+		t.Run(key, func(t *testing.T) {
+			// Arrange
+			changed := types.EvidenceBundle{Events: append([]types.EvidenceEvent(nil), evidence.Events...)}
+			metadata := make(map[string]interface{}, len(evidence.Events[0].Metadata))
+			for k, v := range evidence.Events[0].Metadata {
+				metadata[k] = v
+			}
+			metadata[key] = "changed"
+			changed.Events[0].Metadata = metadata
+
+			// Act & Assert
+			assert.NotEqual(t, baseKey, ai.ContextCacheKey(preamble, changed))
+		})
+	}
+}
+
+func TestContextCacheKey_IgnoresUnrenderedMetadata(t *testing.T) {
+	preamble, evidence := newCacheKeyTestInputs()
+	baseKey := ai.ContextCacheKey(preamble, evidence)
+
+	// Act
+	evidence.Events[0].Metadata = map[string]interface{}{"raw_payload": `{"id": 1}`}
+
+	// Assert
+	assert.Equal(t, baseKey, ai.ContextCacheKey(preamble, evidence), "metadata left out of the prompt must not split the cache")
+}
+
+func TestAnalyze_UsesContextCacheKey(t *testing.T) {
+	// Arrange
+	auditPath := filepath.Join(t.TempDir(), "ai.jsonl")
+	cfg := &types.Config{
+		AI: types.AIConfig{
+			Enabled:  true,
+			Provider: "mock",
+			Mode:     types.AIModeContext,
+			CacheDir: t.TempDir(),
+		},
+		AuditLog: types.AuditLogConfig{Path: auditPath},
+	}
+	engine := ai.NewEngine(cfg, ai.NewMockProvider())
+	preamble, evidence := newCacheKeyTestInputs()
+
+	// Act
+	_, err := engine.Analyze(context.Background(), preamble, evidence)
+
+	// Assert
+	require.NoError(t, err)
+	entries := auditEntriesFor(readAuditEntries(t, auditPath), ai.AuditOperationAnalyze)
+	require.Len(t, entries, 1)
+	assert.Equal(t, ai.AnalysisCacheKey(ai.AnalysisScope{Provider: "mock"}, preamble, evidence), entries[0].CacheKey)
+}
+
+func TestAnalysisCacheKey_ScopedToProviderModelAndPrompt(t *testing.T) {
+	preamble, evidence := newCacheKeyTestInputs()
+	base := ai.AnalysisScope{Provider: "openai", Model: "gpt-4o"}
+	baseKey := ai.AnalysisCacheKey(base, preamble, evidence)
+
+	tests := []struct {
+		name  string
+		scope ai.AnalysisScope
+	}{
+		{name: "model", scope: ai.AnalysisScope{Provider: "openai", Model: "gpt-4o-mini"}},
+		{name: "provider", scope: ai.AnalysisScope{Provider: "anthropic", Model: "gpt-4o"}},
+		{name: "prompt template", scope: ai.AnalysisScope{Provider: "openai", Model: "gpt-4o", TemplateSource: "Assess {{.Preamble.Section}}"}},
+		{name: "system prompt", scope: ai.AnalysisScope{Provider: "openai", Model: "gpt-4o", SystemPrompt: "You are a SOC 2 auditor."}},
+		{name: "system prompt and template boundary", scope: ai.AnalysisScope{Provider: "openai", Model: "gpt-4o", TemplateSource: "You are a SOC 2 auditor."}},
+		{name: "provider and model boundary", scope: ai.AnalysisScope{Provider: "openaig", Model: "pt-4o"}},
+		{name: "temperature", scope: ai.AnalysisScope{Provider: "openai", Model: "gpt-4o", Temperature: 0.7}},
+		{name: "seed", scope: ai.AnalysisScope{Provider: "openai", Model: "gpt-4o", Seed: intPtr(0)}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			key := ai.AnalysisCacheKey(tt.scope, preamble, evidence)
+
+			// Assert
+			assert.NotEqual(t, baseKey, key)
+		})
+	}
+
+	assert.Equal(t, baseKey, ai.AnalysisCacheKey(base, preamble, evidence), "identical inputs share a key")
+}
+
+func TestAnalyze_ChangingSystemPromptMissesCache(t *testing.T) {
+	// Arrange
+	cacheDir := t.TempDir()
+	newEngine := func(systemPrompt string) (ai.Engine, *ai.MockProvider) {
+		cfg := &types.Config{
+			AI: types.AIConfig{
+				Enabled:      true,
+				Provider:     "mock",
+				Mode:         types.AIModeContext,
+				CacheDir:     cacheDir,
+				SystemPrompt: systemPrompt,
+			},
+		}
+		provider := ai.NewMockProvider()
+		return ai.NewEngine(cfg, provider), provider
+	}
+	preamble, evidence := newCacheKeyTestInputs()
+
+	firstEngine, _ := newEngine("")
+	_, err := firstEngine.Analyze(context.Background(), preamble, evidence)
+	require.NoError(t, err)
+
+	// Act
+	samePrompt, sameProvider := newEngine("")
+	cached, err := samePrompt.Analyze(context.Background(), preamble, evidence)
+	require.NoError(t, err)
+	otherPrompt, otherProvider := newEngine("You are a SOC 2 auditor.")
+	fresh, err := otherPrompt.Analyze(context.Background(), preamble, evidence)
+	require.NoError(t, err)
+
+	// Assert
+	assert.Equal(t, 0, sameProvider.GetCallCount(), "the same system prompt should be served from the cache")
+	assert.True(t, cached.CacheHit)
+	assert.Equal(t, 1, otherProvider.GetCallCount(), "a different system prompt must not reuse the cached finding")
+	assert.False(t, fresh.CacheHit)
+}
+
+// systemPromptMockProvider is a mock provider configured with a system prompt override
+type systemPromptMockProvider struct {
+	*ai.MockProvider
+	systemPrompt string
+}
+
+func (p *systemPromptMockProvider) SystemPrompt() string {
+	return p.systemPrompt
+}
+
+func TestAnalyze_ProviderSystemPromptScopesCacheKey(t *testing.T) {
+	// Arrange
+	auditPath := filepath.Join(t.TempDir(), "ai.jsonl")
+	cfg := &types.Config{
+		AI:       types.AIConfig{Enabled: true, Provider: "mock", Mode: types.AIModeContext, CacheDir: t.TempDir()},
+		AuditLog: types.AuditLogConfig{Path: auditPath},
+	}
+	provider := &systemPromptMockProvider{MockProvider: ai.NewMockProvider(), systemPrompt: "You are a SOC 2 auditor."}
+	engine := ai.NewEngine(cfg, provider)
+	preamble, evidence := newCacheKeyTestInputs()
+
+	// Act
+	_, err := engine.Analyze(context.Background(), preamble, evidence)
+
+	// Assert
+	require.NoError(t, err)
+	entries := auditEntriesFor(readAuditEntries(t, auditPath), ai.AuditOperationAnalyze)
+	require.Len(t, entries, 1)
+	scope := ai.AnalysisScope{Provider: "mock", SystemPrompt: "You are a SOC 2 auditor."}
+	assert.Equal(t, ai.AnalysisCacheKey(scope, preamble, evidence), entries[0].CacheKey)
+}
+
+func intPtr(v int) *int {
+	return &v
+}
+
+func TestAnalysisCacheKey_ChangesWithSeed(t *testing.T) {
+	preamble, evidence := newCacheKeyTestInputs()
+
+	// Act
+	seeded := ai.AnalysisCacheKey(ai.AnalysisScope{Provider: "openai", Seed: intPtr(7)}, preamble, evidence)
+	reseeded := ai.AnalysisCacheKey(ai.AnalysisScope{Provider: "openai", Seed: intPtr(8)}, preamble, evidence)
+
+	// Assert
+	assert.NotEqual(t, seeded, reseeded)
+	assert.Equal(t, seeded, ai.AnalysisCacheKey(ai.AnalysisScope{Provider: "openai", Seed: intPtr(7)}, preamble, evidence))
+}
+
+func TestAnalyze_SamplingSettingsScopeCache(t *testing.T) {
+	tests := []struct {
+		name    string
+		first   types.AIConfig
+		second  types.AIConfig
+		wantHit bool
+	}{
+		{name: "same seed", first: types.AIConfig{Seed: intPtr(7)}, second: types.AIConfig{Seed: intPtr(7)}, wantHit: true},
+		{name: "different seed", first: types.AIConfig{Seed: intPtr(7)}, second: types.AIConfig{Seed: intPtr(8)}},
+		{name: "seed set", first: types.AIConfig{}, second: types.AIConfig{Seed: intPtr(7)}},
+		{name: "different temperature", first: types.AIConfig{Temperature: 0.2}, second: types.AIConfig{Temperature: 0.8}},
+		{name: "deterministic", first: types.AIConfig{Temperature: 0.8}, second: types.AIConfig{Temperature: 0.8, Deterministic: true}},
+		{
+			name:    "deterministic matches its resolved settings",
+			first:   types.AIConfig{Temperature: 0.8, Deterministic: true},
+			second:  types.AIConfig{Seed: intPtr(ai.DefaultDeterministicSeed)},
+			wantHit: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			cacheDir := t.TempDir()
+			newEngine := func(sampling types.AIConfig) (ai.Engine, *ai.MockProvider) {
+				cfg := &types.Config{AI: sampling}
+				cfg.AI.Enabled = true
+				cfg.AI.Provider = "mock"
+				cfg.AI.Mode = types.AIModeContext
+				cfg.AI.CacheDir = cacheDir
+				provider := ai.NewMockProvider()
+				return ai.NewEngine(cfg, provider), provider
+			}
+			preamble, evidence := newCacheKeyTestInputs()
+
+			firstEngine, _ := newEngine(tt.first)
+			_, err := firstEngine.Analyze(context.Background(), preamble, evidence)
+			require.NoError(t, err)
+
+			// Act
+			secondEngine, secondProvider := newEngine(tt.second)
+			finding, err := secondEngine.Analyze(context.Background(), preamble, evidence)
+
+			// Assert
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantHit, finding.CacheHit)
+			if tt.wantHit {
+				assert.Equal(t, 0, secondProvider.GetCallCount())
+			} else {
+				assert.Equal(t, 1, secondProvider.GetCallCount(), "different sampling settings must not reuse the cached finding")
+			}
+		})
+	}
+}
+
+func TestAnalyze_ChangingModelMissesCache(t *testing.T) {
+	// Arrange
+	cacheDir := t.TempDir()
+	newEngine := func(model string) (ai.Engine, *ai.MockProvider) {
+		cfg := &types.Config{
+			AI: types.AIConfig{
+				Enabled:  true,
+				Provider: "mock",
+				Model:    model,
+				Mode:     types.AIModeContext,
+				CacheDir: cacheDir,
+			},
+		}
+		provider := ai.NewMockProvider()
+		return ai.NewEngine(cfg, provider), provider
+	}
+	preamble, evidence := newCacheKeyTestInputs()
+
+	firstEngine, _ := newEngine("model-a")
+	_, err := firstEngine.Analyze(context.Background(), preamble, evidence)
+	require.NoError(t, err)
+
+	// Act
+	sameModel, sameProvider := newEngine("model-a")
+	cached, err := sameModel.Analyze(context.Background(), preamble, evidence)
+	require.NoError(t, err)
+	otherModel, otherProvider := newEngine("model-b")
+	fresh, err := otherModel.Analyze(context.Background(), preamble, evidence)
+	require.NoError(t, err)
+
+	// Assert
+	assert.Equal(t, 0, sameProvider.GetCallCount(), "the same model should be served from the cache")
+	assert.True(t, cached.CacheHit)
+	assert.Equal(t, 1, otherProvider.GetCallCount(), "a different model must not reuse the cached finding")
+	assert.False(t, fresh.CacheHit)
+}
+
+func TestAnalyze_ChangingProviderDefaultsMissesCache(t *testing.T) {
+	// Arrange: ai.model is unset, so the model comes from ai.provider_defaults
+	cacheDir := t.TempDir()
+	newEngine := func(defaultModel string) (ai.Engine, *ai.MockProvider) {
+		cfg := &types.Config{
+			AI: types.AIConfig{
+				Enabled:          true,
+				Provider:         "openai",
+				Mode:             types.AIModeContext,
+				CacheDir:         cacheDir,
+				ProviderDefaults: map[string]string{"openai": defaultModel},
+			},
+		}
+		provider := ai.NewMockProvider()
+		return ai.NewEngine(cfg, provider), provider
+	}
+	preamble, evidence := newCacheKeyTestInputs()
+
+	firstEngine, _ := newEngine("gpt-4o")
+	first, err := firstEngine.Analyze(context.Background(), preamble, evidence)
+	require.NoError(t, err)
+
+	// Act
+	sameDefault, sameProvider := newEngine("gpt-4o")
+	cached, err := sameDefault.Analyze(context.Background(), preamble, evidence)
+	require.NoError(t, err)
+	otherDefault, otherProvider := newEngine("gpt-4o-mini")
+	fresh, err := otherDefault.Analyze(context.Background(), preamble, evidence)
+	require.NoError(t, err)
+
+	// Assert
+	assert.Equal(t, "gpt-4o", first.Model, "the finding should record the resolved model")
+	assert.Equal(t, 0, sameProvider.GetCallCount())
+	assert.True(t, cached.CacheHit)
+	assert.Equal(t, 1, otherProvider.GetCallCount(), "a different default model must not reuse the cached finding")
+	assert.False(t, fresh.CacheHit)
+	assert.Equal(t, "gpt-4o-mini", fresh.Model)
+}
+
+func TestAnalyze_UnsetProviderUsesDefaultProviderInCacheKey(t *testing.T) {
+	// Arrange
+	auditPath := filepath.Join(t.TempDir(), "ai.jsonl")
+	cfg := &types.Config{
+		AI:       types.AIConfig{Enabled: true, Mode: types.AIModeContext, CacheDir: t.TempDir()},
+		AuditLog: types.AuditLogConfig{Path: auditPath},
+	}
+	engine := ai.NewEngine(cfg, ai.NewMockProvider())
+	preamble, evidence := newCacheKeyTestInputs()
+
+	// Act
+	finding, err := engine.Analyze(context.Background(), preamble, evidence)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, "openai", finding.Provider)
+	assert.Equal(t, types.DefaultProviderModels["openai"], finding.Model)
+	entries := auditEntriesFor(readAuditEntries(t, auditPath), ai.AuditOperationAnalyze)
+	require.Len(t, entries, 1)
+	assert.Equal(t, ai.AnalysisCacheKey(ai.AnalysisScope{Provider: "openai", Model: types.DefaultProviderModels["openai"]}, preamble, evidence), entries[0].CacheKey)
+}
@@ -0,0 +1,82 @@
+package unit
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/pickjonathan/sdek-cli/internal/ai"
+	"github.com/pickjonathan/sdek-cli/pkg/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newCacheModeEngine(t *testing.T) (ai.Engine, *ai.MockProvider, types.ContextPreamble, types.EvidenceBundle) {
+	t.Helper()
+
+	cfg := &types.Config{
+		AI: types.AIConfig{
+			Enabled:  true,
+			Provider: "mock",
+			Mode:     types.AIModeContext,
+			CacheDir: t.TempDir(),
+		},
+	}
+	provider := ai.NewMockProvider()
+
+	preamble, err := types.NewContextPreamble("SOC2", "2017", "CC6.1", "Logical access security software, infrastructure and architectures are implemented", nil)
+	require.NoError(t, err)
+
+	evidence := types.EvidenceBundle{Events: []types.EvidenceEvent{
+		{ID: "evt-1", Source: "github", Type: "commit", Timestamp: time.Date(2025, 1, 15, 10, 0, 0, 0, time.UTC), Content: "Enable MFA on admin login"},
+	}}
+
+	return ai.NewEngine(cfg, provider), provider, *preamble, evidence
+}
+
+func TestAnalyze_CacheOnlyMissSkipsProvider(t *testing.T) {
+	// Arrange
+	engine, provider, preamble, evidence := newCacheModeEngine(t)
+
+	// Act
+	_, err := engine.Analyze(ai.WithCacheMode(context.Background(), ai.CacheOnly), preamble, evidence)
+
+	// Assert
+	assert.ErrorIs(t, err, ai.ErrCacheMiss)
+	assert.Equal(t, 0, provider.GetCallCount())
+}
+
+func TestAnalyze_CacheBypassLeavesCacheUntouched(t *testing.T) {
+	// Arrange
+	engine, provider, preamble, evidence := newCacheModeEngine(t)
+	ctx := context.Background()
+
+	// Act
+	_, err := engine.Analyze(ai.WithCacheMode(ctx, ai.CacheBypass), preamble, evidence)
+	require.NoError(t, err)
+	_, cacheErr := engine.Analyze(ai.WithCacheMode(ctx, ai.CacheOnly), preamble, evidence)
+
+	// Assert
+	assert.Equal(t, 1, provider.GetCallCount())
+	assert.ErrorIs(t, cacheErr, ai.ErrCacheMiss, "bypass should not write to the cache")
+}
+
+func TestAnalyze_CacheRefreshOverwritesCache(t *testing.T) {
+	// Arrange
+	engine, provider, preamble, evidence := newCacheModeEngine(t)
+	ctx := context.Background()
+	_, err := engine.Analyze(ctx, preamble, evidence)
+	require.NoError(t, err)
+	provider.SetResponse(`{"summary": "Partial MFA coverage", "confidence_score": 0.5, "residual_risk": "medium", "citations": ["evt-1"]}`)
+
+	// Act
+	_, err = engine.Analyze(ai.WithCacheMode(ctx, ai.CacheRefresh), preamble, evidence)
+	require.NoError(t, err)
+	cached, err := engine.Analyze(ai.WithCacheMode(ctx, ai.CacheOnly), preamble, evidence)
+	require.NoError(t, err)
+
+	// Assert
+	assert.Equal(t, 2, provider.GetCallCount())
+	assert.Equal(t, "Partial MFA coverage", cached.Summary)
+	assert.True(t, cached.CacheHit)
+}
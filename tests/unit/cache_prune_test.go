@@ -0,0 +1,102 @@
+package unit
+
+import (
+	"testing"
+	"time"
+
+	"github.com/pickjonathan/sdek-cli/internal/ai"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// seedPruneCache writes entries whose CachedAt order differs from their write order.
+// It returns the cache and the on-disk size of one entry.
+func seedPruneCache(t *testing.T) (*ai.Cache, int64) {
+	t.Helper()
+
+	cache, err := ai.NewCache(t.TempDir())
+	require.NoError(t, err)
+
+	base := time.Date(2025, 1, 15, 10, 0, 0, 0, time.UTC)
+	// entry-1 is the oldest but written last
+	for _, e := range []struct {
+		key string
+		age int
+	}{{"entry-3", 3}, {"entry-2", 2}, {"entry-4", 4}, {"entry-1", 1}} {
+		require.NoError(t, cache.Set(e.key, &ai.CachedResult{
+			CacheKey:  e.key,
+			CachedAt:  base.Add(time.Duration(e.age) * time.Hour),
+			Framework: "SOC2",
+			ControlID: "CC6.1",
+		}))
+	}
+
+	stats, err := cache.Stats()
+	require.NoError(t, err)
+	require.Equal(t, 4, stats.TotalEntries)
+	return cache, stats.TotalSize / 4
+}
+
+func TestCache_SetPastCapEvictsOldest(t *testing.T) {
+	// Arrange
+	cache, entrySize := seedPruneCache(t)
+	cache.SetMaxBytes(4 * entrySize)
+
+	// Act
+	err := cache.Set("entry-5", &ai.CachedResult{
+		CacheKey:  "entry-5",
+		CachedAt:  time.Date(2025, 1, 15, 15, 0, 0, 0, time.UTC),
+		Framework: "SOC2",
+		ControlID: "CC6.1",
+	})
+
+	// Assert
+	require.NoError(t, err)
+	present := cachedKeys(t, cache, "entry-1", "entry-2", "entry-3", "entry-4", "entry-5")
+	assert.Equal(t, map[string]bool{
+		"entry-1": false,
+		"entry-2": true,
+		"entry-3": true,
+		"entry-4": true,
+		"entry-5": true,
+	}, present, "only the entry with the oldest CachedAt should be evicted")
+}
+
+func TestCache_SetUnderCapKeepsEverything(t *testing.T) {
+	// Arrange
+	cache, entrySize := seedPruneCache(t)
+	cache.SetMaxBytes(10 * entrySize)
+
+	// Act
+	err := cache.Set("entry-5", &ai.CachedResult{CacheKey: "entry-5", CachedAt: time.Now()})
+
+	// Assert
+	require.NoError(t, err)
+	stats, err := cache.Stats()
+	require.NoError(t, err)
+	assert.Equal(t, 5, stats.TotalEntries)
+}
+
+func TestCache_Prune(t *testing.T) {
+	// Arrange
+	cache, entrySize := seedPruneCache(t)
+
+	// Act
+	result, err := cache.Prune(2 * entrySize)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, 2, result.Removed)
+	assert.Equal(t, 2*entrySize, result.FreedBytes)
+	assert.Equal(t, 2*entrySize, result.RemainingBytes)
+	present := cachedKeys(t, cache, "entry-1", "entry-2", "entry-3", "entry-4")
+	assert.Equal(t, map[string]bool{
+		"entry-1": false,
+		"entry-2": false,
+		"entry-3": true,
+		"entry-4": true,
+	}, present)
+
+	_, err = cache.Prune(0)
+	assert.Error(t, err, "a zero limit would empty the cache and is rejected")
+}
@@ -0,0 +1,53 @@
+package unit
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/pickjonathan/sdek-cli/internal/ai"
+	"github.com/pickjonathan/sdek-cli/pkg/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEngine_FixedClockTimestampsFindings(t *testing.T) {
+	// Arrange
+	fixed := time.Date(2024, 6, 30, 12, 0, 0, 0, time.UTC)
+	cfg := &types.Config{
+		AI: types.AIConfig{
+			Enabled:  true,
+			Provider: "mock",
+			Mode:     types.AIModeContext,
+			NoCache:  true,
+			CacheDir: t.TempDir(),
+		},
+	}
+	engine := ai.NewEngine(cfg, ai.NewMockProvider())
+	ai.SetEngineClock(engine, types.FixedClock{Time: fixed})
+
+	preamble, err := types.NewContextPreamble(
+		"SOC2",
+		"2017",
+		"CC6.1",
+		"Access controls shall be implemented to ensure that only authorized individuals can access sensitive data. This includes implementing role-based access controls, multi-factor authentication, and regular access reviews.",
+		[]string{"CC6.1"},
+	)
+	require.NoError(t, err)
+	evidence := types.EvidenceBundle{Events: []types.EvidenceEvent{
+		{ID: "evt-1", Source: "github", Type: "commit", Timestamp: fixed.Add(-time.Hour), Content: "Added MFA authentication to login endpoint"},
+	}}
+
+	// Act
+	first, err := engine.Analyze(context.Background(), *preamble, evidence)
+	require.NoError(t, err)
+	second, err := engine.Analyze(context.Background(), *preamble, evidence)
+	require.NoError(t, err)
+
+	// Assert
+	for _, finding := range []*types.Finding{first, second} {
+		assert.True(t, finding.CreatedAt.Equal(fixed), "CreatedAt = %v", finding.CreatedAt)
+		assert.True(t, finding.UpdatedAt.Equal(fixed), "UpdatedAt = %v", finding.UpdatedAt)
+	}
+	assert.Equal(t, first.ID, second.ID)
+}
@@ -0,0 +1,140 @@
+package unit
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/pickjonathan/sdek-cli/internal/ai"
+	"github.com/pickjonathan/sdek-cli/pkg/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newConsensusEngine(t *testing.T, threshold float64) (ai.Engine, types.ContextPreamble, types.EvidenceBundle) {
+	t.Helper()
+	cfg := &types.Config{
+		AI: types.AIConfig{
+			Enabled:            true,
+			Provider:           "mock",
+			Mode:               types.AIModeContext,
+			CacheDir:           t.TempDir(),
+			ConsensusThreshold: threshold,
+		},
+	}
+	preamble, err := types.NewContextPreamble("SOC2", "2017", "CC6.1", "Logical access security software, infrastructure and architectures are implemented", nil)
+	require.NoError(t, err)
+	evidence := types.EvidenceBundle{Events: []types.EvidenceEvent{
+		{ID: "evt-1", Source: "github", Type: "commit", Timestamp: time.Now(), Content: "Enforce MFA for all users"},
+		{ID: "evt-2", Source: "jira", Type: "ticket", Timestamp: time.Now(), Content: "Quarterly access review completed"},
+	}}
+	return ai.NewEngine(cfg, ai.NewMockProvider()), *preamble, evidence
+}
+
+func consensusProvider(response string) *ai.MockProvider {
+	provider := ai.NewMockProvider()
+	provider.SetResponse(response)
+	return provider
+}
+
+func TestAnalyzeConsensus_DivergentProvidersFlaggedForReview(t *testing.T) {
+	// Arrange
+	engine, preamble, evidence := newConsensusEngine(t, 0.3)
+	optimistic := consensusProvider(`{"summary": "MFA and access reviews in place", "mapped_controls": ["CC6.1", "CC6.2"], "confidence_score": 0.9, "residual_risk": "low", "citations": ["evt-1", "evt-2"]}`)
+	skeptical := consensusProvider(`{"summary": "Access reviews lack sign-off", "mapped_controls": ["CC6.3"], "confidence_score": 0.4, "residual_risk": "high", "citations": ["evt-2"]}`)
+
+	// Act
+	result, err := engine.AnalyzeConsensus(context.Background(), preamble, evidence, optimistic, skeptical)
+
+	// Assert
+	require.NoError(t, err)
+	require.Len(t, result.Findings, 2)
+	assert.Equal(t, "low", result.Findings[0].ResidualRisk)
+	assert.Equal(t, "high", result.Findings[1].ResidualRisk)
+
+	// (0.5 confidence spread + 1.0 no shared controls + 1.0 low vs high) / 3
+	assert.InDelta(t, 2.5/3, result.Disagreement, 0.001)
+	assert.True(t, result.Finding.ReviewRequired)
+	assert.Equal(t, "high", result.Finding.ResidualRisk, "the highest residual risk wins")
+	assert.Equal(t, types.SeverityHigh, result.Finding.Severity)
+	assert.InDelta(t, 0.65, result.Finding.ConfidenceScore, 0.001)
+	assert.ElementsMatch(t, []string{"CC6.1", "CC6.2", "CC6.3"}, result.Finding.MappedControls)
+	assert.Equal(t, 1, optimistic.GetCallCount())
+	assert.Equal(t, 1, skeptical.GetCallCount())
+}
+
+func TestAnalyzeConsensus_AgreeingProvidersNotFlagged(t *testing.T) {
+	// Arrange
+	engine, preamble, evidence := newConsensusEngine(t, 0.3)
+	first := consensusProvider(`{"summary": "MFA enforced", "mapped_controls": ["CC6.1"], "confidence_score": 0.85, "residual_risk": "low", "citations": ["evt-1"]}`)
+	second := consensusProvider(`{"summary": "MFA is enforced for users", "mapped_controls": ["CC6.1"], "confidence_score": 0.8, "residual_risk": "Minimal", "citations": ["evt-1"]}`)
+
+	// Act
+	result, err := engine.AnalyzeConsensus(context.Background(), preamble, evidence, first, second)
+
+	// Assert
+	require.NoError(t, err)
+	assert.InDelta(t, 0.05/3, result.Disagreement, 0.001)
+	assert.False(t, result.Finding.ReviewRequired)
+	assert.Equal(t, []string{"CC6.1"}, result.Finding.MappedControls)
+}
+
+func TestAnalyzeConsensus_ThresholdFromConfig(t *testing.T) {
+	// Arrange
+	engine, preamble, evidence := newConsensusEngine(t, 0.9)
+	first := consensusProvider(`{"summary": "MFA enforced", "mapped_controls": ["CC6.1"], "confidence_score": 0.9, "residual_risk": "low", "citations": ["evt-1"]}`)
+	second := consensusProvider(`{"summary": "MFA gaps", "mapped_controls": ["CC6.2"], "confidence_score": 0.5, "residual_risk": "high", "citations": ["evt-1"]}`)
+
+	// Act
+	result, err := engine.AnalyzeConsensus(context.Background(), preamble, evidence, first, second)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Greater(t, result.Disagreement, 0.3)
+	assert.False(t, result.Finding.ReviewRequired, "disagreement is below the configured 0.9 threshold")
+}
+
+func TestAnalyzeConsensus_RequiresTwoProviders(t *testing.T) {
+	// Arrange
+	engine, preamble, evidence := newConsensusEngine(t, 0)
+
+	// Act
+	_, err := engine.AnalyzeConsensus(context.Background(), preamble, evidence, ai.NewMockProvider())
+
+	// Assert
+	assert.ErrorIs(t, err, ai.ErrInvalidRequest)
+}
+
+func TestAnalyzeConsensus_ProviderErrorFails(t *testing.T) {
+	// Arrange
+	engine, preamble, evidence := newConsensusEngine(t, 0)
+	failing := ai.NewMockProvider()
+	failing.SetError(ai.ErrProviderAuth)
+
+	// Act
+	_, err := engine.AnalyzeConsensus(context.Background(), preamble, evidence, ai.NewMockProvider(), failing)
+
+	// Assert
+	assert.ErrorIs(t, err, ai.ErrProviderAuth)
+	assert.Contains(t, err.Error(), "consensus provider 2")
+}
+
+func TestAnalyzeConsensus_FindingsNameTheirProvider(t *testing.T) {
+	// Arrange
+	engine, preamble, evidence := newConsensusEngine(t, 0)
+	first := ai.NewMockProvider()
+	first.SetIdentity(types.AIProviderOpenAI, "gpt-4o")
+	second := ai.NewMockProvider()
+	second.SetIdentity(types.AIProviderAnthropic, "")
+
+	// Act
+	result, err := engine.AnalyzeConsensus(context.Background(), preamble, evidence, first, second)
+
+	// Assert
+	require.NoError(t, err)
+	require.Len(t, result.Findings, 2)
+	assert.Equal(t, types.AIProviderOpenAI, result.Findings[0].Provider)
+	assert.Equal(t, "gpt-4o", result.Findings[0].Model)
+	assert.Equal(t, types.AIProviderAnthropic, result.Findings[1].Provider)
+	assert.Equal(t, types.DefaultProviderModels[types.AIProviderAnthropic], result.Findings[1].Model, "an unset model falls back to the provider default")
+}
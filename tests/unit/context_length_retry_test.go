@@ -0,0 +1,281 @@
+package unit
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/pickjonathan/sdek-cli/internal/ai"
+	"github.com/pickjonathan/sdek-cli/internal/ai/providers"
+	"github.com/pickjonathan/sdek-cli/pkg/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// contextLimitedProvider rejects prompts holding more than maxEvents events, as a
+// model with a small context window would, and answers the rest like the mock provider
+type contextLimitedProvider struct {
+	*ai.MockProvider
+	maxEvents int
+	rejected  int
+}
+
+func (p *contextLimitedProvider) AnalyzeWithContext(ctx context.Context, prompt string) (string, error) {
+	if strings.Contains(prompt, fmt.Sprintf("%d. [github/commit]", p.maxEvents+1)) {
+		p.rejected++
+		return "", ai.NewProviderError(ai.CodeContextLengthExceeded, "mock",
+			errors.New("This model's maximum context length is 8192 tokens"))
+	}
+	return p.MockProvider.AnalyzeWithContext(ctx, prompt)
+}
+
+func contextLengthTestEngine(t *testing.T, provider ai.Provider) ai.Engine {
+	t.Helper()
+	cfg := &types.Config{
+		AI: types.AIConfig{
+			Enabled:  true,
+			Provider: "mock",
+			Model:    "small-context-model",
+			Mode:     types.AIModeContext,
+			CacheDir: t.TempDir(),
+		},
+	}
+	return ai.NewEngine(cfg, provider)
+}
+
+func contextLengthTestPreamble(t *testing.T) types.ContextPreamble {
+	t.Helper()
+	preamble, err := types.NewContextPreamble("SOC2", "2017", "CC6.1", "Logical access security software, infrastructure and architectures are implemented", nil)
+	require.NoError(t, err)
+	return *preamble
+}
+
+func TestAnalyze_ContextLengthErrorRetriesInChunks(t *testing.T) {
+	// Arrange
+	provider := &contextLimitedProvider{MockProvider: ai.NewMockProvider(), maxEvents: 5}
+	engine := contextLengthTestEngine(t, provider)
+
+	// Act
+	finding, err := engine.Analyze(context.Background(), contextLengthTestPreamble(t), largeEvidenceBundle(10, 400))
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, 1, provider.rejected, "only the full bundle should be rejected")
+	assert.Equal(t, 2, provider.GetCallCount(), "one successful call per half")
+	assert.NotContains(t, provider.GetLastPrompt(), "6. [github/commit]")
+	assert.Equal(t, "ai", finding.Mode)
+	assert.Equal(t, "small-context-model", finding.Model)
+	assert.Equal(t, []types.ProvenanceEntry{{Source: "github", EventsUsed: 10}}, finding.Provenance)
+}
+
+func TestAnalyze_ContextLengthErrorUsesFallbackModel(t *testing.T) {
+	// Arrange
+	provider := &contextLimitedProvider{MockProvider: ai.NewMockProvider(), maxEvents: 5}
+	fallback := ai.NewMockProvider()
+	engine := contextLengthTestEngine(t, provider)
+	ai.SetContextFallback(engine, fallback, "large-context-model")
+
+	// Act
+	finding, err := engine.Analyze(context.Background(), contextLengthTestPreamble(t), largeEvidenceBundle(10, 400))
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, 1, provider.rejected)
+	assert.Equal(t, 0, provider.GetCallCount(), "the small model should not be retried")
+	assert.Equal(t, 1, fallback.GetCallCount(), "the fallback model should get the whole bundle")
+	assert.Contains(t, fallback.GetLastPrompt(), "10. [github/commit]")
+	assert.Equal(t, "large-context-model", finding.Model)
+}
+
+func TestAnalyze_ContextLengthErrorSingleEventFails(t *testing.T) {
+	// Arrange: even one event is too long for the model
+	provider := &contextLimitedProvider{MockProvider: ai.NewMockProvider(), maxEvents: 0}
+	engine := contextLengthTestEngine(t, provider)
+
+	// Act
+	_, err := engine.Analyze(context.Background(), contextLengthTestPreamble(t), largeEvidenceBundle(1, 400))
+
+	// Assert
+	require.Error(t, err)
+	assert.True(t, errors.Is(err, ai.ErrContextLengthExceeded))
+	assert.Contains(t, err.Error(), "ai.context_fallback_model")
+	assert.Equal(t, 1, provider.rejected, "a single event should not be retried")
+}
+
+func TestIsContextLengthError(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{"nil", nil, false},
+		{"sentinel", ai.NewProviderError(ai.CodeContextLengthExceeded, "openai", nil), true},
+		{"openai code", errors.New("error, status code: 400, message: context_length_exceeded"), true},
+		{"anthropic message", errors.New("prompt is too long: 210000 tokens > 200000 maximum"), true},
+		{"ollama message", fmt.Errorf("ollama api error: %w", errors.New("input is too long for the context window")), true},
+		{"rate limit mentioning tokens", errors.New("Rate limit reached for gpt-4o on tokens per min: too many tokens"), false},
+		{"context window mentioned in passing", errors.New("request timed out; try a model with a larger context window"), false},
+		{"rate limit", ai.NewProviderError(ai.CodeProviderRateLimit, "openai", nil), false},
+		{"rate limit with context length wording", ai.NewProviderError(ai.CodeProviderRateLimit, "anthropic", errors.New("prompt is too long for your remaining tokens per minute")), false},
+		{"max_prompt_chars refusal", fmt.Errorf("%w: set a context window", ai.ErrPromptTooLarge), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, ai.IsContextLengthError(tt.err))
+		})
+	}
+}
+
+func TestAnalyze_ContextFallbackFindingCachedUnderFallbackModel(t *testing.T) {
+	// Arrange
+	cacheDir := t.TempDir()
+	newEngine := func(model string) (ai.Engine, *contextLimitedProvider, *ai.MockProvider) {
+		cfg := &types.Config{
+			AI: types.AIConfig{
+				Enabled:  true,
+				Provider: "mock",
+				Model:    model,
+				Mode:     types.AIModeContext,
+				CacheDir: cacheDir,
+			},
+		}
+		provider := &contextLimitedProvider{MockProvider: ai.NewMockProvider(), maxEvents: 5}
+		fallback := ai.NewMockProvider()
+		engine := ai.NewEngine(cfg, provider)
+		ai.SetContextFallback(engine, fallback, "large-context-model")
+		return engine, provider, fallback
+	}
+	preamble := contextLengthTestPreamble(t)
+	evidence := largeEvidenceBundle(10, 400)
+
+	first, _, _ := newEngine("small-context-model")
+	_, err := first.Analyze(context.Background(), preamble, evidence)
+	require.NoError(t, err)
+
+	// Act
+	again, provider, fallback := newEngine("small-context-model")
+	cached, err := again.Analyze(context.Background(), preamble, evidence)
+	require.NoError(t, err)
+	direct, directProvider, _ := newEngine("large-context-model")
+	fresh, err := direct.Analyze(context.Background(), preamble, evidence)
+	require.NoError(t, err)
+
+	// Assert
+	assert.True(t, cached.CacheHit, "the fallback model's finding should be served from the cache")
+	assert.Equal(t, "large-context-model", cached.Model)
+	assert.Equal(t, 0, provider.rejected+provider.GetCallCount()+fallback.GetCallCount())
+	assert.True(t, fresh.CacheHit, "the finding is cached under the fallback model's key")
+	assert.Equal(t, 0, directProvider.GetCallCount()+directProvider.rejected)
+}
+
+// newErrorServer answers every request with status and body, counting the requests
+func newErrorServer(t *testing.T, status int, body string) (*httptest.Server, *int32) {
+	t.Helper()
+	var requests int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Retry-After-Ms", "1")
+		w.WriteHeader(status)
+		_, _ = io.WriteString(w, body)
+	}))
+	t.Cleanup(server.Close)
+	return server, &requests
+}
+
+func TestProviders_ClassifyContextLengthErrorsByStatus(t *testing.T) {
+	tests := []struct {
+		name     string
+		provider string
+		status   int
+		body     string
+		want     error
+	}{
+		{
+			name:     "openai context_length_exceeded",
+			provider: "openai",
+			status:   http.StatusBadRequest,
+			body:     `{"error":{"message":"This model's maximum context length is 8192 tokens","type":"invalid_request_error","code":"context_length_exceeded"}}`,
+			want:     ai.ErrContextLengthExceeded,
+		},
+		{
+			name:     "openai rate limit mentioning tokens",
+			provider: "openai",
+			status:   http.StatusTooManyRequests,
+			body:     `{"error":{"message":"Rate limit reached for gpt-4o on tokens per min: too many tokens, maximum context length of requests per min","type":"tokens","code":"rate_limit_exceeded"}}`,
+			want:     ai.ErrProviderRateLimit,
+		},
+		{
+			name:     "anthropic prompt too long",
+			provider: "anthropic",
+			status:   http.StatusBadRequest,
+			body:     `{"type":"error","error":{"type":"invalid_request_error","message":"prompt is too long: 210000 tokens > 200000 maximum"}}`,
+			want:     ai.ErrContextLengthExceeded,
+		},
+		{
+			name:     "anthropic rate limit mentioning prompt length",
+			provider: "anthropic",
+			status:   http.StatusTooManyRequests,
+			body:     `{"type":"error","error":{"type":"rate_limit_error","message":"prompt is too long for your remaining input tokens per minute"}}`,
+			want:     ai.ErrProviderRateLimit,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			server, _ := newErrorServer(t, tt.status, tt.body)
+			config := types.ProviderConfig{
+				APIKey:   "test-key",
+				Endpoint: server.URL,
+				Timeout:  1,
+			}
+			var provider ai.Provider
+			var err error
+			if tt.provider == "anthropic" {
+				config.Model = "claude-3-5-sonnet-20241022"
+				provider, err = providers.NewAnthropicEngine(config)
+			} else {
+				config.Model = "gpt-4o"
+				provider, err = providers.NewOpenAIEngine(config)
+			}
+			require.NoError(t, err)
+
+			// Act
+			_, err = provider.AnalyzeWithContext(context.Background(), "Analyze CC6.1")
+
+			// Assert
+			require.Error(t, err)
+			assert.True(t, errors.Is(err, tt.want), "got %v", err)
+			assert.Equal(t, tt.want == ai.ErrContextLengthExceeded, ai.IsContextLengthError(err))
+		})
+	}
+}
+
+func TestOpenAI_ContextLengthErrorIsNotRetried(t *testing.T) {
+	// Arrange
+	server, requests := newErrorServer(t, http.StatusBadRequest,
+		`{"error":{"message":"This model's maximum context length is 8192 tokens","type":"invalid_request_error","code":"context_length_exceeded"}}`)
+	provider, err := providers.NewOpenAIEngine(types.ProviderConfig{
+		APIKey:   "test-key",
+		Model:    "gpt-4o",
+		Endpoint: server.URL,
+		Timeout:  5,
+	})
+	require.NoError(t, err)
+
+	// Act
+	_, err = provider.AnalyzeWithContext(context.Background(), "Analyze CC6.1")
+
+	// Assert
+	require.Error(t, err)
+	assert.True(t, errors.Is(err, ai.ErrContextLengthExceeded))
+	assert.Equal(t, int32(1), atomic.LoadInt32(requests))
+}
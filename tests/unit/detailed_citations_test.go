@@ -0,0 +1,63 @@
+package unit
+
+import (
+	"testing"
+	"time"
+
+	"github.com/pickjonathan/sdek-cli/internal/ai"
+	"github.com/pickjonathan/sdek-cli/pkg/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDetailCitations_ResolvesSourceAndDate(t *testing.T) {
+	// Arrange
+	finding := &types.Finding{Citations: []string{"evt-2", "evt-1"}}
+	evidence := types.EvidenceBundle{Events: []types.EvidenceEvent{
+		{ID: "evt-1", Source: "github", Type: "commit", Timestamp: time.Date(2024, 5, 1, 9, 30, 0, 0, time.UTC)},
+		{ID: "evt-2", Source: "jira", Type: "ticket", Timestamp: time.Date(2024, 6, 12, 23, 0, 0, 0, time.UTC)},
+	}}
+
+	// Act
+	ai.DetailCitations(finding, evidence)
+
+	// Assert
+	assert.Equal(t, []string{"evt-2 (jira, 2024-06-12)", "evt-1 (github, 2024-05-01)"}, finding.CitationsDetailed)
+	assert.Equal(t, []string{"evt-2", "evt-1"}, finding.Citations, "raw citations must be kept")
+}
+
+func TestDetailCitations_UnknownIDs(t *testing.T) {
+	// Arrange
+	finding := &types.Finding{Citations: []string{"evt-1", "evt-9"}}
+	evidence := types.EvidenceBundle{Events: []types.EvidenceEvent{
+		{ID: "evt-1", Source: "aws", Timestamp: time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)},
+	}}
+
+	// Act
+	ai.DetailCitations(finding, evidence)
+
+	// Assert
+	assert.Equal(t, []string{"evt-1 (aws, 2024-05-01)", "evt-9 (unknown event)"}, finding.CitationsDetailed)
+}
+
+func TestDetailCitations_MissingSourceAndTimestamp(t *testing.T) {
+	// Arrange
+	finding := &types.Finding{Citations: []string{"evt-1"}}
+	evidence := types.EvidenceBundle{Events: []types.EvidenceEvent{{ID: "evt-1"}}}
+
+	// Act
+	ai.DetailCitations(finding, evidence)
+
+	// Assert
+	assert.Equal(t, []string{"evt-1 (unknown source)"}, finding.CitationsDetailed)
+}
+
+func TestDetailCitations_NoCitations(t *testing.T) {
+	// Arrange
+	finding := &types.Finding{}
+
+	// Act
+	ai.DetailCitations(finding, types.EvidenceBundle{})
+
+	// Assert
+	assert.Empty(t, finding.CitationsDetailed)
+}
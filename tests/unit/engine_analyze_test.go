@@ -586,3 +586,152 @@ func TestAnalyze_CacheHitPerformance(t *testing.T) {
 	assert.Less(t, duration, 100*time.Millisecond, "Cache hit should take <100ms")
 	t.Logf("Cache hit took %v", duration)
 }
+
+func TestAnalyze_DropsUnknownCitations(t *testing.T) {
+	// Arrange
+	cfg := &types.Config{
+		AI: types.AIConfig{
+			Enabled:  true,
+			Provider: "mock",
+			Mode:     types.AIModeContext,
+		},
+	}
+	mockProvider := ai.NewMockProvider()
+	mockProvider.SetResponse(`{
+		"summary": "MFA is enforced for admin access",
+		"mapped_controls": ["CC6.1"],
+		"confidence_score": 0.9,
+		"residual_risk": "low",
+		"justification": "Commit evt-1 adds MFA",
+		"citations": ["evt-1", "evt-404"]
+	}`)
+	engine := ai.NewEngine(cfg, mockProvider)
+
+	preamble, err := types.NewContextPreamble(
+		"SOC2",
+		"2017",
+		"CC6.1",
+		"Access controls shall be implemented to ensure that only authorized individuals can access sensitive data.",
+		nil,
+	)
+	require.NoError(t, err)
+
+	evidence := types.EvidenceBundle{
+		Events: []types.EvidenceEvent{
+			{
+				ID:        "evt-1",
+				Source:    "github",
+				Timestamp: time.Now(),
+				Type:      "commit",
+				Content:   "Added MFA authentication to login endpoint",
+			},
+		},
+	}
+
+	// Act
+	finding, err := engine.Analyze(context.Background(), *preamble, evidence)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, []string{"evt-1"}, finding.Citations, "Unknown citation should be dropped")
+	assert.InDelta(t, 0.45, finding.ConfidenceScore, 0.001, "Confidence should be scaled by valid citation fraction")
+	assert.True(t, finding.ReviewRequired, "Lowered confidence should require review")
+}
+
+func TestAnalyze_InvalidCitationsBelowThresholdKeepConfidence(t *testing.T) {
+	// Arrange
+	cfg := &types.Config{
+		AI: types.AIConfig{
+			Enabled:                  true,
+			Provider:                 "mock",
+			Mode:                     types.AIModeContext,
+			InvalidCitationThreshold: 0.75,
+		},
+	}
+	mockProvider := ai.NewMockProvider()
+	mockProvider.SetResponse(`{
+		"summary": "MFA is enforced for admin access",
+		"confidence_score": 0.9,
+		"residual_risk": "low",
+		"citations": ["evt-1", "evt-404"]
+	}`)
+	engine := ai.NewEngine(cfg, mockProvider)
+
+	preamble, err := types.NewContextPreamble(
+		"SOC2",
+		"2017",
+		"CC6.1",
+		"Access controls shall be implemented to ensure that only authorized individuals can access sensitive data.",
+		nil,
+	)
+	require.NoError(t, err)
+
+	evidence := types.EvidenceBundle{
+		Events: []types.EvidenceEvent{
+			{
+				ID:        "evt-1",
+				Source:    "github",
+				Timestamp: time.Now(),
+				Type:      "commit",
+				Content:   "Added MFA authentication to login endpoint",
+			},
+		},
+	}
+
+	// Act
+	finding, err := engine.Analyze(context.Background(), *preamble, evidence)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, []string{"evt-1"}, finding.Citations, "Unknown citation should be dropped")
+	assert.Equal(t, 0.9, finding.ConfidenceScore, "Confidence should be unchanged below threshold")
+}
+
+func TestAnalyze_CachedFindingPreservesProviderAndModel(t *testing.T) {
+	// Arrange
+	cfg := &types.Config{
+		AI: types.AIConfig{
+			Enabled:  true,
+			Provider: "anthropic",
+			Model:    "claude-3-5-sonnet-20241022",
+			Mode:     types.AIModeContext,
+			CacheDir: t.TempDir(),
+		},
+	}
+	engine := ai.NewEngine(cfg, ai.NewMockProvider())
+
+	preamble, err := types.NewContextPreamble(
+		"SOC2",
+		"2017",
+		"CC6.1",
+		"Access controls shall be implemented to ensure that only authorized individuals can access sensitive data.",
+		nil,
+	)
+	require.NoError(t, err)
+
+	evidence := types.EvidenceBundle{
+		Events: []types.EvidenceEvent{
+			{
+				ID:      "evt-1",
+				Source:  "github",
+				Content: "Added authentication",
+			},
+		},
+	}
+
+	// Act
+	fresh, err := engine.Analyze(context.Background(), *preamble, evidence)
+	require.NoError(t, err)
+	cached, err := engine.Analyze(context.Background(), *preamble, evidence)
+	require.NoError(t, err)
+
+	// Assert
+	assert.False(t, fresh.CacheHit, "Fresh finding should not be marked as cache hit")
+	assert.Equal(t, "anthropic", fresh.Provider)
+	assert.Equal(t, "claude-3-5-sonnet-20241022", fresh.Model)
+
+	assert.True(t, cached.CacheHit, "Cached finding should be marked as cache hit")
+	assert.Equal(t, "anthropic", cached.Provider, "Cached finding should keep the original provider")
+	assert.Equal(t, "claude-3-5-sonnet-20241022", cached.Model, "Cached finding should keep the original model")
+	assert.Equal(t, fresh.LatencyMs, cached.LatencyMs, "Cached finding should keep the original latency")
+}
@@ -0,0 +1,125 @@
+package unit
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/pickjonathan/sdek-cli/internal/ai"
+	"github.com/pickjonathan/sdek-cli/pkg/types"
+)
+
+// benchmarkEventVolumes are representative evidence bundle sizes: a single PR, a sprint, a quarter
+var benchmarkEventVolumes = []int{10, 100, 1000}
+
+// BenchmarkAnalyze measures end-to-end context analysis with the mock provider.
+// Caching is disabled so every iteration redacts, builds the prompt and parses the response.
+func BenchmarkAnalyze(b *testing.B) {
+	preamble := createBenchmarkPreamble(b)
+
+	for _, n := range benchmarkEventVolumes {
+		b.Run(fmt.Sprintf("events=%d", n), func(b *testing.B) {
+			cfg := &types.Config{
+				AI: types.AIConfig{
+					Enabled:   true,
+					Provider:  "mock",
+					Mode:      types.AIModeContext,
+					NoCache:   true,
+					Redaction: types.RedactionConfig{Enabled: true},
+				},
+			}
+			engine := ai.NewEngine(cfg, ai.NewMockProvider())
+			evidence := types.EvidenceBundle{Events: generateAnalysisEvents(n)}
+
+			b.ResetTimer()
+			b.ReportAllocs()
+
+			for i := 0; i < b.N; i++ {
+				if _, err := engine.Analyze(context.Background(), preamble, evidence); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+// BenchmarkProposePlan measures plan generation with the mock provider
+func BenchmarkProposePlan(b *testing.B) {
+	cfg := &types.Config{
+		AI: types.AIConfig{
+			Enabled:  true,
+			Provider: "mock",
+			Mode:     types.AIModeAutonomous,
+			Autonomous: types.AutonomousConfig{
+				AutoApprove: types.AutoApproveConfig{
+					"github": {"*security*"},
+				},
+			},
+		},
+	}
+	engine := ai.NewEngine(cfg, ai.NewMockProvider())
+	preamble := createBenchmarkPreamble(b)
+
+	b.ResetTimer()
+	b.ReportAllocs()
+
+	for i := 0; i < b.N; i++ {
+		if _, err := engine.ProposePlan(context.Background(), preamble); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func createBenchmarkPreamble(b *testing.B) types.ContextPreamble {
+	b.Helper()
+	preamble, err := types.NewContextPreamble(
+		"SOC2",
+		"2017",
+		"CC6.1",
+		"Access controls shall be implemented to ensure that only authorized individuals can access sensitive data.",
+		nil,
+	)
+	if err != nil {
+		b.Fatal(err)
+	}
+	return *preamble
+}
+
+// generateAnalysisEvents creates evidence events with realistic content, including PII for the redactor
+func generateAnalysisEvents(count int) []types.EvidenceEvent {
+	events := make([]types.EvidenceEvent, count)
+	baseTime := time.Date(2025, 10, 18, 10, 0, 0, 0, time.UTC)
+
+	for i := 0; i < count; i++ {
+		events[i] = types.EvidenceEvent{
+			ID:        fmt.Sprintf("evt-%d", i),
+			Source:    "github",
+			Type:      "pull_request",
+			Timestamp: baseTime.Add(time.Duration(i) * time.Minute),
+			Content: fmt.Sprintf("PR #%d: Enforce MFA on admin login. Reviewed by jane.doe@example.com, "+
+				"rollout tracked in SEC-%d. Access review completed for auth-service.", i, i),
+		}
+	}
+
+	return events
+}
+
+// BenchmarkContextCacheKey measures cache key computation for context analysis.
+// Events are hashed once and only fixed-size digests are sorted, so allocations stay flat per event.
+func BenchmarkContextCacheKey(b *testing.B) {
+	preamble := createBenchmarkPreamble(b)
+
+	for _, n := range benchmarkEventVolumes {
+		b.Run(fmt.Sprintf("events=%d", n), func(b *testing.B) {
+			evidence := types.EvidenceBundle{Events: generateAnalysisEvents(n)}
+
+			b.ResetTimer()
+			b.ReportAllocs()
+
+			for i := 0; i < b.N; i++ {
+				_ = ai.ContextCacheKey(preamble, evidence)
+			}
+		})
+	}
+}
@@ -0,0 +1,109 @@
+package unit
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/pickjonathan/sdek-cli/internal/ai"
+	"github.com/pickjonathan/sdek-cli/pkg/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestAnalyze_ConcurrentWithCache runs many concurrent Analyze calls against a shared
+// cache. Run with -race to detect data races.
+func TestAnalyze_ConcurrentWithCache(t *testing.T) {
+	cacheDir := t.TempDir()
+	cfg := &types.Config{
+		AI: types.AIConfig{
+			Enabled:  true,
+			Provider: "mock",
+			Mode:     types.AIModeContext,
+			CacheDir: cacheDir,
+			Redaction: types.RedactionConfig{
+				Enabled: true,
+			},
+		},
+	}
+	mockProvider := ai.NewMockProvider()
+	engine := ai.NewEngine(cfg, mockProvider)
+
+	const sections = 5
+	const workers = 50
+
+	preambles := make([]*types.ContextPreamble, sections)
+	for i := range preambles {
+		preamble, err := types.NewContextPreamble(
+			"SOC2",
+			"2017",
+			fmt.Sprintf("CC6.%d", i+1),
+			"Access controls shall be implemented to ensure that only authorized individuals can access sensitive data.",
+			nil,
+		)
+		require.NoError(t, err)
+		preambles[i] = preamble
+	}
+
+	evidence := types.EvidenceBundle{
+		Events: []types.EvidenceEvent{
+			{
+				ID:        "evt-1",
+				Source:    "github",
+				Timestamp: time.Now(),
+				Type:      "commit",
+				Content:   "Added MFA for admin@example.com",
+			},
+		},
+	}
+
+	var wg sync.WaitGroup
+	errs := make(chan error, workers)
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			finding, err := engine.Analyze(context.Background(), *preambles[i%sections], evidence)
+			if err != nil {
+				errs <- err
+				return
+			}
+			if finding.ControlID != preambles[i%sections].Section {
+				errs <- fmt.Errorf("worker %d: expected control %s, got %s", i, preambles[i%sections].Section, finding.ControlID)
+			}
+		}(i)
+	}
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		t.Error(err)
+	}
+
+	// Every cache entry is complete and no temp files are left behind
+	entries, err := os.ReadDir(cacheDir)
+	require.NoError(t, err)
+	assert.Len(t, entries, sections, "Expected one cache entry per section")
+	for _, entry := range entries {
+		assert.Equal(t, ".json", filepath.Ext(entry.Name()), "Unexpected file in cache dir: %s", entry.Name())
+	}
+
+	cache, err := ai.NewCache(cacheDir)
+	require.NoError(t, err)
+	stats, err := cache.Stats()
+	require.NoError(t, err)
+	assert.Equal(t, sections, stats.TotalEntries)
+
+	// Subsequent calls are served from cache
+	before := mockProvider.GetCallCount()
+	for _, preamble := range preambles {
+		finding, err := engine.Analyze(context.Background(), *preamble, evidence)
+		require.NoError(t, err)
+		assert.True(t, finding.CacheHit)
+	}
+	assert.Equal(t, before, mockProvider.GetCallCount())
+}
@@ -0,0 +1,129 @@
+package unit
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/pickjonathan/sdek-cli/internal/ai"
+	"github.com/pickjonathan/sdek-cli/pkg/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newDenylistTestInputs(t *testing.T) (types.ContextPreamble, types.EvidenceBundle) {
+	t.Helper()
+
+	// The excerpt is not run through the redactor, so only the denylist check can catch it
+	preamble, err := types.NewContextPreamble(
+		"SOC2",
+		"2017",
+		"CC6.1",
+		"Access controls for Project Nightjar shall be implemented to ensure that only authorized individuals can access sensitive data.",
+		nil,
+	)
+	require.NoError(t, err)
+
+	evidence := types.EvidenceBundle{
+		Events: []types.EvidenceEvent{
+			{
+				ID:        "evt-1",
+				Source:    "github",
+				Timestamp: time.Now().Add(-24 * time.Hour),
+				Type:      "commit",
+				Content:   "Enabled MFA for the nightjar admin console",
+			},
+		},
+	}
+
+	return *preamble, evidence
+}
+
+func TestAnalyze_DenylistRedactMode(t *testing.T) {
+	// Arrange: redaction disabled, so the denylist check is the only safeguard
+	cfg := &types.Config{
+		AI: types.AIConfig{
+			Enabled:  true,
+			Provider: "mock",
+			Mode:     types.AIModeContext,
+			Redaction: types.RedactionConfig{
+				Enabled:      false,
+				Denylist:     []string{"Nightjar"},
+				DenylistMode: types.DenylistModeRedact,
+			},
+		},
+	}
+	provider := ai.NewMockProvider()
+	engine := ai.NewEngine(cfg, provider)
+	preamble, evidence := newDenylistTestInputs(t)
+
+	// Act
+	finding, err := engine.Analyze(context.Background(), preamble, evidence)
+
+	// Assert
+	require.NoError(t, err)
+	require.NotNil(t, finding)
+	assert.Equal(t, 1, provider.GetCallCount())
+
+	prompt := provider.GetLastPrompt()
+	assert.NotContains(t, prompt, "Nightjar")
+	assert.NotContains(t, prompt, "nightjar", "Denylist matching should be case-insensitive")
+	assert.Contains(t, prompt, "Project [REDACTED:SECRET]")
+	assert.Contains(t, prompt, "the [REDACTED:SECRET] admin console")
+}
+
+func TestAnalyze_DenylistBlockMode(t *testing.T) {
+	// Arrange
+	cfg := &types.Config{
+		AI: types.AIConfig{
+			Enabled:  true,
+			Provider: "mock",
+			Mode:     types.AIModeContext,
+			Redaction: types.RedactionConfig{
+				Enabled:      true,
+				Denylist:     []string{"Nightjar"},
+				DenylistMode: types.DenylistModeBlock,
+			},
+		},
+	}
+	provider := ai.NewMockProvider()
+	engine := ai.NewEngine(cfg, provider)
+	preamble, evidence := newDenylistTestInputs(t)
+
+	// Act
+	finding, err := engine.Analyze(context.Background(), preamble, evidence)
+
+	// Assert
+	require.Error(t, err)
+	assert.ErrorIs(t, err, ai.ErrDenylistViolation)
+	assert.NotContains(t, err.Error(), "Nightjar", "Error should not echo the denylisted term")
+	assert.Nil(t, finding)
+	assert.Equal(t, 0, provider.GetCallCount(), "Provider should not be called")
+}
+
+func TestAnalyze_DenylistBlockModeAllowsCleanPrompts(t *testing.T) {
+	// Arrange
+	cfg := &types.Config{
+		AI: types.AIConfig{
+			Enabled:  true,
+			Provider: "mock",
+			Mode:     types.AIModeContext,
+			Redaction: types.RedactionConfig{
+				Enabled:      true,
+				Denylist:     []string{"Kestrel"},
+				DenylistMode: types.DenylistModeBlock,
+			},
+		},
+	}
+	provider := ai.NewMockProvider()
+	engine := ai.NewEngine(cfg, provider)
+	preamble, evidence := newDenylistTestInputs(t)
+
+	// Act
+	finding, err := engine.Analyze(context.Background(), preamble, evidence)
+
+	// Assert
+	require.NoError(t, err)
+	require.NotNil(t, finding)
+	assert.Equal(t, 1, provider.GetCallCount())
+}
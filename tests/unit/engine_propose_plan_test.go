@@ -121,9 +121,9 @@ func TestProposePlan_AutoApproveMarking(t *testing.T) {
 	mockProvider := ai.NewMockProvider()
 	// Configure mock to return plan with matching queries
 	mockProvider.SetPlanItems([]types.PlanItem{
-		{Source: "github", Query: "authentication"},
-		{Source: "github", Query: "payment"},
-		{Source: "aws", Query: "iam:CreateUser"},
+		{Source: "github", Query: "authentication", Rationale: "Authentication changes show access controls"},
+		{Source: "github", Query: "payment", Rationale: "Payment changes may touch cardholder data"},
+		{Source: "aws", Query: "iam:CreateUser", Rationale: "IAM user creation shows account provisioning"},
 	})
 	engine := ai.NewEngine(cfg, mockProvider)
 
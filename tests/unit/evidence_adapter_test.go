@@ -0,0 +1,168 @@
+package unit
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/pickjonathan/sdek-cli/internal/ai"
+	"github.com/pickjonathan/sdek-cli/pkg/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// auditLogAdapter parses a made-up audit log export: {"schema": "auditlog", "entries": [...]}
+type auditLogAdapter struct{}
+
+func (auditLogAdapter) Schema() string { return "auditlog" }
+
+func (auditLogAdapter) Detect(data []byte) bool {
+	var export map[string]json.RawMessage
+	if err := json.Unmarshal(data, &export); err != nil {
+		return false
+	}
+	_, ok := export["entries"]
+	return ok
+}
+
+func (auditLogAdapter) Parse(data []byte) ([]types.EvidenceEvent, error) {
+	var export struct {
+		Entries []struct {
+			Seq    int       `json:"seq"`
+			At     time.Time `json:"at"`
+			Action string    `json:"action"`
+		} `json:"entries"`
+	}
+	if err := json.Unmarshal(data, &export); err != nil {
+		return nil, err
+	}
+	events := make([]types.EvidenceEvent, 0, len(export.Entries))
+	for _, entry := range export.Entries {
+		events = append(events, types.EvidenceEvent{
+			ID:        fmt.Sprintf("audit-%d", entry.Seq),
+			Source:    "auditlog",
+			Type:      "audit",
+			Timestamp: entry.At,
+			Content:   entry.Action,
+		})
+	}
+	return events, nil
+}
+
+func TestParseEvidence_CustomAdapterBySchema(t *testing.T) {
+	// Arrange
+	ai.RegisterEvidenceAdapter(auditLogAdapter{})
+	data := []byte(`{"schema": "auditlog", "entries": [{"seq": 1, "at": "2024-03-01T09:00:00Z", "action": "admin role granted"}]}`)
+
+	// Act
+	events, err := ai.ParseEvidence(data)
+
+	// Assert
+	require.NoError(t, err)
+	require.Len(t, events, 1)
+	assert.Equal(t, "audit-1", events[0].ID)
+	assert.Equal(t, "admin role granted", events[0].Content)
+	assert.Contains(t, ai.EvidenceSchemas(), "auditlog")
+}
+
+func TestParseEvidence_CustomAdapterByContent(t *testing.T) {
+	// Arrange
+	ai.RegisterEvidenceAdapter(auditLogAdapter{})
+	data := []byte(`{"entries": [{"seq": 2, "at": "2024-03-02T09:00:00Z", "action": "mfa enforced"}]}`)
+
+	// Act
+	events, err := ai.ParseEvidence(data)
+
+	// Assert
+	require.NoError(t, err)
+	require.Len(t, events, 1)
+	assert.Equal(t, "audit-2", events[0].ID)
+}
+
+func TestParseEvidence_Native(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+	}{
+		{name: "array", data: `[{"id": "evt-1", "source": "git"}]`},
+		{name: "ndjson", data: "{\"id\": \"evt-1\", \"source\": \"git\"}\n"},
+		{name: "schema object", data: `{"schema": "native", "events": [{"id": "evt-1", "source": "git"}]}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			events, err := ai.ParseEvidence([]byte(tt.data))
+
+			// Assert
+			require.NoError(t, err)
+			require.Len(t, events, 1)
+			assert.Equal(t, "evt-1", events[0].ID)
+		})
+	}
+}
+
+func TestParseEvidence_GitHubExport(t *testing.T) {
+	// Arrange
+	export := []byte(`{
+		"schema": "github",
+		"pull_requests": [{"id": 7, "html_url": "https://github.com/org/repo/pull/7", "created_at": "2024-02-01T10:00:00Z", "title": "Enforce MFA", "body": "Adds MFA", "state": "closed", "user": {"login": "alice"}, "labels": [{"name": "security"}]}],
+		"commits": [{"sha": "abc123", "html_url": "https://github.com/org/repo/commit/abc123", "commit": {"message": "Rotate keys", "author": {"name": "Bob", "date": "2024-02-02T08:00:00Z"}}, "author": {"login": "bob"}}]
+	}`)
+
+	// Act
+	events, err := ai.ParseEvidence(export)
+
+	// Assert
+	require.NoError(t, err)
+	require.Len(t, events, 2)
+	assert.Equal(t, "github-7", events[0].ID)
+	assert.Equal(t, "pr", events[0].Type)
+	assert.Equal(t, "Enforce MFA\n\nAdds MFA", events[0].Content)
+	assert.Equal(t, "github-abc123", events[1].ID)
+	assert.Equal(t, "commit", events[1].Type)
+	assert.Equal(t, "bob", events[1].Metadata["actor"])
+	assert.True(t, events[1].Timestamp.Equal(time.Date(2024, 2, 2, 8, 0, 0, 0, time.UTC)))
+}
+
+func TestParseEvidence_GitHubArrayByContent(t *testing.T) {
+	// Arrange
+	items := []byte(`[
+		{"id": 1, "html_url": "https://github.com/org/repo/issues/1", "created_at": "2024-01-01T00:00:00Z", "title": "Access review", "body": "", "state": "open", "user": {"login": "carol"}},
+		{"id": 2, "created_at": "2024-01-02T00:00:00Z", "title": "Fix auth", "body": "", "state": "closed", "user": {"login": "dan"}, "pull_request": {"url": "https://api.github.com/repos/org/repo/pulls/2"}}
+	]`)
+
+	// Act
+	events, err := ai.ParseEvidence(items)
+
+	// Assert
+	require.NoError(t, err)
+	require.Len(t, events, 2)
+	assert.Equal(t, "issue", events[0].Type)
+	assert.Equal(t, "pr", events[1].Type)
+	assert.Equal(t, "github", events[1].Source)
+}
+
+func TestParseEvidence_NativeEventsWithGitHubSource(t *testing.T) {
+	// Arrange: native events with string IDs, as in the documented github_*.json bundles
+	data := []byte(`[{"id": "gh-commit-1", "source": "github", "type": "commit", "content": "Enforce MFA"}]`)
+
+	// Act
+	events, err := ai.ParseEvidence(data)
+
+	// Assert
+	require.NoError(t, err)
+	require.Len(t, events, 1)
+	assert.Equal(t, "gh-commit-1", events[0].ID)
+	assert.Equal(t, "Enforce MFA", events[0].Content)
+}
+
+func TestParseEvidence_UnknownSchema(t *testing.T) {
+	// Act
+	_, err := ai.ParseEvidence([]byte(`{"schema": "splunk", "results": []}`))
+
+	// Assert
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `unknown evidence schema "splunk"`)
+}
@@ -0,0 +1,155 @@
+package unit
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/pickjonathan/sdek-cli/internal/ai"
+	"github.com/pickjonathan/sdek-cli/pkg/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func largeEvidenceBundle(events, contentChars int) types.EvidenceBundle {
+	var bundle types.EvidenceBundle
+	for i := 1; i <= events; i++ {
+		bundle.Events = append(bundle.Events, types.EvidenceEvent{
+			ID:        fmt.Sprintf("evt-%d", i),
+			Source:    "github",
+			Type:      "commit",
+			Timestamp: time.Date(2025, 1, 15, 10, 0, 0, 0, time.UTC),
+			Content:   strings.Repeat("x", contentChars),
+		})
+	}
+	return bundle
+}
+
+func TestEvidenceTokenBudget(t *testing.T) {
+	tests := []struct {
+		name   string
+		config types.ProviderConfig
+		want   int
+	}{
+		{"no num_ctx", types.ProviderConfig{MaxTokens: 4096}, 0},
+		{"invalid num_ctx", types.ProviderConfig{MaxTokens: 4096, Extra: map[string]string{"num_ctx": "large"}}, 0},
+		{"window minus response and overhead", types.ProviderConfig{MaxTokens: 2048, Extra: map[string]string{"num_ctx": "8192"}}, 8192 - 2048 - 1024},
+		{"window smaller than response", types.ProviderConfig{MaxTokens: 4096, Extra: map[string]string{"num_ctx": "2048"}}, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, ai.EvidenceTokenBudget(tt.config))
+		})
+	}
+}
+
+func TestChunkEvidence_SplitsToBudget(t *testing.T) {
+	// Arrange: each event is ~100 content tokens plus label overhead
+	bundle := largeEvidenceBundle(10, 400)
+
+	// Act
+	chunks := ai.ChunkEvidence(bundle, 300)
+
+	// Assert
+	require.Len(t, chunks, 5)
+	var ids []string
+	for _, chunk := range chunks {
+		assert.Len(t, chunk.Events, 2)
+		for _, event := range chunk.Events {
+			ids = append(ids, event.ID)
+		}
+	}
+	assert.Equal(t, "evt-1", ids[0])
+	assert.Equal(t, "evt-10", ids[9], "chunks should keep every event in order")
+}
+
+func TestChunkEvidence_NoBudgetKeepsBundle(t *testing.T) {
+	// Arrange
+	bundle := largeEvidenceBundle(10, 400)
+
+	// Act
+	chunks := ai.ChunkEvidence(bundle, 0)
+
+	// Assert
+	require.Len(t, chunks, 1)
+	assert.Len(t, chunks[0].Events, 10)
+}
+
+func TestChunkEvidence_OversizedEventGetsOwnChunk(t *testing.T) {
+	// Arrange
+	bundle := largeEvidenceBundle(3, 4000)
+
+	// Act
+	chunks := ai.ChunkEvidence(bundle, 100)
+
+	// Assert
+	require.Len(t, chunks, 3)
+	for _, chunk := range chunks {
+		assert.Len(t, chunk.Events, 1)
+	}
+}
+
+func TestMergeChunkFindings(t *testing.T) {
+	// Arrange
+	chunks := []ai.ChunkFinding{
+		{Events: 3, Finding: &types.Finding{
+			ID: "finding-1", ControlID: "CC6.1", Summary: "MFA enforced", Justification: "Commits enable MFA",
+			MappedControls: []string{"CC6.1"}, Citations: []string{"evt-1", "evt-2"},
+			ConfidenceScore: 0.9, ResidualRisk: "low", Severity: types.SeverityLow,
+		}},
+		{Events: 1, Finding: &types.Finding{
+			ID: "finding-2", ControlID: "CC6.1", Summary: "Service accounts lack MFA", Justification: "Jira ticket open",
+			MappedControls: []string{"CC6.1", "CC6.2"}, Citations: []string{"evt-4"},
+			ConfidenceScore: 0.5, ResidualRisk: "high", Severity: types.SeverityHigh,
+		}},
+	}
+
+	// Act
+	merged := ai.MergeChunkFindings(chunks)
+
+	// Assert
+	assert.Equal(t, "finding-1", merged.ID)
+	assert.Equal(t, []string{"CC6.1", "CC6.2"}, merged.MappedControls)
+	assert.Equal(t, []string{"evt-1", "evt-2", "evt-4"}, merged.Citations)
+	assert.InDelta(t, 0.8, merged.ConfidenceScore, 0.0001, "confidence should be weighted by events")
+	assert.Equal(t, "high", merged.ResidualRisk)
+	assert.Equal(t, types.SeverityHigh, merged.Severity)
+	assert.Equal(t, "MFA enforced Service accounts lack MFA", merged.Summary)
+	assert.Equal(t, []string{"evt-1", "evt-2"}, chunks[0].Finding.Citations, "inputs should not be modified")
+}
+
+func TestAnalyze_ChunksLargeBundle(t *testing.T) {
+	// Arrange
+	cfg := &types.Config{
+		AI: types.AIConfig{
+			Enabled:  true,
+			Provider: "mock",
+			Mode:     types.AIModeContext,
+			CacheDir: t.TempDir(),
+		},
+	}
+	provider := ai.NewMockProvider()
+	provider.SetEvidenceTokenBudget(300)
+	engine := ai.NewEngine(cfg, provider)
+
+	preamble, err := types.NewContextPreamble("SOC2", "2017", "CC6.1", "Logical access security software, infrastructure and architectures are implemented", nil)
+	require.NoError(t, err)
+	evidence := largeEvidenceBundle(10, 400)
+
+	// Act
+	finding, err := engine.Analyze(context.Background(), *preamble, evidence)
+	require.NoError(t, err)
+
+	// Assert
+	assert.Equal(t, 5, provider.GetCallCount(), "one provider call per chunk")
+	assert.Contains(t, provider.GetLastPrompt(), "2. [github/commit]")
+	assert.NotContains(t, provider.GetLastPrompt(), "3. [github/commit]", "each prompt should only hold one chunk")
+	assert.Equal(t, "ai", finding.Mode)
+	assert.Equal(t, []string{"CC6.1"}, finding.MappedControls)
+	assert.Equal(t, []string{"evt-1"}, finding.Citations)
+	assert.InDelta(t, 0.85, finding.ConfidenceScore, 0.0001)
+	assert.Equal(t, []types.ProvenanceEntry{{Source: "github", EventsUsed: 10}}, finding.Provenance)
+}
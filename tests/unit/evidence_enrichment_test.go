@@ -0,0 +1,143 @@
+package unit
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/pickjonathan/sdek-cli/internal/ai"
+	"github.com/pickjonathan/sdek-cli/pkg/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEnrichEvidence_BySource(t *testing.T) {
+	// Arrange
+	ts := time.Date(2025, 1, 15, 10, 0, 0, 0, time.UTC)
+	bundle := types.EvidenceBundle{Events: []types.EvidenceEvent{
+		{ID: "github-1", Source: "github", Type: "pr", Timestamp: ts, Content: "Require MFA", Metadata: map[string]interface{}{
+			"html_url": "https://github.com/acme/api/pull/7",
+			"actor":    "alice",
+			"state":    "closed",
+		}},
+		{ID: "github-abc", Source: "github", Type: "commit", Timestamp: ts, Content: "Rotate keys", Metadata: map[string]interface{}{
+			"author_name":    "Bob Smith",
+			"repository_url": "https://api.github.com/repos/acme/infra",
+		}},
+		{ID: "jira-SEC-42", Source: "jira", Type: "issue", Timestamp: ts, Content: "Access review", Metadata: map[string]interface{}{
+			"key":      "SEC-42",
+			"actor":    "Carol",
+			"html_url": "https://acme.atlassian.net/browse/SEC-42",
+		}},
+		{ID: "evt-4", Source: "aws", Type: "log", Timestamp: ts, Content: "Console login"},
+	}}
+
+	// Act
+	enriched := ai.EnrichEvidence(bundle)
+
+	// Assert
+	require.Len(t, enriched.Events, 4)
+	pr := enriched.Events[0].Metadata
+	assert.Equal(t, "alice", pr[ai.MetadataAuthor])
+	assert.Equal(t, "acme/api", pr[ai.MetadataRepo])
+	assert.Equal(t, "https://github.com/acme/api/pull/7", pr[ai.MetadataURL])
+
+	commit := enriched.Events[1].Metadata
+	assert.Equal(t, "Bob Smith", commit[ai.MetadataAuthor])
+	assert.Equal(t, "acme/infra", commit[ai.MetadataRepo])
+
+	issue := enriched.Events[2].Metadata
+	assert.Equal(t, "Carol", issue[ai.MetadataAuthor])
+	assert.Equal(t, "SEC", issue[ai.MetadataProject])
+	assert.Equal(t, "https://acme.atlassian.net/browse/SEC-42", issue[ai.MetadataURL])
+
+	assert.Nil(t, enriched.Events[3].Metadata, "events without metadata get none")
+	assert.NotContains(t, bundle.Events[0].Metadata, ai.MetadataAuthor, "the input bundle should not be modified")
+}
+
+func TestEnrichEvidence_KeepsExistingValues(t *testing.T) {
+	// Arrange
+	bundle := types.EvidenceBundle{Events: []types.EvidenceEvent{
+		{ID: "github-1", Source: "github", Type: "pr", Content: "Require MFA", Metadata: map[string]interface{}{
+			"html_url": "https://github.com/acme/api/pull/7",
+			"author":   "alice@example.com",
+			"repo":     "acme/api-mirror",
+		}},
+	}}
+
+	// Act
+	enriched := ai.EnrichEvidence(bundle)
+
+	// Assert
+	assert.Equal(t, "alice@example.com", enriched.Events[0].Metadata[ai.MetadataAuthor])
+	assert.Equal(t, "acme/api-mirror", enriched.Events[0].Metadata[ai.MetadataRepo])
+}
+
+func TestAnalyze_PromptIncludesMetadata(t *testing.T) {
+	// Arrange
+	cfg := &types.Config{
+		AI: types.AIConfig{
+			Enabled:  true,
+			Provider: "mock",
+			Mode:     types.AIModeContext,
+			CacheDir: t.TempDir(),
+		},
+	}
+	provider := ai.NewMockProvider()
+	engine := ai.NewEngine(cfg, provider)
+
+	preamble, err := types.NewContextPreamble("SOC2", "2017", "CC6.1", "Logical access security software, infrastructure and architectures are implemented", nil)
+	require.NoError(t, err)
+	evidence := types.EvidenceBundle{Events: []types.EvidenceEvent{
+		{ID: "github-1", Source: "github", Type: "pr", Timestamp: time.Date(2025, 1, 15, 10, 0, 0, 0, time.UTC), Content: "Require MFA for admin logins", Metadata: map[string]interface{}{
+			"html_url": "https://github.com/acme/api/pull/7",
+			"actor":    "alice",
+			"state":    "closed",
+			"raw":      `{"id": 1}`,
+		}},
+	}}
+
+	// Act
+	_, err = engine.Analyze(context.Background(), *preamble, evidence)
+	require.NoError(t, err)
+
+	// Assert
+	prompt := provider.GetLastPrompt()
+	assert.Contains(t, prompt, "1. [github/pr] Require MFA for admin logins\n   Metadata: author=alice, repo=acme/api, state=closed, url=https://github.com/acme/api/pull/7\n")
+	assert.NotContains(t, prompt, `{"id": 1}`, "raw payloads should not be rendered")
+}
+
+func TestAnalyze_PromptMetadataIsRedacted(t *testing.T) {
+	// Arrange
+	cfg := &types.Config{
+		AI: types.AIConfig{
+			Enabled:   true,
+			Provider:  "mock",
+			Mode:      types.AIModeContext,
+			CacheDir:  t.TempDir(),
+			Redaction: types.RedactionConfig{Enabled: true},
+		},
+	}
+	provider := ai.NewMockProvider()
+	engine := ai.NewEngine(cfg, provider)
+
+	preamble, err := types.NewContextPreamble("SOC2", "2017", "CC6.1", "Logical access security software, infrastructure and architectures are implemented", nil)
+	require.NoError(t, err)
+	evidence := types.EvidenceBundle{Events: []types.EvidenceEvent{
+		{ID: "jira-SEC-42", Source: "jira", Type: "issue", Timestamp: time.Date(2025, 1, 15, 10, 0, 0, 0, time.UTC), Content: "Quarterly access review", Metadata: map[string]interface{}{
+			"key":   "SEC-42",
+			"actor": "carol@example.com",
+		}},
+	}}
+
+	// Act
+	finding, err := engine.Analyze(context.Background(), *preamble, evidence)
+	require.NoError(t, err)
+
+	// Assert
+	prompt := provider.GetLastPrompt()
+	assert.NotContains(t, prompt, "carol@example.com")
+	assert.Contains(t, prompt, "project=SEC")
+	require.NotNil(t, finding.Redactions)
+	assert.Equal(t, 1, finding.Redactions.ByCategory["email"])
+}
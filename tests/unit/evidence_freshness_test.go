@@ -0,0 +1,129 @@
+package unit
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/pickjonathan/sdek-cli/internal/ai"
+	"github.com/pickjonathan/sdek-cli/pkg/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newFreshnessEngine(t *testing.T, maxAgeDays int, response string) (ai.Engine, types.ContextPreamble) {
+	t.Helper()
+	cfg := &types.Config{
+		AI: types.AIConfig{
+			Enabled:            true,
+			Provider:           "mock",
+			Mode:               types.AIModeContext,
+			CacheDir:           t.TempDir(),
+			EvidenceMaxAgeDays: maxAgeDays,
+		},
+	}
+	provider := ai.NewMockProvider()
+	provider.SetResponse(response)
+
+	preamble, err := types.NewContextPreamble("SOC2", "2017", "CC6.1", "Logical access security software, infrastructure and architectures are implemented", nil)
+	require.NoError(t, err)
+	return ai.NewEngine(cfg, provider), *preamble
+}
+
+func daysAgo(days int) time.Time {
+	return time.Now().Add(-time.Duration(days) * 24 * time.Hour)
+}
+
+func TestAnalyze_AllOldEvidenceIsStale(t *testing.T) {
+	// Arrange
+	engine, preamble := newFreshnessEngine(t, 90,
+		`{"summary": "Access reviews performed", "confidence_score": 0.8, "residual_risk": "low", "citations": ["evt-1", "evt-2"]}`)
+	evidence := types.EvidenceBundle{Events: []types.EvidenceEvent{
+		{ID: "evt-1", Source: "github", Type: "commit", Timestamp: daysAgo(200), Content: "Access review completed"},
+		{ID: "evt-2", Source: "jira", Type: "ticket", Timestamp: daysAgo(120), Content: "Access review ticket closed"},
+	}}
+
+	// Act
+	finding, err := engine.Analyze(context.Background(), preamble, evidence)
+
+	// Assert
+	require.NoError(t, err)
+	assert.True(t, finding.StaleEvidence)
+	assert.Equal(t, "low", finding.ResidualRisk)
+	assert.Equal(t, "newest cited event is 120 days old (limit 90)", finding.StaleEvidenceNote)
+	assert.Equal(t, types.SeverityLow, finding.Severity)
+}
+
+func TestAnalyze_MixedAgeEvidenceIsFresh(t *testing.T) {
+	// Arrange
+	engine, preamble := newFreshnessEngine(t, 90,
+		`{"summary": "Access reviews performed", "confidence_score": 0.8, "residual_risk": "low", "citations": ["evt-1", "evt-2"]}`)
+	evidence := types.EvidenceBundle{Events: []types.EvidenceEvent{
+		{ID: "evt-1", Source: "github", Type: "commit", Timestamp: daysAgo(200), Content: "Access review completed"},
+		{ID: "evt-2", Source: "jira", Type: "ticket", Timestamp: daysAgo(10), Content: "Access review ticket closed"},
+	}}
+
+	// Act
+	finding, err := engine.Analyze(context.Background(), preamble, evidence)
+
+	// Assert
+	require.NoError(t, err)
+	assert.False(t, finding.StaleEvidence)
+	assert.Equal(t, "low", finding.ResidualRisk)
+}
+
+func TestAnalyze_FreshnessUsesOnlyCitedEvents(t *testing.T) {
+	// Arrange
+	engine, preamble := newFreshnessEngine(t, 90,
+		`{"summary": "Access reviews performed", "confidence_score": 0.8, "residual_risk": "medium", "citations": ["evt-1"]}`)
+	evidence := types.EvidenceBundle{Events: []types.EvidenceEvent{
+		{ID: "evt-1", Source: "github", Type: "commit", Timestamp: daysAgo(365), Content: "Access review completed"},
+		{ID: "evt-2", Source: "jira", Type: "ticket", Timestamp: daysAgo(1), Content: "Unrelated ticket"},
+	}}
+
+	// Act
+	finding, err := engine.Analyze(context.Background(), preamble, evidence)
+
+	// Assert
+	require.NoError(t, err)
+	assert.True(t, finding.StaleEvidence, "the recent event is not cited")
+	assert.Contains(t, finding.StaleEvidenceNote, "365 days old")
+}
+
+func TestAnalyze_FreshnessDisabledByDefault(t *testing.T) {
+	// Arrange
+	engine, preamble := newFreshnessEngine(t, 0,
+		`{"summary": "Access reviews performed", "confidence_score": 0.8, "residual_risk": "low", "citations": ["evt-1"]}`)
+	evidence := types.EvidenceBundle{Events: []types.EvidenceEvent{
+		{ID: "evt-1", Source: "github", Type: "commit", Timestamp: daysAgo(1000), Content: "Access review completed"},
+	}}
+
+	// Act
+	finding, err := engine.Analyze(context.Background(), preamble, evidence)
+
+	// Assert
+	require.NoError(t, err)
+	assert.False(t, finding.StaleEvidence)
+	assert.Equal(t, "low", finding.ResidualRisk)
+}
+
+func TestAnalyze_StaleEvidenceFlaggedOnCacheHit(t *testing.T) {
+	// Arrange
+	engine, preamble := newFreshnessEngine(t, 30,
+		`{"summary": "Access reviews performed", "confidence_score": 0.8, "residual_risk": "high", "citations": ["evt-1"]}`)
+	evidence := types.EvidenceBundle{Events: []types.EvidenceEvent{
+		{ID: "evt-1", Source: "github", Type: "commit", Timestamp: daysAgo(60), Content: "Access review completed"},
+	}}
+	_, err := engine.Analyze(context.Background(), preamble, evidence)
+	require.NoError(t, err)
+
+	// Act
+	finding, err := engine.Analyze(context.Background(), preamble, evidence)
+
+	// Assert
+	require.NoError(t, err)
+	assert.True(t, finding.StaleEvidence)
+	assert.Equal(t, "high", finding.ResidualRisk)
+	assert.Equal(t, "newest cited event is 60 days old (limit 30)", finding.StaleEvidenceNote)
+	assert.Equal(t, types.SeverityHigh, finding.Severity, "the stale note should not be cached with the risk level")
+}
@@ -0,0 +1,114 @@
+package unit
+
+import (
+	"testing"
+	"time"
+
+	"github.com/pickjonathan/sdek-cli/internal/ai"
+	"github.com/pickjonathan/sdek-cli/pkg/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNormalizeEvidence_DefaultsEmptyType(t *testing.T) {
+	// Arrange
+	ts := time.Date(2025, 1, 15, 10, 0, 0, 0, time.UTC)
+	bundle := types.EvidenceBundle{Events: []types.EvidenceEvent{
+		{ID: "evt-1", Source: "github", Type: "", Timestamp: ts, Content: "Enable MFA"},
+		{ID: "evt-2", Source: "jira", Type: "  ", Timestamp: ts, Content: "Access review"},
+		{ID: "evt-3", Source: "github", Type: "commit", Timestamp: ts, Content: "Rotate keys"},
+	}}
+
+	// Act
+	normalized, stats := ai.NormalizeEvidence(bundle, ai.NormalizeOptions{})
+
+	// Assert
+	require.Len(t, normalized.Events, 3)
+	assert.Equal(t, ai.UnknownEventType, normalized.Events[0].Type)
+	assert.Equal(t, ai.UnknownEventType, normalized.Events[1].Type)
+	assert.Equal(t, "commit", normalized.Events[2].Type)
+	assert.Equal(t, 2, stats.DefaultedTypes)
+}
+
+func TestNormalizeEvidence_DefaultsZeroTimestampToLoadTime(t *testing.T) {
+	// Arrange
+	loadTime := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+	ts := time.Date(2025, 1, 15, 10, 0, 0, 0, time.UTC)
+	bundle := types.EvidenceBundle{Events: []types.EvidenceEvent{
+		{ID: "evt-1", Type: "commit", Content: "Enable MFA"},
+		{ID: "evt-2", Type: "ticket", Timestamp: ts, Content: "Access review"},
+	}}
+
+	// Act
+	normalized, stats := ai.NormalizeEvidence(bundle, ai.NormalizeOptions{LoadTime: loadTime})
+
+	// Assert
+	require.Len(t, normalized.Events, 2)
+	assert.Equal(t, loadTime, normalized.Events[0].Timestamp)
+	assert.Equal(t, ts, normalized.Events[1].Timestamp)
+	assert.Equal(t, 1, stats.DefaultedTimestamps)
+	assert.Zero(t, stats.DroppedEvents)
+}
+
+func TestNormalizeEvidence_DropsUntimestampedEvents(t *testing.T) {
+	// Arrange
+	ts := time.Date(2025, 1, 15, 10, 0, 0, 0, time.UTC)
+	bundle := types.EvidenceBundle{Events: []types.EvidenceEvent{
+		{ID: "evt-1", Type: "commit", Content: "Enable MFA"},
+		{ID: "evt-2", Type: "ticket", Timestamp: ts, Content: "Access review"},
+	}}
+
+	// Act
+	normalized, stats := ai.NormalizeEvidence(bundle, ai.NormalizeOptions{
+		LoadTime:          time.Now(),
+		DropUntimestamped: true,
+	})
+
+	// Assert
+	require.Len(t, normalized.Events, 1)
+	assert.Equal(t, "evt-2", normalized.Events[0].ID)
+	assert.Equal(t, 1, stats.DroppedEvents)
+	assert.Zero(t, stats.DefaultedTimestamps)
+}
+
+func TestNormalizeEvidence_TrimsContent(t *testing.T) {
+	// Arrange
+	ts := time.Date(2025, 1, 15, 10, 0, 0, 0, time.UTC)
+	bundle := types.EvidenceBundle{Events: []types.EvidenceEvent{
+		{ID: "evt-1", Type: "commit", Timestamp: ts, Content: "\n  Enable MFA on admin login \t\n"},
+		{ID: "evt-2", Type: "ticket", Timestamp: ts, Content: "Access review"},
+	}}
+
+	// Act
+	normalized, stats := ai.NormalizeEvidence(bundle, ai.NormalizeOptions{})
+
+	// Assert
+	require.Len(t, normalized.Events, 2)
+	assert.Equal(t, "Enable MFA on admin login", normalized.Events[0].Content)
+	assert.Equal(t, "Access review", normalized.Events[1].Content)
+	assert.Equal(t, 1, stats.TrimmedContent)
+}
+
+func TestNormalizeEvidence_LeavesCompleteEventsAndInputUntouched(t *testing.T) {
+	// Arrange
+	bundle := types.EvidenceBundle{Events: []types.EvidenceEvent{
+		{ID: "evt-1", Source: "github", Type: "", Content: " Enable MFA "},
+	}}
+
+	// Act
+	normalized, stats := ai.NormalizeEvidence(bundle, ai.NormalizeOptions{LoadTime: time.Now()})
+
+	// Assert
+	assert.True(t, stats.Adjusted())
+	assert.Equal(t, "", bundle.Events[0].Type, "input bundle must not be modified")
+	assert.Equal(t, " Enable MFA ", bundle.Events[0].Content)
+	assert.True(t, bundle.Events[0].Timestamp.IsZero())
+	assert.False(t, normalized.Events[0].Timestamp.IsZero())
+
+	complete := types.EvidenceBundle{Events: []types.EvidenceEvent{
+		{ID: "evt-2", Type: "commit", Timestamp: time.Now(), Content: "Rotate keys"},
+	}}
+	unchanged, completeStats := ai.NormalizeEvidence(complete, ai.NormalizeOptions{})
+	assert.False(t, completeStats.Adjusted())
+	assert.Equal(t, complete.Events, unchanged.Events)
+}
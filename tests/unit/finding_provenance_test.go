@@ -0,0 +1,67 @@
+package unit
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/pickjonathan/sdek-cli/internal/ai"
+	"github.com/pickjonathan/sdek-cli/pkg/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAnalyze_PopulatesProvenancePerSource(t *testing.T) {
+	// Arrange
+	cfg := &types.Config{
+		AI: types.AIConfig{
+			Enabled:  true,
+			Provider: "mock",
+			Mode:     types.AIModeContext,
+			CacheDir: t.TempDir(),
+		},
+	}
+	engine := ai.NewEngine(cfg, ai.NewMockProvider())
+
+	preamble, err := types.NewContextPreamble("SOC2", "2017", "CC6.1", "Logical access security software, infrastructure and architectures are implemented", nil)
+	require.NoError(t, err)
+	ts := time.Date(2025, 1, 15, 10, 0, 0, 0, time.UTC)
+	evidence := types.EvidenceBundle{Events: []types.EvidenceEvent{
+		{ID: "evt-1", Source: "github", Type: "commit", Timestamp: ts, Content: "Enforce MFA on admin login"},
+		{ID: "evt-2", Source: "jira", Type: "ticket", Timestamp: ts, Content: "Quarterly access review completed"},
+		{ID: "evt-3", Source: "github", Type: "pr", Timestamp: ts, Content: "Remove stale deploy keys"},
+		{ID: "evt-4", Source: "aws", Type: "log", Timestamp: ts, Content: "IAM policy updated"},
+		{ID: "evt-5", Source: "jira", Type: "ticket", Timestamp: ts, Content: "Offboarding checklist done"},
+		{ID: "evt-6", Source: "github", Type: "commit", Timestamp: ts, Content: "Require signed commits"},
+	}}
+	want := []types.ProvenanceEntry{
+		{Source: "github", EventsUsed: 3},
+		{Source: "jira", EventsUsed: 2},
+		{Source: "aws", EventsUsed: 1},
+	}
+
+	// Act
+	finding, err := engine.Analyze(context.Background(), *preamble, evidence)
+	require.NoError(t, err)
+	cached, err := engine.Analyze(context.Background(), *preamble, evidence)
+	require.NoError(t, err)
+
+	// Assert
+	assert.Equal(t, want, finding.Provenance)
+	assert.True(t, cached.CacheHit)
+	assert.Equal(t, want, cached.Provenance, "cache hits should report the same sources")
+}
+
+func TestEvidenceBundle_ProvenanceCountsMissingSource(t *testing.T) {
+	// Arrange
+	bundle := types.EvidenceBundle{Events: []types.EvidenceEvent{{ID: "evt-1"}, {ID: "evt-2", Source: "slack"}}}
+
+	// Act
+	provenance := bundle.Provenance()
+
+	// Assert
+	assert.Equal(t, []types.ProvenanceEntry{
+		{Source: "slack", EventsUsed: 1},
+		{Source: "unknown", EventsUsed: 1},
+	}, provenance)
+}
@@ -0,0 +1,344 @@
+package unit
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/pickjonathan/sdek-cli/internal/ai"
+	"github.com/pickjonathan/sdek-cli/pkg/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type incrementalControl struct {
+	preamble types.ContextPreamble
+	evidence types.EvidenceBundle
+}
+
+func newIncrementalControls(t *testing.T) []incrementalControl {
+	t.Helper()
+	excerpt := "Logical access security software, infrastructure and architectures are implemented"
+	ts := time.Date(2025, 1, 15, 10, 0, 0, 0, time.UTC)
+
+	var controls []incrementalControl
+	for _, c := range []struct{ section, content string }{
+		{"CC6.1", "Enforce MFA on admin login"},
+		{"CC6.2", "Quarterly access review completed"},
+		{"CC7.2", "Alerting enabled for failed logins"},
+	} {
+		preamble, err := types.NewContextPreamble("SOC2", "2017", c.section, excerpt, nil)
+		require.NoError(t, err)
+		controls = append(controls, incrementalControl{
+			preamble: *preamble,
+			evidence: types.EvidenceBundle{Events: []types.EvidenceEvent{
+				{ID: "evt-" + c.section, Source: "github", Type: "commit", Timestamp: ts, Content: c.content},
+			}},
+		})
+	}
+	return controls
+}
+
+func runIncremental(t *testing.T, statePath string, engine ai.Engine, controls []incrementalControl) *ai.IncrementalState {
+	t.Helper()
+	state, err := ai.LoadIncrementalState(statePath)
+	require.NoError(t, err)
+	for _, c := range controls {
+		_, _, err := state.Analyze(context.Background(), engine, c.preamble, c.evidence)
+		require.NoError(t, err)
+	}
+	require.NoError(t, state.Save())
+	return state
+}
+
+func newIncrementalEngine() (ai.Engine, *ai.MockProvider) {
+	cfg := &types.Config{
+		AI: types.AIConfig{
+			Enabled:  true,
+			Provider: "mock",
+			Mode:     types.AIModeContext,
+		},
+	}
+	provider := ai.NewMockProvider()
+	return ai.NewEngine(cfg, provider), provider
+}
+
+func TestIncrementalState_UnchangedEvidenceSkipsProvider(t *testing.T) {
+	// Arrange
+	statePath := filepath.Join(t.TempDir(), "incremental.json")
+	controls := newIncrementalControls(t)
+	engine, provider := newIncrementalEngine()
+	runIncremental(t, statePath, engine, controls)
+	require.Equal(t, 3, provider.GetCallCount())
+
+	// Act
+	secondEngine, secondProvider := newIncrementalEngine()
+	state := runIncremental(t, statePath, secondEngine, controls)
+
+	// Assert
+	assert.Zero(t, secondProvider.GetCallCount())
+	unchanged, reanalyzed := state.Counts()
+	assert.Equal(t, 3, unchanged)
+	assert.Zero(t, reanalyzed)
+	assert.Equal(t, "3 controls unchanged, 0 re-analyzed", state.Summary())
+}
+
+func TestIncrementalState_ChangedEventReanalyzesOnlyThatControl(t *testing.T) {
+	// Arrange
+	statePath := filepath.Join(t.TempDir(), "incremental.json")
+	controls := newIncrementalControls(t)
+	engine, _ := newIncrementalEngine()
+	runIncremental(t, statePath, engine, controls)
+
+	controls[1].evidence.Events[0].Content = "Quarterly access review skipped"
+
+	// Act
+	secondEngine, secondProvider := newIncrementalEngine()
+	state, err := ai.LoadIncrementalState(statePath)
+	require.NoError(t, err)
+	var reused []bool
+	for _, c := range controls {
+		finding, wasReused, err := state.Analyze(context.Background(), secondEngine, c.preamble, c.evidence)
+		require.NoError(t, err)
+		require.NotNil(t, finding)
+		reused = append(reused, wasReused)
+	}
+
+	// Assert
+	assert.Equal(t, 1, secondProvider.GetCallCount())
+	assert.Equal(t, []bool{true, false, true}, reused)
+	assert.Equal(t, "2 controls unchanged, 1 re-analyzed", state.Summary())
+	assert.Contains(t, secondProvider.GetLastPrompt(), "CC6.2")
+}
+
+func TestIncrementalState_ReusedFindingMatchesRecorded(t *testing.T) {
+	// Arrange
+	statePath := filepath.Join(t.TempDir(), "incremental.json")
+	controls := newIncrementalControls(t)[:1]
+	engine, _ := newIncrementalEngine()
+	state := runIncremental(t, statePath, engine, controls)
+	recorded := state.Controls["soc2/CC6.1"].Finding
+	require.NotNil(t, recorded)
+
+	// Act
+	reloaded, err := ai.LoadIncrementalState(statePath)
+	require.NoError(t, err)
+	finding, reused, err := reloaded.Analyze(context.Background(), engine, controls[0].preamble, controls[0].evidence)
+
+	// Assert
+	require.NoError(t, err)
+	assert.True(t, reused)
+	assert.Equal(t, recorded.ID, finding.ID)
+	assert.Equal(t, recorded.ConfidenceScore, finding.ConfidenceScore)
+}
+
+func TestLoadIncrementalState_MissingFileIsEmpty(t *testing.T) {
+	// Act
+	state, err := ai.LoadIncrementalState(filepath.Join(t.TempDir(), "missing.json"))
+
+	// Assert
+	require.NoError(t, err)
+	assert.Empty(t, state.Controls)
+}
+
+func TestIncrementalState_AnalyzeNewMergesWithRecordedFinding(t *testing.T) {
+	// Arrange
+	statePath := filepath.Join(t.TempDir(), "incremental.json")
+	controls := newIncrementalControls(t)[:1]
+	engine, provider := newIncrementalEngine()
+	provider.SetResponse(`{"summary": "MFA enforced", "confidence_score": 0.9, "residual_risk": "low", "citations": ["evt-CC6.1"]}`)
+	runIncremental(t, statePath, engine, controls)
+
+	newEvidence := types.EvidenceBundle{Events: []types.EvidenceEvent{
+		{ID: "evt-new", Source: "jira", Type: "ticket", Timestamp: time.Now(), Content: "MFA exception granted for service account"},
+	}}
+	secondEngine, secondProvider := newIncrementalEngine()
+	secondProvider.SetResponse(`{"summary": "MFA exception granted", "confidence_score": 0.5, "residual_risk": "high", "citations": ["evt-new"]}`)
+
+	// Act
+	state, err := ai.LoadIncrementalState(statePath)
+	require.NoError(t, err)
+	finding, reused, err := state.AnalyzeNew(context.Background(), secondEngine, controls[0].preamble, newEvidence)
+
+	// Assert
+	require.NoError(t, err)
+	assert.False(t, reused)
+	assert.Equal(t, 1, secondProvider.GetCallCount())
+	assert.NotContains(t, secondProvider.GetLastPrompt(), "evt-CC6.1", "previously analyzed evidence must not be resent")
+	assert.Equal(t, []string{"evt-CC6.1", "evt-new"}, finding.Citations)
+	assert.InDelta(t, 0.7, finding.ConfidenceScore, 0.001)
+	assert.Equal(t, "high", finding.ResidualRisk)
+	assert.Equal(t, 2, state.Controls["soc2/CC6.1"].EventCount)
+}
+
+func TestIncrementalState_AnalyzeNewWithoutNewEvidenceReuses(t *testing.T) {
+	// Arrange
+	statePath := filepath.Join(t.TempDir(), "incremental.json")
+	controls := newIncrementalControls(t)[:1]
+	engine, _ := newIncrementalEngine()
+	recorded := runIncremental(t, statePath, engine, controls).Controls["soc2/CC6.1"].Finding
+	secondEngine, secondProvider := newIncrementalEngine()
+
+	// Act
+	state, err := ai.LoadIncrementalState(statePath)
+	require.NoError(t, err)
+	finding, reused, err := state.AnalyzeNew(context.Background(), secondEngine, controls[0].preamble, types.EvidenceBundle{})
+
+	// Assert
+	require.NoError(t, err)
+	assert.True(t, reused)
+	assert.Zero(t, secondProvider.GetCallCount())
+	assert.Equal(t, recorded.ID, finding.ID)
+}
+
+func TestIncrementalState_LastRunRoundTrips(t *testing.T) {
+	// Arrange
+	statePath := filepath.Join(t.TempDir(), "incremental.json")
+	state, err := ai.LoadIncrementalState(statePath)
+	require.NoError(t, err)
+	lastRun := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+	state.LastRun = lastRun
+
+	// Act
+	require.NoError(t, state.Save())
+	reloaded, err := ai.LoadIncrementalState(statePath)
+
+	// Assert
+	require.NoError(t, err)
+	assert.True(t, reloaded.LastRun.Equal(lastRun))
+}
+
+func TestIncrementalState_AnalyzeNewSkipsEventsAlreadyAnalyzed(t *testing.T) {
+	// Arrange: a file touched since the last run still holds the analyzed event
+	statePath := filepath.Join(t.TempDir(), "incremental.json")
+	controls := newIncrementalControls(t)[:1]
+	engine, _ := newIncrementalEngine()
+	recorded := runIncremental(t, statePath, engine, controls).Controls["soc2/CC6.1"].Finding
+	reloaded := types.EvidenceBundle{Events: []types.EvidenceEvent{
+		controls[0].evidence.Events[0],
+		{ID: "evt-new", Source: "jira", Type: "ticket", Timestamp: time.Now(), Content: "MFA exception granted for service account"},
+	}}
+
+	// Act
+	state, err := ai.LoadIncrementalState(statePath)
+	require.NoError(t, err)
+	unchangedEngine, unchangedProvider := newIncrementalEngine()
+	finding, reused, err := state.AnalyzeNew(context.Background(), unchangedEngine, controls[0].preamble, controls[0].evidence)
+	require.NoError(t, err)
+	newEngine, newProvider := newIncrementalEngine()
+	_, newReused, err := state.AnalyzeNew(context.Background(), newEngine, controls[0].preamble, reloaded)
+	require.NoError(t, err)
+
+	// Assert
+	assert.True(t, reused, "evidence already analyzed should reuse the recorded finding")
+	assert.Zero(t, unchangedProvider.GetCallCount())
+	assert.Equal(t, recorded.ID, finding.ID)
+	assert.False(t, newReused)
+	assert.Equal(t, 1, newProvider.GetCallCount())
+	assert.NotContains(t, newProvider.GetLastPrompt(), "Enforce MFA on admin login", "the analyzed event must not be resent")
+	assert.Equal(t, 2, state.Controls["soc2/CC6.1"].EventCount)
+}
+
+func TestIncrementalState_ChangedUncitedEventReanalyzes(t *testing.T) {
+	tests := []struct {
+		name string
+		edit func(evidence *types.EvidenceBundle)
+	}{
+		{name: "changed", edit: func(evidence *types.EvidenceBundle) { evidence.Events[1].Content = "Password policy relaxed" }},
+		{name: "removed", edit: func(evidence *types.EvidenceBundle) { evidence.Events = evidence.Events[:1] }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange: the finding cites only the first of two events
+			statePath := filepath.Join(t.TempDir(), "incremental.json")
+			control := newIncrementalControls(t)[0]
+			control.evidence.Events = append(control.evidence.Events, types.EvidenceEvent{
+				ID: "evt-policy", Source: "jira", Type: "ticket", Timestamp: time.Now(), Content: "Password policy updated",
+			})
+			engine, provider := newIncrementalEngine()
+			provider.SetResponse(`{"summary": "MFA enforced", "confidence_score": 0.9, "residual_risk": "low", "citations": ["evt-CC6.1"]}`)
+			runIncremental(t, statePath, engine, []incrementalControl{control})
+			tt.edit(&control.evidence)
+
+			// Act
+			secondEngine, secondProvider := newIncrementalEngine()
+			state, err := ai.LoadIncrementalState(statePath)
+			require.NoError(t, err)
+			_, reused, err := state.Analyze(context.Background(), secondEngine, control.preamble, control.evidence)
+
+			// Assert
+			require.NoError(t, err)
+			assert.False(t, reused)
+			assert.Equal(t, 1, secondProvider.GetCallCount())
+		})
+	}
+}
+
+func TestIncrementalState_ChangedModelReanalyzes(t *testing.T) {
+	// Arrange
+	statePath := filepath.Join(t.TempDir(), "incremental.json")
+	controls := newIncrementalControls(t)[:1]
+	engine, _ := newIncrementalEngine()
+	runIncremental(t, statePath, engine, controls)
+
+	cfg := &types.Config{
+		AI: types.AIConfig{Enabled: true, Provider: "mock", Model: "model-b", Mode: types.AIModeContext},
+	}
+	provider := ai.NewMockProvider()
+	otherModel := ai.NewEngine(cfg, provider)
+
+	// Act
+	state, err := ai.LoadIncrementalState(statePath)
+	require.NoError(t, err)
+	_, reused, err := state.Analyze(context.Background(), otherModel, controls[0].preamble, controls[0].evidence)
+
+	// Assert
+	require.NoError(t, err)
+	assert.False(t, reused, "a finding from another model must not be reused")
+	assert.Equal(t, 1, provider.GetCallCount())
+}
+
+func TestIncrementalState_AnalyzeNewReanalyzesChangedContext(t *testing.T) {
+	// Arrange
+	statePath := filepath.Join(t.TempDir(), "incremental.json")
+	controls := newIncrementalControls(t)[:1]
+	engine, _ := newIncrementalEngine()
+	runIncremental(t, statePath, engine, controls)
+
+	changed := controls[0].preamble
+	changed.Excerpt = "Logical access to production systems requires multi-factor authentication"
+
+	// Act
+	state, err := ai.LoadIncrementalState(statePath)
+	require.NoError(t, err)
+	secondEngine, secondProvider := newIncrementalEngine()
+	needsFull := state.NeedsFullEvidence(secondEngine, changed)
+	_, reused, err := state.AnalyzeNew(context.Background(), secondEngine, changed, controls[0].evidence)
+
+	// Assert
+	require.NoError(t, err)
+	assert.True(t, needsFull)
+	assert.False(t, reused, "a changed policy excerpt must not reuse the recorded finding")
+	assert.Equal(t, 1, secondProvider.GetCallCount())
+	assert.Contains(t, secondProvider.GetLastPrompt(), "Enforce MFA on admin login", "a changed context re-analyzes all of the evidence given")
+	assert.Equal(t, 1, state.Controls["soc2/CC6.1"].EventCount, "the recorded finding is replaced, not merged")
+	assert.False(t, state.NeedsFullEvidence(secondEngine, changed))
+}
+
+func TestIncrementalState_NeedsFullEvidence(t *testing.T) {
+	// Arrange
+	statePath := filepath.Join(t.TempDir(), "incremental.json")
+	controls := newIncrementalControls(t)
+	engine, _ := newIncrementalEngine()
+	state := runIncremental(t, statePath, engine, controls[:1])
+	otherModel := ai.NewEngine(&types.Config{
+		AI: types.AIConfig{Enabled: true, Provider: "mock", Model: "model-b", Mode: types.AIModeContext},
+	}, ai.NewMockProvider())
+
+	// Act & Assert
+	assert.False(t, state.NeedsFullEvidence(engine, controls[0].preamble), "an unchanged control only needs new evidence")
+	assert.True(t, state.NeedsFullEvidence(engine, controls[1].preamble), "a control without a recorded finding needs all evidence")
+	assert.True(t, state.NeedsFullEvidence(otherModel, controls[0].preamble), "a different model re-analyzes from scratch")
+}
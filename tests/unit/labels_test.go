@@ -0,0 +1,55 @@
+package unit
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/pickjonathan/sdek-cli/internal/ai"
+	"github.com/pickjonathan/sdek-cli/pkg/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAnalyze_CarriesEvidenceLabels(t *testing.T) {
+	// Arrange
+	cfg := &types.Config{
+		AI: types.AIConfig{Enabled: true, Provider: "mock", Mode: types.AIModeContext, CacheDir: t.TempDir()},
+	}
+	provider := ai.NewMockProvider()
+	provider.SetResponse(`{"summary": "Access reviews performed", "confidence_score": 0.8, "residual_risk": "low", "citations": ["evt-1"]}`)
+	engine := ai.NewEngine(cfg, provider)
+
+	preamble, err := types.NewContextPreamble("SOC2", "2017", "CC6.1", "Logical access security software, infrastructure and architectures are implemented", nil)
+	require.NoError(t, err)
+	evidence := types.EvidenceBundle{Events: []types.EvidenceEvent{
+		{ID: "evt-1", Source: "github", Type: "commit", Timestamp: time.Now(), Content: "Access review completed", Labels: map[string]string{"env": "prod", "team": "platform"}},
+		{ID: "evt-2", Source: "jira", Type: "ticket", Timestamp: time.Now(), Content: "Access review ticket", Labels: map[string]string{"env": "staging"}},
+	}}
+
+	// Act
+	finding, err := engine.Analyze(context.Background(), *preamble, evidence)
+	require.NoError(t, err)
+	cached, err := engine.Analyze(context.Background(), *preamble, evidence)
+	require.NoError(t, err)
+
+	// Assert
+	want := []string{"env=prod", "env=staging", "team=platform"}
+	assert.Equal(t, want, finding.Labels)
+	assert.True(t, cached.CacheHit)
+	assert.Equal(t, want, cached.Labels)
+}
+
+func TestMergeChunkFindings_UnionsLabels(t *testing.T) {
+	// Arrange
+	chunks := []ai.ChunkFinding{
+		{Finding: &types.Finding{ConfidenceScore: 0.8, Labels: []string{"env=prod", "team=platform"}}, Events: 1},
+		{Finding: &types.Finding{ConfidenceScore: 0.6, Labels: []string{"env=dev", "team=platform"}}, Events: 1},
+	}
+
+	// Act
+	merged := ai.MergeChunkFindings(chunks)
+
+	// Assert
+	assert.Equal(t, []string{"env=dev", "env=prod", "team=platform"}, merged.Labels)
+}
@@ -0,0 +1,62 @@
+package unit
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/pickjonathan/sdek-cli/internal/ai"
+	"github.com/pickjonathan/sdek-cli/pkg/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newPromptSizeEngine(t *testing.T, maxPromptChars int) (ai.Engine, *ai.MockProvider, types.ContextPreamble, types.EvidenceBundle) {
+	t.Helper()
+
+	cfg := &types.Config{
+		AI: types.AIConfig{
+			Enabled:        true,
+			Provider:       "mock",
+			Mode:           types.AIModeContext,
+			CacheDir:       t.TempDir(),
+			MaxPromptChars: maxPromptChars,
+		},
+	}
+	provider := ai.NewMockProvider()
+
+	preamble, err := types.NewContextPreamble("SOC2", "2017", "CC6.1", "Logical access security software, infrastructure and architectures are implemented", nil)
+	require.NoError(t, err)
+
+	evidence := types.EvidenceBundle{Events: []types.EvidenceEvent{
+		{ID: "evt-1", Source: "github", Type: "commit", Timestamp: time.Date(2025, 1, 15, 10, 0, 0, 0, time.UTC), Content: "Enable MFA on admin login"},
+	}}
+
+	return ai.NewEngine(cfg, provider), provider, *preamble, evidence
+}
+
+func TestAnalyze_MaxPromptCharsRejectsOversizedPrompt(t *testing.T) {
+	// Arrange
+	engine, provider, preamble, evidence := newPromptSizeEngine(t, 100)
+
+	// Act
+	finding, err := engine.Analyze(context.Background(), preamble, evidence)
+
+	// Assert
+	require.ErrorIs(t, err, ai.ErrPromptTooLarge)
+	assert.Nil(t, finding)
+	assert.Contains(t, err.Error(), "limit is 100 (ai.max_prompt_chars)")
+	assert.Equal(t, 0, provider.GetCallCount(), "oversized prompts should never reach the provider")
+}
+
+func TestAnalyze_MaxPromptCharsAllowsPromptWithinLimit(t *testing.T) {
+	// Arrange
+	engine, provider, preamble, evidence := newPromptSizeEngine(t, 1_000_000)
+
+	// Act
+	_, err := engine.Analyze(context.Background(), preamble, evidence)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, 1, provider.GetCallCount())
+}
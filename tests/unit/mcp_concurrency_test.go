@@ -0,0 +1,167 @@
+package unit
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/pickjonathan/sdek-cli/internal/ai"
+	"github.com/pickjonathan/sdek-cli/pkg/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newSlowMCPServer starts an HTTP MCP server with one "search" tool whose
+// calls take delay. maxInFlight returns the most calls it saw in flight at once.
+func newSlowMCPServer(t *testing.T, delay time.Duration) (server *httptest.Server, maxInFlight func() int) {
+	t.Helper()
+	var mu sync.Mutex
+	inFlight, peak, calls := 0, 0, 0
+
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			ID     interface{} `json:"id"`
+			Method string      `json:"method"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		var result interface{}
+		switch req.Method {
+		case "initialize":
+			result = map[string]interface{}{
+				"protocolVersion": "2024-11-05",
+				"serverInfo":      map[string]string{"name": "slow-mcp", "version": "1.0.0"},
+			}
+		case "tools/list":
+			result = map[string]interface{}{
+				"tools": []map[string]interface{}{{"name": "search", "description": "Search evidence"}},
+			}
+		case "tools/call":
+			mu.Lock()
+			calls++
+			call := calls
+			inFlight++
+			if inFlight > peak {
+				peak = inFlight
+			}
+			mu.Unlock()
+
+			time.Sleep(delay)
+
+			mu.Lock()
+			inFlight--
+			mu.Unlock()
+			result = map[string]interface{}{"content": fmt.Sprintf("audit log entry %d", call)}
+		default:
+			result = map[string]interface{}{}
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]interface{}{"jsonrpc": "2.0", "id": req.ID, "result": result})
+	}))
+	t.Cleanup(server.Close)
+
+	return server, func() int {
+		mu.Lock()
+		defer mu.Unlock()
+		return peak
+	}
+}
+
+func TestExecutePlan_MCPHTTPCallsBoundedByMaxConcurrent(t *testing.T) {
+	// Arrange
+	server, maxInFlight := newSlowMCPServer(t, 20*time.Millisecond)
+
+	cfg := &types.Config{
+		AI: types.AIConfig{
+			Enabled:     true,
+			Mode:        types.AIModeAutonomous,
+			Concurrency: types.ConcurrencyLimits{MaxAnalyses: 25},
+		},
+		MCP: types.MCPConfig{
+			Enabled:       true,
+			MaxConcurrent: 3,
+			Servers: map[string]types.MCPServerConfig{
+				"slow-mcp": {Transport: "http", URL: server.URL, Timeout: 10},
+			},
+		},
+	}
+	engine, err := ai.NewEngineWithMCP(context.Background(), cfg, ai.NewMockProvider())
+	require.NoError(t, err)
+
+	plan := &types.EvidencePlan{
+		ID:        "plan-mcp-concurrency",
+		Framework: "SOC2",
+		Section:   "CC7.2",
+		Status:    types.PlanApproved,
+	}
+	for i := 0; i < 20; i++ {
+		plan.Items = append(plan.Items, types.PlanItem{
+			Source:          "slow-mcp:search",
+			Query:           fmt.Sprintf("audit logs %d", i),
+			ApprovalStatus:  types.ApprovalApproved,
+			ExecutionStatus: types.ExecPending,
+		})
+	}
+
+	// Act
+	bundle, err := engine.ExecutePlan(context.Background(), plan)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Len(t, bundle.Events, 20, "every item should still be collected")
+	assert.LessOrEqual(t, maxInFlight(), 3, "in-flight MCP calls must not exceed mcp.max_concurrent")
+	assert.Greater(t, maxInFlight(), 1, "calls should run in parallel up to the limit")
+}
+
+func TestExecutePlan_MCPHTTPCallsBoundedByDefaultWhenMaxConcurrentUnset(t *testing.T) {
+	// Arrange
+	server, maxInFlight := newSlowMCPServer(t, 20*time.Millisecond)
+
+	cfg := &types.Config{
+		AI: types.AIConfig{
+			Enabled:     true,
+			Mode:        types.AIModeAutonomous,
+			Concurrency: types.ConcurrencyLimits{MaxAnalyses: 25},
+		},
+		MCP: types.MCPConfig{
+			Enabled: true,
+			Servers: map[string]types.MCPServerConfig{
+				"slow-mcp": {Transport: "http", URL: server.URL, Timeout: 10},
+			},
+		},
+	}
+	engine, err := ai.NewEngineWithMCP(context.Background(), cfg, ai.NewMockProvider())
+	require.NoError(t, err)
+
+	plan := &types.EvidencePlan{
+		ID:        "plan-mcp-default-concurrency",
+		Framework: "SOC2",
+		Section:   "CC7.2",
+		Status:    types.PlanApproved,
+	}
+	for i := 0; i < 25; i++ {
+		plan.Items = append(plan.Items, types.PlanItem{
+			Source:          "slow-mcp:search",
+			Query:           fmt.Sprintf("audit logs %d", i),
+			ApprovalStatus:  types.ApprovalApproved,
+			ExecutionStatus: types.ExecPending,
+		})
+	}
+
+	// Act
+	bundle, err := engine.ExecutePlan(context.Background(), plan)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Len(t, bundle.Events, 25)
+	assert.LessOrEqual(t, maxInFlight(), types.DefaultMCPConfig().MaxConcurrent, "an unset mcp.max_concurrent must fall back to the default limit")
+}
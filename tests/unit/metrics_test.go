@@ -0,0 +1,78 @@
+package unit
+
+import (
+	"bufio"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/pickjonathan/sdek-cli/internal/ai"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// scrapeMetric scrapes the metrics handler and returns the value of the sample
+// whose name and labels render exactly as series, or 0 if it is absent
+func scrapeMetric(t *testing.T, series string) float64 {
+	t.Helper()
+
+	recorder := httptest.NewRecorder()
+	ai.MetricsHandler().ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	require.Equal(t, http.StatusOK, recorder.Code)
+
+	scanner := bufio.NewScanner(recorder.Body)
+	for scanner.Scan() {
+		name, value, ok := strings.Cut(scanner.Text(), " ")
+		if ok && name == series {
+			v, err := strconv.ParseFloat(value, 64)
+			require.NoError(t, err)
+			return v
+		}
+	}
+	return 0
+}
+
+func TestMetrics_AnalysisIncrementsCounters(t *testing.T) {
+	// Arrange
+	engine, _, preamble, evidence := newCacheModeEngine(t)
+	analyses := `sdek_ai_analyses_total{outcome="success",provider="mock"}`
+	misses := `sdek_ai_cache_lookups_total{result="miss"}`
+	hits := `sdek_ai_cache_lookups_total{result="hit"}`
+	promptTokens := `sdek_ai_tokens_total{direction="prompt",provider="mock"}`
+	before := map[string]float64{}
+	for _, series := range []string{analyses, misses, hits, promptTokens} {
+		before[series] = scrapeMetric(t, series)
+	}
+
+	// Act: the second analysis is served from the cache
+	_, err := engine.Analyze(context.Background(), preamble, evidence)
+	require.NoError(t, err)
+	_, err = engine.Analyze(context.Background(), preamble, evidence)
+	require.NoError(t, err)
+
+	// Assert
+	assert.Equal(t, before[analyses]+2, scrapeMetric(t, analyses))
+	assert.Equal(t, before[misses]+1, scrapeMetric(t, misses))
+	assert.Equal(t, before[hits]+1, scrapeMetric(t, hits))
+	assert.Greater(t, scrapeMetric(t, promptTokens), before[promptTokens])
+}
+
+func TestMetrics_ProviderErrorsByType(t *testing.T) {
+	// Arrange
+	engine, provider, preamble, evidence := newCacheModeEngine(t)
+	provider.SetError(ai.ErrProviderRateLimit)
+	rateLimited := `sdek_ai_provider_errors_total{provider="mock",type="provider_rate_limit"}`
+	failed := `sdek_ai_analyses_total{outcome="error",provider="mock"}`
+	beforeRateLimited, beforeFailed := scrapeMetric(t, rateLimited), scrapeMetric(t, failed)
+
+	// Act
+	_, err := engine.Analyze(context.Background(), preamble, evidence)
+
+	// Assert
+	require.Error(t, err)
+	assert.Equal(t, beforeRateLimited+1, scrapeMetric(t, rateLimited))
+	assert.Equal(t, beforeFailed+1, scrapeMetric(t, failed))
+}
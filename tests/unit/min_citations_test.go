@@ -0,0 +1,113 @@
+package unit
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/pickjonathan/sdek-cli/internal/ai"
+	"github.com/pickjonathan/sdek-cli/pkg/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newMinCitationsEngine(t *testing.T, minCitations int, response string) (ai.Engine, types.ContextPreamble) {
+	t.Helper()
+	cfg := &types.Config{
+		AI: types.AIConfig{
+			Enabled:      true,
+			Provider:     "mock",
+			Mode:         types.AIModeContext,
+			CacheDir:     t.TempDir(),
+			MinCitations: minCitations,
+		},
+	}
+	provider := ai.NewMockProvider()
+	provider.SetResponse(response)
+
+	preamble, err := types.NewContextPreamble("SOC2", "2017", "CC6.1", "Logical access security software, infrastructure and architectures are implemented", nil)
+	require.NoError(t, err)
+	return ai.NewEngine(cfg, provider), *preamble
+}
+
+func minCitationsEvidence() types.EvidenceBundle {
+	return types.EvidenceBundle{Events: []types.EvidenceEvent{
+		{ID: "evt-1", Source: "github", Type: "commit", Timestamp: time.Now(), Content: "Added MFA authentication to login endpoint"},
+		{ID: "evt-2", Source: "jira", Type: "ticket", Timestamp: time.Now(), Content: "Quarterly access review completed"},
+	}}
+}
+
+func TestAnalyze_ZeroCitationHighConfidenceRequiresReview(t *testing.T) {
+	// Arrange
+	engine, preamble := newMinCitationsEngine(t, 1,
+		`{"summary": "Access controls are fully implemented", "confidence_score": 0.95, "residual_risk": "low", "citations": []}`)
+
+	// Act
+	finding, err := engine.Analyze(context.Background(), preamble, minCitationsEvidence())
+
+	// Assert
+	require.NoError(t, err)
+	assert.InDelta(t, 0.95, finding.ConfidenceScore, 0.001)
+	assert.True(t, finding.ReviewRequired, "An uncited finding should require review despite high confidence")
+	assert.Equal(t, "insufficient citations: 0 cited, 1 required", finding.ReviewReason)
+}
+
+func TestAnalyze_UnknownCitationsDontCountTowardMinimum(t *testing.T) {
+	// Arrange
+	engine, preamble := newMinCitationsEngine(t, 2,
+		`{"summary": "MFA enforced", "confidence_score": 0.9, "residual_risk": "low", "citations": ["evt-1", "evt-404"]}`)
+
+	// Act
+	finding, err := engine.Analyze(context.Background(), preamble, minCitationsEvidence())
+
+	// Assert
+	require.NoError(t, err)
+	assert.True(t, finding.ReviewRequired)
+	assert.Equal(t, "insufficient citations: 1 cited, 2 required", finding.ReviewReason)
+}
+
+func TestAnalyze_EnoughCitationsKeepsReviewFlag(t *testing.T) {
+	// Arrange
+	engine, preamble := newMinCitationsEngine(t, 2,
+		`{"summary": "MFA enforced and access reviewed", "confidence_score": 0.9, "residual_risk": "low", "citations": ["evt-1", "evt-2"]}`)
+
+	// Act
+	finding, err := engine.Analyze(context.Background(), preamble, minCitationsEvidence())
+
+	// Assert
+	require.NoError(t, err)
+	assert.False(t, finding.ReviewRequired)
+	assert.Equal(t, "low", finding.ResidualRisk)
+}
+
+func TestAnalyze_MinCitationsDisabledByDefault(t *testing.T) {
+	// Arrange
+	engine, preamble := newMinCitationsEngine(t, 0,
+		`{"summary": "Access controls are fully implemented", "confidence_score": 0.95, "residual_risk": "low", "citations": []}`)
+
+	// Act
+	finding, err := engine.Analyze(context.Background(), preamble, minCitationsEvidence())
+
+	// Assert
+	require.NoError(t, err)
+	assert.False(t, finding.ReviewRequired)
+}
+
+func TestAnalyze_MinCitationsNotedOnceOnCacheHit(t *testing.T) {
+	// Arrange
+	engine, preamble := newMinCitationsEngine(t, 1,
+		`{"summary": "Access controls are fully implemented", "confidence_score": 0.95, "residual_risk": "low", "citations": []}`)
+	evidence := minCitationsEvidence()
+	_, err := engine.Analyze(context.Background(), preamble, evidence)
+	require.NoError(t, err)
+
+	// Act
+	finding, err := engine.Analyze(context.Background(), preamble, evidence)
+
+	// Assert
+	require.NoError(t, err)
+	assert.True(t, finding.CacheHit)
+	assert.True(t, finding.ReviewRequired)
+	assert.Equal(t, "low", finding.ResidualRisk)
+	assert.Equal(t, "insufficient citations: 0 cited, 1 required", finding.ReviewReason)
+}
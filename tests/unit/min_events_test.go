@@ -0,0 +1,76 @@
+package unit
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/pickjonathan/sdek-cli/internal/ai"
+	"github.com/pickjonathan/sdek-cli/pkg/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func analyzeWithMinEvents(t *testing.T, minEvents, eventCount int) (*types.Finding, *ai.MockProvider) {
+	t.Helper()
+
+	cfg := &types.Config{
+		AI: types.AIConfig{
+			Enabled:        true,
+			Provider:       "mock",
+			Mode:           types.AIModeContext,
+			CacheDir:       t.TempDir(),
+			MinEventsForAI: minEvents,
+		},
+	}
+	provider := ai.NewMockProvider()
+	engine := ai.NewEngine(cfg, provider)
+
+	preamble, err := types.NewContextPreamble("SOC2", "2017", "CC6.1", "Logical access security software, infrastructure and architectures are implemented", nil)
+	require.NoError(t, err)
+
+	var evidence types.EvidenceBundle
+	for i := 1; i <= eventCount; i++ {
+		evidence.Events = append(evidence.Events, types.EvidenceEvent{
+			ID:        fmt.Sprintf("evt-%d", i),
+			Source:    "github",
+			Type:      "commit",
+			Timestamp: time.Date(2025, 1, 15, 10, 0, 0, 0, time.UTC),
+			Content:   fmt.Sprintf("Access control change %d", i),
+		})
+	}
+
+	finding, err := engine.Analyze(context.Background(), *preamble, evidence)
+	require.NoError(t, err)
+	return finding, provider
+}
+
+func TestAnalyze_BelowMinEventsSkipsProvider(t *testing.T) {
+	// Act
+	finding, provider := analyzeWithMinEvents(t, 3, 2)
+
+	// Assert
+	assert.Equal(t, 0, provider.GetCallCount(), "provider should not be called")
+	assert.Equal(t, "insufficient evidence (2 < 3)", finding.Summary)
+	assert.Equal(t, 0.3, finding.ConfidenceScore)
+	assert.True(t, finding.ReviewRequired)
+	assert.Equal(t, []types.ProvenanceEntry{{Source: "github", EventsUsed: 2}}, finding.Provenance)
+}
+
+func TestAnalyze_AtMinEventsCallsProvider(t *testing.T) {
+	// Act
+	finding, provider := analyzeWithMinEvents(t, 3, 3)
+
+	// Assert
+	assert.Equal(t, 1, provider.GetCallCount())
+	assert.NotContains(t, finding.Summary, "insufficient evidence")
+}
+
+func TestAnalyze_UnsetMinEventsAnalyzesSingleEvent(t *testing.T) {
+	// Act
+	_, provider := analyzeWithMinEvents(t, 0, 1)
+
+	// Assert
+	assert.Equal(t, 1, provider.GetCallCount())
+}
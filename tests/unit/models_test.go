@@ -0,0 +1,82 @@
+package unit
+
+import (
+	"testing"
+
+	"github.com/pickjonathan/sdek-cli/internal/ai"
+	"github.com/pickjonathan/sdek-cli/pkg/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEditDistance(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{"", "", 0},
+		{"soc2", "", 4},
+		{"soc2", "soc2", 0},
+		{"soc-2", "soc2", 1},
+		{"kitten", "sitting", 3},
+		{"CC6.1", "CC61", 1},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, ai.EditDistance(tt.a, tt.b), "EditDistance(%q, %q)", tt.a, tt.b)
+	}
+}
+
+func TestValidateModels(t *testing.T) {
+	tests := []struct {
+		name    string
+		ai      types.AIConfig
+		wantErr string
+	}{
+		{"known model", types.AIConfig{Enabled: true, Provider: "openai", Model: "gpt-4o"}, ""},
+		{"dated snapshot", types.AIConfig{Enabled: true, Provider: "openai", Model: "gpt-4o-2024-08-06"}, ""},
+		{"preview alias", types.AIConfig{Enabled: true, Provider: "openai", Model: "gpt-4-turbo-preview"}, ""},
+		{"model from another provider", types.AIConfig{Enabled: true, Provider: "anthropic", Model: "gpt-4"}, `"gpt-4" is not a known anthropic model (openai serves it)`},
+		{"typo suggests closest model", types.AIConfig{Enabled: true, Provider: "openai", Model: "gpt-4o-mni"}, `did you mean "gpt-4o-mini"?`},
+		{"provider URL scheme", types.AIConfig{Enabled: true, Provider: "openai", ProviderURL: "anthropic://api.anthropic.com", Model: "gpt-4o"}, "not a known anthropic model"},
+		{"local provider accepts any model", types.AIConfig{Enabled: true, ProviderURL: "ollama://localhost:11434", Model: "gemma3:12b"}, ""},
+		{"allow unknown model", types.AIConfig{Enabled: true, Provider: "anthropic", Model: "claude-next", AllowUnknownModel: true}, ""},
+		{"AI disabled", types.AIConfig{Provider: "anthropic", Model: "gpt-4"}, ""},
+		{"context fallback model", types.AIConfig{Enabled: true, Provider: "openai", Model: "gpt-4o", ContextFallbackModel: "gpt-4-turbo"}, ""},
+		{"context fallback model typo", types.AIConfig{Enabled: true, Provider: "openai", Model: "gpt-4o", ContextFallbackModel: "gpt-4o-mni"}, `invalid ai.context_fallback_model: "gpt-4o-mni" is not a known openai model; did you mean "gpt-4o-mini"?`},
+		{"context fallback model from another provider", types.AIConfig{Enabled: true, Provider: "anthropic", Model: "claude-3-5-sonnet-20241022", ContextFallbackModel: "gpt-4o"}, "invalid ai.context_fallback_model"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			err := ai.ValidateModels(&types.Config{AI: tt.ai})
+
+			// Assert
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
+
+func TestValidateModels_ProviderConfigs(t *testing.T) {
+	// Arrange
+	cfg := &types.Config{
+		AI: types.AIConfig{Enabled: true},
+		Providers: map[string]types.ProviderConfig{
+			"ollama": {Model: "llama3.1:8b"},
+			"gemini": {Model: "gemini-1.5-pr"},
+		},
+	}
+
+	// Act
+	err := ai.ValidateModels(cfg)
+
+	// Assert
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `invalid providers.gemini.model: "gemini-1.5-pr" is not a known gemini model; did you mean "gemini-1.5-pro"?`)
+}
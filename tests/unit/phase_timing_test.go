@@ -0,0 +1,148 @@
+package unit
+
+import (
+	"bytes"
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/pickjonathan/sdek-cli/internal/ai"
+	"github.com/pickjonathan/sdek-cli/pkg/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// slowProvider delays every call so provider time is measurable
+type slowProvider struct {
+	*ai.MockProvider
+	delay time.Duration
+}
+
+func (p *slowProvider) AnalyzeWithContext(ctx context.Context, prompt string) (string, error) {
+	time.Sleep(p.delay)
+	return p.MockProvider.AnalyzeWithContext(ctx, prompt)
+}
+
+func TestAnalyze_RecordsPhaseTimings(t *testing.T) {
+	// Arrange
+	cfg := &types.Config{
+		AI: types.AIConfig{
+			Enabled:   true,
+			Provider:  "mock",
+			Mode:      types.AIModeContext,
+			Redaction: types.RedactionConfig{Enabled: true},
+		},
+	}
+	engine := ai.NewEngine(cfg, &slowProvider{MockProvider: ai.NewMockProvider(), delay: 5 * time.Millisecond})
+	preamble, evidence := newPromptTemplateTestInputs(t)
+	timings := ai.NewPhaseTimings()
+
+	// Act
+	_, err := engine.Analyze(ai.WithPhaseTimings(context.Background(), timings), preamble, evidence)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Greater(t, timings.Get(ai.PhaseRedact), time.Duration(0))
+	assert.Greater(t, timings.Get(ai.PhasePromptBuild), time.Duration(0))
+	assert.GreaterOrEqual(t, timings.Get(ai.PhaseProvider), 5*time.Millisecond)
+	assert.Greater(t, timings.Get(ai.PhaseParse), time.Duration(0))
+	assert.Zero(t, timings.Get(ai.PhaseLoad), "engine does not load inputs")
+	assert.GreaterOrEqual(t, timings.Total(), timings.Get(ai.PhaseProvider))
+}
+
+func TestProposePlan_RecordsPhaseTimings(t *testing.T) {
+	// Arrange
+	cfg := &types.Config{
+		AI: types.AIConfig{
+			Enabled:  true,
+			Provider: "mock",
+			Mode:     types.AIModeAutonomous,
+		},
+	}
+	engine := ai.NewEngine(cfg, &slowProvider{MockProvider: ai.NewMockProvider(), delay: 5 * time.Millisecond})
+	preamble, _ := newPromptTemplateTestInputs(t)
+	timings := ai.NewPhaseTimings()
+
+	// Act
+	_, err := engine.ProposePlan(ai.WithPhaseTimings(context.Background(), timings), preamble)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Greater(t, timings.Get(ai.PhasePromptBuild), time.Duration(0))
+	assert.GreaterOrEqual(t, timings.Get(ai.PhaseProvider), 5*time.Millisecond)
+	assert.Greater(t, timings.Get(ai.PhaseParse), time.Duration(0))
+	assert.Zero(t, timings.Get(ai.PhaseRedact))
+}
+
+func TestAnalyze_WithoutPhaseTimings(t *testing.T) {
+	// Arrange
+	cfg := &types.Config{
+		AI: types.AIConfig{
+			Enabled:  true,
+			Provider: "mock",
+			Mode:     types.AIModeContext,
+		},
+	}
+	engine := ai.NewEngine(cfg, ai.NewMockProvider())
+	preamble, evidence := newPromptTemplateTestInputs(t)
+
+	// Act
+	finding, err := engine.Analyze(context.Background(), preamble, evidence)
+
+	// Assert
+	require.NoError(t, err)
+	assert.NotNil(t, finding)
+}
+
+func TestPhaseTimings_AccumulatesConcurrently(t *testing.T) {
+	// Arrange
+	timings := ai.NewPhaseTimings()
+	var wg sync.WaitGroup
+
+	// Act
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			timings.Add(ai.PhaseProvider, time.Millisecond)
+		}()
+	}
+	wg.Wait()
+
+	// Assert
+	assert.Equal(t, 10*time.Millisecond, timings.Get(ai.PhaseProvider))
+	assert.Equal(t, 10*time.Millisecond, timings.Total())
+}
+
+func TestPhaseTimings_NilIsNoop(t *testing.T) {
+	// Arrange
+	var timings *ai.PhaseTimings
+
+	// Act
+	timings.Add(ai.PhaseParse, time.Second)
+	timings.Track(ai.PhaseParse, time.Now())
+
+	// Assert
+	assert.Zero(t, timings.Get(ai.PhaseParse))
+	assert.Zero(t, timings.Total())
+}
+
+func TestPhaseTimings_Print(t *testing.T) {
+	// Arrange
+	timings := ai.NewPhaseTimings()
+	timings.Add(ai.PhaseLoad, 2*time.Millisecond)
+	timings.Add(ai.PhaseProvider, 40*time.Millisecond)
+	var buf bytes.Buffer
+
+	// Act
+	timings.Print(&buf)
+
+	// Assert
+	output := buf.String()
+	for _, phase := range ai.Phases {
+		assert.Contains(t, output, string(phase))
+	}
+	assert.Contains(t, output, "40ms")
+	assert.Contains(t, output, "42ms")
+}
@@ -0,0 +1,73 @@
+package unit
+
+import (
+	"testing"
+
+	"github.com/pickjonathan/sdek-cli/internal/ai"
+	"github.com/pickjonathan/sdek-cli/pkg/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func newCostPlan(statuses ...types.ApprovalStatus) *types.EvidencePlan {
+	signals := []float64{1.0, 0.5, 0.2}
+	plan := &types.EvidencePlan{Framework: "SOC2", Section: "CC6.1", Status: types.PlanApproved}
+	for i, status := range statuses {
+		plan.Items = append(plan.Items, types.PlanItem{
+			Source:         "github",
+			Query:          "label:security",
+			SignalStrength: signals[i],
+			ApprovalStatus: status,
+		})
+	}
+	return plan
+}
+
+func TestEstimatePlanCost_ApprovedSubsets(t *testing.T) {
+	// Arrange
+	cfg := &types.Config{AI: types.AIConfig{Enabled: true, Provider: "mock", Model: "gpt-4"}}
+	engine := ai.NewEngine(cfg, ai.NewMockProvider())
+
+	all := newCostPlan(types.ApprovalApproved, types.ApprovalAutoApproved, types.ApprovalApproved)
+	subset := newCostPlan(types.ApprovalApproved, types.ApprovalDenied, types.ApprovalPending)
+	none := newCostPlan(types.ApprovalDenied, types.ApprovalPending, types.ApprovalDenied)
+
+	// Act
+	allEstimate := engine.EstimatePlanCost(all)
+	subsetEstimate := engine.EstimatePlanCost(subset)
+	noneEstimate := engine.EstimatePlanCost(none)
+
+	// Assert
+	// 50, 25 and 10 expected events at 120 tokens each
+	assert.Equal(t, 3, allEstimate.ApprovedItems)
+	assert.Equal(t, 3, allEstimate.APICalls)
+	assert.Equal(t, 10200, allEstimate.Tokens)
+	assert.InDelta(t, 0.306, allEstimate.CostUSD, 1e-9)
+	assert.True(t, allEstimate.Priced)
+
+	assert.Equal(t, 1, subsetEstimate.ApprovedItems)
+	assert.Equal(t, 1, subsetEstimate.APICalls)
+	assert.Equal(t, 6000, subsetEstimate.Tokens)
+	assert.InDelta(t, 0.18, subsetEstimate.CostUSD, 1e-9)
+	assert.Less(t, subsetEstimate.CostUSD, allEstimate.CostUSD)
+
+	assert.Equal(t, 0, noneEstimate.APICalls)
+	assert.Equal(t, 0, noneEstimate.Tokens)
+	assert.Zero(t, noneEstimate.CostUSD)
+}
+
+func TestEstimatePlanCost_ModelPricing(t *testing.T) {
+	// Arrange
+	plan := newCostPlan(types.ApprovalApproved)
+
+	// Act
+	mini := ai.EstimatePlanCost(plan, "gpt-4o-mini")
+	gpt4 := ai.EstimatePlanCost(plan, "gpt-4")
+	local := ai.EstimatePlanCost(plan, "gemma2:2b")
+
+	// Assert
+	assert.Equal(t, mini.Tokens, gpt4.Tokens, "tokens don't depend on the model")
+	assert.InDelta(t, 0.0009, mini.CostUSD, 1e-9, "gpt-4o-mini must not be priced as gpt-4")
+	assert.InDelta(t, 0.18, gpt4.CostUSD, 1e-9)
+	assert.False(t, local.Priced)
+	assert.Zero(t, local.CostUSD)
+}
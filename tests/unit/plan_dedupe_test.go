@@ -0,0 +1,106 @@
+package unit
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/pickjonathan/sdek-cli/internal/ai"
+	"github.com/pickjonathan/sdek-cli/pkg/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func dedupePlan(items ...types.PlanItem) *types.EvidencePlan {
+	for i := range items {
+		items[i].ApprovalStatus = types.ApprovalApproved
+		items[i].ExecutionStatus = types.ExecPending
+	}
+	return &types.EvidencePlan{ID: "plan-dedupe", Framework: "SOC2", Section: "CC6.1", Status: types.PlanApproved, Items: items}
+}
+
+func TestExecutePlan_DeduplicatesOverlappingItems(t *testing.T) {
+	// Arrange
+	cfg := &types.Config{AI: types.AIConfig{Enabled: true, Mode: types.AIModeAutonomous}}
+	connector := ai.NewMockMCPConnector()
+	connector.SetEvents("github", []types.EvidenceEvent{
+		{ID: "gh-1", Source: "github", Content: "Enforce MFA for admins"},
+		{ID: "gh-2", Source: "github", Content: "Rotate deploy keys"},
+	})
+	connector.SetEvents("jira", []types.EvidenceEvent{
+		{ID: "jira-1", Source: "jira", Content: "Quarterly access review"},
+	})
+	engine := ai.NewEngineWithConnector(cfg, ai.NewMockProvider(), connector)
+
+	// Both github items return the same two events
+	plan := dedupePlan(
+		types.PlanItem{Source: "github", Query: "MFA"},
+		types.PlanItem{Source: "jira", Query: "access review"},
+		types.PlanItem{Source: "github", Query: "MFA admins"},
+	)
+
+	// Act
+	bundle, err := engine.ExecutePlan(context.Background(), plan)
+
+	// Assert
+	require.NoError(t, err)
+	var ids []string
+	for _, event := range bundle.Events {
+		ids = append(ids, event.ID)
+	}
+	assert.Equal(t, []string{"gh-1", "gh-2", "jira-1"}, ids, "duplicates should be removed, keeping plan order")
+	assert.Equal(t, 2, plan.Items[2].EventsCollected, "per-item counts still report what each item collected")
+}
+
+func TestExecutePlan_DeduplicatesEventsWithoutIDsByContent(t *testing.T) {
+	// Arrange
+	ts := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	cfg := &types.Config{AI: types.AIConfig{Enabled: true, Mode: types.AIModeAutonomous}}
+	connector := ai.NewMockMCPConnector()
+	connector.SetEvents("aws-api:call_aws", []types.EvidenceEvent{
+		{Source: "aws-api", Type: "call_aws", Timestamp: ts, Content: "CloudTrail enabled in all regions"},
+		{Source: "aws-api", Type: "call_aws", Timestamp: ts, Content: "MFA required for root"},
+	})
+	engine := ai.NewEngineWithConnector(cfg, ai.NewMockProvider(), connector)
+
+	plan := dedupePlan(
+		types.PlanItem{Source: "aws-api:call_aws", Query: "cloudtrail"},
+		types.PlanItem{Source: "aws-api:call_aws", Query: "cloudtrail describe-trails"},
+	)
+
+	// Act
+	bundle, err := engine.ExecutePlan(context.Background(), plan)
+
+	// Assert
+	require.NoError(t, err)
+	require.Len(t, bundle.Events, 2, "events with identical content should be collected once")
+	assert.Equal(t, "CloudTrail enabled in all regions", bundle.Events[0].Content)
+	assert.Equal(t, "MFA required for root", bundle.Events[1].Content)
+}
+
+func TestExecutePlan_KeepsEventsSharingAnIDAcrossSources(t *testing.T) {
+	// Arrange
+	cfg := &types.Config{AI: types.AIConfig{Enabled: true, Mode: types.AIModeAutonomous}}
+	connector := ai.NewMockMCPConnector()
+	connector.SetEvents("github", []types.EvidenceEvent{
+		{ID: "1", Source: "github", Content: "Enforce MFA for admins"},
+	})
+	connector.SetEvents("jira", []types.EvidenceEvent{
+		{ID: "1", Source: "jira", Content: "Quarterly access review"},
+	})
+	engine := ai.NewEngineWithConnector(cfg, ai.NewMockProvider(), connector)
+
+	plan := dedupePlan(
+		types.PlanItem{Source: "github", Query: "MFA"},
+		types.PlanItem{Source: "jira", Query: "access review"},
+	)
+
+	// Act
+	bundle, err := engine.ExecutePlan(context.Background(), plan)
+
+	// Assert
+	require.NoError(t, err)
+	require.Len(t, bundle.Events, 2, "events from different sources are distinct even with the same ID")
+	assert.Equal(t, "github", bundle.Events[0].Source)
+	assert.Equal(t, "jira", bundle.Events[1].Source)
+}
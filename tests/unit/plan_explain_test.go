@@ -0,0 +1,81 @@
+package unit
+
+import (
+	"context"
+	"testing"
+
+	"github.com/pickjonathan/sdek-cli/internal/ai"
+	"github.com/pickjonathan/sdek-cli/pkg/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExplainPlan_AutoApprovePatternAndRunningBudget(t *testing.T) {
+	// Arrange
+	cfg := &types.Config{
+		AI: types.AIConfig{
+			Enabled:  true,
+			Provider: "mock",
+			Mode:     types.AIModeAutonomous,
+			CacheDir: t.TempDir(),
+			Budgets:  types.BudgetLimits{MaxSources: 10, MaxAPICalls: 20, MaxTokens: 50000},
+			Autonomous: types.AutonomousConfig{
+				Enabled: true,
+				AutoApprove: types.AutoApproveConfig{
+					"github": {"*mfa*", "label:security*"},
+				},
+				MinRationaleChars: 10,
+			},
+		},
+	}
+	mockProvider := ai.NewMockProvider()
+	mockProvider.SetPlanItems([]types.PlanItem{
+		{Source: "github", Query: "MFA enforcement", SignalStrength: 0.8, Rationale: "PRs enabling MFA show CC6.1 access controls"},
+		{Source: "github", Query: "branch protection", SignalStrength: 0.5, Rationale: "Protected branches limit who can change code"},
+		{Source: "jira", Query: "access review", SignalStrength: 0.6, Rationale: "short"},
+	})
+	engine := ai.NewEngine(cfg, mockProvider)
+
+	plan, err := engine.ProposePlan(context.Background(), planSourcesPreamble(t))
+	require.NoError(t, err)
+
+	// Act
+	explanation := ai.ExplainPlan(plan, cfg.AI)
+
+	// Assert
+	require.Len(t, explanation.Items, 3)
+	assert.Equal(t, cfg.AI.Budgets, explanation.Budgets)
+
+	mfa, branch, jira := explanation.Items[0], explanation.Items[1], explanation.Items[2]
+	assert.Equal(t, "branch protection", branch.Query)
+	assert.Equal(t, types.ApprovalPending, branch.ApprovalStatus)
+	assert.Contains(t, branch.Approval, "matched none of the 2 auto-approve pattern(s) for github")
+
+	assert.Equal(t, "MFA enforcement", mfa.Query)
+	assert.Equal(t, types.ApprovalAutoApproved, mfa.ApprovalStatus)
+	assert.Equal(t, "*mfa*", mfa.Pattern)
+	assert.Contains(t, mfa.Approval, `pattern "*mfa*"`)
+	assert.Equal(t, "PRs enabling MFA show CC6.1 access controls", mfa.Rationale)
+	assert.Equal(t, 0.8, mfa.SignalStrength)
+
+	assert.Contains(t, jira.Approval, "ai.autonomous.minRationaleChars")
+
+	// Running totals grow with each item; tokens use the cost estimate (signal × 50 events × 120 tokens)
+	assert.Equal(t, []int{1, 2, 3}, []int{mfa.Calls, branch.Calls, jira.Calls})
+	assert.Equal(t, []int{1, 2, 3}, []int{mfa.Items, branch.Items, jira.Items})
+	assert.Equal(t, []int{4800, 4800 + 3000, 4800 + 3000 + 3600}, []int{mfa.Tokens, branch.Tokens, jira.Tokens})
+}
+
+func TestExplainPlan_AutoApproveDisabled(t *testing.T) {
+	// Arrange
+	plan := &types.EvidencePlan{Items: []types.PlanItem{
+		{Source: "github", Query: "MFA", Rationale: "PRs enabling MFA", ApprovalStatus: types.ApprovalPending},
+	}}
+
+	// Act
+	explanation := ai.ExplainPlan(plan, types.AIConfig{})
+
+	// Assert
+	require.Len(t, explanation.Items, 1)
+	assert.Contains(t, explanation.Items[0].Approval, "ai.autonomous.enabled is false")
+}
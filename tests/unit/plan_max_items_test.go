@@ -0,0 +1,88 @@
+package unit
+
+import (
+	"context"
+	"testing"
+
+	"github.com/pickjonathan/sdek-cli/internal/ai"
+	"github.com/pickjonathan/sdek-cli/pkg/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newMaxItemsEngine(t *testing.T, maxItems int, items []types.PlanItem) ai.Engine {
+	t.Helper()
+	cfg := &types.Config{
+		AI: types.AIConfig{
+			Enabled:  true,
+			Provider: "mock",
+			Mode:     types.AIModeAutonomous,
+			CacheDir: t.TempDir(),
+			Autonomous: types.AutonomousConfig{
+				MaxItems: maxItems,
+			},
+		},
+	}
+	mockProvider := ai.NewMockProvider()
+	mockProvider.SetPlanItems(items)
+	return ai.NewEngine(cfg, mockProvider)
+}
+
+func planItemQueries(plan *types.EvidencePlan) []string {
+	var queries []string
+	for _, item := range plan.Items {
+		queries = append(queries, item.Source+"/"+item.Query)
+	}
+	return queries
+}
+
+func TestProposePlan_MaxItemsKeepsHighestSignal(t *testing.T) {
+	// Arrange
+	engine := newMaxItemsEngine(t, 2, []types.PlanItem{
+		{Source: "aws", Query: "iam:CreateUser", SignalStrength: 0.4, Rationale: "IAM changes show provisioning"},
+		{Source: "github", Query: "label:security", SignalStrength: 0.9, Rationale: "Security PRs show control changes"},
+		{Source: "jira", Query: "project=SEC", SignalStrength: 0.7, Rationale: "Security tickets track control work"},
+		{Source: "slack", Query: "channel:security", SignalStrength: 0.2, Rationale: "Security channel discussions"},
+	})
+
+	// Act
+	plan, err := engine.ProposePlan(context.Background(), planSourcesPreamble(t))
+
+	// Assert: the kept items stay in source/query order
+	require.NoError(t, err)
+	assert.Equal(t, []string{"github/label:security", "jira/project=SEC"}, planItemQueries(plan))
+	assert.Equal(t, 2, plan.EstimatedCalls)
+	assert.Equal(t, 2, plan.EstimatedSources)
+}
+
+func TestProposePlan_MaxItemsBreaksTiesBySourceThenQuery(t *testing.T) {
+	// Arrange: every item has the same signal strength
+	items := []types.PlanItem{
+		{Source: "jira", Query: "project=SEC", SignalStrength: 0.5, Rationale: "Security tickets track control work"},
+		{Source: "github", Query: "type:pr", SignalStrength: 0.5, Rationale: "Pull requests show reviewed changes"},
+		{Source: "github", Query: "label:security", SignalStrength: 0.5, Rationale: "Security PRs show control changes"},
+	}
+
+	for i := 0; i < 5; i++ {
+		engine := newMaxItemsEngine(t, 2, items)
+
+		// Act
+		plan, err := engine.ProposePlan(context.Background(), planSourcesPreamble(t))
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, []string{"github/label:security", "github/type:pr"}, planItemQueries(plan))
+	}
+}
+
+func TestProposePlan_MaxItemsZeroKeepsAllItems(t *testing.T) {
+	// Arrange
+	engine := newMaxItemsEngine(t, 0, proposedPlanItems)
+
+	// Act
+	plan, err := engine.ProposePlan(context.Background(), planSourcesPreamble(t))
+
+	// Assert
+	require.NoError(t, err)
+	assert.Len(t, plan.Items, len(proposedPlanItems))
+}
@@ -0,0 +1,90 @@
+package unit
+
+import (
+	"context"
+	"testing"
+
+	"github.com/pickjonathan/sdek-cli/internal/ai"
+	"github.com/pickjonathan/sdek-cli/pkg/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newRationaleEngine(t *testing.T, minRationaleChars int, items []types.PlanItem) ai.Engine {
+	t.Helper()
+	cfg := &types.Config{
+		AI: types.AIConfig{
+			Enabled:  true,
+			Provider: "mock",
+			Mode:     types.AIModeAutonomous,
+			CacheDir: t.TempDir(),
+			Autonomous: types.AutonomousConfig{
+				Enabled: true,
+				AutoApprove: types.AutoApproveConfig{
+					"github": {"*"},
+				},
+				MinRationaleChars: minRationaleChars,
+			},
+		},
+	}
+	mockProvider := ai.NewMockProvider()
+	mockProvider.SetPlanItems(items)
+	return ai.NewEngine(cfg, mockProvider)
+}
+
+func TestProposePlan_EmptyRationaleNeverAutoApproved(t *testing.T) {
+	tests := []struct {
+		name              string
+		minRationaleChars int
+	}{
+		{name: "default minimum", minRationaleChars: types.DefaultMinRationaleChars},
+		{name: "no minimum", minRationaleChars: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange: the pattern matches every github query
+			engine := newRationaleEngine(t, tt.minRationaleChars, []types.PlanItem{
+				{Source: "github", Query: "label:security", Rationale: ""},
+				{Source: "github", Query: "type:pr", Rationale: "   "},
+			})
+
+			// Act
+			plan, err := engine.ProposePlan(context.Background(), planSourcesPreamble(t))
+
+			// Assert
+			require.NoError(t, err)
+			require.Len(t, plan.Items, 2)
+			for _, item := range plan.Items {
+				assert.True(t, item.WeakRationale, "%s should be flagged", item.Query)
+				assert.False(t, item.AutoApproved, "%s should not be auto-approved", item.Query)
+				assert.Equal(t, types.ApprovalPending, item.ApprovalStatus)
+			}
+		})
+	}
+}
+
+func TestProposePlan_ShortRationaleRequiresManualApproval(t *testing.T) {
+	// Arrange
+	engine := newRationaleEngine(t, 20, []types.PlanItem{
+		{Source: "github", Query: "label:security", Rationale: "relevant"},
+		{Source: "github", Query: "type:pr", Rationale: "Security PRs show access control implementations"},
+	})
+
+	// Act
+	plan, err := engine.ProposePlan(context.Background(), planSourcesPreamble(t))
+
+	// Assert: items sort by query, so label:security comes first
+	require.NoError(t, err)
+	require.Len(t, plan.Items, 2)
+
+	assert.Equal(t, "label:security", plan.Items[0].Query)
+	assert.True(t, plan.Items[0].WeakRationale)
+	assert.False(t, plan.Items[0].AutoApproved)
+	assert.Equal(t, types.ApprovalPending, plan.Items[0].ApprovalStatus)
+
+	assert.Equal(t, "type:pr", plan.Items[1].Query)
+	assert.False(t, plan.Items[1].WeakRationale)
+	assert.True(t, plan.Items[1].AutoApproved)
+	assert.Equal(t, types.ApprovalAutoApproved, plan.Items[1].ApprovalStatus)
+}
@@ -0,0 +1,69 @@
+package unit
+
+import (
+	"context"
+	"testing"
+
+	"github.com/pickjonathan/sdek-cli/internal/ai"
+	"github.com/pickjonathan/sdek-cli/pkg/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newSignalEngine(t *testing.T, minSignalStrength float64) ai.Engine {
+	t.Helper()
+	cfg := &types.Config{
+		AI: types.AIConfig{
+			Enabled:  true,
+			Provider: "mock",
+			Mode:     types.AIModeAutonomous,
+			CacheDir: t.TempDir(),
+			Autonomous: types.AutonomousConfig{
+				MinSignalStrength: minSignalStrength,
+			},
+		},
+	}
+	mockProvider := ai.NewMockProvider()
+	mockProvider.SetPlanItems([]types.PlanItem{
+		{Source: "github", Query: "label:security", SignalStrength: 0.9, Rationale: "Security PRs show access control changes"},
+		{Source: "jira", Query: "project=SEC", SignalStrength: 0.5, Rationale: "Security tickets track control work"},
+		{Source: "slack", Query: "channel:random", SignalStrength: 0.1, Rationale: "Chat may mention access requests"},
+	})
+	return ai.NewEngine(cfg, mockProvider)
+}
+
+func TestProposePlan_DropsItemsBelowMinSignalStrength(t *testing.T) {
+	// Arrange
+	engine := newSignalEngine(t, 0.5)
+
+	// Act
+	plan, err := engine.ProposePlan(context.Background(), planSourcesPreamble(t))
+
+	// Assert: the threshold is inclusive
+	require.NoError(t, err)
+	assert.Equal(t, []string{"github", "jira"}, planItemSources(plan))
+	assert.Equal(t, 2, plan.EstimatedCalls)
+}
+
+func TestProposePlan_DefaultMinSignalStrengthKeepsAllItems(t *testing.T) {
+	// Arrange
+	engine := newSignalEngine(t, 0)
+
+	// Act
+	plan, err := engine.ProposePlan(context.Background(), planSourcesPreamble(t))
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, []string{"github", "jira", "slack"}, planItemSources(plan))
+}
+
+func TestProposePlan_AllItemsBelowMinSignalStrengthReturnsError(t *testing.T) {
+	// Arrange
+	engine := newSignalEngine(t, 0.95)
+
+	// Act
+	_, err := engine.ProposePlan(context.Background(), planSourcesPreamble(t))
+
+	// Assert
+	assert.ErrorIs(t, err, ai.ErrNoPlanItems)
+}
@@ -0,0 +1,115 @@
+package unit
+
+import (
+	"context"
+	"testing"
+
+	"github.com/pickjonathan/sdek-cli/internal/ai"
+	"github.com/pickjonathan/sdek-cli/pkg/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newPlanSourcesEngine(t *testing.T, connectors map[string]types.ConnectorConfig, items []types.PlanItem) (ai.Engine, *ai.MockProvider) {
+	t.Helper()
+	cfg := &types.Config{
+		AI: types.AIConfig{
+			Enabled:    true,
+			Provider:   "mock",
+			Mode:       types.AIModeAutonomous,
+			CacheDir:   t.TempDir(),
+			Connectors: connectors,
+		},
+	}
+	mockProvider := ai.NewMockProvider()
+	mockProvider.SetPlanItems(items)
+	return ai.NewEngine(cfg, mockProvider), mockProvider
+}
+
+func planSourcesPreamble(t *testing.T) types.ContextPreamble {
+	t.Helper()
+	preamble, err := types.NewContextPreamble(
+		"SOC2",
+		"2017",
+		"CC6.1",
+		"Access controls shall be implemented to ensure that only authorized individuals can access sensitive data.",
+		nil,
+	)
+	require.NoError(t, err)
+	return *preamble
+}
+
+var proposedPlanItems = []types.PlanItem{
+	{Source: "github", Query: "type:pr label:security", SignalStrength: 0.9, Rationale: "Security PRs"},
+	{Source: "jira", Query: "project=SEC", SignalStrength: 0.8, Rationale: "Security tickets"},
+	{Source: "slack", Query: "channel:security", SignalStrength: 0.5, Rationale: "Security discussions"},
+}
+
+func planItemSources(plan *types.EvidencePlan) []string {
+	var sources []string
+	for _, item := range plan.Items {
+		sources = append(sources, item.Source)
+	}
+	return sources
+}
+
+func TestProposePlan_DropsItemsForDisabledSources(t *testing.T) {
+	// Arrange
+	engine, mockProvider := newPlanSourcesEngine(t, map[string]types.ConnectorConfig{
+		"github": {Enabled: true},
+		"jira":   {Enabled: true},
+		"slack":  {Enabled: false},
+	}, proposedPlanItems)
+
+	// Act
+	plan, err := engine.ProposePlan(context.Background(), planSourcesPreamble(t))
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, []string{"github", "jira"}, planItemSources(plan))
+	assert.Equal(t, 2, plan.EstimatedSources)
+	assert.Contains(t, mockProvider.GetLastPrompt(), "one of: github, jira")
+	assert.NotContains(t, mockProvider.GetLastPrompt(), "slack")
+}
+
+func TestProposePlan_SourcesOverrideFromContext(t *testing.T) {
+	// Arrange
+	engine, mockProvider := newPlanSourcesEngine(t, map[string]types.ConnectorConfig{
+		"github": {Enabled: true},
+		"jira":   {Enabled: true},
+	}, proposedPlanItems)
+	ctx := ai.WithPlanSources(context.Background(), []string{"Slack", " github "})
+
+	// Act
+	plan, err := engine.ProposePlan(ctx, planSourcesPreamble(t))
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, []string{"github", "slack"}, planItemSources(plan))
+	assert.Contains(t, mockProvider.GetLastPrompt(), "one of: github, slack")
+}
+
+func TestProposePlan_NoEnabledSourcesKeepsAllItems(t *testing.T) {
+	// Arrange
+	engine, _ := newPlanSourcesEngine(t, nil, proposedPlanItems)
+
+	// Act
+	plan, err := engine.ProposePlan(context.Background(), planSourcesPreamble(t))
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, []string{"github", "jira", "slack"}, planItemSources(plan))
+}
+
+func TestProposePlan_AllItemsForDisabledSourcesReturnsError(t *testing.T) {
+	// Arrange
+	engine, _ := newPlanSourcesEngine(t, map[string]types.ConnectorConfig{
+		"aws": {Enabled: true},
+	}, proposedPlanItems)
+
+	// Act
+	_, err := engine.ProposePlan(context.Background(), planSourcesPreamble(t))
+
+	// Assert
+	assert.ErrorIs(t, err, ai.ErrNoPlanItems)
+}
@@ -0,0 +1,196 @@
+package unit
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/pickjonathan/sdek-cli/internal/ai"
+	"github.com/pickjonathan/sdek-cli/pkg/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const customPromptTemplate = `AUDITOR BRIEFING for {{.Preamble.Framework}} {{.Preamble.Section}}
+Requirement: {{.Preamble.Excerpt}}
+{{range .Evidence.Events}}* {{.ID}} ({{.Source}}): {{.Content}}
+{{end}}
+{{.OutputInstructions}}`
+
+func writePromptTemplate(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "prompt.tmpl")
+	require.NoError(t, os.WriteFile(path, []byte(content), 0644))
+	return path
+}
+
+func newPromptTemplateTestInputs(t *testing.T) (types.ContextPreamble, types.EvidenceBundle) {
+	t.Helper()
+
+	preamble, err := types.NewContextPreamble(
+		"SOC2",
+		"2017",
+		"CC6.1",
+		"Access controls shall be implemented to ensure that only authorized individuals can access sensitive data.",
+		nil,
+	)
+	require.NoError(t, err)
+
+	evidence := types.EvidenceBundle{
+		Events: []types.EvidenceEvent{
+			{
+				ID:        "evt-1",
+				Source:    "github",
+				Timestamp: time.Now().Add(-24 * time.Hour),
+				Type:      "commit",
+				Content:   "Added MFA authentication to login endpoint",
+			},
+		},
+	}
+
+	return *preamble, evidence
+}
+
+func TestAnalyze_CustomPromptTemplate(t *testing.T) {
+	// Arrange
+	cfg := &types.Config{
+		AI: types.AIConfig{
+			Enabled:        true,
+			Provider:       "mock",
+			Mode:           types.AIModeContext,
+			PromptTemplate: writePromptTemplate(t, customPromptTemplate),
+		},
+	}
+	provider := ai.NewMockProvider()
+	engine := ai.NewEngine(cfg, provider)
+	preamble, evidence := newPromptTemplateTestInputs(t)
+
+	// Act
+	finding, err := engine.Analyze(context.Background(), preamble, evidence)
+
+	// Assert
+	require.NoError(t, err)
+	require.NotNil(t, finding)
+	assert.Equal(t, "CC6.1", finding.ControlID)
+	assert.InDelta(t, 0.85, finding.ConfidenceScore, 0.001)
+	assert.Contains(t, finding.Citations, "evt-1")
+
+	prompt := provider.GetLastPrompt()
+	assert.Contains(t, prompt, "AUDITOR BRIEFING for SOC2 CC6.1")
+	assert.Contains(t, prompt, "* evt-1 (github): Added MFA authentication to login endpoint")
+	assert.Contains(t, prompt, "confidence_score")
+	assert.NotContains(t, prompt, "You are analyzing evidence for compliance", "Built-in prompt should be replaced")
+}
+
+func TestAnalyze_DefaultPromptWithoutTemplate(t *testing.T) {
+	// Arrange
+	cfg := &types.Config{
+		AI: types.AIConfig{
+			Enabled:  true,
+			Provider: "mock",
+			Mode:     types.AIModeContext,
+		},
+	}
+	provider := ai.NewMockProvider()
+	engine := ai.NewEngine(cfg, provider)
+	preamble, evidence := newPromptTemplateTestInputs(t)
+
+	// Act
+	_, err := engine.Analyze(context.Background(), preamble, evidence)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Contains(t, provider.GetLastPrompt(), "You are analyzing evidence for compliance with SOC2 CC6.1")
+}
+
+func TestLoadPromptTemplate_Errors(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		errMsg  string
+	}{
+		{
+			name:    "syntax error",
+			content: "Assess {{.Preamble.Framework",
+			errMsg:  "failed to parse prompt template",
+		},
+		{
+			name:    "unknown field",
+			content: "Assess {{.Preamble.Policy}}",
+			errMsg:  "failed to execute prompt template",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ai.LoadPromptTemplate(writePromptTemplate(t, tt.content))
+
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.errMsg)
+		})
+	}
+
+	t.Run("missing file", func(t *testing.T) {
+		_, err := ai.LoadPromptTemplate(filepath.Join(t.TempDir(), "missing.tmpl"))
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "failed to read prompt template")
+	})
+}
+
+func TestAnalyze_InvalidPromptTemplateFallsBack(t *testing.T) {
+	// Arrange
+	cfg := &types.Config{
+		AI: types.AIConfig{
+			Enabled:        true,
+			Provider:       "mock",
+			Mode:           types.AIModeContext,
+			PromptTemplate: writePromptTemplate(t, "{{if}}"),
+		},
+	}
+	provider := ai.NewMockProvider()
+	engine := ai.NewEngine(cfg, provider)
+	preamble, evidence := newPromptTemplateTestInputs(t)
+
+	// Act
+	finding, err := engine.Analyze(context.Background(), preamble, evidence)
+
+	// Assert
+	require.NoError(t, err)
+	require.NotNil(t, finding)
+	assert.Contains(t, provider.GetLastPrompt(), "You are analyzing evidence for compliance")
+}
+
+func TestAnalyze_PromptTemplateNotServedFromBuiltinCache(t *testing.T) {
+	// Arrange: populate the cache using the built-in prompt
+	cacheDir := t.TempDir()
+	preamble, evidence := newPromptTemplateTestInputs(t)
+
+	builtinProvider := ai.NewMockProvider()
+	builtinEngine := ai.NewEngine(&types.Config{
+		AI: types.AIConfig{Enabled: true, Provider: "mock", Mode: types.AIModeContext, CacheDir: cacheDir},
+	}, builtinProvider)
+	_, err := builtinEngine.Analyze(context.Background(), preamble, evidence)
+	require.NoError(t, err)
+
+	templateProvider := ai.NewMockProvider()
+	templateEngine := ai.NewEngine(&types.Config{
+		AI: types.AIConfig{
+			Enabled:        true,
+			Provider:       "mock",
+			Mode:           types.AIModeContext,
+			CacheDir:       cacheDir,
+			PromptTemplate: writePromptTemplate(t, customPromptTemplate),
+		},
+	}, templateProvider)
+
+	// Act
+	finding, err := templateEngine.Analyze(context.Background(), preamble, evidence)
+
+	// Assert
+	require.NoError(t, err)
+	assert.False(t, finding.CacheHit)
+	assert.Equal(t, 1, templateProvider.GetCallCount(), "Custom template should not reuse built-in prompt results")
+}
@@ -0,0 +1,60 @@
+package unit
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/pickjonathan/sdek-cli/internal/ai"
+	"github.com/pickjonathan/sdek-cli/internal/ai/providers"
+	"github.com/pickjonathan/sdek-cli/pkg/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProviderError_MatchesSentinel(t *testing.T) {
+	cause := fmt.Errorf("429 insufficient_quota")
+	err := fmt.Errorf("analysis failed: %w", &ai.ProviderError{
+		Code:     ai.CodeProviderQuotaExceeded,
+		Provider: "anthropic",
+		Model:    "claude-3-5-sonnet-20241022",
+		Attempts: 3,
+		Cause:    cause,
+	})
+
+	assert.True(t, errors.Is(err, ai.ErrProviderQuotaExceeded), "Should match quota sentinel")
+	assert.False(t, errors.Is(err, ai.ErrProviderRateLimit), "Should not match other sentinels")
+	assert.True(t, errors.Is(err, cause), "Should unwrap to the cause")
+	assert.True(t, ai.IsFatalError(err))
+	assert.False(t, ai.IsRetryable(err))
+
+	var providerErr *ai.ProviderError
+	require.True(t, errors.As(err, &providerErr))
+	assert.Equal(t, "anthropic", providerErr.Provider)
+	assert.Equal(t, "claude-3-5-sonnet-20241022", providerErr.Model)
+	assert.Equal(t, ai.CodeProviderQuotaExceeded, providerErr.Code)
+
+	assert.Equal(t,
+		"analysis failed: ai: provider quota exhausted [anthropic/claude-3-5-sonnet-20241022] after 3 attempts: 429 insufficient_quota",
+		err.Error())
+}
+
+func TestProviderError_Retryable(t *testing.T) {
+	err := ai.NewProviderError(ai.CodeProviderRateLimit, "openai", nil)
+
+	assert.True(t, ai.IsRetryable(err))
+	assert.False(t, ai.IsFatalError(err))
+	assert.Equal(t, "ai: provider rate limit exceeded [openai]", err.Error())
+}
+
+func TestProviderError_ReturnedByProvider(t *testing.T) {
+	_, err := providers.NewOpenAIEngine(types.ProviderConfig{})
+	require.Error(t, err)
+
+	assert.True(t, errors.Is(err, ai.ErrProviderAuth), "Missing API key should match auth sentinel")
+
+	var providerErr *ai.ProviderError
+	require.True(t, errors.As(err, &providerErr))
+	assert.Equal(t, "openai", providerErr.Provider)
+	assert.Equal(t, ai.CodeProviderAuth, providerErr.Code)
+}
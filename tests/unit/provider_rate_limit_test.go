@@ -0,0 +1,131 @@
+package unit
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/pickjonathan/sdek-cli/internal/ai/providers"
+	"github.com/pickjonathan/sdek-cli/pkg/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSharedRateLimiter_KeyedByProviderAndEndpoint(t *testing.T) {
+	// Arrange
+	endpoint := "https://limiter.example.test/" + t.Name()
+
+	// Act
+	first := providers.SharedRateLimiter("openai", endpoint, 60, 0)
+	second := providers.SharedRateLimiter("openai", endpoint, 60, 0)
+	otherEndpoint := providers.SharedRateLimiter("openai", endpoint+"/other", 60, 0)
+	otherProvider := providers.SharedRateLimiter("anthropic", endpoint, 60, 0)
+
+	// Assert
+	assert.Same(t, first, second, "same provider and endpoint should share a limiter")
+	assert.NotSame(t, first, otherEndpoint)
+	assert.NotSame(t, first, otherProvider)
+}
+
+func TestSharedRateLimiter_ConcurrentAnalysesRespectAggregateLimit(t *testing.T) {
+	// Arrange: several engines for the same endpoint, as parallel analyses would create.
+	// 600 requests per minute is 10 per second with a burst of 10.
+	server := newRecordingServer(t, openAIChatResponse)
+	const (
+		engines        = 4
+		callsPerEngine = 5
+		rateLimit      = 600
+	)
+
+	var clients []*providers.OpenAIEngine
+	for i := 0; i < engines; i++ {
+		provider, err := providers.NewOpenAIEngine(types.ProviderConfig{
+			APIKey:    "sk-test",
+			Model:     "gpt-4o",
+			Endpoint:  server.URL,
+			Timeout:   5,
+			RateLimit: rateLimit,
+		})
+		require.NoError(t, err)
+		clients = append(clients, provider)
+	}
+
+	// Act
+	start := time.Now()
+	var wg sync.WaitGroup
+	errs := make(chan error, engines*callsPerEngine)
+	for _, provider := range clients {
+		for i := 0; i < callsPerEngine; i++ {
+			wg.Add(1)
+			go func(provider *providers.OpenAIEngine) {
+				defer wg.Done()
+				_, err := provider.AnalyzeWithContext(context.Background(), "Analyze CC6.1")
+				errs <- err
+			}(provider)
+		}
+	}
+	wg.Wait()
+	elapsed := time.Since(start)
+	close(errs)
+
+	// Assert: 20 calls at 10/s with a burst of 10 need about a second in total.
+	// Per-engine limiters would each allow their 5 calls immediately.
+	for err := range errs {
+		require.NoError(t, err)
+	}
+	server.mu.Lock()
+	assert.Len(t, server.bodies, engines*callsPerEngine)
+	server.mu.Unlock()
+	assert.GreaterOrEqual(t, elapsed, 900*time.Millisecond,
+		"%d calls finished in %s, faster than the shared limit of %d per minute allows", engines*callsPerEngine, elapsed, rateLimit)
+}
+
+func TestRateLimiter_BurstProceedsImmediatelyThenThrottles(t *testing.T) {
+	// Arrange: 600 requests per minute refills one token every 100ms
+	limiter := providers.NewRateLimiter(600, 5)
+	ctx := context.Background()
+
+	// Act
+	start := time.Now()
+	for i := 0; i < 5; i++ {
+		require.NoError(t, limiter.Wait(ctx))
+	}
+	burstElapsed := time.Since(start)
+
+	require.NoError(t, limiter.Wait(ctx))
+	throttledElapsed := time.Since(start)
+
+	// Assert
+	assert.Less(t, burstElapsed, 50*time.Millisecond, "a burst up to the capacity should not wait")
+	assert.GreaterOrEqual(t, throttledElapsed, 80*time.Millisecond, "the call after the burst should wait for a token")
+}
+
+func TestRateLimiter_DefaultBurstFromRate(t *testing.T) {
+	// Arrange: 60 requests per minute with no burst set allows a single request at once
+	limiter := providers.NewRateLimiter(60, 0)
+	ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+	defer cancel()
+
+	// Act
+	first := limiter.Wait(ctx)
+	second := limiter.Wait(ctx)
+
+	// Assert
+	assert.NoError(t, first)
+	assert.Error(t, second, "the second request should have to wait about a second")
+}
+
+func TestRateLimiter_UnlimitedIgnoresBurst(t *testing.T) {
+	// Arrange
+	limiter := providers.NewRateLimiter(0, 1)
+
+	// Act
+	start := time.Now()
+	for i := 0; i < 100; i++ {
+		require.NoError(t, limiter.Wait(context.Background()))
+	}
+
+	// Assert
+	assert.Less(t, time.Since(start), 50*time.Millisecond)
+}
@@ -0,0 +1,96 @@
+package unit
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/pickjonathan/sdek-cli/internal/ai"
+	"github.com/pickjonathan/sdek-cli/internal/ai/providers"
+	"github.com/pickjonathan/sdek-cli/pkg/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOpenAI_RequestIncludesSeedAndZeroTemperature(t *testing.T) {
+	// Arrange
+	server := newRecordingServer(t, openAIChatResponse)
+	seed := 1234
+	provider, err := providers.NewOpenAIEngine(types.ProviderConfig{
+		APIKey:      "test-key",
+		Model:       "gpt-4o",
+		Endpoint:    server.URL,
+		Timeout:     5,
+		Temperature: 0,
+		Seed:        &seed,
+	})
+	require.NoError(t, err)
+
+	// Act
+	_, err = provider.AnalyzeWithContext(context.Background(), "Analyze CC6.1")
+
+	// Assert
+	require.NoError(t, err)
+	body := server.lastBody(t)
+
+	assert.Equal(t, float64(1234), body["seed"])
+
+	temperature, ok := body["temperature"]
+	require.True(t, ok, "temperature 0 must be sent explicitly, not omitted")
+	assert.InDelta(t, 0, temperature, 1e-9)
+}
+
+func TestOpenAI_RequestOmitsSeedWhenUnset(t *testing.T) {
+	// Arrange
+	server := newRecordingServer(t, openAIChatResponse)
+	provider, err := providers.NewOpenAIEngine(types.ProviderConfig{
+		APIKey:      "test-key",
+		Model:       "gpt-4o",
+		Endpoint:    server.URL,
+		Timeout:     5,
+		Temperature: 0.3,
+	})
+	require.NoError(t, err)
+
+	// Act
+	_, err = provider.AnalyzeWithContext(context.Background(), "Analyze CC6.1")
+
+	// Assert
+	require.NoError(t, err)
+	body := server.lastBody(t)
+
+	assert.NotContains(t, body, "seed")
+	assert.InDelta(t, 0.3, body["temperature"], 1e-6)
+}
+
+func TestAnalyze_RecordsSeedInFindingAndAuditLog(t *testing.T) {
+	// Arrange
+	auditPath := filepath.Join(t.TempDir(), "ai.jsonl")
+	seed := 42
+	cfg := &types.Config{
+		AI: types.AIConfig{
+			Enabled:       true,
+			Provider:      "openai",
+			Model:         "gpt-4o",
+			Mode:          types.AIModeContext,
+			Deterministic: true,
+			Seed:          &seed,
+		},
+		AuditLog: types.AuditLogConfig{Path: auditPath},
+	}
+	engine := ai.NewEngine(cfg, ai.NewMockProvider())
+	preamble, evidence := newPromptTemplateTestInputs(t)
+
+	// Act
+	finding, err := engine.Analyze(context.Background(), preamble, evidence)
+
+	// Assert
+	require.NoError(t, err)
+	require.NotNil(t, finding.Seed)
+	assert.Equal(t, 42, *finding.Seed)
+
+	entries := readAuditEntries(t, auditPath)
+	require.Len(t, entries, 1)
+	require.NotNil(t, entries[0].Seed)
+	assert.Equal(t, 42, *entries[0].Seed)
+}
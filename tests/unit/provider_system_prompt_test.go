@@ -0,0 +1,193 @@
+package unit
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/pickjonathan/sdek-cli/internal/ai"
+	"github.com/pickjonathan/sdek-cli/internal/ai/providers"
+	"github.com/pickjonathan/sdek-cli/pkg/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// recordingServer captures the JSON body of each request and replies with a canned response
+type recordingServer struct {
+	*httptest.Server
+	mu     sync.Mutex
+	bodies []map[string]interface{}
+}
+
+func newRecordingServer(t *testing.T, response string) *recordingServer {
+	t.Helper()
+
+	rs := &recordingServer{}
+	rs.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		data, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+
+		var body map[string]interface{}
+		require.NoError(t, json.Unmarshal(data, &body))
+
+		rs.mu.Lock()
+		rs.bodies = append(rs.bodies, body)
+		rs.mu.Unlock()
+
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = io.WriteString(w, response)
+	}))
+	t.Cleanup(rs.Close)
+
+	return rs
+}
+
+func (rs *recordingServer) lastBody(t *testing.T) map[string]interface{} {
+	t.Helper()
+	rs.mu.Lock()
+	defer rs.mu.Unlock()
+	require.NotEmpty(t, rs.bodies, "expected at least one request")
+	return rs.bodies[len(rs.bodies)-1]
+}
+
+const openAIChatResponse = `{"id":"chatcmpl-1","object":"chat.completion","created":1,"model":"gpt-4o","choices":[{"index":0,"message":{"role":"assistant","content":"ok"},"finish_reason":"stop"}],"usage":{"prompt_tokens":1,"completion_tokens":1,"total_tokens":2}}`
+
+const anthropicMessageResponse = `{"id":"msg_1","type":"message","role":"assistant","model":"claude-3-5-sonnet","content":[{"type":"text","text":"ok"}],"stop_reason":"end_turn","usage":{"input_tokens":1,"output_tokens":1}}`
+
+// openAISystemMessage returns the content of the system message in an OpenAI chat request
+func openAISystemMessage(t *testing.T, body map[string]interface{}) string {
+	t.Helper()
+	messages, ok := body["messages"].([]interface{})
+	require.True(t, ok, "request should contain messages")
+	for _, m := range messages {
+		msg := m.(map[string]interface{})
+		if msg["role"] == "system" {
+			return msg["content"].(string)
+		}
+	}
+	t.Fatal("request has no system message")
+	return ""
+}
+
+// anthropicSystemText returns the concatenated system text blocks of an Anthropic messages request
+func anthropicSystemText(t *testing.T, body map[string]interface{}) string {
+	t.Helper()
+	blocks, ok := body["system"].([]interface{})
+	require.True(t, ok, "request should contain system blocks")
+	var texts []string
+	for _, b := range blocks {
+		texts = append(texts, b.(map[string]interface{})["text"].(string))
+	}
+	return strings.Join(texts, "\n")
+}
+
+func TestOpenAI_SystemPromptOverride(t *testing.T) {
+	tests := []struct {
+		name         string
+		systemPrompt string
+		want         string
+	}{
+		{
+			name:         "configured system prompt",
+			systemPrompt: "You are a PCI DSS QSA. Follow internal assessment guideline IAG-7.",
+			want:         "You are a PCI DSS QSA. Follow internal assessment guideline IAG-7.",
+		},
+		{
+			name: "default system prompt",
+			want: "You are an expert compliance analyst. Analyze evidence and provide detailed, policy-grounded findings.",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			server := newRecordingServer(t, openAIChatResponse)
+			provider, err := providers.NewOpenAIEngine(types.ProviderConfig{
+				APIKey:       "test-key",
+				Model:        "gpt-4o",
+				Endpoint:     server.URL,
+				Timeout:      5,
+				SystemPrompt: tt.systemPrompt,
+			})
+			require.NoError(t, err)
+
+			// Act
+			response, err := provider.AnalyzeWithContext(context.Background(), "Analyze CC6.1")
+
+			// Assert
+			require.NoError(t, err)
+			assert.Equal(t, "ok", response)
+			assert.Equal(t, tt.want, openAISystemMessage(t, server.lastBody(t)))
+		})
+	}
+}
+
+func TestAnthropic_SystemPromptOverride(t *testing.T) {
+	tests := []struct {
+		name         string
+		systemPrompt string
+		want         string
+	}{
+		{
+			name:         "configured system prompt",
+			systemPrompt: "You are an ISO 27001 lead auditor. Be conservative.",
+			want:         "You are an ISO 27001 lead auditor. Be conservative.",
+		},
+		{
+			name: "default system prompt",
+			want: "You are an expert compliance analyst. Analyze evidence and provide detailed, policy-grounded findings.",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			server := newRecordingServer(t, anthropicMessageResponse)
+			provider, err := providers.NewAnthropicEngine(types.ProviderConfig{
+				APIKey:       "test-key",
+				Model:        "claude-3-5-sonnet",
+				Endpoint:     server.URL,
+				Timeout:      5,
+				SystemPrompt: tt.systemPrompt,
+			})
+			require.NoError(t, err)
+
+			// Act
+			response, err := provider.AnalyzeWithContext(context.Background(), "Analyze CC6.1")
+
+			// Assert
+			require.NoError(t, err)
+			assert.Equal(t, "ok", response)
+			assert.Equal(t, tt.want, anthropicSystemText(t, server.lastBody(t)))
+		})
+	}
+}
+
+func TestEngine_SystemPromptReachesProvider(t *testing.T) {
+	// Arrange
+	server := newRecordingServer(t, openAIChatResponse)
+	systemPrompt := "You are a SOC 2 auditor for a regulated bank."
+	provider, err := providers.NewOpenAIEngine(types.ProviderConfig{
+		APIKey:       "test-key",
+		Model:        "gpt-4o",
+		Endpoint:     server.URL,
+		Timeout:      5,
+		SystemPrompt: systemPrompt,
+	})
+	require.NoError(t, err)
+	engine := ai.NewEngine(&types.Config{
+		AI: types.AIConfig{Enabled: true, Provider: types.AIProviderOpenAI},
+	}, provider)
+
+	// Act
+	err = engine.Health(context.Background())
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, systemPrompt, openAISystemMessage(t, server.lastBody(t)))
+}
@@ -436,3 +436,101 @@ func TestRedactionMap_Structure(t *testing.T) {
 		assert.Contains(t, []types.RedactionType{types.RedactionPII, types.RedactionSecret}, redType)
 	}
 }
+
+func TestRedact_IBANRequiresEULocale(t *testing.T) {
+	input := "Refund sent to DE89370400440532013000 and GB29 NWBK 6016 1331 9268 19"
+
+	// Default: IBANs are not redacted
+	defaultRedactor := ai.NewRedactor(&types.Config{
+		AI: types.AIConfig{
+			Redaction: types.RedactionConfig{Enabled: true},
+		},
+	})
+	output, _, err := defaultRedactor.Redact(input)
+	require.NoError(t, err)
+	assert.Contains(t, output, "DE89370400440532013000", "IBAN should not be redacted by default")
+	assert.NotContains(t, output, "[REDACTED:PII:IBAN]")
+
+	// EU locale: IBANs are redacted
+	euRedactor := ai.NewRedactor(&types.Config{
+		AI: types.AIConfig{
+			Redaction: types.RedactionConfig{
+				Enabled: true,
+				Locales: []string{types.RedactionLocaleEU},
+			},
+		},
+	})
+	output, rm, err := euRedactor.Redact(input)
+	require.NoError(t, err)
+	assert.NotContains(t, output, "DE89370400440532013000", "IBAN should be redacted with EU locale")
+	assert.NotContains(t, output, "NWBK 6016", "Grouped IBAN should be redacted with EU locale")
+	assert.Equal(t, 2, strings.Count(output, "[REDACTED:PII:IBAN]"))
+	assert.Equal(t, 2, rm.TotalRedactions)
+}
+
+func TestRedact_LocalePatterns(t *testing.T) {
+	tests := []struct {
+		name        string
+		locales     []string
+		input       string
+		original    string
+		placeholder string
+	}{
+		{
+			name:        "EU VAT number",
+			locales:     []string{"eu"},
+			input:       "Invoice VAT ID: DE123456789",
+			original:    "DE123456789",
+			placeholder: "[REDACTED:PII:VAT]",
+		},
+		{
+			name:        "UK National Insurance number",
+			locales:     []string{"uk"},
+			input:       "Employee NINO AB 12 34 56 C on file",
+			original:    "AB 12 34 56 C",
+			placeholder: "[REDACTED:PII:NINO]",
+		},
+		{
+			name:        "international phone",
+			locales:     []string{"intl"},
+			input:       "Call the London office on +44 20 7946 0958",
+			original:    "+44 20 7946 0958",
+			placeholder: "[REDACTED:PII:PHONE]",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			redactor := ai.NewRedactor(&types.Config{
+				AI: types.AIConfig{
+					Redaction: types.RedactionConfig{
+						Enabled: true,
+						Locales: tt.locales,
+					},
+				},
+			})
+
+			output, _, err := redactor.Redact(tt.input)
+			require.NoError(t, err)
+			assert.Contains(t, output, tt.placeholder)
+			assert.NotContains(t, output, tt.original)
+		})
+	}
+}
+
+func TestRedact_LocalePatternsIgnoreOrdinaryText(t *testing.T) {
+	redactor := ai.NewRedactor(&types.Config{
+		AI: types.AIConfig{
+			Redaction: types.RedactionConfig{
+				Enabled: true,
+				Locales: []string{"eu", "uk", "intl"},
+			},
+		},
+	})
+	input := "DEPLOYMENTS for ESCALATIONS were reviewed in FY2024"
+
+	output, rm, err := redactor.Redact(input)
+	require.NoError(t, err)
+	assert.Equal(t, input, output)
+	assert.Equal(t, 0, rm.TotalRedactions)
+}
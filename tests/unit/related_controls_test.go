@@ -0,0 +1,39 @@
+package unit
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/pickjonathan/sdek-cli/internal/ai"
+	"github.com/pickjonathan/sdek-cli/pkg/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAnalyze_RecordsRelatedSections(t *testing.T) {
+	// Arrange
+	cfg := &types.Config{
+		AI: types.AIConfig{Enabled: true, Provider: "mock", Mode: types.AIModeContext, CacheDir: t.TempDir()},
+	}
+	provider := ai.NewMockProvider()
+	provider.SetResponse(`{"summary": "Access reviews performed", "confidence_score": 0.8, "residual_risk": "low", "citations": ["evt-1"]}`)
+	engine := ai.NewEngine(cfg, provider)
+
+	preamble, err := types.NewContextPreamble("SOC2", "2017", "CC6.1", "Logical access security software, infrastructure and architectures are implemented", []string{"CC6.2", "CC7.2"})
+	require.NoError(t, err)
+	evidence := types.EvidenceBundle{Events: []types.EvidenceEvent{
+		{ID: "evt-1", Source: "github", Type: "commit", Timestamp: time.Now(), Content: "Access review completed"},
+	}}
+
+	// Act
+	finding, err := engine.Analyze(context.Background(), *preamble, evidence)
+	require.NoError(t, err)
+	cached, err := engine.Analyze(context.Background(), *preamble, evidence)
+	require.NoError(t, err)
+
+	// Assert
+	assert.Equal(t, []string{"CC6.2", "CC7.2"}, finding.RelatedControls)
+	assert.True(t, cached.CacheHit)
+	assert.Equal(t, []string{"CC6.2", "CC7.2"}, cached.RelatedControls)
+}
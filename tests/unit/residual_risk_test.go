@@ -0,0 +1,81 @@
+package unit
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/pickjonathan/sdek-cli/internal/ai"
+	"github.com/pickjonathan/sdek-cli/pkg/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// analyzeWithResidualRisk runs an analysis whose provider response carries the given residual_risk
+func analyzeWithResidualRisk(t *testing.T, risk string, strict bool) (*types.Finding, error) {
+	t.Helper()
+	cfg := &types.Config{
+		AI: types.AIConfig{
+			Enabled:            true,
+			Provider:           "mock",
+			Mode:               types.AIModeContext,
+			CacheDir:           t.TempDir(),
+			StrictResidualRisk: strict,
+		},
+	}
+	provider := ai.NewMockProvider()
+	provider.SetResponse(fmt.Sprintf(`{"summary": "Access reviews are performed", "confidence_score": 0.8, "residual_risk": %q, "citations": ["evt-1"]}`, risk))
+
+	preamble, err := types.NewContextPreamble("SOC2", "2017", "CC6.1", "Logical access security software, infrastructure and architectures are implemented", nil)
+	require.NoError(t, err)
+	evidence := types.EvidenceBundle{Events: []types.EvidenceEvent{
+		{ID: "evt-1", Source: "github", Type: "commit", Timestamp: time.Now(), Content: "Quarterly access review completed"},
+	}}
+
+	return ai.NewEngine(cfg, provider).Analyze(context.Background(), *preamble, evidence)
+}
+
+func TestAnalyze_NormalizesResidualRiskSynonyms(t *testing.T) {
+	tests := []struct {
+		risk         string
+		wantRisk     string
+		wantSeverity string
+	}{
+		{risk: "Minimal", wantRisk: "low", wantSeverity: types.SeverityLow},
+		{risk: "MODERATE", wantRisk: "medium", wantSeverity: types.SeverityMedium},
+		{risk: "elevated risk", wantRisk: "high", wantSeverity: types.SeverityHigh},
+		{risk: "High", wantRisk: "high", wantSeverity: types.SeverityHigh},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.risk, func(t *testing.T) {
+			// Act
+			finding, err := analyzeWithResidualRisk(t, tt.risk, true)
+
+			// Assert
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantRisk, finding.ResidualRisk)
+			assert.Equal(t, tt.wantSeverity, finding.Severity)
+		})
+	}
+}
+
+func TestAnalyze_UnknownResidualRiskStrictModeFails(t *testing.T) {
+	// Act
+	_, err := analyzeWithResidualRisk(t, "MFA is not enforced for contractors", true)
+
+	// Assert
+	assert.ErrorIs(t, err, ai.ErrUnknownResidualRisk)
+	assert.Contains(t, err.Error(), "MFA is not enforced for contractors")
+}
+
+func TestAnalyze_UnknownResidualRiskDefaultsToMedium(t *testing.T) {
+	// Act
+	finding, err := analyzeWithResidualRisk(t, "MFA is not enforced for contractors", false)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, "MFA is not enforced for contractors", finding.ResidualRisk)
+	assert.Equal(t, types.SeverityMedium, finding.Severity)
+}
@@ -0,0 +1,109 @@
+package unit
+
+import (
+	"context"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/pickjonathan/sdek-cli/internal/ai"
+	"github.com/pickjonathan/sdek-cli/internal/ai/providers"
+	"github.com/pickjonathan/sdek-cli/pkg/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const openAIFunctionCallResponse = `{"id":"chatcmpl-2","object":"chat.completion","created":1,"model":"gpt-4o","choices":[{"index":0,"message":{"role":"assistant","content":"","function_call":{"name":"analyze_compliance_evidence","arguments":"{\"title\":\"Access reviews performed\",\"summary\":\"Quarterly access reviews were completed.\",\"justification\":\"Evidence shows access reviews.\",\"confidence_score\":0.9,\"mapped_controls\":[\"CC6.1\"],\"citations\":[\"evt-1\"],\"severity\":\"low\"}"}},"finish_reason":"function_call"}],"usage":{"prompt_tokens":1,"completion_tokens":1,"total_tokens":2}}`
+
+func toolSchemaPreamble() (types.ContextPreamble, types.EvidenceBundle) {
+	preamble := types.ContextPreamble{
+		Framework: "SOC2",
+		Section:   "CC6.1",
+		Excerpt:   "The entity implements logical access security software, infrastructure, and architectures.",
+	}
+	evidence := types.EvidenceBundle{Events: []types.EvidenceEvent{
+		{ID: "evt-1", Source: "github", Type: "commit", Timestamp: time.Now(), Content: "Quarterly access review completed"},
+	}}
+	return preamble, evidence
+}
+
+// schemaFields returns the required fields and sorted property names of a JSON schema from a request body
+func schemaFields(t *testing.T, schema map[string]interface{}) (required, properties []string) {
+	t.Helper()
+	rawRequired, ok := schema["required"].([]interface{})
+	require.True(t, ok, "schema should list required fields")
+	for _, field := range rawRequired {
+		required = append(required, field.(string))
+	}
+	rawProperties, ok := schema["properties"].(map[string]interface{})
+	require.True(t, ok, "schema should have properties")
+	for name := range rawProperties {
+		properties = append(properties, name)
+	}
+	sort.Strings(properties)
+	return required, properties
+}
+
+func sharedSchemaFields() (name string, required, properties []string) {
+	schema := ai.AnalysisToolSchema()
+	for property := range schema.Properties {
+		properties = append(properties, property)
+	}
+	sort.Strings(properties)
+	return schema.Name, schema.Required, properties
+}
+
+func TestOpenAI_AnalyzeUsesSharedToolSchema(t *testing.T) {
+	// Arrange
+	server := newRecordingServer(t, openAIFunctionCallResponse)
+	provider, err := providers.NewOpenAIEngine(types.ProviderConfig{APIKey: "test-key", Model: "gpt-4o", Endpoint: server.URL, Timeout: 5})
+	require.NoError(t, err)
+	preamble, evidence := toolSchemaPreamble()
+
+	// Act
+	_, err = provider.Analyze(context.Background(), preamble, evidence)
+
+	// Assert
+	require.NoError(t, err)
+	functions, ok := server.lastBody(t)["functions"].([]interface{})
+	require.True(t, ok, "request should contain functions")
+	require.Len(t, functions, 1)
+	function := functions[0].(map[string]interface{})
+
+	name, wantRequired, wantProperties := sharedSchemaFields()
+	assert.Equal(t, name, function["name"])
+	required, properties := schemaFields(t, function["parameters"].(map[string]interface{}))
+	assert.Equal(t, wantRequired, required)
+	assert.Equal(t, wantProperties, properties)
+}
+
+func TestAnthropic_AnalyzeUsesSharedToolSchema(t *testing.T) {
+	// Arrange
+	server := newRecordingServer(t, anthropicToolUseResponse)
+	provider := newCachingAnthropicEngine(t, server.URL, "")
+	preamble, evidence := toolSchemaPreamble()
+
+	// Act
+	_, err := provider.Analyze(context.Background(), preamble, evidence)
+
+	// Assert
+	require.NoError(t, err)
+	tools, ok := server.lastBody(t)["tools"].([]interface{})
+	require.True(t, ok, "request should contain tools")
+	require.Len(t, tools, 1)
+	tool := tools[0].(map[string]interface{})
+
+	name, wantRequired, wantProperties := sharedSchemaFields()
+	assert.Equal(t, name, tool["name"])
+	required, properties := schemaFields(t, tool["input_schema"].(map[string]interface{}))
+	assert.Equal(t, wantRequired, required)
+	assert.Equal(t, wantProperties, properties)
+}
+
+func TestToolSchemas_RequiredFieldsAreProperties(t *testing.T) {
+	for _, schema := range []ai.ToolSchema{ai.AnalysisToolSchema(), ai.EvidenceToolSchema()} {
+		for _, field := range schema.Required {
+			assert.Contains(t, schema.Properties, field, "%s: required field %q is not a property", schema.Name, field)
+		}
+	}
+}
@@ -0,0 +1,99 @@
+package unit
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/pickjonathan/sdek-cli/internal/ai"
+	"github.com/pickjonathan/sdek-cli/pkg/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+// recordSpans installs a tracer provider that keeps finished spans in memory
+func recordSpans(t *testing.T) *tracetest.InMemoryExporter {
+	t.Helper()
+
+	exporter := tracetest.NewInMemoryExporter()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+	previous := otel.GetTracerProvider()
+	otel.SetTracerProvider(provider)
+	t.Cleanup(func() {
+		otel.SetTracerProvider(previous)
+		_ = provider.Shutdown(context.Background())
+	})
+	return exporter
+}
+
+func spanAttribute(span tracetest.SpanStub, key attribute.Key) attribute.Value {
+	for _, kv := range span.Attributes {
+		if kv.Key == key {
+			return kv.Value
+		}
+	}
+	return attribute.Value{}
+}
+
+func TestAnalyze_RecordsTraceSpans(t *testing.T) {
+	// Arrange
+	exporter := recordSpans(t)
+	cfg := &types.Config{
+		AI: types.AIConfig{
+			Enabled:  true,
+			Provider: "mock",
+			Model:    "mock-model",
+			Mode:     types.AIModeContext,
+			CacheDir: t.TempDir(),
+		},
+	}
+	engine := ai.NewEngine(cfg, ai.NewMockProvider())
+	preamble, err := types.NewContextPreamble("SOC2", "2017", "CC6.1", "Logical access security software, infrastructure and architectures are implemented", nil)
+	require.NoError(t, err)
+	evidence := types.EvidenceBundle{Events: []types.EvidenceEvent{
+		{ID: "evt-1", Source: "github", Type: "commit", Timestamp: time.Date(2025, 1, 15, 10, 0, 0, 0, time.UTC), Content: "Enable MFA on admin login"},
+	}}
+
+	// Act
+	_, err = engine.Analyze(context.Background(), *preamble, evidence)
+	require.NoError(t, err)
+
+	// Assert: the provider call is a child of the analyze span
+	spans := exporter.GetSpans()
+	require.Len(t, spans, 2)
+	call, analyze := spans[0], spans[1]
+	assert.Equal(t, ai.SpanProviderCall, call.Name)
+	assert.Equal(t, ai.SpanAnalyze, analyze.Name)
+	assert.Equal(t, analyze.SpanContext.SpanID(), call.Parent.SpanID())
+	assert.False(t, analyze.Parent.IsValid(), "analyze should be the root span")
+
+	assert.Equal(t, "mock", spanAttribute(analyze, "ai.provider").AsString())
+	assert.Equal(t, "mock-model", spanAttribute(analyze, "ai.model").AsString())
+	assert.Equal(t, "CC6.1", spanAttribute(analyze, "ai.control").AsString())
+	assert.Equal(t, int64(1), spanAttribute(analyze, "ai.events").AsInt64())
+	assert.Equal(t, ai.AuditOperationAnalyzeCall, spanAttribute(call, "ai.operation").AsString())
+	assert.Positive(t, spanAttribute(call, "ai.tokens.prompt").AsInt64())
+	assert.Positive(t, spanAttribute(call, "ai.tokens.response").AsInt64())
+}
+
+func TestAnalyze_CacheHitSkipsProviderSpan(t *testing.T) {
+	// Arrange
+	engine, _, preamble, evidence := newCacheModeEngine(t)
+	_, err := engine.Analyze(context.Background(), preamble, evidence)
+	require.NoError(t, err)
+	exporter := recordSpans(t)
+
+	// Act
+	_, err = engine.Analyze(context.Background(), preamble, evidence)
+	require.NoError(t, err)
+
+	// Assert
+	spans := exporter.GetSpans()
+	require.Len(t, spans, 1)
+	assert.Equal(t, ai.SpanAnalyze, spans[0].Name)
+	assert.True(t, spanAttribute(spans[0], "ai.cache_hit").AsBool())
+}
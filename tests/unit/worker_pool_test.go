@@ -0,0 +1,120 @@
+package unit
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/pickjonathan/sdek-cli/internal/ai"
+	"github.com/pickjonathan/sdek-cli/pkg/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// concurrencyTracker records the highest number of calls in flight at once
+type concurrencyTracker struct {
+	inFlight int32
+	peak     int32
+}
+
+func (c *concurrencyTracker) enter() {
+	n := atomic.AddInt32(&c.inFlight, 1)
+	for {
+		peak := atomic.LoadInt32(&c.peak)
+		if n <= peak || atomic.CompareAndSwapInt32(&c.peak, peak, n) {
+			return
+		}
+	}
+}
+
+func (c *concurrencyTracker) leave() {
+	atomic.AddInt32(&c.inFlight, -1)
+}
+
+func TestForEachLimit_RespectsLimit(t *testing.T) {
+	// Arrange
+	tracker := &concurrencyTracker{}
+	var mu sync.Mutex
+	seen := make(map[int]bool)
+
+	// Act
+	ai.ForEachLimit(20, types.ConcurrencyLimits{MaxAnalyses: 3}, func(i int) {
+		tracker.enter()
+		defer tracker.leave()
+		time.Sleep(5 * time.Millisecond)
+
+		mu.Lock()
+		seen[i] = true
+		mu.Unlock()
+	})
+
+	// Assert
+	assert.Len(t, seen, 20, "every index should be processed exactly once")
+	assert.LessOrEqual(t, int(tracker.peak), 3)
+	assert.Greater(t, int(tracker.peak), 1, "work should run concurrently")
+}
+
+func TestForEachLimit_UnsetLimitUsesDefault(t *testing.T) {
+	// Arrange
+	tracker := &concurrencyTracker{}
+	n := types.DefaultMaxAnalyses * 2
+
+	// Act
+	ai.ForEachLimit(n, types.ConcurrencyLimits{}, func(i int) {
+		tracker.enter()
+		defer tracker.leave()
+		time.Sleep(5 * time.Millisecond)
+	})
+
+	// Assert
+	assert.LessOrEqual(t, int(tracker.peak), types.DefaultMaxAnalyses)
+	assert.Equal(t, types.DefaultMaxAnalyses, types.ConcurrencyLimits{MaxAnalyses: -4}.Workers())
+}
+
+// trackingConnector is an MCPConnector that records how many Collect calls overlap
+type trackingConnector struct {
+	concurrencyTracker
+}
+
+func (c *trackingConnector) Collect(ctx context.Context, source, query string) ([]types.EvidenceEvent, error) {
+	c.enter()
+	defer c.leave()
+	time.Sleep(5 * time.Millisecond)
+	return []types.EvidenceEvent{{ID: source + "-" + query, Source: source, Timestamp: time.Now(), Content: query}}, nil
+}
+
+func TestExecutePlan_RespectsMaxAnalyses(t *testing.T) {
+	// Arrange
+	cfg := &types.Config{
+		AI: types.AIConfig{
+			Enabled:     true,
+			Provider:    "mock",
+			Mode:        types.AIModeAutonomous,
+			CacheDir:    t.TempDir(),
+			Concurrency: types.ConcurrencyLimits{MaxAnalyses: 2},
+		},
+	}
+	connector := &trackingConnector{}
+	engine := ai.NewEngineWithConnector(cfg, ai.NewMockProvider(), connector)
+
+	plan := &types.EvidencePlan{Status: types.PlanApproved}
+	for i := 0; i < 8; i++ {
+		plan.Items = append(plan.Items, types.PlanItem{
+			Source:         "github",
+			Query:          fmt.Sprintf("query-%d", i),
+			ApprovalStatus: types.ApprovalApproved,
+		})
+	}
+
+	// Act
+	bundle, err := engine.ExecutePlan(context.Background(), plan)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Len(t, bundle.Events, 8)
+	assert.Equal(t, "github-query-0", bundle.Events[0].ID, "events should keep plan order")
+	assert.LessOrEqual(t, int(connector.peak), 2)
+}
@@ -202,6 +202,13 @@ func (m PlanApprovalModel) renderPlanItem(b *strings.Builder, index int, item ty
 	// Show signal strength as indicator
 	signal := fmt.Sprintf("(%.0f%% relevant)", item.SignalStrength*100)
 	b.WriteString(estimateStyle.Render(signal))
+
+	// Warn that the AI gave no real reason for this item
+	if item.WeakRationale {
+		warningStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("214"))
+		b.WriteString(" ")
+		b.WriteString(warningStyle.Render("⚠ weak rationale"))
+	}
 	b.WriteString("\n")
 }
 